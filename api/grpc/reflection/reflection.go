@@ -0,0 +1,317 @@
+// Package reflection serves the grpc server reflection service so that tools
+// such as grpcurl and evans can list the services of the daemon, fetch their
+// descriptors and call them without a copy of api.proto.
+//
+// The vendored grpc predates its reflection package, the service is served
+// from the gzipped FileDescriptorProtos embedded in the generated code.
+package reflection
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var errTruncated = errors.New("reflection: truncated file descriptor")
+
+// Register serves reflection on s for the files, each file is the gzipped
+// FileDescriptorProto returned by the Descriptor method of a generated message
+func Register(s *grpc.Server, files ...[]byte) error {
+	r := &server{
+		files:   make(map[string]*file),
+		symbols: make(map[string]*file),
+	}
+	for _, gz := range files {
+		f, err := decodeFile(gz)
+		if err != nil {
+			return err
+		}
+		r.files[f.name] = f
+		for _, sym := range f.symbols {
+			r.symbols[sym] = f
+		}
+		r.services = append(r.services, f.services...)
+	}
+	sort.Strings(r.services)
+	s.RegisterService(&serviceDesc, r)
+	return nil
+}
+
+type server struct {
+	services []string
+	// files are the files by name and symbols the files by the fully
+	// qualified names of the services, methods, messages and enums they
+	// declare
+	files   map[string]*file
+	symbols map[string]*file
+}
+
+func (s *server) ServerReflectionInfo(stream ServerReflection_ServerReflectionInfoServer) error {
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		resp := &ServerReflectionResponse{
+			ValidHost:       r.Host,
+			OriginalRequest: r,
+		}
+		switch {
+		case r.FileByFilename != nil:
+			s.respondFile(resp, s.files[*r.FileByFilename], *r.FileByFilename)
+		case r.FileContainingSymbol != nil:
+			s.respondFile(resp, s.symbols[*r.FileContainingSymbol], *r.FileContainingSymbol)
+		case r.FileContainingExtension != nil:
+			// the files are proto3 and declare no extensions
+			resp.ErrorResponse = errorResponse(codes.NotFound, "extension %s.%d not found", r.FileContainingExtension.ContainingType, r.FileContainingExtension.ExtensionNumber)
+		case r.AllExtensionNumbersOfType != nil:
+			if _, ok := s.symbols[*r.AllExtensionNumbersOfType]; !ok {
+				resp.ErrorResponse = errorResponse(codes.NotFound, "type %s not found", *r.AllExtensionNumbersOfType)
+				break
+			}
+			resp.AllExtensionNumbersResponse = &ExtensionNumberResponse{
+				BaseTypeName: *r.AllExtensionNumbersOfType,
+			}
+		case r.ListServices != nil:
+			l := &ListServiceResponse{}
+			for _, name := range s.services {
+				l.Service = append(l.Service, &ServiceResponse{Name: name})
+			}
+			resp.ListServicesResponse = l
+		default:
+			resp.ErrorResponse = errorResponse(codes.InvalidArgument, "invalid reflection request")
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// respondFile sets the response to the file f and the files it imports, f is
+// nil when name is not found
+func (s *server) respondFile(resp *ServerReflectionResponse, f *file, name string) {
+	if f == nil {
+		resp.ErrorResponse = errorResponse(codes.NotFound, "%s not found", name)
+		return
+	}
+	var (
+		descriptors [][]byte
+		seen        = make(map[string]bool)
+		add         func(f *file)
+	)
+	add = func(f *file) {
+		if seen[f.name] {
+			return
+		}
+		seen[f.name] = true
+		descriptors = append(descriptors, f.descriptor)
+		for _, dep := range f.dependencies {
+			if d, ok := s.files[dep]; ok {
+				add(d)
+			}
+		}
+	}
+	add(f)
+	resp.FileDescriptorResponse = &FileDescriptorResponse{
+		FileDescriptorProto: descriptors,
+	}
+}
+
+func errorResponse(code codes.Code, format string, args ...interface{}) *ErrorResponse {
+	return &ErrorResponse{
+		ErrorCode:    int32(code),
+		ErrorMessage: fmt.Sprintf(format, args...),
+	}
+}
+
+// file is a FileDescriptorProto with the names it declares
+type file struct {
+	name         string
+	descriptor   []byte
+	dependencies []string
+	services     []string
+	symbols      []string
+}
+
+// The field numbers of descriptor.proto read by decodeFile
+const (
+	fileName        = 1
+	filePackage     = 2
+	fileDependency  = 3
+	fileMessageType = 4
+	fileEnumType    = 5
+	fileService     = 6
+
+	messageName       = 1
+	messageNestedType = 3
+	messageEnumType   = 4
+
+	serviceMethod = 2
+)
+
+// decodeFile decodes the names declared by the gzipped FileDescriptorProto
+func decodeFile(gz []byte) (*file, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	f := &file{descriptor: descriptor}
+	fields, err := decodeFields(descriptor)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		pkg                       string
+		messages, enums, services [][]byte
+	)
+	for _, fd := range fields {
+		switch fd.number {
+		case fileName:
+			f.name = string(fd.data)
+		case filePackage:
+			pkg = string(fd.data)
+		case fileDependency:
+			f.dependencies = append(f.dependencies, string(fd.data))
+		case fileMessageType:
+			messages = append(messages, fd.data)
+		case fileEnumType:
+			enums = append(enums, fd.data)
+		case fileService:
+			services = append(services, fd.data)
+		}
+	}
+	if pkg != "" {
+		pkg += "."
+	}
+	for _, m := range messages {
+		if err := f.addMessage(pkg, m); err != nil {
+			return nil, err
+		}
+	}
+	for _, e := range enums {
+		name, _, err := nameOf(e)
+		if err != nil {
+			return nil, err
+		}
+		f.symbols = append(f.symbols, pkg+name)
+	}
+	for _, sd := range services {
+		name, fields, err := nameOf(sd)
+		if err != nil {
+			return nil, err
+		}
+		f.services = append(f.services, pkg+name)
+		f.symbols = append(f.symbols, pkg+name)
+		for _, fd := range fields {
+			if fd.number != serviceMethod {
+				continue
+			}
+			method, _, err := nameOf(fd.data)
+			if err != nil {
+				return nil, err
+			}
+			f.symbols = append(f.symbols, pkg+name+"."+method)
+		}
+	}
+	return f, nil
+}
+
+// addMessage adds the names of the DescriptorProto and of its nested messages
+// and enums
+func (f *file) addMessage(prefix string, m []byte) error {
+	name, fields, err := nameOf(m)
+	if err != nil {
+		return err
+	}
+	name = prefix + name
+	f.symbols = append(f.symbols, name)
+	for _, fd := range fields {
+		switch fd.number {
+		case messageNestedType:
+			if err := f.addMessage(name+".", fd.data); err != nil {
+				return err
+			}
+		case messageEnumType:
+			enum, _, err := nameOf(fd.data)
+			if err != nil {
+				return err
+			}
+			f.symbols = append(f.symbols, name+"."+enum)
+		}
+	}
+	return nil
+}
+
+// nameOf returns the name of a descriptor, the name is field 1 of every
+// descriptor declaring one
+func nameOf(b []byte) (string, []field, error) {
+	fields, err := decodeFields(b)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, fd := range fields {
+		if fd.number == messageName {
+			return string(fd.data), fields, nil
+		}
+	}
+	return "", fields, nil
+}
+
+// field is a length delimited field of a message
+type field struct {
+	number uint64
+	data   []byte
+}
+
+// decodeFields returns the length delimited fields of the message, the other
+// fields are skipped
+func decodeFields(b []byte) ([]field, error) {
+	var fields []field
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errTruncated
+		}
+		b = b[n:]
+		switch key & 7 {
+		case proto.WireVarint:
+			if _, n = binary.Uvarint(b); n <= 0 {
+				return nil, errTruncated
+			}
+		case proto.WireFixed64:
+			n = 8
+		case proto.WireFixed32:
+			n = 4
+		case proto.WireBytes:
+			l, m := binary.Uvarint(b)
+			if m <= 0 || uint64(len(b)-m) < l {
+				return nil, errTruncated
+			}
+			fields = append(fields, field{number: key >> 3, data: b[m : m+int(l)]})
+			n = m + int(l)
+		default:
+			return nil, fmt.Errorf("reflection: unexpected wire type %d", key&7)
+		}
+		if n > len(b) {
+			return nil, errTruncated
+		}
+		b = b[n:]
+	}
+	return fields, nil
+}
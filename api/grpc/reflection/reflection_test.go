@@ -0,0 +1,97 @@
+package reflection
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func newTestStream(t *testing.T) (ServerReflection_ServerReflectionInfoClient, func()) {
+	dir, err := ioutil.TempDir("", "containerd-reflection")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "containerd.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	gz, _ := (&types.CreateContainerRequest{}).Descriptor()
+	if err := Register(s, gz); err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(l)
+	conn, err := grpc.Dial(path, grpc.WithInsecure(), grpc.WithTimeout(time.Second),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream, err := NewServerReflectionInfoClient(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream, func() {
+		conn.Close()
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func call(t *testing.T, stream ServerReflection_ServerReflectionInfoClient, r *ServerReflectionRequest) *ServerReflectionResponse {
+	if err := stream.Send(r); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestListServices(t *testing.T) {
+	stream, cleanup := newTestStream(t)
+	defer cleanup()
+	resp := call(t, stream, &ServerReflectionRequest{ListServices: proto.String("")})
+	l := resp.ListServicesResponse
+	if l == nil || len(l.Service) != 1 || l.Service[0].Name != "types.API" {
+		t.Fatalf("expected the service types.API but received %v", resp)
+	}
+}
+
+func TestFileContainingSymbol(t *testing.T) {
+	stream, cleanup := newTestStream(t)
+	defer cleanup()
+	for _, sym := range []string{"types.API", "types.API.CreateContainer", "types.CreateContainerRequest", "types.ErrorCode"} {
+		resp := call(t, stream, &ServerReflectionRequest{FileContainingSymbol: proto.String(sym)})
+		fd := resp.FileDescriptorResponse
+		if fd == nil || len(fd.FileDescriptorProto) != 1 {
+			t.Fatalf("%s: expected the descriptor of api.proto but received %v", sym, resp)
+		}
+		name, _, err := nameOf(fd.FileDescriptorProto[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != "api.proto" {
+			t.Fatalf("%s: expected the file api.proto but received %s", sym, name)
+		}
+	}
+	if resp := call(t, stream, &ServerReflectionRequest{FileByFilename: proto.String("api.proto")}); resp.FileDescriptorResponse == nil {
+		t.Fatalf("expected the descriptor of api.proto but received %v", resp)
+	}
+	resp := call(t, stream, &ServerReflectionRequest{FileContainingSymbol: proto.String("types.Missing")})
+	if resp.ErrorResponse == nil || codes.Code(resp.ErrorResponse.ErrorCode) != codes.NotFound {
+		t.Fatalf("expected a NotFound error but received %v", resp)
+	}
+}
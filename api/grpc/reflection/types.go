@@ -0,0 +1,166 @@
+package reflection
+
+import (
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// The messages of grpc/reflection/v1alpha/reflection.proto.  The oneof fields
+// are pointers so that a set field is told from an empty one, they are encoded
+// the same on the wire.
+
+type ServerReflectionRequest struct {
+	Host                      string            `protobuf:"bytes,1,opt,name=host" json:"host,omitempty"`
+	FileByFilename            *string           `protobuf:"bytes,3,opt,name=file_by_filename" json:"file_by_filename,omitempty"`
+	FileContainingSymbol      *string           `protobuf:"bytes,4,opt,name=file_containing_symbol" json:"file_containing_symbol,omitempty"`
+	FileContainingExtension   *ExtensionRequest `protobuf:"bytes,5,opt,name=file_containing_extension" json:"file_containing_extension,omitempty"`
+	AllExtensionNumbersOfType *string           `protobuf:"bytes,6,opt,name=all_extension_numbers_of_type" json:"all_extension_numbers_of_type,omitempty"`
+	ListServices              *string           `protobuf:"bytes,7,opt,name=list_services" json:"list_services,omitempty"`
+}
+
+func (m *ServerReflectionRequest) Reset()         { *m = ServerReflectionRequest{} }
+func (m *ServerReflectionRequest) String() string { return proto.CompactTextString(m) }
+func (*ServerReflectionRequest) ProtoMessage()    {}
+
+type ExtensionRequest struct {
+	ContainingType  string `protobuf:"bytes,1,opt,name=containing_type" json:"containing_type,omitempty"`
+	ExtensionNumber int32  `protobuf:"varint,2,opt,name=extension_number" json:"extension_number,omitempty"`
+}
+
+func (m *ExtensionRequest) Reset()         { *m = ExtensionRequest{} }
+func (m *ExtensionRequest) String() string { return proto.CompactTextString(m) }
+func (*ExtensionRequest) ProtoMessage()    {}
+
+type ServerReflectionResponse struct {
+	ValidHost                   string                   `protobuf:"bytes,1,opt,name=valid_host" json:"valid_host,omitempty"`
+	OriginalRequest             *ServerReflectionRequest `protobuf:"bytes,2,opt,name=original_request" json:"original_request,omitempty"`
+	FileDescriptorResponse      *FileDescriptorResponse  `protobuf:"bytes,4,opt,name=file_descriptor_response" json:"file_descriptor_response,omitempty"`
+	AllExtensionNumbersResponse *ExtensionNumberResponse `protobuf:"bytes,5,opt,name=all_extension_numbers_response" json:"all_extension_numbers_response,omitempty"`
+	ListServicesResponse        *ListServiceResponse     `protobuf:"bytes,6,opt,name=list_services_response" json:"list_services_response,omitempty"`
+	ErrorResponse               *ErrorResponse           `protobuf:"bytes,7,opt,name=error_response" json:"error_response,omitempty"`
+}
+
+func (m *ServerReflectionResponse) Reset()         { *m = ServerReflectionResponse{} }
+func (m *ServerReflectionResponse) String() string { return proto.CompactTextString(m) }
+func (*ServerReflectionResponse) ProtoMessage()    {}
+
+type FileDescriptorResponse struct {
+	// FileDescriptorProto are the serialized FileDescriptorProtos
+	FileDescriptorProto [][]byte `protobuf:"bytes,1,rep,name=file_descriptor_proto,proto3" json:"file_descriptor_proto,omitempty"`
+}
+
+func (m *FileDescriptorResponse) Reset()         { *m = FileDescriptorResponse{} }
+func (m *FileDescriptorResponse) String() string { return proto.CompactTextString(m) }
+func (*FileDescriptorResponse) ProtoMessage()    {}
+
+type ExtensionNumberResponse struct {
+	BaseTypeName    string  `protobuf:"bytes,1,opt,name=base_type_name" json:"base_type_name,omitempty"`
+	ExtensionNumber []int32 `protobuf:"varint,2,rep,packed,name=extension_number" json:"extension_number,omitempty"`
+}
+
+func (m *ExtensionNumberResponse) Reset()         { *m = ExtensionNumberResponse{} }
+func (m *ExtensionNumberResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtensionNumberResponse) ProtoMessage()    {}
+
+type ListServiceResponse struct {
+	Service []*ServiceResponse `protobuf:"bytes,1,rep,name=service" json:"service,omitempty"`
+}
+
+func (m *ListServiceResponse) Reset()         { *m = ListServiceResponse{} }
+func (m *ListServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ListServiceResponse) ProtoMessage()    {}
+
+type ServiceResponse struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ServiceResponse) Reset()         { *m = ServiceResponse{} }
+func (m *ServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ServiceResponse) ProtoMessage()    {}
+
+type ErrorResponse struct {
+	ErrorCode    int32  `protobuf:"varint,1,opt,name=error_code" json:"error_code,omitempty"`
+	ErrorMessage string `protobuf:"bytes,2,opt,name=error_message" json:"error_message,omitempty"`
+}
+
+func (m *ErrorResponse) Reset()         { *m = ErrorResponse{} }
+func (m *ErrorResponse) String() string { return proto.CompactTextString(m) }
+func (*ErrorResponse) ProtoMessage()    {}
+
+// ServerReflectionServer is the server of the ServerReflection service
+type ServerReflectionServer interface {
+	ServerReflectionInfo(ServerReflection_ServerReflectionInfoServer) error
+}
+
+type ServerReflection_ServerReflectionInfoServer interface {
+	Send(*ServerReflectionResponse) error
+	Recv() (*ServerReflectionRequest, error)
+	grpc.ServerStream
+}
+
+type serverReflectionInfoServer struct {
+	grpc.ServerStream
+}
+
+func (x *serverReflectionInfoServer) Send(m *ServerReflectionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *serverReflectionInfoServer) Recv() (*ServerReflectionRequest, error) {
+	m := new(ServerReflectionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func serverReflectionInfoHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServerReflectionServer).ServerReflectionInfo(&serverReflectionInfoServer{stream})
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "grpc.reflection.v1alpha.ServerReflection",
+	HandlerType: (*ServerReflectionServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerReflectionInfo",
+			Handler:       serverReflectionInfoHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+// NewServerReflectionInfoClient opens the ServerReflectionInfo stream on the
+// connection
+func NewServerReflectionInfoClient(ctx context.Context, cc *grpc.ClientConn) (ServerReflection_ServerReflectionInfoClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &serviceDesc.Streams[0], cc, "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo")
+	if err != nil {
+		return nil, err
+	}
+	return &serverReflectionInfoClient{stream}, nil
+}
+
+type ServerReflection_ServerReflectionInfoClient interface {
+	Send(*ServerReflectionRequest) error
+	Recv() (*ServerReflectionResponse, error)
+	grpc.ClientStream
+}
+
+type serverReflectionInfoClient struct {
+	grpc.ClientStream
+}
+
+func (x *serverReflectionInfoClient) Send(m *ServerReflectionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *serverReflectionInfoClient) Recv() (*ServerReflectionResponse, error) {
+	m := new(ServerReflectionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logging"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+var auditLog = logging.Logger("audit")
+
+// EnableAudit logs every rpc with the credentials of its peer and its error
+// to the audit subsystem, it must be called before the server is registered
+func EnableAudit() {
+	AddUnaryInterceptor(func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		audit(ctx, info.FullMethod, err)
+		return resp, err
+	})
+	AddStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *StreamServerInfo, handler StreamHandler) error {
+		err := handler(srv, stream)
+		audit(stream.Context(), info.FullMethod, err)
+		return err
+	})
+}
+
+func audit(ctx context.Context, method string, err error) {
+	fields := logrus.Fields{
+		"method": method,
+	}
+	if p, ok := PeerCredentialsFromContext(ctx); ok {
+		fields["pid"] = p.Pid
+		fields["uid"] = p.Uid
+		fields["gid"] = p.Gid
+	}
+	if err != nil {
+		fields["error"] = err
+	}
+	auditLog.WithFields(fields).Info("rpc")
+}
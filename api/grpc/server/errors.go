@@ -0,0 +1,197 @@
+package server
+
+import (
+	"errors"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/archive"
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/supervisor"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// ErrorCodeKey is the trailer the ErrorCode of a failed rpc is sent in
+const ErrorCodeKey = "containerd-error-code"
+
+var (
+	errLogsNotSupported     = errors.New("containerd: reading logs is only supported by the json-file log driver")
+	errEmptyBundlePath      = errors.New("empty bundle path")
+	errEmptyID              = errors.New("container id cannot be empty")
+	errEmptyPID             = errors.New("process id cannot be empty")
+	errEmptyGroupID         = errors.New("empty group id")
+	errEmptyNetnsName       = errors.New("empty network namespace name")
+	errEmptyCheckpointName  = errors.New("checkpoint name cannot be empty")
+	errNoContainersSelected = errors.New("no containers selected")
+	errNoSuchContainers     = errors.New("no such containers")
+	errInvalidPageToken     = errors.New("containerd: invalid page token")
+	errInvalidNamespace     = errors.New("containerd: namespaces are lowercase letters, digits, dots, dashes and underscores starting and ending with a letter or a digit")
+)
+
+// errorCodes are the codes of the errors returned by the handlers, the
+// supervisor and the runtime
+var errorCodes = map[error]types.ErrorCode{
+	supervisor.ErrBundleNotFound:           types.ErrorCode_NOT_FOUND,
+	supervisor.ErrContainerNotFound:        types.ErrorCode_NOT_FOUND,
+	supervisor.ErrProcessNotFound:          types.ErrorCode_NOT_FOUND,
+	supervisor.ErrGroupNotFound:            types.ErrorCode_NOT_FOUND,
+	supervisor.ErrTemplateNotFound:         types.ErrorCode_NOT_FOUND,
+	supervisor.ErrLeaseNotFound:            types.ErrorCode_NOT_FOUND,
+	supervisor.ErrPhysicalFunctionNotFound: types.ErrorCode_NOT_FOUND,
+	supervisor.ErrNetworkNamespaceNotFound: types.ErrorCode_NOT_FOUND,
+	ipam.ErrUnknownNetwork:                 types.ErrorCode_NOT_FOUND,
+	runtime.ErrCheckpointNotExists:         types.ErrorCode_NOT_FOUND,
+	runtime.ErrProcessNotFound:             types.ErrorCode_NOT_FOUND,
+	runtime.ErrAddressLabelNotFound:        types.ErrorCode_NOT_FOUND,
+	runtime.ErrGPUNotFound:                 types.ErrorCode_NOT_FOUND,
+	errNoSuchContainers:                    types.ErrorCode_NOT_FOUND,
+	supervisor.ErrContainerExists:          types.ErrorCode_CONFLICT,
+	supervisor.ErrGroupExists:              types.ErrorCode_CONFLICT,
+	supervisor.ErrGroupDeleting:            types.ErrorCode_CONFLICT,
+	supervisor.ErrGroupStarting:            types.ErrorCode_CONFLICT,
+	supervisor.ErrGroupShared:              types.ErrorCode_CONFLICT,
+	supervisor.ErrContainerNotStopped:      types.ErrorCode_CONFLICT,
+	supervisor.ErrContainerRestarting:      types.ErrorCode_CONFLICT,
+	supervisor.ErrTemplateExists:           types.ErrorCode_CONFLICT,
+	supervisor.ErrLeaseExists:              types.ErrorCode_CONFLICT,
+	supervisor.ErrNoFreeVirtualFunction:    types.ErrorCode_CONFLICT,
+	supervisor.ErrNetworkNamespaceExists:   types.ErrorCode_CONFLICT,
+	supervisor.ErrNetworkNamespaceInUse:    types.ErrorCode_CONFLICT,
+	ipam.ErrNoFreeAddress:                  types.ErrorCode_CONFLICT,
+	runtime.ErrCheckpointExists:            types.ErrorCode_CONFLICT,
+	runtime.ErrContainerExited:             types.ErrorCode_CONFLICT,
+	runtime.ErrProcessExited:               types.ErrorCode_CONFLICT,
+	runtime.ErrProcessNotExited:            types.ErrorCode_CONFLICT,
+	runtime.ErrStdioSocketClosed:           types.ErrorCode_CONFLICT,
+	runtime.ErrSandboxNotRunning:           types.ErrorCode_CONFLICT,
+	runtime.ErrNetworkNamespaceGone:        types.ErrorCode_CONFLICT,
+	supervisor.ErrCPUSetNotSupported:       types.ErrorCode_UNSUPPORTED,
+	supervisor.ErrCRIUNotFound:             types.ErrorCode_UNSUPPORTED,
+	supervisor.ErrBundleUploadDisabled:     types.ErrorCode_UNSUPPORTED,
+	runtime.ErrTerminalsNotSupported:       types.ErrorCode_UNSUPPORTED,
+	runtime.ErrRealtimeNotSupported:        types.ErrorCode_UNSUPPORTED,
+	runtime.ErrSwapNotSupported:            types.ErrorCode_UNSUPPORTED,
+	runtime.ErrCgroupNSNotSupported:        types.ErrorCode_UNSUPPORTED,
+	runtime.ErrGPUsNotSupported:            types.ErrorCode_UNSUPPORTED,
+	runtime.ErrNetworkWaitNotSupported:     types.ErrorCode_UNSUPPORTED,
+	runtime.ErrInitNotSupported:            types.ErrorCode_UNSUPPORTED,
+	runtime.ErrNetworkNotSupported:         types.ErrorCode_UNSUPPORTED,
+	runtime.ErrNoVirtualFunctions:          types.ErrorCode_UNSUPPORTED,
+	runtime.ErrRoutingNotEnabled:           types.ErrorCode_UNSUPPORTED,
+	runtime.ErrNamespaceNotShareable:       types.ErrorCode_UNSUPPORTED,
+	errLogsNotSupported:                    types.ErrorCode_UNSUPPORTED,
+	supervisor.ErrInvalidLogMode:           types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrLogPathNotAbs:            types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidOverflowPolicy:    types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrBundleConfigNotFound:     types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInitProcess:              types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrAutoRemoveKept:           types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidTemplateName:      types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidContainerID:       types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrBackupVersion:            types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidLeaseID:           types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidLeaseTTL:          types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrQuotaLimitRequired:       types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrInvalidNetnsName:         types.ErrorCode_INVALID_ARGUMENT,
+	supervisor.ErrGroupSharesNetwork:       types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrInvalidRealtime:             types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrRealtimeBudgetExceeded:      types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrNotDevice:                   types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrDevicePathNotAbs:            types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrInvalidNUMANodes:            types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrInvalidMemoryPolicy:         types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrInvalidSwappiness:           types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrNotBlockDevice:              types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrNoProcessArgs:               types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrMountPathNotAbs:             types.ErrorCode_INVALID_ARGUMENT,
+	runtime.ErrInvalidEnv:                  types.ErrorCode_INVALID_ARGUMENT,
+	archive.ErrPathEscapes:                 types.ErrorCode_INVALID_ARGUMENT,
+	archive.ErrNotDirectory:                types.ErrorCode_INVALID_ARGUMENT,
+	logger.ErrUnknownDriver:                types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyBundlePath:                     types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyID:                             types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyPID:                            types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyGroupID:                        types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyNetnsName:                      types.ErrorCode_INVALID_ARGUMENT,
+	errEmptyCheckpointName:                 types.ErrorCode_INVALID_ARGUMENT,
+	errNoContainersSelected:                types.ErrorCode_INVALID_ARGUMENT,
+	errInvalidNamespace:                    types.ErrorCode_INVALID_ARGUMENT,
+	errInvalidPageToken:                    types.ErrorCode_INVALID_ARGUMENT,
+	context.DeadlineExceeded:               types.ErrorCode_TIMEOUT,
+	context.Canceled:                       types.ErrorCode_TIMEOUT,
+	runtime.ErrAttachTooSlow:               types.ErrorCode_TIMEOUT,
+}
+
+// grpcCodes are the grpc codes the errors are returned with
+var grpcCodes = map[types.ErrorCode]codes.Code{
+	types.ErrorCode_UNKNOWN:          codes.Unknown,
+	types.ErrorCode_NOT_FOUND:        codes.NotFound,
+	types.ErrorCode_CONFLICT:         codes.AlreadyExists,
+	types.ErrorCode_RUNTIME_FAILED:   codes.Unknown,
+	types.ErrorCode_UNSUPPORTED:      codes.Unimplemented,
+	types.ErrorCode_TIMEOUT:          codes.DeadlineExceeded,
+	types.ErrorCode_INVALID_ARGUMENT: codes.InvalidArgument,
+	types.ErrorCode_QUOTA_EXCEEDED:   codes.ResourceExhausted,
+}
+
+// errorCode returns the code of an rpc's error.  Errors that already have a
+// grpc code are classified by it and the other errors are failures of the
+// runtime.
+func errorCode(err error) types.ErrorCode {
+	if e, ok := err.(*supervisor.CreateError); ok {
+		// the step that failed is only reported in the message
+		err = e.Err
+	}
+	switch err.(type) {
+	case *supervisor.QuotaError:
+		return types.ErrorCode_QUOTA_EXCEEDED
+	case *runtime.InterfaceError:
+		return types.ErrorCode_INVALID_ARGUMENT
+	}
+	if c, ok := errorCodes[err]; ok {
+		return c
+	}
+	switch grpc.Code(err) {
+	case codes.NotFound:
+		return types.ErrorCode_NOT_FOUND
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return types.ErrorCode_CONFLICT
+	case codes.Unimplemented:
+		return types.ErrorCode_UNSUPPORTED
+	case codes.DeadlineExceeded, codes.Canceled:
+		return types.ErrorCode_TIMEOUT
+	case codes.InvalidArgument, codes.OutOfRange:
+		return types.ErrorCode_INVALID_ARGUMENT
+	case codes.ResourceExhausted:
+		return types.ErrorCode_QUOTA_EXCEEDED
+	case codes.Unknown, codes.Internal:
+		return types.ErrorCode_RUNTIME_FAILED
+	}
+	return types.ErrorCode_UNKNOWN
+}
+
+// rpcError returns the error of an rpc with the grpc code of its ErrorCode,
+// the ErrorCode is set as the trailer of the rpc by setTrailer
+func rpcError(err error, setTrailer func(metadata.MD)) error {
+	if err == nil {
+		return nil
+	}
+	code := errorCode(err)
+	setTrailer(metadata.Pairs(ErrorCodeKey, code.String()))
+	if grpc.Code(err) != codes.Unknown || grpc.ErrorDesc(err) != err.Error() {
+		// the error already has a code
+		return err
+	}
+	return grpc.Errorf(grpcCodes[code], "%s", err.Error())
+}
+
+// unaryTrailer sets the trailer of the unary rpc of ctx
+func unaryTrailer(ctx context.Context) func(metadata.MD) {
+	return func(md metadata.MD) {
+		grpc.SetTrailer(ctx, md)
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// The vendored grpc has no interceptors, these follow the ones of later grpc
+// releases so that interceptors written for them can be added with little
+// change.
+
+// UnaryServerInfo describes the unary rpc being intercepted
+type UnaryServerInfo struct {
+	// Server is the APIServer handling the rpc
+	Server interface{}
+	// FullMethod is the rpc's name as /types.API/<rpc>
+	FullMethod string
+}
+
+// UnaryHandler calls the next interceptor or the rpc
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+// UnaryServerInterceptor intercepts an unary rpc, it calls handler to
+// continue the rpc
+type UnaryServerInterceptor func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error)
+
+// StreamServerInfo describes the streaming rpc being intercepted
+type StreamServerInfo struct {
+	// FullMethod is the rpc's name as /types.API/<rpc>
+	FullMethod     string
+	IsClientStream bool
+	IsServerStream bool
+}
+
+// StreamHandler calls the next interceptor or the rpc
+type StreamHandler func(srv interface{}, stream grpc.ServerStream) error
+
+// StreamServerInterceptor intercepts a streaming rpc, it calls handler to
+// continue the rpc
+type StreamServerInterceptor func(srv interface{}, stream grpc.ServerStream, info *StreamServerInfo, handler StreamHandler) error
+
+var (
+	interceptorsMu     sync.Mutex
+	unaryInterceptors  []UnaryServerInterceptor
+	streamInterceptors []StreamServerInterceptor
+)
+
+// AddUnaryInterceptor adds an interceptor to the unary rpcs of the servers
+// registered afterwards, the interceptors are called in the order they were
+// added.  Packages built into the daemon add their interceptors from init.
+func AddUnaryInterceptor(i UnaryServerInterceptor) {
+	interceptorsMu.Lock()
+	unaryInterceptors = append(unaryInterceptors, i)
+	interceptorsMu.Unlock()
+}
+
+// AddStreamInterceptor adds an interceptor to the streaming rpcs of the
+// servers registered afterwards, the interceptors are called in the order
+// they were added
+func AddStreamInterceptor(i StreamServerInterceptor) {
+	interceptorsMu.Lock()
+	streamInterceptors = append(streamInterceptors, i)
+	interceptorsMu.Unlock()
+}
+
+// Register registers srv on s, the rpcs go through the added interceptors
+func Register(s *grpc.Server, srv types.APIServer) {
+	interceptorsMu.Lock()
+	unary := append([]UnaryServerInterceptor(nil), unaryInterceptors...)
+	stream := append([]StreamServerInterceptor(nil), streamInterceptors...)
+	interceptorsMu.Unlock()
+	if len(unary) == 0 && len(stream) == 0 {
+		types.RegisterAPIServer(s, srv)
+		return
+	}
+	desc := interceptedDesc(types.APIServiceDesc(), unary, stream)
+	s.RegisterService(&desc, srv)
+}
+
+// interceptedDesc returns the service description with its handlers wrapped
+// by the interceptors
+func interceptedDesc(desc grpc.ServiceDesc, unary []UnaryServerInterceptor, stream []StreamServerInterceptor) grpc.ServiceDesc {
+	prefix := "/" + desc.ServiceName + "/"
+	methods := make([]grpc.MethodDesc, len(desc.Methods))
+	for i, md := range desc.Methods {
+		if len(unary) > 0 {
+			md.Handler = interceptUnary(md.Handler, prefix+md.MethodName, requestType(desc, md.MethodName), unary)
+		}
+		methods[i] = md
+	}
+	streams := make([]grpc.StreamDesc, len(desc.Streams))
+	for i, sd := range desc.Streams {
+		if len(stream) > 0 {
+			sd.Handler = interceptStream(sd.Handler, &StreamServerInfo{
+				FullMethod:     prefix + sd.StreamName,
+				IsClientStream: sd.ClientStreams,
+				IsServerStream: sd.ServerStreams,
+			}, stream)
+		}
+		streams[i] = sd
+	}
+	desc.Methods, desc.Streams = methods, streams
+	return desc
+}
+
+// requestType returns the type of the request of the unary rpc
+func requestType(desc grpc.ServiceDesc, name string) reflect.Type {
+	m, _ := reflect.TypeOf(desc.HandlerType).Elem().MethodByName(name)
+	// ctx, request
+	return m.Type.In(1).Elem()
+}
+
+// interceptUnary decodes the request before the interceptors are called, the
+// generated handler then receives the request passed to the last handler
+func interceptUnary(h func(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error), method string, reqType reflect.Type, interceptors []UnaryServerInterceptor) func(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+		req := reflect.New(reqType)
+		if err := dec(req.Interface()); err != nil {
+			return nil, err
+		}
+		info := &UnaryServerInfo{
+			Server:     srv,
+			FullMethod: method,
+		}
+		var next func(i int) UnaryHandler
+		next = func(i int) UnaryHandler {
+			if i == len(interceptors) {
+				return func(ctx context.Context, req interface{}) (interface{}, error) {
+					return h(srv, ctx, func(v interface{}) error {
+						reflect.ValueOf(v).Elem().Set(reflect.ValueOf(req).Elem())
+						return nil
+					})
+				}
+			}
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptors[i](ctx, req, info, next(i+1))
+			}
+		}
+		return next(0)(ctx, req.Interface())
+	}
+}
+
+// interceptStream wraps the handler of a grpc.StreamDesc, grpc does not export
+// the handler's type
+func interceptStream(h func(srv interface{}, stream grpc.ServerStream) error, info *StreamServerInfo, interceptors []StreamServerInterceptor) func(srv interface{}, stream grpc.ServerStream) error {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		var next func(i int) StreamHandler
+		next = func(i int) StreamHandler {
+			if i == len(interceptors) {
+				return StreamHandler(h)
+			}
+			return func(srv interface{}, stream grpc.ServerStream) error {
+				return interceptors[i](srv, stream, info, next(i+1))
+			}
+		}
+		return next(0)(srv, stream)
+	}
+}
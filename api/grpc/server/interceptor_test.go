@@ -0,0 +1,93 @@
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeServer answers Wait with the length of the container id and sends one
+// event, the rpcs it does not implement panic
+type fakeServer struct {
+	types.APIServer
+}
+
+func (s *fakeServer) Wait(ctx context.Context, r *types.WaitRequest) (*types.WaitResponse, error) {
+	return &types.WaitResponse{Status: uint32(len(r.Id))}, nil
+}
+
+func (s *fakeServer) Events(r *types.EventsRequest, stream types.API_EventsServer) error {
+	return stream.Send(&types.Event{Type: "exit"})
+}
+
+func TestInterceptors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-interceptors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var methods []string
+	desc := interceptedDesc(types.APIServiceDesc(), []UnaryServerInterceptor{
+		func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+			methods = append(methods, info.FullMethod)
+			// the request is replaced for the rpc
+			return handler(ctx, &types.WaitRequest{Id: "abcd"})
+		},
+		func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (interface{}, error) {
+			methods = append(methods, "inner")
+			return handler(ctx, req)
+		},
+	}, []StreamServerInterceptor{
+		func(srv interface{}, stream grpc.ServerStream, info *StreamServerInfo, handler StreamHandler) error {
+			methods = append(methods, info.FullMethod)
+			return handler(srv, stream)
+		},
+	})
+	l, err := net.Listen("unix", filepath.Join(dir, "containerd.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	s.RegisterService(&desc, &fakeServer{})
+	go s.Serve(l)
+	defer s.Stop()
+	conn, err := grpc.Dial(filepath.Join(dir, "containerd.sock"), grpc.WithInsecure(), grpc.WithTimeout(time.Second),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := types.NewAPIClient(conn)
+	resp, err := c.Wait(context.Background(), &types.WaitRequest{Id: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != 4 {
+		t.Fatalf("expected the rpc to receive the replaced request but received %d", resp.Status)
+	}
+	events, err := c.Events(context.Background(), &types.EventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err := events.Recv(); err != nil || e.Type != "exit" {
+		t.Fatalf("expected an exit event but received %v %v", e, err)
+	}
+	expected := []string{"/types.API/Wait", "inner", "/types.API/Events"}
+	if len(methods) != len(expected) {
+		t.Fatalf("expected the interceptors to be called for %v but received %v", expected, methods)
+	}
+	for i := range expected {
+		if methods[i] != expected[i] {
+			t.Fatalf("expected the interceptors to be called for %v but received %v", expected, methods)
+		}
+	}
+}
@@ -0,0 +1,528 @@
+package server
+
+import (
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/supervisor"
+	"github.com/rcrowley/go-metrics"
+	"golang.org/x/net/context"
+)
+
+// Error categories of failed rpcs
+const (
+	clientError  = "client-errors"
+	runtimeError = "runtime-errors"
+	timeoutError = "timeouts"
+)
+
+// rpcMetrics are the call rate, latency and errors of an rpc method
+type rpcMetrics struct {
+	calls  metrics.Timer
+	errors map[string]metrics.Counter
+}
+
+var rpcs = make(map[string]*rpcMetrics)
+
+func init() {
+	for _, method := range []string{
+		"CreateContainer",
+		"UpdateContainer",
+		"Signal",
+		"UpdateProcess",
+		"AddProcess",
+		"CreateCheckpoint",
+		"DeleteCheckpoint",
+		"ListCheckpoint",
+		"State",
+		"Events",
+		"Stats",
+		"StatsStream",
+		"CopyFromContainer",
+		"CopyToContainer",
+		"Wait",
+		"Attach",
+		"GetLogs",
+		"CloseStdin",
+		"UpdateDevice",
+		"FreezeContainers",
+		"CreateGroup",
+		"DeleteGroup",
+		"ListGroups",
+		"DeleteVolume",
+		"Capabilities",
+		"DumpState",
+		"Healthz",
+		"UploadBundle",
+		"UpdateContainerSpec",
+		"DeleteContainer",
+		"StopContainer",
+		"ListProcesses",
+		"DeleteProcess",
+		"RestartContainer",
+		"CreateTemplate",
+		"ListTemplates",
+		"DeleteTemplate",
+		"Backup",
+		"RestoreBackup",
+		"CheckConsistency",
+		"CreateLease",
+		"RenewLease",
+		"DeleteLease",
+		"ListLeases",
+		"AddAddress",
+		"DeleteAddresses",
+		"ListAddresses",
+		"CreateNetworkNamespace",
+		"DeleteNetworkNamespace",
+		"ListNetworkNamespaces",
+	} {
+		rpcs[method] = &rpcMetrics{
+			calls: metrics.NewTimer(),
+			errors: map[string]metrics.Counter{
+				clientError:  metrics.NewCounter(),
+				runtimeError: metrics.NewCounter(),
+				timeoutError: metrics.NewCounter(),
+			},
+		}
+	}
+}
+
+// Metrics returns the metrics of every rpc method
+func Metrics() map[string]interface{} {
+	m := make(map[string]interface{})
+	for method, r := range rpcs {
+		m["rpc."+method+".time"] = r.calls
+		for category, c := range r.errors {
+			m["rpc."+method+"."+category] = c
+		}
+	}
+	return m
+}
+
+// errorCategory returns the category of an rpc's error
+func errorCategory(err error) string {
+	switch errorCode(err) {
+	case types.ErrorCode_TIMEOUT:
+		return timeoutError
+	case types.ErrorCode_NOT_FOUND, types.ErrorCode_CONFLICT, types.ErrorCode_UNSUPPORTED, types.ErrorCode_INVALID_ARGUMENT:
+		return clientError
+	}
+	return runtimeError
+}
+
+func observe(method string, start time.Time, err error) {
+	r := rpcs[method]
+	r.calls.UpdateSince(start)
+	if err != nil {
+		r.errors[errorCategory(err)].Inc(1)
+	}
+}
+
+// metricsServer records the metrics of the rpcs handled by an APIServer, the
+// latency of a streaming rpc is the lifetime of its stream.  It also writes a
+// crash report when an rpc panics.
+type metricsServer struct {
+	s  types.APIServer
+	sv *supervisor.Supervisor
+}
+
+func (m *metricsServer) CreateContainer(ctx context.Context, r *types.CreateContainerRequest) (*types.CreateContainerResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateContainer(ctx, r)
+	observe("CreateContainer", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) UpdateContainer(ctx context.Context, r *types.UpdateContainerRequest) (*types.UpdateContainerResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.UpdateContainer(ctx, r)
+	observe("UpdateContainer", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Signal(ctx context.Context, r *types.SignalRequest) (*types.SignalResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.Signal(ctx, r)
+	observe("Signal", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) UpdateProcess(ctx context.Context, r *types.UpdateProcessRequest) (*types.UpdateProcessResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.UpdateProcess(ctx, r)
+	observe("UpdateProcess", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) AddProcess(ctx context.Context, r *types.AddProcessRequest) (*types.AddProcessResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.AddProcess(ctx, r)
+	observe("AddProcess", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) CreateCheckpoint(ctx context.Context, r *types.CreateCheckpointRequest) (*types.CreateCheckpointResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateCheckpoint(ctx, r)
+	observe("CreateCheckpoint", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteCheckpoint(ctx context.Context, r *types.DeleteCheckpointRequest) (*types.DeleteCheckpointResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteCheckpoint(ctx, r)
+	observe("DeleteCheckpoint", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListCheckpoint(ctx context.Context, r *types.ListCheckpointRequest) (*types.ListCheckpointResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListCheckpoint(ctx, r)
+	observe("ListCheckpoint", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) State(ctx context.Context, r *types.StateRequest) (*types.StateResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.State(ctx, r)
+	observe("State", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Events(r *types.EventsRequest, stream types.API_EventsServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.Events(r, stream)
+	observe("Events", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) Stats(ctx context.Context, r *types.StatsRequest) (*types.StatsResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.Stats(ctx, r)
+	observe("Stats", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) StatsStream(r *types.StatsRequest, stream types.API_StatsStreamServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.StatsStream(r, stream)
+	observe("StatsStream", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) CopyFromContainer(r *types.CopyFromContainerRequest, stream types.API_CopyFromContainerServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.CopyFromContainer(r, stream)
+	observe("CopyFromContainer", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) CopyToContainer(stream types.API_CopyToContainerServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.CopyToContainer(stream)
+	observe("CopyToContainer", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) Wait(ctx context.Context, r *types.WaitRequest) (*types.WaitResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.Wait(ctx, r)
+	observe("Wait", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Attach(stream types.API_AttachServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.Attach(stream)
+	observe("Attach", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) GetLogs(r *types.GetLogsRequest, stream types.API_GetLogsServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.GetLogs(r, stream)
+	observe("GetLogs", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) CloseStdin(ctx context.Context, r *types.CloseStdinRequest) (*types.CloseStdinResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CloseStdin(ctx, r)
+	observe("CloseStdin", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) UpdateDevice(ctx context.Context, r *types.UpdateDeviceRequest) (*types.UpdateDeviceResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.UpdateDevice(ctx, r)
+	observe("UpdateDevice", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) FreezeContainers(ctx context.Context, r *types.FreezeContainersRequest) (*types.FreezeContainersResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.FreezeContainers(ctx, r)
+	observe("FreezeContainers", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) CreateGroup(ctx context.Context, r *types.CreateGroupRequest) (*types.CreateGroupResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateGroup(ctx, r)
+	observe("CreateGroup", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteGroup(ctx context.Context, r *types.DeleteGroupRequest) (*types.DeleteGroupResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteGroup(ctx, r)
+	observe("DeleteGroup", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListGroups(ctx context.Context, r *types.ListGroupsRequest) (*types.ListGroupsResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListGroups(ctx, r)
+	observe("ListGroups", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteVolume(ctx context.Context, r *types.DeleteVolumeRequest) (*types.DeleteVolumeResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteVolume(ctx, r)
+	observe("DeleteVolume", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Capabilities(ctx context.Context, r *types.CapabilitiesRequest) (*types.CapabilitiesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.Capabilities(ctx, r)
+	observe("Capabilities", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DumpState(ctx context.Context, r *types.DumpStateRequest) (*types.DumpStateResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DumpState(ctx, r)
+	observe("DumpState", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Healthz(ctx context.Context, r *types.HealthzRequest) (*types.HealthzResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.Healthz(ctx, r)
+	observe("Healthz", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) UploadBundle(stream types.API_UploadBundleServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.UploadBundle(stream)
+	observe("UploadBundle", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) UpdateContainerSpec(ctx context.Context, r *types.UpdateContainerSpecRequest) (*types.UpdateContainerSpecResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.UpdateContainerSpec(ctx, r)
+	observe("UpdateContainerSpec", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteContainer(ctx context.Context, r *types.DeleteContainerRequest) (*types.DeleteContainerResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteContainer(ctx, r)
+	observe("DeleteContainer", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) StopContainer(ctx context.Context, r *types.StopContainerRequest) (*types.StopContainerResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.StopContainer(ctx, r)
+	observe("StopContainer", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListProcesses(ctx context.Context, r *types.ListProcessesRequest) (*types.ListProcessesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListProcesses(ctx, r)
+	observe("ListProcesses", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteProcess(ctx context.Context, r *types.DeleteProcessRequest) (*types.DeleteProcessResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteProcess(ctx, r)
+	observe("DeleteProcess", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) RestartContainer(ctx context.Context, r *types.RestartContainerRequest) (*types.RestartContainerResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.RestartContainer(ctx, r)
+	observe("RestartContainer", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) CreateTemplate(ctx context.Context, r *types.CreateTemplateRequest) (*types.CreateTemplateResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateTemplate(ctx, r)
+	observe("CreateTemplate", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListTemplates(ctx context.Context, r *types.ListTemplatesRequest) (*types.ListTemplatesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListTemplates(ctx, r)
+	observe("ListTemplates", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteTemplate(ctx context.Context, r *types.DeleteTemplateRequest) (*types.DeleteTemplateResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteTemplate(ctx, r)
+	observe("DeleteTemplate", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) Backup(r *types.BackupRequest, stream types.API_BackupServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.Backup(r, stream)
+	observe("Backup", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) RestoreBackup(stream types.API_RestoreBackupServer) error {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	err := m.s.RestoreBackup(stream)
+	observe("RestoreBackup", start, err)
+	return rpcError(err, stream.SetTrailer)
+}
+
+func (m *metricsServer) CheckConsistency(ctx context.Context, r *types.CheckConsistencyRequest) (*types.CheckConsistencyResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CheckConsistency(ctx, r)
+	observe("CheckConsistency", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) CreateLease(ctx context.Context, r *types.CreateLeaseRequest) (*types.Lease, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateLease(ctx, r)
+	observe("CreateLease", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) RenewLease(ctx context.Context, r *types.RenewLeaseRequest) (*types.Lease, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.RenewLease(ctx, r)
+	observe("RenewLease", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteLease(ctx context.Context, r *types.DeleteLeaseRequest) (*types.DeleteLeaseResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteLease(ctx, r)
+	observe("DeleteLease", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListLeases(ctx context.Context, r *types.ListLeasesRequest) (*types.ListLeasesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListLeases(ctx, r)
+	observe("ListLeases", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) AddAddress(ctx context.Context, r *types.AddAddressRequest) (*types.AddAddressResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.AddAddress(ctx, r)
+	observe("AddAddress", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteAddresses(ctx context.Context, r *types.DeleteAddressesRequest) (*types.DeleteAddressesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteAddresses(ctx, r)
+	observe("DeleteAddresses", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListAddresses(ctx context.Context, r *types.ListAddressesRequest) (*types.ListAddressesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListAddresses(ctx, r)
+	observe("ListAddresses", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) CreateNetworkNamespace(ctx context.Context, r *types.CreateNetworkNamespaceRequest) (*types.CreateNetworkNamespaceResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.CreateNetworkNamespace(ctx, r)
+	observe("CreateNetworkNamespace", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) DeleteNetworkNamespace(ctx context.Context, r *types.DeleteNetworkNamespaceRequest) (*types.DeleteNetworkNamespaceResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.DeleteNetworkNamespace(ctx, r)
+	observe("DeleteNetworkNamespace", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
+
+func (m *metricsServer) ListNetworkNamespaces(ctx context.Context, r *types.ListNetworkNamespacesRequest) (*types.ListNetworkNamespacesResponse, error) {
+	defer m.sv.HandlePanic()
+	start := time.Now()
+	resp, err := m.s.ListNetworkNamespaces(ctx, r)
+	observe("ListNetworkNamespaces", start, err)
+	return resp, rpcError(err, unaryTrailer(ctx))
+}
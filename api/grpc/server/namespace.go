@@ -0,0 +1,67 @@
+package server
+
+import (
+	"github.com/docker/containerd/supervisor"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// namespaceKey is the key of the request metadata that carries the namespace
+// of the request's containers
+const namespaceKey = "containerd-namespace"
+
+// requestNamespace returns the namespace of the request, the default namespace
+// when the request has none
+func requestNamespace(ctx context.Context) (string, error) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok || len(md[namespaceKey]) == 0 || md[namespaceKey][0] == "" {
+		return supervisor.DefaultNamespace, nil
+	}
+	namespace := md[namespaceKey][0]
+	if !supervisor.ValidNamespace(namespace) {
+		return "", errInvalidNamespace
+	}
+	return namespace, nil
+}
+
+// containerID returns the supervisor's id of the container id in the
+// namespace of the request
+func containerID(ctx context.Context, id string) (string, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return "", err
+	}
+	return supervisor.QualifiedID(namespace, id), nil
+}
+
+// apiID returns the id in the namespace of the container with the
+// supervisor's id, false when the container belongs to another namespace
+func apiID(namespace, id string) (string, bool) {
+	ns, nsID := supervisor.SplitID(id)
+	return nsID, ns == namespace
+}
+
+// apiIDs returns the ids in the namespace of the containers of the namespace
+func apiIDs(namespace string, ids []string) []string {
+	var out []string
+	for _, id := range ids {
+		if nsID, ok := apiID(namespace, id); ok {
+			out = append(out, nsID)
+		}
+	}
+	return out
+}
+
+// leaseKey is the key of the request metadata that carries the lease holding
+// the resources created by the request
+const leaseKey = "containerd-lease"
+
+// requestLease returns the supervisor's id of the lease of the request, an
+// empty id when the request has none
+func requestLease(ctx context.Context) (string, error) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok || len(md[leaseKey]) == 0 {
+		return "", nil
+	}
+	return containerID(ctx, md[leaseKey][0])
+}
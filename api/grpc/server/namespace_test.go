@@ -0,0 +1,49 @@
+package server
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestContainerID(t *testing.T) {
+	for _, tc := range []struct {
+		namespace string
+		id        string
+		expected  string
+	}{
+		{"", "redis", "redis"},
+		{"default", "redis", "redis"},
+		{"ci", "redis", "ci+redis"},
+		// an id with the separator cannot address another namespace
+		{"", "ci+redis", "default+ci+redis"},
+		{"paas", "ci+redis", "paas+ci+redis"},
+	} {
+		ctx := context.Background()
+		if tc.namespace != "" {
+			ctx = metadata.NewContext(ctx, metadata.Pairs(namespaceKey, tc.namespace))
+		}
+		id, err := containerID(ctx, tc.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != tc.expected {
+			t.Fatalf("expected %s in namespace %q to be %s but received %s", tc.id, tc.namespace, tc.expected, id)
+		}
+		namespace := tc.namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		if nsID, ok := apiID(namespace, id); !ok || nsID != tc.id {
+			t.Fatalf("expected %s to be %s in namespace %s but received %s", id, tc.id, namespace, nsID)
+		}
+	}
+	ctx := metadata.NewContext(context.Background(), metadata.Pairs(namespaceKey, "CI/../x"))
+	if _, err := containerID(ctx, "redis"); err != errInvalidNamespace {
+		t.Fatalf("expected errInvalidNamespace but received %v", err)
+	}
+	if _, ok := apiID("paas", "ci+redis"); ok {
+		t.Fatal("expected the container of another namespace to not be visible")
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/docker/containerd/hooks"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+)
+
+// PeerCredentials are the pid, uid and gid of the process that connected to
+// the daemon's unix socket, read with SO_PEERCRED when the connection was
+// accepted
+type PeerCredentials struct {
+	Pid int32
+	Uid uint32
+	Gid uint32
+}
+
+// AuthType implements credentials.AuthInfo
+func (p *PeerCredentials) AuthType() string {
+	return "peercred"
+}
+
+func (p *PeerCredentials) String() string {
+	return fmt.Sprintf("pid=%d uid=%d gid=%d", p.Pid, p.Uid, p.Gid)
+}
+
+// PeerCredentialsFromContext returns the credentials of the peer of the rpc
+// of ctx, there are none when the rpc was received on a tcp socket
+func PeerCredentialsFromContext(ctx context.Context) (*PeerCredentials, bool) {
+	info, ok := credentials.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	p, ok := info.(*PeerCredentials)
+	return p, ok
+}
+
+// hookPeer returns the peer of the rpc of ctx for the hook plugins
+func hookPeer(ctx context.Context) *hooks.Peer {
+	p, ok := PeerCredentialsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &hooks.Peer{
+		Pid: p.Pid,
+		Uid: p.Uid,
+		Gid: p.Gid,
+	}
+}
+
+// PeerCreds returns the credentials of a grpc server that reads the peer
+// credentials of the connections accepted on unix sockets, the handlers and
+// the interceptors get them with PeerCredentialsFromContext
+func PeerCreds() credentials.TransportAuthenticator {
+	return peerCreds{}
+}
+
+type peerCreds struct{}
+
+func (peerCreds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return conn, nil, nil
+	}
+	p, err := peerCredentials(uc)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if p == nil {
+		return conn, nil, nil
+	}
+	return conn, p, nil
+}
+
+func (peerCreds) ClientHandshake(addr string, conn net.Conn, timeout time.Duration) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (peerCreds) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "peercred",
+	}
+}
+
+func (peerCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return nil, nil
+}
+
+func (peerCreds) RequireTransportSecurity() bool {
+	return false
+}
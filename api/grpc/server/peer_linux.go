@@ -0,0 +1,31 @@
+package server
+
+import (
+	"net"
+	"syscall"
+)
+
+// peerCredentials reads SO_PEERCRED of the connection
+func peerCredentials(conn *net.UnixConn) (*PeerCredentials, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		cred *syscall.Ucred
+		serr error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		cred, serr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return nil, err
+	}
+	if serr != nil {
+		return nil, serr
+	}
+	return &PeerCredentials{
+		Pid: cred.Pid,
+		Uid: cred.Uid,
+		Gid: cred.Gid,
+	}, nil
+}
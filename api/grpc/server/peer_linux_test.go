@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestPeerCredentials(t *testing.T) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var conns []net.Conn
+	for _, fd := range fds {
+		f := os.NewFile(uintptr(fd), "socketpair")
+		c, err := net.FileConn(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		conns = append(conns, c)
+	}
+	_, info, err := PeerCreds().ServerHandshake(conns[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, ok := info.(*PeerCredentials)
+	if !ok {
+		t.Fatalf("expected the peer credentials but received %v", info)
+	}
+	if int(p.Pid) != os.Getpid() || int(p.Uid) != os.Getuid() || int(p.Gid) != os.Getgid() {
+		t.Fatalf("expected the credentials of the test process but received %s", p)
+	}
+}
@@ -0,0 +1,8 @@
+package server
+
+import "net"
+
+// peerCredentials returns no credentials, there is no SO_PEERCRED on windows
+func peerCredentials(conn *net.UnixConn) (*PeerCredentials, error) {
+	return nil, nil
+}
@@ -1,45 +1,167 @@
 package server
 
 import (
-	"errors"
+	"bufio"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"sort"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	"github.com/docker/containerd"
 	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/archive"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/logging"
 	"github.com/docker/containerd/runtime"
 	"github.com/docker/containerd/specs"
 	"github.com/docker/containerd/supervisor"
+	"github.com/docker/containerd/tracing"
+	"github.com/docker/containerd/volumes"
 	"golang.org/x/net/context"
 )
 
+var log = logging.Logger("api")
+
+// copyChunkSize is the size of the data sent in each message of a copy stream
+const copyChunkSize = 32 * 1024
+
 type apiServer struct {
 	sv *supervisor.Supervisor
 }
 
 // NewServer returns grpc server instance
 func NewServer(sv *supervisor.Supervisor) types.APIServer {
-	return &apiServer{
+	return &metricsServer{
+		s: &apiServer{
+			sv: sv,
+		},
 		sv: sv,
 	}
 }
 
+// startSpan starts the span of an rpc tagged with its request and passes it to
+// the task sent for the rpc so that the task's span is its child
+func startSpan(ctx context.Context, rpc string, t supervisor.Task, r fmt.Stringer) *tracing.Span {
+	span, ctx := tracing.StartSpan(ctx, "api."+rpc)
+	t.WithContext(ctx)
+	return span.SetTag("request", r)
+}
+
 func (s *apiServer) CreateContainer(ctx context.Context, c *types.CreateContainerRequest) (*types.CreateContainerResponse, error) {
-	if c.BundlePath == "" {
-		return nil, errors.New("empty bundle path")
+	bundlePath := c.BundlePath
+	if c.BundleId != "" {
+		path, err := s.sv.BundlePath(c.BundleId)
+		if err != nil {
+			return nil, err
+		}
+		bundlePath = path
+	}
+	if bundlePath == "" && c.Template == "" {
+		return nil, errEmptyBundlePath
+	}
+	id, err := containerID(ctx, c.Id)
+	if err != nil {
+		return nil, err
 	}
 	e := &supervisor.StartTask{}
-	e.ID = c.Id
-	e.BundlePath = c.BundlePath
+	defer startSpan(ctx, "CreateContainer", e, c).Finish()
+	e.ID = id
+	e.BundlePath = bundlePath
 	e.Stdin = c.Stdin
 	e.Stdout = c.Stdout
 	e.Stderr = c.Stderr
 	e.Labels = c.Labels
+	e.StdinOnce = c.StdinOnce
+	e.StdioSocket = c.StdioSocket
+	e.CgroupNamespace = c.CgroupNamespace
+	if c.Group != "" {
+		if e.Group, err = containerID(ctx, c.Group); err != nil {
+			return nil, err
+		}
+	}
+	e.NetworkNamespace = c.NetworkNamespace
+	e.GPUs = c.Gpus
+	e.Peer = hookPeer(ctx)
+	e.Keep = c.Keep
+	e.AutoRemove = c.AutoRemove
+	e.NetworkWait = time.Duration(c.NetworkWait) * time.Second
+	e.Init = c.Init
+	e.MaxRuntime = time.Duration(c.MaxRuntime) * time.Second
+	e.MaxRuntimeSignal = syscall.SIGTERM
+	if c.MaxRuntimeSignal != 0 {
+		e.MaxRuntimeSignal = syscall.Signal(int(c.MaxRuntimeSignal))
+	}
+	if p := c.OomRestart; p != nil {
+		e.OOMRestart = &supervisor.OOMRestartPolicy{
+			MaxRestarts:     int(p.MaxRestarts),
+			MemoryIncrement: int64(p.MemoryIncrement),
+			MemoryLimitCap:  int64(p.MemoryLimitCap),
+		}
+	}
+	for _, v := range c.Volumes {
+		// the volumes of a namespace are named like its containers so that
+		// two namespaces can both have a volume data
+		name, err := containerID(ctx, v.Name)
+		if err != nil {
+			return nil, err
+		}
+		e.Volumes = append(e.Volumes, volumes.Volume{
+			Driver:      v.Driver,
+			Name:        name,
+			Destination: v.Destination,
+			Options:     v.Options,
+			ReadOnly:    v.ReadOnly,
+			Ephemeral:   v.Ephemeral,
+		})
+	}
+	if n := c.Numa; n != nil {
+		e.NUMA = runtime.NUMAConfig{
+			Nodes:        n.Nodes,
+			MemoryPolicy: n.MemoryPolicy,
+		}
+	}
+	if n := c.Network; n != nil {
+		e.Network = createRuntimeNetworkConfig(n)
+	}
+	if l := c.LogConfig; l != nil {
+		e.LogConfig = runtime.LogConfig{
+			Driver:        l.Driver,
+			Options:       l.Options,
+			Path:          l.Path,
+			Mode:          l.Mode,
+			MaxBufferSize: int(l.MaxBufferSize),
+		}
+	}
+	if c.Template != "" {
+		if c.Id == "" {
+			return nil, errEmptyID
+		}
+		edit := runtime.SpecEdit{SetEnv: c.TemplateEnv}
+		for _, m := range c.TemplateMounts {
+			edit.AddMounts = append(edit.AddMounts, runtime.BindMount{
+				Source:      m.Source,
+				Destination: m.Destination,
+				ReadOnly:    m.ReadOnly,
+			})
+		}
+		name, err := containerID(ctx, c.Template)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.sv.CloneTemplate(e, name, edit); err != nil {
+			return nil, err
+		}
+	}
 	e.StartResponse = make(chan supervisor.StartResponse, 1)
 	createContainerConfigCheckpoint(e, c)
+	if err := s.sv.PreCreate(e); err != nil {
+		return nil, err
+	}
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
 		return nil, err
@@ -49,16 +171,23 @@ func (s *apiServer) CreateContainer(ctx context.Context, c *types.CreateContaine
 	if err != nil {
 		return nil, err
 	}
+	apiC.Id = c.Id
 	return &types.CreateContainerResponse{
 		Container: apiC,
 	}, nil
 }
 
 func (s *apiServer) Signal(ctx context.Context, r *types.SignalRequest) (*types.SignalResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.SignalTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "Signal", e, r).Finish()
+	e.ID = id
 	e.PID = r.Pid
 	e.Signal = syscall.Signal(int(r.Signal))
+	s.sv.PreStop(e.ID, e.PID, e.Signal, hookPeer(ctx))
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
 		return nil, err
@@ -66,6 +195,48 @@ func (s *apiServer) Signal(ctx context.Context, r *types.SignalRequest) (*types.
 	return &types.SignalResponse{}, nil
 }
 
+func (s *apiServer) StopContainer(ctx context.Context, r *types.StopContainerRequest) (*types.StopContainerResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.StopTask{}
+	defer startSpan(ctx, "StopContainer", e, r).Finish()
+	e.ID = id
+	e.Signal = syscall.SIGTERM
+	if r.Signal != 0 {
+		e.Signal = syscall.Signal(int(r.Signal))
+	}
+	e.Timeout = time.Duration(r.Timeout) * time.Second
+	if r.Timeout < 0 {
+		e.Signal = syscall.SIGKILL
+	}
+	// subscribe before the signal is sent so that the stop cannot be missed
+	events := s.sv.Events(time.Time{})
+	defer s.sv.Unsubscribe(events)
+	s.sv.PreStop(e.ID, runtime.InitProcessID, e.Signal, hookPeer(ctx))
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case evt := <-events:
+			if evt.ID == id && (evt.Type == "stop" || evt.Type == "stop-forced") {
+				return &types.StopContainerResponse{
+					Status: uint32(evt.Status),
+					Forced: evt.Type == "stop-forced",
+				}, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (s *apiServer) AddProcess(ctx context.Context, r *types.AddProcessRequest) (*types.AddProcessResponse, error) {
 	process := &specs.ProcessSpec{
 		Terminal: r.Terminal,
@@ -76,18 +247,24 @@ func (s *apiServer) AddProcess(ctx context.Context, r *types.AddProcessRequest)
 	setPlatformRuntimeProcessSpecUserFields(r, process)
 
 	if r.Id == "" {
-		return nil, fmt.Errorf("container id cannot be empty")
+		return nil, errEmptyID
 	}
 	if r.Pid == "" {
-		return nil, fmt.Errorf("process id cannot be empty")
+		return nil, errEmptyPID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
 	}
 	e := &supervisor.AddProcessTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "AddProcess", e, r).Finish()
+	e.ID = id
 	e.PID = r.Pid
 	e.ProcessSpec = process
 	e.Stdin = r.Stdin
 	e.Stdout = r.Stdout
 	e.Stderr = r.Stderr
+	e.StdioSocket = r.StdioSocket
 	e.StartResponse = make(chan supervisor.StartResponse, 1)
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
@@ -98,8 +275,14 @@ func (s *apiServer) AddProcess(ctx context.Context, r *types.AddProcessRequest)
 }
 
 func (s *apiServer) State(ctx context.Context, r *types.StateRequest) (*types.StateResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.GetContainersTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "State", e, r).Finish()
+	e.ID = supervisor.QualifiedID(namespace, r.Id)
+	e.Namespace = namespace
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
 		return nil, err
@@ -109,22 +292,100 @@ func (s *apiServer) State(ctx context.Context, r *types.StateRequest) (*types.St
 		Machine: &types.Machine{
 			Cpus:   uint32(m.Cpus),
 			Memory: uint64(m.Memory),
+			Gpus:   m.GPUs,
 		},
 	}
-	for _, c := range e.Containers {
+	containers, next, err := containersPage(e.Containers, r.PageToken, r.Limit)
+	if err != nil {
+		return nil, err
+	}
+	state.NextPageToken = next
+	for _, c := range containers {
+		id, _ := apiID(namespace, c.ID())
+		if r.Summary {
+			apiC := createAPIContainerSummary(c, e.Lifecycles[c.ID()])
+			apiC.Id = id
+			state.Containers = append(state.Containers, apiC)
+			continue
+		}
 		apiC, err := createAPIContainer(c, true)
 		if err != nil {
 			return nil, err
 		}
+		apiC.Id = id
+		apiC.Lifecycle = createAPILifecycle(e.Lifecycles[c.ID()])
 		state.Containers = append(state.Containers, apiC)
 	}
 	return state, nil
 }
 
+// containersPage sorts the containers by id and returns at most limit of the
+// containers following the page token, with the token of the next page when
+// more containers follow.  The token is the encoded id of the last container
+// of the page so that pages stay consistent while containers are added and
+// removed.
+func containersPage(containers []runtime.Container, token string, limit uint32) ([]runtime.Container, string, error) {
+	sort.Sort(byID(containers))
+	if token != "" {
+		after, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, "", errInvalidPageToken
+		}
+		i := sort.Search(len(containers), func(i int) bool {
+			return containers[i].ID() > string(after)
+		})
+		containers = containers[i:]
+	}
+	if limit == 0 || len(containers) <= int(limit) {
+		return containers, "", nil
+	}
+	containers = containers[:limit]
+	return containers, base64.RawURLEncoding.EncodeToString([]byte(containers[limit-1].ID())), nil
+}
+
+type byID []runtime.Container
+
+func (c byID) Len() int           { return len(c) }
+func (c byID) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c byID) Less(i, j int) bool { return c[i].ID() < c[j].ID() }
+
+type byPid []*types.Process
+
+func (p byPid) Len() int           { return len(p) }
+func (p byPid) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byPid) Less(i, j int) bool { return p[i].Pid < p[j].Pid }
+
+// createAPIContainerSummary returns the container with only the pid of its
+// processes and the state of its lifecycle, the specs of the processes and the
+// pids read from the container's cgroup are omitted
+func createAPIContainerSummary(c runtime.Container, l supervisor.Lifecycle) *types.Container {
+	var procs []*types.Process
+	if processes, err := c.Processes(); err == nil {
+		for _, p := range processes {
+			procs = append(procs, &types.Process{
+				Pid:       p.ID(),
+				SystemPid: uint32(p.SystemPid()),
+			})
+		}
+	}
+	return &types.Container{
+		Id:         c.ID(),
+		BundlePath: c.Path(),
+		Processes:  procs,
+		Labels:     c.Labels(),
+		Status:     string(c.State()),
+		Runtime:    c.Runtime(),
+		Addresses:  c.Addresses(),
+		Lifecycle: &types.ContainerLifecycle{
+			State: string(l.State()),
+		},
+	}
+}
+
 func createAPIContainer(c runtime.Container, getPids bool) (*types.Container, error) {
 	processes, err := c.Processes()
 	if err != nil {
-		return nil, grpc.Errorf(codes.Internal, "get processes for container: "+err.Error())
+		return nil, fmt.Errorf("get processes for container: %v", err)
 	}
 	var procs []*types.Process
 	for _, p := range processes {
@@ -141,6 +402,9 @@ func createAPIContainer(c runtime.Container, getPids bool) (*types.Container, er
 			Stdout:    stdio.Stdout,
 			Stderr:    stdio.Stderr,
 		}
+		if t := p.StartedAt(); !t.IsZero() {
+			appendToProcs.StartedAt = uint64(t.UnixNano())
+		}
 		setUserFieldsInProcess(appendToProcs, oldProc)
 		procs = append(procs, appendToProcs)
 	}
@@ -148,7 +412,7 @@ func createAPIContainer(c runtime.Container, getPids bool) (*types.Container, er
 	state := c.State()
 	if getPids && (state == runtime.Running || state == runtime.Paused) {
 		if pids, err = c.Pids(); err != nil {
-			return nil, grpc.Errorf(codes.Internal, "get all pids for container: "+err.Error())
+			return nil, fmt.Errorf("get all pids for container: %v", err)
 		}
 	}
 	return &types.Container{
@@ -159,9 +423,157 @@ func createAPIContainer(c runtime.Container, getPids bool) (*types.Container, er
 		Status:     string(state),
 		Pids:       toUint32(pids),
 		Runtime:    c.Runtime(),
+		LogConfig:  createAPILogConfig(c.LogConfig()),
+		StdinOnce:  c.StdinOnce(),
+		Numa:       createAPINUMAConfig(c.NUMA()),
+		Addresses:  c.Addresses(),
+		Network:    createAPINetworkConfig(c.Network()),
 	}, nil
 }
 
+func createAPILifecycle(l supervisor.Lifecycle) *types.ContainerLifecycle {
+	lc := &types.ContainerLifecycle{
+		State:     string(l.State()),
+		LastError: l.LastError,
+	}
+	if !l.LastErrorTimestamp.IsZero() {
+		lc.LastErrorTimestamp = uint64(l.LastErrorTimestamp.UnixNano())
+	}
+	for _, t := range l.Transitions {
+		lc.Transitions = append(lc.Transitions, &types.StateTransition{
+			State:     string(t.State),
+			Timestamp: uint64(t.Timestamp.UnixNano()),
+		})
+	}
+	return lc
+}
+
+// createAPITemplate returns the template with its name and the id of its
+// container in their namespace
+func createAPITemplate(t *supervisor.Template) *types.Template {
+	_, name := supervisor.SplitID(t.Name)
+	_, container := supervisor.SplitID(t.Container)
+	return &types.Template{
+		Name:       name,
+		Container:  container,
+		Labels:     t.Labels,
+		LogConfig:  createAPILogConfig(t.LogConfig),
+		StdinOnce:  t.StdinOnce,
+		Numa:       createAPINUMAConfig(t.NUMA),
+		Keep:       t.Keep,
+		AutoRemove: t.AutoRemove,
+		Created:    uint64(t.Created.UnixNano()),
+	}
+}
+
+func createAPINUMAConfig(n runtime.NUMAConfig) *types.NUMAConfig {
+	if n.Nodes == "" {
+		return nil
+	}
+	return &types.NUMAConfig{
+		Nodes:        n.Nodes,
+		MemoryPolicy: n.MemoryPolicy,
+	}
+}
+
+func createRuntimeNetworkConfig(n *types.NetworkConfig) runtime.NetworkConfig {
+	r := runtime.NetworkConfig{
+		RoutedInterface: n.RoutedInterface,
+	}
+	for _, i := range n.Interfaces {
+		r.Interfaces = append(r.Interfaces, runtime.InterfaceConfig{
+			Name:       i.Name,
+			MTU:        int(i.Mtu),
+			TxQueueLen: int(i.TxQueueLen),
+			MAC:        i.Mac,
+		})
+	}
+	for _, a := range n.Addresses {
+		c := runtime.AddressConfig{
+			Interface: a.Interface,
+			Network:   a.Network,
+		}
+		// the address of a network is allocated by the daemon
+		if c.Network == "" {
+			c.Address = a.Address
+		}
+		r.Addresses = append(r.Addresses, c)
+	}
+	for _, rt := range n.Routes {
+		r.Routes = append(r.Routes, runtime.RouteConfig{
+			Destination: rt.Destination,
+			Gateway:     rt.Gateway,
+			Interface:   rt.Interface,
+			Metric:      int(rt.Metric),
+		})
+	}
+	if vf := n.Vf; vf != nil {
+		r.VF = &runtime.VFConfig{
+			PF:   vf.Pf,
+			Name: vf.Name,
+			MAC:  vf.Mac,
+			VLAN: int(vf.Vlan),
+		}
+	}
+	return r
+}
+
+func createAPINetworkConfig(n runtime.NetworkConfig) *types.NetworkConfig {
+	if n.Empty() {
+		return nil
+	}
+	r := &types.NetworkConfig{
+		RoutedInterface: n.RoutedInterface,
+	}
+	for _, i := range n.Interfaces {
+		r.Interfaces = append(r.Interfaces, &types.InterfaceConfig{
+			Name:       i.Name,
+			Mtu:        uint32(i.MTU),
+			TxQueueLen: uint32(i.TxQueueLen),
+			Mac:        i.MAC,
+		})
+	}
+	for _, a := range n.Addresses {
+		r.Addresses = append(r.Addresses, &types.AddressConfig{
+			Interface: a.Interface,
+			Network:   a.Network,
+			Address:   a.Address,
+			Gateway:   a.Gateway,
+		})
+	}
+	for _, rt := range n.Routes {
+		r.Routes = append(r.Routes, &types.Route{
+			Destination: rt.Destination,
+			Gateway:     rt.Gateway,
+			Interface:   rt.Interface,
+			Metric:      uint32(rt.Metric),
+		})
+	}
+	if vf := n.VF; vf != nil {
+		r.Vf = &types.VirtualFunction{
+			Pf:    vf.PF,
+			Index: uint32(vf.Index),
+			Name:  vf.Name,
+			Mac:   vf.MAC,
+			Vlan:  uint32(vf.VLAN),
+		}
+	}
+	return r
+}
+
+func createAPILogConfig(l runtime.LogConfig) *types.LogConfig {
+	if l.Driver == "" && l.Mode == "" {
+		return nil
+	}
+	return &types.LogConfig{
+		Driver:        l.Driver,
+		Options:       l.Options,
+		Path:          l.Path,
+		Mode:          l.Mode,
+		MaxBufferSize: uint32(l.MaxBufferSize),
+	}
+}
+
 func toUint32(its []int) []uint32 {
 	o := []uint32{}
 	for _, i := range its {
@@ -171,8 +583,13 @@ func toUint32(its []int) []uint32 {
 }
 
 func (s *apiServer) UpdateContainer(ctx context.Context, r *types.UpdateContainerRequest) (*types.UpdateContainerResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.UpdateTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "UpdateContainer", e, r).Finish()
+	e.ID = id
 	e.State = runtime.State(r.Status)
 	if r.Resources != nil {
 		rs := r.Resources
@@ -207,6 +624,29 @@ func (s *apiServer) UpdateContainer(ctx context.Context, r *types.UpdateContaine
 		if rs.MemorySwap != 0 {
 			e.Resources.MemorySwap = int64(rs.MemorySwap)
 		}
+		if rs.CpuRealtimeRuntime != 0 {
+			e.Resources.CPURealtimeRuntime = int64(rs.CpuRealtimeRuntime)
+		}
+		if rs.CpuRealtimePeriod != 0 {
+			e.Resources.CPURealtimePeriod = int64(rs.CpuRealtimePeriod)
+		}
+		if rs.PidsLimit != 0 {
+			e.Resources.PidsLimit = rs.PidsLimit
+		}
+		if rs.MemorySwappiness != nil {
+			swappiness := int64(rs.MemorySwappiness.Value)
+			e.Resources.MemorySwappiness = &swappiness
+		}
+		for _, d := range rs.BlkioWeightDevice {
+			e.Resources.BlkioWeightDevice = append(e.Resources.BlkioWeightDevice, runtime.WeightDevice{
+				Path:   d.Path,
+				Weight: uint16(d.Weight),
+			})
+		}
+		e.Resources.BlkioThrottleReadBpsDevice = toRuntimeThrottleDevices(rs.BlkioThrottleReadBpsDevice)
+		e.Resources.BlkioThrottleWriteBpsDevice = toRuntimeThrottleDevices(rs.BlkioThrottleWriteBpsDevice)
+		e.Resources.BlkioThrottleReadIOPSDevice = toRuntimeThrottleDevices(rs.BlkioThrottleReadIopsDevice)
+		e.Resources.BlkioThrottleWriteIOPSDevice = toRuntimeThrottleDevices(rs.BlkioThrottleWriteIopsDevice)
 	}
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
@@ -215,9 +655,293 @@ func (s *apiServer) UpdateContainer(ctx context.Context, r *types.UpdateContaine
 	return &types.UpdateContainerResponse{}, nil
 }
 
+func toRuntimeThrottleDevices(devices []*types.ThrottleDevice) []runtime.ThrottleDevice {
+	var out []runtime.ThrottleDevice
+	for _, d := range devices {
+		out = append(out, runtime.ThrottleDevice{
+			Path: d.Path,
+			Rate: d.Rate,
+		})
+	}
+	return out
+}
+
+func (s *apiServer) FreezeContainers(ctx context.Context, r *types.FreezeContainersRequest) (*types.FreezeContainersResponse, error) {
+	if len(r.Ids) == 0 && len(r.Labels) == 0 && r.Group == "" {
+		return nil, errNoContainersSelected
+	}
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.FreezeTask{}
+	defer startSpan(ctx, "FreezeContainers", e, r).Finish()
+	for _, id := range r.Ids {
+		e.IDs = append(e.IDs, supervisor.QualifiedID(namespace, id))
+	}
+	e.Labels = r.Labels
+	e.Group = supervisor.QualifiedID(namespace, r.Group)
+	e.Namespace = namespace
+	e.Thaw = r.Thaw
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.FreezeContainersResponse{Ids: apiIDs(namespace, e.Updated)}, nil
+}
+
+func (s *apiServer) DumpState(ctx context.Context, r *types.DumpStateRequest) (*types.DumpStateResponse, error) {
+	timeout := 5 * time.Second
+	if r.Timeout != 0 {
+		timeout = time.Duration(r.Timeout)
+	}
+	d := s.sv.Dump(timeout)
+	resp := &types.DumpStateResponse{
+		Tasks:       toQueueState(d.Tasks),
+		StartTasks:  toQueueState(d.StartTasks),
+		Exits:       toQueueState(d.Exits),
+		Ooms:        toQueueState(d.OOMs),
+		CurrentTask: d.CurrentTask,
+		Blocked:     d.Blocked,
+	}
+	if !d.CurrentTaskStarted.IsZero() {
+		resp.CurrentTaskStarted = uint64(d.CurrentTaskStarted.UnixNano())
+	}
+	for _, q := range d.Subscribers {
+		resp.Subscribers = append(resp.Subscribers, toQueueState(q))
+	}
+	for _, c := range d.Containers {
+		cd := &types.ContainerDump{
+			Id:         c.ID,
+			BundlePath: c.Bundle,
+			Status:     string(c.Status),
+			Labels:     c.Labels,
+			Cpuset:     c.CPUSet,
+		}
+		for _, p := range c.Processes {
+			cd.Processes = append(cd.Processes, &types.ProcessDump{
+				Pid:       p.ID,
+				SystemPid: uint32(p.SystemPid),
+				Status:    string(p.Status),
+			})
+		}
+		resp.Containers = append(resp.Containers, cd)
+	}
+	return resp, nil
+}
+
+func (s *apiServer) Healthz(ctx context.Context, r *types.HealthzRequest) (*types.HealthzResponse, error) {
+	timeout := 5 * time.Second
+	if r.Timeout != 0 {
+		timeout = time.Duration(r.Timeout)
+	}
+	resp := &types.HealthzResponse{
+		Healthy: true,
+	}
+	for _, h := range s.sv.Health(timeout) {
+		resp.Healthy = resp.Healthy && h.Healthy
+		resp.Checks = append(resp.Checks, &types.HealthCheck{
+			Name:     h.Name,
+			Healthy:  h.Healthy,
+			Error:    h.Error,
+			Duration: uint64(h.Duration),
+		})
+	}
+	return resp, nil
+}
+
+func toQueueState(q supervisor.Queue) *types.QueueState {
+	return &types.QueueState{
+		Length:   uint32(q.Length),
+		Capacity: uint32(q.Capacity),
+	}
+}
+
+func (s *apiServer) CreateGroup(ctx context.Context, r *types.CreateGroupRequest) (*types.CreateGroupResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyGroupID
+	}
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.CreateGroupTask{}
+	defer startSpan(ctx, "CreateGroup", e, r).Finish()
+	e.ID = supervisor.QualifiedID(namespace, r.Id)
+	e.Namespaces = r.Namespaces
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.CreateGroupResponse{Group: toAPIGroup(namespace, e.Group)}, nil
+}
+
+func (s *apiServer) DeleteGroup(ctx context.Context, r *types.DeleteGroupRequest) (*types.DeleteGroupResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.DeleteGroupTask{}
+	defer startSpan(ctx, "DeleteGroup", e, r).Finish()
+	e.ID = supervisor.QualifiedID(namespace, r.Id)
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.DeleteGroupResponse{Ids: apiIDs(namespace, e.Killed)}, nil
+}
+
+func (s *apiServer) ListGroups(ctx context.Context, r *types.ListGroupsRequest) (*types.ListGroupsResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.GetGroupsTask{}
+	defer startSpan(ctx, "ListGroups", e, r).Finish()
+	e.ID = supervisor.QualifiedID(namespace, r.Id)
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	resp := &types.ListGroupsResponse{}
+	for _, g := range e.Groups {
+		if _, ok := apiID(namespace, g.ID); ok {
+			resp.Groups = append(resp.Groups, toAPIGroup(namespace, g))
+		}
+	}
+	return resp, nil
+}
+
+func (s *apiServer) CreateNetworkNamespace(ctx context.Context, r *types.CreateNetworkNamespaceRequest) (*types.CreateNetworkNamespaceResponse, error) {
+	if r.Name == "" {
+		return nil, errEmptyNetnsName
+	}
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.CreateNetworkNamespaceTask{}
+	defer startSpan(ctx, "CreateNetworkNamespace", e, r).Finish()
+	e.Name = r.Name
+	if r.Container != "" {
+		if e.Source, err = containerID(ctx, r.Container); err != nil {
+			return nil, err
+		}
+	}
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.CreateNetworkNamespaceResponse{NetworkNamespace: toAPINetworkNamespace(namespace, e.NetworkNamespace)}, nil
+}
+
+func (s *apiServer) DeleteNetworkNamespace(ctx context.Context, r *types.DeleteNetworkNamespaceRequest) (*types.DeleteNetworkNamespaceResponse, error) {
+	e := &supervisor.DeleteNetworkNamespaceTask{}
+	defer startSpan(ctx, "DeleteNetworkNamespace", e, r).Finish()
+	e.Name = r.Name
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.DeleteNetworkNamespaceResponse{}, nil
+}
+
+func (s *apiServer) ListNetworkNamespaces(ctx context.Context, r *types.ListNetworkNamespacesRequest) (*types.ListNetworkNamespacesResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.GetNetworkNamespacesTask{}
+	defer startSpan(ctx, "ListNetworkNamespaces", e, r).Finish()
+	e.Name = r.Name
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	resp := &types.ListNetworkNamespacesResponse{}
+	for _, n := range e.NetworkNamespaces {
+		resp.NetworkNamespaces = append(resp.NetworkNamespaces, toAPINetworkNamespace(namespace, n))
+	}
+	return resp, nil
+}
+
+func (s *apiServer) DeleteVolume(ctx context.Context, r *types.DeleteVolumeRequest) (*types.DeleteVolumeResponse, error) {
+	name, err := containerID(ctx, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sv.DeleteVolume(r.Driver, name); err != nil {
+		return nil, err
+	}
+	return &types.DeleteVolumeResponse{}, nil
+}
+
+func (s *apiServer) Capabilities(ctx context.Context, r *types.CapabilitiesRequest) (*types.CapabilitiesResponse, error) {
+	c := s.sv.Capabilities()
+	return &types.CapabilitiesResponse{
+		Version:           containerd.Version,
+		Runtime:           c.Runtime,
+		Criu:              c.Host.CRIU,
+		Seccomp:           c.Host.Seccomp,
+		Apparmor:          c.Host.AppArmor,
+		Selinux:           c.Host.SELinux,
+		CgroupVersion:     uint32(c.Host.CgroupVersion),
+		CgroupNamespace:   c.Host.CgroupNamespace,
+		Realtime:          c.Host.Realtime,
+		SwapAccounting:    c.Host.SwapAccounting,
+		GroupNamespaces:   c.Host.GroupNamespaces,
+		LogDrivers:        c.LogDrivers,
+		VolumeDrivers:     c.VolumeDrivers,
+		HookPlugins:       c.HookPlugins,
+		OciHooks:          c.OCIHooks,
+		Gpus:              c.GPUs,
+		KernelVersion:     c.Host.KernelVersion,
+		CgroupControllers: c.Host.CgroupControllers,
+		UserNamespaces:    c.Host.UserNamespaces,
+		Overlayfs:         c.Host.Overlayfs,
+		Rootless:          c.Host.Rootless,
+		CgroupsDelegated:  c.Host.CgroupsDelegated,
+		Slirp4Netns:       c.Host.Slirp4netns,
+	}, nil
+}
+
+// toAPIGroup returns the group of the namespace with the containers of the
+// namespace
+func toAPIGroup(namespace string, g supervisor.GroupInfo) *types.Group {
+	id, _ := apiID(namespace, g.ID)
+	return &types.Group{
+		Id:         id,
+		Namespaces: g.Namespaces,
+		Pid:        uint32(g.Pid),
+		Containers: apiIDs(namespace, g.Containers),
+		Running:    g.Running,
+		Deleting:   g.Deleting,
+	}
+}
+
+// toAPINetworkNamespace returns the network namespace with the containers of
+// the request's namespace
+func toAPINetworkNamespace(namespace string, n supervisor.NetworkNamespaceInfo) *types.NetworkNamespace {
+	r := &types.NetworkNamespace{
+		Name:       n.Name,
+		Path:       n.Path,
+		Containers: apiIDs(namespace, n.Containers),
+		Mounted:    n.Mounted,
+	}
+	if source, ok := apiID(namespace, n.Source); ok {
+		r.Source = source
+	}
+	return r
+}
+
 func (s *apiServer) UpdateProcess(ctx context.Context, r *types.UpdateProcessRequest) (*types.UpdateProcessResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.UpdateProcessTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "UpdateProcess", e, r).Finish()
+	e.ID = id
 	e.PID = r.Pid
 	e.Height = int(r.Height)
 	e.Width = int(r.Width)
@@ -229,23 +953,812 @@ func (s *apiServer) UpdateProcess(ctx context.Context, r *types.UpdateProcessReq
 	return &types.UpdateProcessResponse{}, nil
 }
 
-func (s *apiServer) Events(r *types.EventsRequest, stream types.API_EventsServer) error {
-	t := time.Time{}
-	if r.Timestamp != 0 {
-		t = time.Unix(int64(r.Timestamp), 0)
+func (s *apiServer) CloseStdin(ctx context.Context, r *types.CloseStdinRequest) (*types.CloseStdinResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
 	}
-	events := s.sv.Events(t)
-	defer s.sv.Unsubscribe(events)
-	for e := range events {
-		if err := stream.Send(&types.Event{
-			Id:        e.ID,
-			Type:      e.Type,
-			Timestamp: uint64(e.Timestamp.Unix()),
-			Pid:       e.PID,
-			Status:    uint32(e.Status),
-		}); err != nil {
-			return err
+	e := &supervisor.UpdateProcessTask{}
+	defer startSpan(ctx, "CloseStdin", e, r).Finish()
+	e.ID = id
+	e.PID = r.Pid
+	if e.PID == "" {
+		e.PID = runtime.InitProcessID
+	}
+	e.CloseStdin = true
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.CloseStdinResponse{}, nil
+}
+
+func (s *apiServer) UpdateDevice(ctx context.Context, r *types.UpdateDeviceRequest) (*types.UpdateDeviceResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.UpdateDeviceTask{}
+	defer startSpan(ctx, "UpdateDevice", e, r).Finish()
+	e.ID = id
+	e.Device = runtime.Device{
+		Path:          r.Path,
+		ContainerPath: r.ContainerPath,
+		Permissions:   r.Permissions,
+		Mknod:         r.Mknod,
+	}
+	e.Remove = r.Remove
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.UpdateDeviceResponse{}, nil
+}
+
+func (s *apiServer) AddAddress(ctx context.Context, r *types.AddAddressRequest) (*types.AddAddressResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.AddressTask{}
+	defer startSpan(ctx, "AddAddress", e, r).Finish()
+	e.ID = id
+	e.Address = runtime.Address{
+		Interface: r.Interface,
+		Label:     r.Label,
+		Address:   r.Address,
+	}
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.AddAddressResponse{}, nil
+}
+
+func (s *apiServer) DeleteAddresses(ctx context.Context, r *types.DeleteAddressesRequest) (*types.DeleteAddressesResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.AddressTask{}
+	defer startSpan(ctx, "DeleteAddresses", e, r).Finish()
+	e.ID = id
+	e.Address = runtime.Address{Label: r.Label}
+	e.Remove = true
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.DeleteAddressesResponse{Addresses: createAPIAddresses(e.Removed)}, nil
+}
+
+func (s *apiServer) ListAddresses(ctx context.Context, r *types.ListAddressesRequest) (*types.ListAddressesResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.ListAddressesTask{}
+	defer startSpan(ctx, "ListAddresses", e, r).Finish()
+	e.ID = id
+	e.Label = r.Label
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.ListAddressesResponse{Addresses: createAPIAddresses(e.Addresses)}, nil
+}
+
+func createAPIAddresses(addrs []runtime.Address) []*types.InterfaceAddress {
+	var out []*types.InterfaceAddress
+	for _, a := range addrs {
+		out = append(out, &types.InterfaceAddress{
+			Interface: a.Interface,
+			Label:     a.Label,
+			Address:   a.Address,
+		})
+	}
+	return out
+}
+
+func (s *apiServer) UpdateContainerSpec(ctx context.Context, r *types.UpdateContainerSpecRequest) (*types.UpdateContainerSpecResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.UpdateSpecTask{}
+	defer startSpan(ctx, "UpdateContainerSpec", e, r).Finish()
+	e.ID = id
+	for _, m := range r.AddMounts {
+		e.Edit.AddMounts = append(e.Edit.AddMounts, runtime.BindMount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	e.Edit.RemoveMounts = r.RemoveMounts
+	e.Edit.SetEnv = r.SetEnv
+	e.Edit.UnsetEnv = r.UnsetEnv
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.UpdateContainerSpecResponse{}, nil
+}
+
+func (s *apiServer) DeleteContainer(ctx context.Context, r *types.DeleteContainerRequest) (*types.DeleteContainerResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.RemoveTask{}
+	defer startSpan(ctx, "DeleteContainer", e, r).Finish()
+	e.ID = id
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.DeleteContainerResponse{}, nil
+}
+
+func (s *apiServer) ListProcesses(ctx context.Context, r *types.ListProcessesRequest) (*types.ListProcessesResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.GetContainersTask{}
+	defer startSpan(ctx, "ListProcesses", e, r).Finish()
+	e.ID = id
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	c, err := createAPIContainer(e.Containers[0], false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListProcessesResponse{}
+	for _, p := range c.Processes {
+		if p.Pid != runtime.InitProcessID {
+			resp.Processes = append(resp.Processes, p)
 		}
 	}
+	sort.Sort(byPid(resp.Processes))
+	return resp, nil
+}
+
+func (s *apiServer) DeleteProcess(ctx context.Context, r *types.DeleteProcessRequest) (*types.DeleteProcessResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	if r.Pid == "" {
+		return nil, errEmptyPID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.DeleteProcessTask{}
+	defer startSpan(ctx, "DeleteProcess", e, r).Finish()
+	e.ID = id
+	e.PID = r.Pid
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.DeleteProcessResponse{Status: uint32(e.Status)}, nil
+}
+
+func (s *apiServer) RestartContainer(ctx context.Context, r *types.RestartContainerRequest) (*types.RestartContainerResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.RestartTask{}
+	defer startSpan(ctx, "RestartContainer", e, r).Finish()
+	e.ID = id
+	e.Signal = syscall.SIGTERM
+	if r.Signal != 0 {
+		e.Signal = syscall.Signal(int(r.Signal))
+	}
+	e.Timeout = time.Duration(r.Timeout) * time.Second
+	if r.Timeout < 0 {
+		e.Signal = syscall.SIGKILL
+	}
+	e.StartResponse = make(chan supervisor.StartResponse, 1)
+	s.sv.PreStop(e.ID, runtime.InitProcessID, e.Signal, hookPeer(ctx))
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	<-e.StartResponse
+	return &types.RestartContainerResponse{}, nil
+}
+
+func (s *apiServer) Events(r *types.EventsRequest, stream types.API_EventsServer) error {
+	namespace, err := requestNamespace(stream.Context())
+	if err != nil {
+		return err
+	}
+	var (
+		events chan supervisor.Event
+		policy = supervisor.OverflowPolicy(r.OverflowPolicy)
+	)
+	switch {
+	case r.AfterSeq != 0:
+		events, err = s.sv.SubscribeAfter(r.AfterSeq, policy)
+	case r.Timestamp != 0:
+		events, err = s.sv.Subscribe(time.Unix(int64(r.Timestamp), 0), policy)
+	default:
+		events, err = s.sv.Subscribe(time.Time{}, policy)
+	}
+	if err != nil {
+		return err
+	}
+	defer s.sv.Unsubscribe(events)
+	for e := range events {
+		// events of the daemon have no container and are sent to every
+		// namespace
+		id, ok := apiID(namespace, e.ID)
+		if e.ID != "" && !ok {
+			continue
+		}
+		if err := stream.Send(&types.Event{
+			Id:        id,
+			Type:      e.Type,
+			Timestamp: uint64(e.Timestamp.Unix()),
+			Pid:       e.PID,
+			Status:    uint32(e.Status),
+			Level:     e.Level,
+			Seq:       e.Seq,
+			Reason:    e.Reason,
+			Addresses: e.Addresses,
+		}); err != nil {
+			return err
+		}
+	}
+	if s.sv.Disconnected(events) {
+		return grpc.Errorf(codes.ResourceExhausted, "containerd: events subscriber fell behind")
+	}
 	return nil
 }
+
+func (s *apiServer) Wait(ctx context.Context, r *types.WaitRequest) (*types.WaitResponse, error) {
+	pid := r.Pid
+	if pid == "" {
+		pid = runtime.InitProcessID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	// subscribe before looking up the process so that the exit cannot be missed
+	events := s.sv.Events(time.Time{})
+	defer s.sv.Unsubscribe(events)
+	if _, err := s.getProcess(id, pid); err != nil {
+		return nil, err
+	}
+	for {
+		select {
+		case e := <-events:
+			if e.Type == "exit" && e.ID == id && e.PID == pid {
+				return &types.WaitResponse{
+					Status: uint32(e.Status),
+				}, nil
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *apiServer) Attach(stream types.API_AttachServer) error {
+	r, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	pid := r.Pid
+	if pid == "" {
+		pid = runtime.InitProcessID
+	}
+	id, err := containerID(stream.Context(), r.Id)
+	if err != nil {
+		return err
+	}
+	p, err := s.getProcess(id, pid)
+	if err != nil {
+		return err
+	}
+	frames, detach := p.Attach(int(r.Replay))
+	defer detach()
+	// with stdin once the init process' stdin is closed when the first
+	// attached client detaches
+	closeOnDetach := pid == runtime.InitProcessID && p.Container().StdinOnce()
+	go attachStdin(stream, p, r, closeOnDetach)
+	for {
+		select {
+		case f, ok := <-frames:
+			if !ok {
+				// the frames end with the output or when the client
+				// did not keep up with it
+				return detach()
+			}
+			if err := stream.Send(&types.AttachResponse{
+				Stream: uint32(f.Stream),
+				Data:   f.Data,
+			}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *apiServer) GetLogs(r *types.GetLogsRequest, stream types.API_GetLogsServer) error {
+	pid := r.Pid
+	if pid == "" {
+		pid = runtime.InitProcessID
+	}
+	id, err := containerID(stream.Context(), r.Id)
+	if err != nil {
+		return err
+	}
+	// subscribe before looking up the process so that the exit cannot be missed
+	events := s.sv.Events(time.Time{})
+	defer s.sv.Unsubscribe(events)
+	e := &supervisor.GetContainersTask{}
+	e.ID = id
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return err
+	}
+	l := e.Containers[0].LogConfig()
+	if l.Driver != "json-file" {
+		return errLogsNotSupported
+	}
+	config := logger.ReadConfig{
+		Tail:   int(r.Tail),
+		Follow: r.Follow,
+	}
+	if r.Since != 0 {
+		config.Since = time.Unix(int64(r.Since), 0)
+	}
+	done := make(chan struct{})
+	if r.Follow {
+		if _, err := s.getProcess(id, pid); err != nil {
+			// the process has already exited so there is nothing to follow
+			config.Follow = false
+		} else {
+			go func() {
+				defer close(done)
+				for {
+					select {
+					case e, ok := <-events:
+						if !ok || (e.Type == "exit" && e.ID == id && e.PID == pid) {
+							return
+						}
+					case <-stream.Context().Done():
+						return
+					}
+				}
+			}()
+		}
+	}
+	return logger.ReadJSONFile(logger.JSONFilePath(l.Path, id, pid), config, done, func(entry *logger.JSONLog) error {
+		return stream.Send(&types.LogEntry{
+			Stream:    entry.Stream,
+			Data:      []byte(entry.Log),
+			Timestamp: uint64(entry.Time.UnixNano()),
+		})
+	})
+}
+
+func (s *apiServer) CopyFromContainer(r *types.CopyFromContainerRequest, stream types.API_CopyFromContainerServer) error {
+	id, err := containerID(stream.Context(), r.Id)
+	if err != nil {
+		return err
+	}
+	root, err := s.rootFS(id)
+	if err != nil {
+		return err
+	}
+	rc, err := archive.Tar(root, r.Path)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.CopyChunk{Data: buf[:n]}); serr != nil {
+				return serr
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *apiServer) CopyToContainer(stream types.API_CopyToContainerServer) error {
+	r, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	id, err := containerID(stream.Context(), r.Id)
+	if err != nil {
+		return err
+	}
+	root, err := s.rootFS(id)
+	if err != nil {
+		return err
+	}
+	if err := archive.Untar(&copyReader{stream: stream, buf: r.Data}, root, r.Path); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&types.CopyToContainerResponse{})
+}
+
+func (s *apiServer) UploadBundle(stream types.API_UploadBundleServer) error {
+	namespace, err := requestNamespace(stream.Context())
+	if err != nil {
+		return err
+	}
+	lease, err := requestLease(stream.Context())
+	if err != nil {
+		return err
+	}
+	id, path, err := s.sv.UploadBundle(&uploadReader{stream: stream}, namespace, lease)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&types.UploadBundleResponse{
+		Id:         id,
+		BundlePath: path,
+	})
+}
+
+func (s *apiServer) Backup(r *types.BackupRequest, stream types.API_BackupServer) error {
+	e := &supervisor.BackupTask{}
+	defer startSpan(stream.Context(), "Backup", e, r).Finish()
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(&backupWriter{stream: stream}, copyChunkSize)
+	if err := supervisor.WriteBackup(w, e.Backup); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func (s *apiServer) RestoreBackup(stream types.API_RestoreBackupServer) error {
+	b, err := supervisor.ReadBackup(&restoreReader{stream: stream})
+	if err != nil {
+		return err
+	}
+	e := &supervisor.RestoreBackupTask{}
+	defer startSpan(stream.Context(), "RestoreBackup", e, nil).Finish()
+	e.Backup = b
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&types.RestoreBackupResponse{
+		Restored: e.Restored,
+		Skipped:  e.Skipped,
+	})
+}
+
+func (s *apiServer) CheckConsistency(ctx context.Context, r *types.CheckConsistencyRequest) (*types.CheckConsistencyResponse, error) {
+	var report *supervisor.CheckReport
+	if r.Last {
+		if report = s.sv.LastCheck(); report == nil {
+			return &types.CheckConsistencyResponse{}, nil
+		}
+	} else {
+		e := &supervisor.CheckTask{}
+		defer startSpan(ctx, "CheckConsistency", e, r).Finish()
+		e.Clean = r.Clean
+		s.sv.SendTask(e)
+		if err := <-e.ErrorCh(); err != nil {
+			return nil, err
+		}
+		report = e.Report
+	}
+	resp := &types.CheckConsistencyResponse{
+		Started:  uint64(report.Started.UnixNano()),
+		Duration: uint64(report.Duration),
+	}
+	for _, f := range report.Findings {
+		resp.Findings = append(resp.Findings, &types.ConsistencyFinding{
+			Kind:    string(f.Kind),
+			Id:      f.ID,
+			Pid:     f.PID,
+			Detail:  f.Detail,
+			Cleaned: f.Cleaned,
+		})
+	}
+	return resp, nil
+}
+
+func (s *apiServer) CreateLease(ctx context.Context, r *types.CreateLeaseRequest) (*types.Lease, error) {
+	id := r.Id
+	if id == "" {
+		var err error
+		if id, err = supervisor.NewLeaseID(); err != nil {
+			return nil, err
+		}
+	}
+	id, err := containerID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	l, err := s.sv.CreateLease(id, time.Duration(r.Ttl))
+	if err != nil {
+		return nil, err
+	}
+	return createAPILease(l), nil
+}
+
+func (s *apiServer) RenewLease(ctx context.Context, r *types.RenewLeaseRequest) (*types.Lease, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	l, err := s.sv.RenewLease(id, time.Duration(r.Ttl))
+	if err != nil {
+		return nil, err
+	}
+	return createAPILease(l), nil
+}
+
+func (s *apiServer) DeleteLease(ctx context.Context, r *types.DeleteLeaseRequest) (*types.DeleteLeaseResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sv.DeleteLease(id); err != nil {
+		return nil, err
+	}
+	return &types.DeleteLeaseResponse{}, nil
+}
+
+func (s *apiServer) ListLeases(ctx context.Context, r *types.ListLeasesRequest) (*types.ListLeasesResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leases, err := s.sv.Leases()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListLeasesResponse{}
+	for _, l := range leases {
+		if _, ok := apiID(namespace, l.ID); ok {
+			resp.Leases = append(resp.Leases, createAPILease(l))
+		}
+	}
+	return resp, nil
+}
+
+// createAPILease returns the lease with its id in its namespace
+func createAPILease(l *supervisor.Lease) *types.Lease {
+	_, id := supervisor.SplitID(l.ID)
+	return &types.Lease{
+		Id:      id,
+		Expires: uint64(l.Expires.UnixNano()),
+		Bundles: l.Bundles,
+	}
+}
+
+// attachStdin writes the stdin sent by an attached client to the process
+// starting with the first request r
+func attachStdin(stream types.API_AttachServer, p runtime.Process, r *types.AttachRequest, closeOnDetach bool) {
+	var (
+		err    error
+		stdin  io.WriteCloser
+		closed bool
+	)
+	defer func() {
+		if stdin != nil {
+			stdin.Close()
+		}
+		if closeOnDetach && !closed {
+			if err := p.CloseStdin(); err != nil {
+				log.WithField("error", err).Error("containerd: close stdin for attach")
+			}
+		}
+	}()
+	for {
+		if len(r.Stdin) > 0 {
+			if stdin == nil {
+				if stdin, err = p.OpenStdin(); err != nil {
+					log.WithField("error", err).Error("containerd: open stdin for attach")
+					return
+				}
+			}
+			if _, err := stdin.Write(r.Stdin); err != nil {
+				log.WithField("error", err).Error("containerd: write stdin for attach")
+				return
+			}
+		}
+		if r.CloseStdin && !closed {
+			closed = true
+			if err := p.CloseStdin(); err != nil {
+				log.WithField("error", err).Error("containerd: close stdin for attach")
+			}
+		}
+		if r, err = stream.Recv(); err != nil {
+			return
+		}
+	}
+}
+
+// getProcess returns the process pid of the container id
+func (s *apiServer) getProcess(id, pid string) (runtime.Process, error) {
+	e := &supervisor.GetContainersTask{}
+	e.ID = id
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	processes, err := e.Containers[0].Processes()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range processes {
+		if p.ID() == pid {
+			return p, nil
+		}
+	}
+	return nil, supervisor.ErrProcessNotFound
+}
+
+// rootFS returns the host path of the root filesystem for the container
+func (s *apiServer) rootFS(id string) (string, error) {
+	if id == "" {
+		return "", errEmptyID
+	}
+	e := &supervisor.GetContainersTask{}
+	e.ID = id
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return "", err
+	}
+	return e.Containers[0].RootFS()
+}
+
+// copyReader reads the tar stream sent by a client to CopyToContainer
+type copyReader struct {
+	stream types.API_CopyToContainerServer
+	buf    []byte
+}
+
+func (r *copyReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		m, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = m.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// uploadReader reads the tar stream sent by a client to UploadBundle
+type uploadReader struct {
+	stream types.API_UploadBundleServer
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		m, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = m.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// backupWriter sends the backup archive written to it to the client of Backup
+type backupWriter struct {
+	stream types.API_BackupServer
+}
+
+func (w *backupWriter) Write(p []byte) (int, error) {
+	if err := w.stream.Send(&types.BackupChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// restoreReader reads the backup archive sent by a client to RestoreBackup
+type restoreReader struct {
+	stream types.API_RestoreBackupServer
+	buf    []byte
+}
+
+func (r *restoreReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		m, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = m.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (s *apiServer) CreateTemplate(ctx context.Context, r *types.CreateTemplateRequest) (*types.CreateTemplateResponse, error) {
+	if r.Id == "" {
+		return nil, errEmptyID
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	name, err := containerID(ctx, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	e := &supervisor.SaveTemplateTask{}
+	defer startSpan(ctx, "CreateTemplate", e, r).Finish()
+	e.ID = id
+	e.Name = name
+	s.sv.SendTask(e)
+	if err := <-e.ErrorCh(); err != nil {
+		return nil, err
+	}
+	return &types.CreateTemplateResponse{Template: createAPITemplate(e.Template)}, nil
+}
+
+func (s *apiServer) ListTemplates(ctx context.Context, r *types.ListTemplatesRequest) (*types.ListTemplatesResponse, error) {
+	namespace, err := requestNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := s.sv.Templates()
+	if err != nil {
+		return nil, err
+	}
+	resp := &types.ListTemplatesResponse{}
+	for _, t := range templates {
+		if _, ok := apiID(namespace, t.Name); ok {
+			resp.Templates = append(resp.Templates, createAPITemplate(t))
+		}
+	}
+	return resp, nil
+}
+
+func (s *apiServer) DeleteTemplate(ctx context.Context, r *types.DeleteTemplateRequest) (*types.DeleteTemplateResponse, error) {
+	name, err := containerID(ctx, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sv.DeleteTemplate(name); err != nil {
+		return nil, err
+	}
+	return &types.DeleteTemplateResponse{}, nil
+}
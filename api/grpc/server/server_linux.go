@@ -2,7 +2,6 @@ package server
 
 import (
 	"bufio"
-	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -17,8 +16,6 @@ import (
 	"github.com/opencontainers/runc/libcontainer/system"
 	ocs "github.com/opencontainers/specs/specs-go"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
 )
 
 func createContainerConfigCheckpoint(e *supervisor.StartTask, c *types.CreateContainerRequest) {
@@ -30,8 +27,13 @@ func createContainerConfigCheckpoint(e *supervisor.StartTask, c *types.CreateCon
 }
 
 func (s *apiServer) CreateCheckpoint(ctx context.Context, r *types.CreateCheckpointRequest) (*types.CreateCheckpointResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.CreateCheckpointTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "CreateCheckpoint", e, r).Finish()
+	e.ID = id
 	e.Checkpoint = &runtime.Checkpoint{
 		Name:        r.Checkpoint.Name,
 		Exit:        r.Checkpoint.Exit,
@@ -48,10 +50,15 @@ func (s *apiServer) CreateCheckpoint(ctx context.Context, r *types.CreateCheckpo
 
 func (s *apiServer) DeleteCheckpoint(ctx context.Context, r *types.DeleteCheckpointRequest) (*types.DeleteCheckpointResponse, error) {
 	if r.Name == "" {
-		return nil, errors.New("checkpoint name cannot be empty")
+		return nil, errEmptyCheckpointName
+	}
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
 	}
 	e := &supervisor.DeleteCheckpointTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "DeleteCheckpoint", e, r).Finish()
+	e.ID = id
 	e.Checkpoint = &runtime.Checkpoint{
 		Name: r.Name,
 	}
@@ -63,20 +70,25 @@ func (s *apiServer) DeleteCheckpoint(ctx context.Context, r *types.DeleteCheckpo
 }
 
 func (s *apiServer) ListCheckpoint(ctx context.Context, r *types.ListCheckpointRequest) (*types.ListCheckpointResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.GetContainersTask{}
+	defer startSpan(ctx, "ListCheckpoint", e, r).Finish()
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
 		return nil, err
 	}
 	var container runtime.Container
 	for _, c := range e.Containers {
-		if c.ID() == r.Id {
+		if c.ID() == id {
 			container = c
 			break
 		}
 	}
 	if container == nil {
-		return nil, grpc.Errorf(codes.NotFound, "no such containers")
+		return nil, errNoSuchContainers
 	}
 	var out []*types.Checkpoint
 	checkpoints, err := container.Checkpoints()
@@ -105,6 +117,19 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 	if !ok {
 		return pbSt
 	}
+	for _, i := range lcSt.Interfaces {
+		pbSt.NetworkStats = append(pbSt.NetworkStats, &types.NetworkStats{
+			Name:       i.Name,
+			RxBytes:    i.RxBytes,
+			Rx_Packets: i.RxPackets,
+			RxErrors:   i.RxErrors,
+			RxDropped:  i.RxDropped,
+			TxBytes:    i.TxBytes,
+			TxPackets:  i.TxPackets,
+			TxErrors:   i.TxErrors,
+			TxDropped:  i.TxDropped,
+		})
+	}
 	cpuSt := lcSt.CgroupStats.CpuStats
 	systemUsage, _ := getSystemCPUUsage()
 	pbSt.CgroupStats.CpuStats = &types.CpuStats{
@@ -142,6 +167,16 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 			Failcnt:  memSt.KernelUsage.Failcnt,
 			Limit:    memSt.KernelUsage.Limit,
 		},
+		Swappiness: st.MemorySwappiness,
+	}
+	for _, n := range st.NUMA {
+		pbSt.CgroupStats.MemoryStats.NumaStats = append(pbSt.CgroupStats.MemoryStats.NumaStats, &types.NUMAStats{
+			Node:        uint32(n.Node),
+			Total:       n.Total,
+			File:        n.File,
+			Anon:        n.Anon,
+			Unevictable: n.Unevictable,
+		})
 	}
 	blkSt := lcSt.CgroupStats.BlkioStats
 	pbSt.CgroupStats.BlkioStats = &types.BlkioStats{
@@ -164,6 +199,7 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 	}
 	pbSt.CgroupStats.PidsStats = &types.PidsStats{
 		Current: lcSt.CgroupStats.PidsStats.Current,
+		Limit:   lcSt.CgroupStats.PidsStats.Limit,
 	}
 	return pbSt
 }
@@ -233,8 +269,13 @@ func getSystemCPUUsage() (uint64, error) {
 }
 
 func (s *apiServer) Stats(ctx context.Context, r *types.StatsRequest) (*types.StatsResponse, error) {
+	id, err := containerID(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
 	e := &supervisor.StatsTask{}
-	e.ID = r.Id
+	defer startSpan(ctx, "Stats", e, r).Finish()
+	e.ID = id
 	e.Stat = make(chan *runtime.Stat, 1)
 	s.sv.SendTask(e)
 	if err := <-e.ErrorCh(); err != nil {
@@ -245,6 +286,28 @@ func (s *apiServer) Stats(ctx context.Context, r *types.StatsRequest) (*types.St
 	return t, nil
 }
 
+func (s *apiServer) StatsStream(r *types.StatsRequest, stream types.API_StatsStreamServer) error {
+	id, err := containerID(stream.Context(), r.Id)
+	if err != nil {
+		return err
+	}
+	stats := s.sv.SubscribeStats(id)
+	defer s.sv.UnsubscribeStats(id, stats)
+	for {
+		select {
+		case st, ok := <-stats:
+			if !ok {
+				return supervisor.ErrContainerNotFound
+			}
+			if err := stream.Send(convertToPb(st)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
 func setUserFieldsInProcess(p *types.Process, oldProc specs.ProcessSpec) {
 	p.User = &types.User{
 		Uid:            oldProc.User.UID,
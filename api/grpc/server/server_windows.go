@@ -1,12 +1,12 @@
 package server
 
 import (
-	"errors"
-
 	"github.com/docker/containerd/api/grpc/types"
 	"github.com/docker/containerd/specs"
 	"github.com/docker/containerd/supervisor"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 // noop on Windows (Checkpoints not supported)
@@ -15,21 +15,25 @@ func createContainerConfigCheckpoint(e *supervisor.StartTask, c *types.CreateCon
 
 // TODO Windows - may be able to completely factor out
 func (s *apiServer) CreateCheckpoint(ctx context.Context, r *types.CreateCheckpointRequest) (*types.CreateCheckpointResponse, error) {
-	return nil, errors.New("CreateCheckpoint() not supported on Windows")
+	return nil, grpc.Errorf(codes.Unimplemented, "CreateCheckpoint() not supported on Windows")
 }
 
 // TODO Windows - may be able to completely factor out
 func (s *apiServer) DeleteCheckpoint(ctx context.Context, r *types.DeleteCheckpointRequest) (*types.DeleteCheckpointResponse, error) {
-	return nil, errors.New("DeleteCheckpoint() not supported on Windows")
+	return nil, grpc.Errorf(codes.Unimplemented, "DeleteCheckpoint() not supported on Windows")
 }
 
 // TODO Windows - may be able to completely factor out
 func (s *apiServer) ListCheckpoint(ctx context.Context, r *types.ListCheckpointRequest) (*types.ListCheckpointResponse, error) {
-	return nil, errors.New("ListCheckpoint() not supported on Windows")
+	return nil, grpc.Errorf(codes.Unimplemented, "ListCheckpoint() not supported on Windows")
 }
 
 func (s *apiServer) Stats(ctx context.Context, r *types.StatsRequest) (*types.StatsResponse, error) {
-	return nil, errors.New("Stats() not supported on Windows")
+	return nil, grpc.Errorf(codes.Unimplemented, "Stats() not supported on Windows")
+}
+
+func (s *apiServer) StatsStream(r *types.StatsRequest, stream types.API_StatsStreamServer) error {
+	return grpc.Errorf(codes.Unimplemented, "StatsStream() not supported on Windows")
 }
 
 func setUserFieldsInProcess(p *types.Process, oldProc specs.ProcessSpec) {
@@ -12,6 +12,9 @@ It has these top-level messages:
 	UpdateProcessRequest
 	UpdateProcessResponse
 	CreateContainerRequest
+	Volume
+	NUMAConfig
+	LogConfig
 	CreateContainerResponse
 	SignalRequest
 	SignalResponse
@@ -30,10 +33,15 @@ It has these top-level messages:
 	ContainerState
 	Process
 	Container
+	ContainerLifecycle
+	StateTransition
 	Machine
 	StateResponse
 	UpdateContainerRequest
 	UpdateResource
+	WeightDevice
+	ThrottleDevice
+	MemorySwappiness
 	UpdateContainerResponse
 	EventsRequest
 	Event
@@ -44,12 +52,104 @@ It has these top-level messages:
 	PidsStats
 	MemoryData
 	MemoryStats
+	NUMAStats
 	BlkioStatsEntry
 	BlkioStats
 	HugetlbStats
 	CgroupStats
 	StatsResponse
 	StatsRequest
+	CopyFromContainerRequest
+	CopyChunk
+	CopyToContainerRequest
+	CopyToContainerResponse
+	WaitRequest
+	WaitResponse
+	AttachRequest
+	AttachResponse
+	GetLogsRequest
+	LogEntry
+	CloseStdinRequest
+	CloseStdinResponse
+	UpdateDeviceRequest
+	UpdateDeviceResponse
+	FreezeContainersRequest
+	FreezeContainersResponse
+	DumpStateRequest
+	DumpStateResponse
+	QueueState
+	ContainerDump
+	ProcessDump
+	HealthzRequest
+	HealthzResponse
+	HealthCheck
+	CreateGroupRequest
+	CreateGroupResponse
+	Group
+	DeleteGroupRequest
+	DeleteGroupResponse
+	ListGroupsRequest
+	ListGroupsResponse
+	DeleteVolumeRequest
+	DeleteVolumeResponse
+	CapabilitiesRequest
+	CapabilitiesResponse
+	UploadBundleRequest
+	UploadBundleResponse
+	BindMount
+	UpdateContainerSpecRequest
+	UpdateContainerSpecResponse
+	DeleteContainerRequest
+	DeleteContainerResponse
+	StopContainerRequest
+	StopContainerResponse
+	ListProcessesRequest
+	ListProcessesResponse
+	DeleteProcessRequest
+	DeleteProcessResponse
+	RestartContainerRequest
+	RestartContainerResponse
+	Template
+	CreateTemplateRequest
+	CreateTemplateResponse
+	ListTemplatesRequest
+	ListTemplatesResponse
+	DeleteTemplateRequest
+	DeleteTemplateResponse
+	OOMRestartPolicy
+	BackupRequest
+	BackupChunk
+	RestoreBackupRequest
+	RestoreBackupResponse
+	CheckConsistencyRequest
+	ConsistencyFinding
+	CheckConsistencyResponse
+	Lease
+	CreateLeaseRequest
+	RenewLeaseRequest
+	DeleteLeaseRequest
+	DeleteLeaseResponse
+	ListLeasesRequest
+	ListLeasesResponse
+	NetworkConfig
+	InterfaceConfig
+	VirtualFunction
+	Route
+	InterfaceAddress
+	AddAddressRequest
+	AddAddressResponse
+	DeleteAddressesRequest
+	DeleteAddressesResponse
+	ListAddressesRequest
+	ListAddressesResponse
+	AddressConfig
+	NetworkNamespace
+	CreateNetworkNamespaceRequest
+	CreateNetworkNamespaceResponse
+	DeleteNetworkNamespaceRequest
+	DeleteNetworkNamespaceResponse
+	ListNetworkNamespacesRequest
+	ListNetworkNamespacesResponse
 */
 package types
 
@@ -67,6 +167,55 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// ErrorCode classifies the error of a failed rpc, it is sent as the
+// containerd-error-code trailer and the grpc code of the error follows from it
+type ErrorCode int32
+
+const (
+	// the error was not classified, grpc code Unknown
+	ErrorCode_UNKNOWN ErrorCode = 0
+	// the container, process, group, checkpoint or volume does not exist, grpc code NotFound
+	ErrorCode_NOT_FOUND ErrorCode = 1
+	// the object already exists or is not in a state allowing the call, grpc code AlreadyExists
+	ErrorCode_CONFLICT ErrorCode = 2
+	// the runtime, the shim or the host failed the call, grpc code Unknown
+	ErrorCode_RUNTIME_FAILED ErrorCode = 3
+	// the host, the runtime or the platform does not support the call, grpc code Unimplemented
+	ErrorCode_UNSUPPORTED ErrorCode = 4
+	// the call timed out or was canceled, grpc code DeadlineExceeded
+	ErrorCode_TIMEOUT ErrorCode = 5
+	// the request is invalid, grpc code InvalidArgument
+	ErrorCode_INVALID_ARGUMENT ErrorCode = 6
+	// the request would take the namespace over its quota, grpc code ResourceExhausted
+	ErrorCode_QUOTA_EXCEEDED ErrorCode = 7
+)
+
+var ErrorCode_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "NOT_FOUND",
+	2: "CONFLICT",
+	3: "RUNTIME_FAILED",
+	4: "UNSUPPORTED",
+	5: "TIMEOUT",
+	6: "INVALID_ARGUMENT",
+	7: "QUOTA_EXCEEDED",
+}
+var ErrorCode_value = map[string]int32{
+	"UNKNOWN":          0,
+	"NOT_FOUND":        1,
+	"CONFLICT":         2,
+	"RUNTIME_FAILED":   3,
+	"UNSUPPORTED":      4,
+	"TIMEOUT":          5,
+	"INVALID_ARGUMENT": 6,
+	"QUOTA_EXCEEDED":   7,
+}
+
+func (x ErrorCode) String() string {
+	return proto.EnumName(ErrorCode_name, int32(x))
+}
+func (ErrorCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type UpdateProcessRequest struct {
 	Id         string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Pid        string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
@@ -89,13 +238,34 @@ func (*UpdateProcessResponse) ProtoMessage()               {}
 func (*UpdateProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
 type CreateContainerRequest struct {
-	Id         string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	BundlePath string   `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
-	Checkpoint string   `protobuf:"bytes,3,opt,name=checkpoint" json:"checkpoint,omitempty"`
-	Stdin      string   `protobuf:"bytes,4,opt,name=stdin" json:"stdin,omitempty"`
-	Stdout     string   `protobuf:"bytes,5,opt,name=stdout" json:"stdout,omitempty"`
-	Stderr     string   `protobuf:"bytes,6,opt,name=stderr" json:"stderr,omitempty"`
-	Labels     []string `protobuf:"bytes,7,rep,name=labels" json:"labels,omitempty"`
+	Id               string            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	BundlePath       string            `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
+	Checkpoint       string            `protobuf:"bytes,3,opt,name=checkpoint" json:"checkpoint,omitempty"`
+	Stdin            string            `protobuf:"bytes,4,opt,name=stdin" json:"stdin,omitempty"`
+	Stdout           string            `protobuf:"bytes,5,opt,name=stdout" json:"stdout,omitempty"`
+	Stderr           string            `protobuf:"bytes,6,opt,name=stderr" json:"stderr,omitempty"`
+	Labels           []string          `protobuf:"bytes,7,rep,name=labels" json:"labels,omitempty"`
+	LogConfig        *LogConfig        `protobuf:"bytes,8,opt,name=logConfig" json:"logConfig,omitempty"`
+	StdinOnce        bool              `protobuf:"varint,9,opt,name=stdinOnce" json:"stdinOnce,omitempty"`
+	StdioSocket      bool              `protobuf:"varint,10,opt,name=stdioSocket" json:"stdioSocket,omitempty"`
+	Numa             *NUMAConfig       `protobuf:"bytes,11,opt,name=numa" json:"numa,omitempty"`
+	CgroupNamespace  bool              `protobuf:"varint,12,opt,name=cgroupNamespace" json:"cgroupNamespace,omitempty"`
+	Group            string            `protobuf:"bytes,13,opt,name=group" json:"group,omitempty"`
+	Volumes          []*Volume         `protobuf:"bytes,14,rep,name=volumes" json:"volumes,omitempty"`
+	Gpus             []string          `protobuf:"bytes,15,rep,name=gpus" json:"gpus,omitempty"`
+	BundleId         string            `protobuf:"bytes,16,opt,name=bundleId" json:"bundleId,omitempty"`
+	Keep             bool              `protobuf:"varint,17,opt,name=keep" json:"keep,omitempty"`
+	AutoRemove       bool              `protobuf:"varint,18,opt,name=autoRemove" json:"autoRemove,omitempty"`
+	NetworkWait      uint32            `protobuf:"varint,19,opt,name=networkWait" json:"networkWait,omitempty"`
+	Init             bool              `protobuf:"varint,20,opt,name=init" json:"init,omitempty"`
+	Template         string            `protobuf:"bytes,21,opt,name=template" json:"template,omitempty"`
+	TemplateEnv      []string          `protobuf:"bytes,22,rep,name=templateEnv" json:"templateEnv,omitempty"`
+	TemplateMounts   []*BindMount      `protobuf:"bytes,23,rep,name=templateMounts" json:"templateMounts,omitempty"`
+	MaxRuntime       uint32            `protobuf:"varint,24,opt,name=maxRuntime" json:"maxRuntime,omitempty"`
+	MaxRuntimeSignal uint32            `protobuf:"varint,25,opt,name=maxRuntimeSignal" json:"maxRuntimeSignal,omitempty"`
+	OomRestart       *OOMRestartPolicy `protobuf:"bytes,26,opt,name=oomRestart" json:"oomRestart,omitempty"`
+	Network          *NetworkConfig    `protobuf:"bytes,27,opt,name=network" json:"network,omitempty"`
+	NetworkNamespace string            `protobuf:"bytes,28,opt,name=networkNamespace" json:"networkNamespace,omitempty"`
 }
 
 func (m *CreateContainerRequest) Reset()                    { *m = CreateContainerRequest{} }
@@ -103,6 +273,102 @@ func (m *CreateContainerRequest) String() string            { return proto.Compa
 func (*CreateContainerRequest) ProtoMessage()               {}
 func (*CreateContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
 
+func (m *CreateContainerRequest) GetLogConfig() *LogConfig {
+	if m != nil {
+		return m.LogConfig
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetNuma() *NUMAConfig {
+	if m != nil {
+		return m.Numa
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetTemplateMounts() []*BindMount {
+	if m != nil {
+		return m.TemplateMounts
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetOomRestart() *OOMRestartPolicy {
+	if m != nil {
+		return m.OomRestart
+	}
+	return nil
+}
+
+func (m *CreateContainerRequest) GetNetwork() *NetworkConfig {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+// Volume is provisioned by a volume driver of the daemon
+type Volume struct {
+	Driver      string            `protobuf:"bytes,1,opt,name=driver" json:"driver,omitempty"`
+	Name        string            `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Destination string            `protobuf:"bytes,3,opt,name=destination" json:"destination,omitempty"`
+	Options     map[string]string `protobuf:"bytes,4,rep,name=options" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ReadOnly    bool              `protobuf:"varint,5,opt,name=readOnly" json:"readOnly,omitempty"`
+	Ephemeral   bool              `protobuf:"varint,6,opt,name=ephemeral" json:"ephemeral,omitempty"`
+}
+
+func (m *Volume) Reset()                    { *m = Volume{} }
+func (m *Volume) String() string            { return proto.CompactTextString(m) }
+func (*Volume) ProtoMessage()               {}
+func (*Volume) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Volume) GetOptions() map[string]string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// NUMAConfig binds the memory of a container's processes to NUMA nodes
+type NUMAConfig struct {
+	Nodes        string `protobuf:"bytes,1,opt,name=nodes" json:"nodes,omitempty"`
+	MemoryPolicy string `protobuf:"bytes,2,opt,name=memoryPolicy" json:"memoryPolicy,omitempty"`
+}
+
+func (m *NUMAConfig) Reset()                    { *m = NUMAConfig{} }
+func (m *NUMAConfig) String() string            { return proto.CompactTextString(m) }
+func (*NUMAConfig) ProtoMessage()               {}
+func (*NUMAConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+// LogConfig configures the log driver used to capture the output of a container's processes
+type LogConfig struct {
+	Driver        string            `protobuf:"bytes,1,opt,name=driver" json:"driver,omitempty"`
+	Options       map[string]string `protobuf:"bytes,2,rep,name=options" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Path          string            `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+	Mode          string            `protobuf:"bytes,4,opt,name=mode" json:"mode,omitempty"`
+	MaxBufferSize uint32            `protobuf:"varint,5,opt,name=maxBufferSize" json:"maxBufferSize,omitempty"`
+}
+
+func (m *LogConfig) Reset()                    { *m = LogConfig{} }
+func (m *LogConfig) String() string            { return proto.CompactTextString(m) }
+func (*LogConfig) ProtoMessage()               {}
+func (*LogConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *LogConfig) GetOptions() map[string]string {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 type CreateContainerResponse struct {
 	Container *Container `protobuf:"bytes,1,opt,name=container" json:"container,omitempty"`
 }
@@ -110,7 +376,7 @@ type CreateContainerResponse struct {
 func (m *CreateContainerResponse) Reset()                    { *m = CreateContainerResponse{} }
 func (m *CreateContainerResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateContainerResponse) ProtoMessage()               {}
-func (*CreateContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*CreateContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *CreateContainerResponse) GetContainer() *Container {
 	if m != nil {
@@ -128,7 +394,7 @@ type SignalRequest struct {
 func (m *SignalRequest) Reset()                    { *m = SignalRequest{} }
 func (m *SignalRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()               {}
-func (*SignalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*SignalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 type SignalResponse struct {
 }
@@ -136,7 +402,7 @@ type SignalResponse struct {
 func (m *SignalResponse) Reset()                    { *m = SignalResponse{} }
 func (m *SignalResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()               {}
-func (*SignalResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*SignalResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 type AddProcessRequest struct {
 	Id              string    `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -154,12 +420,13 @@ type AddProcessRequest struct {
 	SelinuxLabel    string    `protobuf:"bytes,13,opt,name=selinuxLabel" json:"selinuxLabel,omitempty"`
 	NoNewPrivileges bool      `protobuf:"varint,14,opt,name=noNewPrivileges" json:"noNewPrivileges,omitempty"`
 	Rlimits         []*Rlimit `protobuf:"bytes,15,rep,name=rlimits" json:"rlimits,omitempty"`
+	StdioSocket     bool      `protobuf:"varint,16,opt,name=stdioSocket" json:"stdioSocket,omitempty"`
 }
 
 func (m *AddProcessRequest) Reset()                    { *m = AddProcessRequest{} }
 func (m *AddProcessRequest) String() string            { return proto.CompactTextString(m) }
 func (*AddProcessRequest) ProtoMessage()               {}
-func (*AddProcessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*AddProcessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *AddProcessRequest) GetUser() *User {
 	if m != nil {
@@ -184,7 +451,7 @@ type Rlimit struct {
 func (m *Rlimit) Reset()                    { *m = Rlimit{} }
 func (m *Rlimit) String() string            { return proto.CompactTextString(m) }
 func (*Rlimit) ProtoMessage()               {}
-func (*Rlimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Rlimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 type User struct {
 	Uid            uint32   `protobuf:"varint,1,opt,name=uid" json:"uid,omitempty"`
@@ -195,7 +462,7 @@ type User struct {
 func (m *User) Reset()                    { *m = User{} }
 func (m *User) String() string            { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()               {}
-func (*User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 type AddProcessResponse struct {
 }
@@ -203,7 +470,7 @@ type AddProcessResponse struct {
 func (m *AddProcessResponse) Reset()                    { *m = AddProcessResponse{} }
 func (m *AddProcessResponse) String() string            { return proto.CompactTextString(m) }
 func (*AddProcessResponse) ProtoMessage()               {}
-func (*AddProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*AddProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 type CreateCheckpointRequest struct {
 	Id         string      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -213,7 +480,7 @@ type CreateCheckpointRequest struct {
 func (m *CreateCheckpointRequest) Reset()                    { *m = CreateCheckpointRequest{} }
 func (m *CreateCheckpointRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateCheckpointRequest) ProtoMessage()               {}
-func (*CreateCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*CreateCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *CreateCheckpointRequest) GetCheckpoint() *Checkpoint {
 	if m != nil {
@@ -228,7 +495,7 @@ type CreateCheckpointResponse struct {
 func (m *CreateCheckpointResponse) Reset()                    { *m = CreateCheckpointResponse{} }
 func (m *CreateCheckpointResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateCheckpointResponse) ProtoMessage()               {}
-func (*CreateCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*CreateCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 type DeleteCheckpointRequest struct {
 	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -238,7 +505,7 @@ type DeleteCheckpointRequest struct {
 func (m *DeleteCheckpointRequest) Reset()                    { *m = DeleteCheckpointRequest{} }
 func (m *DeleteCheckpointRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCheckpointRequest) ProtoMessage()               {}
-func (*DeleteCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DeleteCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 type DeleteCheckpointResponse struct {
 }
@@ -246,7 +513,7 @@ type DeleteCheckpointResponse struct {
 func (m *DeleteCheckpointResponse) Reset()                    { *m = DeleteCheckpointResponse{} }
 func (m *DeleteCheckpointResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCheckpointResponse) ProtoMessage()               {}
-func (*DeleteCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DeleteCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 type ListCheckpointRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -255,7 +522,7 @@ type ListCheckpointRequest struct {
 func (m *ListCheckpointRequest) Reset()                    { *m = ListCheckpointRequest{} }
 func (m *ListCheckpointRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListCheckpointRequest) ProtoMessage()               {}
-func (*ListCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ListCheckpointRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 type Checkpoint struct {
 	Name        string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -268,7 +535,7 @@ type Checkpoint struct {
 func (m *Checkpoint) Reset()                    { *m = Checkpoint{} }
 func (m *Checkpoint) String() string            { return proto.CompactTextString(m) }
 func (*Checkpoint) ProtoMessage()               {}
-func (*Checkpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Checkpoint) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 type ListCheckpointResponse struct {
 	Checkpoints []*Checkpoint `protobuf:"bytes,1,rep,name=checkpoints" json:"checkpoints,omitempty"`
@@ -277,7 +544,7 @@ type ListCheckpointResponse struct {
 func (m *ListCheckpointResponse) Reset()                    { *m = ListCheckpointResponse{} }
 func (m *ListCheckpointResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListCheckpointResponse) ProtoMessage()               {}
-func (*ListCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*ListCheckpointResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *ListCheckpointResponse) GetCheckpoints() []*Checkpoint {
 	if m != nil {
@@ -287,13 +554,16 @@ func (m *ListCheckpointResponse) GetCheckpoints() []*Checkpoint {
 }
 
 type StateRequest struct {
-	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Summary   bool   `protobuf:"varint,2,opt,name=summary" json:"summary,omitempty"`
+	Limit     uint32 `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
+	PageToken string `protobuf:"bytes,4,opt,name=pageToken" json:"pageToken,omitempty"`
 }
 
 func (m *StateRequest) Reset()                    { *m = StateRequest{} }
 func (m *StateRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateRequest) ProtoMessage()               {}
-func (*StateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*StateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 type ContainerState struct {
 	Status string `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
@@ -302,7 +572,7 @@ type ContainerState struct {
 func (m *ContainerState) Reset()                    { *m = ContainerState{} }
 func (m *ContainerState) String() string            { return proto.CompactTextString(m) }
 func (*ContainerState) ProtoMessage()               {}
-func (*ContainerState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ContainerState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 type Process struct {
 	Pid             string    `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
@@ -320,12 +590,13 @@ type Process struct {
 	SelinuxLabel    string    `protobuf:"bytes,13,opt,name=selinuxLabel" json:"selinuxLabel,omitempty"`
 	NoNewPrivileges bool      `protobuf:"varint,14,opt,name=noNewPrivileges" json:"noNewPrivileges,omitempty"`
 	Rlimits         []*Rlimit `protobuf:"bytes,15,rep,name=rlimits" json:"rlimits,omitempty"`
+	StartedAt       uint64    `protobuf:"varint,16,opt,name=startedAt" json:"startedAt,omitempty"`
 }
 
 func (m *Process) Reset()                    { *m = Process{} }
 func (m *Process) String() string            { return proto.CompactTextString(m) }
 func (*Process) ProtoMessage()               {}
-func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *Process) GetUser() *User {
 	if m != nil {
@@ -342,19 +613,25 @@ func (m *Process) GetRlimits() []*Rlimit {
 }
 
 type Container struct {
-	Id         string     `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	BundlePath string     `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
-	Processes  []*Process `protobuf:"bytes,3,rep,name=processes" json:"processes,omitempty"`
-	Status     string     `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
-	Labels     []string   `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty"`
-	Pids       []uint32   `protobuf:"varint,6,rep,name=pids" json:"pids,omitempty"`
-	Runtime    string     `protobuf:"bytes,7,opt,name=runtime" json:"runtime,omitempty"`
+	Id         string              `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	BundlePath string              `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
+	Processes  []*Process          `protobuf:"bytes,3,rep,name=processes" json:"processes,omitempty"`
+	Status     string              `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
+	Labels     []string            `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty"`
+	Pids       []uint32            `protobuf:"varint,6,rep,name=pids" json:"pids,omitempty"`
+	Runtime    string              `protobuf:"bytes,7,opt,name=runtime" json:"runtime,omitempty"`
+	LogConfig  *LogConfig          `protobuf:"bytes,8,opt,name=logConfig" json:"logConfig,omitempty"`
+	StdinOnce  bool                `protobuf:"varint,9,opt,name=stdinOnce" json:"stdinOnce,omitempty"`
+	Numa       *NUMAConfig         `protobuf:"bytes,10,opt,name=numa" json:"numa,omitempty"`
+	Lifecycle  *ContainerLifecycle `protobuf:"bytes,11,opt,name=lifecycle" json:"lifecycle,omitempty"`
+	Addresses  []string            `protobuf:"bytes,12,rep,name=addresses" json:"addresses,omitempty"`
+	Network    *NetworkConfig      `protobuf:"bytes,13,opt,name=network" json:"network,omitempty"`
 }
 
 func (m *Container) Reset()                    { *m = Container{} }
 func (m *Container) String() string            { return proto.CompactTextString(m) }
 func (*Container) ProtoMessage()               {}
-func (*Container) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Container) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *Container) GetProcesses() []*Process {
 	if m != nil {
@@ -363,27 +640,87 @@ func (m *Container) GetProcesses() []*Process {
 	return nil
 }
 
+func (m *Container) GetLogConfig() *LogConfig {
+	if m != nil {
+		return m.LogConfig
+	}
+	return nil
+}
+
+func (m *Container) GetNuma() *NUMAConfig {
+	if m != nil {
+		return m.Numa
+	}
+	return nil
+}
+
+func (m *Container) GetLifecycle() *ContainerLifecycle {
+	if m != nil {
+		return m.Lifecycle
+	}
+	return nil
+}
+
+func (m *Container) GetNetwork() *NetworkConfig {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+// ContainerLifecycle is the history of a container's lifecycle states
+type ContainerLifecycle struct {
+	State              string             `protobuf:"bytes,1,opt,name=state" json:"state,omitempty"`
+	Transitions        []*StateTransition `protobuf:"bytes,2,rep,name=transitions" json:"transitions,omitempty"`
+	LastError          string             `protobuf:"bytes,3,opt,name=lastError" json:"lastError,omitempty"`
+	LastErrorTimestamp uint64             `protobuf:"varint,4,opt,name=lastErrorTimestamp" json:"lastErrorTimestamp,omitempty"`
+}
+
+func (m *ContainerLifecycle) Reset()                    { *m = ContainerLifecycle{} }
+func (m *ContainerLifecycle) String() string            { return proto.CompactTextString(m) }
+func (*ContainerLifecycle) ProtoMessage()               {}
+func (*ContainerLifecycle) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ContainerLifecycle) GetTransitions() []*StateTransition {
+	if m != nil {
+		return m.Transitions
+	}
+	return nil
+}
+
+type StateTransition struct {
+	State     string `protobuf:"bytes,1,opt,name=state" json:"state,omitempty"`
+	Timestamp uint64 `protobuf:"varint,2,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *StateTransition) Reset()                    { *m = StateTransition{} }
+func (m *StateTransition) String() string            { return proto.CompactTextString(m) }
+func (*StateTransition) ProtoMessage()               {}
+func (*StateTransition) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
 // Machine is information about machine on which containerd is run
 type Machine struct {
-	Cpus   uint32 `protobuf:"varint,1,opt,name=cpus" json:"cpus,omitempty"`
-	Memory uint64 `protobuf:"varint,2,opt,name=memory" json:"memory,omitempty"`
+	Cpus   uint32   `protobuf:"varint,1,opt,name=cpus" json:"cpus,omitempty"`
+	Memory uint64   `protobuf:"varint,2,opt,name=memory" json:"memory,omitempty"`
+	Gpus   []string `protobuf:"bytes,3,rep,name=gpus" json:"gpus,omitempty"`
 }
 
 func (m *Machine) Reset()                    { *m = Machine{} }
 func (m *Machine) String() string            { return proto.CompactTextString(m) }
 func (*Machine) ProtoMessage()               {}
-func (*Machine) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*Machine) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 // StateResponse is information about containerd daemon
 type StateResponse struct {
-	Containers []*Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
-	Machine    *Machine     `protobuf:"bytes,2,opt,name=machine" json:"machine,omitempty"`
+	Containers    []*Container `protobuf:"bytes,1,rep,name=containers" json:"containers,omitempty"`
+	Machine       *Machine     `protobuf:"bytes,2,opt,name=machine" json:"machine,omitempty"`
+	NextPageToken string       `protobuf:"bytes,3,opt,name=nextPageToken" json:"nextPageToken,omitempty"`
 }
 
 func (m *StateResponse) Reset()                    { *m = StateResponse{} }
 func (m *StateResponse) String() string            { return proto.CompactTextString(m) }
 func (*StateResponse) ProtoMessage()               {}
-func (*StateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*StateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *StateResponse) GetContainers() []*Container {
 	if m != nil {
@@ -409,7 +746,7 @@ type UpdateContainerRequest struct {
 func (m *UpdateContainerRequest) Reset()                    { *m = UpdateContainerRequest{} }
 func (m *UpdateContainerRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateContainerRequest) ProtoMessage()               {}
-func (*UpdateContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*UpdateContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *UpdateContainerRequest) GetResources() *UpdateResource {
 	if m != nil {
@@ -419,22 +756,104 @@ func (m *UpdateContainerRequest) GetResources() *UpdateResource {
 }
 
 type UpdateResource struct {
-	BlkioWeight       uint32 `protobuf:"varint,1,opt,name=blkioWeight" json:"blkioWeight,omitempty"`
-	CpuShares         uint32 `protobuf:"varint,2,opt,name=cpuShares" json:"cpuShares,omitempty"`
-	CpuPeriod         uint32 `protobuf:"varint,3,opt,name=cpuPeriod" json:"cpuPeriod,omitempty"`
-	CpuQuota          uint32 `protobuf:"varint,4,opt,name=cpuQuota" json:"cpuQuota,omitempty"`
-	CpusetCpus        string `protobuf:"bytes,5,opt,name=cpusetCpus" json:"cpusetCpus,omitempty"`
-	CpusetMems        string `protobuf:"bytes,6,opt,name=cpusetMems" json:"cpusetMems,omitempty"`
-	MemoryLimit       uint32 `protobuf:"varint,7,opt,name=memoryLimit" json:"memoryLimit,omitempty"`
-	MemorySwap        uint32 `protobuf:"varint,8,opt,name=memorySwap" json:"memorySwap,omitempty"`
-	MemoryReservation uint32 `protobuf:"varint,9,opt,name=memoryReservation" json:"memoryReservation,omitempty"`
-	KernelMemoryLimit uint32 `protobuf:"varint,10,opt,name=kernelMemoryLimit" json:"kernelMemoryLimit,omitempty"`
+	BlkioWeight                  uint32            `protobuf:"varint,1,opt,name=blkioWeight" json:"blkioWeight,omitempty"`
+	CpuShares                    uint32            `protobuf:"varint,2,opt,name=cpuShares" json:"cpuShares,omitempty"`
+	CpuPeriod                    uint32            `protobuf:"varint,3,opt,name=cpuPeriod" json:"cpuPeriod,omitempty"`
+	CpuQuota                     uint32            `protobuf:"varint,4,opt,name=cpuQuota" json:"cpuQuota,omitempty"`
+	CpusetCpus                   string            `protobuf:"bytes,5,opt,name=cpusetCpus" json:"cpusetCpus,omitempty"`
+	CpusetMems                   string            `protobuf:"bytes,6,opt,name=cpusetMems" json:"cpusetMems,omitempty"`
+	MemoryLimit                  uint32            `protobuf:"varint,7,opt,name=memoryLimit" json:"memoryLimit,omitempty"`
+	MemorySwap                   uint32            `protobuf:"varint,8,opt,name=memorySwap" json:"memorySwap,omitempty"`
+	MemoryReservation            uint32            `protobuf:"varint,9,opt,name=memoryReservation" json:"memoryReservation,omitempty"`
+	KernelMemoryLimit            uint32            `protobuf:"varint,10,opt,name=kernelMemoryLimit" json:"kernelMemoryLimit,omitempty"`
+	CpuRealtimeRuntime           uint32            `protobuf:"varint,11,opt,name=cpuRealtimeRuntime" json:"cpuRealtimeRuntime,omitempty"`
+	CpuRealtimePeriod            uint32            `protobuf:"varint,12,opt,name=cpuRealtimePeriod" json:"cpuRealtimePeriod,omitempty"`
+	PidsLimit                    int64             `protobuf:"varint,13,opt,name=pidsLimit" json:"pidsLimit,omitempty"`
+	MemorySwappiness             *MemorySwappiness `protobuf:"bytes,14,opt,name=memorySwappiness" json:"memorySwappiness,omitempty"`
+	BlkioWeightDevice            []*WeightDevice   `protobuf:"bytes,15,rep,name=blkioWeightDevice" json:"blkioWeightDevice,omitempty"`
+	BlkioThrottleReadBpsDevice   []*ThrottleDevice `protobuf:"bytes,16,rep,name=blkioThrottleReadBpsDevice" json:"blkioThrottleReadBpsDevice,omitempty"`
+	BlkioThrottleWriteBpsDevice  []*ThrottleDevice `protobuf:"bytes,17,rep,name=blkioThrottleWriteBpsDevice" json:"blkioThrottleWriteBpsDevice,omitempty"`
+	BlkioThrottleReadIopsDevice  []*ThrottleDevice `protobuf:"bytes,18,rep,name=blkioThrottleReadIopsDevice" json:"blkioThrottleReadIopsDevice,omitempty"`
+	BlkioThrottleWriteIopsDevice []*ThrottleDevice `protobuf:"bytes,19,rep,name=blkioThrottleWriteIopsDevice" json:"blkioThrottleWriteIopsDevice,omitempty"`
 }
 
 func (m *UpdateResource) Reset()                    { *m = UpdateResource{} }
 func (m *UpdateResource) String() string            { return proto.CompactTextString(m) }
 func (*UpdateResource) ProtoMessage()               {}
-func (*UpdateResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*UpdateResource) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *UpdateResource) GetMemorySwappiness() *MemorySwappiness {
+	if m != nil {
+		return m.MemorySwappiness
+	}
+	return nil
+}
+
+func (m *UpdateResource) GetBlkioWeightDevice() []*WeightDevice {
+	if m != nil {
+		return m.BlkioWeightDevice
+	}
+	return nil
+}
+
+func (m *UpdateResource) GetBlkioThrottleReadBpsDevice() []*ThrottleDevice {
+	if m != nil {
+		return m.BlkioThrottleReadBpsDevice
+	}
+	return nil
+}
+
+func (m *UpdateResource) GetBlkioThrottleWriteBpsDevice() []*ThrottleDevice {
+	if m != nil {
+		return m.BlkioThrottleWriteBpsDevice
+	}
+	return nil
+}
+
+func (m *UpdateResource) GetBlkioThrottleReadIopsDevice() []*ThrottleDevice {
+	if m != nil {
+		return m.BlkioThrottleReadIopsDevice
+	}
+	return nil
+}
+
+func (m *UpdateResource) GetBlkioThrottleWriteIopsDevice() []*ThrottleDevice {
+	if m != nil {
+		return m.BlkioThrottleWriteIopsDevice
+	}
+	return nil
+}
+
+// WeightDevice is the blkio weight of a host block device
+type WeightDevice struct {
+	Path   string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Weight uint32 `protobuf:"varint,2,opt,name=weight" json:"weight,omitempty"`
+}
+
+func (m *WeightDevice) Reset()                    { *m = WeightDevice{} }
+func (m *WeightDevice) String() string            { return proto.CompactTextString(m) }
+func (*WeightDevice) ProtoMessage()               {}
+func (*WeightDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+// ThrottleDevice is the blkio throttle of a host block device
+type ThrottleDevice struct {
+	Path string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Rate uint64 `protobuf:"varint,2,opt,name=rate" json:"rate,omitempty"`
+}
+
+func (m *ThrottleDevice) Reset()                    { *m = ThrottleDevice{} }
+func (m *ThrottleDevice) String() string            { return proto.CompactTextString(m) }
+func (*ThrottleDevice) ProtoMessage()               {}
+func (*ThrottleDevice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+type MemorySwappiness struct {
+	Value uint64 `protobuf:"varint,1,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *MemorySwappiness) Reset()                    { *m = MemorySwappiness{} }
+func (m *MemorySwappiness) String() string            { return proto.CompactTextString(m) }
+func (*MemorySwappiness) ProtoMessage()               {}
+func (*MemorySwappiness) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 type UpdateContainerResponse struct {
 }
@@ -442,29 +861,35 @@ type UpdateContainerResponse struct {
 func (m *UpdateContainerResponse) Reset()                    { *m = UpdateContainerResponse{} }
 func (m *UpdateContainerResponse) String() string            { return proto.CompactTextString(m) }
 func (*UpdateContainerResponse) ProtoMessage()               {}
-func (*UpdateContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*UpdateContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 type EventsRequest struct {
-	Timestamp uint64 `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Timestamp      uint64 `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	OverflowPolicy string `protobuf:"bytes,2,opt,name=overflowPolicy" json:"overflowPolicy,omitempty"`
+	AfterSeq       uint64 `protobuf:"varint,3,opt,name=afterSeq" json:"afterSeq,omitempty"`
 }
 
 func (m *EventsRequest) Reset()                    { *m = EventsRequest{} }
 func (m *EventsRequest) String() string            { return proto.CompactTextString(m) }
 func (*EventsRequest) ProtoMessage()               {}
-func (*EventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*EventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
 
 type Event struct {
-	Type      string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
-	Id        string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
-	Status    uint32 `protobuf:"varint,3,opt,name=status" json:"status,omitempty"`
-	Pid       string `protobuf:"bytes,4,opt,name=pid" json:"pid,omitempty"`
-	Timestamp uint64 `protobuf:"varint,5,opt,name=timestamp" json:"timestamp,omitempty"`
+	Type      string   `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Id        string   `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Status    uint32   `protobuf:"varint,3,opt,name=status" json:"status,omitempty"`
+	Pid       string   `protobuf:"bytes,4,opt,name=pid" json:"pid,omitempty"`
+	Timestamp uint64   `protobuf:"varint,5,opt,name=timestamp" json:"timestamp,omitempty"`
+	Level     string   `protobuf:"bytes,6,opt,name=level" json:"level,omitempty"`
+	Seq       uint64   `protobuf:"varint,7,opt,name=seq" json:"seq,omitempty"`
+	Reason    string   `protobuf:"bytes,8,opt,name=reason" json:"reason,omitempty"`
+	Addresses []string `protobuf:"bytes,9,rep,name=addresses" json:"addresses,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
 func (m *Event) String() string            { return proto.CompactTextString(m) }
 func (*Event) ProtoMessage()               {}
-func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
 
 type NetworkStats struct {
 	Name       string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -481,7 +906,7 @@ type NetworkStats struct {
 func (m *NetworkStats) Reset()                    { *m = NetworkStats{} }
 func (m *NetworkStats) String() string            { return proto.CompactTextString(m) }
 func (*NetworkStats) ProtoMessage()               {}
-func (*NetworkStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*NetworkStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
 
 type CpuUsage struct {
 	TotalUsage        uint64   `protobuf:"varint,1,opt,name=total_usage" json:"total_usage,omitempty"`
@@ -493,7 +918,7 @@ type CpuUsage struct {
 func (m *CpuUsage) Reset()                    { *m = CpuUsage{} }
 func (m *CpuUsage) String() string            { return proto.CompactTextString(m) }
 func (*CpuUsage) ProtoMessage()               {}
-func (*CpuUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*CpuUsage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
 
 type ThrottlingData struct {
 	Periods          uint64 `protobuf:"varint,1,opt,name=periods" json:"periods,omitempty"`
@@ -504,7 +929,7 @@ type ThrottlingData struct {
 func (m *ThrottlingData) Reset()                    { *m = ThrottlingData{} }
 func (m *ThrottlingData) String() string            { return proto.CompactTextString(m) }
 func (*ThrottlingData) ProtoMessage()               {}
-func (*ThrottlingData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*ThrottlingData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 type CpuStats struct {
 	CpuUsage       *CpuUsage       `protobuf:"bytes,1,opt,name=cpu_usage" json:"cpu_usage,omitempty"`
@@ -515,7 +940,7 @@ type CpuStats struct {
 func (m *CpuStats) Reset()                    { *m = CpuStats{} }
 func (m *CpuStats) String() string            { return proto.CompactTextString(m) }
 func (*CpuStats) ProtoMessage()               {}
-func (*CpuStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*CpuStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *CpuStats) GetCpuUsage() *CpuUsage {
 	if m != nil {
@@ -533,12 +958,13 @@ func (m *CpuStats) GetThrottlingData() *ThrottlingData {
 
 type PidsStats struct {
 	Current uint64 `protobuf:"varint,1,opt,name=current" json:"current,omitempty"`
+	Limit   uint64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
 }
 
 func (m *PidsStats) Reset()                    { *m = PidsStats{} }
 func (m *PidsStats) String() string            { return proto.CompactTextString(m) }
 func (*PidsStats) ProtoMessage()               {}
-func (*PidsStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*PidsStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 type MemoryData struct {
 	Usage    uint64 `protobuf:"varint,1,opt,name=usage" json:"usage,omitempty"`
@@ -550,7 +976,7 @@ type MemoryData struct {
 func (m *MemoryData) Reset()                    { *m = MemoryData{} }
 func (m *MemoryData) String() string            { return proto.CompactTextString(m) }
 func (*MemoryData) ProtoMessage()               {}
-func (*MemoryData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+func (*MemoryData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 type MemoryStats struct {
 	Cache       uint64            `protobuf:"varint,1,opt,name=cache" json:"cache,omitempty"`
@@ -558,12 +984,14 @@ type MemoryStats struct {
 	SwapUsage   *MemoryData       `protobuf:"bytes,3,opt,name=swap_usage" json:"swap_usage,omitempty"`
 	KernelUsage *MemoryData       `protobuf:"bytes,4,opt,name=kernel_usage" json:"kernel_usage,omitempty"`
 	Stats       map[string]uint64 `protobuf:"bytes,5,rep,name=stats" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	NumaStats   []*NUMAStats      `protobuf:"bytes,6,rep,name=numa_stats" json:"numa_stats,omitempty"`
+	Swappiness  uint64            `protobuf:"varint,7,opt,name=swappiness" json:"swappiness,omitempty"`
 }
 
 func (m *MemoryStats) Reset()                    { *m = MemoryStats{} }
 func (m *MemoryStats) String() string            { return proto.CompactTextString(m) }
 func (*MemoryStats) ProtoMessage()               {}
-func (*MemoryStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+func (*MemoryStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
 
 func (m *MemoryStats) GetUsage() *MemoryData {
 	if m != nil {
@@ -593,6 +1021,27 @@ func (m *MemoryStats) GetStats() map[string]uint64 {
 	return nil
 }
 
+func (m *MemoryStats) GetNumaStats() []*NUMAStats {
+	if m != nil {
+		return m.NumaStats
+	}
+	return nil
+}
+
+// NUMAStats is the memory of a container allocated on a NUMA node in bytes
+type NUMAStats struct {
+	Node        uint32 `protobuf:"varint,1,opt,name=node" json:"node,omitempty"`
+	Total       uint64 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
+	File        uint64 `protobuf:"varint,3,opt,name=file" json:"file,omitempty"`
+	Anon        uint64 `protobuf:"varint,4,opt,name=anon" json:"anon,omitempty"`
+	Unevictable uint64 `protobuf:"varint,5,opt,name=unevictable" json:"unevictable,omitempty"`
+}
+
+func (m *NUMAStats) Reset()                    { *m = NUMAStats{} }
+func (m *NUMAStats) String() string            { return proto.CompactTextString(m) }
+func (*NUMAStats) ProtoMessage()               {}
+func (*NUMAStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
 type BlkioStatsEntry struct {
 	Major uint64 `protobuf:"varint,1,opt,name=major" json:"major,omitempty"`
 	Minor uint64 `protobuf:"varint,2,opt,name=minor" json:"minor,omitempty"`
@@ -603,7 +1052,7 @@ type BlkioStatsEntry struct {
 func (m *BlkioStatsEntry) Reset()                    { *m = BlkioStatsEntry{} }
 func (m *BlkioStatsEntry) String() string            { return proto.CompactTextString(m) }
 func (*BlkioStatsEntry) ProtoMessage()               {}
-func (*BlkioStatsEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*BlkioStatsEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
 
 type BlkioStats struct {
 	IoServiceBytesRecursive []*BlkioStatsEntry `protobuf:"bytes,1,rep,name=io_service_bytes_recursive" json:"io_service_bytes_recursive,omitempty"`
@@ -619,7 +1068,7 @@ type BlkioStats struct {
 func (m *BlkioStats) Reset()                    { *m = BlkioStats{} }
 func (m *BlkioStats) String() string            { return proto.CompactTextString(m) }
 func (*BlkioStats) ProtoMessage()               {}
-func (*BlkioStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+func (*BlkioStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
 
 func (m *BlkioStats) GetIoServiceBytesRecursive() []*BlkioStatsEntry {
 	if m != nil {
@@ -687,7 +1136,7 @@ type HugetlbStats struct {
 func (m *HugetlbStats) Reset()                    { *m = HugetlbStats{} }
 func (m *HugetlbStats) String() string            { return proto.CompactTextString(m) }
 func (*HugetlbStats) ProtoMessage()               {}
-func (*HugetlbStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*HugetlbStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
 
 type CgroupStats struct {
 	CpuStats     *CpuStats                `protobuf:"bytes,1,opt,name=cpu_stats" json:"cpu_stats,omitempty"`
@@ -700,7 +1149,7 @@ type CgroupStats struct {
 func (m *CgroupStats) Reset()                    { *m = CgroupStats{} }
 func (m *CgroupStats) String() string            { return proto.CompactTextString(m) }
 func (*CgroupStats) ProtoMessage()               {}
-func (*CgroupStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*CgroupStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *CgroupStats) GetCpuStats() *CpuStats {
 	if m != nil {
@@ -746,7 +1195,7 @@ type StatsResponse struct {
 func (m *StatsResponse) Reset()                    { *m = StatsResponse{} }
 func (m *StatsResponse) String() string            { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()               {}
-func (*StatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*StatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
 
 func (m *StatsResponse) GetNetworkStats() []*NetworkStats {
 	if m != nil {
@@ -769,135 +1218,1410 @@ type StatsRequest struct {
 func (m *StatsRequest) Reset()                    { *m = StatsRequest{} }
 func (m *StatsRequest) String() string            { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()               {}
-func (*StatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*StatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
-func init() {
-	proto.RegisterType((*UpdateProcessRequest)(nil), "types.UpdateProcessRequest")
-	proto.RegisterType((*UpdateProcessResponse)(nil), "types.UpdateProcessResponse")
-	proto.RegisterType((*CreateContainerRequest)(nil), "types.CreateContainerRequest")
-	proto.RegisterType((*CreateContainerResponse)(nil), "types.CreateContainerResponse")
-	proto.RegisterType((*SignalRequest)(nil), "types.SignalRequest")
-	proto.RegisterType((*SignalResponse)(nil), "types.SignalResponse")
-	proto.RegisterType((*AddProcessRequest)(nil), "types.AddProcessRequest")
-	proto.RegisterType((*Rlimit)(nil), "types.Rlimit")
-	proto.RegisterType((*User)(nil), "types.User")
-	proto.RegisterType((*AddProcessResponse)(nil), "types.AddProcessResponse")
-	proto.RegisterType((*CreateCheckpointRequest)(nil), "types.CreateCheckpointRequest")
-	proto.RegisterType((*CreateCheckpointResponse)(nil), "types.CreateCheckpointResponse")
-	proto.RegisterType((*DeleteCheckpointRequest)(nil), "types.DeleteCheckpointRequest")
-	proto.RegisterType((*DeleteCheckpointResponse)(nil), "types.DeleteCheckpointResponse")
-	proto.RegisterType((*ListCheckpointRequest)(nil), "types.ListCheckpointRequest")
-	proto.RegisterType((*Checkpoint)(nil), "types.Checkpoint")
-	proto.RegisterType((*ListCheckpointResponse)(nil), "types.ListCheckpointResponse")
-	proto.RegisterType((*StateRequest)(nil), "types.StateRequest")
-	proto.RegisterType((*ContainerState)(nil), "types.ContainerState")
-	proto.RegisterType((*Process)(nil), "types.Process")
-	proto.RegisterType((*Container)(nil), "types.Container")
-	proto.RegisterType((*Machine)(nil), "types.Machine")
-	proto.RegisterType((*StateResponse)(nil), "types.StateResponse")
-	proto.RegisterType((*UpdateContainerRequest)(nil), "types.UpdateContainerRequest")
-	proto.RegisterType((*UpdateResource)(nil), "types.UpdateResource")
-	proto.RegisterType((*UpdateContainerResponse)(nil), "types.UpdateContainerResponse")
-	proto.RegisterType((*EventsRequest)(nil), "types.EventsRequest")
-	proto.RegisterType((*Event)(nil), "types.Event")
-	proto.RegisterType((*NetworkStats)(nil), "types.NetworkStats")
-	proto.RegisterType((*CpuUsage)(nil), "types.CpuUsage")
-	proto.RegisterType((*ThrottlingData)(nil), "types.ThrottlingData")
-	proto.RegisterType((*CpuStats)(nil), "types.CpuStats")
-	proto.RegisterType((*PidsStats)(nil), "types.PidsStats")
-	proto.RegisterType((*MemoryData)(nil), "types.MemoryData")
-	proto.RegisterType((*MemoryStats)(nil), "types.MemoryStats")
-	proto.RegisterType((*BlkioStatsEntry)(nil), "types.BlkioStatsEntry")
-	proto.RegisterType((*BlkioStats)(nil), "types.BlkioStats")
-	proto.RegisterType((*HugetlbStats)(nil), "types.HugetlbStats")
-	proto.RegisterType((*CgroupStats)(nil), "types.CgroupStats")
-	proto.RegisterType((*StatsResponse)(nil), "types.StatsResponse")
-	proto.RegisterType((*StatsRequest)(nil), "types.StatsRequest")
+type CopyFromContainerRequest struct {
+	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *CopyFromContainerRequest) Reset()                    { *m = CopyFromContainerRequest{} }
+func (m *CopyFromContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyFromContainerRequest) ProtoMessage()               {}
+func (*CopyFromContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
-// Client API for API service
+type CopyChunk struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
 
-type APIClient interface {
-	CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error)
-	UpdateContainer(ctx context.Context, in *UpdateContainerRequest, opts ...grpc.CallOption) (*UpdateContainerResponse, error)
-	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
-	UpdateProcess(ctx context.Context, in *UpdateProcessRequest, opts ...grpc.CallOption) (*UpdateProcessResponse, error)
-	AddProcess(ctx context.Context, in *AddProcessRequest, opts ...grpc.CallOption) (*AddProcessResponse, error)
-	CreateCheckpoint(ctx context.Context, in *CreateCheckpointRequest, opts ...grpc.CallOption) (*CreateCheckpointResponse, error)
-	DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error)
-	ListCheckpoint(ctx context.Context, in *ListCheckpointRequest, opts ...grpc.CallOption) (*ListCheckpointResponse, error)
-	State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error)
-	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (API_EventsClient, error)
-	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+func (m *CopyChunk) Reset()                    { *m = CopyChunk{} }
+func (m *CopyChunk) String() string            { return proto.CompactTextString(m) }
+func (*CopyChunk) ProtoMessage()               {}
+func (*CopyChunk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+type CopyToContainerRequest struct {
+	Id   string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-type aPIClient struct {
-	cc *grpc.ClientConn
+func (m *CopyToContainerRequest) Reset()                    { *m = CopyToContainerRequest{} }
+func (m *CopyToContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyToContainerRequest) ProtoMessage()               {}
+func (*CopyToContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type CopyToContainerResponse struct {
 }
 
-func NewAPIClient(cc *grpc.ClientConn) APIClient {
-	return &aPIClient{cc}
+func (m *CopyToContainerResponse) Reset()                    { *m = CopyToContainerResponse{} }
+func (m *CopyToContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*CopyToContainerResponse) ProtoMessage()               {}
+func (*CopyToContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+type WaitRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Pid string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
 }
 
-func (c *aPIClient) CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error) {
-	out := new(CreateContainerResponse)
-	err := grpc.Invoke(ctx, "/types.API/CreateContainer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *WaitRequest) Reset()                    { *m = WaitRequest{} }
+func (m *WaitRequest) String() string            { return proto.CompactTextString(m) }
+func (*WaitRequest) ProtoMessage()               {}
+func (*WaitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+type WaitResponse struct {
+	Status uint32 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
 }
 
-func (c *aPIClient) UpdateContainer(ctx context.Context, in *UpdateContainerRequest, opts ...grpc.CallOption) (*UpdateContainerResponse, error) {
-	out := new(UpdateContainerResponse)
-	err := grpc.Invoke(ctx, "/types.API/UpdateContainer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *WaitResponse) Reset()                    { *m = WaitResponse{} }
+func (m *WaitResponse) String() string            { return proto.CompactTextString(m) }
+func (*WaitResponse) ProtoMessage()               {}
+func (*WaitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+// AttachRequest is sent by the client to attach to a process.  The first
+// request selects the process and the amount of output to replay, following
+// requests carry data to write to the process' stdin.
+type AttachRequest struct {
+	Id         string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Pid        string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
+	Replay     uint32 `protobuf:"varint,3,opt,name=replay" json:"replay,omitempty"`
+	Stdin      []byte `protobuf:"bytes,4,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	CloseStdin bool   `protobuf:"varint,5,opt,name=closeStdin" json:"closeStdin,omitempty"`
 }
 
-func (c *aPIClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error) {
-	out := new(SignalResponse)
-	err := grpc.Invoke(ctx, "/types.API/Signal", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *AttachRequest) Reset()                    { *m = AttachRequest{} }
+func (m *AttachRequest) String() string            { return proto.CompactTextString(m) }
+func (*AttachRequest) ProtoMessage()               {}
+func (*AttachRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+type AttachResponse struct {
+	Stream uint32 `protobuf:"varint,1,opt,name=stream" json:"stream,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (c *aPIClient) UpdateProcess(ctx context.Context, in *UpdateProcessRequest, opts ...grpc.CallOption) (*UpdateProcessResponse, error) {
-	out := new(UpdateProcessResponse)
-	err := grpc.Invoke(ctx, "/types.API/UpdateProcess", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *AttachResponse) Reset()                    { *m = AttachResponse{} }
+func (m *AttachResponse) String() string            { return proto.CompactTextString(m) }
+func (*AttachResponse) ProtoMessage()               {}
+func (*AttachResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+type GetLogsRequest struct {
+	Id     string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Pid    string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
+	Tail   uint32 `protobuf:"varint,3,opt,name=tail" json:"tail,omitempty"`
+	Since  uint64 `protobuf:"varint,4,opt,name=since" json:"since,omitempty"`
+	Follow bool   `protobuf:"varint,5,opt,name=follow" json:"follow,omitempty"`
 }
 
-func (c *aPIClient) AddProcess(ctx context.Context, in *AddProcessRequest, opts ...grpc.CallOption) (*AddProcessResponse, error) {
-	out := new(AddProcessResponse)
-	err := grpc.Invoke(ctx, "/types.API/AddProcess", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *GetLogsRequest) Reset()                    { *m = GetLogsRequest{} }
+func (m *GetLogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetLogsRequest) ProtoMessage()               {}
+func (*GetLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+type LogEntry struct {
+	Stream    string `protobuf:"bytes,1,opt,name=stream" json:"stream,omitempty"`
+	Data      []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Timestamp uint64 `protobuf:"varint,3,opt,name=timestamp" json:"timestamp,omitempty"`
 }
 
-func (c *aPIClient) CreateCheckpoint(ctx context.Context, in *CreateCheckpointRequest, opts ...grpc.CallOption) (*CreateCheckpointResponse, error) {
-	out := new(CreateCheckpointResponse)
-	err := grpc.Invoke(ctx, "/types.API/CreateCheckpoint", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *LogEntry) Reset()                    { *m = LogEntry{} }
+func (m *LogEntry) String() string            { return proto.CompactTextString(m) }
+func (*LogEntry) ProtoMessage()               {}
+func (*LogEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+type CloseStdinRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Pid string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
 }
 
-func (c *aPIClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error) {
+func (m *CloseStdinRequest) Reset()                    { *m = CloseStdinRequest{} }
+func (m *CloseStdinRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloseStdinRequest) ProtoMessage()               {}
+func (*CloseStdinRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+type CloseStdinResponse struct {
+}
+
+func (m *CloseStdinResponse) Reset()                    { *m = CloseStdinResponse{} }
+func (m *CloseStdinResponse) String() string            { return proto.CompactTextString(m) }
+func (*CloseStdinResponse) ProtoMessage()               {}
+func (*CloseStdinResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+// UpdateDeviceRequest grants or revokes a running container's access to a host device node
+type UpdateDeviceRequest struct {
+	Id            string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Path          string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	ContainerPath string `protobuf:"bytes,3,opt,name=containerPath" json:"containerPath,omitempty"`
+	Permissions   string `protobuf:"bytes,4,opt,name=permissions" json:"permissions,omitempty"`
+	Remove        bool   `protobuf:"varint,5,opt,name=remove" json:"remove,omitempty"`
+	Mknod         bool   `protobuf:"varint,6,opt,name=mknod" json:"mknod,omitempty"`
+}
+
+func (m *UpdateDeviceRequest) Reset()                    { *m = UpdateDeviceRequest{} }
+func (m *UpdateDeviceRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceRequest) ProtoMessage()               {}
+func (*UpdateDeviceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+type UpdateDeviceResponse struct {
+}
+
+func (m *UpdateDeviceResponse) Reset()                    { *m = UpdateDeviceResponse{} }
+func (m *UpdateDeviceResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateDeviceResponse) ProtoMessage()               {}
+func (*UpdateDeviceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+// FreezeContainersRequest pauses or resumes a group of containers, either all of the containers change state or none
+type FreezeContainersRequest struct {
+	Ids    []string `protobuf:"bytes,1,rep,name=ids" json:"ids,omitempty"`
+	Labels []string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty"`
+	Thaw   bool     `protobuf:"varint,3,opt,name=thaw" json:"thaw,omitempty"`
+	Group  string   `protobuf:"bytes,4,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *FreezeContainersRequest) Reset()                    { *m = FreezeContainersRequest{} }
+func (m *FreezeContainersRequest) String() string            { return proto.CompactTextString(m) }
+func (*FreezeContainersRequest) ProtoMessage()               {}
+func (*FreezeContainersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+type FreezeContainersResponse struct {
+	Ids []string `protobuf:"bytes,1,rep,name=ids" json:"ids,omitempty"`
+}
+
+func (m *FreezeContainersResponse) Reset()                    { *m = FreezeContainersResponse{} }
+func (m *FreezeContainersResponse) String() string            { return proto.CompactTextString(m) }
+func (*FreezeContainersResponse) ProtoMessage()               {}
+func (*FreezeContainersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+type DumpStateRequest struct {
+	Timeout uint64 `protobuf:"varint,1,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *DumpStateRequest) Reset()                    { *m = DumpStateRequest{} }
+func (m *DumpStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DumpStateRequest) ProtoMessage()               {}
+func (*DumpStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+// DumpStateResponse is a snapshot of the supervisor's internal state used to debug a wedged daemon
+type DumpStateResponse struct {
+	Tasks              *QueueState      `protobuf:"bytes,1,opt,name=tasks" json:"tasks,omitempty"`
+	StartTasks         *QueueState      `protobuf:"bytes,2,opt,name=startTasks" json:"startTasks,omitempty"`
+	Exits              *QueueState      `protobuf:"bytes,3,opt,name=exits" json:"exits,omitempty"`
+	Ooms               *QueueState      `protobuf:"bytes,4,opt,name=ooms" json:"ooms,omitempty"`
+	Subscribers        []*QueueState    `protobuf:"bytes,5,rep,name=subscribers" json:"subscribers,omitempty"`
+	CurrentTask        string           `protobuf:"bytes,6,opt,name=currentTask" json:"currentTask,omitempty"`
+	CurrentTaskStarted uint64           `protobuf:"varint,7,opt,name=currentTaskStarted" json:"currentTaskStarted,omitempty"`
+	Blocked            bool             `protobuf:"varint,8,opt,name=blocked" json:"blocked,omitempty"`
+	Containers         []*ContainerDump `protobuf:"bytes,9,rep,name=containers" json:"containers,omitempty"`
+}
+
+func (m *DumpStateResponse) Reset()                    { *m = DumpStateResponse{} }
+func (m *DumpStateResponse) String() string            { return proto.CompactTextString(m) }
+func (*DumpStateResponse) ProtoMessage()               {}
+func (*DumpStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *DumpStateResponse) GetTasks() *QueueState {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+func (m *DumpStateResponse) GetStartTasks() *QueueState {
+	if m != nil {
+		return m.StartTasks
+	}
+	return nil
+}
+
+func (m *DumpStateResponse) GetExits() *QueueState {
+	if m != nil {
+		return m.Exits
+	}
+	return nil
+}
+
+func (m *DumpStateResponse) GetOoms() *QueueState {
+	if m != nil {
+		return m.Ooms
+	}
+	return nil
+}
+
+func (m *DumpStateResponse) GetSubscribers() []*QueueState {
+	if m != nil {
+		return m.Subscribers
+	}
+	return nil
+}
+
+func (m *DumpStateResponse) GetContainers() []*ContainerDump {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
+type QueueState struct {
+	Length   uint32 `protobuf:"varint,1,opt,name=length" json:"length,omitempty"`
+	Capacity uint32 `protobuf:"varint,2,opt,name=capacity" json:"capacity,omitempty"`
+}
+
+func (m *QueueState) Reset()                    { *m = QueueState{} }
+func (m *QueueState) String() string            { return proto.CompactTextString(m) }
+func (*QueueState) ProtoMessage()               {}
+func (*QueueState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+type ContainerDump struct {
+	Id         string         `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	BundlePath string         `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
+	Status     string         `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+	Labels     []string       `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty"`
+	Cpuset     string         `protobuf:"bytes,5,opt,name=cpuset" json:"cpuset,omitempty"`
+	Processes  []*ProcessDump `protobuf:"bytes,6,rep,name=processes" json:"processes,omitempty"`
+}
+
+func (m *ContainerDump) Reset()                    { *m = ContainerDump{} }
+func (m *ContainerDump) String() string            { return proto.CompactTextString(m) }
+func (*ContainerDump) ProtoMessage()               {}
+func (*ContainerDump) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{69} }
+
+func (m *ContainerDump) GetProcesses() []*ProcessDump {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type ProcessDump struct {
+	Pid       string `protobuf:"bytes,1,opt,name=pid" json:"pid,omitempty"`
+	SystemPid uint32 `protobuf:"varint,2,opt,name=systemPid" json:"systemPid,omitempty"`
+	Status    string `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *ProcessDump) Reset()                    { *m = ProcessDump{} }
+func (m *ProcessDump) String() string            { return proto.CompactTextString(m) }
+func (*ProcessDump) ProtoMessage()               {}
+func (*ProcessDump) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{70} }
+
+type HealthzRequest struct {
+	Timeout uint64 `protobuf:"varint,1,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *HealthzRequest) Reset()                    { *m = HealthzRequest{} }
+func (m *HealthzRequest) String() string            { return proto.CompactTextString(m) }
+func (*HealthzRequest) ProtoMessage()               {}
+func (*HealthzRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+// HealthzResponse reports whether the event loop, the start workers and the runtime binary are functional
+type HealthzResponse struct {
+	Healthy bool           `protobuf:"varint,1,opt,name=healthy" json:"healthy,omitempty"`
+	Checks  []*HealthCheck `protobuf:"bytes,2,rep,name=checks" json:"checks,omitempty"`
+}
+
+func (m *HealthzResponse) Reset()                    { *m = HealthzResponse{} }
+func (m *HealthzResponse) String() string            { return proto.CompactTextString(m) }
+func (*HealthzResponse) ProtoMessage()               {}
+func (*HealthzResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+func (m *HealthzResponse) GetChecks() []*HealthCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+type HealthCheck struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Healthy  bool   `protobuf:"varint,2,opt,name=healthy" json:"healthy,omitempty"`
+	Error    string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Duration uint64 `protobuf:"varint,4,opt,name=duration" json:"duration,omitempty"`
+}
+
+func (m *HealthCheck) Reset()                    { *m = HealthCheck{} }
+func (m *HealthCheck) String() string            { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()               {}
+func (*HealthCheck) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+// CreateGroupRequest starts a sandbox holder whose namespaces are shared by the containers created in the group
+type CreateGroupRequest struct {
+	Id         string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Namespaces []string `protobuf:"bytes,2,rep,name=namespaces" json:"namespaces,omitempty"`
+}
+
+func (m *CreateGroupRequest) Reset()                    { *m = CreateGroupRequest{} }
+func (m *CreateGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateGroupRequest) ProtoMessage()               {}
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+type CreateGroupResponse struct {
+	Group *Group `protobuf:"bytes,1,opt,name=group" json:"group,omitempty"`
+}
+
+func (m *CreateGroupResponse) Reset()                    { *m = CreateGroupResponse{} }
+func (m *CreateGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateGroupResponse) ProtoMessage()               {}
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+func (m *CreateGroupResponse) GetGroup() *Group {
+	if m != nil {
+		return m.Group
+	}
+	return nil
+}
+
+type Group struct {
+	Id         string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Namespaces []string `protobuf:"bytes,2,rep,name=namespaces" json:"namespaces,omitempty"`
+	Pid        uint32   `protobuf:"varint,3,opt,name=pid" json:"pid,omitempty"`
+	Containers []string `protobuf:"bytes,4,rep,name=containers" json:"containers,omitempty"`
+	Running    bool     `protobuf:"varint,5,opt,name=running" json:"running,omitempty"`
+	Deleting   bool     `protobuf:"varint,6,opt,name=deleting" json:"deleting,omitempty"`
+}
+
+func (m *Group) Reset()                    { *m = Group{} }
+func (m *Group) String() string            { return proto.CompactTextString(m) }
+func (*Group) ProtoMessage()               {}
+func (*Group) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+// DeleteGroupRequest kills all of the group's containers, the sandbox holder is stopped once they are deleted
+type DeleteGroupRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteGroupRequest) Reset()                    { *m = DeleteGroupRequest{} }
+func (m *DeleteGroupRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGroupRequest) ProtoMessage()               {}
+func (*DeleteGroupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{77} }
+
+type DeleteGroupResponse struct {
+	Ids []string `protobuf:"bytes,1,rep,name=ids" json:"ids,omitempty"`
+}
+
+func (m *DeleteGroupResponse) Reset()                    { *m = DeleteGroupResponse{} }
+func (m *DeleteGroupResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteGroupResponse) ProtoMessage()               {}
+func (*DeleteGroupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{78} }
+
+type ListGroupsRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ListGroupsRequest) Reset()                    { *m = ListGroupsRequest{} }
+func (m *ListGroupsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListGroupsRequest) ProtoMessage()               {}
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
+
+type ListGroupsResponse struct {
+	Groups []*Group `protobuf:"bytes,1,rep,name=groups" json:"groups,omitempty"`
+}
+
+func (m *ListGroupsResponse) Reset()                    { *m = ListGroupsResponse{} }
+func (m *ListGroupsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListGroupsResponse) ProtoMessage()               {}
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{80} }
+
+func (m *ListGroupsResponse) GetGroups() []*Group {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+// DeleteVolumeRequest removes a volume of a volume driver
+type DeleteVolumeRequest struct {
+	Driver string `protobuf:"bytes,1,opt,name=driver" json:"driver,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteVolumeRequest) Reset()                    { *m = DeleteVolumeRequest{} }
+func (m *DeleteVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteVolumeRequest) ProtoMessage()               {}
+func (*DeleteVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+type DeleteVolumeResponse struct {
+}
+
+func (m *DeleteVolumeResponse) Reset()                    { *m = DeleteVolumeResponse{} }
+func (m *DeleteVolumeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteVolumeResponse) ProtoMessage()               {}
+func (*DeleteVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+type CapabilitiesRequest struct {
+}
+
+func (m *CapabilitiesRequest) Reset()                    { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string            { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()               {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+
+// CapabilitiesResponse lists the features of the daemon and of the host as they were probed at startup
+type CapabilitiesResponse struct {
+	Version           string   `protobuf:"bytes,1,opt,name=version" json:"version,omitempty"`
+	Runtime           string   `protobuf:"bytes,2,opt,name=runtime" json:"runtime,omitempty"`
+	Criu              bool     `protobuf:"varint,3,opt,name=criu" json:"criu,omitempty"`
+	Seccomp           bool     `protobuf:"varint,4,opt,name=seccomp" json:"seccomp,omitempty"`
+	Apparmor          bool     `protobuf:"varint,5,opt,name=apparmor" json:"apparmor,omitempty"`
+	Selinux           bool     `protobuf:"varint,6,opt,name=selinux" json:"selinux,omitempty"`
+	CgroupVersion     uint32   `protobuf:"varint,7,opt,name=cgroupVersion" json:"cgroupVersion,omitempty"`
+	CgroupNamespace   bool     `protobuf:"varint,8,opt,name=cgroupNamespace" json:"cgroupNamespace,omitempty"`
+	Realtime          bool     `protobuf:"varint,9,opt,name=realtime" json:"realtime,omitempty"`
+	SwapAccounting    bool     `protobuf:"varint,10,opt,name=swapAccounting" json:"swapAccounting,omitempty"`
+	GroupNamespaces   []string `protobuf:"bytes,11,rep,name=groupNamespaces" json:"groupNamespaces,omitempty"`
+	LogDrivers        []string `protobuf:"bytes,12,rep,name=logDrivers" json:"logDrivers,omitempty"`
+	VolumeDrivers     []string `protobuf:"bytes,13,rep,name=volumeDrivers" json:"volumeDrivers,omitempty"`
+	HookPlugins       bool     `protobuf:"varint,14,opt,name=hookPlugins" json:"hookPlugins,omitempty"`
+	OciHooks          bool     `protobuf:"varint,15,opt,name=ociHooks" json:"ociHooks,omitempty"`
+	Gpus              []string `protobuf:"bytes,16,rep,name=gpus" json:"gpus,omitempty"`
+	KernelVersion     string   `protobuf:"bytes,17,opt,name=kernelVersion" json:"kernelVersion,omitempty"`
+	CgroupControllers []string `protobuf:"bytes,18,rep,name=cgroupControllers" json:"cgroupControllers,omitempty"`
+	UserNamespaces    bool     `protobuf:"varint,19,opt,name=userNamespaces" json:"userNamespaces,omitempty"`
+	Overlayfs         bool     `protobuf:"varint,20,opt,name=overlayfs" json:"overlayfs,omitempty"`
+	Rootless          bool     `protobuf:"varint,21,opt,name=rootless" json:"rootless,omitempty"`
+	CgroupsDelegated  bool     `protobuf:"varint,22,opt,name=cgroupsDelegated" json:"cgroupsDelegated,omitempty"`
+	Slirp4Netns       bool     `protobuf:"varint,23,opt,name=slirp4netns" json:"slirp4netns,omitempty"`
+}
+
+func (m *CapabilitiesResponse) Reset()                    { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string            { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()               {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+
+type UploadBundleRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *UploadBundleRequest) Reset()                    { *m = UploadBundleRequest{} }
+func (m *UploadBundleRequest) String() string            { return proto.CompactTextString(m) }
+func (*UploadBundleRequest) ProtoMessage()               {}
+func (*UploadBundleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+type UploadBundleResponse struct {
+	Id         string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	BundlePath string `protobuf:"bytes,2,opt,name=bundlePath" json:"bundlePath,omitempty"`
+}
+
+func (m *UploadBundleResponse) Reset()                    { *m = UploadBundleResponse{} }
+func (m *UploadBundleResponse) String() string            { return proto.CompactTextString(m) }
+func (*UploadBundleResponse) ProtoMessage()               {}
+func (*UploadBundleResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+type BindMount struct {
+	Source      string `protobuf:"bytes,1,opt,name=source" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination" json:"destination,omitempty"`
+	ReadOnly    bool   `protobuf:"varint,3,opt,name=readOnly" json:"readOnly,omitempty"`
+}
+
+func (m *BindMount) Reset()                    { *m = BindMount{} }
+func (m *BindMount) String() string            { return proto.CompactTextString(m) }
+func (*BindMount) ProtoMessage()               {}
+func (*BindMount) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+type UpdateContainerSpecRequest struct {
+	Id           string       `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	AddMounts    []*BindMount `protobuf:"bytes,2,rep,name=addMounts" json:"addMounts,omitempty"`
+	RemoveMounts []string     `protobuf:"bytes,3,rep,name=removeMounts" json:"removeMounts,omitempty"`
+	SetEnv       []string     `protobuf:"bytes,4,rep,name=setEnv" json:"setEnv,omitempty"`
+	UnsetEnv     []string     `protobuf:"bytes,5,rep,name=unsetEnv" json:"unsetEnv,omitempty"`
+}
+
+func (m *UpdateContainerSpecRequest) Reset()                    { *m = UpdateContainerSpecRequest{} }
+func (m *UpdateContainerSpecRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateContainerSpecRequest) ProtoMessage()               {}
+func (*UpdateContainerSpecRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+
+func (m *UpdateContainerSpecRequest) GetAddMounts() []*BindMount {
+	if m != nil {
+		return m.AddMounts
+	}
+	return nil
+}
+
+type UpdateContainerSpecResponse struct {
+}
+
+func (m *UpdateContainerSpecResponse) Reset()                    { *m = UpdateContainerSpecResponse{} }
+func (m *UpdateContainerSpecResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpdateContainerSpecResponse) ProtoMessage()               {}
+func (*UpdateContainerSpecResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+type DeleteContainerRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteContainerRequest) Reset()                    { *m = DeleteContainerRequest{} }
+func (m *DeleteContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteContainerRequest) ProtoMessage()               {}
+func (*DeleteContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+type DeleteContainerResponse struct {
+}
+
+func (m *DeleteContainerResponse) Reset()                    { *m = DeleteContainerResponse{} }
+func (m *DeleteContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteContainerResponse) ProtoMessage()               {}
+func (*DeleteContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+
+type StopContainerRequest struct {
+	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Signal  uint32 `protobuf:"varint,2,opt,name=signal" json:"signal,omitempty"`
+	Timeout int64  `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *StopContainerRequest) Reset()                    { *m = StopContainerRequest{} }
+func (m *StopContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*StopContainerRequest) ProtoMessage()               {}
+func (*StopContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+
+type StopContainerResponse struct {
+	Status uint32 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
+	Forced bool   `protobuf:"varint,2,opt,name=forced" json:"forced,omitempty"`
+}
+
+func (m *StopContainerResponse) Reset()                    { *m = StopContainerResponse{} }
+func (m *StopContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*StopContainerResponse) ProtoMessage()               {}
+func (*StopContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+
+type ListProcessesRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *ListProcessesRequest) Reset()                    { *m = ListProcessesRequest{} }
+func (m *ListProcessesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListProcessesRequest) ProtoMessage()               {}
+func (*ListProcessesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+
+type ListProcessesResponse struct {
+	Processes []*Process `protobuf:"bytes,1,rep,name=processes" json:"processes,omitempty"`
+}
+
+func (m *ListProcessesResponse) Reset()                    { *m = ListProcessesResponse{} }
+func (m *ListProcessesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListProcessesResponse) ProtoMessage()               {}
+func (*ListProcessesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+
+func (m *ListProcessesResponse) GetProcesses() []*Process {
+	if m != nil {
+		return m.Processes
+	}
+	return nil
+}
+
+type DeleteProcessRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Pid string `protobuf:"bytes,2,opt,name=pid" json:"pid,omitempty"`
+}
+
+func (m *DeleteProcessRequest) Reset()                    { *m = DeleteProcessRequest{} }
+func (m *DeleteProcessRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteProcessRequest) ProtoMessage()               {}
+func (*DeleteProcessRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+
+type DeleteProcessResponse struct {
+	Status uint32 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *DeleteProcessResponse) Reset()                    { *m = DeleteProcessResponse{} }
+func (m *DeleteProcessResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteProcessResponse) ProtoMessage()               {}
+func (*DeleteProcessResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
+
+type RestartContainerRequest struct {
+	Id      string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Signal  uint32 `protobuf:"varint,2,opt,name=signal" json:"signal,omitempty"`
+	Timeout int64  `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+}
+
+func (m *RestartContainerRequest) Reset()                    { *m = RestartContainerRequest{} }
+func (m *RestartContainerRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestartContainerRequest) ProtoMessage()               {}
+func (*RestartContainerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
+
+type RestartContainerResponse struct {
+}
+
+func (m *RestartContainerResponse) Reset()                    { *m = RestartContainerResponse{} }
+func (m *RestartContainerResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestartContainerResponse) ProtoMessage()               {}
+func (*RestartContainerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
+
+type Template struct {
+	Name       string      `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Container  string      `protobuf:"bytes,2,opt,name=container" json:"container,omitempty"`
+	Labels     []string    `protobuf:"bytes,3,rep,name=labels" json:"labels,omitempty"`
+	LogConfig  *LogConfig  `protobuf:"bytes,4,opt,name=logConfig" json:"logConfig,omitempty"`
+	StdinOnce  bool        `protobuf:"varint,5,opt,name=stdinOnce" json:"stdinOnce,omitempty"`
+	Numa       *NUMAConfig `protobuf:"bytes,6,opt,name=numa" json:"numa,omitempty"`
+	Keep       bool        `protobuf:"varint,7,opt,name=keep" json:"keep,omitempty"`
+	AutoRemove bool        `protobuf:"varint,8,opt,name=autoRemove" json:"autoRemove,omitempty"`
+	Created    uint64      `protobuf:"varint,9,opt,name=created" json:"created,omitempty"`
+}
+
+func (m *Template) Reset()                    { *m = Template{} }
+func (m *Template) String() string            { return proto.CompactTextString(m) }
+func (*Template) ProtoMessage()               {}
+func (*Template) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *Template) GetLogConfig() *LogConfig {
+	if m != nil {
+		return m.LogConfig
+	}
+	return nil
+}
+
+func (m *Template) GetNuma() *NUMAConfig {
+	if m != nil {
+		return m.Numa
+	}
+	return nil
+}
+
+type CreateTemplateRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *CreateTemplateRequest) Reset()                    { *m = CreateTemplateRequest{} }
+func (m *CreateTemplateRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateTemplateRequest) ProtoMessage()               {}
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+type CreateTemplateResponse struct {
+	Template *Template `protobuf:"bytes,1,opt,name=template" json:"template,omitempty"`
+}
+
+func (m *CreateTemplateResponse) Reset()                    { *m = CreateTemplateResponse{} }
+func (m *CreateTemplateResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateTemplateResponse) ProtoMessage()               {}
+func (*CreateTemplateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+func (m *CreateTemplateResponse) GetTemplate() *Template {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+}
+
+func (m *ListTemplatesRequest) Reset()                    { *m = ListTemplatesRequest{} }
+func (m *ListTemplatesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTemplatesRequest) ProtoMessage()               {}
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+type ListTemplatesResponse struct {
+	Templates []*Template `protobuf:"bytes,1,rep,name=templates" json:"templates,omitempty"`
+}
+
+func (m *ListTemplatesResponse) Reset()                    { *m = ListTemplatesResponse{} }
+func (m *ListTemplatesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTemplatesResponse) ProtoMessage()               {}
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
+
+func (m *ListTemplatesResponse) GetTemplates() []*Template {
+	if m != nil {
+		return m.Templates
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteTemplateRequest) Reset()                    { *m = DeleteTemplateRequest{} }
+func (m *DeleteTemplateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteTemplateRequest) ProtoMessage()               {}
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+type DeleteTemplateResponse struct {
+}
+
+func (m *DeleteTemplateResponse) Reset()                    { *m = DeleteTemplateResponse{} }
+func (m *DeleteTemplateResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteTemplateResponse) ProtoMessage()               {}
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+type OOMRestartPolicy struct {
+	MaxRestarts     uint32 `protobuf:"varint,1,opt,name=maxRestarts" json:"maxRestarts,omitempty"`
+	MemoryIncrement uint64 `protobuf:"varint,2,opt,name=memoryIncrement" json:"memoryIncrement,omitempty"`
+	MemoryLimitCap  uint64 `protobuf:"varint,3,opt,name=memoryLimitCap" json:"memoryLimitCap,omitempty"`
+}
+
+func (m *OOMRestartPolicy) Reset()                    { *m = OOMRestartPolicy{} }
+func (m *OOMRestartPolicy) String() string            { return proto.CompactTextString(m) }
+func (*OOMRestartPolicy) ProtoMessage()               {}
+func (*OOMRestartPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+type BackupRequest struct {
+}
+
+func (m *BackupRequest) Reset()                    { *m = BackupRequest{} }
+func (m *BackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()               {}
+func (*BackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+type BackupChunk struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *BackupChunk) Reset()                    { *m = BackupChunk{} }
+func (m *BackupChunk) String() string            { return proto.CompactTextString(m) }
+func (*BackupChunk) ProtoMessage()               {}
+func (*BackupChunk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
+
+type RestoreBackupRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *RestoreBackupRequest) Reset()                    { *m = RestoreBackupRequest{} }
+func (m *RestoreBackupRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupRequest) ProtoMessage()               {}
+func (*RestoreBackupRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+type RestoreBackupResponse struct {
+	Restored []string `protobuf:"bytes,1,rep,name=restored" json:"restored,omitempty"`
+	Skipped  []string `protobuf:"bytes,2,rep,name=skipped" json:"skipped,omitempty"`
+}
+
+func (m *RestoreBackupResponse) Reset()                    { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string            { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()               {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+type CheckConsistencyRequest struct {
+	Clean bool `protobuf:"varint,1,opt,name=clean" json:"clean,omitempty"`
+	Last  bool `protobuf:"varint,2,opt,name=last" json:"last,omitempty"`
+}
+
+func (m *CheckConsistencyRequest) Reset()                    { *m = CheckConsistencyRequest{} }
+func (m *CheckConsistencyRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckConsistencyRequest) ProtoMessage()               {}
+func (*CheckConsistencyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+type ConsistencyFinding struct {
+	Kind    string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	Id      string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	Pid     string `protobuf:"bytes,3,opt,name=pid" json:"pid,omitempty"`
+	Detail  string `protobuf:"bytes,4,opt,name=detail" json:"detail,omitempty"`
+	Cleaned bool   `protobuf:"varint,5,opt,name=cleaned" json:"cleaned,omitempty"`
+}
+
+func (m *ConsistencyFinding) Reset()                    { *m = ConsistencyFinding{} }
+func (m *ConsistencyFinding) String() string            { return proto.CompactTextString(m) }
+func (*ConsistencyFinding) ProtoMessage()               {}
+func (*ConsistencyFinding) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+type CheckConsistencyResponse struct {
+	Findings []*ConsistencyFinding `protobuf:"bytes,1,rep,name=findings" json:"findings,omitempty"`
+	Started  uint64                `protobuf:"varint,2,opt,name=started" json:"started,omitempty"`
+	Duration uint64                `protobuf:"varint,3,opt,name=duration" json:"duration,omitempty"`
+}
+
+func (m *CheckConsistencyResponse) Reset()                    { *m = CheckConsistencyResponse{} }
+func (m *CheckConsistencyResponse) String() string            { return proto.CompactTextString(m) }
+func (*CheckConsistencyResponse) ProtoMessage()               {}
+func (*CheckConsistencyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+func (m *CheckConsistencyResponse) GetFindings() []*ConsistencyFinding {
+	if m != nil {
+		return m.Findings
+	}
+	return nil
+}
+
+type Lease struct {
+	Id      string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Expires uint64   `protobuf:"varint,2,opt,name=expires" json:"expires,omitempty"`
+	Bundles []string `protobuf:"bytes,3,rep,name=bundles" json:"bundles,omitempty"`
+}
+
+func (m *Lease) Reset()                    { *m = Lease{} }
+func (m *Lease) String() string            { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()               {}
+func (*Lease) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
+
+type CreateLeaseRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Ttl uint64 `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *CreateLeaseRequest) Reset()                    { *m = CreateLeaseRequest{} }
+func (m *CreateLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateLeaseRequest) ProtoMessage()               {}
+func (*CreateLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
+
+type RenewLeaseRequest struct {
+	Id  string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Ttl uint64 `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+}
+
+func (m *RenewLeaseRequest) Reset()                    { *m = RenewLeaseRequest{} }
+func (m *RenewLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenewLeaseRequest) ProtoMessage()               {}
+func (*RenewLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+type DeleteLeaseRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *DeleteLeaseRequest) Reset()                    { *m = DeleteLeaseRequest{} }
+func (m *DeleteLeaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteLeaseRequest) ProtoMessage()               {}
+func (*DeleteLeaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+type DeleteLeaseResponse struct {
+}
+
+func (m *DeleteLeaseResponse) Reset()                    { *m = DeleteLeaseResponse{} }
+func (m *DeleteLeaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteLeaseResponse) ProtoMessage()               {}
+func (*DeleteLeaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+type ListLeasesRequest struct {
+}
+
+func (m *ListLeasesRequest) Reset()                    { *m = ListLeasesRequest{} }
+func (m *ListLeasesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesRequest) ProtoMessage()               {}
+func (*ListLeasesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
+
+type ListLeasesResponse struct {
+	Leases []*Lease `protobuf:"bytes,1,rep,name=leases" json:"leases,omitempty"`
+}
+
+func (m *ListLeasesResponse) Reset()                    { *m = ListLeasesResponse{} }
+func (m *ListLeasesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListLeasesResponse) ProtoMessage()               {}
+func (*ListLeasesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
+
+func (m *ListLeasesResponse) GetLeases() []*Lease {
+	if m != nil {
+		return m.Leases
+	}
+	return nil
+}
+
+type NetworkConfig struct {
+	Interfaces      []*InterfaceConfig `protobuf:"bytes,1,rep,name=interfaces" json:"interfaces,omitempty"`
+	Vf              *VirtualFunction   `protobuf:"bytes,2,opt,name=vf" json:"vf,omitempty"`
+	Routes          []*Route           `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+	RoutedInterface string             `protobuf:"bytes,4,opt,name=routedInterface" json:"routedInterface,omitempty"`
+	Addresses       []*AddressConfig   `protobuf:"bytes,5,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *NetworkConfig) Reset()                    { *m = NetworkConfig{} }
+func (m *NetworkConfig) String() string            { return proto.CompactTextString(m) }
+func (*NetworkConfig) ProtoMessage()               {}
+func (*NetworkConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
+
+func (m *NetworkConfig) GetInterfaces() []*InterfaceConfig {
+	if m != nil {
+		return m.Interfaces
+	}
+	return nil
+}
+
+func (m *NetworkConfig) GetVf() *VirtualFunction {
+	if m != nil {
+		return m.Vf
+	}
+	return nil
+}
+
+func (m *NetworkConfig) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+func (m *NetworkConfig) GetAddresses() []*AddressConfig {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type InterfaceConfig struct {
+	Name       string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Mtu        uint32 `protobuf:"varint,2,opt,name=mtu" json:"mtu,omitempty"`
+	TxQueueLen uint32 `protobuf:"varint,3,opt,name=txQueueLen" json:"txQueueLen,omitempty"`
+	Mac        string `protobuf:"bytes,4,opt,name=mac" json:"mac,omitempty"`
+}
+
+func (m *InterfaceConfig) Reset()                    { *m = InterfaceConfig{} }
+func (m *InterfaceConfig) String() string            { return proto.CompactTextString(m) }
+func (*InterfaceConfig) ProtoMessage()               {}
+func (*InterfaceConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
+
+type VirtualFunction struct {
+	Pf    string `protobuf:"bytes,1,opt,name=pf" json:"pf,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	Name  string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	Mac   string `protobuf:"bytes,4,opt,name=mac" json:"mac,omitempty"`
+	Vlan  uint32 `protobuf:"varint,5,opt,name=vlan" json:"vlan,omitempty"`
+}
+
+func (m *VirtualFunction) Reset()                    { *m = VirtualFunction{} }
+func (m *VirtualFunction) String() string            { return proto.CompactTextString(m) }
+func (*VirtualFunction) ProtoMessage()               {}
+func (*VirtualFunction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+type Route struct {
+	Destination string `protobuf:"bytes,1,opt,name=destination" json:"destination,omitempty"`
+	Gateway     string `protobuf:"bytes,2,opt,name=gateway" json:"gateway,omitempty"`
+	Interface   string `protobuf:"bytes,3,opt,name=interface" json:"interface,omitempty"`
+	Metric      uint32 `protobuf:"varint,4,opt,name=metric" json:"metric,omitempty"`
+}
+
+func (m *Route) Reset()                    { *m = Route{} }
+func (m *Route) String() string            { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()               {}
+func (*Route) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+type InterfaceAddress struct {
+	Interface string `protobuf:"bytes,1,opt,name=interface" json:"interface,omitempty"`
+	Label     string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+	Address   string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+}
+
+func (m *InterfaceAddress) Reset()                    { *m = InterfaceAddress{} }
+func (m *InterfaceAddress) String() string            { return proto.CompactTextString(m) }
+func (*InterfaceAddress) ProtoMessage()               {}
+func (*InterfaceAddress) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
+
+type AddAddressRequest struct {
+	Id        string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Interface string `protobuf:"bytes,2,opt,name=interface" json:"interface,omitempty"`
+	Label     string `protobuf:"bytes,3,opt,name=label" json:"label,omitempty"`
+	Address   string `protobuf:"bytes,4,opt,name=address" json:"address,omitempty"`
+}
+
+func (m *AddAddressRequest) Reset()                    { *m = AddAddressRequest{} }
+func (m *AddAddressRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddAddressRequest) ProtoMessage()               {}
+func (*AddAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+type AddAddressResponse struct {
+}
+
+func (m *AddAddressResponse) Reset()                    { *m = AddAddressResponse{} }
+func (m *AddAddressResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddAddressResponse) ProtoMessage()               {}
+func (*AddAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
+
+type DeleteAddressesRequest struct {
+	Id    string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Label string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *DeleteAddressesRequest) Reset()                    { *m = DeleteAddressesRequest{} }
+func (m *DeleteAddressesRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAddressesRequest) ProtoMessage()               {}
+func (*DeleteAddressesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
+
+type DeleteAddressesResponse struct {
+	Addresses []*InterfaceAddress `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *DeleteAddressesResponse) Reset()                    { *m = DeleteAddressesResponse{} }
+func (m *DeleteAddressesResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteAddressesResponse) ProtoMessage()               {}
+func (*DeleteAddressesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
+
+func (m *DeleteAddressesResponse) GetAddresses() []*InterfaceAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type ListAddressesRequest struct {
+	Id    string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Label string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *ListAddressesRequest) Reset()                    { *m = ListAddressesRequest{} }
+func (m *ListAddressesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesRequest) ProtoMessage()               {}
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
+
+type ListAddressesResponse struct {
+	Addresses []*InterfaceAddress `protobuf:"bytes,1,rep,name=addresses" json:"addresses,omitempty"`
+}
+
+func (m *ListAddressesResponse) Reset()                    { *m = ListAddressesResponse{} }
+func (m *ListAddressesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAddressesResponse) ProtoMessage()               {}
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
+
+func (m *ListAddressesResponse) GetAddresses() []*InterfaceAddress {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+type AddressConfig struct {
+	Interface string `protobuf:"bytes,1,opt,name=interface" json:"interface,omitempty"`
+	Network   string `protobuf:"bytes,2,opt,name=network" json:"network,omitempty"`
+	Address   string `protobuf:"bytes,3,opt,name=address" json:"address,omitempty"`
+	Gateway   string `protobuf:"bytes,4,opt,name=gateway" json:"gateway,omitempty"`
+}
+
+func (m *AddressConfig) Reset()                    { *m = AddressConfig{} }
+func (m *AddressConfig) String() string            { return proto.CompactTextString(m) }
+func (*AddressConfig) ProtoMessage()               {}
+func (*AddressConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
+
+type NetworkNamespace struct {
+	Name       string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Path       string   `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	Source     string   `protobuf:"bytes,3,opt,name=source" json:"source,omitempty"`
+	Containers []string `protobuf:"bytes,4,rep,name=containers" json:"containers,omitempty"`
+	Mounted    bool     `protobuf:"varint,5,opt,name=mounted" json:"mounted,omitempty"`
+}
+
+func (m *NetworkNamespace) Reset()                    { *m = NetworkNamespace{} }
+func (m *NetworkNamespace) String() string            { return proto.CompactTextString(m) }
+func (*NetworkNamespace) ProtoMessage()               {}
+func (*NetworkNamespace) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
+
+type CreateNetworkNamespaceRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Container string `protobuf:"bytes,2,opt,name=container" json:"container,omitempty"`
+}
+
+func (m *CreateNetworkNamespaceRequest) Reset()                    { *m = CreateNetworkNamespaceRequest{} }
+func (m *CreateNetworkNamespaceRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateNetworkNamespaceRequest) ProtoMessage()               {}
+func (*CreateNetworkNamespaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
+
+type CreateNetworkNamespaceResponse struct {
+	NetworkNamespace *NetworkNamespace `protobuf:"bytes,1,opt,name=networkNamespace" json:"networkNamespace,omitempty"`
+}
+
+func (m *CreateNetworkNamespaceResponse) Reset()                    { *m = CreateNetworkNamespaceResponse{} }
+func (m *CreateNetworkNamespaceResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateNetworkNamespaceResponse) ProtoMessage()               {}
+func (*CreateNetworkNamespaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *CreateNetworkNamespaceResponse) GetNetworkNamespace() *NetworkNamespace {
+	if m != nil {
+		return m.NetworkNamespace
+	}
+	return nil
+}
+
+type DeleteNetworkNamespaceRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteNetworkNamespaceRequest) Reset()                    { *m = DeleteNetworkNamespaceRequest{} }
+func (m *DeleteNetworkNamespaceRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNetworkNamespaceRequest) ProtoMessage()               {}
+func (*DeleteNetworkNamespaceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+type DeleteNetworkNamespaceResponse struct {
+}
+
+func (m *DeleteNetworkNamespaceResponse) Reset()                    { *m = DeleteNetworkNamespaceResponse{} }
+func (m *DeleteNetworkNamespaceResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteNetworkNamespaceResponse) ProtoMessage()               {}
+func (*DeleteNetworkNamespaceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+type ListNetworkNamespacesRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ListNetworkNamespacesRequest) Reset()                    { *m = ListNetworkNamespacesRequest{} }
+func (m *ListNetworkNamespacesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListNetworkNamespacesRequest) ProtoMessage()               {}
+func (*ListNetworkNamespacesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+type ListNetworkNamespacesResponse struct {
+	NetworkNamespaces []*NetworkNamespace `protobuf:"bytes,1,rep,name=networkNamespaces" json:"networkNamespaces,omitempty"`
+}
+
+func (m *ListNetworkNamespacesResponse) Reset()                    { *m = ListNetworkNamespacesResponse{} }
+func (m *ListNetworkNamespacesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListNetworkNamespacesResponse) ProtoMessage()               {}
+func (*ListNetworkNamespacesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *ListNetworkNamespacesResponse) GetNetworkNamespaces() []*NetworkNamespace {
+	if m != nil {
+		return m.NetworkNamespaces
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*UpdateProcessRequest)(nil), "types.UpdateProcessRequest")
+	proto.RegisterType((*UpdateProcessResponse)(nil), "types.UpdateProcessResponse")
+	proto.RegisterType((*CreateContainerRequest)(nil), "types.CreateContainerRequest")
+	proto.RegisterType((*Volume)(nil), "types.Volume")
+	proto.RegisterType((*NUMAConfig)(nil), "types.NUMAConfig")
+	proto.RegisterType((*LogConfig)(nil), "types.LogConfig")
+	proto.RegisterType((*CreateContainerResponse)(nil), "types.CreateContainerResponse")
+	proto.RegisterType((*SignalRequest)(nil), "types.SignalRequest")
+	proto.RegisterType((*SignalResponse)(nil), "types.SignalResponse")
+	proto.RegisterType((*AddProcessRequest)(nil), "types.AddProcessRequest")
+	proto.RegisterType((*Rlimit)(nil), "types.Rlimit")
+	proto.RegisterType((*User)(nil), "types.User")
+	proto.RegisterType((*AddProcessResponse)(nil), "types.AddProcessResponse")
+	proto.RegisterType((*CreateCheckpointRequest)(nil), "types.CreateCheckpointRequest")
+	proto.RegisterType((*CreateCheckpointResponse)(nil), "types.CreateCheckpointResponse")
+	proto.RegisterType((*DeleteCheckpointRequest)(nil), "types.DeleteCheckpointRequest")
+	proto.RegisterType((*DeleteCheckpointResponse)(nil), "types.DeleteCheckpointResponse")
+	proto.RegisterType((*ListCheckpointRequest)(nil), "types.ListCheckpointRequest")
+	proto.RegisterType((*Checkpoint)(nil), "types.Checkpoint")
+	proto.RegisterType((*ListCheckpointResponse)(nil), "types.ListCheckpointResponse")
+	proto.RegisterType((*StateRequest)(nil), "types.StateRequest")
+	proto.RegisterType((*ContainerState)(nil), "types.ContainerState")
+	proto.RegisterType((*Process)(nil), "types.Process")
+	proto.RegisterType((*Container)(nil), "types.Container")
+	proto.RegisterType((*ContainerLifecycle)(nil), "types.ContainerLifecycle")
+	proto.RegisterType((*StateTransition)(nil), "types.StateTransition")
+	proto.RegisterType((*Machine)(nil), "types.Machine")
+	proto.RegisterType((*StateResponse)(nil), "types.StateResponse")
+	proto.RegisterType((*UpdateContainerRequest)(nil), "types.UpdateContainerRequest")
+	proto.RegisterType((*UpdateResource)(nil), "types.UpdateResource")
+	proto.RegisterType((*WeightDevice)(nil), "types.WeightDevice")
+	proto.RegisterType((*ThrottleDevice)(nil), "types.ThrottleDevice")
+	proto.RegisterType((*MemorySwappiness)(nil), "types.MemorySwappiness")
+	proto.RegisterType((*UpdateContainerResponse)(nil), "types.UpdateContainerResponse")
+	proto.RegisterType((*EventsRequest)(nil), "types.EventsRequest")
+	proto.RegisterType((*Event)(nil), "types.Event")
+	proto.RegisterType((*NetworkStats)(nil), "types.NetworkStats")
+	proto.RegisterType((*CpuUsage)(nil), "types.CpuUsage")
+	proto.RegisterType((*ThrottlingData)(nil), "types.ThrottlingData")
+	proto.RegisterType((*CpuStats)(nil), "types.CpuStats")
+	proto.RegisterType((*PidsStats)(nil), "types.PidsStats")
+	proto.RegisterType((*MemoryData)(nil), "types.MemoryData")
+	proto.RegisterType((*MemoryStats)(nil), "types.MemoryStats")
+	proto.RegisterType((*NUMAStats)(nil), "types.NUMAStats")
+	proto.RegisterType((*BlkioStatsEntry)(nil), "types.BlkioStatsEntry")
+	proto.RegisterType((*BlkioStats)(nil), "types.BlkioStats")
+	proto.RegisterType((*HugetlbStats)(nil), "types.HugetlbStats")
+	proto.RegisterType((*CgroupStats)(nil), "types.CgroupStats")
+	proto.RegisterType((*StatsResponse)(nil), "types.StatsResponse")
+	proto.RegisterType((*StatsRequest)(nil), "types.StatsRequest")
+	proto.RegisterType((*CopyFromContainerRequest)(nil), "types.CopyFromContainerRequest")
+	proto.RegisterType((*CopyChunk)(nil), "types.CopyChunk")
+	proto.RegisterType((*CopyToContainerRequest)(nil), "types.CopyToContainerRequest")
+	proto.RegisterType((*CopyToContainerResponse)(nil), "types.CopyToContainerResponse")
+	proto.RegisterType((*WaitRequest)(nil), "types.WaitRequest")
+	proto.RegisterType((*WaitResponse)(nil), "types.WaitResponse")
+	proto.RegisterType((*AttachRequest)(nil), "types.AttachRequest")
+	proto.RegisterType((*AttachResponse)(nil), "types.AttachResponse")
+	proto.RegisterType((*GetLogsRequest)(nil), "types.GetLogsRequest")
+	proto.RegisterType((*LogEntry)(nil), "types.LogEntry")
+	proto.RegisterType((*CloseStdinRequest)(nil), "types.CloseStdinRequest")
+	proto.RegisterType((*CloseStdinResponse)(nil), "types.CloseStdinResponse")
+	proto.RegisterType((*UpdateDeviceRequest)(nil), "types.UpdateDeviceRequest")
+	proto.RegisterType((*UpdateDeviceResponse)(nil), "types.UpdateDeviceResponse")
+	proto.RegisterType((*FreezeContainersRequest)(nil), "types.FreezeContainersRequest")
+	proto.RegisterType((*FreezeContainersResponse)(nil), "types.FreezeContainersResponse")
+	proto.RegisterType((*DumpStateRequest)(nil), "types.DumpStateRequest")
+	proto.RegisterType((*DumpStateResponse)(nil), "types.DumpStateResponse")
+	proto.RegisterType((*QueueState)(nil), "types.QueueState")
+	proto.RegisterType((*ContainerDump)(nil), "types.ContainerDump")
+	proto.RegisterType((*ProcessDump)(nil), "types.ProcessDump")
+	proto.RegisterType((*HealthzRequest)(nil), "types.HealthzRequest")
+	proto.RegisterType((*HealthzResponse)(nil), "types.HealthzResponse")
+	proto.RegisterType((*HealthCheck)(nil), "types.HealthCheck")
+	proto.RegisterType((*CreateGroupRequest)(nil), "types.CreateGroupRequest")
+	proto.RegisterType((*CreateGroupResponse)(nil), "types.CreateGroupResponse")
+	proto.RegisterType((*Group)(nil), "types.Group")
+	proto.RegisterType((*DeleteGroupRequest)(nil), "types.DeleteGroupRequest")
+	proto.RegisterType((*DeleteGroupResponse)(nil), "types.DeleteGroupResponse")
+	proto.RegisterType((*ListGroupsRequest)(nil), "types.ListGroupsRequest")
+	proto.RegisterType((*ListGroupsResponse)(nil), "types.ListGroupsResponse")
+	proto.RegisterType((*DeleteVolumeRequest)(nil), "types.DeleteVolumeRequest")
+	proto.RegisterType((*DeleteVolumeResponse)(nil), "types.DeleteVolumeResponse")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "types.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "types.CapabilitiesResponse")
+	proto.RegisterType((*UploadBundleRequest)(nil), "types.UploadBundleRequest")
+	proto.RegisterType((*UploadBundleResponse)(nil), "types.UploadBundleResponse")
+	proto.RegisterType((*BindMount)(nil), "types.BindMount")
+	proto.RegisterType((*UpdateContainerSpecRequest)(nil), "types.UpdateContainerSpecRequest")
+	proto.RegisterType((*UpdateContainerSpecResponse)(nil), "types.UpdateContainerSpecResponse")
+	proto.RegisterType((*DeleteContainerRequest)(nil), "types.DeleteContainerRequest")
+	proto.RegisterType((*DeleteContainerResponse)(nil), "types.DeleteContainerResponse")
+	proto.RegisterType((*StopContainerRequest)(nil), "types.StopContainerRequest")
+	proto.RegisterType((*StopContainerResponse)(nil), "types.StopContainerResponse")
+	proto.RegisterType((*ListProcessesRequest)(nil), "types.ListProcessesRequest")
+	proto.RegisterType((*ListProcessesResponse)(nil), "types.ListProcessesResponse")
+	proto.RegisterType((*DeleteProcessRequest)(nil), "types.DeleteProcessRequest")
+	proto.RegisterType((*DeleteProcessResponse)(nil), "types.DeleteProcessResponse")
+	proto.RegisterType((*RestartContainerRequest)(nil), "types.RestartContainerRequest")
+	proto.RegisterType((*RestartContainerResponse)(nil), "types.RestartContainerResponse")
+	proto.RegisterType((*Template)(nil), "types.Template")
+	proto.RegisterType((*CreateTemplateRequest)(nil), "types.CreateTemplateRequest")
+	proto.RegisterType((*CreateTemplateResponse)(nil), "types.CreateTemplateResponse")
+	proto.RegisterType((*ListTemplatesRequest)(nil), "types.ListTemplatesRequest")
+	proto.RegisterType((*ListTemplatesResponse)(nil), "types.ListTemplatesResponse")
+	proto.RegisterType((*DeleteTemplateRequest)(nil), "types.DeleteTemplateRequest")
+	proto.RegisterType((*DeleteTemplateResponse)(nil), "types.DeleteTemplateResponse")
+	proto.RegisterType((*OOMRestartPolicy)(nil), "types.OOMRestartPolicy")
+	proto.RegisterType((*BackupRequest)(nil), "types.BackupRequest")
+	proto.RegisterType((*BackupChunk)(nil), "types.BackupChunk")
+	proto.RegisterType((*RestoreBackupRequest)(nil), "types.RestoreBackupRequest")
+	proto.RegisterType((*RestoreBackupResponse)(nil), "types.RestoreBackupResponse")
+	proto.RegisterType((*CheckConsistencyRequest)(nil), "types.CheckConsistencyRequest")
+	proto.RegisterType((*ConsistencyFinding)(nil), "types.ConsistencyFinding")
+	proto.RegisterType((*CheckConsistencyResponse)(nil), "types.CheckConsistencyResponse")
+	proto.RegisterType((*Lease)(nil), "types.Lease")
+	proto.RegisterType((*CreateLeaseRequest)(nil), "types.CreateLeaseRequest")
+	proto.RegisterType((*RenewLeaseRequest)(nil), "types.RenewLeaseRequest")
+	proto.RegisterType((*DeleteLeaseRequest)(nil), "types.DeleteLeaseRequest")
+	proto.RegisterType((*DeleteLeaseResponse)(nil), "types.DeleteLeaseResponse")
+	proto.RegisterType((*ListLeasesRequest)(nil), "types.ListLeasesRequest")
+	proto.RegisterType((*ListLeasesResponse)(nil), "types.ListLeasesResponse")
+	proto.RegisterType((*NetworkConfig)(nil), "types.NetworkConfig")
+	proto.RegisterType((*InterfaceConfig)(nil), "types.InterfaceConfig")
+	proto.RegisterType((*VirtualFunction)(nil), "types.VirtualFunction")
+	proto.RegisterType((*Route)(nil), "types.Route")
+	proto.RegisterType((*InterfaceAddress)(nil), "types.InterfaceAddress")
+	proto.RegisterType((*AddAddressRequest)(nil), "types.AddAddressRequest")
+	proto.RegisterType((*AddAddressResponse)(nil), "types.AddAddressResponse")
+	proto.RegisterType((*DeleteAddressesRequest)(nil), "types.DeleteAddressesRequest")
+	proto.RegisterType((*DeleteAddressesResponse)(nil), "types.DeleteAddressesResponse")
+	proto.RegisterType((*ListAddressesRequest)(nil), "types.ListAddressesRequest")
+	proto.RegisterType((*ListAddressesResponse)(nil), "types.ListAddressesResponse")
+	proto.RegisterType((*AddressConfig)(nil), "types.AddressConfig")
+	proto.RegisterType((*NetworkNamespace)(nil), "types.NetworkNamespace")
+	proto.RegisterType((*CreateNetworkNamespaceRequest)(nil), "types.CreateNetworkNamespaceRequest")
+	proto.RegisterType((*CreateNetworkNamespaceResponse)(nil), "types.CreateNetworkNamespaceResponse")
+	proto.RegisterType((*DeleteNetworkNamespaceRequest)(nil), "types.DeleteNetworkNamespaceRequest")
+	proto.RegisterType((*DeleteNetworkNamespaceResponse)(nil), "types.DeleteNetworkNamespaceResponse")
+	proto.RegisterType((*ListNetworkNamespacesRequest)(nil), "types.ListNetworkNamespacesRequest")
+	proto.RegisterType((*ListNetworkNamespacesResponse)(nil), "types.ListNetworkNamespacesResponse")
+	proto.RegisterEnum("types.ErrorCode", ErrorCode_name, ErrorCode_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// Client API for API service
+
+type APIClient interface {
+	CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error)
+	UpdateContainer(ctx context.Context, in *UpdateContainerRequest, opts ...grpc.CallOption) (*UpdateContainerResponse, error)
+	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
+	UpdateProcess(ctx context.Context, in *UpdateProcessRequest, opts ...grpc.CallOption) (*UpdateProcessResponse, error)
+	AddProcess(ctx context.Context, in *AddProcessRequest, opts ...grpc.CallOption) (*AddProcessResponse, error)
+	CreateCheckpoint(ctx context.Context, in *CreateCheckpointRequest, opts ...grpc.CallOption) (*CreateCheckpointResponse, error)
+	DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error)
+	ListCheckpoint(ctx context.Context, in *ListCheckpointRequest, opts ...grpc.CallOption) (*ListCheckpointResponse, error)
+	State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (API_EventsClient, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	StatsStream(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (API_StatsStreamClient, error)
+	CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (API_CopyFromContainerClient, error)
+	CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (API_CopyToContainerClient, error)
+	Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error)
+	Attach(ctx context.Context, opts ...grpc.CallOption) (API_AttachClient, error)
+	GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (API_GetLogsClient, error)
+	CloseStdin(ctx context.Context, in *CloseStdinRequest, opts ...grpc.CallOption) (*CloseStdinResponse, error)
+	UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error)
+	FreezeContainers(ctx context.Context, in *FreezeContainersRequest, opts ...grpc.CallOption) (*FreezeContainersResponse, error)
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error)
+	DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error)
+	DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*DumpStateResponse, error)
+	Healthz(ctx context.Context, in *HealthzRequest, opts ...grpc.CallOption) (*HealthzResponse, error)
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+	UploadBundle(ctx context.Context, opts ...grpc.CallOption) (API_UploadBundleClient, error)
+	UpdateContainerSpec(ctx context.Context, in *UpdateContainerSpecRequest, opts ...grpc.CallOption) (*UpdateContainerSpecResponse, error)
+	DeleteContainer(ctx context.Context, in *DeleteContainerRequest, opts ...grpc.CallOption) (*DeleteContainerResponse, error)
+	StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error)
+	ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error)
+	DeleteProcess(ctx context.Context, in *DeleteProcessRequest, opts ...grpc.CallOption) (*DeleteProcessResponse, error)
+	RestartContainer(ctx context.Context, in *RestartContainerRequest, opts ...grpc.CallOption) (*RestartContainerResponse, error)
+	CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error)
+	ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error)
+	DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error)
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (API_BackupClient, error)
+	RestoreBackup(ctx context.Context, opts ...grpc.CallOption) (API_RestoreBackupClient, error)
+	CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*CheckConsistencyResponse, error)
+	CreateLease(ctx context.Context, in *CreateLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
+	RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*Lease, error)
+	DeleteLease(ctx context.Context, in *DeleteLeaseRequest, opts ...grpc.CallOption) (*DeleteLeaseResponse, error)
+	ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error)
+	AddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*AddAddressResponse, error)
+	DeleteAddresses(ctx context.Context, in *DeleteAddressesRequest, opts ...grpc.CallOption) (*DeleteAddressesResponse, error)
+	ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error)
+	CreateNetworkNamespace(ctx context.Context, in *CreateNetworkNamespaceRequest, opts ...grpc.CallOption) (*CreateNetworkNamespaceResponse, error)
+	DeleteNetworkNamespace(ctx context.Context, in *DeleteNetworkNamespaceRequest, opts ...grpc.CallOption) (*DeleteNetworkNamespaceResponse, error)
+	ListNetworkNamespaces(ctx context.Context, in *ListNetworkNamespacesRequest, opts ...grpc.CallOption) (*ListNetworkNamespacesResponse, error)
+}
+
+type aPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIClient(cc *grpc.ClientConn) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) CreateContainer(ctx context.Context, in *CreateContainerRequest, opts ...grpc.CallOption) (*CreateContainerResponse, error) {
+	out := new(CreateContainerResponse)
+	err := grpc.Invoke(ctx, "/types.API/CreateContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateContainer(ctx context.Context, in *UpdateContainerRequest, opts ...grpc.CallOption) (*UpdateContainerResponse, error) {
+	out := new(UpdateContainerResponse)
+	err := grpc.Invoke(ctx, "/types.API/UpdateContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error) {
+	out := new(SignalResponse)
+	err := grpc.Invoke(ctx, "/types.API/Signal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateProcess(ctx context.Context, in *UpdateProcessRequest, opts ...grpc.CallOption) (*UpdateProcessResponse, error) {
+	out := new(UpdateProcessResponse)
+	err := grpc.Invoke(ctx, "/types.API/UpdateProcess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddProcess(ctx context.Context, in *AddProcessRequest, opts ...grpc.CallOption) (*AddProcessResponse, error) {
+	out := new(AddProcessResponse)
+	err := grpc.Invoke(ctx, "/types.API/AddProcess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateCheckpoint(ctx context.Context, in *CreateCheckpointRequest, opts ...grpc.CallOption) (*CreateCheckpointResponse, error) {
+	out := new(CreateCheckpointResponse)
+	err := grpc.Invoke(ctx, "/types.API/CreateCheckpoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRequest, opts ...grpc.CallOption) (*DeleteCheckpointResponse, error) {
 	out := new(DeleteCheckpointResponse)
 	err := grpc.Invoke(ctx, "/types.API/DeleteCheckpoint", in, out, c.cc, opts...)
 	if err != nil {
@@ -906,220 +2630,1359 @@ func (c *aPIClient) DeleteCheckpoint(ctx context.Context, in *DeleteCheckpointRe
 	return out, nil
 }
 
-func (c *aPIClient) ListCheckpoint(ctx context.Context, in *ListCheckpointRequest, opts ...grpc.CallOption) (*ListCheckpointResponse, error) {
-	out := new(ListCheckpointResponse)
-	err := grpc.Invoke(ctx, "/types.API/ListCheckpoint", in, out, c.cc, opts...)
+func (c *aPIClient) ListCheckpoint(ctx context.Context, in *ListCheckpointRequest, opts ...grpc.CallOption) (*ListCheckpointResponse, error) {
+	out := new(ListCheckpointResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListCheckpoint", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error) {
+	out := new(StateResponse)
+	err := grpc.Invoke(ctx, "/types.API/State", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (API_EventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[0], c.cc, "/types.API/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_EventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type aPIEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := grpc.Invoke(ctx, "/types.API/Stats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) StatsStream(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (API_StatsStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[1], c.cc, "/types.API/StatsStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIStatsStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_StatsStreamClient interface {
+	Recv() (*StatsResponse, error)
+	grpc.ClientStream
+}
+
+type aPIStatsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIStatsStreamClient) Recv() (*StatsResponse, error) {
+	m := new(StatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) CopyFromContainer(ctx context.Context, in *CopyFromContainerRequest, opts ...grpc.CallOption) (API_CopyFromContainerClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[2], c.cc, "/types.API/CopyFromContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPICopyFromContainerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_CopyFromContainerClient interface {
+	Recv() (*CopyChunk, error)
+	grpc.ClientStream
+}
+
+type aPICopyFromContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPICopyFromContainerClient) Recv() (*CopyChunk, error) {
+	m := new(CopyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (API_CopyToContainerClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[3], c.cc, "/types.API/CopyToContainer", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPICopyToContainerClient{stream}
+	return x, nil
+}
+
+type API_CopyToContainerClient interface {
+	Send(*CopyToContainerRequest) error
+	CloseAndRecv() (*CopyToContainerResponse, error)
+	grpc.ClientStream
+}
+
+type aPICopyToContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPICopyToContainerClient) Send(m *CopyToContainerRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPICopyToContainerClient) CloseAndRecv() (*CopyToContainerResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyToContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error) {
+	out := new(WaitResponse)
+	err := grpc.Invoke(ctx, "/types.API/Wait", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Attach(ctx context.Context, opts ...grpc.CallOption) (API_AttachClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[4], c.cc, "/types.API/Attach", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIAttachClient{stream}
+	return x, nil
+}
+
+type API_AttachClient interface {
+	Send(*AttachRequest) error
+	Recv() (*AttachResponse, error)
+	grpc.ClientStream
+}
+
+type aPIAttachClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIAttachClient) Send(m *AttachRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIAttachClient) Recv() (*AttachResponse, error) {
+	m := new(AttachResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) GetLogs(ctx context.Context, in *GetLogsRequest, opts ...grpc.CallOption) (API_GetLogsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[5], c.cc, "/types.API/GetLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGetLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_GetLogsClient interface {
+	Recv() (*LogEntry, error)
+	grpc.ClientStream
+}
+
+type aPIGetLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIGetLogsClient) Recv() (*LogEntry, error) {
+	m := new(LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) CloseStdin(ctx context.Context, in *CloseStdinRequest, opts ...grpc.CallOption) (*CloseStdinResponse, error) {
+	out := new(CloseStdinResponse)
+	err := grpc.Invoke(ctx, "/types.API/CloseStdin", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UpdateDevice(ctx context.Context, in *UpdateDeviceRequest, opts ...grpc.CallOption) (*UpdateDeviceResponse, error) {
+	out := new(UpdateDeviceResponse)
+	err := grpc.Invoke(ctx, "/types.API/UpdateDevice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FreezeContainers(ctx context.Context, in *FreezeContainersRequest, opts ...grpc.CallOption) (*FreezeContainersResponse, error) {
+	out := new(FreezeContainersResponse)
+	err := grpc.Invoke(ctx, "/types.API/FreezeContainers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*CreateGroupResponse, error) {
+	out := new(CreateGroupResponse)
+	err := grpc.Invoke(ctx, "/types.API/CreateGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteGroup(ctx context.Context, in *DeleteGroupRequest, opts ...grpc.CallOption) (*DeleteGroupResponse, error) {
+	out := new(DeleteGroupResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteGroup", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListGroups", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteVolume(ctx context.Context, in *DeleteVolumeRequest, opts ...grpc.CallOption) (*DeleteVolumeResponse, error) {
+	out := new(DeleteVolumeResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DumpState(ctx context.Context, in *DumpStateRequest, opts ...grpc.CallOption) (*DumpStateResponse, error) {
+	out := new(DumpStateResponse)
+	err := grpc.Invoke(ctx, "/types.API/DumpState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Healthz(ctx context.Context, in *HealthzRequest, opts ...grpc.CallOption) (*HealthzResponse, error) {
+	out := new(HealthzResponse)
+	err := grpc.Invoke(ctx, "/types.API/Healthz", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := grpc.Invoke(ctx, "/types.API/Capabilities", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) UploadBundle(ctx context.Context, opts ...grpc.CallOption) (API_UploadBundleClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[6], c.cc, "/types.API/UploadBundle", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIUploadBundleClient{stream}
+	return x, nil
+}
+
+type API_UploadBundleClient interface {
+	Send(*UploadBundleRequest) error
+	CloseAndRecv() (*UploadBundleResponse, error)
+	grpc.ClientStream
+}
+
+type aPIUploadBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIUploadBundleClient) Send(m *UploadBundleRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIUploadBundleClient) CloseAndRecv() (*UploadBundleResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBundleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) UpdateContainerSpec(ctx context.Context, in *UpdateContainerSpecRequest, opts ...grpc.CallOption) (*UpdateContainerSpecResponse, error) {
+	out := new(UpdateContainerSpecResponse)
+	err := grpc.Invoke(ctx, "/types.API/UpdateContainerSpec", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteContainer(ctx context.Context, in *DeleteContainerRequest, opts ...grpc.CallOption) (*DeleteContainerResponse, error) {
+	out := new(DeleteContainerResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) StopContainer(ctx context.Context, in *StopContainerRequest, opts ...grpc.CallOption) (*StopContainerResponse, error) {
+	out := new(StopContainerResponse)
+	err := grpc.Invoke(ctx, "/types.API/StopContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListProcesses(ctx context.Context, in *ListProcessesRequest, opts ...grpc.CallOption) (*ListProcessesResponse, error) {
+	out := new(ListProcessesResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListProcesses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteProcess(ctx context.Context, in *DeleteProcessRequest, opts ...grpc.CallOption) (*DeleteProcessResponse, error) {
+	out := new(DeleteProcessResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteProcess", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RestartContainer(ctx context.Context, in *RestartContainerRequest, opts ...grpc.CallOption) (*RestartContainerResponse, error) {
+	out := new(RestartContainerResponse)
+	err := grpc.Invoke(ctx, "/types.API/RestartContainer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error) {
+	out := new(CreateTemplateResponse)
+	err := grpc.Invoke(ctx, "/types.API/CreateTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	out := new(ListTemplatesResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListTemplates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	out := new(DeleteTemplateResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteTemplate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (API_BackupClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[7], c.cc, "/types.API/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type aPIBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) RestoreBackup(ctx context.Context, opts ...grpc.CallOption) (API_RestoreBackupClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[8], c.cc, "/types.API/RestoreBackup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIRestoreBackupClient{stream}
+	return x, nil
+}
+
+type API_RestoreBackupClient interface {
+	Send(*RestoreBackupRequest) error
+	CloseAndRecv() (*RestoreBackupResponse, error)
+	grpc.ClientStream
+}
+
+type aPIRestoreBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIRestoreBackupClient) Send(m *RestoreBackupRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIRestoreBackupClient) CloseAndRecv() (*RestoreBackupResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreBackupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*CheckConsistencyResponse, error) {
+	out := new(CheckConsistencyResponse)
+	err := grpc.Invoke(ctx, "/types.API/CheckConsistency", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateLease(ctx context.Context, in *CreateLeaseRequest, opts ...grpc.CallOption) (*Lease, error) {
+	out := new(Lease)
+	err := grpc.Invoke(ctx, "/types.API/CreateLease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) RenewLease(ctx context.Context, in *RenewLeaseRequest, opts ...grpc.CallOption) (*Lease, error) {
+	out := new(Lease)
+	err := grpc.Invoke(ctx, "/types.API/RenewLease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteLease(ctx context.Context, in *DeleteLeaseRequest, opts ...grpc.CallOption) (*DeleteLeaseResponse, error) {
+	out := new(DeleteLeaseResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteLease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListLeases(ctx context.Context, in *ListLeasesRequest, opts ...grpc.CallOption) (*ListLeasesResponse, error) {
+	out := new(ListLeasesResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListLeases", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AddAddress(ctx context.Context, in *AddAddressRequest, opts ...grpc.CallOption) (*AddAddressResponse, error) {
+	out := new(AddAddressResponse)
+	err := grpc.Invoke(ctx, "/types.API/AddAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteAddresses(ctx context.Context, in *DeleteAddressesRequest, opts ...grpc.CallOption) (*DeleteAddressesResponse, error) {
+	out := new(DeleteAddressesResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteAddresses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListAddresses(ctx context.Context, in *ListAddressesRequest, opts ...grpc.CallOption) (*ListAddressesResponse, error) {
+	out := new(ListAddressesResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListAddresses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) CreateNetworkNamespace(ctx context.Context, in *CreateNetworkNamespaceRequest, opts ...grpc.CallOption) (*CreateNetworkNamespaceResponse, error) {
+	out := new(CreateNetworkNamespaceResponse)
+	err := grpc.Invoke(ctx, "/types.API/CreateNetworkNamespace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) DeleteNetworkNamespace(ctx context.Context, in *DeleteNetworkNamespaceRequest, opts ...grpc.CallOption) (*DeleteNetworkNamespaceResponse, error) {
+	out := new(DeleteNetworkNamespaceResponse)
+	err := grpc.Invoke(ctx, "/types.API/DeleteNetworkNamespace", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListNetworkNamespaces(ctx context.Context, in *ListNetworkNamespacesRequest, opts ...grpc.CallOption) (*ListNetworkNamespacesResponse, error) {
+	out := new(ListNetworkNamespacesResponse)
+	err := grpc.Invoke(ctx, "/types.API/ListNetworkNamespaces", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for API service
+
+type APIServer interface {
+	CreateContainer(context.Context, *CreateContainerRequest) (*CreateContainerResponse, error)
+	UpdateContainer(context.Context, *UpdateContainerRequest) (*UpdateContainerResponse, error)
+	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
+	UpdateProcess(context.Context, *UpdateProcessRequest) (*UpdateProcessResponse, error)
+	AddProcess(context.Context, *AddProcessRequest) (*AddProcessResponse, error)
+	CreateCheckpoint(context.Context, *CreateCheckpointRequest) (*CreateCheckpointResponse, error)
+	DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*DeleteCheckpointResponse, error)
+	ListCheckpoint(context.Context, *ListCheckpointRequest) (*ListCheckpointResponse, error)
+	State(context.Context, *StateRequest) (*StateResponse, error)
+	Events(*EventsRequest, API_EventsServer) error
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	StatsStream(*StatsRequest, API_StatsStreamServer) error
+	CopyFromContainer(*CopyFromContainerRequest, API_CopyFromContainerServer) error
+	CopyToContainer(API_CopyToContainerServer) error
+	Wait(context.Context, *WaitRequest) (*WaitResponse, error)
+	Attach(API_AttachServer) error
+	GetLogs(*GetLogsRequest, API_GetLogsServer) error
+	CloseStdin(context.Context, *CloseStdinRequest) (*CloseStdinResponse, error)
+	UpdateDevice(context.Context, *UpdateDeviceRequest) (*UpdateDeviceResponse, error)
+	FreezeContainers(context.Context, *FreezeContainersRequest) (*FreezeContainersResponse, error)
+	CreateGroup(context.Context, *CreateGroupRequest) (*CreateGroupResponse, error)
+	DeleteGroup(context.Context, *DeleteGroupRequest) (*DeleteGroupResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	DeleteVolume(context.Context, *DeleteVolumeRequest) (*DeleteVolumeResponse, error)
+	DumpState(context.Context, *DumpStateRequest) (*DumpStateResponse, error)
+	Healthz(context.Context, *HealthzRequest) (*HealthzResponse, error)
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+	UploadBundle(API_UploadBundleServer) error
+	UpdateContainerSpec(context.Context, *UpdateContainerSpecRequest) (*UpdateContainerSpecResponse, error)
+	DeleteContainer(context.Context, *DeleteContainerRequest) (*DeleteContainerResponse, error)
+	StopContainer(context.Context, *StopContainerRequest) (*StopContainerResponse, error)
+	ListProcesses(context.Context, *ListProcessesRequest) (*ListProcessesResponse, error)
+	DeleteProcess(context.Context, *DeleteProcessRequest) (*DeleteProcessResponse, error)
+	RestartContainer(context.Context, *RestartContainerRequest) (*RestartContainerResponse, error)
+	CreateTemplate(context.Context, *CreateTemplateRequest) (*CreateTemplateResponse, error)
+	ListTemplates(context.Context, *ListTemplatesRequest) (*ListTemplatesResponse, error)
+	DeleteTemplate(context.Context, *DeleteTemplateRequest) (*DeleteTemplateResponse, error)
+	Backup(*BackupRequest, API_BackupServer) error
+	RestoreBackup(API_RestoreBackupServer) error
+	CheckConsistency(context.Context, *CheckConsistencyRequest) (*CheckConsistencyResponse, error)
+	CreateLease(context.Context, *CreateLeaseRequest) (*Lease, error)
+	RenewLease(context.Context, *RenewLeaseRequest) (*Lease, error)
+	DeleteLease(context.Context, *DeleteLeaseRequest) (*DeleteLeaseResponse, error)
+	ListLeases(context.Context, *ListLeasesRequest) (*ListLeasesResponse, error)
+	AddAddress(context.Context, *AddAddressRequest) (*AddAddressResponse, error)
+	DeleteAddresses(context.Context, *DeleteAddressesRequest) (*DeleteAddressesResponse, error)
+	ListAddresses(context.Context, *ListAddressesRequest) (*ListAddressesResponse, error)
+	CreateNetworkNamespace(context.Context, *CreateNetworkNamespaceRequest) (*CreateNetworkNamespaceResponse, error)
+	DeleteNetworkNamespace(context.Context, *DeleteNetworkNamespaceRequest) (*DeleteNetworkNamespaceResponse, error)
+	ListNetworkNamespaces(context.Context, *ListNetworkNamespacesRequest) (*ListNetworkNamespacesResponse, error)
+}
+
+func RegisterAPIServer(s *grpc.Server, srv APIServer) {
+	s.RegisterService(&_API_serviceDesc, srv)
+}
+
+func _API_CreateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).CreateContainer(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_UpdateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(UpdateContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).UpdateContainer(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).Signal(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_UpdateProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(UpdateProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).UpdateProcess(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_AddProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AddProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).AddProcess(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_CreateCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).CreateCheckpoint(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DeleteCheckpoint(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_ListCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListCheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).ListCheckpoint(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_State_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).State(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).Events(m, &aPIEventsServer{stream})
+}
+
+type API_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type aPIEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).Stats(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_StatsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).StatsStream(m, &aPIStatsStreamServer{stream})
+}
+
+type API_StatsStreamServer interface {
+	Send(*StatsResponse) error
+	grpc.ServerStream
+}
+
+type aPIStatsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIStatsStreamServer) Send(m *StatsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_CopyFromContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromContainerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).CopyFromContainer(m, &aPICopyFromContainerServer{stream})
+}
+
+type API_CopyFromContainerServer interface {
+	Send(*CopyChunk) error
+	grpc.ServerStream
+}
+
+type aPICopyFromContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPICopyFromContainerServer) Send(m *CopyChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_CopyToContainer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).CopyToContainer(&aPICopyToContainerServer{stream})
+}
+
+type API_CopyToContainerServer interface {
+	SendAndClose(*CopyToContainerResponse) error
+	Recv() (*CopyToContainerRequest, error)
+	grpc.ServerStream
+}
+
+type aPICopyToContainerServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPICopyToContainerServer) SendAndClose(m *CopyToContainerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPICopyToContainerServer) Recv() (*CopyToContainerRequest, error) {
+	m := new(CopyToContainerRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_Wait_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(WaitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).Wait(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).Attach(&aPIAttachServer{stream})
+}
+
+type API_AttachServer interface {
+	Send(*AttachResponse) error
+	Recv() (*AttachRequest, error)
+	grpc.ServerStream
+}
+
+type aPIAttachServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIAttachServer) Send(m *AttachResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIAttachServer) Recv() (*AttachRequest, error) {
+	m := new(AttachRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_GetLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).GetLogs(m, &aPIGetLogsServer{stream})
+}
+
+type API_GetLogsServer interface {
+	Send(*LogEntry) error
+	grpc.ServerStream
+}
+
+type aPIGetLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIGetLogsServer) Send(m *LogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_CloseStdin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CloseStdinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).CloseStdin(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_UpdateDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(UpdateDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).UpdateDevice(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_FreezeContainers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(FreezeContainersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).FreezeContainers(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).CreateGroup(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DeleteGroup(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).ListGroups(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_DeleteVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DeleteVolume(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_DumpState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DumpStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DumpState(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(HealthzRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).Healthz(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).Capabilities(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_UploadBundle_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).UploadBundle(&aPIUploadBundleServer{stream})
+}
+
+type API_UploadBundleServer interface {
+	SendAndClose(*UploadBundleResponse) error
+	Recv() (*UploadBundleRequest, error)
+	grpc.ServerStream
+}
+
+type aPIUploadBundleServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIUploadBundleServer) SendAndClose(m *UploadBundleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIUploadBundleServer) Recv() (*UploadBundleRequest, error) {
+	m := new(UploadBundleRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_UpdateContainerSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(UpdateContainerSpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).UpdateContainerSpec(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _API_DeleteContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DeleteContainer(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error) {
-	out := new(StateResponse)
-	err := grpc.Invoke(ctx, "/types.API/State", in, out, c.cc, opts...)
+func _API_StopContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(StopContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).StopContainer(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *aPIClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (API_EventsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_API_serviceDesc.Streams[0], c.cc, "/types.API/Events", opts...)
+func _API_ListProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).ListProcesses(ctx, in)
 	if err != nil {
 		return nil, err
 	}
-	x := &aPIEventsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return out, nil
+}
+
+func _API_DeleteProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteProcessRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	out, err := srv.(APIServer).DeleteProcess(ctx, in)
+	if err != nil {
 		return nil, err
 	}
-	return x, nil
+	return out, nil
 }
 
-type API_EventsClient interface {
-	Recv() (*Event, error)
-	grpc.ClientStream
+func _API_RestartContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(RestartContainerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).RestartContainer(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type aPIEventsClient struct {
-	grpc.ClientStream
+func _API_CreateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).CreateTemplate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *aPIEventsClient) Recv() (*Event, error) {
-	m := new(Event)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _API_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListTemplatesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	out, err := srv.(APIServer).ListTemplates(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *aPIClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
-	out := new(StatsResponse)
-	err := grpc.Invoke(ctx, "/types.API/Stats", in, out, c.cc, opts...)
+func _API_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(APIServer).DeleteTemplate(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for API service
+func _API_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).Backup(m, &aPIBackupServer{stream})
+}
 
-type APIServer interface {
-	CreateContainer(context.Context, *CreateContainerRequest) (*CreateContainerResponse, error)
-	UpdateContainer(context.Context, *UpdateContainerRequest) (*UpdateContainerResponse, error)
-	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
-	UpdateProcess(context.Context, *UpdateProcessRequest) (*UpdateProcessResponse, error)
-	AddProcess(context.Context, *AddProcessRequest) (*AddProcessResponse, error)
-	CreateCheckpoint(context.Context, *CreateCheckpointRequest) (*CreateCheckpointResponse, error)
-	DeleteCheckpoint(context.Context, *DeleteCheckpointRequest) (*DeleteCheckpointResponse, error)
-	ListCheckpoint(context.Context, *ListCheckpointRequest) (*ListCheckpointResponse, error)
-	State(context.Context, *StateRequest) (*StateResponse, error)
-	Events(*EventsRequest, API_EventsServer) error
-	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+type API_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
 }
 
-func RegisterAPIServer(s *grpc.Server, srv APIServer) {
-	s.RegisterService(&_API_serviceDesc, srv)
+type aPIBackupServer struct {
+	grpc.ServerStream
 }
 
-func _API_CreateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(CreateContainerRequest)
+func (x *aPIBackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_RestoreBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).RestoreBackup(&aPIRestoreBackupServer{stream})
+}
+
+type API_RestoreBackupServer interface {
+	SendAndClose(*RestoreBackupResponse) error
+	Recv() (*RestoreBackupRequest, error)
+	grpc.ServerStream
+}
+
+type aPIRestoreBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIRestoreBackupServer) SendAndClose(m *RestoreBackupResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIRestoreBackupServer) Recv() (*RestoreBackupRequest, error) {
+	m := new(RestoreBackupRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_CheckConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CheckConsistencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).CreateContainer(ctx, in)
+	out, err := srv.(APIServer).CheckConsistency(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_UpdateContainer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(UpdateContainerRequest)
+func _API_CreateLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateLeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).UpdateContainer(ctx, in)
+	out, err := srv.(APIServer).CreateLease(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(SignalRequest)
+func _API_RenewLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(RenewLeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).Signal(ctx, in)
+	out, err := srv.(APIServer).RenewLease(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_UpdateProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(UpdateProcessRequest)
+func _API_DeleteLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteLeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).UpdateProcess(ctx, in)
+	out, err := srv.(APIServer).DeleteLease(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_AddProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(AddProcessRequest)
+func _API_ListLeases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListLeasesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).AddProcess(ctx, in)
+	out, err := srv.(APIServer).ListLeases(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_CreateCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(CreateCheckpointRequest)
+func _API_AddAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AddAddressRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).CreateCheckpoint(ctx, in)
+	out, err := srv.(APIServer).AddAddress(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_DeleteCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(DeleteCheckpointRequest)
+func _API_DeleteAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteAddressesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).DeleteCheckpoint(ctx, in)
+	out, err := srv.(APIServer).DeleteAddresses(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_ListCheckpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(ListCheckpointRequest)
+func _API_ListAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListAddressesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).ListCheckpoint(ctx, in)
+	out, err := srv.(APIServer).ListAddresses(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_State_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(StateRequest)
+func _API_CreateNetworkNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(CreateNetworkNamespaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).State(ctx, in)
+	out, err := srv.(APIServer).CreateNetworkNamespace(ctx, in)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func _API_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(EventsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _API_DeleteNetworkNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(DeleteNetworkNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(APIServer).Events(m, &aPIEventsServer{stream})
-}
-
-type API_EventsServer interface {
-	Send(*Event) error
-	grpc.ServerStream
-}
-
-type aPIEventsServer struct {
-	grpc.ServerStream
-}
-
-func (x *aPIEventsServer) Send(m *Event) error {
-	return x.ServerStream.SendMsg(m)
+	out, err := srv.(APIServer).DeleteNetworkNamespace(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func _API_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
-	in := new(StatsRequest)
+func _API_ListNetworkNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(ListNetworkNamespacesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
-	out, err := srv.(APIServer).Stats(ctx, in)
+	out, err := srv.(APIServer).ListNetworkNamespaces(ctx, in)
 	if err != nil {
 		return nil, err
 	}
@@ -1170,6 +4033,130 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Stats",
 			Handler:    _API_Stats_Handler,
 		},
+		{
+			MethodName: "Wait",
+			Handler:    _API_Wait_Handler,
+		},
+		{
+			MethodName: "CloseStdin",
+			Handler:    _API_CloseStdin_Handler,
+		},
+		{
+			MethodName: "UpdateDevice",
+			Handler:    _API_UpdateDevice_Handler,
+		},
+		{
+			MethodName: "FreezeContainers",
+			Handler:    _API_FreezeContainers_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _API_CreateGroup_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _API_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _API_ListGroups_Handler,
+		},
+		{
+			MethodName: "DeleteVolume",
+			Handler:    _API_DeleteVolume_Handler,
+		},
+		{
+			MethodName: "DumpState",
+			Handler:    _API_DumpState_Handler,
+		},
+		{
+			MethodName: "Healthz",
+			Handler:    _API_Healthz_Handler,
+		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _API_Capabilities_Handler,
+		},
+		{
+			MethodName: "UpdateContainerSpec",
+			Handler:    _API_UpdateContainerSpec_Handler,
+		},
+		{
+			MethodName: "DeleteContainer",
+			Handler:    _API_DeleteContainer_Handler,
+		},
+		{
+			MethodName: "StopContainer",
+			Handler:    _API_StopContainer_Handler,
+		},
+		{
+			MethodName: "ListProcesses",
+			Handler:    _API_ListProcesses_Handler,
+		},
+		{
+			MethodName: "DeleteProcess",
+			Handler:    _API_DeleteProcess_Handler,
+		},
+		{
+			MethodName: "RestartContainer",
+			Handler:    _API_RestartContainer_Handler,
+		},
+		{
+			MethodName: "CreateTemplate",
+			Handler:    _API_CreateTemplate_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _API_ListTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _API_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "CheckConsistency",
+			Handler:    _API_CheckConsistency_Handler,
+		},
+		{
+			MethodName: "CreateLease",
+			Handler:    _API_CreateLease_Handler,
+		},
+		{
+			MethodName: "RenewLease",
+			Handler:    _API_RenewLease_Handler,
+		},
+		{
+			MethodName: "DeleteLease",
+			Handler:    _API_DeleteLease_Handler,
+		},
+		{
+			MethodName: "ListLeases",
+			Handler:    _API_ListLeases_Handler,
+		},
+		{
+			MethodName: "AddAddress",
+			Handler:    _API_AddAddress_Handler,
+		},
+		{
+			MethodName: "DeleteAddresses",
+			Handler:    _API_DeleteAddresses_Handler,
+		},
+		{
+			MethodName: "ListAddresses",
+			Handler:    _API_ListAddresses_Handler,
+		},
+		{
+			MethodName: "CreateNetworkNamespace",
+			Handler:    _API_CreateNetworkNamespace_Handler,
+		},
+		{
+			MethodName: "DeleteNetworkNamespace",
+			Handler:    _API_DeleteNetworkNamespace_Handler,
+		},
+		{
+			MethodName: "ListNetworkNamespaces",
+			Handler:    _API_ListNetworkNamespaces_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1177,121 +4164,376 @@ var _API_serviceDesc = grpc.ServiceDesc{
 			Handler:       _API_Events_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StatsStream",
+			Handler:       _API_StatsStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyFromContainer",
+			Handler:       _API_CopyFromContainer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyToContainer",
+			Handler:       _API_CopyToContainer_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _API_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetLogs",
+			Handler:       _API_GetLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadBundle",
+			Handler:       _API_UploadBundle_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _API_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreBackup",
+			Handler:       _API_RestoreBackup_Handler,
+			ClientStreams: true,
+		},
 	},
 }
 
 var fileDescriptor0 = []byte{
-	// 1791 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x58, 0xd9, 0x8e, 0xdb, 0x54,
-	0x18, 0x9e, 0xec, 0x93, 0x3f, 0x71, 0x66, 0xc6, 0xb3, 0x65, 0xd2, 0x6d, 0x70, 0x0b, 0x1d, 0xa1,
-	0x6a, 0x54, 0x52, 0x96, 0x52, 0x24, 0x44, 0x99, 0x56, 0x14, 0x34, 0x2d, 0xe9, 0x2c, 0x20, 0xae,
-	0x22, 0xc7, 0x3e, 0x4d, 0xcc, 0x38, 0xb6, 0xf1, 0x39, 0x9e, 0xe5, 0x19, 0x10, 0xb7, 0xbc, 0x04,
-	0x12, 0xe2, 0x8a, 0x07, 0xe0, 0x59, 0xb8, 0xe2, 0x29, 0xf8, 0xcf, 0x62, 0xc7, 0x76, 0x96, 0x41,
-	0x42, 0x5c, 0x70, 0x13, 0xe5, 0x9c, 0xf3, 0xaf, 0xdf, 0xbf, 0x9d, 0x63, 0xa8, 0x9b, 0x81, 0xb3,
-	0x1f, 0x84, 0x3e, 0xf3, 0xf5, 0x0a, 0xbb, 0x0a, 0x08, 0x35, 0x06, 0xb0, 0x71, 0x1a, 0xd8, 0x26,
-	0x23, 0xbd, 0xd0, 0xb7, 0x08, 0xa5, 0x47, 0xe4, 0x87, 0x88, 0x50, 0xa6, 0x03, 0x14, 0x1d, 0xbb,
-	0x5d, 0xd8, 0x2d, 0xec, 0xd5, 0xf5, 0x06, 0x94, 0x02, 0x5c, 0x14, 0xc5, 0x02, 0x4f, 0x2c, 0xd7,
-	0xa7, 0xe4, 0x98, 0xd9, 0x8e, 0xd7, 0x2e, 0xe1, 0xde, 0xb2, 0xae, 0x41, 0xe5, 0xc2, 0xb1, 0xd9,
-	0xa8, 0x5d, 0xc6, 0xa5, 0xa6, 0xb7, 0xa0, 0x3a, 0x22, 0xce, 0x70, 0xc4, 0xda, 0x15, 0xbe, 0x36,
-	0xb6, 0x61, 0x33, 0xa7, 0x83, 0x06, 0xbe, 0x47, 0x89, 0xf1, 0x63, 0x01, 0xb6, 0x0e, 0x42, 0x82,
-	0x27, 0x07, 0xbe, 0xc7, 0x4c, 0xc7, 0x23, 0xe1, 0x2c, 0xfd, 0xb8, 0x18, 0x44, 0x9e, 0xed, 0x92,
-	0x9e, 0x89, 0x3a, 0x26, 0x66, 0x8c, 0x88, 0x75, 0x16, 0xf8, 0x8e, 0xc7, 0x84, 0x19, 0x75, 0x6e,
-	0x06, 0x15, 0x56, 0x95, 0xc5, 0x12, 0xcd, 0xc0, 0xa5, 0x1f, 0x49, 0x33, 0xe2, 0x35, 0x09, 0xc3,
-	0x76, 0x35, 0x5e, 0xbb, 0xe6, 0x80, 0xb8, 0xb4, 0x5d, 0xdb, 0x2d, 0xed, 0xd5, 0x8d, 0x4f, 0x61,
-	0x7b, 0xca, 0x18, 0x69, 0xa8, 0x7e, 0x17, 0xea, 0x56, 0xbc, 0x29, 0x8c, 0x6a, 0x74, 0x57, 0xf7,
-	0x05, 0x80, 0xfb, 0x09, 0xb1, 0xf1, 0x18, 0xb4, 0x63, 0x67, 0xe8, 0x99, 0xee, 0xb5, 0x18, 0x72,
-	0x4b, 0x04, 0xa5, 0x30, 0x5c, 0x33, 0x56, 0xa1, 0x15, 0x73, 0x2a, 0x64, 0x7e, 0x2d, 0xc2, 0xda,
-	0x53, 0xdb, 0x5e, 0x10, 0x94, 0x55, 0x58, 0x66, 0x24, 0x1c, 0x3b, 0x5c, 0x4a, 0x51, 0x44, 0x61,
-	0x07, 0xca, 0x11, 0x45, 0xfb, 0x4a, 0xc2, 0xbe, 0x86, 0xb2, 0xef, 0x14, 0xb7, 0xf4, 0x26, 0x94,
-	0xcd, 0x70, 0x48, 0x11, 0x98, 0x92, 0xb4, 0x85, 0x78, 0xe7, 0x88, 0x8a, 0x5a, 0x58, 0x17, 0xb6,
-	0x82, 0x44, 0x59, 0x59, 0xcb, 0xc2, 0xb9, 0x9c, 0x83, 0xb3, 0x9e, 0x83, 0x13, 0xc4, 0x7a, 0x03,
-	0x9a, 0x96, 0x19, 0x98, 0x03, 0xc7, 0x75, 0x98, 0x43, 0x68, 0xbb, 0x21, 0xc4, 0x6f, 0xc3, 0x8a,
-	0x19, 0x04, 0x66, 0x38, 0xf6, 0x43, 0x74, 0xe6, 0x8d, 0xe3, 0x92, 0x76, 0x33, 0x26, 0xa7, 0xc4,
-	0x75, 0xbc, 0xe8, 0xf2, 0x90, 0x07, 0xa1, 0xad, 0x89, 0x5d, 0x24, 0xf7, 0xfc, 0x57, 0xe4, 0xa2,
-	0x17, 0x3a, 0xe7, 0x48, 0x3b, 0x44, 0x39, 0x2d, 0xe1, 0xdc, 0x6d, 0xa8, 0x85, 0xae, 0x33, 0x76,
-	0x18, 0x6d, 0xaf, 0xa0, 0xe0, 0x46, 0x57, 0x53, 0xfe, 0x1d, 0x89, 0x5d, 0xa3, 0x0b, 0x55, 0xf9,
-	0x8f, 0xfb, 0xca, 0x4f, 0x14, 0x4c, 0xb8, 0xa2, 0xfe, 0x1b, 0x26, 0x20, 0x2a, 0xf3, 0xd5, 0xc8,
-	0x0c, 0x6d, 0x01, 0x51, 0x19, 0x03, 0x56, 0x16, 0xe8, 0xa0, 0xd7, 0x91, 0xc2, 0x55, 0xe3, 0x8b,
-	0xa1, 0x0a, 0x94, 0xa6, 0x6f, 0x41, 0xcb, 0xb4, 0x6d, 0xf4, 0xc7, 0x47, 0x98, 0xbf, 0x70, 0x6c,
-	0x8a, 0x9c, 0x25, 0x0c, 0xd8, 0x06, 0xe8, 0xe9, 0xe8, 0xa8, 0xa0, 0x1d, 0x26, 0x09, 0x94, 0x64,
-	0xe6, 0xac, 0xc8, 0xbd, 0x9d, 0x49, 0xdd, 0xa2, 0x88, 0xd6, 0x5a, 0x9c, 0x4d, 0xc9, 0x81, 0xd1,
-	0x81, 0xf6, 0xb4, 0x34, 0xa5, 0xe9, 0x11, 0x6c, 0x3f, 0x23, 0x2e, 0xb9, 0x4e, 0x13, 0xba, 0xeb,
-	0x99, 0x63, 0x22, 0xb3, 0x8e, 0x0b, 0x9c, 0x66, 0x52, 0x02, 0xef, 0xc2, 0xe6, 0xa1, 0x43, 0xd9,
-	0x42, 0x71, 0xc6, 0x77, 0x00, 0x13, 0x82, 0x44, 0x78, 0xa2, 0x8a, 0x5c, 0x3a, 0x4c, 0xa5, 0x22,
-	0x82, 0xc8, 0xac, 0x40, 0x75, 0x87, 0x75, 0x68, 0x44, 0x9e, 0x73, 0x79, 0xec, 0x5b, 0x67, 0x84,
-	0x51, 0x51, 0x9c, 0xa2, 0x65, 0xd0, 0x11, 0x71, 0x5d, 0x51, 0x9b, 0xcb, 0xc6, 0x67, 0xb0, 0x95,
-	0xd7, 0xaf, 0x4a, 0xef, 0x1d, 0x68, 0x4c, 0xd0, 0xa2, 0xa8, 0xad, 0x34, 0x0f, 0xae, 0xe6, 0x31,
-	0x43, 0xb4, 0x66, 0x19, 0xbe, 0x0b, 0xad, 0xa4, 0x4c, 0x05, 0x91, 0x4c, 0x5e, 0x93, 0x45, 0x54,
-	0x51, 0xfc, 0x52, 0x84, 0x9a, 0x0a, 0x67, 0x5c, 0x04, 0xff, 0x61, 0x99, 0xad, 0x41, 0x9d, 0x5e,
-	0x51, 0x46, 0xc6, 0x3d, 0x55, 0x6c, 0xda, 0xff, 0xab, 0xd8, 0x7e, 0x2a, 0x40, 0x3d, 0x01, 0xf4,
-	0xda, 0x56, 0xfd, 0x16, 0xd4, 0x03, 0x09, 0x2d, 0x91, 0xf5, 0xd3, 0xe8, 0xb6, 0x94, 0xbc, 0x18,
-	0xf2, 0x49, 0x38, 0xca, 0xb9, 0xd6, 0x2c, 0xd1, 0x43, 0x60, 0x03, 0x5e, 0x7d, 0x55, 0x5e, 0x7d,
-	0xfa, 0x0a, 0x9a, 0x17, 0x79, 0xcc, 0xc1, 0xe4, 0x13, 0x9d, 0xca, 0xb8, 0x0f, 0xb5, 0x97, 0xa6,
-	0x35, 0x42, 0x6b, 0x38, 0xa5, 0x15, 0xa8, 0xb0, 0x8a, 0x49, 0x34, 0x26, 0x88, 0xc6, 0x95, 0xac,
-	0x7f, 0xe3, 0x1b, 0x6c, 0xd1, 0x32, 0x49, 0x54, 0x76, 0xdd, 0xc3, 0x5a, 0x8c, 0x1d, 0x89, 0x93,
-	0x6b, 0xaa, 0xb3, 0xeb, 0x77, 0xa0, 0x36, 0x96, 0xf2, 0x55, 0xb9, 0xc6, 0xf6, 0x2b, 0xad, 0xc6,
-	0x19, 0x6c, 0xc9, 0x09, 0xb7, 0x70, 0x8e, 0x4d, 0xcd, 0x00, 0xe9, 0xb2, 0x1c, 0x5e, 0x7b, 0x50,
-	0x0f, 0x09, 0xf5, 0xa3, 0x10, 0x01, 0x11, 0x28, 0x34, 0xba, 0x9b, 0x71, 0x6e, 0x09, 0xd1, 0x47,
-	0xea, 0xd4, 0xf8, 0xb3, 0x00, 0xad, 0xec, 0x16, 0x2f, 0xb1, 0x81, 0x7b, 0xe6, 0xf8, 0xdf, 0xca,
-	0xb1, 0x2b, 0x9d, 0xc7, 0x2c, 0x43, 0x28, 0x8e, 0xb1, 0xe1, 0xa1, 0xc4, 0x62, 0x6a, 0xab, 0x47,
-	0x42, 0xc7, 0x97, 0x4d, 0x50, 0xe3, 0x09, 0x8e, 0x5b, 0xaf, 0x23, 0x9f, 0x99, 0x6a, 0x7c, 0xf3,
-	0xd1, 0x8a, 0x10, 0x12, 0x76, 0xc0, 0x81, 0xac, 0x24, 0xe3, 0x56, 0xec, 0xbd, 0x24, 0x63, 0xaa,
-	0xb2, 0x18, 0x95, 0x4a, 0x70, 0x0f, 0x79, 0x52, 0xa8, 0x3c, 0x46, 0x42, 0xb9, 0x79, 0x7c, 0x61,
-	0x06, 0x22, 0x99, 0x35, 0xac, 0x98, 0x35, 0xb9, 0x87, 0xf6, 0x92, 0xf0, 0xdc, 0xe4, 0xed, 0x54,
-	0xe4, 0xb5, 0x38, 0x3a, 0x23, 0xa1, 0x47, 0xdc, 0x97, 0x29, 0x49, 0x20, 0x86, 0xe2, 0x0e, 0x6c,
-	0x4f, 0x61, 0xaa, 0xba, 0x95, 0x01, 0xda, 0xf3, 0x73, 0x82, 0xed, 0x20, 0x46, 0x19, 0xfd, 0xe2,
-	0xe9, 0x80, 0x80, 0x8e, 0x03, 0xe1, 0x7d, 0xd9, 0x78, 0x0d, 0x15, 0x41, 0x93, 0x9b, 0x07, 0x32,
-	0x1e, 0xb3, 0x42, 0xa0, 0xc5, 0xf1, 0x29, 0xc7, 0x35, 0x3a, 0x11, 0x59, 0x11, 0x22, 0x7f, 0x2f,
-	0x40, 0xf3, 0x15, 0x61, 0x17, 0x7e, 0x78, 0xc6, 0xb3, 0x88, 0xe6, 0x5a, 0x20, 0x22, 0x19, 0x5e,
-	0xf6, 0x07, 0x57, 0x4c, 0xc1, 0x5d, 0xe6, 0x60, 0xe0, 0x4e, 0xcf, 0x94, 0x8d, 0x4f, 0x0c, 0x1d,
-	0x2e, 0xf7, 0xe8, 0xb2, 0x8f, 0x95, 0xec, 0x87, 0x32, 0xce, 0x82, 0x0c, 0xb7, 0xec, 0xd0, 0x0f,
-	0x02, 0x62, 0x4b, 0x5d, 0x5c, 0xd8, 0x49, 0x2c, 0xac, 0x1a, 0x53, 0xe1, 0x4e, 0xa0, 0x84, 0xd5,
-	0x62, 0x61, 0x27, 0x89, 0xb0, 0xe5, 0x14, 0x59, 0x2c, 0xac, 0x2e, 0x0c, 0x1f, 0xc3, 0x32, 0xc6,
-	0xf2, 0x94, 0x9a, 0x43, 0x91, 0x2a, 0x0c, 0x63, 0xed, 0xf6, 0x23, 0xbe, 0x94, 0x60, 0xf1, 0xfe,
-	0x10, 0x90, 0x10, 0x23, 0xac, 0x76, 0x8b, 0x58, 0x08, 0x65, 0xfd, 0x06, 0xac, 0x8b, 0x65, 0xdf,
-	0xf1, 0xfa, 0x32, 0x4a, 0x63, 0xdf, 0x26, 0xca, 0x0f, 0x8c, 0x5c, 0x72, 0xc8, 0xfb, 0xa1, 0x38,
-	0x12, 0xfe, 0x18, 0x27, 0xd0, 0x3a, 0x19, 0xe1, 0x25, 0x93, 0x61, 0xc7, 0x19, 0x3e, 0x33, 0x99,
-	0xc9, 0x2b, 0x36, 0x10, 0x49, 0x47, 0x95, 0x42, 0xe4, 0x66, 0x92, 0x84, 0xd8, 0xfd, 0xf8, 0x48,
-	0x82, 0x86, 0x33, 0x77, 0x72, 0x24, 0x8a, 0x5c, 0x4e, 0x6b, 0x26, 0x9c, 0x90, 0xc0, 0x1b, 0x22,
-	0x8f, 0x53, 0x2e, 0x34, 0xba, 0x2b, 0x71, 0xd5, 0xc6, 0x8e, 0xee, 0xc3, 0x0a, 0x4b, 0xac, 0xe8,
-	0x63, 0x22, 0x99, 0xaa, 0x78, 0xe3, 0xb2, 0xca, 0xd9, 0xc8, 0x7b, 0xa4, 0x68, 0xca, 0x4a, 0xac,
-	0xd4, 0x7a, 0x13, 0xea, 0xd8, 0xa4, 0xa9, 0x54, 0x8b, 0x6e, 0x58, 0x51, 0x18, 0x62, 0x56, 0xa9,
-	0x24, 0x7b, 0x05, 0x20, 0x13, 0x57, 0x48, 0xc0, 0x1e, 0x9e, 0x06, 0x15, 0x83, 0x33, 0x36, 0x2f,
-	0x13, 0x44, 0xf9, 0x16, 0x0a, 0x78, 0x63, 0x3a, 0xae, 0xa5, 0xae, 0xac, 0x65, 0xce, 0x22, 0x5a,
-	0xaa, 0x42, 0xee, 0xaf, 0x02, 0x34, 0xa4, 0x40, 0xa9, 0x10, 0x8f, 0x2d, 0x6c, 0x31, 0xb1, 0xc4,
-	0xdd, 0x58, 0x41, 0xf6, 0xd2, 0x90, 0x32, 0x01, 0xef, 0x16, 0x14, 0x0b, 0x2f, 0xe5, 0xc2, 0x4c,
-	0xb2, 0xfb, 0xd0, 0x94, 0x01, 0x55, 0x84, 0xe5, 0x79, 0x84, 0x0f, 0xf8, 0x58, 0x42, 0x4b, 0x44,
-	0x1f, 0x6e, 0x74, 0x6f, 0x65, 0x28, 0x84, 0x8d, 0xfb, 0xe2, 0xf7, 0xb9, 0xc7, 0xc2, 0xab, 0xce,
-	0x03, 0x80, 0xc9, 0x8a, 0x97, 0xd3, 0x19, 0xb9, 0x52, 0xc5, 0x81, 0x9e, 0x9c, 0x9b, 0x6e, 0xa4,
-	0x80, 0x78, 0x52, 0x7c, 0x5c, 0x30, 0xbe, 0x82, 0x95, 0xcf, 0x79, 0xd3, 0x4a, 0xb1, 0x20, 0xd5,
-	0xd8, 0xfc, 0xde, 0x0f, 0x95, 0xbf, 0x7c, 0xe9, 0x78, 0xb8, 0x94, 0xe8, 0x61, 0xed, 0xfa, 0xc1,
-	0xe4, 0xae, 0x2f, 0xe5, 0x49, 0xe0, 0xfe, 0x28, 0x01, 0x4c, 0x84, 0xe9, 0x4f, 0xa0, 0xe3, 0xf8,
-	0x7d, 0xde, 0x6c, 0x1c, 0x8b, 0xc8, 0x2a, 0xea, 0x87, 0x04, 0x63, 0x47, 0x9d, 0x73, 0xa2, 0xda,
-	0xfc, 0x96, 0xf2, 0x25, 0x6f, 0xc3, 0x07, 0xb0, 0x39, 0xe1, 0xb5, 0x53, 0x6c, 0xc5, 0x85, 0x6c,
-	0x8f, 0x60, 0x1d, 0xd9, 0xb0, 0x1d, 0x45, 0x19, 0xa6, 0xd2, 0x42, 0xa6, 0x8f, 0x61, 0x27, 0x65,
-	0x27, 0x4f, 0xf6, 0x14, 0x6b, 0x79, 0x21, 0xeb, 0x87, 0xb0, 0x85, 0xac, 0x17, 0xa6, 0xc3, 0xf2,
-	0x7c, 0x95, 0x7f, 0x60, 0xe7, 0x98, 0x84, 0xc3, 0x8c, 0x9d, 0xd5, 0x85, 0x4c, 0xef, 0xc1, 0x1a,
-	0x32, 0xe5, 0xf4, 0xd4, 0xae, 0x63, 0xa1, 0xc4, 0x62, 0xd8, 0x98, 0x52, 0x2c, 0xcb, 0x8b, 0x58,
-	0x8c, 0x1e, 0x34, 0x5f, 0x44, 0x43, 0xc2, 0xdc, 0x41, 0x92, 0xfd, 0xff, 0xb2, 0x9e, 0x7e, 0x2b,
-	0x42, 0xe3, 0x60, 0x18, 0xfa, 0x51, 0x90, 0xe9, 0x1b, 0x32, 0xa5, 0xa7, 0xfa, 0x86, 0xa4, 0xd9,
-	0x83, 0xa6, 0x9c, 0x56, 0x8a, 0x4c, 0xd6, 0x9a, 0x3e, 0x9d, 0xf9, 0xfc, 0x6a, 0x2a, 0xa6, 0xae,
-	0x22, 0xcc, 0x56, 0x5b, 0x2a, 0x1b, 0x3f, 0x01, 0x6d, 0x24, 0xfd, 0x52, 0x94, 0x32, 0xb2, 0xf7,
-	0x62, 0xcd, 0x13, 0x03, 0xf7, 0xd3, 0xfe, 0x4b, 0x1c, 0xf1, 0x86, 0xc2, 0xaf, 0x3e, 0xfd, 0xb8,
-	0x0c, 0xd3, 0x6f, 0xcf, 0xa4, 0x33, 0x75, 0x5e, 0xc0, 0xda, 0x34, 0x6b, 0xa6, 0x00, 0x8d, 0x74,
-	0x01, 0x36, 0xba, 0xeb, 0x4a, 0x44, 0x9a, 0x4b, 0x54, 0xe5, 0xa5, 0xbc, 0x22, 0x25, 0xaf, 0x1a,
-	0xfd, 0x5d, 0xd0, 0x3c, 0x39, 0xf4, 0x12, 0xdc, 0x4a, 0x29, 0x01, 0x99, 0x81, 0x88, 0xd8, 0x59,
-	0xc2, 0x9b, 0x99, 0xd8, 0xa5, 0x23, 0x91, 0x19, 0xaf, 0xb2, 0xd5, 0xaa, 0x1b, 0xfc, 0xac, 0xd7,
-	0x6e, 0xf7, 0xe7, 0x2a, 0x94, 0x9e, 0xf6, 0xbe, 0xd4, 0x8f, 0x60, 0x25, 0xf7, 0x46, 0xd7, 0xe3,
-	0x9e, 0x34, 0xfb, 0x43, 0x42, 0xe7, 0xf6, 0xbc, 0x63, 0x75, 0x97, 0x58, 0xe2, 0x32, 0x73, 0x17,
-	0x8d, 0x44, 0xe6, 0xec, 0x4b, 0x5d, 0x22, 0x73, 0xde, 0xfd, 0x64, 0x49, 0xff, 0x08, 0xaa, 0xf2,
-	0x45, 0xaf, 0x6f, 0x28, 0xda, 0xcc, 0xa7, 0x81, 0xce, 0x66, 0x6e, 0x37, 0x61, 0x3c, 0x04, 0x2d,
-	0xf3, 0xad, 0x44, 0xbf, 0x91, 0xd1, 0x95, 0xfd, 0x20, 0xd0, 0xb9, 0x39, 0xfb, 0x30, 0x91, 0x76,
-	0x00, 0x30, 0x79, 0xa7, 0xea, 0x6d, 0x45, 0x3d, 0xf5, 0x61, 0xa1, 0xb3, 0x33, 0xe3, 0x24, 0x11,
-	0x72, 0x0a, 0xab, 0xf9, 0x87, 0xa8, 0x9e, 0x43, 0x35, 0xff, 0x6c, 0xec, 0xdc, 0x99, 0x7b, 0x9e,
-	0x16, 0x9b, 0x7f, 0x8e, 0x26, 0x62, 0xe7, 0x3c, 0x6e, 0x13, 0xb1, 0x73, 0xdf, 0xb1, 0x4b, 0xfa,
-	0xd7, 0xd0, 0xca, 0xbe, 0x24, 0xf5, 0x18, 0xa4, 0x99, 0x0f, 0xdc, 0xce, 0xad, 0x39, 0xa7, 0x89,
-	0xc0, 0xf7, 0xa1, 0x22, 0xdf, 0x8c, 0x71, 0xc6, 0xa7, 0x9f, 0x99, 0x9d, 0x8d, 0xec, 0x66, 0xc2,
-	0xf5, 0x10, 0xaa, 0xf2, 0x8a, 0x9a, 0x24, 0x40, 0xe6, 0xc6, 0xda, 0x69, 0xa6, 0x77, 0x8d, 0xa5,
-	0x87, 0x85, 0x58, 0x0f, 0xcd, 0xe8, 0xa1, 0xb3, 0xf4, 0xa4, 0x82, 0x33, 0xa8, 0x8a, 0xaf, 0x79,
-	0x8f, 0xfe, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x78, 0x6c, 0xcd, 0xda, 0x13, 0x00, 0x00,
+	// 5211 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd5, 0x5c, 0x49, 0x73, 0x24, 0x49,
+	0x56, 0x56, 0x6e, 0x52, 0xe6, 0x4b, 0xa5, 0x94, 0x0a, 0x6d, 0x59, 0x59, 0x4b, 0x57, 0x47, 0x55,
+	0xd1, 0x65, 0xdd, 0x85, 0x98, 0x52, 0x2f, 0xd3, 0xd3, 0x05, 0x58, 0xab, 0xb4, 0x74, 0x6b, 0x46,
+	0x5b, 0x6b, 0xa9, 0x9e, 0x31, 0x30, 0x64, 0xa1, 0x4c, 0x57, 0x2a, 0x46, 0x91, 0x11, 0x31, 0x11,
+	0x91, 0x5a, 0xfa, 0x82, 0x71, 0x80, 0x33, 0x18, 0x57, 0x8e, 0x9c, 0x31, 0xcc, 0x30, 0xe3, 0x06,
+	0x98, 0xc1, 0x81, 0x1b, 0x7f, 0x84, 0x3f, 0xc1, 0xf3, 0x35, 0xdc, 0x23, 0x22, 0xa5, 0x6a, 0xda,
+	0x38, 0x70, 0xcb, 0xf0, 0xe5, 0xb9, 0xfb, 0xf3, 0xb7, 0x7e, 0xcf, 0x25, 0x68, 0x38, 0xa1, 0xbb,
+	0x12, 0x46, 0x41, 0x12, 0x58, 0xb5, 0xe4, 0x36, 0x24, 0xb1, 0x7d, 0x06, 0x0b, 0x27, 0x61, 0xdf,
+	0x49, 0xc8, 0x41, 0x14, 0xf4, 0x48, 0x1c, 0x1f, 0x92, 0xdf, 0x8d, 0x48, 0x9c, 0x58, 0x00, 0x65,
+	0xb7, 0xdf, 0x29, 0x3d, 0x2d, 0xbd, 0x6c, 0x58, 0x4d, 0xa8, 0x84, 0xf8, 0x51, 0x66, 0x1f, 0xd8,
+	0xd3, 0xf3, 0x82, 0x98, 0x1c, 0x25, 0x7d, 0xd7, 0xef, 0x54, 0xb0, 0xad, 0x6e, 0xb5, 0xa0, 0x76,
+	0xed, 0xf6, 0x93, 0x8b, 0x4e, 0x15, 0x3f, 0x5b, 0xd6, 0x0c, 0x4c, 0x5e, 0x10, 0x77, 0x70, 0x91,
+	0x74, 0x6a, 0xf4, 0xdb, 0x5e, 0x86, 0xc5, 0xcc, 0x1a, 0x71, 0x18, 0xf8, 0x31, 0xb1, 0xff, 0xb6,
+	0x06, 0x4b, 0xeb, 0x11, 0xc1, 0x9e, 0xf5, 0xc0, 0x4f, 0x1c, 0xd7, 0x27, 0x51, 0xd1, 0xfa, 0xf8,
+	0x71, 0x36, 0xf2, 0xfb, 0x1e, 0x39, 0x70, 0x70, 0x8d, 0x74, 0x1b, 0x17, 0xa4, 0x77, 0x19, 0x06,
+	0xae, 0x9f, 0xb0, 0x6d, 0x34, 0xe8, 0x36, 0x62, 0xb6, 0xab, 0x2a, 0xfb, 0xc4, 0x6d, 0xe0, 0x67,
+	0x30, 0xe2, 0xdb, 0x90, 0xdf, 0x24, 0x8a, 0x3a, 0x93, 0xf2, 0xdb, 0x73, 0xce, 0x88, 0x17, 0x77,
+	0xa6, 0x9e, 0x56, 0xf0, 0xfb, 0x19, 0x34, 0xbc, 0x60, 0x80, 0x3b, 0x39, 0x77, 0x07, 0x9d, 0x3a,
+	0x0e, 0x69, 0xae, 0xb6, 0x57, 0x18, 0x97, 0x56, 0x76, 0x64, 0xbb, 0x35, 0x07, 0x0d, 0xb6, 0xc6,
+	0xbe, 0xdf, 0x23, 0x9d, 0x06, 0x3b, 0xfd, 0x3c, 0x34, 0x69, 0x53, 0x70, 0x14, 0xf4, 0x2e, 0x49,
+	0xd2, 0x01, 0xd6, 0xf8, 0x01, 0x54, 0xfd, 0xd1, 0xd0, 0xe9, 0x34, 0x19, 0x9d, 0x39, 0x41, 0x67,
+	0xef, 0x64, 0x77, 0x4d, 0x10, 0x5a, 0x86, 0xd9, 0xde, 0x20, 0x0a, 0x46, 0xe1, 0x9e, 0x33, 0x44,
+	0x7e, 0x38, 0x48, 0x6e, 0x5a, 0x32, 0x93, 0xb5, 0x77, 0x5a, 0x6c, 0x97, 0x4f, 0x60, 0xea, 0x2a,
+	0xf0, 0x46, 0x38, 0xa6, 0x33, 0x83, 0xdb, 0x6c, 0xae, 0xb6, 0x04, 0xad, 0x77, 0xac, 0xd5, 0x9a,
+	0x86, 0xea, 0x20, 0x1c, 0xc5, 0x9d, 0x59, 0x76, 0x86, 0x36, 0xd4, 0x39, 0xab, 0xb6, 0xfb, 0x9d,
+	0x36, 0x9b, 0x8f, 0xfd, 0x97, 0x84, 0x84, 0x9d, 0x39, 0x46, 0x1c, 0xd9, 0xe6, 0x8c, 0x92, 0xe0,
+	0x90, 0x0c, 0x83, 0x2b, 0xd2, 0xb1, 0xe4, 0xfe, 0x7d, 0x92, 0x5c, 0x07, 0xd1, 0xe5, 0xf7, 0x8e,
+	0x9b, 0x74, 0xe6, 0xd9, 0x1d, 0xe2, 0x34, 0xd7, 0xc7, 0xaf, 0x05, 0x36, 0x04, 0xc9, 0x26, 0x64,
+	0x18, 0x7a, 0x78, 0x53, 0x9d, 0x45, 0x46, 0x16, 0x27, 0xc9, 0x96, 0x4d, 0xff, 0xaa, 0xb3, 0xc4,
+	0x56, 0x7f, 0x09, 0x33, 0xb2, 0x71, 0x37, 0x18, 0xf9, 0x49, 0xdc, 0x59, 0x66, 0x5b, 0x96, 0x6c,
+	0x7c, 0xeb, 0xfa, 0x7d, 0xd6, 0x41, 0xf7, 0x31, 0x74, 0x6e, 0x0e, 0xf1, 0xa7, 0x3b, 0x24, 0x9d,
+	0x0e, 0x5b, 0xb2, 0x03, 0xed, 0xb4, 0xed, 0xc8, 0x1d, 0xf8, 0x8e, 0xd7, 0x79, 0xc0, 0x7a, 0x3e,
+	0x01, 0x08, 0x82, 0x21, 0x8a, 0x4d, 0xe2, 0x44, 0x49, 0xa7, 0xcb, 0x58, 0xba, 0x2c, 0x68, 0xee,
+	0xef, 0xef, 0x8a, 0x8e, 0x83, 0xc0, 0x73, 0x7b, 0xb7, 0xd6, 0x0b, 0x98, 0x12, 0xc7, 0xe9, 0x3c,
+	0x64, 0x23, 0x17, 0x24, 0xf3, 0x79, 0xab, 0xe0, 0x3f, 0xae, 0x26, 0x86, 0xa5, 0x17, 0xf0, 0x88,
+	0x1e, 0xcd, 0xfe, 0x97, 0x12, 0x4c, 0x0a, 0xe6, 0xa2, 0x88, 0xf4, 0x23, 0xf7, 0x8a, 0x44, 0x42,
+	0x12, 0x91, 0x2b, 0x3e, 0x8e, 0x16, 0x32, 0x88, 0x3c, 0xe8, 0xe3, 0xd2, 0xae, 0xef, 0x24, 0x6e,
+	0xe0, 0x0b, 0x21, 0xfc, 0x04, 0xa6, 0x82, 0x90, 0x7e, 0xc7, 0x28, 0x86, 0xf4, 0xf0, 0x5d, 0xe3,
+	0xbe, 0x56, 0xf6, 0x79, 0xe7, 0xa6, 0x9f, 0x44, 0xb7, 0x94, 0xaf, 0x28, 0xfe, 0xfd, 0x7d, 0xdf,
+	0xbb, 0x65, 0x42, 0x5a, 0xa7, 0xf2, 0x45, 0xc2, 0x0b, 0x32, 0x24, 0x11, 0x9e, 0x9e, 0xca, 0x69,
+	0xbd, 0xbb, 0x02, 0xd3, 0xc6, 0x24, 0x54, 0xc7, 0x4b, 0x72, 0x2b, 0x76, 0x84, 0xd2, 0x72, 0xe5,
+	0x78, 0x23, 0xb1, 0xa5, 0xaf, 0xca, 0x5f, 0x96, 0xec, 0xd7, 0x00, 0x9a, 0x9c, 0xe1, 0x00, 0x3f,
+	0xc0, 0x6d, 0x8a, 0xf1, 0x0b, 0x30, 0x3d, 0xc4, 0xcb, 0x8f, 0x6e, 0x39, 0xb7, 0xf8, 0x34, 0xfb,
+	0x1f, 0x4a, 0xd0, 0x48, 0x65, 0x3c, 0x7b, 0xea, 0x95, 0xf4, 0x48, 0x65, 0x76, 0xa4, 0xc7, 0x59,
+	0xb5, 0x30, 0x4f, 0x85, 0x5c, 0x0a, 0xa9, 0xa6, 0x56, 0x24, 0xcf, 0x86, 0xb8, 0x01, 0xa1, 0x94,
+	0x8b, 0xd0, 0xc2, 0x4b, 0x7e, 0x3b, 0x3a, 0x3f, 0x27, 0xd1, 0x91, 0xfb, 0x03, 0xe1, 0x26, 0xe2,
+	0x47, 0x9f, 0xf1, 0x8f, 0x61, 0x39, 0x67, 0x38, 0xb8, 0x51, 0xa1, 0x6a, 0xdc, 0x93, 0x8d, 0x8c,
+	0x40, 0x2a, 0x7f, 0x6a, 0xb0, 0xfd, 0x25, 0xb4, 0xb8, 0x84, 0xdd, 0x6b, 0xef, 0xa8, 0xd5, 0xe0,
+	0xb2, 0x58, 0x61, 0xc6, 0xac, 0x0d, 0x33, 0x72, 0xa6, 0xb0, 0x62, 0xff, 0x51, 0x86, 0xb9, 0xb5,
+	0x7e, 0xff, 0x0e, 0x03, 0xca, 0xd4, 0x27, 0x1a, 0xba, 0x94, 0x4a, 0x99, 0x5d, 0xf3, 0x03, 0xa8,
+	0x8e, 0x62, 0xdc, 0x5f, 0x85, 0xed, 0xaf, 0x29, 0xf6, 0x77, 0x82, 0x4d, 0x94, 0x5f, 0x4e, 0x34,
+	0xe0, 0xd2, 0xc3, 0xf6, 0x42, 0x50, 0xbf, 0x6a, 0xf2, 0xa3, 0x77, 0xdd, 0x17, 0xe6, 0x4b, 0xec,
+	0x72, 0xca, 0x34, 0x7d, 0xf5, 0x8c, 0xe9, 0x6b, 0x64, 0x4c, 0x1f, 0x48, 0x29, 0xe8, 0x39, 0xa1,
+	0x73, 0xe6, 0x7a, 0x6e, 0xe2, 0xa2, 0x6c, 0x34, 0x19, 0x79, 0x34, 0x49, 0x4e, 0x18, 0x3a, 0x11,
+	0x8a, 0x07, 0x1e, 0xe6, 0xdc, 0xf5, 0xb8, 0x49, 0x62, 0xc3, 0x63, 0xe2, 0xb9, 0xfe, 0xe8, 0x66,
+	0x87, 0x1a, 0x4c, 0x61, 0x99, 0x70, 0xb8, 0x1f, 0xec, 0x91, 0xeb, 0x03, 0x94, 0x15, 0x1c, 0x3b,
+	0x60, 0x16, 0x8a, 0x1e, 0x0e, 0x4d, 0x56, 0xe4, 0xb9, 0x43, 0x37, 0xe1, 0x56, 0x29, 0x35, 0x59,
+	0x87, 0xac, 0x35, 0x6b, 0x30, 0xa9, 0x9d, 0xaa, 0xdb, 0xab, 0x30, 0x29, 0xba, 0x91, 0x01, 0x74,
+	0x78, 0xaa, 0x72, 0x71, 0x70, 0x9e, 0x30, 0xbe, 0x55, 0xe9, 0xd7, 0x85, 0x13, 0xf5, 0x19, 0xdf,
+	0xaa, 0x78, 0x8b, 0x55, 0xc6, 0x32, 0x64, 0xc5, 0x48, 0x30, 0xbb, 0x45, 0x3f, 0x06, 0xe2, 0xf6,
+	0x5a, 0xd6, 0x12, 0xcc, 0x38, 0xfd, 0xbe, 0x4b, 0x25, 0xcb, 0xf1, 0xbe, 0x71, 0xfb, 0x31, 0xce,
+	0xac, 0xe0, 0x2d, 0x2e, 0x80, 0xa5, 0x5f, 0x99, 0xb8, 0xc9, 0x1d, 0x25, 0x55, 0xca, 0xb5, 0x14,
+	0x5d, 0xe7, 0x0b, 0xc3, 0xf7, 0x94, 0x0d, 0x0b, 0x9f, 0xce, 0xb4, 0xbb, 0xd0, 0xc9, 0x53, 0x13,
+	0x2b, 0x7d, 0x0a, 0xcb, 0x1b, 0xc4, 0x23, 0xf7, 0xad, 0x64, 0xd8, 0x1b, 0x4a, 0x30, 0x3f, 0x49,
+	0x10, 0x7c, 0x06, 0x8b, 0x3b, 0x6e, 0x9c, 0xdc, 0x49, 0xce, 0xfe, 0x0d, 0x40, 0x3a, 0x40, 0x11,
+	0x57, 0x4b, 0x91, 0x1b, 0x37, 0x11, 0xf2, 0x89, 0x4c, 0x4c, 0x7a, 0xa1, 0x70, 0xef, 0x78, 0x5f,
+	0x23, 0xdf, 0xbd, 0xe1, 0xd7, 0x15, 0x33, 0x45, 0x66, 0x6e, 0x2a, 0xbe, 0x20, 0x9e, 0xc7, 0xed,
+	0x96, 0xfd, 0x35, 0x2c, 0x65, 0xd7, 0x17, 0xfa, 0xf8, 0x7b, 0xd0, 0x4c, 0xb9, 0x45, 0xcd, 0x50,
+	0xa5, 0x98, 0x5d, 0xbb, 0x30, 0x7d, 0x94, 0x20, 0xb7, 0x8a, 0xf8, 0x30, 0x0b, 0x53, 0xf1, 0x68,
+	0x38, 0x74, 0xa2, 0x5b, 0xb1, 0x3f, 0x5c, 0x9d, 0x09, 0x0b, 0x57, 0x4a, 0x6a, 0x35, 0x43, 0x67,
+	0x40, 0x8e, 0x83, 0x4b, 0x22, 0xbc, 0xbf, 0xfd, 0x14, 0x66, 0x94, 0xba, 0x33, 0xba, 0x5c, 0x09,
+	0x9c, 0x64, 0x24, 0x4c, 0xa1, 0xfd, 0xaf, 0x65, 0x98, 0x12, 0x12, 0x20, 0x95, 0xe9, 0xff, 0x50,
+	0x5d, 0x69, 0xe0, 0x70, 0x1b, 0xa3, 0x7b, 0x3c, 0x10, 0x4a, 0xdb, 0xfa, 0xff, 0xa5, 0xb4, 0x2c,
+	0xf0, 0x41, 0x2f, 0x4b, 0xfa, 0x6b, 0x5c, 0x65, 0xab, 0xf6, 0x7f, 0x95, 0xa1, 0xa1, 0x78, 0x7c,
+	0x6f, 0xc4, 0xf6, 0x21, 0xde, 0x11, 0xe7, 0x36, 0xe1, 0x5a, 0xd8, 0x5c, 0x9d, 0x11, 0x4b, 0xc8,
+	0x5b, 0x48, 0x6f, 0xa8, 0x9a, 0x89, 0xd0, 0x38, 0x43, 0xa9, 0x63, 0xa1, 0x3a, 0x3c, 0x49, 0x75,
+	0x98, 0x0a, 0x45, 0x24, 0x02, 0x08, 0x6e, 0x04, 0xff, 0xb7, 0x01, 0x9c, 0x8c, 0xd5, 0x60, 0x5c,
+	0xac, 0xf6, 0x0a, 0x09, 0xbb, 0xe7, 0xa4, 0x77, 0xdb, 0x43, 0xee, 0xf2, 0x88, 0xee, 0x41, 0xd6,
+	0xa5, 0xec, 0xc8, 0x01, 0x74, 0x05, 0xb4, 0x39, 0x11, 0x3f, 0xe8, 0x34, 0xdb, 0xb8, 0x16, 0x93,
+	0xb4, 0xc6, 0xc7, 0x24, 0xf6, 0x9f, 0x83, 0x55, 0x40, 0x8f, 0x89, 0x09, 0x8d, 0xbc, 0x4a, 0x22,
+	0xc0, 0x68, 0x26, 0x91, 0xe3, 0xc7, 0xae, 0xee, 0x91, 0x97, 0x04, 0x3d, 0x26, 0xe9, 0xc7, 0xaa,
+	0x9b, 0xee, 0xc5, 0x73, 0xe2, 0x64, 0x33, 0x8a, 0x82, 0x48, 0xf8, 0xe3, 0x2e, 0x58, 0xaa, 0xe9,
+	0x18, 0x99, 0x87, 0xb4, 0x87, 0x21, 0x63, 0x78, 0x15, 0xcd, 0xd2, 0x6c, 0x96, 0x42, 0x66, 0x75,
+	0x24, 0x98, 0xa8, 0x49, 0xcc, 0x26, 0xdb, 0x9f, 0xc3, 0xd4, 0xae, 0xd3, 0xbb, 0xc0, 0x4d, 0xd3,
+	0x0b, 0xea, 0x85, 0x42, 0xc1, 0x58, 0x1e, 0xc0, 0x63, 0x8d, 0xd4, 0x78, 0xb3, 0x50, 0x95, 0x5e,
+	0x7e, 0xc3, 0x1e, 0xa2, 0x0b, 0xe6, 0xfa, 0x2e, 0x0c, 0xc5, 0x73, 0x34, 0xab, 0xf2, 0xf4, 0xd2,
+	0x4e, 0xe4, 0x3c, 0x37, 0x5e, 0xd6, 0xd4, 0x90, 0xaf, 0x26, 0x2c, 0xaf, 0x14, 0x22, 0xb9, 0x07,
+	0x8c, 0x30, 0x7c, 0x72, 0x93, 0x1c, 0x28, 0x7b, 0xc0, 0x8e, 0x6d, 0x5f, 0xc2, 0x12, 0x4f, 0x42,
+	0xee, 0x4c, 0x35, 0x72, 0xae, 0x9f, 0x8b, 0x23, 0xe7, 0xdc, 0x4b, 0x68, 0xe0, 0xad, 0x06, 0xa3,
+	0x08, 0x85, 0x95, 0x31, 0xac, 0xb9, 0xba, 0x28, 0x4d, 0x01, 0x23, 0x7d, 0x28, 0x7a, 0xed, 0xbf,
+	0xa8, 0xc1, 0x8c, 0xd9, 0x44, 0x8d, 0xe8, 0x99, 0x77, 0xe9, 0x06, 0xdf, 0xf3, 0xcc, 0xa8, 0x24,
+	0xed, 0x16, 0xf2, 0xeb, 0x08, 0x5d, 0x1a, 0x89, 0x85, 0xc7, 0xe2, 0x4d, 0x07, 0x24, 0x72, 0x83,
+	0xbe, 0xb0, 0x6e, 0x68, 0x8f, 0xb0, 0xe9, 0xbb, 0x51, 0x90, 0x38, 0x22, 0xc3, 0xa2, 0xd9, 0x0f,
+	0x72, 0x92, 0x24, 0xeb, 0x94, 0x9f, 0x35, 0x95, 0x11, 0xb1, 0xb6, 0x5d, 0x32, 0x8c, 0x85, 0xd1,
+	0xc1, 0x45, 0xf9, 0x0d, 0xec, 0x30, 0x63, 0x39, 0x25, 0x27, 0xf3, 0xc6, 0xa3, 0x6b, 0x27, 0x64,
+	0x7a, 0xd2, 0x42, 0x03, 0x37, 0xc7, 0xdb, 0x70, 0xbf, 0x24, 0xba, 0xe2, 0x01, 0x6d, 0x43, 0x76,
+	0x5d, 0x92, 0xc8, 0x27, 0xde, 0xae, 0x46, 0x09, 0x58, 0x17, 0x8a, 0x12, 0x2e, 0x79, 0x48, 0x1c,
+	0x8f, 0xca, 0x84, 0x8c, 0xe6, 0x9b, 0x72, 0x9a, 0xd6, 0x27, 0xce, 0x33, 0xad, 0xac, 0x35, 0xaa,
+	0x31, 0xa7, 0x44, 0xf5, 0xa1, 0x62, 0xbd, 0xc6, 0xd8, 0x5f, 0xed, 0x29, 0xc4, 0xdb, 0x89, 0xb9,
+	0x5d, 0x4a, 0xe3, 0xfc, 0xdd, 0x4c, 0x37, 0x46, 0xa5, 0x73, 0x1a, 0x43, 0x37, 0xc8, 0x95, 0x8b,
+	0x0a, 0xcd, 0x4d, 0xd7, 0xbc, 0x98, 0xa3, 0x77, 0x59, 0xbf, 0x80, 0x2e, 0x1b, 0x7f, 0x7c, 0x81,
+	0xf9, 0x6f, 0xe2, 0xe1, 0xcd, 0x38, 0xfd, 0xb7, 0x61, 0x2c, 0x26, 0xb6, 0xd9, 0x44, 0x79, 0x9d,
+	0x72, 0x8c, 0x98, 0xfa, 0x15, 0x3c, 0x34, 0xa6, 0x7e, 0x1f, 0xb9, 0x09, 0x49, 0xe7, 0xce, 0xfd,
+	0x98, 0xb9, 0x74, 0xd9, 0xed, 0x40, 0xcd, 0xb5, 0xee, 0x9a, 0xfb, 0x06, 0x1e, 0xe5, 0xd7, 0xd5,
+	0x26, 0xcf, 0xdf, 0x31, 0xd9, 0x7e, 0x05, 0xd3, 0xc6, 0xf9, 0x65, 0x54, 0x5e, 0x92, 0xb2, 0x7d,
+	0xcd, 0x25, 0x91, 0x89, 0x1d, 0x8e, 0x9e, 0xc9, 0x2c, 0x6e, 0x8e, 0xc7, 0xaf, 0x88, 0x5a, 0x01,
+	0xae, 0xf2, 0x1f, 0x42, 0x3b, 0x77, 0x1f, 0x2a, 0x4a, 0x2f, 0xb1, 0x21, 0x0f, 0x60, 0x39, 0xa7,
+	0x6f, 0x2a, 0xcc, 0x6a, 0x6d, 0x5e, 0x11, 0x0c, 0x06, 0xa4, 0x06, 0x1a, 0x46, 0x85, 0x4d, 0xa7,
+	0x81, 0x1b, 0x66, 0xa8, 0xd1, 0xb9, 0x17, 0x5c, 0xeb, 0x99, 0x0a, 0xd5, 0x05, 0xe7, 0x1c, 0xbd,
+	0xf3, 0x11, 0xf9, 0x9d, 0x08, 0x02, 0xff, 0xba, 0x04, 0x35, 0x46, 0x2e, 0x13, 0x38, 0x72, 0xb5,
+	0x2e, 0xd2, 0xe4, 0x96, 0x54, 0xf3, 0x6a, 0xde, 0xa4, 0xd5, 0xd8, 0xea, 0x34, 0xbc, 0x20, 0x57,
+	0xc4, 0x4b, 0x43, 0xed, 0x18, 0xd7, 0x9b, 0x62, 0x7d, 0x48, 0x0b, 0xa3, 0xba, 0x38, 0x90, 0x6e,
+	0xdb, 0x30, 0xf7, 0x0d, 0x66, 0xda, 0xfe, 0xb9, 0x04, 0xd3, 0xc2, 0xb2, 0x53, 0x13, 0x17, 0x67,
+	0x42, 0x2d, 0x9a, 0xf5, 0xdd, 0x9c, 0x9e, 0xdd, 0x26, 0x42, 0xe9, 0xab, 0x54, 0x25, 0xb1, 0xe5,
+	0xc0, 0xe1, 0x01, 0x16, 0x3b, 0x17, 0xa5, 0x7b, 0x78, 0x73, 0x4a, 0xa8, 0x99, 0xe6, 0xd6, 0x86,
+	0x0d, 0xc3, 0xa6, 0x7e, 0x14, 0x84, 0x21, 0xe9, 0x8b, 0xad, 0x22, 0xb1, 0x63, 0x49, 0x6c, 0x52,
+	0x8e, 0xc2, 0x96, 0x50, 0x10, 0x9b, 0x92, 0xc4, 0x8e, 0x15, 0xb1, 0xba, 0x36, 0x4c, 0x12, 0x6b,
+	0x30, 0x5e, 0x0e, 0xa1, 0x8e, 0x16, 0xe5, 0x24, 0x46, 0xdb, 0xc9, 0x32, 0x7c, 0xb4, 0x38, 0xde,
+	0xe9, 0x88, 0x7e, 0x8a, 0x6b, 0xc1, 0xa0, 0x22, 0x24, 0x11, 0x2a, 0xb6, 0x68, 0xa5, 0xde, 0xa7,
+	0x6a, 0x3d, 0x84, 0x79, 0xf6, 0x79, 0xea, 0xfa, 0xa7, 0xdc, 0x56, 0xb0, 0x8c, 0x8f, 0x9f, 0x03,
+	0x0d, 0x81, 0xea, 0xa4, 0x41, 0x94, 0x4a, 0x06, 0xab, 0xf6, 0xb1, 0x12, 0x3a, 0xd7, 0x1f, 0x6c,
+	0x38, 0x89, 0x43, 0x7d, 0x7a, 0xc8, 0x4c, 0x45, 0x2c, 0x16, 0xc4, 0xd9, 0x89, 0x90, 0xcb, 0xfe,
+	0xa9, 0xec, 0x2a, 0x4b, 0x11, 0x49, 0xbb, 0x98, 0xe5, 0xe1, 0x02, 0x91, 0xb0, 0x43, 0x70, 0xc6,
+	0xdb, 0xcc, 0x9a, 0x6a, 0x47, 0x68, 0xae, 0xce, 0x4a, 0x97, 0x22, 0x0f, 0xba, 0x02, 0xb3, 0x89,
+	0xda, 0xc5, 0x29, 0x8a, 0xac, 0x23, 0x3c, 0x4b, 0x46, 0xb1, 0xe4, 0x1e, 0x69, 0x60, 0xc5, 0x22,
+	0x39, 0x41, 0x96, 0xaf, 0xfa, 0x09, 0x34, 0x30, 0xb2, 0x8b, 0xf9, 0xb2, 0x78, 0x8c, 0xde, 0x28,
+	0x8a, 0x50, 0x28, 0xc5, 0x31, 0x54, 0xbc, 0xca, 0xf5, 0x67, 0x0f, 0x80, 0xeb, 0x0f, 0x23, 0x88,
+	0x9d, 0x3a, 0x8f, 0xf1, 0xae, 0x30, 0x45, 0x56, 0x0c, 0xa6, 0x4d, 0x48, 0xef, 0xdc, 0x71, 0xbd,
+	0x9e, 0x80, 0xba, 0x34, 0x7a, 0x9c, 0x91, 0x7f, 0x5f, 0x86, 0xa6, 0x50, 0x48, 0xb6, 0x3e, 0x76,
+	0xf7, 0xd0, 0x1d, 0x4a, 0x8a, 0x4f, 0xe5, 0x02, 0x66, 0xae, 0xa2, 0x6d, 0x01, 0x53, 0x9a, 0x18,
+	0x55, 0x59, 0x3b, 0x51, 0xe1, 0xb0, 0x8f, 0x60, 0x9a, 0xdf, 0xaf, 0x18, 0x58, 0x1d, 0x37, 0xf0,
+	0x15, 0x8f, 0x1a, 0x78, 0xe0, 0x96, 0x02, 0x06, 0xda, 0x1e, 0x59, 0xa8, 0x22, 0xb2, 0x7d, 0xf4,
+	0xfc, 0x34, 0x00, 0x3b, 0xe5, 0x53, 0x26, 0x0d, 0xcf, 0x4f, 0xc3, 0x30, 0x7e, 0x28, 0x8b, 0xef,
+	0x51, 0x78, 0x07, 0x26, 0xd7, 0xdd, 0x57, 0x00, 0x1a, 0x9d, 0xf1, 0xa8, 0x41, 0x95, 0xa1, 0x06,
+	0xbf, 0x81, 0x46, 0x4a, 0x8e, 0xea, 0x24, 0x15, 0xc5, 0x92, 0x8c, 0xc5, 0x99, 0xb4, 0xa7, 0xa1,
+	0x0a, 0x0b, 0xa5, 0x2b, 0xf2, 0xcb, 0xf1, 0x03, 0x5f, 0x68, 0x21, 0x4b, 0x87, 0xa8, 0x8d, 0x4c,
+	0x9c, 0x33, 0x8f, 0x03, 0x18, 0x55, 0xfb, 0x97, 0x30, 0xfb, 0x96, 0x9a, 0x6a, 0x6d, 0x37, 0x48,
+	0x72, 0xe8, 0xfc, 0x36, 0x88, 0x52, 0x11, 0xc0, 0x94, 0x02, 0x3f, 0xf9, 0x0a, 0x68, 0x9e, 0x82,
+	0x30, 0x05, 0x2e, 0xf9, 0x56, 0xf9, 0x6d, 0xfe, 0x7b, 0x05, 0x20, 0x25, 0x86, 0x1e, 0xa4, 0xeb,
+	0x06, 0xa7, 0xd4, 0x2d, 0xa3, 0x59, 0xe6, 0x9a, 0x7e, 0x1a, 0x11, 0x94, 0xaf, 0xd8, 0xbd, 0x22,
+	0x22, 0x4e, 0x92, 0xf1, 0x5f, 0x76, 0x0f, 0x9f, 0xc3, 0x62, 0x3a, 0xb7, 0xaf, 0x4d, 0x2b, 0xdf,
+	0x39, 0xed, 0x53, 0x98, 0xc7, 0x69, 0x68, 0x9c, 0x47, 0xc6, 0xa4, 0xca, 0x9d, 0x93, 0x7e, 0x01,
+	0x0f, 0xb4, 0x7d, 0x52, 0x85, 0xd4, 0xa6, 0x56, 0xef, 0x9c, 0xfa, 0x05, 0x2c, 0xe1, 0xd4, 0x6b,
+	0xc7, 0x4d, 0xb2, 0xf3, 0x6a, 0xef, 0xb1, 0xcf, 0x21, 0x89, 0x06, 0xc6, 0x3e, 0x27, 0xef, 0x9c,
+	0xf4, 0x1a, 0xe6, 0x70, 0x52, 0x66, 0x9d, 0xa9, 0xfb, 0xa6, 0xc4, 0xa4, 0x97, 0xa0, 0xf1, 0xd4,
+	0xa6, 0xd4, 0xef, 0x9a, 0x62, 0x1f, 0xc0, 0xf4, 0xb7, 0xa3, 0x01, 0x49, 0xbc, 0x33, 0xa5, 0x92,
+	0x3f, 0x51, 0xc9, 0xff, 0x11, 0x95, 0x7c, 0x9d, 0x41, 0xc3, 0x86, 0x6d, 0xe3, 0x4a, 0x93, 0xb3,
+	0x6d, 0x7c, 0xcc, 0x4b, 0x09, 0xf7, 0x89, 0x61, 0xdc, 0x00, 0x58, 0x79, 0x75, 0xa4, 0x69, 0x3a,
+	0x8b, 0x35, 0xc4, 0x40, 0xd3, 0x04, 0x68, 0xd2, 0xf8, 0x06, 0x5a, 0x17, 0xfc, 0x5c, 0x62, 0x24,
+	0xbf, 0xd9, 0xe7, 0x72, 0xe5, 0x74, 0x83, 0x2b, 0xfa, 0xf9, 0x95, 0xa2, 0xd3, 0xc8, 0xef, 0x54,
+	0xda, 0x06, 0x3d, 0x45, 0x53, 0xd6, 0xb3, 0xfb, 0x2d, 0xcc, 0xe5, 0xa7, 0x1a, 0xba, 0x6d, 0xeb,
+	0xba, 0x9d, 0xc6, 0x7b, 0xfa, 0x2c, 0xa6, 0xf0, 0x37, 0x3c, 0xc7, 0x50, 0x08, 0x8f, 0xf5, 0x31,
+	0x4d, 0x0e, 0x98, 0x63, 0x56, 0x7c, 0xd3, 0x03, 0x46, 0xc3, 0x69, 0x23, 0xef, 0x38, 0x42, 0x5f,
+	0xc8, 0x3b, 0xfd, 0x26, 0x8c, 0x08, 0x82, 0xbb, 0x83, 0x2e, 0x47, 0x33, 0x8a, 0xe0, 0x40, 0xfb,
+	0x33, 0xe8, 0xac, 0x07, 0xe1, 0xed, 0x56, 0x14, 0x0c, 0xef, 0x4c, 0x46, 0x64, 0x04, 0xc6, 0xd1,
+	0x9f, 0x07, 0x34, 0xd9, 0x0e, 0x6f, 0xd7, 0x2f, 0x46, 0xfe, 0x25, 0xed, 0x62, 0x8e, 0x8a, 0x0e,
+	0x9c, 0xa6, 0xe0, 0x0b, 0xed, 0x3a, 0x0e, 0xde, 0x9f, 0x9c, 0xa2, 0x50, 0x61, 0x14, 0x30, 0x5a,
+	0xcb, 0x51, 0x10, 0xd1, 0x1a, 0x0a, 0x06, 0xad, 0x0b, 0xdc, 0x97, 0x2d, 0xd9, 0x4f, 0x30, 0xde,
+	0x64, 0xe3, 0x04, 0xab, 0x4d, 0xb8, 0xa5, 0x65, 0xff, 0x09, 0xb4, 0xd6, 0x92, 0x04, 0xbd, 0xd2,
+	0xfb, 0xe4, 0x5d, 0x11, 0x09, 0x3d, 0xe7, 0x56, 0x44, 0x6b, 0x46, 0x5d, 0x67, 0x3a, 0x53, 0x81,
+	0xe2, 0xf0, 0xd3, 0x0a, 0xcc, 0x48, 0xe2, 0xfa, 0xf2, 0x18, 0xa8, 0x0d, 0x85, 0x81, 0x97, 0xe7,
+	0x2d, 0xb3, 0xf3, 0xbe, 0x83, 0x99, 0x6f, 0x48, 0xb2, 0x13, 0x0c, 0xee, 0x2f, 0x78, 0xd1, 0xa8,
+	0x12, 0xd5, 0x52, 0xdb, 0x8b, 0x4b, 0xa1, 0x83, 0xaa, 0x0c, 0x06, 0xcf, 0x03, 0x0f, 0x83, 0x54,
+	0xb1, 0x8f, 0x37, 0x50, 0x47, 0xa2, 0x5c, 0x62, 0xcd, 0x1d, 0x34, 0xcc, 0x1d, 0x14, 0xc9, 0xcc,
+	0x2b, 0x98, 0x5b, 0x57, 0x07, 0xbb, 0x97, 0xdf, 0x0b, 0x60, 0xe9, 0xa3, 0xc5, 0x6d, 0xfd, 0x00,
+	0xf3, 0x3c, 0xec, 0xe6, 0x51, 0xfc, 0xfd, 0x72, 0x80, 0xe9, 0xb2, 0xca, 0xba, 0x0f, 0x52, 0xd4,
+	0x1e, 0x9d, 0x5c, 0x48, 0x31, 0xb0, 0x38, 0x16, 0xa5, 0x0c, 0x75, 0x31, 0xac, 0x72, 0x54, 0x93,
+	0x28, 0xdc, 0xf0, 0x12, 0x9d, 0x28, 0x2f, 0x54, 0xd8, 0x4b, 0xb2, 0x96, 0x28, 0xd7, 0x16, 0x7b,
+	0x3a, 0x82, 0xe5, 0xad, 0x88, 0x90, 0x1f, 0xd2, 0x54, 0x40, 0x71, 0x1d, 0x4f, 0xe4, 0xf6, 0xb9,
+	0x16, 0xea, 0x70, 0x4f, 0x59, 0xc2, 0x3d, 0xc9, 0x85, 0x73, 0x9d, 0x16, 0x19, 0x79, 0x5d, 0x8c,
+	0xe3, 0x7b, 0x1f, 0x41, 0x27, 0x4f, 0x54, 0xdc, 0xbd, 0x4e, 0xd5, 0x7e, 0x06, 0xed, 0x8d, 0xd1,
+	0x30, 0x34, 0xb0, 0x45, 0x34, 0xb5, 0x94, 0xf9, 0x14, 0x6b, 0xe3, 0xd9, 0xca, 0x3f, 0x95, 0x61,
+	0x4e, 0x1b, 0x25, 0xe8, 0x60, 0xdc, 0x94, 0x38, 0xf1, 0xa5, 0xb4, 0xae, 0xd2, 0x1a, 0x7e, 0x47,
+	0xfd, 0x22, 0xc7, 0x14, 0x69, 0xdc, 0x44, 0x51, 0xb1, 0x63, 0x36, 0xac, 0x3c, 0x6e, 0x18, 0x12,
+	0xa2, 0xe0, 0x6a, 0xd6, 0xac, 0x6a, 0x23, 0x3e, 0x80, 0x6a, 0x10, 0x0c, 0xe3, 0x4c, 0x44, 0xa5,
+	0x0d, 0x40, 0x35, 0x8c, 0x47, 0x67, 0x71, 0x2f, 0x72, 0xcf, 0x28, 0x3c, 0x52, 0x33, 0x60, 0x54,
+	0x6d, 0x1c, 0x5e, 0x9c, 0x08, 0x3d, 0xe9, 0x9e, 0x44, 0x02, 0x43, 0x13, 0xf5, 0xb4, 0xf1, 0x88,
+	0xe3, 0x78, 0x22, 0x35, 0x40, 0x5e, 0x9c, 0x79, 0x14, 0xda, 0xed, 0xb3, 0xc4, 0xa0, 0x8e, 0x76,
+	0x4f, 0xc7, 0x61, 0x1a, 0x6c, 0xa1, 0x85, 0x2c, 0x0e, 0x43, 0x99, 0x85, 0x5a, 0x07, 0xda, 0xca,
+	0xf4, 0xfa, 0x88, 0x3f, 0x10, 0x29, 0x23, 0x87, 0x2d, 0x1c, 0x4c, 0x43, 0xdc, 0xe4, 0x56, 0x24,
+	0x99, 0x7f, 0x55, 0x82, 0x96, 0x41, 0xe1, 0x5e, 0xd0, 0x30, 0x0b, 0xc1, 0xa4, 0x22, 0x52, 0x95,
+	0x22, 0xc3, 0x41, 0x0f, 0x01, 0x82, 0xbc, 0xd0, 0x41, 0x46, 0x1e, 0x06, 0x58, 0x26, 0xc8, 0xc8,
+	0x36, 0xfe, 0x47, 0xd0, 0xd4, 0x3e, 0x4d, 0xf4, 0xd7, 0x00, 0x6a, 0xcb, 0x12, 0xc8, 0xd2, 0x77,
+	0x81, 0xe9, 0xef, 0xcc, 0xb7, 0x14, 0xd8, 0xb8, 0xf8, 0x61, 0xac, 0x40, 0x6d, 0xc1, 0xac, 0x1a,
+	0x22, 0xa4, 0x09, 0xc7, 0x5c, 0xb0, 0x26, 0xee, 0xc5, 0xea, 0xe8, 0xc5, 0x26, 0x19, 0x32, 0x2e,
+	0x41, 0x3c, 0xb9, 0x53, 0x3e, 0x91, 0x41, 0xe3, 0xf6, 0x2e, 0x34, 0xb5, 0xcf, 0x4c, 0x22, 0xa9,
+	0x51, 0x54, 0xb0, 0x38, 0xd1, 0xa0, 0x3e, 0xbc, 0x81, 0xfe, 0x28, 0xe2, 0x60, 0x0e, 0x8f, 0x21,
+	0x3e, 0x43, 0xa3, 0xc1, 0x6a, 0x12, 0xdf, 0x50, 0x55, 0x1a, 0x53, 0x6c, 0xf7, 0x65, 0x41, 0x54,
+	0x28, 0xa2, 0xbd, 0x0a, 0xf3, 0xc6, 0x2c, 0x71, 0xa0, 0x87, 0x52, 0x23, 0xb9, 0x7a, 0x4c, 0x8b,
+	0xed, 0xb3, 0x41, 0xf6, 0x25, 0xd4, 0xd8, 0x8f, 0xfb, 0x88, 0x4b, 0xe6, 0x57, 0x14, 0xb0, 0x95,
+	0xca, 0x1e, 0xbf, 0x63, 0x8e, 0xf3, 0xfa, 0x98, 0x7e, 0x09, 0xb3, 0x43, 0x8f, 0x45, 0xeb, 0x20,
+	0xb4, 0x85, 0x5b, 0x9e, 0xa7, 0x60, 0xf1, 0xca, 0xc8, 0xb8, 0x63, 0xd9, 0x36, 0xcc, 0x1b, 0x23,
+	0x8a, 0x2c, 0xc5, 0x07, 0x30, 0x47, 0x6b, 0x18, 0x6c, 0x44, 0xa1, 0xe3, 0x5e, 0x05, 0x4b, 0x1f,
+	0x20, 0x68, 0x3c, 0x82, 0x49, 0xc6, 0x06, 0x19, 0x4c, 0x98, 0x7c, 0xf8, 0x54, 0x2e, 0xcc, 0xeb,
+	0xbf, 0x92, 0xec, 0x9d, 0x95, 0x65, 0x6a, 0x49, 0xcd, 0x49, 0xc2, 0x92, 0x2e, 0xe2, 0x45, 0x68,
+	0x25, 0x00, 0x41, 0xcc, 0xfe, 0xef, 0x0a, 0x2c, 0x98, 0xed, 0xa9, 0xc8, 0xe1, 0x12, 0xd4, 0x84,
+	0xa7, 0x12, 0x23, 0x31, 0x73, 0xe5, 0xdd, 0xd0, 0xa4, 0x8c, 0x84, 0x8d, 0xa5, 0x75, 0x16, 0xd2,
+	0xeb, 0x05, 0x02, 0x10, 0x66, 0xac, 0x96, 0xd5, 0x05, 0xc1, 0x7c, 0x36, 0x84, 0x95, 0x15, 0x38,
+	0xef, 0x99, 0x03, 0x61, 0xe7, 0x7f, 0x27, 0x56, 0xe2, 0x28, 0x63, 0xc1, 0xfb, 0x86, 0xba, 0x24,
+	0x19, 0x09, 0x54, 0x50, 0xe0, 0xef, 0x98, 0xc8, 0xd3, 0xc4, 0x6e, 0x0d, 0x17, 0xa6, 0x7b, 0xc3,
+	0x5b, 0xe5, 0x6f, 0x28, 0x90, 0x84, 0x49, 0x41, 0xd6, 0x3c, 0xf0, 0x52, 0xbc, 0x60, 0xb0, 0xc1,
+	0xf8, 0x27, 0x21, 0x76, 0xdc, 0x06, 0x7f, 0x27, 0x21, 0x9b, 0x5b, 0xac, 0x19, 0xcd, 0xe1, 0x45,
+	0x10, 0x5c, 0x1e, 0x78, 0xa3, 0x81, 0xeb, 0xcb, 0x5a, 0x07, 0x6e, 0x21, 0xe8, 0xb9, 0xdf, 0x62,
+	0x3b, 0x2d, 0x76, 0xd0, 0x16, 0x09, 0x4d, 0xb7, 0x25, 0x2d, 0x9e, 0xe6, 0xca, 0x23, 0xcd, 0x31,
+	0x5e, 0x51, 0x48, 0x93, 0x6d, 0x88, 0xda, 0xb0, 0x08, 0xdd, 0x3e, 0x5d, 0xc6, 0x62, 0x33, 0xf0,
+	0x08, 0x14, 0xdb, 0xd0, 0x76, 0x3a, 0x2f, 0xcb, 0xf9, 0x14, 0xc6, 0xc2, 0x58, 0xe6, 0x3c, 0x4e,
+	0xdf, 0x52, 0x44, 0x41, 0x90, 0x78, 0x34, 0x89, 0x5d, 0x64, 0x2d, 0x1d, 0x68, 0x73, 0xba, 0x31,
+	0xbd, 0xf4, 0x81, 0x43, 0x6d, 0xf3, 0x92, 0x7a, 0x5a, 0xe2, 0xb9, 0x51, 0xf8, 0x19, 0x06, 0xad,
+	0x3e, 0x7d, 0x4d, 0x41, 0x85, 0xfd, 0x19, 0x75, 0xf1, 0x5e, 0xe0, 0xf4, 0xdf, 0x32, 0x6b, 0x29,
+	0x25, 0xca, 0x0c, 0x09, 0xbf, 0xa0, 0xbe, 0x58, 0x1f, 0x24, 0x24, 0xe2, 0x1e, 0x83, 0x6b, 0xbf,
+	0x85, 0x46, 0xfa, 0x4a, 0x83, 0xda, 0x3d, 0x86, 0x5e, 0x8b, 0x09, 0x99, 0x07, 0x0f, 0x0a, 0x91,
+	0x53, 0x6f, 0x18, 0x98, 0x14, 0xd9, 0x7f, 0x59, 0x82, 0x6e, 0x06, 0xfb, 0x3b, 0x0a, 0x49, 0xaf,
+	0xc8, 0xda, 0x3c, 0x63, 0xe0, 0x99, 0x78, 0x2c, 0x52, 0x1e, 0xf3, 0x58, 0x64, 0x01, 0xa6, 0x79,
+	0xd8, 0x21, 0xc6, 0x55, 0xa4, 0xe9, 0x47, 0xbb, 0x4f, 0x1f, 0x9f, 0x54, 0xe5, 0xd3, 0x97, 0x91,
+	0x2f, 0x5a, 0x58, 0xb9, 0xc8, 0x7e, 0x0c, 0x0f, 0x0b, 0xb7, 0x21, 0x94, 0xe9, 0x39, 0x2c, 0x89,
+	0x72, 0xea, 0x1d, 0x51, 0x33, 0x8d, 0x8c, 0x73, 0xa3, 0x04, 0x81, 0x75, 0x58, 0x38, 0x4a, 0x82,
+	0xf0, 0xce, 0xa0, 0x3b, 0x7d, 0x3e, 0xc0, 0x5d, 0x89, 0xe6, 0x28, 0x28, 0xb3, 0x2a, 0xf6, 0xcf,
+	0x61, 0x31, 0x43, 0xa4, 0x38, 0x7e, 0xe6, 0xa1, 0x26, 0xde, 0x05, 0x77, 0x4a, 0x75, 0xb4, 0x68,
+	0x0b, 0xd4, 0x18, 0x1d, 0x48, 0x77, 0x57, 0xb4, 0xf9, 0xaf, 0x78, 0x55, 0x58, 0x1b, 0x23, 0x88,
+	0x1b, 0xc5, 0xb8, 0x52, 0x51, 0x31, 0xce, 0xfe, 0x03, 0x69, 0x83, 0xde, 0xf3, 0x65, 0x18, 0x46,
+	0x64, 0x8b, 0x99, 0x09, 0x63, 0x32, 0x81, 0x2d, 0x58, 0x16, 0x4f, 0x76, 0x7e, 0x1a, 0xeb, 0xba,
+	0xd0, 0xc9, 0xd3, 0x11, 0x77, 0xf3, 0x9f, 0x25, 0xa8, 0x1f, 0x8b, 0xb7, 0x48, 0x19, 0xaf, 0x39,
+	0xa7, 0x3f, 0x10, 0x29, 0x67, 0xc2, 0x8a, 0x4a, 0xfe, 0x29, 0x58, 0xf5, 0x7d, 0x2a, 0x89, 0x35,
+	0xa3, 0x92, 0x38, 0x39, 0xae, 0x92, 0x28, 0x5f, 0x63, 0x4d, 0x15, 0xbc, 0xc6, 0xaa, 0x4b, 0xfb,
+	0xda, 0x63, 0xbe, 0x56, 0x62, 0xb2, 0xaf, 0x61, 0x91, 0x3b, 0x5f, 0x79, 0x1c, 0x4d, 0xe1, 0xb5,
+	0x53, 0x69, 0x70, 0x37, 0x66, 0x21, 0x4b, 0xd9, 0x29, 0xea, 0xde, 0xd3, 0x87, 0x5c, 0x26, 0x64,
+	0x20, 0x87, 0x52, 0xdf, 0x43, 0x65, 0x46, 0x7e, 0x2b, 0x27, 0xf3, 0x86, 0xcb, 0x92, 0xd6, 0x2e,
+	0x68, 0xda, 0x98, 0xc9, 0xc8, 0x46, 0x21, 0x4b, 0x39, 0xa2, 0x2f, 0xa4, 0x6c, 0xdc, 0x79, 0x08,
+	0xbb, 0x23, 0x55, 0x32, 0xbb, 0x71, 0xfb, 0xd7, 0xd0, 0xce, 0xbd, 0xf4, 0xa2, 0xd5, 0x2d, 0xe7,
+	0x46, 0xb4, 0x49, 0x35, 0x41, 0xa7, 0xc1, 0x11, 0x8f, 0x6d, 0x1f, 0xf9, 0x38, 0x24, 0x7e, 0x92,
+	0xc2, 0xc5, 0x5a, 0x2d, 0x0c, 0xdd, 0xa5, 0xc8, 0xba, 0x66, 0xa1, 0xf5, 0xd6, 0xe9, 0x5d, 0xaa,
+	0xb0, 0xc1, 0x7e, 0x08, 0x4d, 0xde, 0x50, 0x94, 0x6a, 0x3f, 0x87, 0x05, 0xba, 0x60, 0x10, 0x11,
+	0x63, 0x52, 0x66, 0x14, 0xea, 0x5d, 0x66, 0x94, 0xe0, 0x15, 0x33, 0x96, 0xac, 0xa3, 0x2f, 0x92,
+	0x1e, 0xea, 0x4f, 0x2f, 0x5d, 0x86, 0xc1, 0xf3, 0x60, 0xeb, 0x0b, 0x4c, 0xc5, 0x69, 0xac, 0x87,
+	0x12, 0x13, 0x23, 0xbf, 0x89, 0xdf, 0xbb, 0x95, 0x8b, 0x50, 0x58, 0xd7, 0x23, 0x8e, 0x2f, 0xe2,
+	0x47, 0x5c, 0x93, 0x56, 0x72, 0x85, 0x3d, 0xf8, 0x53, 0x56, 0x3c, 0x96, 0x53, 0xb6, 0xd0, 0x7a,
+	0xa2, 0x27, 0x65, 0x02, 0x87, 0x3f, 0x0b, 0x6a, 0x22, 0x5a, 0xdc, 0xc5, 0x14, 0xa0, 0x4f, 0x58,
+	0x9a, 0x5b, 0x95, 0x71, 0x02, 0x5b, 0x49, 0x94, 0x19, 0xea, 0xf6, 0x6f, 0xa1, 0x93, 0xdf, 0x95,
+	0x38, 0xd4, 0x27, 0x50, 0x3f, 0xe7, 0xcb, 0xc9, 0xfb, 0xd7, 0xaa, 0xe3, 0xd9, 0x0d, 0xd1, 0xf3,
+	0x8a, 0xfc, 0xa3, 0x2c, 0x0b, 0x18, 0x2a, 0x48, 0xad, 0x88, 0x82, 0x72, 0x6d, 0x87, 0x38, 0x19,
+	0x67, 0x85, 0xf3, 0xc8, 0x4d, 0xe8, 0x46, 0xaa, 0x66, 0x42, 0xf3, 0x16, 0xe6, 0xbd, 0x64, 0x41,
+	0xf9, 0xf7, 0x65, 0x6c, 0xcb, 0x26, 0x8f, 0x31, 0x57, 0x49, 0x22, 0x20, 0x5e, 0x9a, 0x6d, 0x1f,
+	0x12, 0x9f, 0x5c, 0xbf, 0xdf, 0x68, 0x15, 0x61, 0x8e, 0x1b, 0x4e, 0x63, 0x33, 0x63, 0x84, 0x10,
+	0xdc, 0x79, 0x1e, 0x54, 0xb2, 0x46, 0xa5, 0x4b, 0x22, 0x90, 0x94, 0x8d, 0x69, 0x20, 0xe9, 0xb1,
+	0x96, 0x4c, 0x20, 0xc9, 0x86, 0xd9, 0xff, 0x86, 0xc9, 0x93, 0xf9, 0x84, 0xf1, 0x63, 0x00, 0xd7,
+	0x4f, 0x48, 0x74, 0xce, 0x02, 0x0e, 0x13, 0x08, 0xde, 0x96, 0x1d, 0x62, 0xac, 0x0d, 0xe5, 0xab,
+	0x73, 0x91, 0xa0, 0xca, 0x31, 0xef, 0xdc, 0x28, 0x19, 0x39, 0xde, 0xd6, 0xc8, 0xef, 0xb1, 0x52,
+	0x3f, 0xae, 0x8f, 0x51, 0x48, 0xa2, 0x9e, 0x67, 0xc8, 0xf5, 0x0f, 0x69, 0x23, 0x55, 0x2c, 0xd6,
+	0xdb, 0x57, 0xa4, 0x85, 0xac, 0x7c, 0xa4, 0x17, 0xc0, 0x6a, 0x46, 0xba, 0xb8, 0xc6, 0xdb, 0xc5,
+	0xf3, 0x86, 0x5d, 0x98, 0xcd, 0x6e, 0xcb, 0xb4, 0x61, 0xc8, 0xf1, 0x61, 0x32, 0x12, 0xe6, 0x1e,
+	0x99, 0x9b, 0xdc, 0xb0, 0xf4, 0x72, 0x47, 0x14, 0xf1, 0x59, 0xdd, 0x6e, 0xe8, 0xf4, 0x04, 0x02,
+	0x70, 0x02, 0xb3, 0xd9, 0x13, 0xe0, 0x7d, 0x84, 0xe7, 0x29, 0xfe, 0x8f, 0x22, 0x47, 0x6e, 0x04,
+	0x39, 0xb9, 0x52, 0x45, 0xad, 0x24, 0x09, 0xd1, 0xae, 0x2b, 0xcf, 0xf1, 0xc5, 0x6b, 0xe5, 0x03,
+	0xa8, 0xf1, 0x03, 0x67, 0xa2, 0x1d, 0x25, 0x86, 0x34, 0x40, 0xbb, 0x76, 0x64, 0x41, 0x12, 0xbd,
+	0x80, 0xba, 0x84, 0x54, 0x99, 0x86, 0x24, 0x89, 0x5c, 0x4e, 0xbf, 0x65, 0x6f, 0x42, 0x5b, 0x9d,
+	0x5b, 0x70, 0xc4, 0x9c, 0xa6, 0x36, 0xcc, 0x9c, 0x90, 0x20, 0x8c, 0x2b, 0x09, 0xb6, 0x8a, 0xac,
+	0xf3, 0x90, 0x3d, 0x33, 0x14, 0x04, 0x8a, 0x04, 0xd6, 0xa0, 0x59, 0x36, 0x69, 0x56, 0xb2, 0x34,
+	0xab, 0x12, 0x44, 0xd2, 0x69, 0xaa, 0xd7, 0x69, 0xc2, 0x0c, 0xaf, 0xc9, 0x7b, 0x2d, 0x5a, 0xce,
+	0xdc, 0x2f, 0x9e, 0x72, 0x39, 0x37, 0x49, 0xa1, 0xae, 0x9a, 0x84, 0x70, 0x39, 0x5d, 0xce, 0xca,
+	0xa9, 0x98, 0x85, 0xee, 0x8e, 0xb9, 0x9f, 0x1f, 0xb3, 0xf2, 0x3a, 0xf7, 0x4c, 0x3f, 0x6d, 0xdd,
+	0x13, 0x68, 0x19, 0xd2, 0x5a, 0x74, 0x43, 0xb3, 0xe9, 0x33, 0x9e, 0xe2, 0x3b, 0xd2, 0xc5, 0x83,
+	0x33, 0xb8, 0x07, 0xed, 0xbd, 0xcc, 0x33, 0xe3, 0xfc, 0xc3, 0xbb, 0xd0, 0x04, 0x3c, 0x78, 0xc8,
+	0x5d, 0x51, 0xaf, 0x3a, 0x0a, 0x12, 0xe2, 0x21, 0x8d, 0x84, 0x95, 0x71, 0xfe, 0x1a, 0x1e, 0x73,
+	0xcb, 0x97, 0x5d, 0xaa, 0x38, 0x54, 0xc8, 0x07, 0x40, 0xf6, 0x11, 0x3c, 0x19, 0x47, 0x41, 0xf0,
+	0xf2, 0x75, 0xc1, 0x7b, 0xe9, 0x92, 0xf1, 0x42, 0x23, 0x3b, 0x15, 0x0d, 0xf2, 0x63, 0x2e, 0x11,
+	0xef, 0xb5, 0x2d, 0x34, 0xb1, 0x4f, 0xc6, 0x0d, 0x17, 0x72, 0xf9, 0x0a, 0x1e, 0xd1, 0x8b, 0xce,
+	0xf6, 0xc7, 0xc5, 0xf4, 0x8e, 0xe0, 0xf1, 0x98, 0xd1, 0xe2, 0x48, 0xab, 0x30, 0x97, 0x3d, 0x52,
+	0x56, 0x4c, 0xb2, 0x93, 0x3f, 0xfe, 0x9b, 0x12, 0x34, 0xd8, 0xd3, 0xa9, 0xf5, 0xa0, 0x4f, 0xe1,
+	0x83, 0xa9, 0x93, 0xbd, 0x5f, 0xed, 0xed, 0x7f, 0xbf, 0xd7, 0x9e, 0x40, 0xa9, 0x6c, 0xec, 0xed,
+	0x1f, 0x9f, 0x6e, 0xed, 0x9f, 0xec, 0x6d, 0xb4, 0x4b, 0xb8, 0x99, 0xfa, 0xfa, 0xfe, 0xde, 0xd6,
+	0xce, 0xf6, 0xfa, 0x71, 0xbb, 0x8c, 0xf7, 0x38, 0x73, 0x78, 0xb2, 0x77, 0xbc, 0xbd, 0xbb, 0x79,
+	0xba, 0xb5, 0xb6, 0xbd, 0xb3, 0xb9, 0xd1, 0xae, 0xe0, 0x3d, 0x36, 0x4f, 0xf6, 0x8e, 0x4e, 0x0e,
+	0x0e, 0xf6, 0x0f, 0x8f, 0xb1, 0xa1, 0x4a, 0xc9, 0xd1, 0x11, 0xfb, 0x27, 0xc7, 0xed, 0x1a, 0x66,
+	0x3d, 0xed, 0xed, 0xbd, 0x77, 0x6b, 0x3b, 0xdb, 0x1b, 0xa7, 0x6b, 0x87, 0xdf, 0x9c, 0xec, 0x6e,
+	0xee, 0x1d, 0xb7, 0x27, 0x29, 0x9d, 0xef, 0x4e, 0xf6, 0x8f, 0xd7, 0x4e, 0x37, 0x7f, 0xbd, 0xbe,
+	0xb9, 0xb9, 0x81, 0xd3, 0xa6, 0x56, 0xff, 0xee, 0x31, 0x54, 0xd6, 0x0e, 0xb6, 0xad, 0x43, 0x98,
+	0xcd, 0x3c, 0x8a, 0xb6, 0x64, 0xe1, 0xb5, 0xf8, 0xaf, 0x2c, 0xba, 0x4f, 0xc6, 0x75, 0x0b, 0x86,
+	0x4f, 0x50, 0x9a, 0x99, 0x1c, 0x4a, 0xd1, 0x2c, 0x7e, 0x4e, 0xa5, 0x68, 0x8e, 0x7b, 0xfd, 0x31,
+	0x61, 0xfd, 0x1c, 0x26, 0xf9, 0x13, 0x6a, 0x4b, 0xfa, 0x09, 0xe3, 0x2d, 0x76, 0x77, 0x31, 0xd3,
+	0xaa, 0x26, 0xee, 0x40, 0xcb, 0xf8, 0x43, 0x12, 0xeb, 0xa1, 0xb1, 0x96, 0x99, 0xa8, 0x74, 0x1f,
+	0x15, 0x77, 0x2a, 0x6a, 0xeb, 0x00, 0xe9, 0x1b, 0x60, 0xab, 0x93, 0xba, 0xac, 0x0c, 0x9d, 0x07,
+	0x05, 0x3d, 0x8a, 0xc8, 0x09, 0xb4, 0xb3, 0x8f, 0x7c, 0xad, 0x0c, 0x57, 0xb3, 0x4f, 0x72, 0xbb,
+	0x1f, 0x8c, 0xed, 0xd7, 0xc9, 0x66, 0x9f, 0xfa, 0x2a, 0xb2, 0x63, 0x1e, 0x0e, 0x2b, 0xb2, 0x63,
+	0xdf, 0x08, 0x4f, 0x58, 0xfb, 0x30, 0x63, 0xbe, 0xd2, 0xb5, 0x24, 0x93, 0x0a, 0x1f, 0x0f, 0x77,
+	0x1f, 0x8f, 0xe9, 0x55, 0x04, 0x3f, 0x83, 0x9a, 0x80, 0x9d, 0xf5, 0x07, 0x88, 0x72, 0xfa, 0x82,
+	0xd9, 0xa8, 0x66, 0xfd, 0x0c, 0x26, 0xf9, 0x03, 0x20, 0x25, 0x00, 0xc6, 0x7b, 0xa0, 0xee, 0xb4,
+	0xde, 0x6a, 0x4f, 0xfc, 0xac, 0x24, 0xd7, 0x89, 0x8d, 0x75, 0xe2, 0xa2, 0x75, 0xf4, 0xcb, 0xf9,
+	0x43, 0x68, 0xb2, 0xa6, 0x23, 0x56, 0x86, 0xf9, 0x51, 0x73, 0x71, 0xcd, 0x5f, 0xc2, 0x5c, 0xae,
+	0x4c, 0x67, 0xa9, 0xbb, 0x1b, 0x53, 0xc0, 0xeb, 0xb6, 0xb5, 0x01, 0x2c, 0x81, 0x60, 0xb4, 0x8e,
+	0x51, 0x35, 0xcd, 0xfa, 0x5a, 0xaa, 0x9a, 0x85, 0x95, 0xbb, 0x54, 0x35, 0xc7, 0x94, 0xe5, 0x26,
+	0x5e, 0x96, 0xd0, 0x26, 0x57, 0x69, 0xc9, 0xcd, 0x92, 0xc0, 0xb1, 0x56, 0xa7, 0xeb, 0xce, 0x1b,
+	0x6d, 0x8a, 0x25, 0x6f, 0x60, 0x92, 0x17, 0xca, 0x14, 0xeb, 0x8d, 0xa2, 0x9c, 0xd2, 0x3d, 0xb3,
+	0x9a, 0x46, 0x57, 0xc3, 0x53, 0x7c, 0x0e, 0x53, 0xa2, 0x6a, 0x66, 0xc9, 0x71, 0x66, 0x15, 0xad,
+	0x3b, 0x9b, 0x66, 0xc9, 0xbc, 0x0c, 0x4e, 0x0f, 0x8f, 0x8a, 0x96, 0x56, 0xaa, 0x94, 0xa2, 0xe5,
+	0x4a, 0x5d, 0x4a, 0xd1, 0x0a, 0xca, 0x5a, 0x13, 0xd6, 0x36, 0x4c, 0xeb, 0xc5, 0x25, 0xab, 0x6b,
+	0x68, 0xb7, 0x51, 0xed, 0xea, 0x3e, 0x2c, 0xec, 0xd3, 0x95, 0x2b, 0x5b, 0x3a, 0x52, 0xca, 0x35,
+	0xa6, 0x50, 0xa5, 0x94, 0x6b, 0x5c, 0xcd, 0x09, 0xc9, 0x6e, 0x41, 0x53, 0x43, 0xc9, 0xad, 0x07,
+	0x86, 0x96, 0xeb, 0xc0, 0x74, 0xb7, 0x5b, 0xd4, 0xa5, 0xd3, 0xd1, 0xa0, 0x6a, 0x45, 0x27, 0x0f,
+	0x70, 0x2b, 0x3a, 0x05, 0xc8, 0x36, 0xb7, 0x6f, 0x29, 0x5a, 0xad, 0xd8, 0x9e, 0x43, 0xb8, 0x15,
+	0xdb, 0xf3, 0xd0, 0x36, 0x67, 0xbb, 0x8e, 0x44, 0x5b, 0xe6, 0x92, 0x06, 0xa6, 0xad, 0xd8, 0x5e,
+	0x08, 0x5d, 0x4f, 0x58, 0x5f, 0x43, 0x43, 0x95, 0xd8, 0x2c, 0xe9, 0x60, 0xb3, 0xa5, 0xb9, 0x6e,
+	0x27, 0xdf, 0xa1, 0x28, 0x7c, 0x05, 0x53, 0xa2, 0xa8, 0xa2, 0xe4, 0xcf, 0xac, 0xc3, 0x74, 0x97,
+	0xb2, 0xcd, 0xfa, 0x41, 0x74, 0x88, 0x5c, 0x1d, 0xa4, 0x00, 0x4f, 0x57, 0x07, 0x29, 0xc2, 0xd4,
+	0x91, 0xd4, 0xaf, 0xa8, 0x28, 0xa6, 0xd8, 0xaa, 0x26, 0x8a, 0x39, 0x54, 0x56, 0x13, 0xc5, 0x3c,
+	0x18, 0xcb, 0x74, 0xf8, 0xcf, 0x64, 0xc1, 0xd6, 0x00, 0x29, 0xad, 0x0f, 0x8b, 0xbd, 0xa8, 0x86,
+	0xa3, 0x76, 0xed, 0xbb, 0x86, 0xe8, 0x0e, 0x3c, 0x83, 0x5f, 0x2a, 0xcb, 0x53, 0x8c, 0x7e, 0x76,
+	0x9f, 0x8c, 0xeb, 0xd6, 0xfd, 0xb0, 0x81, 0x59, 0x2a, 0x3f, 0x5c, 0x04, 0x87, 0x2a, 0x3f, 0x5c,
+	0x08, 0x73, 0x72, 0x6a, 0x06, 0x48, 0xa9, 0xa8, 0x15, 0xc1, 0x9b, 0xdd, 0x47, 0xc5, 0x9d, 0x3a,
+	0x35, 0x03, 0x85, 0xb4, 0x4c, 0xa9, 0x1c, 0x13, 0x23, 0x14, 0x02, 0x97, 0xdc, 0x54, 0x64, 0x21,
+	0x46, 0x65, 0x2a, 0xc6, 0x60, 0x98, 0xca, 0x54, 0x8c, 0xc5, 0x26, 0x99, 0x1f, 0x36, 0x01, 0x3a,
+	0xe5, 0x87, 0x0b, 0xa1, 0xbe, 0xee, 0xe3, 0x31, 0xbd, 0x59, 0x1e, 0x2a, 0x70, 0xce, 0xe0, 0x61,
+	0x16, 0xca, 0x33, 0x78, 0x98, 0xc3, 0xf3, 0xf8, 0xf6, 0x4c, 0x18, 0xce, 0x32, 0xf9, 0x34, 0x6e,
+	0x7b, 0x63, 0xb0, 0xbb, 0x09, 0xeb, 0x0b, 0x98, 0xe4, 0x40, 0x98, 0xf2, 0x3a, 0x06, 0x7a, 0xd6,
+	0xb5, 0x8c, 0xd6, 0xd4, 0x6d, 0xee, 0x41, 0xcb, 0xc0, 0xd1, 0xd4, 0xb1, 0x8a, 0x30, 0x38, 0x75,
+	0xac, 0x42, 0xe8, 0x8d, 0x29, 0x1b, 0x8d, 0xd6, 0x32, 0x28, 0x56, 0x1a, 0xad, 0x15, 0x83, 0x6e,
+	0x69, 0xb4, 0x36, 0x06, 0xfe, 0xc2, 0xe3, 0x7d, 0x29, 0x2d, 0x3f, 0x87, 0xad, 0x4c, 0xcb, 0xaf,
+	0x03, 0x46, 0x5d, 0x13, 0xd2, 0xa1, 0x8c, 0x81, 0x14, 0x84, 0x52, 0x36, 0x3a, 0x87, 0x4b, 0xe5,
+	0xe6, 0x29, 0x1f, 0x61, 0xae, 0x98, 0x87, 0xa8, 0x32, 0x3e, 0xc2, 0xc4, 0xa6, 0x94, 0x8f, 0xe0,
+	0x40, 0x94, 0xe1, 0x23, 0x0c, 0xc0, 0xca, 0xf0, 0x11, 0x26, 0x6a, 0xa5, 0x02, 0x69, 0x89, 0x6c,
+	0x68, 0x81, 0xb4, 0x89, 0x55, 0xe8, 0x81, 0x74, 0x16, 0x71, 0xd0, 0xec, 0x94, 0x4a, 0xe3, 0x33,
+	0x76, 0x2a, 0x8b, 0x08, 0x64, 0xec, 0x54, 0x2e, 0xfb, 0x4f, 0xb5, 0x22, 0xa5, 0xa8, 0x6b, 0x45,
+	0x8e, 0xde, 0xa3, 0xe2, 0x4e, 0x45, 0x6d, 0x20, 0x51, 0xf5, 0x5c, 0x42, 0xff, 0xdc, 0xb8, 0xf0,
+	0x31, 0xd9, 0x6e, 0xf7, 0xc5, 0x3d, 0xa3, 0xf4, 0x85, 0x8a, 0x13, 0x61, 0xb5, 0xd0, 0x9d, 0x69,
+	0xb5, 0x5a, 0xe8, 0x9e, 0x6c, 0x7a, 0xc2, 0xea, 0x73, 0xe0, 0x24, 0x97, 0x21, 0x5b, 0xcf, 0x34,
+	0x56, 0x8c, 0xcb, 0xb6, 0xbb, 0xcf, 0xef, 0x1e, 0x24, 0x57, 0x39, 0x9b, 0x64, 0xff, 0x70, 0xe0,
+	0xd3, 0xff, 0x01, 0x62, 0x58, 0x12, 0x22, 0x7d, 0x40, 0x00, 0x00,
 }
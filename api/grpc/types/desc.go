@@ -0,0 +1,9 @@
+package types
+
+import "google.golang.org/grpc"
+
+// APIServiceDesc returns the description of the API service registered by
+// RegisterAPIServer, servers wrap its handlers to intercept the rpcs
+func APIServiceDesc() grpc.ServiceDesc {
+	return _API_serviceDesc
+}
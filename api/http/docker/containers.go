@@ -0,0 +1,400 @@
+package docker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/runtime"
+	netcontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// defaultStopTimeout is the time a stopped container has to exit after
+// SIGTERM before it is killed
+const defaultStopTimeout = 10 * time.Second
+
+var validName = regexp.MustCompile(`^/?[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// container is a container created through the API, it is kept after the
+// container exited until it is removed
+type container struct {
+	ID         string
+	Bundle     string
+	Labels     map[string]string
+	LogConfig  logConfig
+	StdinOnce  bool
+	Created    time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Started is true while containerd runs the container
+	Started  bool
+	Exited   bool
+	ExitCode int
+}
+
+type logConfig struct {
+	Type   string
+	Config map[string]string
+}
+
+type createRequest struct {
+	Image      string
+	Cmd        []string
+	Entrypoint []string
+	Env        []string
+	Labels     map[string]string
+	StdinOnce  bool
+	HostConfig struct {
+		LogConfig logConfig
+	}
+}
+
+func (s *server) create(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Query().Get("name"), "/")
+	if id == "" {
+		id = newID()
+	} else if !validName.MatchString(id) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid container name (%s)", id))
+		return
+	}
+	if !filepath.IsAbs(req.Image) {
+		writeError(w, http.StatusBadRequest, "image must be the absolute path to an OCI bundle")
+		return
+	}
+	if _, err := runtime.ReadSpec(req.Image); err != nil {
+		if os.IsNotExist(err) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("No such image: %s", req.Image))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	lc := req.HostConfig.LogConfig
+	if lc.Type == "" {
+		lc.Type = "json-file"
+	}
+	if lc.Type != "none" {
+		if err := logger.Validate(lc.Type, lc.Config); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	var warnings []string
+	if len(req.Cmd) > 0 || len(req.Entrypoint) > 0 || len(req.Env) > 0 {
+		warnings = append(warnings, "Cmd, Entrypoint and Env are ignored, the process of the bundle's spec is run")
+	}
+	// containerd is asked before the lock is taken so that a slow rpc does
+	// not block the other requests
+	exists := s.exists(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.containers[id]; ok || exists {
+		writeError(w, http.StatusConflict, fmt.Sprintf("Conflict. The name %q is already in use.", id))
+		return
+	}
+	s.containers[id] = &container{
+		ID:        id,
+		Bundle:    req.Image,
+		Labels:    req.Labels,
+		LogConfig: lc,
+		StdinOnce: req.StdinOnce,
+		Created:   time.Now(),
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"Id":       id,
+		"Warnings": warnings,
+	})
+}
+
+// exists returns true if containerd has a container with the id
+func (s *server) exists(id string) bool {
+	_, err := s.c.State(netcontext.Background(), &types.StateRequest{Id: id})
+	return err == nil
+}
+
+func newID() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (s *server) start(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	c, ok := s.containers[id]
+	if !ok || c.Started {
+		s.mu.Unlock()
+		if ok || s.exists(id) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No such container: %s", id))
+		return
+	}
+	// the container is marked started before it is created so that it is not
+	// started twice and its exit is recorded even if it exits right away
+	c.Started, c.Exited, c.ExitCode = true, false, 0
+	c.StartedAt, c.FinishedAt = time.Now(), time.Time{}
+	req := &types.CreateContainerRequest{
+		Id:         c.ID,
+		BundlePath: c.Bundle,
+		Labels:     toLabels(c.Labels),
+		StdinOnce:  c.StdinOnce,
+	}
+	if c.LogConfig.Type != "none" {
+		req.LogConfig = &types.LogConfig{
+			Driver:  c.LogConfig.Type,
+			Options: c.LogConfig.Config,
+			Path:    s.logPath(c.ID),
+		}
+	}
+	s.mu.Unlock()
+	_, err := s.c.CreateContainer(netcontext.Background(), req)
+	if err != nil {
+		s.mu.Lock()
+		c.Started, c.StartedAt = false, time.Time{}
+		s.mu.Unlock()
+		writeRPCError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) logPath(id string) string {
+	return filepath.Join(s.root, id)
+}
+
+func (s *server) stop(w http.ResponseWriter, r *http.Request, id string) {
+	timeout := int64(defaultStopTimeout / time.Second)
+	if t := r.URL.Query().Get("t"); t != "" {
+		n, err := strconv.Atoi(t)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// a timeout of 0 kills the container without waiting
+		timeout = int64(n)
+		if n <= 0 {
+			timeout = -1
+		}
+	}
+	if !s.exists(id) {
+		s.notRunning(w, id)
+		return
+	}
+	if _, err := s.c.StopContainer(netcontext.Background(), &types.StopContainerRequest{
+		Id:      id,
+		Signal:  uint32(syscall.SIGTERM),
+		Timeout: timeout,
+	}); err != nil && grpc.Code(err) != codes.NotFound {
+		writeRPCError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// notRunning answers a request to stop a container that is not running
+func (s *server) notRunning(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	_, ok := s.containers[id]
+	s.mu.Unlock()
+	if ok {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("No such container: %s", id))
+}
+
+func (s *server) signal(id string, sig syscall.Signal) error {
+	_, err := s.c.Signal(netcontext.Background(), &types.SignalRequest{
+		Id:     id,
+		Pid:    runtime.InitProcessID,
+		Signal: uint32(sig),
+	})
+	return err
+}
+
+func (s *server) kill(w http.ResponseWriter, r *http.Request, id string) {
+	sig := syscall.SIGKILL
+	if name := r.URL.Query().Get("signal"); name != "" {
+		var ok bool
+		if sig, ok = parseSignal(name); !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid signal: %s", name))
+			return
+		}
+	}
+	if err := s.signal(id, sig); err != nil {
+		if grpc.Code(err) == codes.NotFound {
+			s.mu.Lock()
+			_, ok := s.containers[id]
+			s.mu.Unlock()
+			if ok {
+				writeError(w, http.StatusConflict, fmt.Sprintf("Container %s is not running", id))
+				return
+			}
+		}
+		writeRPCError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var signals = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"TERM": syscall.SIGTERM,
+	"STOP": syscall.SIGSTOP,
+	"CONT": syscall.SIGCONT,
+}
+
+// parseSignal parses a signal number or name with or without the SIG prefix
+func parseSignal(s string) (syscall.Signal, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return syscall.Signal(n), n > 0
+	}
+	sig, ok := signals[strings.TrimPrefix(strings.ToUpper(s), "SIG")]
+	return sig, ok
+}
+
+func (s *server) wait(w http.ResponseWriter, r *http.Request, id string) {
+	ctx, cancel := requestContext(w)
+	defer cancel()
+	resp, err := s.c.Wait(ctx, &types.WaitRequest{Id: id})
+	if err != nil {
+		if grpc.Code(err) != codes.NotFound {
+			writeRPCError(w, err)
+			return
+		}
+		s.mu.Lock()
+		c, ok := s.containers[id]
+		var code int
+		if ok {
+			ok, code = c.Exited, c.ExitCode
+		}
+		s.mu.Unlock()
+		if !ok {
+			writeRPCError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]int{"StatusCode": code})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"StatusCode": int(resp.Status)})
+}
+
+// requestContext returns a context that is canceled when the client closes
+// the connection
+func requestContext(w http.ResponseWriter) (netcontext.Context, func()) {
+	ctx, cancel := netcontext.WithCancel(netcontext.Background())
+	if cn, ok := w.(http.CloseNotifier); ok {
+		closed := cn.CloseNotify()
+		go func() {
+			select {
+			case <-closed:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	return ctx, cancel
+}
+
+func (s *server) remove(w http.ResponseWriter, r *http.Request, id string) {
+	if s.exists(id) {
+		if force, _ := strconv.ParseBool(r.URL.Query().Get("force")); !force {
+			writeError(w, http.StatusConflict, fmt.Sprintf("You cannot remove a running container %s. Stop the container before attempting removal or use -f", id))
+			return
+		}
+		if err := s.signal(id, syscall.SIGKILL); err != nil {
+			writeRPCError(w, err)
+			return
+		}
+	}
+	s.mu.Lock()
+	_, ok := s.containers[id]
+	delete(s.containers, id)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No such container: %s", id))
+		return
+	}
+	if err := os.RemoveAll(s.logPath(id)); err != nil {
+		log.WithField("error", err).Warn("containerd: remove container logs")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// watchExits records the exit codes of the containers created through the
+// API so that they can be inspected after they exited
+func (s *server) watchExits() {
+	var from uint64
+	for {
+		events, err := s.c.Events(netcontext.Background(), &types.EventsRequest{Timestamp: from})
+		for err == nil {
+			var e *types.Event
+			if e, err = events.Recv(); err == nil {
+				from = e.Timestamp
+				if e.Type == "exit" && e.Pid == runtime.InitProcessID {
+					s.exited(e.Id, int(e.Status), time.Unix(int64(e.Timestamp), 0))
+				}
+			}
+		}
+		log.WithField("error", err).Warn("containerd: docker api events")
+		time.Sleep(time.Second)
+	}
+}
+
+func (s *server) exited(id string, status int, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// exits replayed after the events were resubscribed may be of a previous
+	// run of the container
+	if c, ok := s.containers[id]; ok && c.Started && !t.Before(c.StartedAt.Truncate(time.Second)) {
+		c.Started, c.Exited = false, true
+		c.ExitCode, c.FinishedAt = status, t
+	}
+}
+
+func toLabels(m map[string]string) []string {
+	var out []string
+	for k, v := range m {
+		out = append(out, k+"="+v)
+	}
+	return out
+}
+
+func fromLabels(labels []string) map[string]string {
+	m := make(map[string]string)
+	for _, l := range labels {
+		kv := strings.SplitN(l, "=", 2)
+		if len(kv) == 2 {
+			m[kv[0]] = kv[1]
+		} else {
+			m[kv[0]] = ""
+		}
+	}
+	return m
+}
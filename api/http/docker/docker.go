@@ -0,0 +1,147 @@
+// Package docker serves a subset of the Docker Engine API on top of
+// containerd's grpc API so that existing tooling can drive containerd.
+//
+// Containerd has no images, the image of a created container is the absolute
+// path to its OCI bundle and the bundle's process is run.  Containers are
+// started by containerd when they are started through this API, not when they
+// are created.
+package docker
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/docker/containerd"
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/api/http/local"
+	"github.com/docker/containerd/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+var log = logging.Logger("docker")
+
+// apiVersion is the version of the Docker Engine API that is served
+const apiVersion = "1.24"
+
+// versionPrefix matches the optional version of request paths
+var versionPrefix = regexp.MustCompile(`^/v[0-9.]+/`)
+
+type server struct {
+	c types.APIClient
+	// root is the directory containers' logs are written to so that they can
+	// be read after the containers exited
+	root string
+
+	mu         sync.Mutex
+	containers map[string]*container
+}
+
+// Enable serves the Docker Engine API on the address and translates the
+// requests to calls on the client.  The address is either a unix:// socket or
+// a loopback tcp address as the API has no authentication and runs any bundle
+// on the host.  The logs of the containers created through the API are kept in
+// root until the containers are removed.
+func Enable(address, root string, c types.APIClient) error {
+	l, err := local.Listen(address)
+	if err != nil {
+		return err
+	}
+	s := newServer(root, c)
+	go s.watchExits()
+	go func() {
+		if err := http.Serve(l, s); err != nil {
+			log.WithField("error", err).Error("containerd: docker api http server")
+		}
+	}()
+	log.Debugf("docker api listening in address %s", address)
+	return nil
+}
+
+func newServer(root string, c types.APIClient) *server {
+	return &server{
+		c:          c,
+		root:       root,
+		containers: make(map[string]*container),
+	}
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := versionPrefix.ReplaceAllString(r.URL.Path, "/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case r.Method == "GET" && path == "/_ping":
+		w.Write([]byte("OK"))
+	case r.Method == "GET" && path == "/version":
+		s.version(w, r)
+	case r.Method == "GET" && path == "/containers/json":
+		s.list(w, r)
+	case r.Method == "POST" && path == "/containers/create":
+		s.create(w, r)
+	case len(parts) == 2 && parts[0] == "containers" && r.Method == "DELETE":
+		s.remove(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "containers":
+		s.containerAction(w, r, parts[1], r.Method+" "+parts[2])
+	default:
+		writeError(w, http.StatusNotFound, "page not found")
+	}
+}
+
+func (s *server) containerAction(w http.ResponseWriter, r *http.Request, id, action string) {
+	switch action {
+	case "GET json":
+		s.inspect(w, r, id)
+	case "POST start":
+		s.start(w, r, id)
+	case "POST stop":
+		s.stop(w, r, id)
+	case "POST kill":
+		s.kill(w, r, id)
+	case "POST wait":
+		s.wait(w, r, id)
+	case "GET logs":
+		s.logs(w, r, id)
+	case "GET stats":
+		s.stats(w, r, id)
+	default:
+		writeError(w, http.StatusNotFound, "page not found")
+	}
+}
+
+func (s *server) version(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"Version":       containerd.Version,
+		"ApiVersion":    apiVersion,
+		"MinAPIVersion": apiVersion,
+		"GitCommit":     containerd.GitCommit,
+		"Os":            "linux",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{
+		"message": msg,
+	})
+}
+
+// writeRPCError writes the error of a grpc call with the status the Docker
+// API uses for it
+func writeRPCError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch grpc.Code(err) {
+	case codes.NotFound:
+		status = http.StatusNotFound
+	case codes.AlreadyExists:
+		status = http.StatusConflict
+	}
+	writeError(w, status, grpc.ErrorDesc(err))
+}
@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeClient runs the container db, the rpcs it does not implement panic
+type fakeClient struct {
+	types.APIClient
+	created []*types.CreateContainerRequest
+}
+
+func (c *fakeClient) State(ctx context.Context, r *types.StateRequest, opts ...grpc.CallOption) (*types.StateResponse, error) {
+	if r.Id != "db" {
+		return nil, grpc.Errorf(codes.NotFound, "containerd: container not found")
+	}
+	return &types.StateResponse{
+		Containers: []*types.Container{{Id: "db", Status: "running"}},
+	}, nil
+}
+
+func (c *fakeClient) CreateContainer(ctx context.Context, r *types.CreateContainerRequest, opts ...grpc.CallOption) (*types.CreateContainerResponse, error) {
+	c.created = append(c.created, r)
+	return &types.CreateContainerResponse{}, nil
+}
+
+func (c *fakeClient) Signal(ctx context.Context, r *types.SignalRequest, opts ...grpc.CallOption) (*types.SignalResponse, error) {
+	if r.Id != "db" {
+		return nil, grpc.Errorf(codes.NotFound, "containerd: container not found")
+	}
+	return &types.SignalResponse{}, nil
+}
+
+func request(t *testing.T, s *server, method, path, body string) *httptest.ResponseRecorder {
+	r, err := http.NewRequest(method, path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func newBundle(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "docker-api-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"process": {"args": ["sh"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestCreateAndStart(t *testing.T) {
+	bundle := newBundle(t)
+	defer os.RemoveAll(bundle)
+	c := &fakeClient{}
+	s := newServer("/logs", c)
+	body := `{"Image": "` + bundle + `"}`
+	if w := request(t, s, "POST", "/v1.24/containers/create?name=web", body); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "POST", "/containers/create?name=web", body); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a name used by the API but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "POST", "/containers/create?name=db", body); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a name used by containerd but received %d: %s", w.Code, w.Body)
+	}
+	if len(c.created) != 0 {
+		t.Fatal("expected the container to be created in containerd when it is started")
+	}
+	if w := request(t, s, "POST", "/containers/web/start", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 but received %d: %s", w.Code, w.Body)
+	}
+	if len(c.created) != 1 || c.created[0].BundlePath != bundle || c.created[0].LogConfig.Path != filepath.Join("/logs", "web") {
+		t.Fatalf("expected web to be created with its bundle and log path but received %v", c.created)
+	}
+	if w := request(t, s, "POST", "/containers/web/start", ""); w.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304 for a started container but received %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestCreateInvalid(t *testing.T) {
+	s := newServer("/logs", &fakeClient{})
+	for _, tc := range []struct {
+		path, body string
+		status     int
+	}{
+		{"/containers/create", `{"Image": "bundle"}`, http.StatusBadRequest},
+		{"/containers/create", `{"Image": "/nonexistent"}`, http.StatusNotFound},
+		{"/containers/create?name=-x", `{"Image": "/nonexistent"}`, http.StatusBadRequest},
+		{"/containers/create", `{`, http.StatusBadRequest},
+	} {
+		if w := request(t, s, "POST", tc.path, tc.body); w.Code != tc.status {
+			t.Errorf("expected status %d for %s %s but received %d: %s", tc.status, tc.path, tc.body, w.Code, w.Body)
+		}
+	}
+}
+
+func TestKill(t *testing.T) {
+	bundle := newBundle(t)
+	defer os.RemoveAll(bundle)
+	s := newServer("/logs", &fakeClient{})
+	if w := request(t, s, "POST", "/containers/create?name=web", `{"Image": "`+bundle+`"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 but received %d: %s", w.Code, w.Body)
+	}
+	for _, tc := range []struct {
+		path   string
+		status int
+	}{
+		{"/containers/db/kill", http.StatusNoContent},
+		{"/containers/db/kill?signal=SIGTERM", http.StatusNoContent},
+		{"/containers/db/kill?signal=BOGUS", http.StatusBadRequest},
+		{"/containers/web/kill", http.StatusConflict},
+		{"/containers/missing/kill", http.StatusNotFound},
+	} {
+		if w := request(t, s, "POST", tc.path, ""); w.Code != tc.status {
+			t.Errorf("expected status %d for %s but received %d: %s", tc.status, tc.path, w.Code, w.Body)
+		}
+	}
+}
+
+func TestRemove(t *testing.T) {
+	bundle := newBundle(t)
+	defer os.RemoveAll(bundle)
+	root, err := ioutil.TempDir("", "docker-api-logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	s := newServer(root, &fakeClient{})
+	if w := request(t, s, "POST", "/containers/create?name=web", `{"Image": "`+bundle+`"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "DELETE", "/containers/db", ""); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a running container but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "DELETE", "/containers/web", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "DELETE", "/containers/web", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a removed container but received %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestPing(t *testing.T) {
+	s := newServer("/logs", &fakeClient{})
+	if w := request(t, s, "GET", "/_ping", ""); w.Code != http.StatusOK || w.Body.String() != "OK" {
+		t.Fatalf("expected OK but received %d: %s", w.Code, w.Body)
+	}
+	if w := request(t, s, "GET", "/v1.24/nonexistent", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 but received %d: %s", w.Code, w.Body)
+	}
+}
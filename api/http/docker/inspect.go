@@ -0,0 +1,194 @@
+package docker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/runtime"
+	netcontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+type containerJSON struct {
+	ID         string `json:"Id"`
+	Created    string
+	Path       string
+	Args       []string
+	State      containerState
+	Image      string
+	Name       string
+	Config     containerConfig
+	HostConfig hostConfig
+}
+
+type containerState struct {
+	Status     string
+	Running    bool
+	Paused     bool
+	Pid        int
+	ExitCode   int
+	StartedAt  string
+	FinishedAt string
+}
+
+type containerConfig struct {
+	Image     string
+	Cmd       []string
+	Labels    map[string]string
+	Tty       bool
+	StdinOnce bool
+}
+
+type hostConfig struct {
+	LogConfig logConfig
+}
+
+type containerSummary struct {
+	ID      string `json:"Id"`
+	Names   []string
+	Image   string
+	Command string
+	Created int64
+	State   string
+	Status  string
+	Labels  map[string]string
+}
+
+func (s *server) inspect(w http.ResponseWriter, r *http.Request, id string) {
+	resp, err := s.c.State(netcontext.Background(), &types.StateRequest{Id: id})
+	if err != nil && grpc.Code(err) != codes.NotFound {
+		writeRPCError(w, err)
+		return
+	}
+	s.mu.Lock()
+	var c *container
+	if cc, ok := s.containers[id]; ok {
+		cp := *cc
+		c = &cp
+	}
+	s.mu.Unlock()
+	var running *types.Container
+	if err == nil && len(resp.Containers) == 1 {
+		running = resp.Containers[0]
+	}
+	if running == nil && c == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No such container: %s", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, toContainerJSON(c, running))
+}
+
+// toContainerJSON returns the inspect response of a container created through
+// the API, running or both
+func toContainerJSON(c *container, running *types.Container) *containerJSON {
+	j := &containerJSON{
+		Created: formatTime(time.Time{}),
+		State: containerState{
+			StartedAt:  formatTime(time.Time{}),
+			FinishedAt: formatTime(time.Time{}),
+		},
+	}
+	if c != nil {
+		j.ID, j.Image = c.ID, c.Bundle
+		j.Created = formatTime(c.Created)
+		j.Config.Labels = c.Labels
+		j.Config.StdinOnce = c.StdinOnce
+		j.HostConfig.LogConfig = c.LogConfig
+		j.State.Status = "created"
+		j.State.StartedAt = formatTime(c.StartedAt)
+		if c.Exited {
+			j.State.Status = "exited"
+			j.State.ExitCode = c.ExitCode
+			j.State.FinishedAt = formatTime(c.FinishedAt)
+		}
+	}
+	if running != nil {
+		j.ID, j.Image = running.Id, running.BundlePath
+		j.Config.Labels = fromLabels(running.Labels)
+		j.State.Status = running.Status
+		j.State.Running = running.Status == string(runtime.Running)
+		j.State.Paused = running.Status == string(runtime.Paused)
+		if running.Status == string(runtime.Stopped) {
+			j.State.Status = "exited"
+		}
+		for _, p := range running.Processes {
+			if p.Pid == runtime.InitProcessID {
+				j.State.Pid = int(p.SystemPid)
+				j.Config.Cmd = p.Args
+				j.Config.Tty = p.Terminal
+			}
+		}
+	}
+	j.Name = "/" + j.ID
+	j.Config.Image = j.Image
+	if c != nil && len(j.Config.Cmd) == 0 {
+		if spec, err := runtime.ReadSpec(c.Bundle); err == nil {
+			j.Config.Cmd = spec.Process.Args
+			j.Config.Tty = spec.Process.Terminal
+		}
+	}
+	if len(j.Config.Cmd) > 0 {
+		j.Path, j.Args = j.Config.Cmd[0], j.Config.Cmd[1:]
+	}
+	return j
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (s *server) list(w http.ResponseWriter, r *http.Request) {
+	all, _ := strconv.ParseBool(r.URL.Query().Get("all"))
+	resp, err := s.c.State(netcontext.Background(), &types.StateRequest{})
+	if err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*containerSummary{}
+	seen := make(map[string]bool)
+	for _, rc := range resp.Containers {
+		seen[rc.Id] = true
+		out = append(out, toSummary(s.containers[rc.Id], rc))
+	}
+	if all {
+		for id, c := range s.containers {
+			if !seen[id] {
+				out = append(out, toSummary(c, nil))
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func toSummary(c *container, running *types.Container) *containerSummary {
+	j := toContainerJSON(c, running)
+	sum := &containerSummary{
+		ID:      j.ID,
+		Names:   []string{j.Name},
+		Image:   j.Image,
+		Command: strings.Join(j.Config.Cmd, " "),
+		State:   j.State.Status,
+		Labels:  j.Config.Labels,
+	}
+	if c != nil {
+		sum.Created = c.Created.Unix()
+	}
+	switch {
+	case j.State.Paused:
+		sum.Status = "Up (Paused)"
+	case j.State.Running:
+		sum.Status = "Up"
+	case j.State.Status == "exited":
+		sum.Status = fmt.Sprintf("Exited (%d)", j.State.ExitCode)
+	default:
+		sum.Status = "Created"
+	}
+	return sum
+}
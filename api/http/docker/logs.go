@@ -0,0 +1,167 @@
+package docker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// streams are the stream ids of the Docker API's multiplexed stdio
+var streams = map[string]byte{
+	"stdout": 1,
+	"stderr": 2,
+}
+
+// logWriter writes log entries as the Docker API's multiplexed stdio, or as
+// they are for containers with a terminal
+type logWriter struct {
+	w          io.Writer
+	flusher    http.Flusher
+	raw        bool
+	timestamps bool
+	streams    map[string]bool
+}
+
+func (l *logWriter) write(stream string, data []byte, t time.Time) error {
+	if !l.streams[stream] {
+		return nil
+	}
+	if l.timestamps {
+		data = append([]byte(t.UTC().Format(time.RFC3339Nano)+" "), data...)
+	}
+	if !l.raw {
+		header := make([]byte, 8)
+		header[0] = streams[stream]
+		binary.BigEndian.PutUint32(header[4:], uint32(len(data)))
+		if _, err := l.w.Write(header); err != nil {
+			return err
+		}
+	}
+	if _, err := l.w.Write(data); err != nil {
+		return err
+	}
+	if l.flusher != nil {
+		l.flusher.Flush()
+	}
+	return nil
+}
+
+func (s *server) logs(w http.ResponseWriter, r *http.Request, id string) {
+	q := r.URL.Query()
+	stdout, _ := strconv.ParseBool(q.Get("stdout"))
+	stderr, _ := strconv.ParseBool(q.Get("stderr"))
+	if !stdout && !stderr {
+		writeError(w, http.StatusBadRequest, "Bad parameters: you must choose at least one stream")
+		return
+	}
+	follow, _ := strconv.ParseBool(q.Get("follow"))
+	timestamps, _ := strconv.ParseBool(q.Get("timestamps"))
+	var tail int
+	if t := q.Get("tail"); t != "" && t != "all" {
+		n, err := strconv.Atoi(t)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		tail = n
+	}
+	var since int64
+	if v := q.Get("since"); v != "" {
+		// the time may have a fraction of seconds
+		n, err := strconv.ParseInt(strings.SplitN(v, ".", 2)[0], 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		since = n
+	}
+	s.mu.Lock()
+	c, ok := s.containers[id]
+	var bundle string
+	var exited bool
+	if ok {
+		bundle, exited = c.Bundle, !c.Started
+	}
+	s.mu.Unlock()
+	lw := &logWriter{
+		w:          w,
+		timestamps: timestamps,
+		streams: map[string]bool{
+			"stdout": stdout,
+			"stderr": stderr,
+		},
+	}
+	if follow {
+		lw.flusher, _ = w.(http.Flusher)
+	}
+	if spec, err := runtime.ReadSpec(bundle); ok && err == nil {
+		lw.raw = spec.Process.Terminal
+	}
+	if ok && exited {
+		// the container is not known to containerd anymore so its log file is
+		// read directly
+		if c.LogConfig.Type != "json-file" {
+			writeError(w, http.StatusNotImplemented, fmt.Sprintf("configured logging driver does not support reading: %s", c.LogConfig.Type))
+			return
+		}
+		config := logger.ReadConfig{
+			Tail: tail,
+		}
+		if since != 0 {
+			config.Since = time.Unix(since, 0)
+		}
+		w.Header().Set("Content-Type", "application/vnd.docker.raw-stream")
+		w.WriteHeader(http.StatusOK)
+		path := logger.JSONFilePath(s.logPath(id), id, runtime.InitProcessID)
+		if err := logger.ReadJSONFile(path, config, nil, func(e *logger.JSONLog) error {
+			return lw.write(e.Stream, []byte(e.Log), e.Time)
+		}); err != nil && !os.IsNotExist(err) {
+			log.WithField("error", err).Warn("containerd: read container logs")
+		}
+		return
+	}
+	ctx, cancel := requestContext(w)
+	defer cancel()
+	entries, err := s.c.GetLogs(ctx, &types.GetLogsRequest{
+		Id:     id,
+		Tail:   uint32(tail),
+		Since:  uint64(since),
+		Follow: follow,
+	})
+	if err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	// the first entry is received before the header is written so that the
+	// error of a container that does not exist is answered
+	e, err := entries.Recv()
+	if err != nil && err != io.EOF {
+		if grpc.Code(err) == codes.NotFound && ok {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Container %s is not running", id))
+			return
+		}
+		writeRPCError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.docker.raw-stream")
+	w.WriteHeader(http.StatusOK)
+	for err == nil {
+		if err = lw.write(e.Stream, e.Data, time.Unix(0, int64(e.Timestamp))); err == nil {
+			e, err = entries.Recv()
+		}
+	}
+	if err != io.EOF {
+		log.WithField("error", err).Debug("containerd: stream container logs")
+	}
+}
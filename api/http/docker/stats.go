@@ -0,0 +1,229 @@
+package docker
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+type statsJSON struct {
+	Read        string                  `json:"read"`
+	PreRead     string                  `json:"preread"`
+	PidsStats   pidsStats               `json:"pids_stats"`
+	Networks    map[string]networkStats `json:"networks,omitempty"`
+	MemoryStats memoryStats             `json:"memory_stats"`
+	BlkioStats  blkioStats              `json:"blkio_stats"`
+	CPUStats    cpuStats                `json:"cpu_stats"`
+	PreCPUStats cpuStats                `json:"precpu_stats"`
+}
+
+type pidsStats struct {
+	Current uint64 `json:"current,omitempty"`
+	Limit   uint64 `json:"limit,omitempty"`
+}
+
+type networkStats struct {
+	RxBytes   uint64 `json:"rx_bytes"`
+	RxPackets uint64 `json:"rx_packets"`
+	RxErrors  uint64 `json:"rx_errors"`
+	RxDropped uint64 `json:"rx_dropped"`
+	TxBytes   uint64 `json:"tx_bytes"`
+	TxPackets uint64 `json:"tx_packets"`
+	TxErrors  uint64 `json:"tx_errors"`
+	TxDropped uint64 `json:"tx_dropped"`
+}
+
+type memoryStats struct {
+	Usage    uint64            `json:"usage,omitempty"`
+	MaxUsage uint64            `json:"max_usage,omitempty"`
+	Stats    map[string]uint64 `json:"stats,omitempty"`
+	Failcnt  uint64            `json:"failcnt,omitempty"`
+	Limit    uint64            `json:"limit,omitempty"`
+}
+
+type blkioStatEntry struct {
+	Major uint64 `json:"major"`
+	Minor uint64 `json:"minor"`
+	Op    string `json:"op"`
+	Value uint64 `json:"value"`
+}
+
+type blkioStats struct {
+	IoServiceBytesRecursive []blkioStatEntry `json:"io_service_bytes_recursive"`
+	IoServicedRecursive     []blkioStatEntry `json:"io_serviced_recursive"`
+	IoQueuedRecursive       []blkioStatEntry `json:"io_queue_recursive"`
+	IoServiceTimeRecursive  []blkioStatEntry `json:"io_service_time_recursive"`
+	IoWaitTimeRecursive     []blkioStatEntry `json:"io_wait_time_recursive"`
+	IoMergedRecursive       []blkioStatEntry `json:"io_merged_recursive"`
+	IoTimeRecursive         []blkioStatEntry `json:"io_time_recursive"`
+	SectorsRecursive        []blkioStatEntry `json:"sectors_recursive"`
+}
+
+type cpuUsage struct {
+	TotalUsage        uint64   `json:"total_usage"`
+	PercpuUsage       []uint64 `json:"percpu_usage,omitempty"`
+	UsageInKernelmode uint64   `json:"usage_in_kernelmode"`
+	UsageInUsermode   uint64   `json:"usage_in_usermode"`
+}
+
+type throttlingData struct {
+	Periods          uint64 `json:"periods"`
+	ThrottledPeriods uint64 `json:"throttled_periods"`
+	ThrottledTime    uint64 `json:"throttled_time"`
+}
+
+type cpuStats struct {
+	CPUUsage       cpuUsage       `json:"cpu_usage"`
+	SystemUsage    uint64         `json:"system_cpu_usage,omitempty"`
+	OnlineCPUs     uint32         `json:"online_cpus,omitempty"`
+	ThrottlingData throttlingData `json:"throttling_data,omitempty"`
+}
+
+func (s *server) stats(w http.ResponseWriter, r *http.Request, id string) {
+	stream := true
+	if v := r.URL.Query().Get("stream"); v != "" {
+		stream, _ = strconv.ParseBool(v)
+	}
+	if !stream {
+		resp, err := s.c.Stats(netcontext.Background(), &types.StatsRequest{Id: id})
+		if err != nil {
+			writeRPCError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, toStatsJSON(resp, nil))
+		return
+	}
+	ctx, cancel := requestContext(w)
+	defer cancel()
+	stats, err := s.c.StatsStream(ctx, &types.StatsRequest{Id: id})
+	if err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	// the first stats are received before the header is written so that the
+	// error of a container that does not exist is answered
+	resp, err := stats.Recv()
+	if err != nil {
+		writeRPCError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	var prev *types.StatsResponse
+	for err == nil {
+		if err = enc.Encode(toStatsJSON(resp, prev)); err != nil {
+			break
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		prev = resp
+		resp, err = stats.Recv()
+	}
+	log.WithField("error", err).Debug("containerd: stream container stats")
+}
+
+// toStatsJSON converts the stats to the Docker API's format, the cpu stats
+// of prev are the precpu stats
+func toStatsJSON(st, prev *types.StatsResponse) *statsJSON {
+	j := &statsJSON{
+		Read:    formatTime(time.Unix(int64(st.Timestamp), 0)),
+		PreRead: formatTime(time.Time{}),
+	}
+	for _, n := range st.NetworkStats {
+		if j.Networks == nil {
+			j.Networks = make(map[string]networkStats)
+		}
+		j.Networks[n.Name] = networkStats{
+			RxBytes:   n.RxBytes,
+			RxPackets: n.Rx_Packets,
+			RxErrors:  n.RxErrors,
+			RxDropped: n.RxDropped,
+			TxBytes:   n.TxBytes,
+			TxPackets: n.TxPackets,
+			TxErrors:  n.TxErrors,
+			TxDropped: n.TxDropped,
+		}
+	}
+	if prev != nil {
+		j.PreRead = formatTime(time.Unix(int64(prev.Timestamp), 0))
+		j.PreCPUStats = toCPUStats(prev.CgroupStats.GetCpuStats())
+	}
+	cg := st.CgroupStats
+	if cg == nil {
+		return j
+	}
+	j.CPUStats = toCPUStats(cg.CpuStats)
+	if p := cg.PidsStats; p != nil {
+		j.PidsStats = pidsStats{
+			Current: p.Current,
+			Limit:   p.Limit,
+		}
+	}
+	if m := cg.MemoryStats; m != nil {
+		j.MemoryStats.Stats = m.Stats
+		if u := m.Usage; u != nil {
+			j.MemoryStats.Usage = u.Usage
+			j.MemoryStats.MaxUsage = u.MaxUsage
+			j.MemoryStats.Failcnt = u.Failcnt
+			j.MemoryStats.Limit = u.Limit
+		}
+	}
+	if b := cg.BlkioStats; b != nil {
+		j.BlkioStats = blkioStats{
+			IoServiceBytesRecursive: toBlkioEntries(b.IoServiceBytesRecursive),
+			IoServicedRecursive:     toBlkioEntries(b.IoServicedRecursive),
+			IoQueuedRecursive:       toBlkioEntries(b.IoQueuedRecursive),
+			IoServiceTimeRecursive:  toBlkioEntries(b.IoServiceTimeRecursive),
+			IoWaitTimeRecursive:     toBlkioEntries(b.IoWaitTimeRecursive),
+			IoMergedRecursive:       toBlkioEntries(b.IoMergedRecursive),
+			IoTimeRecursive:         toBlkioEntries(b.IoTimeRecursive),
+			SectorsRecursive:        toBlkioEntries(b.SectorsRecursive),
+		}
+	}
+	return j
+}
+
+func toCPUStats(c *types.CpuStats) cpuStats {
+	var out cpuStats
+	if c == nil {
+		return out
+	}
+	out.SystemUsage = c.SystemUsage
+	if u := c.CpuUsage; u != nil {
+		out.CPUUsage = cpuUsage{
+			TotalUsage:        u.TotalUsage,
+			PercpuUsage:       u.PercpuUsage,
+			UsageInKernelmode: u.UsageInKernelmode,
+			UsageInUsermode:   u.UsageInUsermode,
+		}
+		out.OnlineCPUs = uint32(len(u.PercpuUsage))
+	}
+	if t := c.ThrottlingData; t != nil {
+		out.ThrottlingData = throttlingData{
+			Periods:          t.Periods,
+			ThrottledPeriods: t.ThrottledPeriods,
+			ThrottledTime:    t.ThrottledTime,
+		}
+	}
+	return out
+}
+
+func toBlkioEntries(entries []*types.BlkioStatsEntry) []blkioStatEntry {
+	out := []blkioStatEntry{}
+	for _, e := range entries {
+		out = append(out, blkioStatEntry{
+			Major: e.Major,
+			Minor: e.Minor,
+			Op:    e.Op,
+			Value: e.Value,
+		})
+	}
+	return out
+}
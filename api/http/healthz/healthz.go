@@ -0,0 +1,38 @@
+package healthz
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/supervisor"
+)
+
+// timeout is the time each check may take
+const timeout = 5 * time.Second
+
+// Enable serves /healthz on the address which answers 200 when all of the
+// supervisor's health checks pass and 503 otherwise, the checks are written
+// as json in both cases
+func Enable(address string, sv *supervisor.Supervisor) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		checks := sv.Health(timeout)
+		status := http.StatusOK
+		for _, c := range checks {
+			if !c.Healthy {
+				status = http.StatusServiceUnavailable
+			}
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(checks)
+	})
+	go func() {
+		if err := http.ListenAndServe(address, mux); err != nil {
+			logrus.WithField("error", err).Error("containerd: healthz http server")
+		}
+	}()
+	logrus.Debugf("healthz listening in address %s", address)
+}
@@ -0,0 +1,44 @@
+// Package local listens on the addresses of the http APIs that have no
+// authentication.  They act with the daemon's credentials so they are only
+// served to clients on the host.
+package local
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+)
+
+const unixPrefix = "unix://"
+
+// ErrNotLocal is returned for tcp addresses other hosts can connect to
+var ErrNotLocal = errors.New("address must be a unix:// socket or a loopback tcp address")
+
+// Listen returns a listener on the unix:// socket, which only root can connect
+// to, or on the tcp address if its host is a loopback address
+func Listen(address string) (net.Listener, error) {
+	if strings.HasPrefix(address, unixPrefix) {
+		path := strings.TrimPrefix(address, unixPrefix)
+		if err := os.RemoveAll(path); err != nil {
+			return nil, err
+		}
+		l, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(path, 0600); err != nil {
+			l.Close()
+			return nil, err
+		}
+		return l, nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return nil, ErrNotLocal
+	}
+	return net.Listen("tcp", address)
+}
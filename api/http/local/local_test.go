@@ -0,0 +1,47 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for address, allowed := range map[string]bool{
+		"unix://" + filepath.Join(dir, "api.sock"): true,
+		"127.0.0.1:0":   true,
+		"[::1]:0":       true,
+		"localhost:0":   true,
+		"0.0.0.0:2375":  false,
+		":2375":         false,
+		"10.0.0.1:0":    false,
+		"example.com:0": false,
+	} {
+		l, err := Listen(address)
+		if allowed && err != nil {
+			t.Errorf("expected to listen on %s: %v", address, err)
+		}
+		if !allowed && err != ErrNotLocal {
+			t.Errorf("expected %s to be refused but received %v", address, err)
+		}
+		if err != nil {
+			continue
+		}
+		if l.Addr().Network() == "unix" {
+			fi, err := os.Stat(l.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fi.Mode().Perm() != 0600 {
+				t.Errorf("expected the socket to have mode 0600 but it has %v", fi.Mode().Perm())
+			}
+		}
+		l.Close()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"runtime"
 
 	"github.com/Sirupsen/logrus"
 )
@@ -21,9 +22,29 @@ func Enable(address string) {
 	http.Handle("/debug/pprof/heap", pprof.Handler("heap"))
 	http.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
 	http.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
+	http.Handle("/debug/stack", http.HandlerFunc(stackDump))
 
-	go http.ListenAndServe(address, nil)
-	logrus.Debug("pprof listening in address %s", address)
+	go func() {
+		if err := http.ListenAndServe(address, nil); err != nil {
+			logrus.WithField("error", err).Error("containerd: debug http server")
+		}
+	}()
+	logrus.Debugf("pprof listening in address %s", address)
+}
+
+// stackDump writes the stacks of all goroutines
+func stackDump(w http.ResponseWriter, r *http.Request) {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(buf)
 }
 
 // Replicated from expvar.go as not public.
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/docker/containerd"
+)
+
+// schema is an OpenAPI 2.0 schema object
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+}
+
+// openAPI returns the OpenAPI 2.0 definitions of the methods, the schemas are
+// derived from the json encoding of the generated types
+func openAPI(methods []*method) []byte {
+	definitions := make(map[string]*schema)
+	paths := make(map[string]interface{})
+	errorRef := schemaOf(reflect.TypeOf(errorBody{}), definitions)
+	for _, m := range methods {
+		response := schemaOf(m.response, definitions)
+		description := "OK"
+		if m.stream {
+			description = "a stream of newline delimited {\"result\": ...} objects"
+			response = &schema{
+				Type: "object",
+				Properties: map[string]*schema{
+					"result": response,
+					"error":  errorRef,
+				},
+			}
+		}
+		paths[prefix+m.name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": m.name,
+				"parameters": []interface{}{
+					map[string]interface{}{
+						"name":     "body",
+						"in":       "body",
+						"required": true,
+						"schema":   schemaOf(m.request, definitions),
+					},
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": description,
+						"schema":      response,
+					},
+					"default": map[string]interface{}{
+						"description": "the grpc code and message of the error",
+						"schema": &schema{
+							Type: "object",
+							Properties: map[string]*schema{
+								"error": errorRef,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	doc, err := json.MarshalIndent(map[string]interface{}{
+		"swagger": "2.0",
+		"info": map[string]string{
+			"title":   "containerd",
+			"version": containerd.Version,
+		},
+		"basePath":    "/",
+		"schemes":     []string{"http"},
+		"consumes":    []string{"application/json"},
+		"produces":    []string{"application/json"},
+		"paths":       paths,
+		"definitions": definitions,
+	}, "", "  ")
+	if err != nil {
+		// the document only holds maps, strings and schemas
+		panic(err)
+	}
+	return doc
+}
+
+// schemaOf returns the schema of the type's json encoding, structs are added
+// to the definitions and referenced
+func schemaOf(t reflect.Type, definitions map[string]*schema) *schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem(), definitions)
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return &schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint64:
+		return &schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &schema{Type: "string", Format: "byte"}
+		}
+		return &schema{Type: "array", Items: schemaOf(t.Elem(), definitions)}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), definitions)}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := definitions[name]; !ok {
+			s := &schema{
+				Type:       "object",
+				Properties: make(map[string]*schema),
+			}
+			// added before the fields for recursive types
+			definitions[name] = s
+			for i := 0; i < t.NumField(); i++ {
+				f := t.Field(i)
+				tag := strings.Split(f.Tag.Get("json"), ",")[0]
+				if f.PkgPath != "" || tag == "-" {
+					continue
+				}
+				if tag == "" {
+					tag = f.Name
+				}
+				s.Properties[tag] = schemaOf(f.Type, definitions)
+			}
+		}
+		return &schema{Ref: "#/definitions/" + name}
+	}
+	return &schema{}
+}
@@ -0,0 +1,286 @@
+// Package rest serves a JSON translation of containerd's grpc API over http so
+// that the API can be scripted with curl or used from languages without a grpc
+// implementation.
+//
+// Each rpc is served as POST /v1/<rpc> taking the json of its request in the
+// body, the field names are the ones of api.proto.  The json of the response
+// of an unary rpc is written as the body, the responses of a server streaming
+// rpc are written as one {"result": ...} object per line until the stream ends
+// or the client goes away.  Rpcs streaming from the client, Attach,
+// CopyToContainer and UploadBundle, are not served.  The OpenAPI definitions
+// of the served rpcs are served on GET /v1/openapi.json.
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	grpcserver "github.com/docker/containerd/api/grpc/server"
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/api/http/local"
+	"github.com/docker/containerd/logging"
+	netcontext "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+var log = logging.Logger("rest")
+
+// prefix is the path every rpc is served under
+const prefix = "/v1/"
+
+var (
+	contextType = reflect.TypeOf((*netcontext.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// method is an rpc of the APIClient that can be called with a json request
+type method struct {
+	name     string
+	fn       reflect.Value
+	request  reflect.Type
+	response reflect.Type
+	stream   bool
+}
+
+type server struct {
+	methods map[string]*method
+	openapi []byte
+}
+
+// Enable serves the json translation of the API on the address and forwards
+// the requests to the client.  The client calls the API with the daemon's
+// credentials and there is no authentication so the address is either a
+// unix:// socket or a loopback tcp address.
+func Enable(address string, c types.APIClient) error {
+	l, err := local.Listen(address)
+	if err != nil {
+		return err
+	}
+	s := newServer(c)
+	go func() {
+		if err := http.Serve(l, s); err != nil {
+			log.WithField("error", err).Error("containerd: rest api http server")
+		}
+	}()
+	log.Debugf("rest api listening in address %s", address)
+	return nil
+}
+
+func newServer(c types.APIClient) *server {
+	s := &server{
+		methods: make(map[string]*method),
+	}
+	v := reflect.ValueOf(c)
+	t := reflect.TypeOf((*types.APIClient)(nil)).Elem()
+	for i := 0; i < t.NumMethod(); i++ {
+		if m := newMethod(t.Method(i), v.MethodByName(t.Method(i).Name)); m != nil {
+			s.methods[m.name] = m
+		}
+	}
+	s.openapi = openAPI(s.sortedMethods())
+	return s
+}
+
+// newMethod returns the rpc of the client's method, or nil when the rpc
+// streams from the client
+func newMethod(m reflect.Method, fn reflect.Value) *method {
+	t := m.Type
+	// ctx, request, opts...
+	if t.NumIn() != 3 || t.In(0) != contextType || t.NumOut() != 2 || t.Out(1) != errorType {
+		return nil
+	}
+	out := t.Out(0)
+	if out.Kind() == reflect.Ptr {
+		return &method{
+			name:     m.Name,
+			fn:       fn,
+			request:  t.In(1).Elem(),
+			response: out.Elem(),
+		}
+	}
+	recv, ok := out.MethodByName("Recv")
+	if !ok || recv.Type.NumOut() != 2 {
+		return nil
+	}
+	return &method{
+		name:     m.Name,
+		fn:       fn,
+		request:  t.In(1).Elem(),
+		response: recv.Type.Out(0).Elem(),
+		stream:   true,
+	}
+}
+
+func (s *server) sortedMethods() []*method {
+	var names []string
+	for name := range s.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var methods []*method
+	for _, name := range names {
+		methods = append(methods, s.methods[name])
+	}
+	return methods
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == prefix+"openapi.json" && r.Method == "GET" {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(s.openapi)
+		return
+	}
+	m, ok := s.methods[strings.TrimPrefix(r.URL.Path, prefix)]
+	if !ok {
+		writeError(w, http.StatusNotFound, codes.NotFound, "page not found")
+		return
+	}
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		writeError(w, http.StatusMethodNotAllowed, codes.Unimplemented, "method not allowed")
+		return
+	}
+	in := reflect.New(m.request)
+	if err := json.NewDecoder(r.Body).Decode(in.Interface()); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+	ctx, cancel := requestContext(w)
+	defer cancel()
+	var trailer metadata.MD
+	out := m.fn.Call([]reflect.Value{reflect.ValueOf(ctx), in, reflect.ValueOf(grpc.Trailer(&trailer))})
+	if err, _ := out[1].Interface().(error); err != nil {
+		writeRPCError(w, err, trailer)
+		return
+	}
+	if m.stream {
+		s.stream(w, out[0])
+		return
+	}
+	writeJSON(w, http.StatusOK, out[0].Interface())
+}
+
+// stream writes each response received from the stream as a line of json
+// until the stream ends, an error ending the stream is written as the last
+// line
+func (s *server) stream(w http.ResponseWriter, stream reflect.Value) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	cs := stream.Interface().(grpc.ClientStream)
+	for {
+		out := stream.MethodByName("Recv").Call(nil)
+		if err, _ := out[1].Interface().(error); err != nil {
+			if err != io.EOF {
+				enc.Encode(map[string]interface{}{
+					"error": rpcError(err, cs.Trailer()),
+				})
+			}
+			return
+		}
+		if err := enc.Encode(map[string]interface{}{
+			"result": out[0].Interface(),
+		}); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// requestContext returns a context that is canceled when the client closes
+// the connection
+func requestContext(w http.ResponseWriter) (netcontext.Context, func()) {
+	ctx, cancel := netcontext.WithCancel(netcontext.Background())
+	if cn, ok := w.(http.CloseNotifier); ok {
+		closed := cn.CloseNotify()
+		go func() {
+			select {
+			case <-closed:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	return ctx, cancel
+}
+
+type errorBody struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+	// ErrorCode is the name of the daemon's ErrorCode of the error
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+// rpcError returns the body of an rpc's error with the ErrorCode of the rpc's
+// trailer
+func rpcError(err error, trailer metadata.MD) errorBody {
+	b := errorBody{
+		Code:    grpc.Code(err),
+		Message: grpc.ErrorDesc(err),
+	}
+	if v := trailer[grpcserver.ErrorCodeKey]; len(v) > 0 {
+		b.ErrorCode = v[0]
+	}
+	return b
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code codes.Code, msg string) {
+	writeJSON(w, status, map[string]errorBody{
+		"error": {Code: code, Message: msg},
+	})
+}
+
+// writeRPCError writes the error of a grpc call with the http status of its
+// code
+func writeRPCError(w http.ResponseWriter, err error, trailer metadata.MD) {
+	writeJSON(w, httpStatus(grpc.Code(err)), map[string]errorBody{
+		"error": rpcError(err, trailer),
+	})
+}
+
+// httpStatus returns the http status closest to the grpc code
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return http.StatusRequestTimeout
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/api/http/local"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeClient knows the container c, the rpcs it does not implement panic
+type fakeClient struct {
+	types.APIClient
+}
+
+func (c *fakeClient) State(ctx context.Context, r *types.StateRequest, opts ...grpc.CallOption) (*types.StateResponse, error) {
+	if r.Id != "" && r.Id != "c" {
+		return nil, grpc.Errorf(codes.NotFound, "containerd: container not found")
+	}
+	return &types.StateResponse{
+		Containers: []*types.Container{{Id: "c", Status: "running"}},
+	}, nil
+}
+
+func (c *fakeClient) Events(ctx context.Context, r *types.EventsRequest, opts ...grpc.CallOption) (types.API_EventsClient, error) {
+	return &fakeEvents{
+		events: []*types.Event{{Type: "start-container", Id: "c"}, {Type: "exit", Id: "c"}},
+	}, nil
+}
+
+type fakeEvents struct {
+	types.API_EventsClient
+	events []*types.Event
+}
+
+func (e *fakeEvents) Recv() (*types.Event, error) {
+	if len(e.events) == 0 {
+		return nil, io.EOF
+	}
+	evt := e.events[0]
+	e.events = e.events[1:]
+	return evt, nil
+}
+
+func post(t *testing.T, s *server, path, body string) *httptest.ResponseRecorder {
+	r, err := http.NewRequest("POST", path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestUnary(t *testing.T) {
+	s := newServer(&fakeClient{})
+	w := post(t, s, "/v1/State", `{"id": "c"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 but received %d: %s", w.Code, w.Body)
+	}
+	var resp types.StateResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Containers) != 1 || resp.Containers[0].Id != "c" {
+		t.Fatalf("expected the container c but received %v", resp.Containers)
+	}
+	// an empty body is an empty request
+	if w := post(t, s, "/v1/State", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for an empty body but received %d: %s", w.Code, w.Body)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := newServer(&fakeClient{})
+	for _, c := range []struct {
+		path, body string
+		status     int
+		code       codes.Code
+	}{
+		{"/v1/State", `{"id": "missing"}`, http.StatusNotFound, codes.NotFound},
+		{"/v1/State", `{"id":`, http.StatusBadRequest, codes.InvalidArgument},
+		{"/v1/Attach", `{}`, http.StatusNotFound, codes.NotFound},
+	} {
+		w := post(t, s, c.path, c.body)
+		if w.Code != c.status {
+			t.Errorf("%s %s: expected status %d but received %d", c.path, c.body, c.status, w.Code)
+		}
+		var resp map[string]errorBody
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp["error"].Code != c.code {
+			t.Errorf("%s %s: expected code %s but received %s", c.path, c.body, c.code, resp["error"].Code)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	s := newServer(&fakeClient{})
+	w := post(t, s, "/v1/Events", `{}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 but received %d: %s", w.Code, w.Body)
+	}
+	var received []string
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var line struct {
+			Result struct {
+				Type string `json:"type"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatal(err)
+		}
+		received = append(received, line.Result.Type)
+	}
+	if strings.Join(received, ",") != "start-container,exit" {
+		t.Fatalf("expected the start and exit events but received %v", received)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	s := newServer(&fakeClient{})
+	r, err := http.NewRequest("GET", "/v1/openapi.json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	var doc struct {
+		Paths       map[string]interface{}
+		Definitions map[string]*schema
+	}
+	if err := json.NewDecoder(w.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/v1/CreateContainer", "/v1/Events"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("expected the path %s", path)
+		}
+	}
+	if _, ok := doc.Paths["/v1/Attach"]; ok {
+		t.Error("expected no path for the client streaming Attach")
+	}
+	d, ok := doc.Definitions["CreateContainerRequest"]
+	if !ok {
+		t.Fatal("expected the definition of CreateContainerRequest")
+	}
+	if p := d.Properties["bundlePath"]; p == nil || p.Type != "string" {
+		t.Errorf("expected the string property bundlePath but received %v", p)
+	}
+	if p := d.Properties["volumes"]; p == nil || p.Items == nil || p.Items.Ref != "#/definitions/Volume" {
+		t.Errorf("expected the property volumes to be an array of Volume but received %v", p)
+	}
+}
+
+func TestEnableOnlyLocal(t *testing.T) {
+	for _, address := range []string{"0.0.0.0:8080", ":8080", "10.0.0.1:0", "example.com:0"} {
+		if err := Enable(address, &fakeClient{}); err != local.ErrNotLocal {
+			t.Errorf("expected %s to be refused but received %v", address, err)
+		}
+	}
+	if err := Enable("127.0.0.1:0", &fakeClient{}); err != nil {
+		t.Fatalf("expected to serve on a loopback address: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/symlink"
+)
+
+var (
+	ErrPathEscapes     = errors.New("containerd: archive entry escapes the destination")
+	ErrNotDirectory    = errors.New("containerd: destination parent is not a directory")
+	errEmptyArchive    = errors.New("containerd: archive does not contain any entries")
+	errUnsupportedType = errors.New("containerd: unsupported archive entry type")
+)
+
+// Resolve returns the host path for path interpreted inside of root.  Any
+// symlinks are evaluated as if root was the filesystem root so that the
+// result can never point outside of root.
+func Resolve(root, path string) (string, error) {
+	return symlink.FollowSymlinkInScope(filepath.Join(root, filepath.Clean("/"+path)), root)
+}
+
+// Tar returns a tar stream of path inside of root.  The entries in the stream
+// are named relative to the parent of path so that a file or directory is
+// recreated with its own name when the stream is extracted.
+func Tar(root, path string) (io.ReadCloser, error) {
+	src, err := Resolve(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Lstat(src); err != nil {
+		return nil, err
+	}
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeTar(w, src))
+	}()
+	return r, nil
+}
+
+func writeTar(w io.Writer, src string) error {
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(src)
+	err := filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if fi.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, f)
+		f.Close()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// Untar extracts the tar stream r to dest inside of root.  If dest is an
+// existing directory the entries are extracted inside of it, otherwise the
+// top level entry of the archive is renamed to the last element of dest.
+func Untar(r io.Reader, root, dest string) error {
+	target, err := Resolve(root, dest)
+	if err != nil {
+		return err
+	}
+	var (
+		dir    = target
+		rename string
+	)
+	if fi, err := os.Stat(target); err != nil || !fi.IsDir() {
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		dir, rename = filepath.Dir(target), filepath.Base(target)
+	}
+	if fi, err := os.Stat(dir); err != nil {
+		return err
+	} else if !fi.IsDir() {
+		return ErrNotDirectory
+	}
+	tr := tar.NewReader(r)
+	var entries int
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		entries++
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = entryName(hdr.Linkname, rename)
+		}
+		if err := extract(tr, hdr, root, dir, entryName(hdr.Name, rename)); err != nil {
+			return err
+		}
+	}
+	if entries == 0 {
+		return errEmptyArchive
+	}
+	return nil
+}
+
+// entryName returns the cleaned name of an archive entry with its top level
+// element replaced by rename if one is set
+func entryName(name, rename string) string {
+	name = filepath.Clean(filepath.FromSlash(name))
+	if rename == "" {
+		return name
+	}
+	parts := strings.SplitN(name, string(filepath.Separator), 2)
+	parts[0] = rename
+	return filepath.Join(parts...)
+}
+
+func extract(r io.Reader, hdr *tar.Header, root, dir, name string) error {
+	path := filepath.Join(dir, name)
+	if !within(dir, path) {
+		return ErrPathEscapes
+	}
+	// resolve the parent so that a symlink created by an earlier entry cannot
+	// be used to write outside of root
+	parent, err := symlink.FollowSymlinkInScope(filepath.Dir(path), root)
+	if err != nil {
+		return err
+	}
+	path = filepath.Join(parent, filepath.Base(path))
+	mode := os.FileMode(hdr.Mode).Perm()
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if fi, err := os.Lstat(path); err == nil && fi.IsDir() {
+			return os.Chmod(path, mode)
+		}
+		if err := os.Mkdir(path, mode); err != nil {
+			return err
+		}
+	case tar.TypeReg, tar.TypeRegA:
+		// never write through an existing symlink
+		if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, r)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		if err := os.RemoveAll(path); err != nil {
+			return err
+		}
+		if err := os.Symlink(hdr.Linkname, path); err != nil {
+			return err
+		}
+		return nil
+	case tar.TypeLink:
+		src := filepath.Join(dir, hdr.Linkname)
+		if !within(dir, src) {
+			return ErrPathEscapes
+		}
+		if src, err = symlink.FollowSymlinkInScope(src, root); err != nil {
+			return err
+		}
+		if err := os.Link(src, path); err != nil {
+			return err
+		}
+	default:
+		return errUnsupportedType
+	}
+	return os.Chtimes(path, hdr.ModTime, hdr.ModTime)
+}
+
+// within returns true if path is dir or is located below dir
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
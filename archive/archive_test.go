@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTarUntar(t *testing.T) {
+	src, err := ioutil.TempDir("", "archive-src-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	if err := os.MkdirAll(filepath.Join(src, "etc", "conf.d"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "etc", "conf.d", "app"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := Tar(src, "/etc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	dst, err := ioutil.TempDir("", "archive-dst-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+	if err := Untar(rc, dst, "/config"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dst, "config", "conf.d", "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "data" {
+		t.Fatalf("expected %q but received %q", "data", string(data))
+	}
+}
+
+func TestUntarSymlinkEscape(t *testing.T) {
+	root, err := ioutil.TempDir("", "archive-root-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	outside, err := ioutil.TempDir("", "archive-outside-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, h := range []*tar.Header{
+		{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "dir/link", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "dir/link/file", Typeflag: tar.TypeReg, Mode: 0644},
+	} {
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	Untar(buf, root, "/")
+	if _, err := os.Stat(filepath.Join(outside, "file")); !os.IsNotExist(err) {
+		t.Fatalf("expected file not to be written outside of root: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// Address is an address of an interface in a container's network namespace
+type Address struct {
+	Interface string
+	// Label is the label of the address, the primary IPv4 addresses are
+	// labelled with the name of their interface
+	Label string
+	// Address is in CIDR notation
+	Address string
+}
+
+func newAddresses(addrs []*types.InterfaceAddress) []Address {
+	var out []Address
+	for _, a := range addrs {
+		out = append(out, Address{
+			Interface: a.Interface,
+			Label:     a.Label,
+			Address:   a.Address,
+		})
+	}
+	return out
+}
+
+// AddAddress adds the IPv4 address in CIDR notation to the interface of the
+// running container id, the label is the interface's name followed by a colon
+// and a suffix, e.g. eth0:meta
+func (c *Client) AddAddress(ctx context.Context, id, iface, label, address string) error {
+	_, err := c.API().AddAddress(ctx, &types.AddAddressRequest{
+		Id:        id,
+		Interface: iface,
+		Label:     label,
+		Address:   address,
+	})
+	return translate(err)
+}
+
+// DeleteAddresses removes the addresses with the label from the running
+// container id and returns them
+func (c *Client) DeleteAddresses(ctx context.Context, id, label string) ([]Address, error) {
+	resp, err := c.API().DeleteAddresses(ctx, &types.DeleteAddressesRequest{
+		Id:    id,
+		Label: label,
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	return newAddresses(resp.Addresses), nil
+}
+
+// Addresses returns the addresses of the interfaces of the running container
+// id, only the addresses with the label when it is not empty
+func (c *Client) Addresses(ctx context.Context, id, label string) ([]Address, error) {
+	resp, err := c.API().ListAddresses(ctx, &types.ListAddressesRequest{
+		Id:    id,
+		Label: label,
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	return newAddresses(resp.Addresses), nil
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"io"
+	"sync"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/mux"
+	"golang.org/x/net/context"
+)
+
+// stdinChunkSize is the size of the stdin sent in each message of an attach
+const stdinChunkSize = 32 * 1024
+
+// Attachment is a client attached to the stdio of a process
+type Attachment struct {
+	stream types.API_AttachClient
+	// mu serializes the sends of Write and CloseStdin
+	mu sync.Mutex
+}
+
+// Attach attaches to the stdio of the process pid of the container id,
+// replaying up to replay bytes of its recent output.  Cancel ctx to detach.
+func (c *Client) Attach(ctx context.Context, id, pid string, replay int) (*Attachment, error) {
+	stream, err := c.API().Attach(ctx)
+	if err != nil {
+		return nil, translate(err)
+	}
+	if err := stream.Send(&types.AttachRequest{
+		Id:     id,
+		Pid:    pid,
+		Replay: uint32(replay),
+	}); err != nil {
+		return nil, translate(err)
+	}
+	return &Attachment{stream: stream}, nil
+}
+
+// Write sends p to the stdin of the process
+func (a *Attachment) Write(p []byte) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > stdinChunkSize {
+			chunk = chunk[:stdinChunkSize]
+		}
+		if err := a.stream.Send(&types.AttachRequest{Stdin: chunk}); err != nil {
+			return n, translate(err)
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// CloseStdin closes the stdin of the process, the output is still received
+func (a *Attachment) CloseStdin() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return translate(a.stream.Send(&types.AttachRequest{CloseStdin: true}))
+}
+
+// Copy copies the output of the process to stdout and stderr until the
+// process exits, all the output of a process with a terminal is on stdout
+func (a *Attachment) Copy(stdout, stderr io.Writer) error {
+	for {
+		r, err := a.stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return translate(err)
+		}
+		w := stdout
+		if mux.Stream(r.Stream) == mux.Stderr {
+			w = stderr
+		}
+		if _, err := w.Write(r.Data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"io"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// Backup writes an archive of the daemon's container records, checkpoints
+// index, templates and groups to w
+func (c *Client) Backup(ctx context.Context, w io.Writer) error {
+	stream, err := c.API().Backup(ctx, &types.BackupRequest{})
+	if err != nil {
+		return translate(err)
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return translate(err)
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// RestoreBackup sends the backup archive r to the daemon, it returns the ids
+// of the containers that were restored and of those that existed already or
+// could not be restored
+func (c *Client) RestoreBackup(ctx context.Context, r io.Reader) (restored, skipped []string, err error) {
+	stream, err := c.API().RestoreBackup(ctx)
+	if err != nil {
+		return nil, nil, translate(err)
+	}
+	buf := make([]byte, bundleChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.RestoreBackupRequest{Data: buf[:n]}); serr != nil {
+				return nil, nil, translate(serr)
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			stream.CloseSend()
+			return nil, nil, err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, nil, translate(err)
+	}
+	return resp.Restored, resp.Skipped, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"io"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// bundleChunkSize is the size of the tar stream sent in each message of an
+// upload
+const bundleChunkSize = 32 * 1024
+
+// UploadBundle sends the tar stream r of a bundle's config.json and rootfs to
+// the daemon and returns the id of the bundle, containers are created from it
+// with the BundleID of CreateOpts
+func (c *Client) UploadBundle(ctx context.Context, r io.Reader) (string, error) {
+	stream, err := c.API().UploadBundle(ctx)
+	if err != nil {
+		return "", translate(err)
+	}
+	buf := make([]byte, bundleChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.UploadBundleRequest{Data: buf[:n]}); serr != nil {
+				return "", translate(serr)
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			stream.CloseSend()
+			return "", err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", translate(err)
+	}
+	return resp.Id, nil
+}
@@ -0,0 +1,304 @@
+// Package client is the Go client of containerd's grpc API.
+//
+// It wraps the generated types.APIClient with plain types for containers and
+// processes, helpers for the common calls and errors that can be compared
+// against ErrNotFound and ErrAlreadyExists.  The generated client is still
+// available from API for the rpcs that have no helper.
+package client
+
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+)
+
+// DefaultAddress is the address the daemon listens on by default
+const DefaultAddress = "/run/containerd/containerd.sock"
+
+// DefaultTimeout is the time New waits for the connection to the daemon
+const DefaultTimeout = 1 * time.Second
+
+var (
+	// ErrNotFound is returned when the container, process, group or
+	// checkpoint of a call does not exist
+	ErrNotFound = errors.New("containerd: not found")
+	// ErrAlreadyExists is returned when the container, group or checkpoint
+	// a call creates already exists, or when the container or process is not
+	// in a state allowing the call
+	ErrAlreadyExists = errors.New("containerd: already exists")
+	// ErrQuotaExceeded is returned when a call would take the namespace of
+	// the client over its quota
+	ErrQuotaExceeded = errors.New("containerd: quota exceeded")
+	// ErrClosed is returned by the streams of a client that was closed
+	ErrClosed = errors.New("containerd: client is closed")
+)
+
+// State is the state of a client's connection to the daemon
+type State int
+
+const (
+	// Connected is the state of a client that can reach the daemon
+	Connected State = iota
+	// Disconnected is the state of a client that lost its connection and is
+	// reconnecting
+	Disconnected
+	// Closed is the state of a client after Close
+	Closed
+)
+
+func (s State) String() string {
+	switch s {
+	case Connected:
+		return "connected"
+	case Disconnected:
+		return "disconnected"
+	case Closed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// Backoff is the delay between the attempts to reconnect to the daemon,
+// starting at Initial and multiplied by Factor after each failed attempt up
+// to Max
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultBackoff is the backoff of the clients returned by New
+var DefaultBackoff = Backoff{
+	Initial: 100 * time.Millisecond,
+	Max:     10 * time.Second,
+	Factor:  2,
+}
+
+// next returns the delay after d
+func (b Backoff) next(d time.Duration) time.Duration {
+	if d = time.Duration(float64(d) * b.Factor); d > b.Max {
+		d = b.Max
+	}
+	return d
+}
+
+// Client is a connection to containerd, it is safe for concurrent use.  A
+// client returned by New reconnects to the daemon when the connection is
+// lost, the calls made while it is disconnected fail.
+type Client struct {
+	address string
+	timeout time.Duration
+	// Backoff is the delay between the attempts to reconnect, it must be
+	// set before the connection is lost
+	Backoff Backoff
+
+	mu      sync.Mutex
+	conn    *grpc.ClientConn
+	api     types.APIClient
+	state   State
+	onState func(State)
+	// unary and streams are the interceptors of the rpcs
+	unary   []UnaryClientInterceptor
+	streams []StreamClientInterceptor
+	// done is closed by Close
+	done chan struct{}
+}
+
+// New connects to the daemon listening on the unix socket at address within
+// timeout
+func New(address string, timeout time.Duration) (*Client, error) {
+	// grpc logs its reconnects to stderr which is the caller's to use
+	grpclog.SetLogger(log.New(ioutil.Discard, "", log.LstdFlags))
+	conn, err := dial(address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	c := NewFromConn(conn)
+	c.address = address
+	c.timeout = timeout
+	go c.monitor(conn)
+	return c, nil
+}
+
+// NewFromConn returns a client using an established grpc connection, the
+// client does not reconnect when the connection is closed
+func NewFromConn(conn *grpc.ClientConn) *Client {
+	return &Client{
+		Backoff: DefaultBackoff,
+		conn:    conn,
+		api:     types.NewAPIClient(conn),
+		done:    make(chan struct{}),
+	}
+}
+
+func dial(address string, timeout time.Duration) (*grpc.ClientConn, error) {
+	return grpc.Dial(address,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithTimeout(timeout),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}),
+	)
+}
+
+// API returns the generated client for the rpcs that have no helper, it is
+// replaced when the client reconnects so it should not be kept
+func (c *Client) API() types.APIClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.api
+}
+
+// State returns the state of the connection to the daemon
+func (c *Client) State() State {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// OnStateChange sets the function called with the new state each time the
+// connection to the daemon is lost or established and when the client is
+// closed.  It is called from the client's goroutine and must not block.
+func (c *Client) OnStateChange(f func(State)) {
+	c.mu.Lock()
+	c.onState = f
+	c.mu.Unlock()
+}
+
+// Close closes the connection to the daemon and stops reconnecting
+func (c *Client) Close() error {
+	c.mu.Lock()
+	select {
+	case <-c.done:
+		c.mu.Unlock()
+		return nil
+	default:
+	}
+	close(c.done)
+	conn := c.conn
+	c.mu.Unlock()
+	err := conn.Close()
+	c.setState(Closed)
+	return err
+}
+
+func (c *Client) setState(s State) {
+	c.mu.Lock()
+	if c.state == s || c.state == Closed {
+		c.mu.Unlock()
+		return
+	}
+	c.state = s
+	f := c.onState
+	c.mu.Unlock()
+	if f != nil {
+		f(s)
+	}
+}
+
+// monitor follows the state of conn while grpc reconnects it and dials a new
+// connection once grpc has given up on it
+func (c *Client) monitor(conn *grpc.ClientConn) {
+	state := grpc.Ready
+	for {
+		s, err := conn.WaitForStateChange(context.Background(), state)
+		if err != nil {
+			return
+		}
+		state = s
+		switch s {
+		case grpc.Ready:
+			c.setState(Connected)
+		case grpc.TransientFailure:
+			c.setState(Disconnected)
+		case grpc.Shutdown:
+			select {
+			case <-c.done:
+				return
+			default:
+			}
+			c.setState(Disconnected)
+			c.reconnect()
+			return
+		}
+	}
+}
+
+// reconnect dials the daemon with a backoff until it succeeds or the client
+// is closed
+func (c *Client) reconnect() {
+	delay := c.Backoff.Initial
+	for {
+		conn, err := dial(c.address, c.timeout)
+		if err == nil {
+			c.mu.Lock()
+			select {
+			case <-c.done:
+				c.mu.Unlock()
+				conn.Close()
+				return
+			default:
+			}
+			c.conn, c.api = conn, c.newAPI(conn)
+			c.mu.Unlock()
+			c.setState(Connected)
+			go c.monitor(conn)
+			return
+		}
+		select {
+		case <-c.done:
+			return
+		case <-time.After(delay):
+		}
+		delay = c.Backoff.next(delay)
+	}
+}
+
+// disconnected returns true if err is caused by the loss of the connection to
+// the daemon rather than by the call
+func disconnected(err error) bool {
+	switch grpc.Code(err) {
+	case codes.Internal, codes.Unavailable:
+		return true
+	}
+	switch grpc.ErrorDesc(err) {
+	case grpc.ErrClientConnClosing.Error(), grpc.ErrClientConnTimeout.Error():
+		return true
+	}
+	return false
+}
+
+// translate returns ErrNotFound, ErrAlreadyExists or ErrQuotaExceeded for the
+// errors of the daemon with the NOT_FOUND, CONFLICT or QUOTA_EXCEEDED error
+// code and the description of the failures of the runtime.  Errors that are
+// not from the daemon, such as io.EOF, are returned as is.
+func translate(err error) error {
+	if err == nil {
+		return nil
+	}
+	desc := grpc.ErrorDesc(err)
+	if desc == err.Error() {
+		return err
+	}
+	switch grpc.Code(err) {
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.AlreadyExists:
+		return ErrAlreadyExists
+	case codes.ResourceExhausted:
+		return ErrQuotaExceeded
+	case codes.Unknown:
+		return errors.New(desc)
+	}
+	return err
+}
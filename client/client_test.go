@@ -0,0 +1,354 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/mux"
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/supervisor"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeServer serves the container c and returns the errors of the daemon
+// for any other container, the rpcs it does not implement panic
+type fakeServer struct {
+	types.APIServer
+	stdin chan []byte
+	// after receives the sequence number of each events subscription
+	after chan uint64
+}
+
+func (s *fakeServer) CreateContainer(ctx context.Context, r *types.CreateContainerRequest) (*types.CreateContainerResponse, error) {
+	if r.Id == "c" {
+		return nil, grpc.Errorf(codes.AlreadyExists, "%s", supervisor.ErrContainerExists)
+	}
+	return &types.CreateContainerResponse{
+		Container: &types.Container{
+			Id:         r.Id,
+			BundlePath: r.BundlePath,
+			Status:     "running",
+			Processes: []*types.Process{
+				{Pid: InitProcess, Args: []string{"sh"}},
+			},
+		},
+	}, nil
+}
+
+func (s *fakeServer) Wait(ctx context.Context, r *types.WaitRequest) (*types.WaitResponse, error) {
+	if r.Id != "c" {
+		return nil, grpc.Errorf(codes.NotFound, "%s", supervisor.ErrContainerNotFound)
+	}
+	return &types.WaitResponse{Status: 3}, nil
+}
+
+func (s *fakeServer) Signal(ctx context.Context, r *types.SignalRequest) (*types.SignalResponse, error) {
+	return nil, grpc.Errorf(codes.InvalidArgument, "invalid signal")
+}
+
+func (s *fakeServer) Attach(stream types.API_AttachServer) error {
+	r, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if r.Id != "c" {
+		return grpc.Errorf(codes.NotFound, "%s", runtime.ErrProcessNotFound)
+	}
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if r.CloseStdin {
+			break
+		}
+		s.stdin <- r.Stdin
+	}
+	stream.Send(&types.AttachResponse{Stream: uint32(mux.Stdout), Data: []byte("out")})
+	stream.Send(&types.AttachResponse{Stream: uint32(mux.Stderr), Data: []byte("err")})
+	return nil
+}
+
+// Events sends the two events after the requested sequence number and
+// waits for the client to go away
+func (s *fakeServer) Events(r *types.EventsRequest, stream types.API_EventsServer) error {
+	s.after <- r.AfterSeq
+	for seq := r.AfterSeq + 1; seq <= r.AfterSeq+2; seq++ {
+		if err := stream.Send(&types.Event{Type: "exit", Id: "c", Seq: seq}); err != nil {
+			return err
+		}
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+// State returns the containers a, b and c in pages of one container
+func (s *fakeServer) State(ctx context.Context, r *types.StateRequest) (*types.StateResponse, error) {
+	next := map[string]string{"": "a", "a": "b", "b": "c"}
+	id := next[r.PageToken]
+	resp := &types.StateResponse{
+		Containers: []*types.Container{{Id: id, Status: "running"}},
+	}
+	if id != "c" {
+		resp.NextPageToken = id
+	}
+	return resp, nil
+}
+
+// UploadBundle returns the number of bytes received as the id of the bundle
+func (s *fakeServer) UploadBundle(stream types.API_UploadBundleServer) error {
+	var n int
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		n += len(r.Data)
+	}
+	return stream.SendAndClose(&types.UploadBundleResponse{Id: strconv.Itoa(n)})
+}
+
+func serve(t *testing.T, path string, fake *fakeServer) *grpc.Server {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	types.RegisterAPIServer(s, fake)
+	go s.Serve(l)
+	return s
+}
+
+func newTestClient(t *testing.T) (*Client, *fakeServer, func()) {
+	dir, err := ioutil.TempDir("", "containerd-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeServer{
+		stdin: make(chan []byte, 1),
+		after: make(chan uint64, 4),
+	}
+	s := serve(t, filepath.Join(dir, "containerd.sock"), fake)
+	c, err := New(filepath.Join(dir, "containerd.sock"), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, fake, func() {
+		c.Close()
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "containerd.sock")
+	fake := &fakeServer{after: make(chan uint64, 4)}
+	s := serve(t, path, fake)
+	c, err := New(path, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.Backoff = Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Factor: 2}
+	states := make(chan State, 16)
+	c.OnStateChange(func(s State) {
+		states <- s
+	})
+	events, err := c.Events(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seq := range []uint64{1, 2} {
+		e, err := events.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Seq != seq {
+			t.Fatalf("expected event %d but received %d", seq, e.Seq)
+		}
+	}
+
+	// restart the daemon
+	s.Stop()
+	if s := <-states; s != Disconnected {
+		t.Fatalf("expected %s but received %s", Disconnected, s)
+	}
+	s = serve(t, path, fake)
+	defer s.Stop()
+	e, err := events.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Seq != 3 {
+		t.Fatalf("expected the events to resume at 3 but received %d", e.Seq)
+	}
+	if after := []uint64{<-fake.after, <-fake.after}; after[0] != 0 || after[1] != 2 {
+		t.Fatalf("expected subscriptions after 0 and 2 but received %v", after)
+	}
+	if s := <-states; s != Connected {
+		t.Fatalf("expected %s but received %s", Connected, s)
+	}
+}
+
+func TestCreateAndWait(t *testing.T) {
+	c, _, cleanup := newTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	ct, err := c.Create(ctx, "d", "/bundle", CreateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ct.ID != "d" || ct.Bundle != "/bundle" || len(ct.Processes) != 1 || ct.Processes[0].ID != InitProcess {
+		t.Fatalf("unexpected container %+v", ct)
+	}
+	if _, err := c.Create(ctx, "c", "/bundle", CreateOpts{}); err != ErrAlreadyExists {
+		t.Fatalf("expected ErrAlreadyExists but received %v", err)
+	}
+	status, err := c.Wait(ctx, "c", InitProcess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != 3 {
+		t.Fatalf("expected status 3 but received %d", status)
+	}
+	if _, err := c.Wait(ctx, "d", InitProcess); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound but received %v", err)
+	}
+	if err := c.Kill(ctx, "c", 9); grpc.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the error of the daemon with its code but received %v", err)
+	}
+}
+
+func TestAttach(t *testing.T) {
+	c, fake, cleanup := newTestClient(t)
+	defer cleanup()
+	a, err := c.Attach(context.Background(), "c", InitProcess, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(a, "in"); err != nil {
+		t.Fatal(err)
+	}
+	if in := <-fake.stdin; string(in) != "in" {
+		t.Fatalf("expected stdin in but received %q", in)
+	}
+	if err := a.CloseStdin(); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if err := a.Copy(&stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "out" || stderr.String() != "err" {
+		t.Fatalf("expected out and err but received %q and %q", stdout.String(), stderr.String())
+	}
+
+	a, err = c.Attach(context.Background(), "d", InitProcess, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Copy(ioutil.Discard, ioutil.Discard); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound but received %v", err)
+	}
+}
+
+func TestContainersPages(t *testing.T) {
+	c, _, cleanup := newTestClient(t)
+	defer cleanup()
+	containers, err := c.Containers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, ct := range containers {
+		ids = append(ids, ct.ID)
+	}
+	if strings.Join(ids, ",") != "a,b,c" {
+		t.Fatalf("expected the containers of every page but received %v", ids)
+	}
+}
+
+func TestUploadBundle(t *testing.T) {
+	c, _, cleanup := newTestClient(t)
+	defer cleanup()
+	data := bytes.Repeat([]byte("a"), 3*bundleChunkSize+1)
+	id, err := c.UploadBundle(context.Background(), bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != strconv.Itoa(len(data)) {
+		t.Fatalf("expected the daemon to receive %d bytes but it received %s", len(data), id)
+	}
+}
+
+func TestIntercept(t *testing.T) {
+	c, _, cleanup := newTestClient(t)
+	defer cleanup()
+	var methods []string
+	c.Intercept([]UnaryClientInterceptor{
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker UnaryInvoker, opts ...grpc.CallOption) error {
+			methods = append(methods, method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		},
+	}, []StreamClientInterceptor{
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			methods = append(methods, method)
+			return streamer(ctx, desc, cc, method, opts...)
+		},
+	})
+	ctx := context.Background()
+	if _, err := c.Wait(ctx, "c", InitProcess); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Containers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	events, err := c.API().Events(ctx, &types.EventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, err := events.Recv(); err != nil || e.Type != "exit" {
+		t.Fatalf("expected an exit event but received %v %v", e, err)
+	}
+	expected := "/types.API/Wait,/types.API/State,/types.API/State,/types.API/State,/types.API/Events"
+	if strings.Join(methods, ",") != expected {
+		t.Fatalf("expected the calls %s but received %v", expected, methods)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if e := translate(grpc.Errorf(codes.NotFound, "%s", supervisor.ErrContainerNotFound)); e != ErrNotFound {
+		t.Errorf("expected ErrNotFound but received %v", e)
+	}
+	if e := translate(grpc.Errorf(codes.AlreadyExists, "%s", supervisor.ErrContainerExists)); e != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists but received %v", e)
+	}
+	if e := translate(grpc.Errorf(codes.Unknown, "%s", runtime.ErrContainerNotStarted)); e.Error() != runtime.ErrContainerNotStarted.Error() {
+		t.Errorf("expected the description of the error but received %v", e)
+	}
+	if e := translate(grpc.Errorf(codes.InvalidArgument, "invalid signal")); grpc.Code(e) != codes.InvalidArgument {
+		t.Errorf("expected the InvalidArgument error but received %v", e)
+	}
+	if e := translate(io.EOF); e != io.EOF {
+		t.Errorf("expected io.EOF but received %v", e)
+	}
+}
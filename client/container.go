@@ -0,0 +1,484 @@
+package client
+
+import (
+	"syscall"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// InitProcess is the id of the process started from a container's bundle
+const InitProcess = "init"
+
+// pageSize is the number of containers requested at once by Containers
+const pageSize = 100
+
+// Container is the state of a container
+type Container struct {
+	ID        string
+	Bundle    string
+	Status    string
+	Runtime   string
+	Labels    []string
+	Processes []Process
+	// Addresses are the addresses of the container's network namespace in
+	// CIDR notation, read when the container was last started
+	Addresses []string
+}
+
+// Process is a process running in a container
+type Process struct {
+	ID        string
+	Args      []string
+	Env       []string
+	Cwd       string
+	Terminal  bool
+	SystemPid uint32
+	// StartedAt is the zero time when the daemon did not know when the
+	// process was started
+	StartedAt time.Time
+}
+
+// CreateOpts are the optional settings of a created container
+type CreateOpts struct {
+	// Checkpoint restores the container from the checkpoint with this name
+	Checkpoint string
+	// Stdin, Stdout and Stderr are the paths of the fifos of the init process
+	Stdin  string
+	Stdout string
+	Stderr string
+	// StdinOnce closes the init process's stdin when the first attached
+	// client detaches
+	StdinOnce bool
+	// StdioSocket serves the stdio of the init process on a socket instead
+	// of fifos
+	StdioSocket bool
+	Labels      []string
+	// Group is the group whose namespaces the container joins
+	Group           string
+	CgroupNamespace bool
+	GPUs            []string
+	// NetworkNamespace is the named network namespace the container joins
+	NetworkNamespace string
+	// BundleID is a bundle sent with UploadBundle, it is used instead of
+	// the bundle path
+	BundleID string
+	// Keep keeps the container after its init process exits until it is
+	// deleted with Delete
+	Keep bool
+	// AutoRemove removes the bundle with the container when its init
+	// process exits, unless another container uses the bundle
+	AutoRemove bool
+	// NetworkWait delays the start of the user process until the
+	// container's network namespace has an address, for at most this long.
+	// It is rounded down to seconds.
+	NetworkWait time.Duration
+	// Init runs the container's process under a minimal init that reaps
+	// zombies and forwards signals to it
+	Init bool
+	// Template is the name of a template the container is created from, it
+	// is used instead of the bundle path
+	Template string
+	// TemplateEnv are KEY=VALUE variables set in the template's spec
+	TemplateEnv []string
+	// TemplateMounts are added to the template's spec
+	TemplateMounts []Mount
+	// MaxRuntime stops the container with MaxRuntimeSignal, SIGTERM by
+	// default, once it ran for this long.  It is rounded down to seconds.
+	MaxRuntime       time.Duration
+	MaxRuntimeSignal syscall.Signal
+	// OOMRestart restarts the container when its init process was killed
+	// after the container ran out of memory
+	OOMRestart *OOMRestartPolicy
+	// Interfaces are the link properties set on the interfaces of the
+	// container's network namespace once it started
+	Interfaces []InterfaceConfig
+	// VF allocates an SR-IOV virtual function to the container
+	VF *VirtualFunction
+	// Addresses are added to the interfaces of the container's network
+	// namespace once they are configured
+	Addresses []AddressConfig
+	// Routes are added to the container's network namespace once the
+	// interfaces are configured
+	Routes []Route
+	// RoutedInterface is the container's side of a veth pair whose addresses
+	// are routed by the host and proxied on the daemon's uplink
+	RoutedInterface string
+}
+
+// AddressConfig is added to an interface of a container's network namespace
+type AddressConfig struct {
+	Interface string
+	// Network is the daemon's ipam network the address is allocated from,
+	// Address is used when it is empty
+	Network string
+	// Address is in CIDR notation
+	Address string
+}
+
+// Route is added to a container's network namespace, it replaces a route to
+// the same destination
+type Route struct {
+	// Destination is a network in CIDR notation or default
+	Destination string
+	Gateway     string
+	Interface   string
+	Metric      uint32
+}
+
+// VirtualFunction is an SR-IOV virtual function moved into a container's
+// network namespace
+type VirtualFunction struct {
+	// PF is the physical function the virtual function is allocated from,
+	// any of the daemon's when empty
+	PF string
+	// Name is the name of the interface in the container, the interface
+	// keeps its name when empty
+	Name string
+	MAC  string
+	VLAN uint32
+}
+
+// InterfaceConfig sets the link properties of an interface in a container's
+// network namespace, the zero values leave a property unchanged
+type InterfaceConfig struct {
+	// Name is the name of the interface in the container, e.g. eth0
+	Name       string
+	MTU        uint32
+	TxQueueLen uint32
+	MAC        string
+}
+
+// OOMRestartPolicy restarts a container killed after it ran out of memory
+type OOMRestartPolicy struct {
+	// MaxRestarts is the number of restarts, 0 restarts the container
+	// every time
+	MaxRestarts uint32
+	// MemoryIncrement is added to the memory limit of the bundle's spec
+	// before each restart, the limit is not raised above MemoryLimitCap
+	// unless it is 0
+	MemoryIncrement uint64
+	MemoryLimitCap  uint64
+}
+
+// Mount is a host path bind mounted into a container
+type Mount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// SpecUpdate changes the mounts and the environment of a stopped container
+type SpecUpdate struct {
+	// AddMounts replace the mounts of the spec at the same destination
+	AddMounts []Mount
+	// RemoveMounts are the destinations of the mounts that are removed
+	RemoveMounts []string
+	// SetEnv are KEY=VALUE variables, a variable with the same key is
+	// replaced
+	SetEnv []string
+	// UnsetEnv are the keys of the variables that are removed
+	UnsetEnv []string
+}
+
+// ProcessSpec is the process added to a running container
+type ProcessSpec struct {
+	Args     []string
+	Env      []string
+	Cwd      string
+	Terminal bool
+	UID      uint32
+	GID      uint32
+	// Stdin, Stdout and Stderr are the paths of the fifos of the process
+	Stdin  string
+	Stdout string
+	Stderr string
+}
+
+// Create creates and starts the container id from its OCI bundle, bundle is
+// empty for a container created from an uploaded bundle
+func (c *Client) Create(ctx context.Context, id, bundle string, opts CreateOpts) (*Container, error) {
+	r := &types.CreateContainerRequest{
+		Id:               id,
+		BundlePath:       bundle,
+		Checkpoint:       opts.Checkpoint,
+		Stdin:            opts.Stdin,
+		Stdout:           opts.Stdout,
+		Stderr:           opts.Stderr,
+		StdinOnce:        opts.StdinOnce,
+		StdioSocket:      opts.StdioSocket,
+		Labels:           opts.Labels,
+		Group:            opts.Group,
+		NetworkNamespace: opts.NetworkNamespace,
+		CgroupNamespace:  opts.CgroupNamespace,
+		Gpus:             opts.GPUs,
+		BundleId:         opts.BundleID,
+		Keep:             opts.Keep,
+		AutoRemove:       opts.AutoRemove,
+		NetworkWait:      uint32(opts.NetworkWait / time.Second),
+		Init:             opts.Init,
+		Template:         opts.Template,
+		TemplateEnv:      opts.TemplateEnv,
+		MaxRuntime:       uint32(opts.MaxRuntime / time.Second),
+		MaxRuntimeSignal: uint32(opts.MaxRuntimeSignal),
+	}
+	if p := opts.OOMRestart; p != nil {
+		r.OomRestart = &types.OOMRestartPolicy{
+			MaxRestarts:     p.MaxRestarts,
+			MemoryIncrement: p.MemoryIncrement,
+			MemoryLimitCap:  p.MemoryLimitCap,
+		}
+	}
+	if len(opts.Interfaces) > 0 || opts.VF != nil || len(opts.Addresses) > 0 || len(opts.Routes) > 0 || opts.RoutedInterface != "" {
+		r.Network = &types.NetworkConfig{
+			RoutedInterface: opts.RoutedInterface,
+		}
+		for _, i := range opts.Interfaces {
+			r.Network.Interfaces = append(r.Network.Interfaces, &types.InterfaceConfig{
+				Name:       i.Name,
+				Mtu:        i.MTU,
+				TxQueueLen: i.TxQueueLen,
+				Mac:        i.MAC,
+			})
+		}
+		for _, a := range opts.Addresses {
+			r.Network.Addresses = append(r.Network.Addresses, &types.AddressConfig{
+				Interface: a.Interface,
+				Network:   a.Network,
+				Address:   a.Address,
+			})
+		}
+		for _, rt := range opts.Routes {
+			r.Network.Routes = append(r.Network.Routes, &types.Route{
+				Destination: rt.Destination,
+				Gateway:     rt.Gateway,
+				Interface:   rt.Interface,
+				Metric:      rt.Metric,
+			})
+		}
+		if vf := opts.VF; vf != nil {
+			r.Network.Vf = &types.VirtualFunction{
+				Pf:   vf.PF,
+				Name: vf.Name,
+				Mac:  vf.MAC,
+				Vlan: vf.VLAN,
+			}
+		}
+	}
+	for _, m := range opts.TemplateMounts {
+		r.TemplateMounts = append(r.TemplateMounts, &types.BindMount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	resp, err := c.API().CreateContainer(ctx, r)
+	if err != nil {
+		return nil, translate(err)
+	}
+	return newContainer(resp.Container), nil
+}
+
+// Exec starts the process pid in the running container id
+func (c *Client) Exec(ctx context.Context, id, pid string, p ProcessSpec) error {
+	_, err := c.API().AddProcess(ctx, &types.AddProcessRequest{
+		Id:       id,
+		Pid:      pid,
+		Args:     p.Args,
+		Env:      p.Env,
+		Cwd:      p.Cwd,
+		Terminal: p.Terminal,
+		User: &types.User{
+			Uid: p.UID,
+			Gid: p.GID,
+		},
+		Stdin:  p.Stdin,
+		Stdout: p.Stdout,
+		Stderr: p.Stderr,
+	})
+	return translate(err)
+}
+
+// Containers returns all the containers of the daemon sorted by id, they are
+// requested in pages of pageSize containers
+func (c *Client) Containers(ctx context.Context) ([]*Container, error) {
+	var (
+		containers []*Container
+		token      string
+	)
+	for {
+		resp, err := c.API().State(ctx, &types.StateRequest{
+			Limit:     pageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			return nil, translate(err)
+		}
+		for _, ct := range resp.Containers {
+			containers = append(containers, newContainer(ct))
+		}
+		if token = resp.NextPageToken; token == "" {
+			return containers, nil
+		}
+	}
+}
+
+// Container returns the container id
+func (c *Client) Container(ctx context.Context, id string) (*Container, error) {
+	resp, err := c.API().State(ctx, &types.StateRequest{Id: id})
+	if err != nil {
+		return nil, translate(err)
+	}
+	if len(resp.Containers) == 0 {
+		return nil, ErrNotFound
+	}
+	return newContainer(resp.Containers[0]), nil
+}
+
+// Signal sends sig to the process pid of the container id
+func (c *Client) Signal(ctx context.Context, id, pid string, sig syscall.Signal) error {
+	_, err := c.API().Signal(ctx, &types.SignalRequest{
+		Id:     id,
+		Pid:    pid,
+		Signal: uint32(sig),
+	})
+	return translate(err)
+}
+
+// Kill sends sig to the init process of the container id
+func (c *Client) Kill(ctx context.Context, id string, sig syscall.Signal) error {
+	return c.Signal(ctx, id, InitProcess, sig)
+}
+
+// Stop sends sig to the init process of the container id and kills every
+// process of the container if it did not exit within the timeout.  It
+// returns the exit status of the init process and whether the container was
+// killed.  A timeout of 0 waits for the daemon's default of 10 seconds.
+func (c *Client) Stop(ctx context.Context, id string, sig syscall.Signal, timeout time.Duration) (uint32, bool, error) {
+	resp, err := c.API().StopContainer(ctx, &types.StopContainerRequest{
+		Id:      id,
+		Signal:  uint32(sig),
+		Timeout: int64(timeout / time.Second),
+	})
+	if err != nil {
+		return 0, false, translate(err)
+	}
+	return resp.Status, resp.Forced, nil
+}
+
+// Restart stops the container id as Stop does and starts it again with the
+// same bundle, labels, checkpoints and log file.  A kept container that is
+// stopped is started right away.
+func (c *Client) Restart(ctx context.Context, id string, sig syscall.Signal, timeout time.Duration) error {
+	_, err := c.API().RestartContainer(ctx, &types.RestartContainerRequest{
+		Id:      id,
+		Signal:  uint32(sig),
+		Timeout: int64(timeout / time.Second),
+	})
+	return translate(err)
+}
+
+// Processes returns the exec processes of the container id sorted by id
+func (c *Client) Processes(ctx context.Context, id string) ([]Process, error) {
+	resp, err := c.API().ListProcesses(ctx, &types.ListProcessesRequest{Id: id})
+	if err != nil {
+		return nil, translate(err)
+	}
+	var processes []Process
+	for _, p := range resp.Processes {
+		processes = append(processes, newProcess(p))
+	}
+	return processes, nil
+}
+
+// DeleteProcess kills the exec process pid of the container id and removes
+// it without waiting for its shim, it returns the exit status of the process
+func (c *Client) DeleteProcess(ctx context.Context, id, pid string) (uint32, error) {
+	resp, err := c.API().DeleteProcess(ctx, &types.DeleteProcessRequest{
+		Id:  id,
+		Pid: pid,
+	})
+	if err != nil {
+		return 0, translate(err)
+	}
+	return resp.Status, nil
+}
+
+// Wait blocks until the process pid of the container id exits, or until ctx
+// is done, and returns its exit status
+func (c *Client) Wait(ctx context.Context, id, pid string) (uint32, error) {
+	resp, err := c.API().Wait(ctx, &types.WaitRequest{
+		Id:  id,
+		Pid: pid,
+	})
+	if err != nil {
+		return 0, translate(err)
+	}
+	return resp.Status, nil
+}
+
+// CloseStdin closes the stdin of the process pid of the container id
+func (c *Client) CloseStdin(ctx context.Context, id, pid string) error {
+	_, err := c.API().CloseStdin(ctx, &types.CloseStdinRequest{
+		Id:  id,
+		Pid: pid,
+	})
+	return translate(err)
+}
+
+// UpdateSpec changes the bundle's spec of the stopped container id, the
+// container must have been created with Keep
+func (c *Client) UpdateSpec(ctx context.Context, id string, u SpecUpdate) error {
+	r := &types.UpdateContainerSpecRequest{
+		Id:           id,
+		RemoveMounts: u.RemoveMounts,
+		SetEnv:       u.SetEnv,
+		UnsetEnv:     u.UnsetEnv,
+	}
+	for _, m := range u.AddMounts {
+		r.AddMounts = append(r.AddMounts, &types.BindMount{
+			Source:      m.Source,
+			Destination: m.Destination,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	_, err := c.API().UpdateContainerSpec(ctx, r)
+	return translate(err)
+}
+
+// Delete deletes the stopped container id that was created with Keep
+func (c *Client) Delete(ctx context.Context, id string) error {
+	_, err := c.API().DeleteContainer(ctx, &types.DeleteContainerRequest{Id: id})
+	return translate(err)
+}
+
+func newContainer(c *types.Container) *Container {
+	ct := &Container{
+		ID:        c.Id,
+		Bundle:    c.BundlePath,
+		Status:    c.Status,
+		Runtime:   c.Runtime,
+		Labels:    c.Labels,
+		Addresses: c.Addresses,
+	}
+	for _, p := range c.Processes {
+		ct.Processes = append(ct.Processes, newProcess(p))
+	}
+	return ct
+}
+
+func newProcess(p *types.Process) Process {
+	proc := Process{
+		ID:        p.Pid,
+		Args:      p.Args,
+		Env:       p.Env,
+		Cwd:       p.Cwd,
+		Terminal:  p.Terminal,
+		SystemPid: p.SystemPid,
+	}
+	if p.StartedAt != 0 {
+		proc.StartedAt = time.Unix(0, int64(p.StartedAt))
+	}
+	return proc
+}
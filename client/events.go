@@ -0,0 +1,119 @@
+package client
+
+import (
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// Event is an event of a container or one of its processes
+type Event struct {
+	// Seq is the sequence number of the event, it is 0 for the live event
+	// marking the end of the replayed events
+	Seq       uint64
+	Type      string
+	ID        string
+	PID       string
+	Status    uint32
+	Timestamp time.Time
+	// Level is the memory pressure level of memory-pressure events
+	Level string
+	// Reason is why the container of a corrupt event could not be restored
+	Reason string
+	// Addresses are the addresses of the container's network namespace in
+	// start-container and restart events
+	Addresses []string
+}
+
+// Events is a stream of events that survives the reconnects of its client,
+// the stream is resubscribed after the last event received so that no event
+// kept by the daemon is missed or received twice
+type Events struct {
+	c      *Client
+	ctx    context.Context
+	stream types.API_EventsClient
+	// last is the sequence number of the last event received
+	last uint64
+}
+
+// Events subscribes to the events with a sequence number greater than
+// after, 0 subscribes to the live events only.  Cancel ctx to unsubscribe.
+func (c *Client) Events(ctx context.Context, after uint64) (*Events, error) {
+	e := &Events{
+		c:    c,
+		ctx:  ctx,
+		last: after,
+	}
+	if err := e.subscribe(); err != nil {
+		return nil, translate(err)
+	}
+	return e, nil
+}
+
+func (e *Events) subscribe() error {
+	stream, err := e.c.API().Events(e.ctx, &types.EventsRequest{
+		AfterSeq:       e.last,
+		OverflowPolicy: "disconnect",
+	})
+	if err != nil {
+		return err
+	}
+	e.stream = stream
+	return nil
+}
+
+// Recv returns the next event, it blocks while the client reconnects and
+// returns an error once ctx is done or the client is closed
+func (e *Events) Recv() (*Event, error) {
+	delay := e.c.Backoff.Initial
+	for {
+		if e.stream == nil {
+			if err := e.subscribe(); err != nil {
+				if !disconnected(err) {
+					return nil, translate(err)
+				}
+				if err := e.wait(delay); err != nil {
+					return nil, err
+				}
+				delay = e.c.Backoff.next(delay)
+				continue
+			}
+		}
+		ev, err := e.stream.Recv()
+		if err != nil {
+			if !disconnected(err) || e.ctx.Err() != nil {
+				return nil, translate(err)
+			}
+			e.stream = nil
+			continue
+		}
+		delay = e.c.Backoff.Initial
+		if ev.Seq > e.last {
+			e.last = ev.Seq
+		}
+		return &Event{
+			Seq:       ev.Seq,
+			Type:      ev.Type,
+			ID:        ev.Id,
+			PID:       ev.Pid,
+			Status:    ev.Status,
+			Timestamp: time.Unix(int64(ev.Timestamp), 0),
+			Level:     ev.Level,
+			Reason:    ev.Reason,
+			Addresses: ev.Addresses,
+		}, nil
+	}
+}
+
+// wait waits for delay before the next subscription
+func (e *Events) wait(delay time.Duration) error {
+	select {
+	case <-e.ctx.Done():
+		return e.ctx.Err()
+	case <-e.c.done:
+		return ErrClosed
+	case <-time.After(delay):
+		return nil
+	}
+}
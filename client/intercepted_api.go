@@ -0,0 +1,578 @@
+package client
+
+import (
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// interceptedAPI is the generated client with its calls going through the
+// interceptors of the client
+type interceptedAPI struct {
+	cc      *grpc.ClientConn
+	unary   []UnaryClientInterceptor
+	streams []StreamClientInterceptor
+}
+
+func (c *interceptedAPI) CreateContainer(ctx context.Context, in *types.CreateContainerRequest, opts ...grpc.CallOption) (*types.CreateContainerResponse, error) {
+	out := new(types.CreateContainerResponse)
+	if err := c.invoke(ctx, "CreateContainer", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) UpdateContainer(ctx context.Context, in *types.UpdateContainerRequest, opts ...grpc.CallOption) (*types.UpdateContainerResponse, error) {
+	out := new(types.UpdateContainerResponse)
+	if err := c.invoke(ctx, "UpdateContainer", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Signal(ctx context.Context, in *types.SignalRequest, opts ...grpc.CallOption) (*types.SignalResponse, error) {
+	out := new(types.SignalResponse)
+	if err := c.invoke(ctx, "Signal", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) UpdateProcess(ctx context.Context, in *types.UpdateProcessRequest, opts ...grpc.CallOption) (*types.UpdateProcessResponse, error) {
+	out := new(types.UpdateProcessResponse)
+	if err := c.invoke(ctx, "UpdateProcess", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) AddProcess(ctx context.Context, in *types.AddProcessRequest, opts ...grpc.CallOption) (*types.AddProcessResponse, error) {
+	out := new(types.AddProcessResponse)
+	if err := c.invoke(ctx, "AddProcess", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) CreateCheckpoint(ctx context.Context, in *types.CreateCheckpointRequest, opts ...grpc.CallOption) (*types.CreateCheckpointResponse, error) {
+	out := new(types.CreateCheckpointResponse)
+	if err := c.invoke(ctx, "CreateCheckpoint", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteCheckpoint(ctx context.Context, in *types.DeleteCheckpointRequest, opts ...grpc.CallOption) (*types.DeleteCheckpointResponse, error) {
+	out := new(types.DeleteCheckpointResponse)
+	if err := c.invoke(ctx, "DeleteCheckpoint", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListCheckpoint(ctx context.Context, in *types.ListCheckpointRequest, opts ...grpc.CallOption) (*types.ListCheckpointResponse, error) {
+	out := new(types.ListCheckpointResponse)
+	if err := c.invoke(ctx, "ListCheckpoint", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) State(ctx context.Context, in *types.StateRequest, opts ...grpc.CallOption) (*types.StateResponse, error) {
+	out := new(types.StateResponse)
+	if err := c.invoke(ctx, "State", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Events(ctx context.Context, in *types.EventsRequest, opts ...grpc.CallOption) (types.API_EventsClient, error) {
+	stream, err := c.newStream(ctx, "Events", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &eventsClient{stream}, nil
+}
+
+func (c *interceptedAPI) Stats(ctx context.Context, in *types.StatsRequest, opts ...grpc.CallOption) (*types.StatsResponse, error) {
+	out := new(types.StatsResponse)
+	if err := c.invoke(ctx, "Stats", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) StatsStream(ctx context.Context, in *types.StatsRequest, opts ...grpc.CallOption) (types.API_StatsStreamClient, error) {
+	stream, err := c.newStream(ctx, "StatsStream", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &statsStreamClient{stream}, nil
+}
+
+func (c *interceptedAPI) CopyFromContainer(ctx context.Context, in *types.CopyFromContainerRequest, opts ...grpc.CallOption) (types.API_CopyFromContainerClient, error) {
+	stream, err := c.newStream(ctx, "CopyFromContainer", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &copyFromContainerClient{stream}, nil
+}
+
+func (c *interceptedAPI) CopyToContainer(ctx context.Context, opts ...grpc.CallOption) (types.API_CopyToContainerClient, error) {
+	stream, err := c.newStream(ctx, "CopyToContainer", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &copyToContainerClient{stream}, nil
+}
+
+func (c *interceptedAPI) Wait(ctx context.Context, in *types.WaitRequest, opts ...grpc.CallOption) (*types.WaitResponse, error) {
+	out := new(types.WaitResponse)
+	if err := c.invoke(ctx, "Wait", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Attach(ctx context.Context, opts ...grpc.CallOption) (types.API_AttachClient, error) {
+	stream, err := c.newStream(ctx, "Attach", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &attachClient{stream}, nil
+}
+
+func (c *interceptedAPI) GetLogs(ctx context.Context, in *types.GetLogsRequest, opts ...grpc.CallOption) (types.API_GetLogsClient, error) {
+	stream, err := c.newStream(ctx, "GetLogs", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &getLogsClient{stream}, nil
+}
+
+func (c *interceptedAPI) CloseStdin(ctx context.Context, in *types.CloseStdinRequest, opts ...grpc.CallOption) (*types.CloseStdinResponse, error) {
+	out := new(types.CloseStdinResponse)
+	if err := c.invoke(ctx, "CloseStdin", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) UpdateDevice(ctx context.Context, in *types.UpdateDeviceRequest, opts ...grpc.CallOption) (*types.UpdateDeviceResponse, error) {
+	out := new(types.UpdateDeviceResponse)
+	if err := c.invoke(ctx, "UpdateDevice", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) FreezeContainers(ctx context.Context, in *types.FreezeContainersRequest, opts ...grpc.CallOption) (*types.FreezeContainersResponse, error) {
+	out := new(types.FreezeContainersResponse)
+	if err := c.invoke(ctx, "FreezeContainers", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) CreateGroup(ctx context.Context, in *types.CreateGroupRequest, opts ...grpc.CallOption) (*types.CreateGroupResponse, error) {
+	out := new(types.CreateGroupResponse)
+	if err := c.invoke(ctx, "CreateGroup", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteGroup(ctx context.Context, in *types.DeleteGroupRequest, opts ...grpc.CallOption) (*types.DeleteGroupResponse, error) {
+	out := new(types.DeleteGroupResponse)
+	if err := c.invoke(ctx, "DeleteGroup", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListGroups(ctx context.Context, in *types.ListGroupsRequest, opts ...grpc.CallOption) (*types.ListGroupsResponse, error) {
+	out := new(types.ListGroupsResponse)
+	if err := c.invoke(ctx, "ListGroups", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteVolume(ctx context.Context, in *types.DeleteVolumeRequest, opts ...grpc.CallOption) (*types.DeleteVolumeResponse, error) {
+	out := new(types.DeleteVolumeResponse)
+	if err := c.invoke(ctx, "DeleteVolume", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DumpState(ctx context.Context, in *types.DumpStateRequest, opts ...grpc.CallOption) (*types.DumpStateResponse, error) {
+	out := new(types.DumpStateResponse)
+	if err := c.invoke(ctx, "DumpState", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Healthz(ctx context.Context, in *types.HealthzRequest, opts ...grpc.CallOption) (*types.HealthzResponse, error) {
+	out := new(types.HealthzResponse)
+	if err := c.invoke(ctx, "Healthz", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Capabilities(ctx context.Context, in *types.CapabilitiesRequest, opts ...grpc.CallOption) (*types.CapabilitiesResponse, error) {
+	out := new(types.CapabilitiesResponse)
+	if err := c.invoke(ctx, "Capabilities", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) UploadBundle(ctx context.Context, opts ...grpc.CallOption) (types.API_UploadBundleClient, error) {
+	stream, err := c.newStream(ctx, "UploadBundle", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &uploadBundleClient{stream}, nil
+}
+
+func (c *interceptedAPI) UpdateContainerSpec(ctx context.Context, in *types.UpdateContainerSpecRequest, opts ...grpc.CallOption) (*types.UpdateContainerSpecResponse, error) {
+	out := new(types.UpdateContainerSpecResponse)
+	if err := c.invoke(ctx, "UpdateContainerSpec", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteContainer(ctx context.Context, in *types.DeleteContainerRequest, opts ...grpc.CallOption) (*types.DeleteContainerResponse, error) {
+	out := new(types.DeleteContainerResponse)
+	if err := c.invoke(ctx, "DeleteContainer", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) StopContainer(ctx context.Context, in *types.StopContainerRequest, opts ...grpc.CallOption) (*types.StopContainerResponse, error) {
+	out := new(types.StopContainerResponse)
+	if err := c.invoke(ctx, "StopContainer", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListProcesses(ctx context.Context, in *types.ListProcessesRequest, opts ...grpc.CallOption) (*types.ListProcessesResponse, error) {
+	out := new(types.ListProcessesResponse)
+	if err := c.invoke(ctx, "ListProcesses", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteProcess(ctx context.Context, in *types.DeleteProcessRequest, opts ...grpc.CallOption) (*types.DeleteProcessResponse, error) {
+	out := new(types.DeleteProcessResponse)
+	if err := c.invoke(ctx, "DeleteProcess", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) RestartContainer(ctx context.Context, in *types.RestartContainerRequest, opts ...grpc.CallOption) (*types.RestartContainerResponse, error) {
+	out := new(types.RestartContainerResponse)
+	if err := c.invoke(ctx, "RestartContainer", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) CreateTemplate(ctx context.Context, in *types.CreateTemplateRequest, opts ...grpc.CallOption) (*types.CreateTemplateResponse, error) {
+	out := new(types.CreateTemplateResponse)
+	if err := c.invoke(ctx, "CreateTemplate", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListTemplates(ctx context.Context, in *types.ListTemplatesRequest, opts ...grpc.CallOption) (*types.ListTemplatesResponse, error) {
+	out := new(types.ListTemplatesResponse)
+	if err := c.invoke(ctx, "ListTemplates", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteTemplate(ctx context.Context, in *types.DeleteTemplateRequest, opts ...grpc.CallOption) (*types.DeleteTemplateResponse, error) {
+	out := new(types.DeleteTemplateResponse)
+	if err := c.invoke(ctx, "DeleteTemplate", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) Backup(ctx context.Context, in *types.BackupRequest, opts ...grpc.CallOption) (types.API_BackupClient, error) {
+	stream, err := c.newStream(ctx, "Backup", opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &backupClient{stream}, nil
+}
+
+func (c *interceptedAPI) RestoreBackup(ctx context.Context, opts ...grpc.CallOption) (types.API_RestoreBackupClient, error) {
+	stream, err := c.newStream(ctx, "RestoreBackup", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &restoreBackupClient{stream}, nil
+}
+
+func (c *interceptedAPI) CheckConsistency(ctx context.Context, in *types.CheckConsistencyRequest, opts ...grpc.CallOption) (*types.CheckConsistencyResponse, error) {
+	out := new(types.CheckConsistencyResponse)
+	if err := c.invoke(ctx, "CheckConsistency", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) CreateLease(ctx context.Context, in *types.CreateLeaseRequest, opts ...grpc.CallOption) (*types.Lease, error) {
+	out := new(types.Lease)
+	if err := c.invoke(ctx, "CreateLease", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) RenewLease(ctx context.Context, in *types.RenewLeaseRequest, opts ...grpc.CallOption) (*types.Lease, error) {
+	out := new(types.Lease)
+	if err := c.invoke(ctx, "RenewLease", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteLease(ctx context.Context, in *types.DeleteLeaseRequest, opts ...grpc.CallOption) (*types.DeleteLeaseResponse, error) {
+	out := new(types.DeleteLeaseResponse)
+	if err := c.invoke(ctx, "DeleteLease", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListLeases(ctx context.Context, in *types.ListLeasesRequest, opts ...grpc.CallOption) (*types.ListLeasesResponse, error) {
+	out := new(types.ListLeasesResponse)
+	if err := c.invoke(ctx, "ListLeases", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) AddAddress(ctx context.Context, in *types.AddAddressRequest, opts ...grpc.CallOption) (*types.AddAddressResponse, error) {
+	out := new(types.AddAddressResponse)
+	if err := c.invoke(ctx, "AddAddress", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteAddresses(ctx context.Context, in *types.DeleteAddressesRequest, opts ...grpc.CallOption) (*types.DeleteAddressesResponse, error) {
+	out := new(types.DeleteAddressesResponse)
+	if err := c.invoke(ctx, "DeleteAddresses", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListAddresses(ctx context.Context, in *types.ListAddressesRequest, opts ...grpc.CallOption) (*types.ListAddressesResponse, error) {
+	out := new(types.ListAddressesResponse)
+	if err := c.invoke(ctx, "ListAddresses", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) CreateNetworkNamespace(ctx context.Context, in *types.CreateNetworkNamespaceRequest, opts ...grpc.CallOption) (*types.CreateNetworkNamespaceResponse, error) {
+	out := new(types.CreateNetworkNamespaceResponse)
+	if err := c.invoke(ctx, "CreateNetworkNamespace", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) DeleteNetworkNamespace(ctx context.Context, in *types.DeleteNetworkNamespaceRequest, opts ...grpc.CallOption) (*types.DeleteNetworkNamespaceResponse, error) {
+	out := new(types.DeleteNetworkNamespaceResponse)
+	if err := c.invoke(ctx, "DeleteNetworkNamespace", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interceptedAPI) ListNetworkNamespaces(ctx context.Context, in *types.ListNetworkNamespacesRequest, opts ...grpc.CallOption) (*types.ListNetworkNamespacesResponse, error) {
+	out := new(types.ListNetworkNamespacesResponse)
+	if err := c.invoke(ctx, "ListNetworkNamespaces", in, out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type eventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventsClient) Recv() (*types.Event, error) {
+	m := new(types.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type statsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *statsStreamClient) Recv() (*types.StatsResponse, error) {
+	m := new(types.StatsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type copyFromContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *copyFromContainerClient) Recv() (*types.CopyChunk, error) {
+	m := new(types.CopyChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type copyToContainerClient struct {
+	grpc.ClientStream
+}
+
+func (x *copyToContainerClient) Send(m *types.CopyToContainerRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *copyToContainerClient) CloseAndRecv() (*types.CopyToContainerResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(types.CopyToContainerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type attachClient struct {
+	grpc.ClientStream
+}
+
+func (x *attachClient) Send(m *types.AttachRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *attachClient) Recv() (*types.AttachResponse, error) {
+	m := new(types.AttachResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type getLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *getLogsClient) Recv() (*types.LogEntry, error) {
+	m := new(types.LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type uploadBundleClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadBundleClient) Send(m *types.UploadBundleRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *uploadBundleClient) CloseAndRecv() (*types.UploadBundleResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(types.UploadBundleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type backupClient struct {
+	grpc.ClientStream
+}
+
+func (x *backupClient) Recv() (*types.BackupChunk, error) {
+	m := new(types.BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type restoreBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *restoreBackupClient) Send(m *types.RestoreBackupRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *restoreBackupClient) CloseAndRecv() (*types.RestoreBackupResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(types.RestoreBackupResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
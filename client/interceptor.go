@@ -0,0 +1,82 @@
+package client
+
+import (
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// The vendored grpc has no interceptors, these follow the ones of later grpc
+// releases so that interceptors written for them can be used with little
+// change.
+
+// UnaryInvoker calls the next interceptor or the rpc
+type UnaryInvoker func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error
+
+// UnaryClientInterceptor intercepts an unary rpc called as method,
+// /types.API/<rpc>, it calls invoker to continue the call
+type UnaryClientInterceptor func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker UnaryInvoker, opts ...grpc.CallOption) error
+
+// Streamer opens the stream of the next interceptor or of the rpc
+type Streamer func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error)
+
+// StreamClientInterceptor intercepts the opening of a streaming rpc, it calls
+// streamer to open the stream and may wrap the returned stream to intercept
+// its messages
+type StreamClientInterceptor func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error)
+
+// Intercept sets the interceptors the rpcs of the client go through, the
+// interceptors are called in order.  It applies to the helpers and to the
+// client returned by API, and must be called before the client is used.
+func (c *Client) Intercept(unary []UnaryClientInterceptor, stream []StreamClientInterceptor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unary, c.streams = unary, stream
+	c.api = c.newAPI(c.conn)
+}
+
+// newAPI returns the generated client for the connection, wrapped when the
+// client has interceptors
+func (c *Client) newAPI(conn *grpc.ClientConn) types.APIClient {
+	if len(c.unary) == 0 && len(c.streams) == 0 {
+		return types.NewAPIClient(conn)
+	}
+	return &interceptedAPI{
+		cc:      conn,
+		unary:   c.unary,
+		streams: c.streams,
+	}
+}
+
+func (c *interceptedAPI) invoke(ctx context.Context, rpc string, in, out interface{}, opts []grpc.CallOption) error {
+	var next func(i int) UnaryInvoker
+	next = func(i int) UnaryInvoker {
+		if i == len(c.unary) {
+			return grpc.Invoke
+		}
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return c.unary[i](ctx, method, req, reply, cc, next(i+1), opts...)
+		}
+	}
+	return next(0)(ctx, "/types.API/"+rpc, in, out, c.cc, opts...)
+}
+
+func (c *interceptedAPI) newStream(ctx context.Context, rpc string, opts []grpc.CallOption) (grpc.ClientStream, error) {
+	var desc *grpc.StreamDesc
+	sd := types.APIServiceDesc()
+	for i := range sd.Streams {
+		if sd.Streams[i].StreamName == rpc {
+			desc = &sd.Streams[i]
+		}
+	}
+	var next func(i int) Streamer
+	next = func(i int) Streamer {
+		if i == len(c.streams) {
+			return grpc.NewClientStream
+		}
+		return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return c.streams[i](ctx, desc, cc, method, next(i+1), opts...)
+		}
+	}
+	return next(0)(ctx, desc, c.cc, "/types.API/"+rpc, opts...)
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+// LeaseKey is the key of the request metadata that carries the lease of a
+// call, the bundles uploaded by the call are added to the lease
+const LeaseKey = "containerd-lease"
+
+// WithLease returns a context whose calls add the resources they create to
+// the lease id so that they are not collected before the lease is released
+func WithLease(ctx context.Context, id string) context.Context {
+	md, _ := metadata.FromContext(ctx)
+	md = md.Copy()
+	md[LeaseKey] = []string{id}
+	return metadata.NewContext(ctx, md)
+}
+
+// Lease is a lease of the daemon that references the resources of a client
+// building a container in several steps
+type Lease struct {
+	ID      string
+	Expires time.Time
+	// Bundles are the ids of the uploaded bundles referenced by the lease
+	Bundles []string
+
+	client *Client
+	once   sync.Once
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func (c *Client) newLease(l *types.Lease) *Lease {
+	return &Lease{
+		ID:      l.Id,
+		Expires: time.Unix(0, int64(l.Expires)),
+		Bundles: l.Bundles,
+		client:  c,
+	}
+}
+
+// CreateLease creates the lease id, or a lease with a random id when id is
+// empty, that expires after ttl unless it is renewed.  The lease is renewed
+// every half ttl until it is released or ctx is done, so that it expires soon
+// after the client goes away.
+func (c *Client) CreateLease(ctx context.Context, id string, ttl time.Duration) (*Lease, error) {
+	resp, err := c.API().CreateLease(ctx, &types.CreateLeaseRequest{
+		Id:  id,
+		Ttl: uint64(ttl),
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	l := c.newLease(resp)
+	l.done = make(chan struct{})
+	if ttl/2 > 0 {
+		l.wg.Add(1)
+		go l.renew(ctx, ttl)
+	}
+	return l, nil
+}
+
+func (l *Lease) renew(ctx context.Context, ttl time.Duration) {
+	defer l.wg.Done()
+	ticker := time.NewTicker(ttl / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := l.client.RenewLease(ctx, l.ID, ttl); err == ErrNotFound {
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// Context returns ctx with the lease set with WithLease
+func (l *Lease) Context(ctx context.Context) context.Context {
+	return WithLease(ctx, l.ID)
+}
+
+// Release stops renewing the lease and deletes it, the resources it referenced
+// are collected once nothing else references them.  Leases that were not
+// created by CreateLease are only deleted.
+func (l *Lease) Release(ctx context.Context) error {
+	l.once.Do(func() {
+		if l.done != nil {
+			close(l.done)
+		}
+	})
+	l.wg.Wait()
+	return l.client.DeleteLease(ctx, l.ID)
+}
+
+// RenewLease extends the lease id to expire after ttl
+func (c *Client) RenewLease(ctx context.Context, id string, ttl time.Duration) (*Lease, error) {
+	resp, err := c.API().RenewLease(ctx, &types.RenewLeaseRequest{
+		Id:  id,
+		Ttl: uint64(ttl),
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	return c.newLease(resp), nil
+}
+
+// DeleteLease deletes the lease id
+func (c *Client) DeleteLease(ctx context.Context, id string) error {
+	_, err := c.API().DeleteLease(ctx, &types.DeleteLeaseRequest{Id: id})
+	return translate(err)
+}
+
+// Leases returns the leases that did not expire
+func (c *Client) Leases(ctx context.Context) ([]*Lease, error) {
+	resp, err := c.API().ListLeases(ctx, &types.ListLeasesRequest{})
+	if err != nil {
+		return nil, translate(err)
+	}
+	var leases []*Lease
+	for _, l := range resp.Leases {
+		leases = append(leases, c.newLease(l))
+	}
+	return leases, nil
+}
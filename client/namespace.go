@@ -0,0 +1,39 @@
+package client
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// NamespaceKey is the key of the request metadata that carries the namespace
+// of a call, calls without a namespace are made in the default namespace
+const NamespaceKey = "containerd-namespace"
+
+// WithNamespace returns a context whose calls are made in the namespace, the
+// containers of a namespace are not visible to the calls of other namespaces
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	md, _ := metadata.FromContext(ctx)
+	md = md.Copy()
+	md[NamespaceKey] = []string{namespace}
+	return metadata.NewContext(ctx, md)
+}
+
+// NamespaceInterceptors returns the interceptors that make the calls of a
+// client in the namespace, unless their context was set a namespace with
+// WithNamespace
+func NamespaceInterceptors(namespace string) (UnaryClientInterceptor, StreamClientInterceptor) {
+	withNamespace := func(ctx context.Context) context.Context {
+		if md, ok := metadata.FromContext(ctx); ok && len(md[NamespaceKey]) > 0 {
+			return ctx
+		}
+		return WithNamespace(ctx, namespace)
+	}
+	unary := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withNamespace(ctx), method, req, reply, cc, opts...)
+	}
+	stream := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withNamespace(ctx), desc, cc, method, opts...)
+	}
+	return unary, stream
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// NetworkNamespace is a network namespace bind mounted under a name that
+// containers join with CreateOpts.NetworkNamespace
+type NetworkNamespace struct {
+	Name string
+	// Path is the file the namespace is bind mounted on
+	Path string
+	// Source is the container whose network namespace was kept under the
+	// name, empty for a namespace created for the name
+	Source string
+	// Containers joined the namespace
+	Containers []string
+	// Mounted is false once the bind mount was lost, e.g. after a reboot
+	Mounted bool
+}
+
+func newNetworkNamespace(n *types.NetworkNamespace) NetworkNamespace {
+	return NetworkNamespace{
+		Name:       n.Name,
+		Path:       n.Path,
+		Source:     n.Source,
+		Containers: n.Containers,
+		Mounted:    n.Mounted,
+	}
+}
+
+// CreateNetworkNamespace bind mounts a new network namespace under the name,
+// or the network namespace of the running container source when it is not
+// empty
+func (c *Client) CreateNetworkNamespace(ctx context.Context, name, source string) (NetworkNamespace, error) {
+	resp, err := c.API().CreateNetworkNamespace(ctx, &types.CreateNetworkNamespaceRequest{
+		Name:      name,
+		Container: source,
+	})
+	if err != nil {
+		return NetworkNamespace{}, translate(err)
+	}
+	return newNetworkNamespace(resp.NetworkNamespace), nil
+}
+
+// DeleteNetworkNamespace unmounts the named network namespace, it fails while
+// containers joined it
+func (c *Client) DeleteNetworkNamespace(ctx context.Context, name string) error {
+	_, err := c.API().DeleteNetworkNamespace(ctx, &types.DeleteNetworkNamespaceRequest{
+		Name: name,
+	})
+	return translate(err)
+}
+
+// NetworkNamespaces returns the named network namespaces, only the one with
+// the name when it is not empty
+func (c *Client) NetworkNamespaces(ctx context.Context, name string) ([]NetworkNamespace, error) {
+	resp, err := c.API().ListNetworkNamespaces(ctx, &types.ListNetworkNamespacesRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	var out []NetworkNamespace
+	for _, n := range resp.NetworkNamespaces {
+		out = append(out, newNetworkNamespace(n))
+	}
+	return out, nil
+}
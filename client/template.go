@@ -0,0 +1,66 @@
+package client
+
+import (
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"golang.org/x/net/context"
+)
+
+// Template is the configuration and the spec of a container saved to create
+// new containers from with the Template of CreateOpts
+type Template struct {
+	Name string
+	// Container is the id of the container the template was saved from
+	Container  string
+	Labels     []string
+	StdinOnce  bool
+	Keep       bool
+	AutoRemove bool
+	Created    time.Time
+}
+
+func newTemplate(t *types.Template) *Template {
+	return &Template{
+		Name:       t.Name,
+		Container:  t.Container,
+		Labels:     t.Labels,
+		StdinOnce:  t.StdinOnce,
+		Keep:       t.Keep,
+		AutoRemove: t.AutoRemove,
+		Created:    time.Unix(0, int64(t.Created)),
+	}
+}
+
+// SaveTemplate saves the configuration and the spec of the container id as
+// the template name
+func (c *Client) SaveTemplate(ctx context.Context, name, id string) (*Template, error) {
+	resp, err := c.API().CreateTemplate(ctx, &types.CreateTemplateRequest{
+		Name: name,
+		Id:   id,
+	})
+	if err != nil {
+		return nil, translate(err)
+	}
+	return newTemplate(resp.Template), nil
+}
+
+// Templates returns the saved templates sorted by name
+func (c *Client) Templates(ctx context.Context) ([]*Template, error) {
+	resp, err := c.API().ListTemplates(ctx, &types.ListTemplatesRequest{})
+	if err != nil {
+		return nil, translate(err)
+	}
+	var templates []*Template
+	for _, t := range resp.Templates {
+		templates = append(templates, newTemplate(t))
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes the template name, the containers created from it
+// are not affected
+func (c *Client) DeleteTemplate(ctx context.Context, name string) error {
+	_, err := c.API().DeleteTemplate(ctx, &types.DeleteTemplateRequest{Name: name})
+	return translate(err)
+}
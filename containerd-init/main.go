@@ -0,0 +1,94 @@
+// containerd-init is a minimal init injected as the process of containers
+// created with init.  It starts the container's command as its child, forwards
+// the signals it receives to the child and reaps the zombies reparented to it
+// as the first process of the container's pid namespace.  It exits with the
+// exit status of the child.
+//
+// It is built without cgo so that it runs in images without a libc.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "containerd-init: no command to run")
+		os.Exit(127)
+	}
+	os.Exit(run(args))
+}
+
+func run(args []string) int {
+	// handle the signals before the child is started so that none is lost
+	signals := make(chan os.Signal, 2048)
+	signal.Notify(signals)
+	defer signal.Stop(signals)
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "containerd-init: %v\n", err)
+		return 127
+	}
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Args[0] = args[0]
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "containerd-init: %v\n", err)
+		return 126
+	}
+	child := cmd.Process.Pid
+	for s := range signals {
+		switch s {
+		case syscall.SIGCHLD:
+			if status, exited := reap(child); exited {
+				return status
+			}
+		case syscall.SIGURG:
+			// used by the go runtime to preempt goroutines
+		default:
+			syscall.Kill(child, s.(syscall.Signal))
+		}
+	}
+	return 0
+}
+
+// reap waits for all the exited children and returns the exit status of the
+// child once it exited
+func reap(child int) (int, bool) {
+	var (
+		status int
+		exited bool
+	)
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, syscall.WNOHANG, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if pid <= 0 || err != nil {
+			return status, exited
+		}
+		if pid == child {
+			status, exited = exitStatus(ws), true
+		}
+	}
+}
+
+// exitStatus returns the exit code of the process or 128 plus the signal
+// that killed it
+func exitStatus(ws syscall.WaitStatus) int {
+	if ws.Signaled() {
+		return 128 + int(ws.Signal())
+	}
+	return ws.ExitStatus()
+}
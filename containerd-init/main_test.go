@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+)
+
+func TestExitStatus(t *testing.T) {
+	for script, expected := range map[string]int{
+		"exit 0":      0,
+		"exit 3":      3,
+		"kill -9 $$":  137,
+		"kill -15 $$": 143,
+	} {
+		cmd := exec.Command("sh", "-c", script)
+		cmd.Run()
+		ws := cmd.ProcessState.Sys().(syscall.WaitStatus)
+		if status := exitStatus(ws); status != expected {
+			t.Errorf("expected the status %d for %q but received %d", expected, script, status)
+		}
+	}
+}
+
+func TestRunReapsChild(t *testing.T) {
+	if status := run([]string{"sh", "-c", "sleep 0 & exit 5"}); status != 5 {
+		t.Fatalf("expected the status 5 of the child but received %d", status)
+	}
+}
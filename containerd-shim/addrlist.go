@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// addrList returns the addresses of the family on the link, or on all of the
+// links when link is nil.  It replaces netlink.AddrList which drops every
+// address of the dump as it compares their family with one it never sets.
+func addrList(link netlink.Link, family int) ([]netlink.Addr, error) {
+	req := nl.NewNetlinkRequest(syscall.RTM_GETADDR, syscall.NLM_F_DUMP)
+	req.AddData(nl.NewIfAddrmsg(family))
+	msgs, err := req.Execute(syscall.NETLINK_ROUTE, syscall.RTM_NEWADDR)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []netlink.Addr
+	for _, m := range msgs {
+		msg := nl.DeserializeIfAddrmsg(m)
+		if link != nil && int(msg.Index) != link.Attrs().Index {
+			continue
+		}
+		if family != netlink.FAMILY_ALL && int(msg.Family) != family {
+			continue
+		}
+		attrs, err := nl.ParseRouteAttr(m[msg.Len():])
+		if err != nil {
+			return nil, err
+		}
+		a := netlink.Addr{Flags: int(msg.Flags), Scope: int(msg.Scope)}
+		for _, attr := range attrs {
+			ipnet := &net.IPNet{IP: attr.Value, Mask: net.CIDRMask(int(msg.Prefixlen), 8*len(attr.Value))}
+			switch attr.Attr.Type {
+			case syscall.IFA_ADDRESS:
+				// the peer of a point to point address, the local
+				// address follows when they differ
+				if a.IPNet == nil {
+					a.IPNet = ipnet
+				}
+			case syscall.IFA_LOCAL:
+				a.IPNet = ipnet
+			case syscall.IFA_LABEL:
+				a.Label = nl.BytesToString(attr.Value)
+			case netlink.IFA_FLAGS:
+				a.Flags = int(nl.NativeEndian().Uint32(attr.Value[0:4]))
+			}
+		}
+		if a.IPNet != nil {
+			addrs = append(addrs, a)
+		}
+	}
+	return addrs, nil
+}
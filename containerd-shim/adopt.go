@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// adoptProcess holds the exit fifo of a running process whose shim died until
+// the process exits.  The process was reparented to containerd so the shim
+// cannot wait for it, instead it polls for the process and leaves writing the
+// exit status to containerd which reaps the process.
+func adoptProcess() error {
+	f, err := os.OpenFile(runtime.ExitFile, syscall.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	control, err := os.OpenFile(runtime.ControlFile, syscall.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer control.Close()
+	// the process' stdio died with the previous shim so control messages are
+	// discarded
+	go func() {
+		s := bufio.NewScanner(control)
+		for s.Scan() {
+		}
+	}()
+	data, err := ioutil.ReadFile("pid")
+	if err != nil {
+		return err
+	}
+	pid, err := strconv.Atoi(string(data))
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(runtime.AdoptedFile, nil, 0644); err != nil {
+		return err
+	}
+	logrus.WithField("pid", pid).Info("shim: adopted process")
+	for syscall.Kill(pid, 0) != syscall.ESRCH {
+		time.Sleep(100 * time.Millisecond)
+	}
+	// containerd writes the status right after reaping the process
+	for i := 0; i < 10; i++ {
+		if _, err := os.Stat(runtime.ExitStatusFile); err == nil {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	logrus.WithField("pid", pid).Warn("shim: exit status of adopted process is unknown")
+	return writeInt(runtime.ExitStatusFile, 255)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer"
+)
+
+// consoleSocketName is the name of the socket in the process' state directory
+// that the runtime sends the pty master to
+const consoleSocketName = "console.sock"
+
+var errNoConsole = errors.New("shim: runtime did not send the console")
+
+// consoleSocketProbe is the cached result of probing a subcommand of the
+// runtime for the --console-socket flag
+type consoleSocketProbe struct {
+	once      sync.Once
+	supported bool
+}
+
+// consoleSocketProbes are the probes of the subcommands that start processes
+var consoleSocketProbes = map[string]*consoleSocketProbe{
+	"start": {},
+	"exec":  {},
+}
+
+// supportsConsoleSocket returns true if the runtime's subcommand accepts the
+// --console-socket flag to send the pty master of the container to the shim.
+// The runtime is only run once for each subcommand.
+func supportsConsoleSocket(runtime, subcommand string) bool {
+	probe := consoleSocketProbes[subcommand]
+	probe.once.Do(func() {
+		out, err := exec.Command(runtime, subcommand, "--help").CombinedOutput()
+		probe.supported = err == nil && strings.Contains(string(out), "console-socket")
+	})
+	return probe.supported
+}
+
+// openConsole allocates the terminal for the process and calls ready with it.
+// When the runtime supports it the pty is allocated by the runtime inside of
+// the container and the master is received over a socket once it is started,
+// otherwise the shim allocates the pty itself.
+func (p *process) openConsole(uid, gid int, ready func(libcontainer.Console)) error {
+	subcommand := "start"
+	if p.state.Exec {
+		subcommand = "exec"
+	}
+	// restore has no console flags so the restored process keeps its terminal
+	if p.checkpoint == nil && supportsConsoleSocket(p.runtime, subcommand) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(cwd, consoleSocketName)
+		l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+		if err != nil {
+			return err
+		}
+		p.consoleSocket = l
+		p.consoleReady = ready
+		return nil
+	}
+	console, err := libcontainer.NewConsole(uid, gid)
+	if err != nil {
+		return err
+	}
+	p.console = console
+	p.consolePath = console.Path()
+	ready(console)
+	return nil
+}
+
+// receiveConsole waits for the runtime to connect to the console socket and
+// returns the pty master that it sends
+func receiveConsole(l *net.UnixListener) (*os.File, error) {
+	conn, err := l.AcceptUnix()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	var (
+		name = make([]byte, 4096)
+		oob  = make([]byte, syscall.CmsgSpace(4))
+	)
+	n, oobn, _, _, err := conn.ReadMsgUnix(name, oob)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, errNoConsole
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(fds) != 1 {
+		return nil, errNoConsole
+	}
+	return os.NewFile(uintptr(fds[0]), string(name[:n])), nil
+}
+
+// waitForConsole returns the pty master received by receiveConsole after the
+// runtime has started the container
+func (p *process) waitForConsole(received chan consoleResult) error {
+	defer p.closeConsoleSocket()
+	select {
+	case r := <-received:
+		if r.err != nil {
+			return r.err
+		}
+		console := &masterConsole{r.master}
+		p.console = console
+		p.consoleReady(console)
+		return nil
+	case <-time.After(10 * time.Second):
+		return errNoConsole
+	}
+}
+
+// closeConsoleSocket closes the console socket so that receiveConsole returns
+// if the runtime has not connected, it can be called more than once
+func (p *process) closeConsoleSocket() {
+	p.consoleSocket.Close()
+	os.Remove(consoleSocketName)
+}
+
+type consoleResult struct {
+	master *os.File
+	err    error
+}
+
+// masterConsole is the pty master received from the runtime
+type masterConsole struct {
+	*os.File
+}
+
+// Path returns an empty path as the slave was allocated inside the container
+func (c *masterConsole) Path() string {
+	return ""
+}
+
+// consoleArgs returns the runtime flags used to allocate the terminal
+func (p *process) consoleArgs() []string {
+	if p.consoleSocket != nil {
+		return []string{"--console-socket", p.consoleSocket.Addr().String()}
+	}
+	return []string{"--console", p.consolePath}
+}
@@ -1,18 +1,29 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/containerd/osutils"
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/tracing"
 	"github.com/docker/docker/pkg/term"
 )
 
+var (
+	adopt   = flag.Bool("adopt", false, "take over the running process of a shim that died")
+	idle    = flag.Bool("idle", false, "wait on stdin for the process to run, used by the daemon's shim pool")
+	sandbox = flag.Bool("sandbox", false, "hold the namespaces the shim was started in for the containers of a group")
+	netWait = flag.Duration("wait-network", 0, "run as the prestart hook waiting for an address in the container's network namespace")
+)
+
 // containerd-shim is a small shim that sits in front of a runtime implementation
 // that allows it to be repartented to init and handle reattach from the caller.
 //
@@ -20,6 +31,23 @@ import (
 // to the state directory where the shim can locate fifos and other information.
 func main() {
 	flag.Parse()
+	args := flag.Args()
+	if *netWait > 0 {
+		// the runtime reports the stderr of a failed hook
+		if err := waitForNetwork(*netWait); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *idle {
+		h, err := waitForHandoff()
+		if err != nil {
+			// the daemon closed the pool
+			os.Exit(1)
+		}
+		args = h.Args
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		panic(err)
@@ -30,7 +58,21 @@ func main() {
 	}
 	logrus.SetOutput(f)
 	logrus.SetFormatter(&logrus.JSONFormatter{})
-	if err := start(); err != nil {
+	if *sandbox {
+		holdNamespaces()
+		f.Close()
+		return
+	}
+	if *adopt {
+		if err := adoptProcess(); err != nil {
+			logrus.Error(err)
+			f.Close()
+			os.Exit(1)
+		}
+		f.Close()
+		return
+	}
+	if err := start(args); err != nil {
 		// this means that the runtime failed starting the container and will have the
 		// proper error messages in the runtime log so we should to treat this as a
 		// shim failure because the sim executed properly
@@ -47,7 +89,7 @@ func main() {
 	}
 }
 
-func start() error {
+func start(args []string) error {
 	// start handling signals as soon as possible so that things are properly reaped
 	// or if runtime exits before we hit the handler
 	signals := make(chan os.Signal, 2048)
@@ -67,7 +109,10 @@ func start() error {
 		return err
 	}
 	defer control.Close()
-	p, err := newProcess(flag.Arg(0), flag.Arg(1), flag.Arg(2))
+	if len(args) < 3 {
+		return fmt.Errorf("expected the id, bundle and runtime but received %v", args)
+	}
+	p, err := newProcess(args[0], args[1], args[2])
 	if err != nil {
 		return err
 	}
@@ -76,10 +121,12 @@ func start() error {
 			logrus.Warn(err)
 		}
 	}()
+	started := time.Now()
 	if err := p.start(); err != nil {
 		p.delete()
 		return err
 	}
+	p.started, p.ready = started, time.Now()
 	go func() {
 		for {
 			var msg, w, h int
@@ -101,6 +148,13 @@ func start() error {
 					Height: uint16(h),
 				}
 				term.SetWinsize(p.console.Fd(), &ws)
+			case 2:
+				// the daemon traces the start of the process
+				tracing.Enable()
+				p.reportSpans(tracing.SpanContext{
+					TraceID: uint64(w),
+					SpanID:  uint64(h),
+				})
 			}
 		}
 	}()
@@ -136,6 +190,28 @@ func start() error {
 	return nil
 }
 
+// waitForHandoff reads the work of an idle shim from stdin and changes to the
+// process' directory
+func waitForHandoff() (*runtime.ShimHandoff, error) {
+	var h runtime.ShimHandoff
+	if err := json.NewDecoder(os.Stdin).Decode(&h); err != nil {
+		return nil, err
+	}
+	// the shim's stdio is /dev/null when it is not pooled
+	null, err := os.Open(os.DevNull)
+	if err != nil {
+		return nil, err
+	}
+	defer null.Close()
+	if err := syscall.Dup2(int(null.Fd()), 0); err != nil {
+		return nil, err
+	}
+	if err := os.Chdir(h.Dir); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
 func writeInt(path string, i int) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	goruntime "runtime"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/vishvananda/netlink"
+)
+
+var errNetworkTimeout = errors.New("timed out waiting for an address in the container's network namespace")
+
+// waitForNetwork runs as a prestart hook of the runtime, it blocks until the
+// network namespace of the container has an address so that the user process
+// is not started before DHCP or IPAM configured the container's network.
+// Loopback and link local addresses are ignored as the kernel adds them
+// without any configuration.
+func waitForNetwork(timeout time.Duration) error {
+	// the runtime writes the state of the container to the hook's stdin
+	var state struct {
+		Pid int `json:"pid"`
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&state); err != nil {
+		return fmt.Errorf("decode container state: %v", err)
+	}
+	ns, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", state.Pid))
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+	// the netlink sockets are opened in the namespace of the thread, it is
+	// never unlocked as the hook exits after the wait
+	goruntime.LockOSThread()
+	if err := system.Setns(ns.Fd(), syscall.CLONE_NEWNET); err != nil {
+		return fmt.Errorf("join network namespace of %d: %v", state.Pid, err)
+	}
+	return waitForAddress(timeout)
+}
+
+// waitForAddress blocks until the network namespace of the calling thread has
+// a configured address or the timeout expires
+func waitForAddress(timeout time.Duration) error {
+	updates := make(chan netlink.AddrUpdate)
+	done := make(chan struct{})
+	defer close(done)
+	// subscribe before the addresses are listed so that an address added
+	// in between is not missed
+	if err := netlink.AddrSubscribe(updates, done); err != nil {
+		return err
+	}
+	addrs, err := addrList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return err
+	}
+	for _, a := range addrs {
+		if configured(a.IPNet) {
+			return nil
+		}
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case u, ok := <-updates:
+			if !ok {
+				return errors.New("netlink address subscription closed")
+			}
+			if u.NewAddr && configured(&u.LinkAddress) {
+				return nil
+			}
+		case <-timer.C:
+			return errNetworkTimeout
+		}
+	}
+}
+
+// configured returns true for addresses that are not added by the kernel
+func configured(n *net.IPNet) bool {
+	return n != nil && !n.IP.IsLoopback() && !n.IP.IsLinkLocalUnicast()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net"
+	"os"
+	goruntime "runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vishvananda/netlink"
+)
+
+func TestConfiguredAddress(t *testing.T) {
+	for addr, expected := range map[string]bool{
+		"127.0.0.1/8":    false,
+		"::1/128":        false,
+		"169.254.1.1/16": false,
+		"fe80::1/64":     false,
+		"10.0.0.2/24":    true,
+		"2001:db8::2/64": true,
+	} {
+		ip, n, err := net.ParseCIDR(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n.IP = ip
+		if configured(n) != expected {
+			t.Errorf("expected %s to be configured %v", addr, expected)
+		}
+	}
+}
+
+func TestWaitForPreassignedAddress(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("creating a network namespace requires root")
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		// the thread is left in the new namespace, it exits with the
+		// goroutine as it is never unlocked
+		goruntime.LockOSThread()
+		if err := syscall.Unshare(syscall.CLONE_NEWNET); err != nil {
+			errCh <- err
+			return
+		}
+		lo, err := netlink.LinkByName("lo")
+		if err != nil {
+			errCh <- err
+			return
+		}
+		addr, err := netlink.ParseAddr("10.0.0.2/24")
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if err := netlink.AddrAdd(lo, addr); err != nil {
+			errCh <- err
+			return
+		}
+		errCh <- waitForAddress(time.Second)
+	}()
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
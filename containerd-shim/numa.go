@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// memory policy modes of set_mempolicy(2)
+const (
+	mpolDefault    = 0
+	mpolPreferred  = 1
+	mpolBind       = 2
+	mpolInterleave = 3
+)
+
+// setMemoryPolicy sets the memory policy of the calling thread for the NUMA
+// nodes, the caller must lock the goroutine to its thread
+func setMemoryPolicy(policy, list string) error {
+	var mode uintptr
+	switch policy {
+	case runtime.MemoryPolicyBind:
+		mode = mpolBind
+	case runtime.MemoryPolicyPreferred:
+		mode = mpolPreferred
+	case runtime.MemoryPolicyInterleave:
+		mode = mpolInterleave
+	default:
+		return fmt.Errorf("unknown memory policy %q", policy)
+	}
+	nodes, err := runtime.ParseNUMANodes(list)
+	if err != nil {
+		return err
+	}
+	var max int
+	for _, n := range nodes {
+		if n > max {
+			max = n
+		}
+	}
+	mask := make([]uint64, max/64+1)
+	for _, n := range nodes {
+		mask[n/64] |= 1 << uint(n%64)
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SET_MEMPOLICY, mode, uintptr(unsafe.Pointer(&mask[0])), uintptr(len(mask)*64+1)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// resetMemoryPolicy restores the default memory policy of the calling thread
+func resetMemoryPolicy() error {
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SET_MEMPOLICY, mpolDefault, 0, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -6,14 +6,21 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	goruntime "runtime"
 	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/mux"
 	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/tracing"
 	"github.com/opencontainers/runc/libcontainer"
 )
 
@@ -31,15 +38,34 @@ type process struct {
 	stdinCloser  io.Closer
 	console      libcontainer.Console
 	consolePath  string
-	state        *runtime.ProcessState
-	runtime      string
+	// consoleSocket receives the pty master from the runtime
+	consoleSocket *net.UnixListener
+	consoleReady  func(libcontainer.Console)
+	state         *runtime.ProcessState
+	runtime       string
+	logger        logger.Driver
+	logEvents     int
+	output        *mux.Muxer
+	outputFd      int
+	// stdioSocket is containerd's connection to the stdio when the process
+	// uses a stdio socket
+	stdioSocket *stdioSocket
+	// timings of the start that are reported once the daemon sends the
+	// process' span context
+	started        time.Time
+	ready          time.Time
+	runtimeCommand string
+	runtimeStarted time.Time
+	runtimeExited  time.Time
 }
 
 func newProcess(id, bundle, runtimeName string) (*process, error) {
 	p := &process{
-		id:      id,
-		bundle:  bundle,
-		runtime: runtimeName,
+		id:        id,
+		bundle:    bundle,
+		runtime:   runtimeName,
+		logEvents: -1,
+		outputFd:  -1,
 	}
 	s, err := loadProcess()
 	if err != nil {
@@ -53,6 +79,12 @@ func newProcess(id, bundle, runtimeName string) (*process, error) {
 		}
 		p.checkpoint = cpt
 	}
+	if err := p.openLogger(); err != nil {
+		return nil, err
+	}
+	if err := p.openOutput(); err != nil {
+		return nil, err
+	}
 	if err := p.openIO(); err != nil {
 		return nil, err
 	}
@@ -85,12 +117,161 @@ func loadCheckpoint(bundle, name string) (*runtime.Checkpoint, error) {
 	return &cpt, nil
 }
 
+// openLogger creates the log driver that the process' output is copied to
+func (p *process) openLogger() error {
+	if p.state.LogConfig.Driver == "" {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	// the raw fd is used so that writes fail with EAGAIN instead of blocking
+	// when containerd is not reading events
+	fd, err := syscall.Open(runtime.LogEventsFile, syscall.O_RDWR|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	p.logEvents = fd
+	// logs are written next to the process directories in the container's
+	// state directory unless a path was configured
+	dir := p.state.LogConfig.Path
+	if dir == "" {
+		dir = filepath.Dir(cwd)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	d, err := logger.New(p.state.LogConfig.Driver, logger.Info{
+		ContainerID: p.id,
+		ProcessID:   filepath.Base(cwd),
+		Path:        dir,
+		Options:     p.state.LogConfig.Options,
+		OnRotate:    p.logRotated,
+	})
+	if err != nil {
+		syscall.Close(fd)
+		return err
+	}
+	p.logger = d
+	return nil
+}
+
+// logRotated notifies containerd that the log file at path was rotated.  The
+// notification is dropped if the fifo is full.
+func (p *process) logRotated(path string) {
+	if _, err := syscall.Write(p.logEvents, []byte(path+"\n")); err != nil {
+		logrus.WithField("error", err).Warn("shim: notify log rotation")
+	}
+}
+
+// openOutput opens the fifo that the process' stdout and stderr are written to
+// as a single framed stream for containerd to serve to attached clients
+func (p *process) openOutput() error {
+	if p.state.StdioSocket {
+		// the framed output is written to the stdio socket instead
+		s, err := newStdioSocket()
+		if err != nil {
+			return err
+		}
+		p.stdioSocket = s
+		p.output = mux.New(s)
+		return nil
+	}
+	fd, err := syscall.Open(runtime.OutputFile, syscall.O_RDWR|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		// processes created by an older containerd do not have the fifo
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	p.outputFd = fd
+	p.output = mux.New(dropWriter(fd))
+	return nil
+}
+
+// copyOutput copies the output of the process to dst, the log driver and
+// the framed output stream
+func (p *process) copyOutput(dst io.Writer, src io.Reader, s mux.Stream) {
+	var writers []io.Writer
+	if p.logger != nil {
+		w := logger.NewWriter(p.logger, s.String())
+		defer w.Close()
+		writers = append(writers, w)
+	}
+	if p.output != nil {
+		writers = append(writers, p.output.Stream(s))
+	}
+	if p.state.LogConfig.Mode == runtime.LogModeNonBlocking {
+		size := p.state.LogConfig.MaxBufferSize
+		if size <= 0 {
+			size = runtime.DefaultMaxBufferSize
+		}
+		// the process' output is read into the buffer which never blocks so
+		// slow writers cause output to be dropped instead of stalling the process
+		rb := newRingBuffer(size)
+		go func() {
+			io.Copy(rb, src)
+			rb.Close()
+		}()
+		defer func() {
+			if dropped := rb.Dropped(); dropped > 0 {
+				logrus.WithFields(logrus.Fields{
+					"stream":  s.String(),
+					"dropped": dropped,
+				}).Warn("shim: output dropped in non-blocking mode")
+			}
+		}()
+		src = rb
+	}
+	if d, s, ok := spliceable(dst, src); ok {
+		var (
+			n   int64
+			err error
+		)
+		if len(writers) == 0 {
+			n, err = spliceCopy(d, s)
+		} else {
+			n, err = teeCopy(d, s, io.MultiWriter(writers...))
+		}
+		if err == nil {
+			return
+		}
+		logrus.WithField("error", err).Warn("shim: splice output")
+		if n > 0 {
+			return
+		}
+		// nothing was moved so the output can still be copied
+	}
+	if dst != nil {
+		writers = append(writers, dst)
+	}
+	// the log writer and the writers of the muxer never fail a write so
+	// that io.MultiWriter keeps copying to dst when a log driver fails
+	io.Copy(io.MultiWriter(writers...), src)
+}
+
+// dropWriter writes to an fd.  Writes that fail, for example because the
+// non-blocking fifo is full, are dropped so that they never stall or break the
+// copy of the process' output.
+type dropWriter int
+
+func (f dropWriter) Write(p []byte) (int, error) {
+	syscall.Write(int(f), p)
+	return len(p), nil
+}
+
 func (p *process) start() error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 	logPath := filepath.Join(cwd, "log.json")
+	bundle := p.bundle
+	if p.state.RuntimeBundle != "" {
+		bundle = p.state.RuntimeBundle
+	}
 	args := append([]string{
 		"--log", logPath,
 		"--log-format", "json",
@@ -98,8 +279,8 @@ func (p *process) start() error {
 	if p.state.Exec {
 		args = append(args, "exec",
 			"--process", filepath.Join(cwd, "process.json"),
-			"--console", p.consolePath,
 		)
+		args = append(args, p.consoleArgs()...)
 	} else if p.checkpoint != nil {
 		args = append(args, "restore",
 			"--image-path", filepath.Join(p.bundle, "checkpoints", p.checkpoint.Name),
@@ -118,9 +299,9 @@ func (p *process) start() error {
 		}
 	} else {
 		args = append(args, "start",
-			"--bundle", p.bundle,
-			"--console", p.consolePath,
+			"--bundle", bundle,
 		)
+		args = append(args, p.consoleArgs()...)
 	}
 	args = append(args,
 		"-d",
@@ -128,7 +309,9 @@ func (p *process) start() error {
 		p.id,
 	)
 	cmd := exec.Command(p.runtime, args...)
-	cmd.Dir = p.bundle
+	// the runtime restores a checkpoint into the bundle of its cwd
+	cmd.Dir = bundle
+	p.runtimeCommand = args[len(p.state.RuntimeArgs)+4]
 	cmd.Stdin = p.stdio.stdin
 	cmd.Stdout = p.stdio.stdout
 	cmd.Stderr = p.stdio.stderr
@@ -137,6 +320,27 @@ func (p *process) start() error {
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Pdeathsig: syscall.SIGKILL,
 	}
+	var received chan consoleResult
+	if p.consoleSocket != nil {
+		received = make(chan consoleResult, 1)
+		go func() {
+			master, err := receiveConsole(p.consoleSocket)
+			received <- consoleResult{master: master, err: err}
+		}()
+		// the goroutine returns when the runtime fails to start or exits
+		// before it sends the console
+		defer p.closeConsoleSocket()
+	}
+	// the runtime and the container's processes inherit the memory policy of
+	// the thread that starts the runtime
+	if policy := p.state.NUMA.MemoryPolicy; policy != "" {
+		goruntime.LockOSThread()
+		defer goruntime.UnlockOSThread()
+		if err := setMemoryPolicy(policy, p.state.NUMA.Nodes); err != nil {
+			return err
+		}
+	}
+	p.runtimeStarted = time.Now()
 	if err := cmd.Start(); err != nil {
 		if exErr, ok := err.(*exec.Error); ok {
 			if exErr.Err == exec.ErrNotFound || exErr.Err == os.ErrNotExist {
@@ -145,14 +349,26 @@ func (p *process) start() error {
 		}
 		return err
 	}
+	if p.state.NUMA.MemoryPolicy != "" {
+		if err := resetMemoryPolicy(); err != nil {
+			return err
+		}
+	}
 	p.stdio.stdout.Close()
 	p.stdio.stderr.Close()
-	if err := cmd.Wait(); err != nil {
+	err = cmd.Wait()
+	p.runtimeExited = time.Now()
+	if err != nil {
 		if _, ok := err.(*exec.ExitError); ok {
 			return errRuntime
 		}
 		return err
 	}
+	if received != nil {
+		if err := p.waitForConsole(received); err != nil {
+			return err
+		}
+	}
 	data, err := ioutil.ReadFile("pid")
 	if err != nil {
 		return err
@@ -165,6 +381,18 @@ func (p *process) start() error {
 	return nil
 }
 
+// reportSpans records the shim's start and the runtime command as children of
+// the span that started the process in the daemon
+func (p *process) reportSpans(parent tracing.SpanContext) {
+	shim := tracing.StartSpanFrom(parent, "shim.start")
+	shim.Start = p.started
+	shim.SetTag("id", p.id)
+	run := tracing.StartSpanFrom(shim.Context, p.runtime+"."+p.runtimeCommand)
+	run.Start = p.runtimeStarted
+	run.FinishAt(p.runtimeExited)
+	shim.FinishAt(p.ready)
+}
+
 func (p *process) pid() int {
 	return p.containerPid
 }
@@ -187,6 +415,9 @@ func (p *process) openIO() error {
 		uid = p.state.RootUID
 		gid = p.state.RootGID
 	)
+	if p.state.StdioSocket {
+		return p.openSocketIO(uid, gid)
+	}
 	go func() {
 		if stdinCloser, err := os.OpenFile(p.state.Stdin, syscall.O_WRONLY, 0); err == nil {
 			p.stdinCloser = stdinCloser
@@ -194,28 +425,23 @@ func (p *process) openIO() error {
 	}()
 
 	if p.state.Terminal {
-		console, err := libcontainer.NewConsole(uid, gid)
-		if err != nil {
-			return err
-		}
-		p.console = console
-		p.consolePath = console.Path()
 		stdin, err := os.OpenFile(p.state.Stdin, syscall.O_RDONLY, 0)
 		if err != nil {
 			return err
 		}
-		go io.Copy(console, stdin)
 		stdout, err := os.OpenFile(p.state.Stdout, syscall.O_RDWR, 0)
 		if err != nil {
 			return err
 		}
 		p.Add(1)
-		go func() {
-			io.Copy(stdout, console)
-			console.Close()
-			p.Done()
-		}()
-		return nil
+		return p.openConsole(uid, gid, func(console libcontainer.Console) {
+			go io.Copy(console, stdin)
+			go func() {
+				p.copyOutput(stdout, console, mux.Stdout)
+				console.Close()
+				p.Done()
+			}()
+		})
 	}
 	i, err := p.initializeIO(uid)
 	if err != nil {
@@ -227,14 +453,14 @@ func (p *process) openIO() error {
 		p.state.Stdout: func(f *os.File) {
 			p.Add(1)
 			go func() {
-				io.Copy(f, i.Stdout)
+				p.copyOutput(f, i.Stdout, mux.Stdout)
 				p.Done()
 			}()
 		},
 		p.state.Stderr: func(f *os.File) {
 			p.Add(1)
 			go func() {
-				io.Copy(f, i.Stderr)
+				p.copyOutput(f, i.Stderr, mux.Stderr)
 				p.Done()
 			}()
 		},
@@ -251,13 +477,83 @@ func (p *process) openIO() error {
 		return err
 	}
 	go func() {
-		io.Copy(i.Stdin, f)
+		copyStdinPipe(i.Stdin, f)
 		i.Stdin.Close()
 	}()
 
 	return nil
 }
 
+// openSocketIO uses the socket passed by containerd for the process' stdio.
+// Output is written to the socket as frames and stdin is read from the
+// stdin frames that containerd writes to it.
+func (p *process) openSocketIO(uid, gid int) error {
+	if p.state.Terminal {
+		p.Add(1)
+		return p.openConsole(uid, gid, func(console libcontainer.Console) {
+			if err := p.stdioSocket.serve(console); err != nil {
+				logrus.WithField("error", err).Error("shim: serve stdio socket")
+			}
+			go func() {
+				p.copyOutput(nil, console, mux.Stdout)
+				console.Close()
+				p.Done()
+			}()
+		})
+	}
+	i, err := p.initializeIO(uid)
+	if err != nil {
+		return err
+	}
+	p.shimIO = i
+	p.stdinCloser = i.Stdin
+	for s, r := range map[mux.Stream]io.Reader{
+		mux.Stdout: i.Stdout,
+		mux.Stderr: i.Stderr,
+	} {
+		p.Add(1)
+		go func(s mux.Stream, r io.Reader) {
+			p.copyOutput(nil, r, s)
+			p.Done()
+		}(s, r)
+	}
+	// stdin is only closed through the control pipe so that containerd going
+	// away does not close the process' stdin
+	return p.stdioSocket.serve(i.Stdin)
+}
+
+// copyStdinPipe copies the stdin fifo to the process' stdin, splicing when
+// possible
+func copyStdinPipe(w io.Writer, r io.Reader) {
+	if d, s, ok := spliceable(w, r); ok {
+		n, err := spliceCopy(d, s)
+		if err == nil {
+			return
+		}
+		logrus.WithField("error", err).Warn("shim: splice stdin")
+		if n > 0 {
+			return
+		}
+	}
+	io.Copy(w, r)
+}
+
+// copyStdin writes the payload of the stdin frames read from r to w
+func copyStdin(w io.Writer, r io.Reader) {
+	for {
+		s, data, err := mux.ReadFrame(r)
+		if err != nil {
+			return
+		}
+		if s != mux.Stdin {
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			return
+		}
+	}
+}
+
 type IO struct {
 	Stdin  io.WriteCloser
 	Stdout io.ReadCloser
@@ -303,7 +599,17 @@ func (p *process) initializeIO(rootuid int) (i *IO, err error) {
 	return i, nil
 }
 func (p *process) Close() error {
-	return p.stdio.Close()
+	err := p.stdio.Close()
+	if p.logger != nil {
+		if lerr := p.logger.Close(); err == nil {
+			err = lerr
+		}
+		syscall.Close(p.logEvents)
+	}
+	if p.outputFd != -1 {
+		syscall.Close(p.outputFd)
+	}
+	return err
 }
 
 type stdio struct {
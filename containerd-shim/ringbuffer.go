@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io"
+	"sync"
+)
+
+// ringBuffer is a bounded buffer whose writes never block.  Data that does
+// not fit in the buffer is dropped and counted.
+type ringBuffer struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	buf     []byte
+	start   int
+	len     int
+	closed  bool
+	dropped int64
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	r := &ringBuffer{
+		buf: make([]byte, size),
+	}
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+func (r *ringBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(p)
+	if free := len(r.buf) - r.len; n > free {
+		r.dropped += int64(n - free)
+		n = free
+	}
+	for i := 0; i < n; {
+		// copy into the free space after the buffered data up to either the
+		// end of the buffer or the start of the buffered data once wrapped
+		end := (r.start + r.len) % len(r.buf)
+		limit := len(r.buf)
+		if end < r.start {
+			limit = r.start
+		}
+		c := copy(r.buf[end:limit], p[i:n])
+		r.len += c
+		i += c
+	}
+	if n > 0 {
+		r.cond.Signal()
+	}
+	// report the full write so that the copy from the process continues
+	return len(p), nil
+}
+
+// Read blocks until data is available or the buffer is closed and drained
+func (r *ringBuffer) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.len == 0 {
+		if r.closed {
+			return 0, io.EOF
+		}
+		r.cond.Wait()
+	}
+	n := 0
+	for n < len(p) && r.len > 0 {
+		end := r.start + r.len
+		if end > len(r.buf) {
+			end = len(r.buf)
+		}
+		c := copy(p[n:], r.buf[r.start:end])
+		r.start = (r.start + c) % len(r.buf)
+		r.len -= c
+		n += c
+	}
+	return n, nil
+}
+
+// Close causes Read to return io.EOF once the buffered data is read
+func (r *ringBuffer) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	r.cond.Broadcast()
+	return nil
+}
+
+// Dropped returns the number of bytes dropped because the buffer was full
+func (r *ringBuffer) Dropped() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestRingBufferDropsWhenFull(t *testing.T) {
+	r := newRingBuffer(8)
+	r.Write([]byte("abcdef"))
+	p := make([]byte, 4)
+	if n, _ := r.Read(p); string(p[:n]) != "abcd" {
+		t.Fatalf("expected abcd but received %q", p[:n])
+	}
+	// wraps around the end of the buffer and drops what does not fit
+	r.Write([]byte("ghijklmn"))
+	r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte("efghijkl")) {
+		t.Fatalf("expected efghijkl but received %q", data)
+	}
+	if d := r.Dropped(); d != 2 {
+		t.Fatalf("expected 2 dropped bytes but received %d", d)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/osutils"
+)
+
+// holdNamespaces keeps the namespaces that the shim was started in alive for
+// the containers of a group until the shim is terminated.  When the group
+// shares the pid namespace the shim is its init and reaps the orphaned
+// processes of the group's containers.
+func holdNamespaces() {
+	signals := make(chan os.Signal, 2048)
+	signal.Notify(signals, syscall.SIGCHLD, syscall.SIGTERM, syscall.SIGINT)
+	for s := range signals {
+		if s != syscall.SIGCHLD {
+			return
+		}
+		if _, err := osutils.Reap(); err != nil {
+			logrus.Warn(err)
+		}
+	}
+}
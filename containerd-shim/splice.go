@@ -0,0 +1,131 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+const (
+	// spliceSize is the most data moved by a single splice or tee
+	spliceSize = 64 * 1024
+	// SPLICE_F_MOVE and SPLICE_F_NONBLOCK are not defined by the syscall
+	// package
+	spliceMove     = 0x1
+	spliceNonblock = 0x2
+)
+
+var (
+	spliceOnce      sync.Once
+	spliceSupported bool
+)
+
+// canSplice returns true if the kernel supports splicing and teeing between
+// pipes, the result is written to the stdio mode file for containerd
+func canSplice() bool {
+	spliceOnce.Do(func() {
+		spliceSupported = probeSplice()
+		mode := runtime.StdioModeCopy
+		if spliceSupported {
+			mode = runtime.StdioModeSplice
+		}
+		if err := ioutil.WriteFile(runtime.StdioModeFile, []byte(mode), 0644); err != nil {
+			logrus.WithField("error", err).Warn("shim: write stdio mode")
+		}
+	})
+	return spliceSupported
+}
+
+func probeSplice() bool {
+	r1, w1, err := os.Pipe()
+	if err != nil {
+		return false
+	}
+	defer r1.Close()
+	defer w1.Close()
+	r2, w2, err := os.Pipe()
+	if err != nil {
+		return false
+	}
+	defer r2.Close()
+	defer w2.Close()
+	if _, err := w1.Write([]byte{0}); err != nil {
+		return false
+	}
+	if _, err := syscall.Tee(int(r1.Fd()), int(w2.Fd()), 1, spliceNonblock); err != nil {
+		return false
+	}
+	_, err = syscall.Splice(int(r1.Fd()), nil, int(w2.Fd()), nil, 1, spliceNonblock)
+	return err == nil
+}
+
+// isPipe returns true if the file is a pipe or a fifo
+func isPipe(f *os.File) bool {
+	var st syscall.Stat_t
+	if err := syscall.Fstat(int(f.Fd()), &st); err != nil {
+		return false
+	}
+	return st.Mode&syscall.S_IFMT == syscall.S_IFIFO
+}
+
+// spliceable returns the files of src and dst when the data between them can
+// be moved by the kernel
+func spliceable(dst io.Writer, src io.Reader) (*os.File, *os.File, bool) {
+	d, ok := dst.(*os.File)
+	if !ok {
+		return nil, nil, false
+	}
+	s, ok := src.(*os.File)
+	if !ok {
+		return nil, nil, false
+	}
+	if !isPipe(d) || !isPipe(s) || !canSplice() {
+		return nil, nil, false
+	}
+	return d, s, true
+}
+
+// spliceCopy moves the data of the src pipe to the dst pipe without copying
+// it to userspace, it returns the number of bytes moved
+func spliceCopy(dst, src *os.File) (int64, error) {
+	var written int64
+	for {
+		n, err := syscall.Splice(int(src.Fd()), nil, int(dst.Fd()), nil, spliceSize, spliceMove)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || n == 0 {
+			return written, err
+		}
+		written += n
+	}
+}
+
+// teeCopy duplicates the data of the src pipe to the dst pipe in the kernel
+// and then reads it from src for the writers that need it in userspace, it
+// returns the number of bytes copied
+func teeCopy(dst, src *os.File, w io.Writer) (int64, error) {
+	var (
+		written int64
+		buf     = make([]byte, spliceSize)
+	)
+	for {
+		n, err := syscall.Tee(int(src.Fd()), int(dst.Fd()), spliceSize, 0)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil || n == 0 {
+			return written, err
+		}
+		if _, err := io.ReadFull(src, buf[:n]); err != nil {
+			return written, err
+		}
+		w.Write(buf[:n])
+		written += n
+	}
+}
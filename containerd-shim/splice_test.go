@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTeeCopy(t *testing.T) {
+	if !probeSplice() {
+		t.Skip("splice is not supported")
+	}
+	sr, sw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dr, dw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("output\n"), 1024)
+	go func() {
+		sw.Write(data)
+		sw.Close()
+	}()
+	done := make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(dr)
+		done <- b
+	}()
+	buf := &bytes.Buffer{}
+	n, err := teeCopy(dw, sr, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dw.Close()
+	if n != int64(len(data)) {
+		t.Fatalf("expected %d bytes copied but received %d", len(data), n)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("expected the writer to receive the output")
+	}
+	if !bytes.Equal(<-done, data) {
+		t.Fatal("expected the destination pipe to receive the output")
+	}
+}
+
+func TestSpliceCopy(t *testing.T) {
+	if !probeSplice() {
+		t.Skip("splice is not supported")
+	}
+	sr, sw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dr, dw, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		sw.Write([]byte("stdin"))
+		sw.Close()
+	}()
+	done := make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(dr)
+		done <- b
+	}()
+	if _, err := spliceCopy(dw, sr); err != nil {
+		t.Fatal(err)
+	}
+	dw.Close()
+	if b := <-done; string(b) != "stdin" {
+		t.Fatalf("expected stdin but received %q", b)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// stdioSocketFd is the fd of the socket passed by containerd in place of the
+// stdio fifos
+const stdioSocketFd = 3
+
+// frameTimeout is how long the rest of a frame that was partially written to
+// the stdio socket may take before the connection is closed
+const frameTimeout = time.Second
+
+var errFrameTimeout = errors.New("shim: timed out writing a frame to the stdio socket")
+
+// stdioSocket is containerd's connection to the process' stdio.  The frames of
+// the output are written to it and stdin frames are read from it.  The shim
+// keeps the process' side of the stdio when containerd goes away, a restarted
+// containerd takes it over by connecting to runtime.StdioSocketFile in the
+// process' directory.
+type stdioSocket struct {
+	mu sync.Mutex
+	// f is the connection whose fd is written to, it is nil when there is
+	// no connection
+	f  *os.File
+	fd int
+}
+
+// newStdioSocket returns the connection passed by containerd as fd 3.  It is
+// made non-blocking so that a containerd that does not read the output never
+// stalls the process.
+func newStdioSocket() (*stdioSocket, error) {
+	// the socket must not leak into the runtime or the container
+	syscall.CloseOnExec(stdioSocketFd)
+	if err := syscall.SetNonblock(stdioSocketFd, true); err != nil {
+		return nil, err
+	}
+	return &stdioSocket{
+		f:  os.NewFile(stdioSocketFd, "stdio"),
+		fd: stdioSocketFd,
+	}, nil
+}
+
+// Write writes a frame to the connection without blocking.  A frame is
+// dropped when the connection is full, but a frame that was partially written
+// must be completed or containerd loses the framing so the connection is
+// closed if the rest cannot be written in time.  Write never fails so that
+// io.MultiWriter keeps copying the output to the other writers.
+func (s *stdioSocket) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return len(p), nil
+	}
+	n, _ := syscall.Write(s.fd, p)
+	if n > 0 && n < len(p) {
+		if err := writeFrameRest(s.fd, p[n:]); err != nil {
+			logrus.WithField("error", err).Warn("shim: close stdio socket")
+			s.f.Close()
+			s.f = nil
+		}
+	}
+	return len(p), nil
+}
+
+func writeFrameRest(fd int, p []byte) error {
+	deadline := time.Now().Add(frameTimeout)
+	for len(p) > 0 {
+		n, err := syscall.Write(fd, p)
+		if n > 0 {
+			p = p[n:]
+			continue
+		}
+		if err != syscall.EAGAIN && err != syscall.EINTR {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return errFrameTimeout
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+// serve copies the stdin frames of the connection to stdin and accepts the
+// connections of a restarted containerd, a new connection replaces the
+// current one
+func (s *stdioSocket) serve(stdin io.Writer) error {
+	l, err := listenStdioSocket()
+	if err != nil {
+		return err
+	}
+	go copyStdin(stdin, s.f)
+	go func() {
+		for {
+			fd, _, err := syscall.Accept4(l, syscall.SOCK_NONBLOCK|syscall.SOCK_CLOEXEC)
+			if err == syscall.EINTR {
+				continue
+			}
+			if err != nil {
+				logrus.WithField("error", err).Error("shim: accept stdio socket connection")
+				return
+			}
+			f := os.NewFile(uintptr(fd), "stdio")
+			s.mu.Lock()
+			if s.f != nil {
+				// the reader of the previous connection returns
+				s.f.Close()
+			}
+			s.f, s.fd = f, fd
+			s.mu.Unlock()
+			logrus.Info("shim: containerd took over the stdio socket")
+			go copyStdin(stdin, f)
+		}
+	}()
+	return nil
+}
+
+// listenStdioSocket listens on runtime.StdioSocketFile in the cwd, only the
+// daemon's user can connect to it
+func listenStdioSocket() (int, error) {
+	fd, err := syscall.Socket(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	os.Remove(runtime.StdioSocketFile)
+	if err := syscall.Bind(fd, &syscall.SockaddrUnix{Name: runtime.StdioSocketFile}); err != nil {
+		syscall.Close(fd)
+		return -1, err
+	}
+	if err := os.Chmod(runtime.StdioSocketFile, 0600); err != nil {
+		syscall.Close(fd)
+		return -1, err
+	}
+	if err := syscall.Listen(fd, 1); err != nil {
+		syscall.Close(fd)
+		return -1, err
+	}
+	return fd, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/mux"
+	"github.com/docker/containerd/runtime"
+)
+
+// syncBuffer is the stdin of the process
+type syncBuffer struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	return len(p), nil
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+func TestStdioSocketTakeover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stdio-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.SetNonblock(fds[1], true); err != nil {
+		t.Fatal(err)
+	}
+	old := os.NewFile(uintptr(fds[0]), "containerd")
+	defer old.Close()
+	s := &stdioSocket{f: os.NewFile(uintptr(fds[1]), "stdio"), fd: fds[1]}
+	stdin := &syncBuffer{}
+	if err := s.serve(stdin); err != nil {
+		t.Fatal(err)
+	}
+
+	// a restarted containerd connects to the socket in the process' directory
+	conn, err := net.Dial("unix", runtime.StdioSocketFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := mux.New(conn).Stream(mux.Stdin).Write([]byte("input")); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; stdin.String() != "input"; i++ {
+		if i == 100 {
+			t.Fatalf("expected the stdin of the new connection but received %q", stdin.String())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the output goes to the new connection and the previous one is closed
+	if _, err := mux.New(s).Stream(mux.Stdout).Write([]byte("output")); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	stream, data, err := mux.ReadFrame(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream != mux.Stdout || string(data) != "output" {
+		t.Fatalf("expected the output on stdout but received %q on %s", data, stream)
+	}
+	old.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := old.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("expected the previous connection to be closed but received %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+)
+
+const (
+	// activationPrefix is the prefix of the listen address that serves the
+	// api on the sockets passed by systemd socket activation
+	activationPrefix = "fd://"
+	// listenFdsStart is the first fd passed by systemd
+	listenFdsStart = 3
+)
+
+var errNoActivationSockets = errors.New("containerd: no sockets were passed by socket activation")
+
+// listeners returns the listeners for the api address.  An fd:// address uses
+// every socket passed by systemd and an fd://name address only the sockets
+// with the name in their FileDescriptorName.
+func listeners(address string) ([]net.Listener, error) {
+	if !strings.HasPrefix(address, activationPrefix) {
+		if err := os.RemoveAll(address); err != nil {
+			return nil, err
+		}
+		l, err := net.Listen(defaultListenType, address)
+		if err != nil {
+			return nil, err
+		}
+		return []net.Listener{l}, nil
+	}
+	return activationListeners(strings.TrimPrefix(address, activationPrefix))
+}
+
+// activationListeners returns listeners for the sockets passed with the
+// LISTEN_FDS protocol.  The environment is cleared so that it is not passed
+// to the shims and the passed fds are closed once they are wrapped in
+// listeners, sockets that are not selected by name are closed too.
+func activationListeners(name string) ([]net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errNoActivationSockets
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, errNoActivationSockets
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+	for _, e := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		os.Unsetenv(e)
+	}
+	var out []net.Listener
+	for i := 0; i < n; i++ {
+		fd := listenFdsStart + i
+		fdName := ""
+		if i < len(names) {
+			fdName = names[i]
+		}
+		f := os.NewFile(uintptr(fd), fdName)
+		if name != "" && fdName != name {
+			f.Close()
+			continue
+		}
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			// datagram and other non stream sockets cannot serve the api
+			logrus.WithFields(logrus.Fields{
+				"fd":    fd,
+				"name":  fdName,
+				"error": err,
+			}).Warn("containerd: skip socket passed by socket activation")
+			continue
+		}
+		out = append(out, l)
+	}
+	if len(out) == 0 {
+		if name != "" {
+			return nil, fmt.Errorf("containerd: no socket named %q was passed by socket activation", name)
+		}
+		return nil, errNoActivationSockets
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/docker/containerd/api/grpc/types"
+	"google.golang.org/grpc"
+)
+
+var errLocalListenerClosed = errors.New("containerd: local listener is closed")
+
+// localListener accepts in-process connections over socketpairs so that the
+// daemon can be a client of its own grpc api whatever address it listens on
+type localListener struct {
+	conns  chan net.Conn
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newLocalListener() *localListener {
+	return &localListener{
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (l *localListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, errLocalListenerClosed
+	}
+}
+
+func (l *localListener) Close() error {
+	l.once.Do(func() {
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *localListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: "local", Net: "unix"}
+}
+
+// dial returns one end of a new socketpair and hands the other to Accept
+func (l *localListener) dial(addr string, timeout time.Duration) (net.Conn, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	client, err := fileConn(fds[0])
+	if err != nil {
+		syscall.Close(fds[1])
+		return nil, err
+	}
+	server, err := fileConn(fds[1])
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.closed:
+		client.Close()
+		server.Close()
+		return nil, errLocalListenerClosed
+	}
+}
+
+func fileConn(fd int) (net.Conn, error) {
+	f := os.NewFile(uintptr(fd), "local")
+	defer f.Close()
+	return net.FileConn(f)
+}
+
+// localClient serves the grpc server on a local listener and returns a client
+// connected to it
+func localClient(s *grpc.Server) (types.APIClient, error) {
+	l := newLocalListener()
+	go s.Serve(l)
+	conn, err := grpc.Dial("local", grpc.WithInsecure(), grpc.WithDialer(l.dial))
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return types.NewAPIClient(conn), nil
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -14,10 +15,18 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/codegangsta/cli"
 	"github.com/docker/containerd"
+	"github.com/docker/containerd/api/grpc/reflection"
 	"github.com/docker/containerd/api/grpc/server"
 	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/api/http/docker"
+	"github.com/docker/containerd/api/http/healthz"
+	"github.com/docker/containerd/api/http/rest"
+	"github.com/docker/containerd/hooks"
+	"github.com/docker/containerd/ipam"
 	"github.com/docker/containerd/osutils"
+	"github.com/docker/containerd/runtime"
 	"github.com/docker/containerd/supervisor"
+	"github.com/docker/containerd/volumes"
 )
 
 const (
@@ -28,13 +37,36 @@ const (
 var daemonFlags = []cli.Flag{
 	cli.BoolFlag{
 		Name:  "debug",
-		Usage: "enable debug output in the logs",
+		Usage: "enable debug output in the logs, same as --log-level=debug",
+	},
+	cli.StringFlag{
+		Name:  "log-level",
+		Value: "info",
+		Usage: "level of the logs: debug, info, warning, error or fatal",
+	},
+	cli.StringFlag{
+		Name:  "log-format",
+		Value: "text",
+		Usage: "format of the logs: text or json",
+	},
+	cli.StringSliceFlag{
+		Name:  "log-level-override",
+		Value: &cli.StringSlice{},
+		Usage: "log level of a subsystem (supervisor, runtime, api or metrics) as subsystem=level",
 	},
 	cli.StringFlag{
 		Name:  "state-dir",
 		Value: defaultStateDir,
 		Usage: "runtime state directory",
 	},
+	cli.BoolFlag{
+		Name:  "migrate-dry-run",
+		Usage: "print the migrations the state directory needs to be upgraded to this version and exit",
+	},
+	cli.StringFlag{
+		Name:  "migrate-backup-dir",
+		Usage: "directory the state directory is archived in before it is migrated, <state-dir>/" + supervisor.MigrationBackupsDir + " by default",
+	},
 	cli.DurationFlag{
 		Name:  "metrics-interval",
 		Value: 5 * time.Minute,
@@ -43,7 +75,15 @@ var daemonFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "listen,l",
 		Value: defaultGRPCEndpoint,
-		Usage: "Address on which GRPC API will listen",
+		Usage: "Address on which GRPC API will listen, fd:// or fd://<name> serves on the sockets passed by systemd socket activation",
+	},
+	cli.BoolFlag{
+		Name:  "audit",
+		Usage: "log every rpc with the pid, uid and gid of the client that connected to the unix socket to the audit subsystem",
+	},
+	cli.BoolTFlag{
+		Name:  "grpc-reflection",
+		Usage: "serve grpc server reflection on the api for tools such as grpcurl, --grpc-reflection=false disables it",
 	},
 	cli.StringFlag{
 		Name:  "runtime,r",
@@ -55,9 +95,93 @@ var daemonFlags = []cli.Flag{
 		Value: &cli.StringSlice{},
 		Usage: "specify additional runtime args",
 	},
+	cli.StringFlag{
+		Name:  "debug-addr",
+		Usage: "http address to serve pprof, expvar and goroutine stacks on for debugging",
+	},
 	cli.StringFlag{
 		Name:  "pprof-address",
-		Usage: "http address to listen for pprof events",
+		Usage: "deprecated alias of --debug-addr",
+	},
+	cli.StringFlag{
+		Name:  "cpuset-policy",
+		Value: "none",
+		Usage: "rebalance the cpusets of containers as they come and go: none, spread, pack or exclusive",
+	},
+	cli.StringFlag{
+		Name:  "crash-dir",
+		Value: "/var/lib/containerd/crash",
+		Usage: "directory to write crash reports with the goroutine stacks, supervisor state and last events to when the daemon panics, empty disables them",
+	},
+	cli.StringFlag{
+		Name:  "healthz-addr",
+		Usage: "http address to serve /healthz on for liveness probes",
+	},
+	cli.StringSliceFlag{
+		Name:  "hook-plugin",
+		Value: &cli.StringSlice{},
+		Usage: "binary or unix:// socket called with pre-create, post-start, pre-stop and post-delete events of containers, plugins are called in order",
+	},
+	cli.DurationFlag{
+		Name:  "hook-timeout",
+		Value: hooks.DefaultTimeout,
+		Usage: "time a hook plugin has to answer",
+	},
+	cli.StringSliceFlag{
+		Name:  "volume-driver",
+		Value: &cli.StringSlice{},
+		Usage: "volume driver plugin as name=unix:///path, containers are created with the volumes it provisions",
+	},
+	cli.StringFlag{
+		Name:  "oci-hooks",
+		Usage: "json file of prestart, poststart and poststop hooks added to the spec of every container, containers labeled " + supervisor.OCIHooksOptOutLabel + " opt out",
+	},
+	cli.StringFlag{
+		Name:  "docker-api-addr",
+		Usage: "unix:// socket or loopback http address to serve a subset of the Docker Engine API on, images are the paths to OCI bundles",
+	},
+	cli.StringFlag{
+		Name:  "docker-api-root",
+		Value: "/var/lib/containerd/docker",
+		Usage: "directory the logs of containers created through the Docker Engine API are kept in",
+	},
+	cli.StringFlag{
+		Name:  "bundle-root",
+		Value: "/var/lib/containerd/bundles",
+		Usage: "directory bundles uploaded over the api are unpacked in",
+	},
+	cli.StringFlag{
+		Name:  "rest-api-addr",
+		Usage: "unix:// socket or loopback http address to serve a json translation of the grpc api and its OpenAPI definitions on",
+	},
+	cli.BoolFlag{
+		Name:  "trace",
+		Usage: "log trace spans of rpcs, supervisor tasks and runtime calls",
+	},
+	cli.DurationFlag{
+		Name:  "slow-threshold",
+		Usage: "log a warning with the phase timings of rpcs and supervisor tasks that take longer than the threshold, 0 disables it",
+	},
+	cli.DurationFlag{
+		Name:  "check-interval",
+		Usage: "interval of the checks of the containers against their state directories, cgroups, shims and namespaces, 0 disables them",
+	},
+	cli.BoolFlag{
+		Name:  "check-clean",
+		Usage: "clean the discrepancies found by the periodic checks that can be cleaned",
+	},
+	cli.StringFlag{
+		Name:  "quotas",
+		Usage: "json file of the quotas of the namespaces: containers, memory, cpus, checkpoints and bundleDisk",
+	},
+	cli.StringSliceFlag{
+		Name:  "sriov-pf",
+		Value: &cli.StringSlice{},
+		Usage: "SR-IOV physical function whose virtual functions are allocated to the containers requesting one",
+	},
+	cli.StringFlag{
+		Name:  "ipam-networks",
+		Usage: "json file of the networks the addresses of the containers are allocated from: subnet, gateway, rangeStart, rangeEnd and plugin",
 	},
 }
 
@@ -75,12 +199,73 @@ func main() {
 	setAppBefore(app)
 
 	app.Action = func(context *cli.Context) {
+		h, err := hooks.New(context.StringSlice("hook-plugin"), context.Duration("hook-timeout"))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		var ociHooks *runtime.OCIHooks
+		if path := context.String("oci-hooks"); path != "" {
+			if ociHooks, err = runtime.LoadOCIHooks(path); err != nil {
+				logrus.Fatal(err)
+			}
+		}
+		drivers, err := volumes.New(context.StringSlice("volume-driver"), volumes.DefaultTimeout)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		var quotas map[string]supervisor.Quota
+		if path := context.String("quotas"); path != "" {
+			if quotas, err = supervisor.LoadQuotas(path); err != nil {
+				logrus.Fatal(err)
+			}
+		}
+		var networks map[string]ipam.Network
+		if path := context.String("ipam-networks"); path != "" {
+			if networks, err = ipam.LoadNetworks(path); err != nil {
+				logrus.Fatal(err)
+			}
+		}
+		logRootless()
+		if context.Bool("audit") {
+			server.EnableAudit()
+		}
+		stateDir := pathFlag(context, "state-dir", userRuntimeDir)
+		if err := migrate(stateDir, context.Bool("migrate-dry-run"), context.String("migrate-backup-dir")); err != nil {
+			logrus.Fatal(err)
+		}
+		if context.Bool("migrate-dry-run") {
+			return
+		}
 		if err := daemon(
-			context.String("listen"),
-			context.String("state-dir"),
+			pathFlag(context, "listen", func() string {
+				return filepath.Join(userRuntimeDir(), "containerd.sock")
+			}),
+			stateDir,
 			10,
 			context.String("runtime"),
 			context.StringSlice("runtime-args"),
+			context.String("cpuset-policy"),
+			pathFlag(context, "crash-dir", func() string {
+				return filepath.Join(userDataDir(), "crash")
+			}),
+			context.String("healthz-addr"),
+			context.String("docker-api-addr"),
+			pathFlag(context, "docker-api-root", func() string {
+				return filepath.Join(userDataDir(), "docker")
+			}),
+			context.String("rest-api-addr"),
+			context.BoolT("grpc-reflection"),
+			pathFlag(context, "bundle-root", func() string {
+				return filepath.Join(userDataDir(), "bundles")
+			}),
+			h,
+			ociHooks,
+			drivers,
+			quotas,
+			context.StringSlice("sriov-pf"),
+			networks,
+			context.Duration("check-interval"),
+			context.Bool("check-clean"),
 		); err != nil {
 			logrus.Fatal(err)
 		}
@@ -90,7 +275,31 @@ func main() {
 	}
 }
 
-func daemon(address, stateDir string, concurrency int, runtimeName string, runtimeArgs []string) error {
+// migrate upgrades the state directory to the layout of this version, with
+// dryRun it prints the pending migrations instead
+func migrate(stateDir string, dryRun bool, backupDir string) error {
+	if backupDir == "" {
+		backupDir = filepath.Join(stateDir, supervisor.MigrationBackupsDir)
+	}
+	migrations, err := supervisor.Migrate(stateDir, supervisor.MigrateOptions{
+		DryRun:    dryRun,
+		BackupDir: backupDir,
+	})
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		if len(migrations) == 0 {
+			fmt.Printf("state directory %s is at version %d\n", stateDir, supervisor.FormatVersion)
+		}
+		for _, m := range migrations {
+			fmt.Printf("%d\t%s\n", m.Version, m.Description)
+		}
+	}
+	return nil
+}
+
+func daemon(address, stateDir string, concurrency int, runtimeName string, runtimeArgs []string, cpusetPolicy, crashDir, healthzAddr, dockerAddr, dockerRoot, restAddr string, reflect bool, bundleRoot string, h *hooks.Hooks, ociHooks *runtime.OCIHooks, drivers *volumes.Drivers, quotas map[string]supervisor.Quota, physicalFunctions []string, networks map[string]ipam.Network, checkInterval time.Duration, checkClean bool) error {
 	// setup a standard reaper so that we don't leave any zombies if we are still alive
 	// this is just good practice because we are spawning new processes
 	s := make(chan os.Signal, 2048)
@@ -98,10 +307,22 @@ func daemon(address, stateDir string, concurrency int, runtimeName string, runti
 	if err := osutils.SetSubreaper(1); err != nil {
 		logrus.WithField("error", err).Error("containerd: set subpreaper")
 	}
-	sv, err := supervisor.New(stateDir, runtimeName, runtimeArgs)
+	sv, err := supervisor.New(stateDir, runtimeName, runtimeArgs, cpusetPolicy, crashDir)
 	if err != nil {
 		return err
 	}
+	sv.SetHooks(h)
+	sv.SetOCIHooks(ociHooks)
+	sv.SetVolumeDrivers(drivers)
+	sv.SetBundleRoot(bundleRoot)
+	sv.SetQuotas(quotas)
+	if err := sv.SetPhysicalFunctions(physicalFunctions); err != nil {
+		return err
+	}
+	if err := sv.SetIPAMNetworks(networks); err != nil {
+		return err
+	}
+	defer sv.HandlePanic()
 	wg := &sync.WaitGroup{}
 	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
@@ -111,18 +332,50 @@ func daemon(address, stateDir string, concurrency int, runtimeName string, runti
 	if err := sv.Start(); err != nil {
 		return err
 	}
-	server, err := startServer(address, sv)
+	if checkInterval > 0 {
+		sv.StartChecks(checkInterval, checkClean)
+	}
+	server, err := startServer(address, sv, reflect)
 	if err != nil {
 		return err
 	}
+	if healthzAddr != "" {
+		healthz.Enable(healthzAddr, sv)
+	}
+	if dockerAddr != "" {
+		c, err := localClient(server)
+		if err != nil {
+			return err
+		}
+		if err := docker.Enable(dockerAddr, dockerRoot, c); err != nil {
+			return err
+		}
+	}
+	if restAddr != "" {
+		c, err := localClient(server)
+		if err != nil {
+			return err
+		}
+		if err := rest.Enable(restAddr, c); err != nil {
+			return err
+		}
+	}
+	// the containers were restored by supervisor.New and the api is served
+	notify("READY=1")
+	startWatchdog(sv)
 	for ss := range s {
 		switch ss {
 		case syscall.SIGCHLD:
-			if _, err := osutils.Reap(); err != nil {
+			exits, err := osutils.Reap()
+			if err != nil {
 				logrus.WithField("error", err).Warn("containerd: reap child processes")
 			}
+			for _, e := range exits {
+				runtime.Reaped(e.Pid, e.Status)
+			}
 		default:
 			logrus.Infof("stopping containerd after receiving %s", ss)
+			notify("STOPPING=1")
 			server.Stop()
 			os.Exit(0)
 		}
@@ -130,22 +383,27 @@ func daemon(address, stateDir string, concurrency int, runtimeName string, runti
 	return nil
 }
 
-func startServer(address string, sv *supervisor.Supervisor) (*grpc.Server, error) {
-	if err := os.RemoveAll(address); err != nil {
-		return nil, err
-	}
-	l, err := net.Listen(defaultListenType, address)
+func startServer(address string, sv *supervisor.Supervisor, reflect bool) (*grpc.Server, error) {
+	ls, err := listeners(address)
 	if err != nil {
 		return nil, err
 	}
-	s := grpc.NewServer()
-	types.RegisterAPIServer(s, server.NewServer(sv))
-	go func() {
-		logrus.Debugf("containerd: grpc api on %s", address)
-		if err := s.Serve(l); err != nil {
-			logrus.WithField("error", err).Fatal("containerd: serve grpc")
+	s := grpc.NewServer(grpc.Creds(server.PeerCreds()))
+	server.Register(s, server.NewServer(sv))
+	if reflect {
+		gz, _ := (&types.CreateContainerRequest{}).Descriptor()
+		if err := reflection.Register(s, gz); err != nil {
+			return nil, err
 		}
-	}()
+	}
+	for _, l := range ls {
+		go func(l net.Listener) {
+			logrus.Debugf("containerd: grpc api on %s", l.Addr())
+			if err := s.Serve(l); err != nil {
+				logrus.WithField("error", err).Fatal("containerd: serve grpc")
+			}
+		}(l)
+	}
 	return s, nil
 }
 
@@ -4,7 +4,7 @@ import (
 	"log"
 	"net"
 	"os"
-	"runtime"
+	goruntime "runtime"
 	"syscall"
 	"time"
 
@@ -12,9 +12,13 @@ import (
 	"github.com/cloudfoundry/gosigar"
 	"github.com/codegangsta/cli"
 	"github.com/cyberdelia/go-metrics-graphite"
+	"github.com/docker/containerd/api/grpc/server"
 	"github.com/docker/containerd/api/http/pprof"
+	"github.com/docker/containerd/logging"
 	"github.com/docker/containerd/osutils"
+	"github.com/docker/containerd/runtime"
 	"github.com/docker/containerd/supervisor"
+	"github.com/docker/containerd/tracing"
 	"github.com/rcrowley/go-metrics"
 )
 
@@ -28,13 +32,25 @@ func appendPlatformFlags() {
 	daemonFlags = append(daemonFlags, cli.StringFlag{
 		Name:  "graphite-address",
 		Usage: "Address of graphite server",
+	}, cli.IntFlag{
+		Name:  "shim-pool-size",
+		Usage: "number of idle shims kept started to hand new containers and execs to, 0 disables the pool",
+	}, cli.StringFlag{
+		Name:  "routed-uplink",
+		Usage: "host interface that answers ARP and NDP for the addresses of the containers' routed interfaces",
 	})
 }
 
 func setAppBefore(app *cli.App) {
 	app.Before = func(context *cli.Context) error {
+		level := context.GlobalString("log-level")
+		if context.GlobalBool("debug") {
+			level = "debug"
+		}
+		if err := logging.Configure(level, context.GlobalString("log-format"), context.GlobalStringSlice("log-level-override")); err != nil {
+			return err
+		}
 		if context.GlobalBool("debug") {
-			logrus.SetLevel(logrus.DebugLevel)
 			if context.GlobalDuration("metrics-interval") > 0 {
 				if err := debugMetrics(context.GlobalDuration("metrics-interval"), context.GlobalString("graphite-address")); err != nil {
 					return err
@@ -42,9 +58,25 @@ func setAppBefore(app *cli.App) {
 			}
 
 		}
-		if p := context.GlobalString("pprof-address"); len(p) > 0 {
+		p := context.GlobalString("debug-addr")
+		if p == "" {
+			p = context.GlobalString("pprof-address")
+		}
+		if len(p) > 0 {
 			pprof.Enable(p)
 		}
+		if context.GlobalBool("trace") {
+			tracing.Enable()
+		}
+		if n := context.GlobalInt("shim-pool-size"); n > 0 {
+			runtime.EnableShimPool(n)
+		}
+		if uplink := context.GlobalString("routed-uplink"); uplink != "" {
+			if err := runtime.EnableRouting(uplink); err != nil {
+				return err
+			}
+		}
+		tracing.SetSlowThreshold(context.GlobalDuration("slow-threshold"))
 		if err := checkLimits(); err != nil {
 			return err
 		}
@@ -79,7 +111,7 @@ func processMetrics() {
 	metrics.DefaultRegistry.Register("memory-used", memg)
 	collect := func() {
 		// update number of goroutines
-		g.Update(int64(runtime.NumGoroutine()))
+		g.Update(int64(goruntime.NumGoroutine()))
 		// collect the number of open fds
 		fds, err := osutils.GetOpenFds(os.Getpid())
 		if err != nil {
@@ -107,6 +139,11 @@ func debugMetrics(interval time.Duration, graphiteAddr string) error {
 			return err
 		}
 	}
+	for name, m := range server.Metrics() {
+		if err := metrics.DefaultRegistry.Register(name, m); err != nil {
+			return err
+		}
+	}
 	processMetrics()
 	if graphiteAddr != "" {
 		addr, err := net.ResolveTCPAddr("tcp", graphiteAddr)
@@ -115,7 +152,7 @@ func debugMetrics(interval time.Duration, graphiteAddr string) error {
 		}
 		go graphite.Graphite(metrics.DefaultRegistry, 10e9, "metrics", addr)
 	} else {
-		l := log.New(os.Stdout, "[containerd] ", log.LstdFlags)
+		l := log.New(logging.Writer("metrics"), "", 0)
 		go metrics.Log(metrics.DefaultRegistry, interval, l)
 	}
 	return nil
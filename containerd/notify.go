@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/supervisor"
+)
+
+// notify sends the state to systemd when the daemon runs in a Type=notify
+// service, it does nothing otherwise
+func notify(state string) {
+	path := os.Getenv("NOTIFY_SOCKET")
+	if path == "" {
+		return
+	}
+	addr := &net.UnixAddr{
+		Name: path,
+		Net:  "unixgram",
+	}
+	// abstract sockets are passed with an @ in place of the leading NUL
+	if path[0] == '@' {
+		addr.Name = "\x00" + path[1:]
+	}
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err != nil {
+		logrus.WithField("error", err).Warn("containerd: connect to systemd notify socket")
+		return
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		logrus.WithField("error", err).Warn("containerd: notify systemd")
+	}
+}
+
+// watchdogInterval returns the interval of the systemd watchdog or 0 if the
+// watchdog is not enabled for the daemon
+func watchdogInterval() time.Duration {
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// startWatchdog sends a heartbeat to systemd each time the supervisor's event
+// loop handles a task within the interval so that systemd restarts a daemon
+// whose event loop is stuck
+func startWatchdog(sv *supervisor.Supervisor) {
+	interval := watchdogInterval()
+	if interval == 0 {
+		return
+	}
+	// heartbeats are sent twice per interval as recommended by systemd
+	interval /= 2
+	go func() {
+		for range time.Tick(interval) {
+			if err := sv.Ping(interval); err != nil {
+				logrus.WithField("error", err).Warn("containerd: skip watchdog heartbeat")
+				continue
+			}
+			notify("WATCHDOG=1")
+		}
+	}()
+}
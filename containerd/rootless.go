@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/runtime"
+)
+
+// pathFlag returns the value of the flag.  An unprivileged daemon that was
+// not given the flag uses the path below the user's runtime or data directory
+// instead of the system default it cannot write to.
+func pathFlag(context *cli.Context, name string, rootless func() string) string {
+	if !runtime.Rootless() || context.IsSet(name) {
+		return context.String(name)
+	}
+	return rootless()
+}
+
+// userRuntimeDir returns the directory of containerd in $XDG_RUNTIME_DIR
+func userRuntimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "containerd")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("containerd-%d", os.Geteuid()))
+}
+
+// userDataDir returns the directory of containerd in $XDG_DATA_HOME
+func userDataDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "containerd")
+	}
+	return filepath.Join(os.Getenv("HOME"), ".local", "share", "containerd")
+}
+
+func logRootless() {
+	if runtime.Rootless() {
+		logrus.WithFields(logrus.Fields{
+			"uid":              os.Geteuid(),
+			"cgroupsDelegated": runtime.CgroupsDelegated(),
+		}).Info("containerd: running unprivileged, containers get a user namespace")
+	}
+}
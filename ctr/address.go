@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var addressCommand = cli.Command{
+	Name:  "address",
+	Usage: "add, remove or list the labelled addresses of a running container",
+	Subcommands: []cli.Command{
+		{
+			Name:  "add",
+			Usage: "add a labelled secondary IPv4 address to an interface of a container",
+			Action: func(context *cli.Context) {
+				id, label, address := context.Args().Get(0), context.Args().Get(1), context.Args().Get(2)
+				if id == "" {
+					fatal("container id cannot be empty", 1)
+				}
+				if label == "" || address == "" {
+					fatal("a label such as eth0:meta and an address in CIDR notation are required", 1)
+				}
+				// the label of the address begins with the name of its interface
+				iface := strings.SplitN(label, ":", 2)[0]
+				c := getClient(context)
+				if _, err := c.AddAddress(netcontext.Background(), &types.AddAddressRequest{
+					Id:        id,
+					Interface: iface,
+					Label:     label,
+					Address:   address,
+				}); err != nil {
+					fatal(err.Error(), 1)
+				}
+			},
+		},
+		{
+			Name:  "remove",
+			Usage: "remove the addresses with a label from a container",
+			Action: func(context *cli.Context) {
+				id, label := context.Args().Get(0), context.Args().Get(1)
+				if id == "" {
+					fatal("container id cannot be empty", 1)
+				}
+				if label == "" {
+					fatal("label cannot be empty", 1)
+				}
+				c := getClient(context)
+				resp, err := c.DeleteAddresses(netcontext.Background(), &types.DeleteAddressesRequest{
+					Id:    id,
+					Label: label,
+				})
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				printAddresses(resp.Addresses)
+			},
+		},
+		{
+			Name:  "list",
+			Usage: "list the addresses of the interfaces of a container",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "label",
+					Usage: "only list the addresses with the label",
+				},
+			},
+			Action: func(context *cli.Context) {
+				id := context.Args().First()
+				if id == "" {
+					fatal("container id cannot be empty", 1)
+				}
+				c := getClient(context)
+				resp, err := c.ListAddresses(netcontext.Background(), &types.ListAddressesRequest{
+					Id:    id,
+					Label: context.String("label"),
+				})
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				printAddresses(resp.Addresses)
+			},
+		},
+	},
+}
+
+func printAddresses(addrs []*types.InterfaceAddress) {
+	w := tabwriter.NewWriter(os.Stdout, 10, 1, 3, ' ', 0)
+	fmt.Fprint(w, "INTERFACE\tLABEL\tADDRESS\n")
+	for _, a := range addrs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", a.Interface, a.Label, a.Address)
+	}
+	if err := w.Flush(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
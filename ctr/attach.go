@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/mux"
+	"github.com/docker/docker/pkg/term"
+	netcontext "golang.org/x/net/context"
+)
+
+var attachCommand = cli.Command{
+	Name:  "attach",
+	Usage: "attach to the stdio of a running process over the containerd api",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pid,p",
+			Usage: "process id of the process to attach to, defaults to init",
+		},
+		cli.IntFlag{
+			Name:  "replay",
+			Usage: "number of bytes of recent output to replay on attach",
+		},
+		cli.BoolFlag{
+			Name:  "tty,t",
+			Usage: "put the local terminal in raw mode for a process with a terminal",
+		},
+		cli.BoolFlag{
+			Name:  "no-stdin",
+			Usage: "do not forward stdin to the process",
+		},
+		cli.StringFlag{
+			Name:  "detach-keys",
+			Value: defaultDetachKeys,
+			Usage: "key sequence for detaching from the process",
+		},
+	},
+	Action: func(context *cli.Context) {
+		var (
+			id  = context.Args().First()
+			pid = context.String("pid")
+			tty = context.Bool("tty") && term.IsTerminal(os.Stdin.Fd())
+		)
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		if pid == "" {
+			pid = "init"
+		}
+		detachKeys, err := term.ToBytes(context.String("detach-keys"))
+		if err != nil {
+			fatal(fmt.Sprintf("invalid detach keys: %v", err), 1)
+		}
+		attachProcess(getClient(context), id, pid, context.Int("replay"), tty, !context.Bool("no-stdin"), detachKeys)
+	},
+}
+
+// attachProcess streams the stdio of a process over the Attach RPC until the
+// process exits or the detach keys are read and then exits ctr
+func attachProcess(c types.APIClient, id, pid string, replay int, tty, stdin bool, detachKeys []byte) {
+	stream, err := c.Attach(netcontext.Background())
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	if err := stream.Send(&types.AttachRequest{
+		Id:     id,
+		Pid:    pid,
+		Replay: uint32(replay),
+	}); err != nil {
+		fatal(err.Error(), 1)
+	}
+	var state *term.State
+	restore := func() {
+		if state != nil {
+			term.RestoreTerminal(os.Stdin.Fd(), state)
+		}
+	}
+	if tty {
+		if state, err = term.SetRawTerminal(os.Stdin.Fd()); err != nil {
+			fatal(err.Error(), 1)
+		}
+		if err := resize(id, pid, c); err != nil {
+			log.Println(err)
+		}
+		go func() {
+			s := make(chan os.Signal, 64)
+			signal.Notify(s, syscall.SIGWINCH)
+			for range s {
+				if err := resize(id, pid, c); err != nil {
+					log.Println(err)
+				}
+			}
+		}()
+	}
+	if stdin {
+		go func() {
+			err := sendStdin(stream, newDetachReader(os.Stdin, detachKeys))
+			if err == errDetached {
+				restore()
+				os.Exit(0)
+			}
+			if err != nil {
+				log.Println(err)
+			}
+		}()
+	}
+	for {
+		r, err := stream.Recv()
+		if err != nil {
+			restore()
+			if err == io.EOF {
+				os.Exit(0)
+			}
+			fatal(err.Error(), 1)
+		}
+		w := os.Stdout
+		if mux.Stream(r.Stream) == mux.Stderr {
+			w = os.Stderr
+		}
+		w.Write(r.Data)
+	}
+}
+
+// sendStdin sends everything read from r to the attached process and closes
+// the process' stdin once r returns io.EOF
+func sendStdin(stream types.API_AttachClient, r io.Reader) error {
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.AttachRequest{Stdin: buf[:n]}); serr != nil {
+				return serr
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			return stream.Send(&types.AttachRequest{CloseStdin: true})
+		}
+	}
+}
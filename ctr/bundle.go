@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/archive"
+	"github.com/docker/containerd/client"
+	netcontext "golang.org/x/net/context"
+)
+
+var bundlesCommand = cli.Command{
+	Name:  "bundles",
+	Usage: "interact with the bundles uploaded to the daemon",
+	Subcommands: []cli.Command{
+		uploadBundleCommand,
+	},
+}
+
+var uploadBundleCommand = cli.Command{
+	Name:  "upload",
+	Usage: "upload a bundle to the daemon and print its id",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "lease",
+			Usage: "add the bundle to the lease so that it is not collected before the lease is deleted",
+		},
+	},
+	Action: func(context *cli.Context) {
+		path := context.Args().First()
+		if path == "" {
+			fatal("bundle path cannot be empty", 1)
+		}
+		id, err := uploadBundle(getClient(context), path, context.String("lease"))
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(id)
+	},
+}
+
+// uploadBundle sends the bundle at path to the daemon and returns its id, the
+// bundle is added to the lease unless it is empty
+func uploadBundle(c types.APIClient, path, lease string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rc, err := archive.Tar("/", path)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	ctx := netcontext.Background()
+	if lease != "" {
+		ctx = client.WithLease(ctx, lease)
+	}
+	stream, err := c.UploadBundle(ctx)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.UploadBundleRequest{Data: buf[:n]}); serr != nil {
+				return "", serr
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	return resp.Id, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var capabilitiesCommand = cli.Command{
+	Name:  "capabilities",
+	Usage: "list the features of the daemon and the host",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: func(context *cli.Context) {
+		c := getClient(context)
+		resp, err := c.Capabilities(netcontext.Background(), &types.CapabilitiesRequest{})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		f := context.String("format")
+		if f == "" {
+			f = "json"
+		}
+		printFormatted(f, resp)
+	},
+}
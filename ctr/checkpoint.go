@@ -13,6 +13,9 @@ import (
 var checkpointCommand = cli.Command{
 	Name:  "checkpoints",
 	Usage: "list all checkpoints",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
 	Subcommands: []cli.Command{
 		listCheckpointCommand,
 		createCheckpointCommand,
@@ -22,8 +25,11 @@ var checkpointCommand = cli.Command{
 }
 
 var listCheckpointCommand = cli.Command{
-	Name:   "list",
-	Usage:  "list all checkpoints for a container",
+	Name:  "list",
+	Usage: "list all checkpoints for a container",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
 	Action: listCheckpoints,
 }
 
@@ -41,6 +47,16 @@ func listCheckpoints(context *cli.Context) {
 	if err != nil {
 		fatal(err.Error(), 1)
 	}
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, resp.Checkpoints)
+			return
+		}
+		for _, c := range resp.Checkpoints {
+			printFormatted(f, c)
+		}
+		return
+	}
 	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
 	fmt.Fprint(w, "NAME\tTCP\tUNIX SOCKETS\tSHELL\n")
 	for _, c := range resp.Checkpoints {
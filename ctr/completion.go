@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var completionCommand = cli.Command{
+	Name:  "completion",
+	Usage: "output a shell completion script for bash, zsh or fish",
+	Subcommands: []cli.Command{
+		{
+			Name:   "bash",
+			Usage:  "output the bash completion script",
+			Action: func(context *cli.Context) { fmt.Print(bashCompletion(rootApp(context))) },
+		},
+		{
+			Name:   "zsh",
+			Usage:  "output the zsh completion script",
+			Action: func(context *cli.Context) { fmt.Print(zshCompletion(rootApp(context))) },
+		},
+		{
+			Name:   "fish",
+			Usage:  "output the fish completion script",
+			Action: func(context *cli.Context) { fmt.Print(fishCompletion(rootApp(context))) },
+		},
+		{
+			Name:   "containers",
+			Usage:  "list container ids for use by completion scripts",
+			Action: completeContainers,
+		},
+		{
+			Name:   "checkpoints",
+			Usage:  "list checkpoint names of a container for use by completion scripts",
+			Action: completeCheckpoints,
+		},
+	},
+}
+
+// commands that take a container id as their first argument
+var idCommands = []string{
+	"attach", "close-stdin", "create", "delete", "delete-process", "kill", "list", "logs", "pause", "ps", "restart", "resume", "stats", "stop", "update", "update-spec", "wait", "watch",
+}
+
+func completeContainers(context *cli.Context) {
+	c := getClient(context)
+	resp, err := c.State(netcontext.Background(), &types.StateRequest{})
+	if err != nil {
+		return
+	}
+	for _, c := range resp.Containers {
+		fmt.Println(c.Id)
+	}
+}
+
+func completeCheckpoints(context *cli.Context) {
+	id := context.Args().First()
+	if id == "" {
+		return
+	}
+	c := getClient(context)
+	resp, err := c.ListCheckpoint(netcontext.Background(), &types.ListCheckpointRequest{
+		Id: id,
+	})
+	if err != nil {
+		return
+	}
+	for _, c := range resp.Checkpoints {
+		fmt.Println(c.Name)
+	}
+}
+
+// rootApp returns the top level application for a (sub)command context
+func rootApp(context *cli.Context) *cli.App {
+	for context.Parent() != nil {
+		context = context.Parent()
+	}
+	return context.App
+}
+
+// commandNames returns the names of the provided commands
+func commandNames(commands []cli.Command) []string {
+	var names []string
+	for _, c := range commands {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func bashCompletion(app *cli.App) string {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, `# bash completion for %[1]s
+_%[1]s() {
+	local cur="${COMP_WORDS[COMP_CWORD]}"
+	local cmd="${COMP_WORDS[1]}"
+	local sub="${COMP_WORDS[2]}"
+	if [ "$COMP_CWORD" -eq 1 ]; then
+		COMPREPLY=($(compgen -W "%[2]s" -- "$cur"))
+		return
+	fi
+	case "$cmd" in
+`, app.Name, strings.Join(commandNames(app.Commands), " "))
+	for _, c := range app.Commands {
+		if len(c.Subcommands) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "\t%s)\n", c.Name)
+		fmt.Fprintf(b, "\t\tif [ \"$COMP_CWORD\" -eq 2 ]; then\n")
+		fmt.Fprintf(b, "\t\t\tCOMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(commandNames(c.Subcommands), " "))
+		fmt.Fprintf(b, "\t\t\treturn\n\t\tfi\n\t\t;;\n")
+	}
+	fmt.Fprintf(b, `	esac
+	if [ "$cmd" = "checkpoints" ] && [ "$sub" = "delete" ] && [ "$COMP_CWORD" -eq 4 ]; then
+		COMPREPLY=($(compgen -W "$(%[1]s completion checkpoints "${COMP_WORDS[3]}" 2>/dev/null)" -- "$cur"))
+		return
+	fi
+	case "$sub" in
+	%[2]s)
+		COMPREPLY=($(compgen -W "$(%[1]s completion containers 2>/dev/null)" -- "$cur"))
+		;;
+	*)
+		COMPREPLY=($(compgen -f -- "$cur"))
+		;;
+	esac
+}
+complete -F _%[1]s %[1]s
+`, app.Name, strings.Join(idCommands, "|"))
+	return b.String()
+}
+
+func zshCompletion(app *cli.App) string {
+	return fmt.Sprintf("#compdef %s\n\nautoload -U +X bashcompinit && bashcompinit\n%s", app.Name, bashCompletion(app))
+}
+
+func fishCompletion(app *cli.App) string {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "# fish completion for %s\n", app.Name)
+	fmt.Fprintf(b, "complete -c %s -f -n '__fish_use_subcommand' -a '%s'\n", app.Name, strings.Join(commandNames(app.Commands), " "))
+	for _, c := range app.Commands {
+		if len(c.Subcommands) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "complete -c %s -f -n '__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s' -a '%s'\n",
+			app.Name, c.Name, strings.Join(commandNames(c.Subcommands), " "), strings.Join(commandNames(c.Subcommands), " "))
+	}
+	fmt.Fprintf(b, "complete -c %[1]s -f -n '__fish_seen_subcommand_from containers checkpoints; and __fish_seen_subcommand_from %[2]s' -a '(%[1]s completion containers 2>/dev/null)'\n",
+		app.Name, strings.Join(idCommands, " "))
+	return b.String()
+}
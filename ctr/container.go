@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"text/tabwriter"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/client"
 	"github.com/docker/containerd/specs"
 	"github.com/docker/docker/pkg/term"
 	netcontext "golang.org/x/net/context"
@@ -38,22 +40,43 @@ func getClient(ctx *cli.Context) types.APIClient {
 	if err != nil {
 		fatal(err.Error(), 1)
 	}
-	return types.NewAPIClient(conn)
+	c := client.NewFromConn(conn)
+	unary, stream := client.NamespaceInterceptors(ctx.GlobalString("namespace"))
+	c.Intercept([]client.UnaryClientInterceptor{unary}, []client.StreamClientInterceptor{stream})
+	return c.API()
 }
 
 var containersCommand = cli.Command{
 	Name:  "containers",
 	Usage: "interact with running containers",
+	Flags: []cli.Flag{
+		formatFlag,
+		summaryFlag,
+	},
 	Subcommands: []cli.Command{
+		addressCommand,
+		attachCommand,
+		closeStdinCommand,
+		deleteCommand,
+		deviceCommand,
 		execCommand,
+		freezeCommand,
 		killCommand,
 		listCommand,
+		logsCommand,
 		pauseCommand,
+		psCommand,
+		deleteProcessCommand,
+		restartCommand,
 		resumeCommand,
 		startCommand,
 		statsCommand,
+		stopCommand,
+		thawCommand,
 		watchCommand,
 		updateCommand,
+		updateSpecCommand,
+		waitCommand,
 	},
 	Action: listContainers,
 }
@@ -61,6 +84,9 @@ var containersCommand = cli.Command{
 var stateCommand = cli.Command{
 	Name:  "state",
 	Usage: "get a raw dump of the containerd state",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
 	Action: func(context *cli.Context) {
 		c := getClient(context)
 		resp, err := c.State(netcontext.Background(), &types.StateRequest{
@@ -69,6 +95,10 @@ var stateCommand = cli.Command{
 		if err != nil {
 			fatal(err.Error(), 1)
 		}
+		if f := context.String("format"); f != "" {
+			printFormatted(f, resp)
+			return
+		}
 		data, err := json.Marshal(resp)
 		if err != nil {
 			fatal(err.Error(), 1)
@@ -78,23 +108,58 @@ var stateCommand = cli.Command{
 }
 
 var listCommand = cli.Command{
-	Name:   "list",
-	Usage:  "list all running containers",
+	Name:  "list",
+	Usage: "list all running containers",
+	Flags: []cli.Flag{
+		formatFlag,
+		summaryFlag,
+	},
 	Action: listContainers,
 }
 
+var summaryFlag = cli.BoolFlag{
+	Name:  "summary",
+	Usage: "only request the ids of the processes instead of their specs",
+}
+
+// listPageSize is the number of containers requested at once by list
+const listPageSize = 100
+
 func listContainers(context *cli.Context) {
 	c := getClient(context)
-	resp, err := c.State(netcontext.Background(), &types.StateRequest{
-		Id: context.Args().First(),
-	})
-	if err != nil {
-		fatal(err.Error(), 1)
+	var (
+		containers []*types.Container
+		token      string
+	)
+	for {
+		resp, err := c.State(netcontext.Background(), &types.StateRequest{
+			Id:        context.Args().First(),
+			Summary:   context.Bool("summary"),
+			Limit:     listPageSize,
+			PageToken: token,
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		containers = append(containers, resp.Containers...)
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	sortContainers(containers)
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, containers)
+			return
+		}
+		for _, c := range containers {
+			printFormatted(f, c)
+		}
+		return
 	}
 	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
 	fmt.Fprint(w, "ID\tPATH\tSTATUS\tPROCESSES\n")
-	sortContainers(resp.Containers)
-	for _, c := range resp.Containers {
+	for _, c := range containers {
 		procs := []string{}
 		for _, p := range c.Processes {
 			procs = append(procs, p.Pid)
@@ -124,11 +189,138 @@ var startCommand = cli.Command{
 			Value: &cli.StringSlice{},
 			Usage: "set labels for the container",
 		},
+		cli.StringFlag{
+			Name:  "log-driver",
+			Usage: "log driver used to capture the container's output (json-file, syslog or journald)",
+		},
+		cli.StringSliceFlag{
+			Name:  "log-opt",
+			Value: &cli.StringSlice{},
+			Usage: "set log driver options, e.g. max-size=10m, max-file=3 or max-age=24h",
+		},
+		cli.StringFlag{
+			Name:  "log-mode",
+			Usage: "blocking or non-blocking to drop output instead of stalling the container",
+		},
+		cli.IntFlag{
+			Name:  "log-max-buffer-size",
+			Usage: "size in bytes of the output buffer in non-blocking mode",
+		},
+		cli.StringFlag{
+			Name:  "log-path",
+			Usage: "directory that file based log drivers write to",
+		},
+		cli.BoolFlag{
+			Name:  "stdio-socket",
+			Usage: "pass a socket to the shim for stdio instead of fifos and attach over the api",
+		},
+		cli.BoolFlag{
+			Name:  "stdin-once",
+			Usage: "close the container's stdin after the first client attached with ctr containers attach detaches",
+		},
+		cli.StringFlag{
+			Name:  "numa-nodes",
+			Usage: "NUMA nodes to bind the container's memory to, e.g. 0-1,3",
+		},
+		cli.StringFlag{
+			Name:  "memory-policy",
+			Usage: "memory policy for the NUMA nodes: bind, preferred or interleave",
+		},
+		cli.StringSliceFlag{
+			Name:  "interface",
+			Value: &cli.StringSlice{},
+			Usage: "set the link properties of an interface of the container as name[,mtu=N][,txqueuelen=N][,mac=ADDR]",
+		},
+		cli.StringSliceFlag{
+			Name:  "address",
+			Value: &cli.StringSlice{},
+			Usage: "add an address to an interface of the container's network namespace as INTERFACE,network=NAME to allocate it from the daemon's ipam network or INTERFACE,address=CIDR",
+		},
+		cli.StringSliceFlag{
+			Name:  "route",
+			Value: &cli.StringSlice{},
+			Usage: "add a route to the container's network namespace as destination[,via=GATEWAY][,dev=INTERFACE][,metric=N], the destination is a CIDR or default",
+		},
+		cli.StringFlag{
+			Name:  "routed",
+			Usage: "veth interface of the container whose addresses are routed by the host, requires the daemon's --routed-uplink",
+		},
+		cli.StringFlag{
+			Name:  "vf",
+			Usage: "allocate an SR-IOV virtual function to the container as [pf=PF][,name=NAME][,mac=ADDR][,vlan=N], use name=NAME to allocate from any physical function",
+		},
+		cli.BoolFlag{
+			Name:  "cgroupns",
+			Usage: "run the container in a new cgroup namespace, the bundle's spec is updated",
+		},
+		cli.StringFlag{
+			Name:  "group",
+			Usage: "join the namespaces of the group, the bundle's spec is updated",
+		},
+		cli.StringFlag{
+			Name:  "netns",
+			Usage: "join the named network namespace, the bundle's spec is updated",
+		},
+		cli.StringSliceFlag{
+			Name:  "volume,v",
+			Value: &cli.StringSlice{},
+			Usage: "mount a volume of a driver as driver:name:destination[:ro,ephemeral], the bundle's spec is updated",
+		},
+		cli.StringFlag{
+			Name:  "gpus",
+			Usage: "all or a comma separated list of gpu ids such as nvidia0,nvidia1 to add to the container, the bundle's spec is updated",
+		},
+		cli.BoolFlag{
+			Name:  "upload",
+			Usage: "upload the bundle to the daemon instead of passing its path, with --stdio-socket for daemons on another host",
+		},
+		cli.BoolFlag{
+			Name:  "keep",
+			Usage: "keep the container after it exits so that its spec can be updated, delete it with ctr containers delete",
+		},
+		cli.BoolFlag{
+			Name:  "rm",
+			Usage: "remove the bundle with the container when it exits",
+		},
+		cli.BoolFlag{
+			Name:  "init",
+			Usage: "run the process under a minimal init that reaps zombies and forwards signals",
+		},
+		cli.DurationFlag{
+			Name:  "wait-network",
+			Usage: "delay the start of the process until the container's network namespace has an address, for at most this long",
+		},
+		cli.DurationFlag{
+			Name:  "max-runtime",
+			Usage: "stop the container once it ran for this long",
+		},
+		cli.IntFlag{
+			Name:  "max-runtime-signal",
+			Value: 15,
+			Usage: "signal sent when the maximum runtime passed, the container is killed if it did not exit within 10 seconds",
+		},
+		cli.BoolFlag{
+			Name:  "oom-restart",
+			Usage: "restart the container when it was killed after it ran out of memory",
+		},
+		cli.IntFlag{
+			Name:  "oom-max-restarts",
+			Usage: "number of restarts after running out of memory, 0 restarts the container every time",
+		},
+		cli.IntFlag{
+			Name:  "oom-memory-increment",
+			Usage: "bytes added to the memory limit of the bundle's spec before each restart after running out of memory",
+		},
+		cli.IntFlag{
+			Name:  "oom-memory-cap",
+			Usage: "bytes the memory limit is not raised above",
+		},
 	},
 	Action: func(context *cli.Context) {
 		var (
-			id   = context.Args().Get(0)
-			path = context.Args().Get(1)
+			id       = context.Args().Get(0)
+			path     = context.Args().Get(1)
+			bundleID string
 		)
 		if path == "" {
 			fatal("bundle path cannot be empty", 1)
@@ -140,6 +332,52 @@ var startCommand = cli.Command{
 		if err != nil {
 			fatal(fmt.Sprintf("cannot get the absolute path of the bundle: %v", err), 1)
 		}
+		// the terminal setting is still read from the local bundle
+		requestPath := bpath
+		if context.Bool("upload") {
+			if bundleID, err = uploadBundle(getClient(context), bpath, ""); err != nil {
+				fatal(fmt.Sprintf("cannot upload the bundle: %v", err), 1)
+			}
+			requestPath = ""
+		}
+		if context.Bool("stdio-socket") {
+			c := getClient(context)
+			if _, err := c.CreateContainer(netcontext.Background(), &types.CreateContainerRequest{
+				Id:               id,
+				BundlePath:       requestPath,
+				BundleId:         bundleID,
+				Checkpoint:       context.String("checkpoint"),
+				Labels:           context.StringSlice("label"),
+				LogConfig:        logConfig(context),
+				StdinOnce:        context.Bool("stdin-once"),
+				StdioSocket:      true,
+				Numa:             numaConfig(context),
+				Network:          networkConfig(context),
+				CgroupNamespace:  context.Bool("cgroupns"),
+				Group:            context.String("group"),
+				NetworkNamespace: context.String("netns"),
+				Volumes:          volumes(context),
+				Gpus:             gpus(context),
+				Keep:             context.Bool("keep"),
+				AutoRemove:       context.Bool("rm"),
+				NetworkWait:      uint32(context.Duration("wait-network") / time.Second),
+				Init:             context.Bool("init"),
+				MaxRuntime:       uint32(context.Duration("max-runtime") / time.Second),
+				MaxRuntimeSignal: uint32(context.Int("max-runtime-signal")),
+				OomRestart:       oomRestartPolicy(context),
+			}); err != nil {
+				fatal(err.Error(), 1)
+			}
+			if context.Bool("attach") {
+				tty, err := readTermSetting(bpath)
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				keys, _ := term.ToBytes(defaultDetachKeys)
+				attachProcess(c, id, "init", startReplaySize, tty && term.IsTerminal(os.Stdin.Fd()), true, keys)
+			}
+			return
+		}
 		s, err := createStdio()
 		if err != nil {
 			fatal(err.Error(), 1)
@@ -149,13 +387,30 @@ var startCommand = cli.Command{
 			tty                  bool
 			c                    = getClient(context)
 			r                    = &types.CreateContainerRequest{
-				Id:         id,
-				BundlePath: bpath,
-				Checkpoint: context.String("checkpoint"),
-				Stdin:      s.stdin,
-				Stdout:     s.stdout,
-				Stderr:     s.stderr,
-				Labels:     context.StringSlice("label"),
+				Id:               id,
+				BundlePath:       requestPath,
+				BundleId:         bundleID,
+				Checkpoint:       context.String("checkpoint"),
+				Stdin:            s.stdin,
+				Stdout:           s.stdout,
+				Stderr:           s.stderr,
+				Labels:           context.StringSlice("label"),
+				LogConfig:        logConfig(context),
+				StdinOnce:        context.Bool("stdin-once"),
+				Numa:             numaConfig(context),
+				Network:          networkConfig(context),
+				CgroupNamespace:  context.Bool("cgroupns"),
+				Group:            context.String("group"),
+				NetworkNamespace: context.String("netns"),
+				Volumes:          volumes(context),
+				Gpus:             gpus(context),
+				Keep:             context.Bool("keep"),
+				AutoRemove:       context.Bool("rm"),
+				NetworkWait:      uint32(context.Duration("wait-network") / time.Second),
+				Init:             context.Bool("init"),
+				MaxRuntime:       uint32(context.Duration("max-runtime") / time.Second),
+				MaxRuntimeSignal: uint32(context.Int("max-runtime-signal")),
+				OomRestart:       oomRestartPolicy(context),
 			}
 		)
 		restoreAndCloseStdin = func() {
@@ -220,6 +475,10 @@ var startCommand = cli.Command{
 	},
 }
 
+// startReplaySize is the amount of output replayed when attaching to a process
+// over the api right after starting it so that no early output is missed
+const startReplaySize = 64 * 1024
+
 func resize(id, pid string, c types.APIClient) error {
 	ws, err := term.GetWinsize(os.Stdin.Fd())
 	if err != nil {
@@ -227,7 +486,7 @@ func resize(id, pid string, c types.APIClient) error {
 	}
 	if _, err := c.UpdateProcess(netcontext.Background(), &types.UpdateProcessRequest{
 		Id:     id,
-		Pid:    "init",
+		Pid:    pid,
 		Width:  uint32(ws.Width),
 		Height: uint32(ws.Height),
 	}); err != nil {
@@ -236,6 +495,247 @@ func resize(id, pid string, c types.APIClient) error {
 	return nil
 }
 
+// logConfig returns the log configuration set by the start command's flags
+func logConfig(context *cli.Context) *types.LogConfig {
+	var (
+		driver = context.String("log-driver")
+		mode   = context.String("log-mode")
+	)
+	if driver == "" && mode == "" {
+		return nil
+	}
+	path := context.String("log-path")
+	if path != "" {
+		p, err := filepath.Abs(path)
+		if err != nil {
+			fatal(fmt.Sprintf("cannot get the absolute path of the log directory: %v", err), 1)
+		}
+		path = p
+	}
+	return &types.LogConfig{
+		Driver:        driver,
+		Options:       parseLogOptions(context.StringSlice("log-opt")),
+		Path:          path,
+		Mode:          mode,
+		MaxBufferSize: uint32(context.Int("log-max-buffer-size")),
+	}
+}
+
+// networkConfig returns the network configuration set by the start command's
+// flags
+func networkConfig(context *cli.Context) *types.NetworkConfig {
+	values := context.StringSlice("interface")
+	vf := context.String("vf")
+	addresses := context.StringSlice("address")
+	routes := context.StringSlice("route")
+	routed := context.String("routed")
+	if len(values) == 0 && vf == "" && len(addresses) == 0 && len(routes) == 0 && routed == "" {
+		return nil
+	}
+	n := &types.NetworkConfig{
+		RoutedInterface: routed,
+	}
+	for _, v := range addresses {
+		parts := strings.Split(v, ",")
+		a := &types.AddressConfig{Interface: parts[0]}
+		for _, p := range parts[1:] {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 {
+				fatal(fmt.Sprintf("invalid address property %q, expected key=value", p), 1)
+			}
+			switch kv[0] {
+			case "network":
+				a.Network = kv[1]
+			case "address":
+				a.Address = kv[1]
+			default:
+				fatal(fmt.Sprintf("unknown address property %q", kv[0]), 1)
+			}
+		}
+		n.Addresses = append(n.Addresses, a)
+	}
+	for _, v := range routes {
+		parts := strings.Split(v, ",")
+		r := &types.Route{Destination: parts[0]}
+		for _, p := range parts[1:] {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 {
+				fatal(fmt.Sprintf("invalid route property %q, expected key=value", p), 1)
+			}
+			switch kv[0] {
+			case "via":
+				r.Gateway = kv[1]
+			case "dev":
+				r.Interface = kv[1]
+			case "metric":
+				metric, err := strconv.ParseUint(kv[1], 10, 32)
+				if err != nil {
+					fatal(fmt.Sprintf("invalid route metric %q: %v", kv[1], err), 1)
+				}
+				r.Metric = uint32(metric)
+			default:
+				fatal(fmt.Sprintf("unknown route property %q", kv[0]), 1)
+			}
+		}
+		n.Routes = append(n.Routes, r)
+	}
+	if vf != "" {
+		n.Vf = &types.VirtualFunction{}
+		for _, p := range strings.Split(vf, ",") {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 {
+				fatal(fmt.Sprintf("invalid virtual function property %q, expected key=value", p), 1)
+			}
+			switch kv[0] {
+			case "pf":
+				n.Vf.Pf = kv[1]
+			case "name":
+				n.Vf.Name = kv[1]
+			case "mac":
+				n.Vf.Mac = kv[1]
+			case "vlan":
+				vlan, err := strconv.ParseUint(kv[1], 10, 32)
+				if err != nil {
+					fatal(fmt.Sprintf("invalid virtual function vlan %q: %v", kv[1], err), 1)
+				}
+				n.Vf.Vlan = uint32(vlan)
+			default:
+				fatal(fmt.Sprintf("unknown virtual function property %q", kv[0]), 1)
+			}
+		}
+	}
+	for _, v := range values {
+		parts := strings.Split(v, ",")
+		i := &types.InterfaceConfig{Name: parts[0]}
+		for _, p := range parts[1:] {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 {
+				fatal(fmt.Sprintf("invalid interface property %q, expected key=value", p), 1)
+			}
+			switch kv[0] {
+			case "mtu", "txqueuelen":
+				n, err := strconv.ParseUint(kv[1], 10, 32)
+				if err != nil {
+					fatal(fmt.Sprintf("invalid interface %s %q: %v", kv[0], kv[1], err), 1)
+				}
+				if kv[0] == "mtu" {
+					i.Mtu = uint32(n)
+				} else {
+					i.TxQueueLen = uint32(n)
+				}
+			case "mac":
+				i.Mac = kv[1]
+			default:
+				fatal(fmt.Sprintf("unknown interface property %q", kv[0]), 1)
+			}
+		}
+		n.Interfaces = append(n.Interfaces, i)
+	}
+	return n
+}
+
+// parseDeviceRates parses block device rates in the form of path:rate
+func parseDeviceRates(values []string) []*types.ThrottleDevice {
+	var devices []*types.ThrottleDevice
+	for _, v := range values {
+		i := strings.LastIndex(v, ":")
+		if i < 0 {
+			fatal(fmt.Sprintf("invalid device rate %q, expected path:rate", v), 1)
+		}
+		rate, err := strconv.ParseUint(v[i+1:], 10, 64)
+		if err != nil {
+			fatal(fmt.Sprintf("invalid device rate %q: %v", v, err), 1)
+		}
+		devices = append(devices, &types.ThrottleDevice{
+			Path: v[:i],
+			Rate: rate,
+		})
+	}
+	return devices
+}
+
+// numaConfig returns the NUMA binding set by the start command's flags
+func numaConfig(context *cli.Context) *types.NUMAConfig {
+	var (
+		nodes  = context.String("numa-nodes")
+		policy = context.String("memory-policy")
+	)
+	if nodes == "" && policy == "" {
+		return nil
+	}
+	return &types.NUMAConfig{
+		Nodes:        nodes,
+		MemoryPolicy: policy,
+	}
+}
+
+// oomRestartPolicy returns the OOM restart policy set by the start command's
+// flags
+func oomRestartPolicy(context *cli.Context) *types.OOMRestartPolicy {
+	if !context.Bool("oom-restart") {
+		return nil
+	}
+	return &types.OOMRestartPolicy{
+		MaxRestarts:     uint32(context.Int("oom-max-restarts")),
+		MemoryIncrement: uint64(context.Int("oom-memory-increment")),
+		MemoryLimitCap:  uint64(context.Int("oom-memory-cap")),
+	}
+}
+
+// volumes parses the volumes in the form of driver:name:destination with
+// optional ro and ephemeral options
+func volumes(context *cli.Context) []*types.Volume {
+	var vs []*types.Volume
+	for _, s := range context.StringSlice("volume") {
+		parts := strings.Split(s, ":")
+		if len(parts) != 3 && len(parts) != 4 {
+			fatal(fmt.Sprintf("volume %s is not in the format driver:name:destination[:ro,ephemeral]", s), 1)
+		}
+		v := &types.Volume{
+			Driver:      parts[0],
+			Name:        parts[1],
+			Destination: parts[2],
+		}
+		if len(parts) == 4 {
+			for _, o := range strings.Split(parts[3], ",") {
+				switch o {
+				case "ro":
+					v.ReadOnly = true
+				case "ephemeral":
+					v.Ephemeral = true
+				default:
+					fatal(fmt.Sprintf("unknown option %s of volume %s", o, s), 1)
+				}
+			}
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+func gpus(context *cli.Context) []string {
+	if g := context.String("gpus"); g != "" {
+		return strings.Split(g, ",")
+	}
+	return nil
+}
+
+// parseLogOptions parses log driver options in the form of key=value
+func parseLogOptions(opts []string) map[string]string {
+	if len(opts) == 0 {
+		return nil
+	}
+	m := make(map[string]string)
+	for _, o := range opts {
+		parts := strings.SplitN(o, "=", 2)
+		if len(parts) != 2 {
+			fatal(fmt.Sprintf("invalid log option %q, expected key=value", o), 1)
+		}
+		m[parts[0]] = parts[1]
+	}
+	return m
+}
+
 var (
 	stdin io.WriteCloser
 	state *term.State
@@ -334,6 +834,60 @@ var pauseCommand = cli.Command{
 	},
 }
 
+var freezeFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "label",
+		Value: &cli.StringSlice{},
+		Usage: "select the containers that have all of the labels",
+	},
+	cli.StringFlag{
+		Name:  "group",
+		Usage: "select the containers of the group",
+	},
+}
+
+var freezeCommand = cli.Command{
+	Name:  "freeze",
+	Usage: "pause a group of containers, either all of them are paused or none",
+	Flags: freezeFlags,
+	Action: func(context *cli.Context) {
+		freezeContainers(context, false)
+	},
+}
+
+var thawCommand = cli.Command{
+	Name:  "thaw",
+	Usage: "resume a group of containers, either all of them are resumed or none",
+	Flags: freezeFlags,
+	Action: func(context *cli.Context) {
+		freezeContainers(context, true)
+	},
+}
+
+func freezeContainers(context *cli.Context, thaw bool) {
+	var (
+		ids    = []string(context.Args())
+		labels = context.StringSlice("label")
+		group  = context.String("group")
+	)
+	if len(ids) == 0 && len(labels) == 0 && group == "" {
+		fatal("container ids, labels or a group are required", 1)
+	}
+	c := getClient(context)
+	resp, err := c.FreezeContainers(netcontext.Background(), &types.FreezeContainersRequest{
+		Ids:    ids,
+		Labels: labels,
+		Group:  group,
+		Thaw:   thaw,
+	})
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	for _, id := range resp.Ids {
+		fmt.Println(id)
+	}
+}
+
 var resumeCommand = cli.Command{
 	Name:  "resume",
 	Usage: "resume a paused container",
@@ -385,6 +939,144 @@ var killCommand = cli.Command{
 	},
 }
 
+var psCommand = cli.Command{
+	Name:  "ps",
+	Usage: "list the exec processes of a container",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.ListProcesses(netcontext.Background(), &types.ListProcessesRequest{
+			Id: id,
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		if f := context.String("format"); f != "" {
+			printFormatted(f, resp.Processes)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+		fmt.Fprint(w, "PID\tSYSTEM PID\tSTARTED\tCOMMAND\n")
+		for _, p := range resp.Processes {
+			started := "-"
+			if p.StartedAt != 0 {
+				started = time.Unix(0, int64(p.StartedAt)).Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", p.Pid, p.SystemPid, started, strings.Join(p.Args, " "))
+		}
+		if err := w.Flush(); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var deleteProcessCommand = cli.Command{
+	Name:  "delete-process",
+	Usage: "kill an exec process and remove it from the container without waiting for its shim",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pid,p",
+			Usage: "pid of the exec process to delete",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.DeleteProcess(netcontext.Background(), &types.DeleteProcessRequest{
+			Id:  id,
+			Pid: context.String("pid"),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(resp.Status)
+	},
+}
+
+var stopCommand = cli.Command{
+	Name:  "stop",
+	Usage: "stop a container with a signal and kill it if it did not exit within the timeout",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "signal,s",
+			Value: 15,
+			Usage: "signal sent to the init process",
+		},
+		cli.IntFlag{
+			Name:  "timeout,t",
+			Value: 10,
+			Usage: "seconds to wait before the container is killed, 0 kills it without waiting",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		timeout := int64(context.Int("timeout"))
+		if timeout <= 0 {
+			timeout = -1
+		}
+		c := getClient(context)
+		resp, err := c.StopContainer(netcontext.Background(), &types.StopContainerRequest{
+			Id:      id,
+			Signal:  uint32(context.Int("signal")),
+			Timeout: timeout,
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		if resp.Forced {
+			fmt.Fprintf(os.Stderr, "container %s was killed after the timeout\n", id)
+		}
+		os.Exit(int(resp.Status))
+	},
+}
+
+var restartCommand = cli.Command{
+	Name:  "restart",
+	Usage: "stop a container and start it again with the same bundle, labels and checkpoints",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "signal,s",
+			Value: 15,
+			Usage: "signal sent to the init process",
+		},
+		cli.IntFlag{
+			Name:  "timeout,t",
+			Value: 10,
+			Usage: "seconds to wait before the container is killed, 0 kills it without waiting",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		timeout := int64(context.Int("timeout"))
+		if timeout <= 0 {
+			timeout = -1
+		}
+		c := getClient(context)
+		if _, err := c.RestartContainer(netcontext.Background(), &types.RestartContainerRequest{
+			Id:      id,
+			Signal:  uint32(context.Int("signal")),
+			Timeout: timeout,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
 var execCommand = cli.Command{
 	Name:  "exec",
 	Usage: "exec another process in an existing container",
@@ -409,6 +1101,19 @@ var execCommand = cli.Command{
 			Name:  "tty,t",
 			Usage: "create a terminal for the process",
 		},
+		cli.BoolFlag{
+			Name:  "interactive,i",
+			Usage: "attach to the process with a terminal when stdin is a terminal",
+		},
+		cli.StringFlag{
+			Name:  "detach-keys",
+			Value: defaultDetachKeys,
+			Usage: "key sequence for detaching from an attached process",
+		},
+		cli.BoolFlag{
+			Name:  "stdio-socket",
+			Usage: "pass a socket to the shim for stdio instead of fifos and attach over the api",
+		},
 		cli.StringSliceFlag{
 			Name:  "env,e",
 			Value: &cli.StringSlice{},
@@ -424,20 +1129,38 @@ var execCommand = cli.Command{
 		},
 	},
 	Action: func(context *cli.Context) {
-		var restoreAndCloseStdin func()
-
+		var (
+			restoreAndCloseStdin func()
+			attach               = context.Bool("attach") || context.Bool("interactive")
+			tty                  = context.Bool("tty") || (context.Bool("interactive") && term.IsTerminal(os.Stdin.Fd()))
+		)
+		detachKeys, err := term.ToBytes(context.String("detach-keys"))
+		if err != nil {
+			fatal(fmt.Sprintf("invalid detach keys: %v", err), 1)
+		}
 		p := &types.AddProcessRequest{
 			Id:       context.String("id"),
 			Pid:      context.String("pid"),
 			Args:     context.Args(),
 			Cwd:      context.String("cwd"),
-			Terminal: context.Bool("tty"),
+			Terminal: tty,
 			Env:      context.StringSlice("env"),
 			User: &types.User{
 				Uid: uint32(context.Int("uid")),
 				Gid: uint32(context.Int("gid")),
 			},
 		}
+		if context.Bool("stdio-socket") {
+			p.StdioSocket = true
+			c := getClient(context)
+			if _, err := c.AddProcess(netcontext.Background(), p); err != nil {
+				fatal(err.Error(), 1)
+			}
+			if attach {
+				attachProcess(c, p.Id, p.Pid, startReplaySize, tty && term.IsTerminal(os.Stdin.Fd()), true, detachKeys)
+			}
+			return
+		}
 		s, err := createStdio()
 		if err != nil {
 			fatal(err.Error(), 1)
@@ -454,8 +1177,8 @@ var execCommand = cli.Command{
 			}
 		}
 		defer restoreAndCloseStdin()
-		if context.Bool("attach") {
-			if context.Bool("tty") {
+		if attach {
+			if tty {
 				s, err := term.SetRawTerminal(os.Stdin.Fd())
 				if err != nil {
 					fatal(err.Error(), 1)
@@ -474,9 +1197,15 @@ var execCommand = cli.Command{
 		if _, err := c.AddProcess(netcontext.Background(), p); err != nil {
 			fatal(err.Error(), 1)
 		}
-		if context.Bool("attach") {
+		if attach {
 			go func() {
-				io.Copy(stdin, os.Stdin)
+				if _, err := io.Copy(stdin, newDetachReader(os.Stdin, detachKeys)); err == errDetached {
+					// leave the process running with its stdin open
+					if state != nil {
+						term.RestoreTerminal(os.Stdin.Fd(), state)
+					}
+					os.Exit(0)
+				}
 				if _, err := c.UpdateProcess(netcontext.Background(), &types.UpdateProcessRequest{
 					Id:         p.Id,
 					Pid:        p.Pid,
@@ -486,8 +1215,10 @@ var execCommand = cli.Command{
 				}
 				restoreAndCloseStdin()
 			}()
-			if context.Bool("tty") {
-				resize(p.Id, p.Pid, c)
+			if tty {
+				if err := resize(p.Id, p.Pid, c); err != nil {
+					log.Println(err)
+				}
 				go func() {
 					s := make(chan os.Signal, 64)
 					signal.Notify(s, syscall.SIGWINCH)
@@ -506,11 +1237,33 @@ var execCommand = cli.Command{
 var statsCommand = cli.Command{
 	Name:  "stats",
 	Usage: "get stats for running container",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "stream",
+			Usage: "print the stats every second until the container is gone",
+		},
+	},
 	Action: func(context *cli.Context) {
 		req := &types.StatsRequest{
 			Id: context.Args().First(),
 		}
 		c := getClient(context)
+		if context.Bool("stream") {
+			stream, err := c.StatsStream(netcontext.Background(), req)
+			if err != nil {
+				fatal(err.Error(), 1)
+			}
+			enc := json.NewEncoder(os.Stdout)
+			for {
+				stats, err := stream.Recv()
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				if err := enc.Encode(stats); err != nil {
+					fatal(err.Error(), 1)
+				}
+			}
+		}
 		stats, err := c.Stats(netcontext.Background(), req)
 		if err != nil {
 			fatal(err.Error(), 1)
@@ -554,6 +1307,48 @@ var updateCommand = cli.Command{
 		cli.StringFlag{
 			Name: "cpuset-mems",
 		},
+		cli.IntFlag{
+			Name:  "cpu-rt-runtime",
+			Usage: "usecs of realtime scheduling allowed per realtime period",
+		},
+		cli.IntFlag{
+			Name:  "cpu-rt-period",
+			Usage: "realtime period in usecs",
+		},
+		cli.IntFlag{
+			Name:  "pids-limit",
+			Usage: "maximum number of pids, -1 removes the limit",
+		},
+		cli.IntFlag{
+			Name:  "memory-swappiness",
+			Value: -1,
+			Usage: "memory swappiness between 0 and 100, -1 leaves it unchanged",
+		},
+		cli.StringSliceFlag{
+			Name:  "blkio-weight-device",
+			Value: &cli.StringSlice{},
+			Usage: "blkio weight of a block device in the form of path:weight",
+		},
+		cli.StringSliceFlag{
+			Name:  "device-read-bps",
+			Value: &cli.StringSlice{},
+			Usage: "read bytes per second of a block device in the form of path:rate, a rate of 0 removes the throttle",
+		},
+		cli.StringSliceFlag{
+			Name:  "device-write-bps",
+			Value: &cli.StringSlice{},
+			Usage: "write bytes per second of a block device in the form of path:rate, a rate of 0 removes the throttle",
+		},
+		cli.StringSliceFlag{
+			Name:  "device-read-iops",
+			Value: &cli.StringSlice{},
+			Usage: "read operations per second of a block device in the form of path:rate, a rate of 0 removes the throttle",
+		},
+		cli.StringSliceFlag{
+			Name:  "device-write-iops",
+			Value: &cli.StringSlice{},
+			Usage: "write operations per second of a block device in the form of path:rate, a rate of 0 removes the throttle",
+		},
 	},
 	Action: func(context *cli.Context) {
 		req := &types.UpdateContainerRequest{
@@ -569,6 +1364,22 @@ var updateCommand = cli.Command{
 		req.Resources.CpuShares = uint32(context.Int("cpu-shares"))
 		req.Resources.CpusetCpus = context.String("cpuset-cpus")
 		req.Resources.CpusetMems = context.String("cpuset-mems")
+		req.Resources.CpuRealtimeRuntime = uint32(context.Int("cpu-rt-runtime"))
+		req.Resources.CpuRealtimePeriod = uint32(context.Int("cpu-rt-period"))
+		req.Resources.PidsLimit = int64(context.Int("pids-limit"))
+		if swappiness := context.Int("memory-swappiness"); swappiness >= 0 {
+			req.Resources.MemorySwappiness = &types.MemorySwappiness{Value: uint64(swappiness)}
+		}
+		for _, d := range parseDeviceRates(context.StringSlice("blkio-weight-device")) {
+			req.Resources.BlkioWeightDevice = append(req.Resources.BlkioWeightDevice, &types.WeightDevice{
+				Path:   d.Path,
+				Weight: uint32(d.Rate),
+			})
+		}
+		req.Resources.BlkioThrottleReadBpsDevice = parseDeviceRates(context.StringSlice("device-read-bps"))
+		req.Resources.BlkioThrottleWriteBpsDevice = parseDeviceRates(context.StringSlice("device-write-bps"))
+		req.Resources.BlkioThrottleReadIopsDevice = parseDeviceRates(context.StringSlice("device-read-iops"))
+		req.Resources.BlkioThrottleWriteIopsDevice = parseDeviceRates(context.StringSlice("device-write-iops"))
 		c := getClient(context)
 		if _, err := c.UpdateContainer(netcontext.Background(), req); err != nil {
 			fatal(err.Error(), 1)
@@ -576,6 +1387,58 @@ var updateCommand = cli.Command{
 	},
 }
 
+var waitCommand = cli.Command{
+	Name:  "wait",
+	Usage: "block until a container's process exits and exit with its status",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pid,p",
+			Value: "init",
+			Usage: "pid of the process to wait on within the container",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.Wait(netcontext.Background(), &types.WaitRequest{
+			Id:  id,
+			Pid: context.String("pid"),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		os.Exit(int(resp.Status))
+	},
+}
+
+var closeStdinCommand = cli.Command{
+	Name:  "close-stdin",
+	Usage: "close the stdin of a container's process",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pid,p",
+			Value: "init",
+			Usage: "pid of the process within the container",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.CloseStdin(netcontext.Background(), &types.CloseStdinRequest{
+			Id:  id,
+			Pid: context.String("pid"),
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
 func waitForExit(c types.APIClient, events types.API_EventsClient, id, pid string, closer func()) {
 	timestamp := uint64(time.Now().Unix())
 	for {
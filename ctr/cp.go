@@ -0,0 +1,141 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	"github.com/docker/containerd/archive"
+	netcontext "golang.org/x/net/context"
+)
+
+const copyChunkSize = 32 * 1024
+
+var cpCommand = cli.Command{
+	Name:  "cp",
+	Usage: "copy files between a container's rootfs and the host (ctr cp <id>:<path> <path> or ctr cp <path> <id>:<path>)",
+	Action: func(context *cli.Context) {
+		var (
+			src = context.Args().Get(0)
+			dst = context.Args().Get(1)
+		)
+		if src == "" || dst == "" {
+			fatal("source and destination cannot be empty", 1)
+		}
+		var (
+			srcID, srcPath = splitCopyPath(src)
+			dstID, dstPath = splitCopyPath(dst)
+			c              = getClient(context)
+		)
+		switch {
+		case srcID != "" && dstID != "":
+			fatal("copying between containers is not supported", 1)
+		case srcID != "":
+			if err := copyFromContainer(c, srcID, srcPath, dstPath); err != nil {
+				fatal(err.Error(), 1)
+			}
+		case dstID != "":
+			if err := copyToContainer(c, srcPath, dstID, dstPath); err != nil {
+				fatal(err.Error(), 1)
+			}
+		default:
+			fatal("either the source or destination must be a container path", 1)
+		}
+	},
+}
+
+// splitCopyPath splits a path in the form of <id>:<path> into the container id
+// and the path inside of the container. If the argument does not reference a
+// container then an empty id is returned.
+func splitCopyPath(arg string) (string, string) {
+	if i := strings.Index(arg, ":"); i > 0 && !strings.Contains(arg[:i], "/") {
+		return arg[:i], arg[i+1:]
+	}
+	return "", arg
+}
+
+func copyFromContainer(c types.APIClient, id, path, dst string) error {
+	dst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+	stream, err := c.CopyFromContainer(netcontext.Background(), &types.CopyFromContainerRequest{
+		Id:   id,
+		Path: path,
+	})
+	if err != nil {
+		return err
+	}
+	return untarCopy(&chunkReader{stream: stream}, dst)
+}
+
+// untarCopy extracts the tar stream copied out of a container to the host
+// path dst.  The stream is not trusted so dst, or its parent when dst is not a
+// directory, is the root that the symlinks of the entries are resolved in.
+func untarCopy(r io.Reader, dst string) error {
+	if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+		return archive.Untar(r, dst, "/")
+	}
+	return archive.Untar(r, filepath.Dir(dst), filepath.Base(dst))
+}
+
+func copyToContainer(c types.APIClient, src, id, path string) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	rc, err := archive.Tar("/", src)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	stream, err := c.CopyToContainer(netcontext.Background())
+	if err != nil {
+		return err
+	}
+	r := &types.CopyToContainerRequest{
+		Id:   id,
+		Path: path,
+	}
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			r.Data = buf[:n]
+			if serr := stream.Send(r); serr != nil {
+				return serr
+			}
+			r = &types.CopyToContainerRequest{}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// chunkReader reads the tar stream returned by CopyFromContainer
+type chunkReader struct {
+	stream types.API_CopyFromContainerClient
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		m, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = m.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUntarCopySymlinkedParent(t *testing.T) {
+	outside, err := ioutil.TempDir("", "ctr-cp-outside-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outside)
+	for _, existing := range []bool{true, false} {
+		dir, err := ioutil.TempDir("", "ctr-cp-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		dst := filepath.Join(dir, "out")
+		if existing {
+			if err := os.Mkdir(dst, 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the container sends a symlink to a host directory followed by an
+		// entry below the symlink
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, h := range []*tar.Header{
+			{Name: "x/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "x/link", Typeflag: tar.TypeSymlink, Linkname: outside},
+			{Name: "x/link/passwd", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+		} {
+			if err := tw.WriteHeader(h); err != nil {
+				t.Fatal(err)
+			}
+			if h.Size > 0 {
+				tw.Write([]byte("root"))
+			}
+		}
+		tw.Close()
+		untarCopy(buf, dst)
+		if _, err := os.Stat(filepath.Join(outside, "passwd")); !os.IsNotExist(err) {
+			t.Fatalf("expected the entry below the symlink to stay inside of the destination (existing %v) but received %v", existing, err)
+		}
+	}
+}
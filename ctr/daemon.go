@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var daemonCommand = cli.Command{
+	Name:  "daemon",
+	Usage: "back up and restore the daemon's state",
+	Subcommands: []cli.Command{
+		backupCommand,
+		restoreCommand,
+	},
+}
+
+var backupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "write an archive of the container records, checkpoints index, templates and groups",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output,o",
+			Usage: "write the archive to a file instead of stdout",
+		},
+	},
+	Action: func(context *cli.Context) {
+		var w io.Writer = os.Stdout
+		if path := context.String("output"); path != "" {
+			f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				fatal(err.Error(), 1)
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := backup(getClient(context), w); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var restoreCommand = cli.Command{
+	Name:  "restore",
+	Usage: "restore the containers, templates and groups of a backup archive that do not exist",
+	Action: func(context *cli.Context) {
+		path := context.Args().First()
+		if path == "" {
+			fatal("backup path cannot be empty", 1)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		defer f.Close()
+		resp, err := restoreBackup(getClient(context), f)
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		for _, id := range resp.Restored {
+			fmt.Printf("restored %s\n", id)
+		}
+		for _, id := range resp.Skipped {
+			fmt.Printf("skipped %s\n", id)
+		}
+	},
+}
+
+func backup(c types.APIClient, w io.Writer) error {
+	stream, err := c.Backup(netcontext.Background(), &types.BackupRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+func restoreBackup(c types.APIClient, r io.Reader) (*types.RestoreBackupResponse, error) {
+	stream, err := c.RestoreBackup(netcontext.Background())
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if serr := stream.Send(&types.RestoreBackupRequest{Data: buf[:n]}); serr != nil {
+				return nil, serr
+			}
+		}
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
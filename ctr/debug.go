@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var debugCommand = cli.Command{
+	Name:  "debug",
+	Usage: "inspect the internals of the daemon",
+	Subcommands: []cli.Command{
+		{
+			Name:  "dump",
+			Usage: "dump the supervisor's task queues, containers and subscribers",
+			Flags: []cli.Flag{
+				formatFlag,
+				cli.DurationFlag{
+					Name:  "timeout",
+					Value: 5 * time.Second,
+					Usage: "time to wait for the event loop to report the containers",
+				},
+			},
+			Action: func(context *cli.Context) {
+				c := getClient(context)
+				resp, err := c.DumpState(netcontext.Background(), &types.DumpStateRequest{
+					Timeout: uint64(context.Duration("timeout")),
+				})
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				if f := context.String("format"); f != "" {
+					printFormatted(f, resp)
+					return
+				}
+				data, err := json.MarshalIndent(resp, "", "  ")
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				fmt.Println(string(data))
+			},
+		},
+		{
+			Name:  "check",
+			Usage: "check the containers against their state directories, cgroups, shims and namespaces",
+			Flags: []cli.Flag{
+				formatFlag,
+				cli.BoolFlag{
+					Name:  "clean",
+					Usage: "clean the discrepancies that can be cleaned",
+				},
+				cli.BoolFlag{
+					Name:  "last",
+					Usage: "print the findings of the daemon's last periodic check instead of checking",
+				},
+			},
+			Action: func(context *cli.Context) {
+				c := getClient(context)
+				resp, err := c.CheckConsistency(netcontext.Background(), &types.CheckConsistencyRequest{
+					Clean: context.Bool("clean"),
+					Last:  context.Bool("last"),
+				})
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				if f := context.String("format"); f != "" {
+					printFormatted(f, resp)
+					return
+				}
+				if resp.Started == 0 {
+					fatal("the daemon has not run a periodic check", 1)
+				}
+				w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+				fmt.Fprint(w, "KIND\tID\tPID\tDETAIL\tCLEANED\n")
+				for _, f := range resp.Findings {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", f.Kind, f.Id, f.Pid, f.Detail, f.Cleaned)
+				}
+				if err := w.Flush(); err != nil {
+					fatal(err.Error(), 1)
+				}
+			},
+		},
+	},
+}
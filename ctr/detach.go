@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"io"
+)
+
+const defaultDetachKeys = "ctrl-p,ctrl-q"
+
+var errDetached = errors.New("detached from process")
+
+// detachReader passes through everything read from r until the detach key
+// sequence is read, at which point errDetached is returned.  Bytes that are
+// part of a partial match are held back and only returned if the sequence is
+// not completed or r ends.
+type detachReader struct {
+	r    io.Reader
+	keys []byte
+	// matched is the number of detach keys matched so far
+	matched int
+	// out holds bytes ready to be returned by the next Read
+	out []byte
+	// err is returned once the bytes in out have been read
+	err error
+}
+
+func newDetachReader(r io.Reader, keys []byte) *detachReader {
+	return &detachReader{
+		r:    r,
+		keys: keys,
+	}
+}
+
+func (d *detachReader) Read(p []byte) (int, error) {
+	if len(d.keys) == 0 {
+		return d.r.Read(p)
+	}
+	for len(d.out) == 0 && d.err == nil {
+		buf := make([]byte, len(p))
+		n, err := d.r.Read(buf)
+		for _, b := range buf[:n] {
+			if b == d.keys[d.matched] {
+				d.matched++
+				if d.matched == len(d.keys) {
+					// the bytes before the sequence are still returned,
+					// the bytes after it are dropped
+					d.matched = 0
+					d.err = errDetached
+					break
+				}
+				continue
+			}
+			// the sequence was broken so release the bytes held back
+			d.out = append(d.out, d.keys[:d.matched]...)
+			d.matched = 0
+			if b == d.keys[0] {
+				d.matched = 1
+				continue
+			}
+			d.out = append(d.out, b)
+		}
+		if err != nil && d.err == nil {
+			// a partial match at the end of the input is not a sequence
+			d.out = append(d.out, d.keys[:d.matched]...)
+			d.matched = 0
+			d.err = err
+		}
+	}
+	if len(d.out) == 0 {
+		return 0, d.err
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
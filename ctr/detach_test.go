@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDetachReader(t *testing.T) {
+	keys := []byte{16, 17}
+	for _, tc := range []struct {
+		name  string
+		input string
+		out   string
+		err   error
+	}{
+		{"plain input", "hello", "hello", io.EOF},
+		{"detach", "hello\x10\x11world", "hello", errDetached},
+		{"detach only", "\x10\x11", "", errDetached},
+		{"broken sequence", "a\x10b\x11", "a\x10b\x11", io.EOF},
+		{"repeated first key", "\x10\x10\x11after", "\x10", errDetached},
+		{"partial match at eof", "hello\x10", "hello\x10", io.EOF},
+	} {
+		for _, split := range []bool{false, true} {
+			var r io.Reader = bytes.NewReader([]byte(tc.input))
+			if split {
+				r = iotest.OneByteReader(r)
+			}
+			d := newDetachReader(r, keys)
+			var (
+				out []byte
+				err error
+				buf = make([]byte, 32)
+			)
+			for err == nil {
+				var n int
+				n, err = d.Read(buf)
+				out = append(out, buf[:n]...)
+			}
+			if string(out) != tc.out || err != tc.err {
+				t.Errorf("%s (one byte reads %v): expected %q and %v but received %q and %v", tc.name, split, tc.out, tc.err, out, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var deviceCommand = cli.Command{
+	Name:  "device",
+	Usage: "add or remove devices of a running container",
+	Subcommands: []cli.Command{
+		{
+			Name:  "add",
+			Usage: "grant a container access to a host device node",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "container-path",
+					Usage: "path of the device node inside the container, defaults to the host path",
+				},
+				cli.StringFlag{
+					Name:  "permissions",
+					Value: "rwm",
+					Usage: "cgroup permissions for the device",
+				},
+				cli.BoolFlag{
+					Name:  "mknod",
+					Usage: "create the device node inside the container",
+				},
+			},
+			Action: func(context *cli.Context) {
+				updateDevice(context, false)
+			},
+		},
+		{
+			Name:  "remove",
+			Usage: "revoke a container's access to a host device node",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "container-path",
+					Usage: "path of the device node inside the container, defaults to the host path",
+				},
+				cli.BoolFlag{
+					Name:  "mknod",
+					Usage: "remove the device node inside the container",
+				},
+			},
+			Action: func(context *cli.Context) {
+				updateDevice(context, true)
+			},
+		},
+	},
+}
+
+func updateDevice(context *cli.Context, remove bool) {
+	id, path := context.Args().Get(0), context.Args().Get(1)
+	if id == "" {
+		fatal("container id cannot be empty", 1)
+	}
+	if path == "" {
+		fatal("device path cannot be empty", 1)
+	}
+	c := getClient(context)
+	if _, err := c.UpdateDevice(netcontext.Background(), &types.UpdateDeviceRequest{
+		Id:            id,
+		Path:          path,
+		ContainerPath: context.String("container-path"),
+		Permissions:   context.String("permissions"),
+		Remove:        remove,
+		Mknod:         context.Bool("mknod"),
+	}); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
@@ -19,6 +19,16 @@ var eventsCommand = cli.Command{
 			Name:  "timestamp,t",
 			Usage: "get events from a specific time stamp in RFC3339Nano format",
 		},
+		cli.IntFlag{
+			Name:  "after-seq",
+			Usage: "get the events with a greater sequence number, takes precedence over --timestamp",
+		},
+		cli.StringFlag{
+			Name:  "overflow",
+			Value: "drop",
+			Usage: "what the daemon does when events are not read fast enough: drop or disconnect",
+		},
+		formatFlag,
 	},
 	Action: func(context *cli.Context) {
 		var (
@@ -33,11 +43,22 @@ var eventsCommand = cli.Command{
 			t = from.Unix()
 		}
 		events, err := c.Events(netcontext.Background(), &types.EventsRequest{
-			Timestamp: uint64(t),
+			Timestamp:      uint64(t),
+			OverflowPolicy: context.String("overflow"),
+			AfterSeq:       uint64(context.Int("after-seq")),
 		})
 		if err != nil {
 			fatal(err.Error(), 1)
 		}
+		if f := context.String("format"); f != "" {
+			for {
+				e, err := events.Recv()
+				if err != nil {
+					fatal(err.Error(), 1)
+				}
+				printFormatted(f, e)
+			}
+		}
 		w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
 		fmt.Fprint(w, "TIME\tTYPE\tID\tPID\tSTATUS\n")
 		w.Flush()
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/codegangsta/cli"
+)
+
+var formatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: "format the output using a go template or \"json\"",
+}
+
+// printFormatted writes v to stdout formatted either as json or by executing
+// the provided go template against it
+func printFormatted(format string, v interface{}) {
+	if format == "json" {
+		data, err := json.Marshal(v)
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	tmpl, err := template.New("format").Parse(format)
+	if err != nil {
+		fatal(fmt.Sprintf("invalid format template: %v", err), 1)
+	}
+	if err := tmpl.Execute(os.Stdout, v); err != nil {
+		fatal(err.Error(), 1)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var groupsCommand = cli.Command{
+	Name:  "groups",
+	Usage: "interact with groups of containers sharing namespaces",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Subcommands: []cli.Command{
+		createGroupCommand,
+		deleteGroupCommand,
+		listGroupsCommand,
+	},
+	Action: listGroups,
+}
+
+var createGroupCommand = cli.Command{
+	Name:  "create",
+	Usage: "create a group, containers started with --group share its namespaces",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "namespace,n",
+			Value: &cli.StringSlice{},
+			Usage: "namespace to share: network, ipc, uts or pid, defaults to network and ipc",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("group id cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.CreateGroup(netcontext.Background(), &types.CreateGroupRequest{
+			Id:         id,
+			Namespaces: context.StringSlice("namespace"),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(resp.Group.Pid)
+	},
+}
+
+var deleteGroupCommand = cli.Command{
+	Name:  "delete",
+	Usage: "kill all containers of a group and remove the group once they exited",
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("group id cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.DeleteGroup(netcontext.Background(), &types.DeleteGroupRequest{
+			Id: id,
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		for _, id := range resp.Ids {
+			fmt.Println(id)
+		}
+	},
+}
+
+var listGroupsCommand = cli.Command{
+	Name:  "list",
+	Usage: "list all groups",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: listGroups,
+}
+
+func listGroups(context *cli.Context) {
+	c := getClient(context)
+	resp, err := c.ListGroups(netcontext.Background(), &types.ListGroupsRequest{
+		Id: context.Args().First(),
+	})
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, resp.Groups)
+			return
+		}
+		for _, g := range resp.Groups {
+			printFormatted(f, g)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(w, "ID\tPID\tSTATUS\tNAMESPACES\tCONTAINERS\n")
+	for _, g := range resp.Groups {
+		status := "running"
+		switch {
+		case g.Deleting:
+			status = "deleting"
+		case !g.Running:
+			status = "stopped"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", g.Id, g.Pid, status, strings.Join(g.Namespaces, ","), strings.Join(g.Containers, ","))
+	}
+	if err := w.Flush(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
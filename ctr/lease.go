@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var leasesCommand = cli.Command{
+	Name:  "leases",
+	Usage: "hold the resources of the daemon that are built in several steps",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Subcommands: []cli.Command{
+		createLeaseCommand,
+		renewLeaseCommand,
+		deleteLeaseCommand,
+		listLeasesCommand,
+	},
+	Action: listLeases,
+}
+
+var createLeaseCommand = cli.Command{
+	Name:  "create",
+	Usage: "create a lease and print its id, a random id is used when none is given",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "ttl",
+			Value: time.Hour,
+			Usage: "time until the lease expires unless it is renewed",
+		},
+	},
+	Action: func(context *cli.Context) {
+		c := getClient(context)
+		l, err := c.CreateLease(netcontext.Background(), &types.CreateLeaseRequest{
+			Id:  context.Args().First(),
+			Ttl: uint64(context.Duration("ttl")),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(l.Id)
+	},
+}
+
+var renewLeaseCommand = cli.Command{
+	Name:  "renew",
+	Usage: "extend a lease to expire after the ttl",
+	Flags: []cli.Flag{
+		cli.DurationFlag{
+			Name:  "ttl",
+			Value: time.Hour,
+			Usage: "time from now until the lease expires",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("lease id cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.RenewLease(netcontext.Background(), &types.RenewLeaseRequest{
+			Id:  id,
+			Ttl: uint64(context.Duration("ttl")),
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var deleteLeaseCommand = cli.Command{
+	Name:  "delete",
+	Usage: "delete a lease, the resources it held are collected once nothing else references them",
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("lease id cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.DeleteLease(netcontext.Background(), &types.DeleteLeaseRequest{
+			Id: id,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var listLeasesCommand = cli.Command{
+	Name:  "list",
+	Usage: "list the leases that did not expire",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: listLeases,
+}
+
+func listLeases(context *cli.Context) {
+	c := getClient(context)
+	resp, err := c.ListLeases(netcontext.Background(), &types.ListLeasesRequest{})
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, resp.Leases)
+			return
+		}
+		for _, l := range resp.Leases {
+			printFormatted(f, l)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(w, "ID\tEXPIRES\tBUNDLES\n")
+	for _, l := range resp.Leases {
+		expires := time.Unix(0, int64(l.Expires)).Format(time.RFC3339)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", l.Id, expires, strings.Join(l.Bundles, ","))
+	}
+	if err := w.Flush(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
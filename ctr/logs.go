@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var logsCommand = cli.Command{
+	Name:  "logs",
+	Usage: "print the logs of a container captured by the json-file log driver",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "pid,p",
+			Usage: "process id of the process to print the logs of, defaults to init",
+		},
+		cli.BoolFlag{
+			Name:  "follow,f",
+			Usage: "keep printing new output until the process exits",
+		},
+		cli.IntFlag{
+			Name:  "tail,n",
+			Usage: "print only the last n lines",
+		},
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "print only the lines logged since a unix timestamp, RFC3339 time or a duration such as 10m",
+		},
+		cli.BoolFlag{
+			Name:  "timestamps,t",
+			Usage: "prefix each line with the time it was logged",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		since, err := parseSince(context.String("since"))
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		c := getClient(context)
+		stream, err := c.GetLogs(netcontext.Background(), &types.GetLogsRequest{
+			Id:     id,
+			Pid:    context.String("pid"),
+			Tail:   uint32(context.Int("tail")),
+			Since:  since,
+			Follow: context.Bool("follow"),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					return
+				}
+				fatal(err.Error(), 1)
+			}
+			w := os.Stdout
+			if e.Stream == "stderr" {
+				w = os.Stderr
+			}
+			if context.Bool("timestamps") {
+				fmt.Fprintf(w, "%s ", time.Unix(0, int64(e.Timestamp)).Format(time.RFC3339Nano))
+			}
+			w.Write(e.Data)
+		}
+	},
+}
+
+// parseSince returns the unix timestamp for a since flag which is either a
+// unix timestamp, an RFC3339 time or a duration relative to now
+func parseSince(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if ts, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return ts, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return uint64(t.Unix()), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid since value %q", s)
+	}
+	return uint64(time.Now().Add(-d).Unix()), nil
+}
@@ -49,12 +49,29 @@ func main() {
 			Value: 1 * time.Second,
 			Usage: "GRPC connection timeout",
 		},
+		cli.StringFlag{
+			Name:   "namespace,n",
+			Value:  "default",
+			Usage:  "namespace of the containers",
+			EnvVar: "CONTAINERD_NAMESPACE",
+		},
 	}
 	app.Commands = []cli.Command{
+		bundlesCommand,
+		capabilitiesCommand,
 		checkpointCommand,
+		completionCommand,
 		containersCommand,
+		cpCommand,
+		daemonCommand,
+		debugCommand,
 		eventsCommand,
+		groupsCommand,
+		netnsCommand,
+		leasesCommand,
 		stateCommand,
+		templatesCommand,
+		volumesCommand,
 	}
 	app.Before = func(context *cli.Context) error {
 		if context.GlobalBool("debug") {
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var netnsCommand = cli.Command{
+	Name:  "netns",
+	Usage: "interact with named network namespaces shared by containers",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Subcommands: []cli.Command{
+		createNetnsCommand,
+		deleteNetnsCommand,
+		listNetnsCommand,
+	},
+	Action: listNetns,
+}
+
+var createNetnsCommand = cli.Command{
+	Name:  "create",
+	Usage: "create a named network namespace, containers started with --netns join it",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "container,c",
+			Usage: "keep the network namespace of the running container under the name instead of creating one",
+		},
+	},
+	Action: func(context *cli.Context) {
+		name := context.Args().First()
+		if name == "" {
+			fatal("network namespace name cannot be empty", 1)
+		}
+		c := getClient(context)
+		resp, err := c.CreateNetworkNamespace(netcontext.Background(), &types.CreateNetworkNamespaceRequest{
+			Name:      name,
+			Container: context.String("container"),
+		})
+		if err != nil {
+			fatal(err.Error(), 1)
+		}
+		fmt.Println(resp.NetworkNamespace.Path)
+	},
+}
+
+var deleteNetnsCommand = cli.Command{
+	Name:  "delete",
+	Usage: "unmount a named network namespace that no container joined",
+	Action: func(context *cli.Context) {
+		name := context.Args().First()
+		if name == "" {
+			fatal("network namespace name cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.DeleteNetworkNamespace(netcontext.Background(), &types.DeleteNetworkNamespaceRequest{
+			Name: name,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var listNetnsCommand = cli.Command{
+	Name:  "list",
+	Usage: "list the named network namespaces and the containers that joined them",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: listNetns,
+}
+
+func listNetns(context *cli.Context) {
+	c := getClient(context)
+	resp, err := c.ListNetworkNamespaces(netcontext.Background(), &types.ListNetworkNamespacesRequest{
+		Name: context.Args().First(),
+	})
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, resp.NetworkNamespaces)
+			return
+		}
+		for _, n := range resp.NetworkNamespaces {
+			printFormatted(f, n)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(w, "NAME\tSTATUS\tSOURCE\tCONTAINERS\tPATH\n")
+	for _, n := range resp.NetworkNamespaces {
+		status := "mounted"
+		if !n.Mounted {
+			status = "gone"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", n.Name, status, n.Source, strings.Join(n.Containers, ","), n.Path)
+	}
+	if err := w.Flush(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var updateSpecCommand = cli.Command{
+	Name:  "update-spec",
+	Usage: "change the mounts and environment of a stopped container started with --keep",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "mount-add",
+			Value: &cli.StringSlice{},
+			Usage: "bind mount a host path as source:destination[:ro], a mount at the same destination is replaced",
+		},
+		cli.StringSliceFlag{
+			Name:  "mount-rm",
+			Value: &cli.StringSlice{},
+			Usage: "destination of a mount to remove",
+		},
+		cli.StringSliceFlag{
+			Name:  "env,e",
+			Value: &cli.StringSlice{},
+			Usage: "set an environment variable as KEY=VALUE",
+		},
+		cli.StringSliceFlag{
+			Name:  "env-rm",
+			Value: &cli.StringSlice{},
+			Usage: "key of an environment variable to remove",
+		},
+	},
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		r := &types.UpdateContainerSpecRequest{
+			Id:           id,
+			AddMounts:    bindMounts(context.StringSlice("mount-add")),
+			RemoveMounts: context.StringSlice("mount-rm"),
+			SetEnv:       context.StringSlice("env"),
+			UnsetEnv:     context.StringSlice("env-rm"),
+		}
+		c := getClient(context)
+		if _, err := c.UpdateContainerSpec(netcontext.Background(), r); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+// bindMounts parses mounts given as source:destination[:ro]
+func bindMounts(values []string) []*types.BindMount {
+	var mounts []*types.BindMount
+	for _, m := range values {
+		parts := strings.Split(m, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "ro") {
+			fatal("mounts must be source:destination[:ro]", 1)
+		}
+		mounts = append(mounts, &types.BindMount{
+			Source:      parts[0],
+			Destination: parts[1],
+			ReadOnly:    len(parts) == 3,
+		})
+	}
+	return mounts
+}
+
+var deleteCommand = cli.Command{
+	Name:  "delete",
+	Usage: "delete a stopped container started with --keep",
+	Action: func(context *cli.Context) {
+		id := context.Args().First()
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.DeleteContainer(netcontext.Background(), &types.DeleteContainerRequest{
+			Id: id,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
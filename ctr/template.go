@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var templatesCommand = cli.Command{
+	Name:  "templates",
+	Usage: "save the configuration of containers as templates and create containers from them",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Subcommands: []cli.Command{
+		saveTemplateCommand,
+		cloneTemplateCommand,
+		deleteTemplateCommand,
+		listTemplatesCommand,
+	},
+	Action: listTemplates,
+}
+
+var saveTemplateCommand = cli.Command{
+	Name:  "save",
+	Usage: "save the configuration and the spec of a container as a template",
+	Action: func(context *cli.Context) {
+		var (
+			id   = context.Args().Get(0)
+			name = context.Args().Get(1)
+		)
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		if name == "" {
+			fatal("template name cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.CreateTemplate(netcontext.Background(), &types.CreateTemplateRequest{
+			Name: name,
+			Id:   id,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var cloneTemplateCommand = cli.Command{
+	Name:  "clone",
+	Usage: "create a container from a template, its stdio is available through ctr containers attach",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "env,e",
+			Value: &cli.StringSlice{},
+			Usage: "set an environment variable as KEY=VALUE",
+		},
+		cli.StringSliceFlag{
+			Name:  "mount",
+			Value: &cli.StringSlice{},
+			Usage: "bind mount a host path as source:destination[:ro], a mount at the same destination is replaced",
+		},
+		cli.StringSliceFlag{
+			Name:  "label,l",
+			Value: &cli.StringSlice{},
+			Usage: "add labels to the labels of the template",
+		},
+	},
+	Action: func(context *cli.Context) {
+		var (
+			name = context.Args().Get(0)
+			id   = context.Args().Get(1)
+		)
+		if name == "" {
+			fatal("template name cannot be empty", 1)
+		}
+		if id == "" {
+			fatal("container id cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.CreateContainer(netcontext.Background(), &types.CreateContainerRequest{
+			Id:             id,
+			Template:       name,
+			TemplateEnv:    context.StringSlice("env"),
+			TemplateMounts: bindMounts(context.StringSlice("mount")),
+			Labels:         context.StringSlice("label"),
+			StdioSocket:    true,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var deleteTemplateCommand = cli.Command{
+	Name:  "delete",
+	Usage: "delete a template, the containers created from it are not affected",
+	Action: func(context *cli.Context) {
+		name := context.Args().First()
+		if name == "" {
+			fatal("template name cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.DeleteTemplate(netcontext.Background(), &types.DeleteTemplateRequest{
+			Name: name,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
+
+var listTemplatesCommand = cli.Command{
+	Name:  "list",
+	Usage: "list all templates",
+	Flags: []cli.Flag{
+		formatFlag,
+	},
+	Action: listTemplates,
+}
+
+func listTemplates(context *cli.Context) {
+	c := getClient(context)
+	resp, err := c.ListTemplates(netcontext.Background(), &types.ListTemplatesRequest{})
+	if err != nil {
+		fatal(err.Error(), 1)
+	}
+	if f := context.String("format"); f != "" {
+		if f == "json" {
+			printFormatted(f, resp.Templates)
+			return
+		}
+		for _, t := range resp.Templates {
+			printFormatted(f, t)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 20, 1, 3, ' ', 0)
+	fmt.Fprint(w, "NAME\tCONTAINER\tCREATED\tLABELS\n")
+	for _, t := range resp.Templates {
+		created := time.Unix(0, int64(t.Created)).Format(time.RFC3339)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Name, t.Container, created, strings.Join(t.Labels, ","))
+	}
+	if err := w.Flush(); err != nil {
+		fatal(err.Error(), 1)
+	}
+}
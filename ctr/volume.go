@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/codegangsta/cli"
+	"github.com/docker/containerd/api/grpc/types"
+	netcontext "golang.org/x/net/context"
+)
+
+var volumesCommand = cli.Command{
+	Name:  "volumes",
+	Usage: "interact with the volumes of the daemon's volume drivers",
+	Subcommands: []cli.Command{
+		deleteVolumeCommand,
+	},
+}
+
+var deleteVolumeCommand = cli.Command{
+	Name:  "delete",
+	Usage: "delete a volume of a driver",
+	Action: func(context *cli.Context) {
+		var (
+			driver = context.Args().Get(0)
+			name   = context.Args().Get(1)
+		)
+		if driver == "" || name == "" {
+			fatal("volume driver and name cannot be empty", 1)
+		}
+		c := getClient(context)
+		if _, err := c.DeleteVolume(netcontext.Background(), &types.DeleteVolumeRequest{
+			Driver: driver,
+			Name:   name,
+		}); err != nil {
+			fatal(err.Error(), 1)
+		}
+	},
+}
@@ -0,0 +1,249 @@
+// Package hooks calls external plugins at points of a container's lifecycle.
+//
+// A plugin is either the path to a binary or a unix:// address of a socket.
+// Binaries are run with the event as their argument, the request as json on
+// stdin and the response as json on stdout.  Sockets are sent the request as
+// json and answer the response before closing the connection.
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logging"
+)
+
+var log = logging.Logger("hooks")
+
+// Event is the point of the lifecycle that plugins are called at
+type Event string
+
+const (
+	// PreCreate is called before the container is created, plugins may
+	// change the spec
+	PreCreate Event = "pre-create"
+	// PostStart is called after the container's init process started
+	PostStart Event = "post-start"
+	// PreStop is called before the container's init process is sent a signal
+	// that stops it
+	PreStop Event = "pre-stop"
+	// PostDelete is called after the container was deleted
+	PostDelete Event = "post-delete"
+)
+
+// DefaultTimeout is the time a plugin has to answer
+const DefaultTimeout = 10 * time.Second
+
+// socketPrefix is the prefix of plugins that are sockets
+const socketPrefix = "unix://"
+
+var errEmptySpec = errors.New("containerd: plugin returned an empty spec")
+
+// Request is sent to the plugins
+type Request struct {
+	Event  Event    `json:"event"`
+	ID     string   `json:"id"`
+	Bundle string   `json:"bundle"`
+	Labels []string `json:"labels,omitempty"`
+	// Spec is the bundle's config.json
+	Spec json.RawMessage `json:"spec,omitempty"`
+	// Pid is the host pid of the init process once the container started
+	Pid int `json:"pid,omitempty"`
+	// Status is the exit status of the init process for post-delete
+	Status int `json:"status,omitempty"`
+	// Peer is the client that made the call for pre-create and pre-stop,
+	// it is not set for calls the daemon makes itself or receives on tcp
+	Peer *Peer `json:"peer,omitempty"`
+}
+
+// Peer is the process connected to the daemon's unix socket
+type Peer struct {
+	Pid int32  `json:"pid"`
+	Uid uint32 `json:"uid"`
+	Gid uint32 `json:"gid"`
+}
+
+// Response is answered by the plugins, an empty response changes nothing
+type Response struct {
+	// Spec replaces the bundle's spec for pre-create
+	Spec json.RawMessage `json:"spec,omitempty"`
+	// Error fails the pre-create of the container
+	Error string `json:"error,omitempty"`
+}
+
+// Hooks calls the plugins in the order they were configured
+type Hooks struct {
+	plugins []string
+	timeout time.Duration
+}
+
+// New returns the hooks calling the plugins, each of them has the timeout to
+// answer
+func New(plugins []string, timeout time.Duration) (*Hooks, error) {
+	for _, p := range plugins {
+		if strings.HasPrefix(p, socketPrefix) {
+			continue
+		}
+		if !filepath.IsAbs(p) {
+			return nil, fmt.Errorf("containerd: plugin %s is not an absolute path or a %s address", p, socketPrefix)
+		}
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Hooks{
+		plugins: plugins,
+		timeout: timeout,
+	}, nil
+}
+
+// Enabled returns true if there are plugins to call
+func (h *Hooks) Enabled() bool {
+	return h != nil && len(h.plugins) > 0
+}
+
+// PreCreate calls the plugins with the bundle's spec.  Each plugin is sent
+// the spec returned by the previous one and the final spec is written to the
+// bundle.  The first error of a plugin is returned and the bundle is left
+// unchanged.
+func (h *Hooks) PreCreate(r *Request) error {
+	if !h.Enabled() {
+		return nil
+	}
+	r.Event = PreCreate
+	path := filepath.Join(r.Bundle, "config.json")
+	spec, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, p := range h.plugins {
+		r.Spec = spec
+		resp, err := h.call(p, r)
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("containerd: plugin %s: %s", p, resp.Error)
+		}
+		if resp.Spec == nil {
+			continue
+		}
+		if s := bytes.TrimSpace(resp.Spec); len(s) == 0 || bytes.Equal(s, []byte("null")) {
+			return errEmptySpec
+		}
+		spec, changed = resp.Spec, true
+	}
+	if !changed {
+		return nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, spec, fi.Mode())
+}
+
+// Notify calls the plugins for an event that they cannot change, errors of
+// the plugins are logged
+func (h *Hooks) Notify(e Event, r *Request) {
+	if !h.Enabled() {
+		return
+	}
+	r.Event = e
+	if r.Spec == nil && r.Bundle != "" {
+		r.Spec, _ = ioutil.ReadFile(filepath.Join(r.Bundle, "config.json"))
+	}
+	for _, p := range h.plugins {
+		if _, err := h.call(p, r); err != nil {
+			log.WithFields(logrus.Fields{
+				"error":  err,
+				"event":  e,
+				"plugin": p,
+			}).Error("containerd: call plugin")
+		}
+	}
+}
+
+func (h *Hooks) call(plugin string, r *Request) (*Response, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	var out []byte
+	if strings.HasPrefix(plugin, socketPrefix) {
+		out, err = h.callSocket(strings.TrimPrefix(plugin, socketPrefix), data)
+	} else {
+		out, err = h.callBinary(plugin, r.Event, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("containerd: plugin %s: %v", plugin, err)
+	}
+	var resp Response
+	if len(bytes.TrimSpace(out)) == 0 {
+		return &resp, nil
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, fmt.Errorf("containerd: plugin %s: invalid response: %v", plugin, err)
+	}
+	return &resp, nil
+}
+
+func (h *Hooks) callBinary(path string, e Event, data []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, string(e))
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("%v: %s", err, msg)
+			}
+			return nil, err
+		}
+		return stdout.Bytes(), nil
+	case <-time.After(h.timeout):
+		kill(cmd)
+		<-done
+		return nil, fmt.Errorf("did not answer within %s", h.timeout)
+	}
+}
+
+func (h *Hooks) callSocket(path string, data []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("unix", path, h.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(h.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return nil, err
+	}
+	// the plugin reads the request until the write side is closed
+	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(conn)
+}
@@ -0,0 +1,18 @@
+package hooks
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs the plugin in a new process group so that the
+// processes it starts are killed with it
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+}
+
+func kill(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,100 @@
+package hooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newBundle(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "containerd-hooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"process":{"args":["sh"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestPreCreateChangesSpec(t *testing.T) {
+	dir := newBundle(t)
+	defer os.RemoveAll(dir)
+	// the first plugin replaces the spec and the second one checks that it
+	// is sent the replaced spec
+	first := writePlugin(t, dir, "first", `cat >/dev/null; echo '{"spec":{"process":{"args":["true"]}}}'`)
+	second := writePlugin(t, dir, "second", `grep -q '"true"' || echo '{"error":"spec was not changed"}'`)
+	h, err := New([]string{first, second}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.PreCreate(&Request{ID: "test", Bundle: dir}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"true"`) {
+		t.Fatalf("expected the spec to be changed but received %s", data)
+	}
+}
+
+func TestPreCreateSocketError(t *testing.T) {
+	dir := newBundle(t)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "plugin.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var r Request
+		if err := json.NewDecoder(conn).Decode(&r); err != nil || r.Event != PreCreate {
+			return
+		}
+		json.NewEncoder(conn).Encode(Response{Error: "denied"})
+	}()
+	h, err := New([]string{socketPrefix + path}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.PreCreate(&Request{ID: "test", Bundle: dir}); err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected the plugin's error but received %v", err)
+	}
+}
+
+func TestPluginTimeout(t *testing.T) {
+	dir := newBundle(t)
+	defer os.RemoveAll(dir)
+	h, err := New([]string{writePlugin(t, dir, "slow", "sleep 5")}, 100*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := h.PreCreate(&Request{ID: "test", Bundle: dir}); err == nil {
+		t.Fatal("expected the plugin to time out")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Fatalf("plugin was not killed after the timeout, it took %s", d)
+	}
+}
@@ -0,0 +1,10 @@
+package hooks
+
+import "os/exec"
+
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+func kill(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,201 @@
+package ipam
+
+import (
+	"encoding/json"
+	"net"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/metadata"
+)
+
+// Bucket is the bucket of the metadata database holding the host-local
+// allocations of the networks by network name
+const Bucket = "ipam"
+
+// HostLocal allocates the addresses of the networks in turn from the address
+// after the last one allocated, so that the address of a removed container is
+// not given to the next container
+type HostLocal struct {
+	db *metadata.DB
+}
+
+// NewHostLocal returns the host-local allocator keeping its allocations in
+// the database
+func NewHostLocal(db *metadata.DB) *HostLocal {
+	return &HostLocal{db: db}
+}
+
+// pool is the record of the host-local allocations of a network
+type pool struct {
+	// Last is the last address allocated
+	Last string `json:"last,omitempty"`
+	// Allocated are the ids of the containers by address
+	Allocated map[string]string `json:"allocated,omitempty"`
+}
+
+// Allocate returns the next free address of the network's range, or the
+// address the container has already
+func (h *HostLocal) Allocate(name string, n Network, id string) (Allocation, error) {
+	r, err := parseRange(n)
+	if err != nil {
+		return Allocation{}, err
+	}
+	var a Allocation
+	err = h.update(name, func(p *pool) error {
+		for addr, owner := range p.Allocated {
+			if owner != id {
+				continue
+			}
+			// the address is allocated again when the subnet of the
+			// network changed
+			if ip := net.ParseIP(addr); ip != nil && r.subnet.Contains(ip) {
+				a = r.allocation(ip)
+				return nil
+			}
+			delete(p.Allocated, addr)
+		}
+		ip, err := p.next(r)
+		if err != nil {
+			return err
+		}
+		p.Last = ip.String()
+		p.Allocated[p.Last] = id
+		a = r.allocation(ip)
+		return nil
+	})
+	return a, err
+}
+
+// Release frees the addresses of the container in the network
+func (h *HostLocal) Release(name string, n Network, id string) error {
+	return h.update(name, func(p *pool) error {
+		for addr, owner := range p.Allocated {
+			if owner == id {
+				delete(p.Allocated, addr)
+			}
+		}
+		return nil
+	})
+}
+
+// Restore allocates again the addresses the container has in the pool record
+// of the network taken from a backup, it fails when one of them is allocated to
+// another container
+func (h *HostLocal) Restore(name string, record []byte, id string) error {
+	backup := &pool{}
+	if err := json.Unmarshal(record, backup); err != nil {
+		return err
+	}
+	return h.update(name, func(p *pool) error {
+		for addr, owner := range backup.Allocated {
+			if other, ok := p.Allocated[addr]; owner == id && ok && other != id {
+				return ErrAddressInUse
+			}
+		}
+		for addr, owner := range backup.Allocated {
+			if owner == id {
+				p.Allocated[addr] = id
+			}
+		}
+		return nil
+	})
+}
+
+// next returns the first free address of the range after the last address
+// allocated.  Every address that is taken is tried once at most before a free
+// one is found, the range is exhausted when it is smaller.
+func (p *pool) next(r *addrRange) (net.IP, error) {
+	ip := r.start
+	if last := net.ParseIP(p.Last); last != nil {
+		if l4 := last.To4(); l4 != nil {
+			last = l4
+		}
+		if len(last) == len(r.start) && compareIP(last, r.start) >= 0 && compareIP(last, r.end) < 0 {
+			ip = nextIP(last)
+		}
+	}
+	for tries := len(p.Allocated) + 2; tries > 0; tries-- {
+		if _, taken := p.Allocated[ip.String()]; !taken && !ip.Equal(r.gateway) {
+			return ip, nil
+		}
+		if ip.Equal(r.end) {
+			ip = r.start
+		} else {
+			ip = nextIP(ip)
+		}
+	}
+	return nil, ErrNoFreeAddress
+}
+
+// update changes the pool of the network with fn
+func (h *HostLocal) update(name string, fn func(*pool) error) error {
+	return h.db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(Bucket)
+		if err != nil {
+			return err
+		}
+		p := &pool{}
+		if data := b.Get(name); data != nil {
+			if err := json.Unmarshal(data, p); err != nil {
+				return err
+			}
+		}
+		if p.Allocated == nil {
+			p.Allocated = make(map[string]string)
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		return b.Put(name, data)
+	})
+}
+
+// prune frees the addresses of the containers that do not exist
+func (h *HostLocal) prune(exists func(id string) bool) error {
+	return h.db.Update(func(tx *metadata.Tx) error {
+		b := tx.Bucket(Bucket)
+		if b == nil {
+			return nil
+		}
+		pools := make(map[string]*pool)
+		if err := b.ForEach(func(name string, data []byte) error {
+			p := &pool{}
+			if err := json.Unmarshal(data, p); err != nil {
+				return err
+			}
+			pools[name] = p
+			return nil
+		}); err != nil {
+			return err
+		}
+		for name, p := range pools {
+			changed := false
+			for addr, id := range p.Allocated {
+				if !exists(id) {
+					log.WithFields(logrus.Fields{
+						"id":      id,
+						"network": name,
+						"address": addr,
+					}).Info("containerd: release address of removed container")
+					delete(p.Allocated, addr)
+					changed = true
+				}
+			}
+			if !changed {
+				continue
+			}
+			data, err := json.Marshal(p)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(name, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
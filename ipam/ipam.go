@@ -0,0 +1,284 @@
+// Package ipam allocates the addresses of containers from the subnets of
+// networks.
+//
+// The addresses of a network are allocated by an allocator.  The host-local
+// allocator keeps the allocations in the daemon's metadata database so that
+// they survive restarts of the daemon.  An external allocator is a socket that
+// is sent a request as json, the write side of the connection is then closed
+// and the allocator answers the response before closing the connection.
+package ipam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/containerd/logging"
+	"github.com/docker/containerd/metadata"
+)
+
+var log = logging.Logger("ipam")
+
+var (
+	ErrUnknownNetwork = errors.New("containerd: unknown ipam network")
+	ErrNoFreeAddress  = errors.New("containerd: no free address left in the network")
+	ErrAddressInUse   = errors.New("containerd: address is allocated to another container")
+)
+
+// DefaultTimeout is the time an external allocator has to answer
+const DefaultTimeout = 10 * time.Second
+
+// socketPrefix is the prefix of the external allocators' addresses
+const socketPrefix = "unix://"
+
+// validName matches the names of the networks
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Network is a subnet the addresses of containers are allocated from
+type Network struct {
+	// Subnet is in CIDR notation, the addresses of the containers have its
+	// prefix length
+	Subnet string `json:"subnet"`
+	// Gateway is the router of the subnet, it is never allocated
+	Gateway string `json:"gateway,omitempty"`
+	// RangeStart and RangeEnd limit the addresses allocated from the
+	// subnet, they default to the first and the last address of the subnet
+	// that are neither its network nor its broadcast address
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	// Plugin is the unix:// address of the external allocator of the
+	// network, the host-local allocator is used when it is empty
+	Plugin string `json:"plugin,omitempty"`
+}
+
+// Allocation is the address of a container in a network
+type Allocation struct {
+	// Address is in CIDR notation with the prefix length of the subnet
+	Address string `json:"address"`
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// Allocator allocates the addresses of a network
+type Allocator interface {
+	// Allocate returns the address of the container id in the network, a
+	// container that has an address already gets it again
+	Allocate(name string, n Network, id string) (Allocation, error)
+	// Release returns the address of the container to the network, it
+	// succeeds when the container has no address
+	Release(name string, n Network, id string) error
+}
+
+// LoadNetworks reads the networks from a json file that maps their names to
+// their configuration
+func LoadNetworks(path string) (map[string]Network, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var networks map[string]Network
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return nil, fmt.Errorf("containerd: parse ipam networks %s: %v", path, err)
+	}
+	return networks, nil
+}
+
+// IPAM allocates the addresses of the networks with their allocators
+type IPAM struct {
+	networks map[string]Network
+	local    *HostLocal
+	timeout  time.Duration
+}
+
+// New checks the networks and returns their IPAM, the host-local allocations
+// are kept in the database.  External allocators have the timeout to answer.
+func New(networks map[string]Network, db *metadata.DB, timeout time.Duration) (*IPAM, error) {
+	for name, n := range networks {
+		if !validName.MatchString(name) {
+			return nil, fmt.Errorf("containerd: invalid ipam network name %q", name)
+		}
+		if _, err := parseRange(n); err != nil {
+			return nil, fmt.Errorf("containerd: ipam network %s: %v", name, err)
+		}
+		if n.Plugin != "" && (!strings.HasPrefix(n.Plugin, socketPrefix) || !filepath.IsAbs(strings.TrimPrefix(n.Plugin, socketPrefix))) {
+			return nil, fmt.Errorf("containerd: ipam network %s: plugin %s is not a %s address of an absolute path", name, n.Plugin, socketPrefix)
+		}
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &IPAM{
+		networks: networks,
+		local:    NewHostLocal(db),
+		timeout:  timeout,
+	}, nil
+}
+
+// Networks returns the sorted names of the networks
+func (m *IPAM) Networks() []string {
+	if m == nil {
+		return nil
+	}
+	var names []string
+	for name := range m.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Allocate returns the address of the container id in the network
+func (m *IPAM) Allocate(network, id string) (Allocation, error) {
+	n, a, err := m.allocator(network)
+	if err != nil {
+		return Allocation{}, err
+	}
+	return a.Allocate(network, n, id)
+}
+
+// Release returns the address of the container id to the network
+func (m *IPAM) Release(network, id string) error {
+	n, a, err := m.allocator(network)
+	if err != nil {
+		return err
+	}
+	return a.Release(network, n, id)
+}
+
+// Prune releases the host-local addresses of the containers that do not
+// exist, the addresses of the external allocators are left to them
+func (m *IPAM) Prune(exists func(id string) bool) error {
+	if m == nil {
+		return nil
+	}
+	return m.local.prune(exists)
+}
+
+func (m *IPAM) allocator(network string) (Network, Allocator, error) {
+	if m == nil {
+		return Network{}, nil, ErrUnknownNetwork
+	}
+	n, ok := m.networks[network]
+	if !ok {
+		return Network{}, nil, ErrUnknownNetwork
+	}
+	if n.Plugin != "" {
+		return n, &Plugin{
+			path:    strings.TrimPrefix(n.Plugin, socketPrefix),
+			timeout: m.timeout,
+		}, nil
+	}
+	return n, m.local, nil
+}
+
+// addrRange is the range of the addresses allocated from a network
+type addrRange struct {
+	subnet     *net.IPNet
+	start, end net.IP
+	gateway    net.IP
+}
+
+// parseRange returns the range of the network, the start and the end of the
+// range must be in the subnet
+func parseRange(n Network) (*addrRange, error) {
+	_, subnet, err := net.ParseCIDR(n.Subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet %q", n.Subnet)
+	}
+	if ip4 := subnet.IP.To4(); ip4 != nil {
+		subnet.IP = ip4
+	}
+	r := &addrRange{subnet: subnet}
+	ones, bits := subnet.Mask.Size()
+	// the network and the broadcast addresses of IPv4 subnets are not
+	// allocated unless the subnet is a point to point link
+	r.start, r.end = subnet.IP, lastIP(subnet)
+	if bits == 32 && bits-ones > 1 {
+		r.start, r.end = nextIP(r.start), prevIP(r.end)
+	}
+	for _, b := range []struct {
+		s  string
+		ip *net.IP
+	}{
+		{n.RangeStart, &r.start},
+		{n.RangeEnd, &r.end},
+		{n.Gateway, &r.gateway},
+	} {
+		if b.s == "" {
+			continue
+		}
+		ip := net.ParseIP(b.s)
+		if ip == nil || !subnet.Contains(ip) {
+			return nil, fmt.Errorf("%s is not an address of the subnet %s", b.s, subnet)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		*b.ip = ip
+	}
+	if compareIP(r.start, r.end) > 0 {
+		return nil, fmt.Errorf("the range %s-%s is empty", r.start, r.end)
+	}
+	return r, nil
+}
+
+// allocation returns the allocation of the address in the network
+func (r *addrRange) allocation(ip net.IP) Allocation {
+	a := Allocation{
+		Address: (&net.IPNet{IP: ip, Mask: r.subnet.Mask}).String(),
+	}
+	if r.gateway != nil {
+		a.Gateway = r.gateway.String()
+	}
+	return a
+}
+
+func compareIP(a, b net.IP) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+func prevIP(ip net.IP) net.IP {
+	prev := make(net.IP, len(ip))
+	copy(prev, ip)
+	for i := len(prev) - 1; i >= 0; i-- {
+		prev[i]--
+		if prev[i] != 0xff {
+			break
+		}
+	}
+	return prev
+}
+
+func lastIP(n *net.IPNet) net.IP {
+	last := make(net.IP, len(n.IP))
+	for i := range n.IP {
+		last[i] = n.IP[i] | ^n.Mask[i]
+	}
+	return last
+}
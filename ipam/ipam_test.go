@@ -0,0 +1,195 @@
+package ipam
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/metadata"
+)
+
+func openTestDB(t *testing.T, dir string) *metadata.DB {
+	db, err := metadata.Open(filepath.Join(dir, "metadata.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestHostLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-ipam-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := openTestDB(t, dir)
+	m, err := New(map[string]Network{
+		"small": {Subnet: "10.0.0.0/29", Gateway: "10.0.0.1", RangeEnd: "10.0.0.4"},
+	}, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := m.Allocate("small", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Address != "10.0.0.2/29" || a.Gateway != "10.0.0.1" {
+		t.Fatalf("expected the first address after the gateway but received %+v", a)
+	}
+	if again, err := m.Allocate("small", "a"); err != nil || again != a {
+		t.Fatalf("expected the container to keep its address but received %+v %v", again, err)
+	}
+	b, err := m.Allocate("small", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Release("small", "a"); err != nil {
+		t.Fatal(err)
+	}
+	// the released address is only given again once the range wrapped
+	c, err := m.Allocate("small", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Address != "10.0.0.3/29" || c.Address != "10.0.0.4/29" {
+		t.Fatalf("expected the addresses to be allocated in turn but received %s and %s", b.Address, c.Address)
+	}
+	d, err := m.Allocate("small", "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Address != a.Address {
+		t.Fatalf("expected the released address %s but received %s", a.Address, d.Address)
+	}
+	if _, err := m.Allocate("small", "e"); err != ErrNoFreeAddress {
+		t.Fatalf("expected ErrNoFreeAddress but received %v", err)
+	}
+	if _, err := m.Allocate("missing", "e"); err != ErrUnknownNetwork {
+		t.Fatalf("expected ErrUnknownNetwork but received %v", err)
+	}
+
+	// the allocations survive a restart of the daemon
+	db.Close()
+	db = openTestDB(t, dir)
+	defer db.Close()
+	m, err = New(map[string]Network{
+		"small": {Subnet: "10.0.0.0/29", Gateway: "10.0.0.1", RangeEnd: "10.0.0.4"},
+	}, db, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := m.Allocate("small", "b"); err != nil || again != b {
+		t.Fatalf("expected the allocation to be kept but received %+v %v", again, err)
+	}
+	if err := m.Prune(func(id string) bool { return id != "c" }); err != nil {
+		t.Fatal(err)
+	}
+	if e, err := m.Allocate("small", "e"); err != nil || e.Address != c.Address {
+		t.Fatalf("expected the address of the pruned container but received %+v %v", e, err)
+	}
+}
+
+func TestHostLocalRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-ipam-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db := openTestDB(t, dir)
+	defer db.Close()
+	h := NewHostLocal(db)
+	record := []byte(`{"last": "10.0.0.3", "allocated": {"10.0.0.2": "a", "10.0.0.3": "b"}}`)
+	if err := h.Restore("small", record, "a"); err != nil {
+		t.Fatal(err)
+	}
+	n := Network{Subnet: "10.0.0.0/29", Gateway: "10.0.0.1", RangeEnd: "10.0.0.4"}
+	if a, err := h.Allocate("small", n, "a"); err != nil || a.Address != "10.0.0.2/29" {
+		t.Fatalf("expected the restored address but received %+v %v", a, err)
+	}
+	if c, err := h.Allocate("small", n, "c"); err != nil || c.Address != "10.0.0.3/29" {
+		t.Fatalf("expected the address of the container that was not restored but received %+v %v", c, err)
+	}
+	if err := h.Restore("small", record, "b"); err != ErrAddressInUse {
+		t.Fatalf("expected ErrAddressInUse but received %v", err)
+	}
+}
+
+func TestNetworks(t *testing.T) {
+	for _, n := range []Network{
+		{Subnet: "10.0.0.0/33"},
+		{Subnet: "10.0.0.0/24", Gateway: "10.0.1.1"},
+		{Subnet: "10.0.0.0/24", RangeStart: "10.0.0.9", RangeEnd: "10.0.0.8"},
+		{Subnet: "10.0.0.0/24", Plugin: "/run/ipam.sock"},
+	} {
+		if _, err := New(map[string]Network{"net": n}, nil, 0); err == nil {
+			t.Fatalf("expected network %+v to be invalid", n)
+		}
+	}
+	r, err := parseRange(Network{Subnet: "fd00::/126"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.start.String() != "fd00::" || r.end.String() != "fd00::3" {
+		t.Fatalf("expected the whole IPv6 subnet but received %s-%s", r.start, r.end)
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-ipam-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ipam.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	requests := make(chan Request, 2)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			var r Request
+			data, _ := ioutil.ReadAll(conn)
+			json.Unmarshal(data, &r)
+			requests <- r
+			var resp Response
+			if r.Method == AllocateMethod {
+				resp.Address = "192.168.7.20/24"
+			}
+			json.NewEncoder(conn).Encode(resp)
+			conn.Close()
+		}
+	}()
+	m, err := New(map[string]Network{
+		"ext": {Subnet: "192.168.7.0/24", Gateway: "192.168.7.1", Plugin: "unix://" + path},
+	}, nil, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := m.Allocate("ext", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Address != "192.168.7.20/24" || a.Gateway != "192.168.7.1" {
+		t.Fatalf("expected the plugin's address with the network's gateway but received %+v", a)
+	}
+	if err := m.Release("ext", "web"); err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []Method{AllocateMethod, ReleaseMethod} {
+		r := <-requests
+		if r.Method != method || r.Network != "ext" || r.ID != "web" || r.Subnet != "192.168.7.0/24" {
+			t.Fatalf("expected a %s request for web but received %+v", method, r)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package ipam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+)
+
+// Method is the operation an external allocator is asked to do
+type Method string
+
+const (
+	// AllocateMethod answers the address of the container in the network
+	AllocateMethod Method = "allocate"
+	// ReleaseMethod frees the address of the container in the network
+	ReleaseMethod Method = "release"
+)
+
+// Request is sent to the external allocator
+type Request struct {
+	Method Method `json:"method"`
+	// Network is the name of the network and Subnet, Gateway, RangeStart
+	// and RangeEnd are its configuration
+	Network    string `json:"network"`
+	Subnet     string `json:"subnet"`
+	Gateway    string `json:"gateway,omitempty"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	// ID is the container the address is allocated for
+	ID string `json:"id"`
+}
+
+// Response is answered by the external allocator
+type Response struct {
+	// Address is the allocated address for allocate, in CIDR notation
+	Address string `json:"address,omitempty"`
+	// Gateway is the router of the address, the gateway of the network is
+	// used when it is empty
+	Gateway string `json:"gateway,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Plugin is an external allocator reached on a unix socket
+type Plugin struct {
+	path    string
+	timeout time.Duration
+}
+
+// Allocate asks the allocator for the address of the container
+func (p *Plugin) Allocate(name string, n Network, id string) (Allocation, error) {
+	resp, err := p.call(newRequest(AllocateMethod, name, n, id))
+	if err != nil {
+		return Allocation{}, err
+	}
+	ip, _, err := net.ParseCIDR(resp.Address)
+	if err != nil {
+		return Allocation{}, fmt.Errorf("containerd: ipam plugin %s: invalid address %q", p.path, resp.Address)
+	}
+	a := Allocation{
+		Address: resp.Address,
+		Gateway: resp.Gateway,
+	}
+	if a.Gateway == "" {
+		a.Gateway = n.Gateway
+	}
+	if g := net.ParseIP(a.Gateway); a.Gateway != "" && (g == nil || (g.To4() == nil) != (ip.To4() == nil)) {
+		return Allocation{}, fmt.Errorf("containerd: ipam plugin %s: gateway %q is not an address of the family of %s", p.path, a.Gateway, a.Address)
+	}
+	return a, nil
+}
+
+// Release asks the allocator to free the address of the container
+func (p *Plugin) Release(name string, n Network, id string) error {
+	_, err := p.call(newRequest(ReleaseMethod, name, n, id))
+	return err
+}
+
+func newRequest(m Method, name string, n Network, id string) *Request {
+	return &Request{
+		Method:     m,
+		Network:    name,
+		Subnet:     n.Subnet,
+		Gateway:    n.Gateway,
+		RangeStart: n.RangeStart,
+		RangeEnd:   n.RangeEnd,
+		ID:         id,
+	}
+}
+
+func (p *Plugin) call(r *Request) (*Response, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	out, err := p.callSocket(data)
+	if err != nil {
+		return nil, fmt.Errorf("containerd: ipam plugin %s: %v", p.path, err)
+	}
+	var resp Response
+	if len(bytes.TrimSpace(out)) > 0 {
+		if err := json.Unmarshal(out, &resp); err != nil {
+			return nil, fmt.Errorf("containerd: ipam plugin %s: invalid response: %v", p.path, err)
+		}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("containerd: ipam plugin %s: %s %s: %s", p.path, r.Method, r.Network, resp.Error)
+	}
+	return &resp, nil
+}
+
+func (p *Plugin) callSocket(data []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("unix", p.path, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return nil, err
+	}
+	// the allocator reads the request until the write side is closed
+	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(conn)
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strings"
+)
+
+const journaldSocket = "/run/systemd/journal/socket"
+
+func init() {
+	Register("journald", newJournald, validateJournaldOptions)
+}
+
+// journald writes entries using the journal's native protocol
+// http://www.freedesktop.org/wiki/Software/systemd/export/
+type journald struct {
+	conn   *net.UnixConn
+	fields map[string]string
+}
+
+func newJournald(info Info) (Driver, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{
+		Name: journaldSocket,
+		Net:  "unixgram",
+	})
+	if err != nil {
+		return nil, err
+	}
+	tag := info.Options["tag"]
+	if tag == "" {
+		tag = "containerd"
+	}
+	return &journald{
+		conn: conn,
+		fields: map[string]string{
+			"CONTAINER_ID":      info.ContainerID,
+			"CONTAINER_PROCESS": info.ProcessID,
+			"SYSLOG_IDENTIFIER": tag,
+		},
+	}, nil
+}
+
+func validateJournaldOptions(options map[string]string) error {
+	return checkOptions("journald", options, "tag")
+}
+
+func (j *journald) Log(m *Message) error {
+	b := &bytes.Buffer{}
+	priority := "6"
+	if m.Stream == "stderr" {
+		priority = "3"
+	}
+	writeJournalField(b, "PRIORITY", priority)
+	writeJournalField(b, "MESSAGE", string(m.Line))
+	writeJournalField(b, "CONTAINER_STREAM", m.Stream)
+	for k, v := range j.fields {
+		writeJournalField(b, k, v)
+	}
+	_, err := j.conn.Write(b.Bytes())
+	return err
+}
+
+func (j *journald) Close() error {
+	return j.conn.Close()
+}
+
+// writeJournalField writes a single field, using the binary safe format
+// for values that contain newlines
+func writeJournalField(b *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		b.WriteString(key + "=" + value + "\n")
+		return
+	}
+	b.WriteString(key + "\n")
+	binary.Write(b, binary.LittleEndian, uint64(len(value)))
+	b.WriteString(value + "\n")
+}
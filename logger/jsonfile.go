@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+func init() {
+	Register("json-file", newJSONFile, validateJSONFileOptions)
+}
+
+// JSONFilePath returns the path of the file that the json-file driver writes
+// to for process pid of container id in the log directory dir.  The container
+// id is part of the name so that containers sharing a log directory do not
+// write to the same file.
+func JSONFilePath(dir, id, pid string) string {
+	return filepath.Join(dir, id+"-"+pid+"-json.log")
+}
+
+// JSONLog is a single entry in a json-file log
+type JSONLog struct {
+	Log    string    `json:"log"`
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+}
+
+type jsonFile struct {
+	mu       sync.Mutex
+	path     string
+	f        *os.File
+	size     int64
+	opened   time.Time
+	maxSize  int64
+	maxFiles int
+	maxAge   time.Duration
+	onRotate func(string)
+}
+
+func newJSONFile(info Info) (Driver, error) {
+	j := &jsonFile{
+		path:     JSONFilePath(info.Path, info.ContainerID, info.ProcessID),
+		onRotate: info.OnRotate,
+	}
+	if err := parseJSONFileOptions(info.Options, j); err != nil {
+		return nil, err
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func validateJSONFileOptions(options map[string]string) error {
+	return parseJSONFileOptions(options, &jsonFile{})
+}
+
+// parseJSONFileOptions sets the rotation settings of j from the options
+func parseJSONFileOptions(options map[string]string, j *jsonFile) error {
+	if err := checkOptions("json-file", options, "max-size", "max-file", "max-age"); err != nil {
+		return err
+	}
+	j.maxFiles = 1
+	for k, v := range options {
+		var err error
+		switch k {
+		case "max-size":
+			j.maxSize, err = parseSize(v)
+		case "max-file":
+			j.maxFiles, err = strconv.Atoi(v)
+			if err == nil && j.maxFiles < 1 {
+				err = fmt.Errorf("max-file must be at least 1")
+			}
+		case "max-age":
+			j.maxAge, err = time.ParseDuration(v)
+		}
+		if err != nil {
+			return fmt.Errorf("containerd: invalid json-file log option %s=%s: %v", k, v, err)
+		}
+	}
+	return nil
+}
+
+func (j *jsonFile) Log(m *Message) error {
+	data, err := json.Marshal(JSONLog{
+		Log:    string(m.Line) + "\n",
+		Stream: m.Stream,
+		Time:   m.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.shouldRotate(int64(len(data))) {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := j.f.Write(data)
+	j.size += int64(n)
+	return err
+}
+
+func (j *jsonFile) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
+
+func (j *jsonFile) open() error {
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	j.f, j.size, j.opened = f, fi.Size(), time.Now()
+	return nil
+}
+
+// shouldRotate returns true if writing n bytes to the current file would
+// exceed the max size or if the file is older than the max age
+func (j *jsonFile) shouldRotate(n int64) bool {
+	if j.size == 0 {
+		return false
+	}
+	if j.maxSize > 0 && j.size+n > j.maxSize {
+		return true
+	}
+	return j.maxAge > 0 && time.Since(j.opened) > j.maxAge
+}
+
+// rotate shifts the existing files so that the current file becomes
+// <path>.1 and the oldest file above max-file is removed, then reopens
+// an empty file at path
+func (j *jsonFile) rotate() error {
+	if err := j.f.Close(); err != nil {
+		return err
+	}
+	for i := j.maxFiles - 1; i > 0; i-- {
+		from := j.path
+		if i > 1 {
+			from = fmt.Sprintf("%s.%d", j.path, i-1)
+		}
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", j.path, i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// a new file is always created so that readers following the log can
+	// tell that it was rotated
+	if j.maxFiles == 1 {
+		if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := j.open(); err != nil {
+		return err
+	}
+	if j.onRotate != nil {
+		j.onRotate(j.path)
+	}
+	return nil
+}
+
+// parseSize parses a size in bytes with an optional k, m or g suffix
+func parseSize(s string) (int64, error) {
+	var (
+		mult = int64(1)
+		v    = strings.ToLower(strings.TrimSpace(s))
+	)
+	switch {
+	case strings.HasSuffix(v, "k"):
+		mult = 1024
+	case strings.HasSuffix(v, "m"):
+		mult = 1024 * 1024
+	case strings.HasSuffix(v, "g"):
+		mult = 1024 * 1024 * 1024
+	}
+	if mult != 1 {
+		v = v[:len(v)-1]
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("size cannot be negative")
+	}
+	return n * mult, nil
+}
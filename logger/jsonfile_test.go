@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestJSONFileRotation(t *testing.T) {
+	root, err := ioutil.TempDir("", "containerd-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	var rotations int
+	d, err := New("json-file", Info{
+		ContainerID: "test",
+		ProcessID:   "init",
+		Path:        root,
+		Options: map[string]string{
+			"max-size": "100",
+			"max-file": "2",
+		},
+		OnRotate: func(string) { rotations++ },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if err := d.Log(&Message{Stream: "stdout", Line: []byte("a line of output"), Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rotations == 0 {
+		t.Fatal("expected the log file to be rotated")
+	}
+	path := JSONFilePath(root, "test", "init")
+	for _, p := range []string{path, path + ".1"} {
+		fi, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() > 100 {
+			t.Fatalf("expected %s to be at most 100 bytes but it is %d", p, fi.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Fatalf("expected only %d files to be kept", 2)
+	}
+}
+
+func TestReadJSONFileTail(t *testing.T) {
+	root, err := ioutil.TempDir("", "containerd-logger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	d, err := New("json-file", Info{
+		ContainerID: "test",
+		ProcessID:   "init",
+		Path:        root,
+		Options: map[string]string{
+			"max-size": "200",
+			"max-file": "10",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []string{"one", "two", "three", "four", "five"} {
+		if err := d.Log(&Message{Stream: "stdout", Line: []byte(l), Timestamp: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d.Close()
+	var lines []string
+	if err := ReadJSONFile(JSONFilePath(root, "test", "init"), ReadConfig{Tail: 3}, nil, func(l *JSONLog) error {
+		lines = append(lines, l.Log)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"three\n", "four\n", "five\n"}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %v but received %v", expected, lines)
+	}
+	for i := range lines {
+		if lines[i] != expected[i] {
+			t.Fatalf("expected %v but received %v", expected, lines)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownDriver is returned when a log driver is requested that has not been registered
+var ErrUnknownDriver = errors.New("containerd: unknown log driver")
+
+// Message is a single line of output from a container's process
+type Message struct {
+	// Stream is the name of the stdio stream, stdout or stderr
+	Stream string
+	// Line is the output without the trailing newline
+	Line []byte
+	// Timestamp is the time the line was read from the process
+	Timestamp time.Time
+}
+
+// Info is the information about the process that a driver is logging for
+type Info struct {
+	ContainerID string
+	ProcessID   string
+	// Path is the directory that file based drivers write to
+	Path string
+	// Options are the driver specific options such as max-size for json-file
+	Options map[string]string
+	// OnRotate is called by file based drivers after the file at path has
+	// been rotated so that readers know to reopen it
+	OnRotate func(path string)
+}
+
+// Driver persists the output of a container's process
+type Driver interface {
+	io.Closer
+	// Log writes the message to the driver's destination.  It must be safe
+	// to call Log from multiple goroutines.
+	Log(*Message) error
+}
+
+// Creator returns a new driver for the process described by info
+type Creator func(info Info) (Driver, error)
+
+// Validator returns an error if the options are not valid for a driver
+type Validator func(options map[string]string) error
+
+type registration struct {
+	create   Creator
+	validate Validator
+}
+
+var (
+	mu      sync.Mutex
+	drivers = make(map[string]registration)
+)
+
+// Register makes a log driver available under the provided name
+func Register(name string, c Creator, v Validator) {
+	mu.Lock()
+	defer mu.Unlock()
+	drivers[name] = registration{
+		create:   c,
+		validate: v,
+	}
+}
+
+// Drivers returns the names of all registered drivers
+func Drivers() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	var names []string
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns an error if name is not a registered driver or if the
+// options are not valid for it
+func Validate(name string, options map[string]string) error {
+	mu.Lock()
+	r, ok := drivers[name]
+	mu.Unlock()
+	if !ok {
+		return ErrUnknownDriver
+	}
+	return r.validate(options)
+}
+
+// New returns a new instance of the driver registered for name
+func New(name string, info Info) (Driver, error) {
+	mu.Lock()
+	r, ok := drivers[name]
+	mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownDriver
+	}
+	if err := r.validate(info.Options); err != nil {
+		return nil, err
+	}
+	return r.create(info)
+}
+
+// checkOptions returns an error for any option that is not in known
+func checkOptions(driver string, options map[string]string, known ...string) error {
+	for k := range options {
+		var found bool
+		for _, o := range known {
+			if k == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("containerd: unknown %s log option %q", driver, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// followInterval is how often a followed log file is checked for new entries
+const followInterval = 250 * time.Millisecond
+
+// ReadConfig selects the entries returned by ReadJSONFile
+type ReadConfig struct {
+	// Tail returns only the last Tail entries when greater than zero
+	Tail int
+	// Since skips entries logged before it when set
+	Since time.Time
+	// Follow keeps returning new entries until done is closed
+	Follow bool
+}
+
+// ReadJSONFile calls fn for the entries of the json-file log at path
+// including its rotated files.  When following, new entries are returned as
+// they are written until done is closed, at which point any remaining entries
+// are read before returning.
+func ReadJSONFile(path string, config ReadConfig, done <-chan struct{}, fn func(*JSONLog) error) error {
+	files, err := openRotated(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	var (
+		tail []*JSONLog
+		emit = func(l *JSONLog) error {
+			if !config.Since.IsZero() && l.Time.Before(config.Since) {
+				return nil
+			}
+			if config.Tail <= 0 {
+				return fn(l)
+			}
+			if tail = append(tail, l); len(tail) > config.Tail {
+				tail = tail[1:]
+			}
+			return nil
+		}
+		current = newLineReader(files[len(files)-1])
+	)
+	for _, f := range files[:len(files)-1] {
+		if err := newLineReader(f).read(emit); err != nil {
+			return err
+		}
+	}
+	if err := current.read(emit); err != nil {
+		return err
+	}
+	for _, l := range tail {
+		if err := fn(l); err != nil {
+			return err
+		}
+	}
+	if !config.Follow {
+		return nil
+	}
+	for {
+		select {
+		case <-done:
+			return current.read(fn)
+		case <-time.After(followInterval):
+		}
+		if err := current.read(fn); err != nil {
+			return err
+		}
+		// after a rotation the remaining entries of the old file are read
+		// before moving to the new file at path
+		if rotated, err := isRotated(path, current.f); err != nil {
+			return err
+		} else if rotated {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			if err := current.read(fn); err != nil {
+				f.Close()
+				return err
+			}
+			current.f.Close()
+			current = newLineReader(f)
+			files = append(files, f)
+		}
+	}
+}
+
+// openRotated opens the rotated files of path followed by path itself in
+// the order that they were written
+func openRotated(path string) ([]*os.File, error) {
+	var files []*os.File
+	for i := 1; ; i++ {
+		f, err := os.Open(fmt.Sprintf("%s.%d", path, i))
+		if err != nil {
+			if os.IsNotExist(err) {
+				break
+			}
+			return nil, err
+		}
+		files = append([]*os.File{f}, files...)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		for _, f := range files {
+			f.Close()
+		}
+		return nil, err
+	}
+	return append(files, f), nil
+}
+
+func isRotated(path string, f *os.File) (bool, error) {
+	current, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !os.SameFile(current, fi), nil
+}
+
+// lineReader decodes the complete lines of a file that may still be written
+type lineReader struct {
+	f       *os.File
+	r       *bufio.Reader
+	partial []byte
+}
+
+func newLineReader(f *os.File) *lineReader {
+	return &lineReader{
+		f: f,
+		r: bufio.NewReader(f),
+	}
+}
+
+// read calls fn for each complete line available in the file
+func (l *lineReader) read(fn func(*JSONLog) error) error {
+	for {
+		line, err := l.r.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				// hold on to a partially written line until it is complete
+				l.partial = append(l.partial, line...)
+				return nil
+			}
+			return err
+		}
+		if len(l.partial) > 0 {
+			line = append(l.partial, line...)
+			l.partial = nil
+		}
+		var entry JSONLog
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return err
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+}
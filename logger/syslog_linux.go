@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"net/url"
+)
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+func init() {
+	Register("syslog", newSyslog, validateSyslogOptions)
+}
+
+type syslogDriver struct {
+	w *syslog.Writer
+}
+
+func newSyslog(info Info) (Driver, error) {
+	var (
+		network, addr string
+		facility      = syslog.LOG_DAEMON
+		tag           = fmt.Sprintf("containerd/%s/%s", info.ContainerID, info.ProcessID)
+	)
+	if a := info.Options["syslog-address"]; a != "" {
+		u, err := url.Parse(a)
+		if err != nil {
+			return nil, err
+		}
+		network, addr = u.Scheme, u.Host
+		if network == "unix" || network == "unixgram" {
+			addr = u.Path
+		}
+	}
+	if f := info.Options["syslog-facility"]; f != "" {
+		facility = syslogFacilities[f]
+	}
+	if t := info.Options["tag"]; t != "" {
+		tag = t
+	}
+	w, err := syslog.Dial(network, addr, facility, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogDriver{
+		w: w,
+	}, nil
+}
+
+func validateSyslogOptions(options map[string]string) error {
+	if err := checkOptions("syslog", options, "syslog-address", "syslog-facility", "tag"); err != nil {
+		return err
+	}
+	if a := options["syslog-address"]; a != "" {
+		u, err := url.Parse(a)
+		if err != nil {
+			return fmt.Errorf("containerd: invalid syslog-address %q: %v", a, err)
+		}
+		switch u.Scheme {
+		case "tcp", "udp", "unix", "unixgram":
+		default:
+			return fmt.Errorf("containerd: unsupported syslog-address scheme %q", u.Scheme)
+		}
+	}
+	if f := options["syslog-facility"]; f != "" {
+		if _, ok := syslogFacilities[f]; !ok {
+			return fmt.Errorf("containerd: invalid syslog-facility %q", f)
+		}
+	}
+	return nil
+}
+
+func (s *syslogDriver) Log(m *Message) error {
+	if m.Stream == "stderr" {
+		return s.w.Err(string(m.Line))
+	}
+	return s.w.Info(string(m.Line))
+}
+
+func (s *syslogDriver) Close() error {
+	return s.w.Close()
+}
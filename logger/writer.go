@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logging"
+)
+
+var log = logging.Logger("logger")
+
+// NewWriter returns a writer that splits everything written to it on newlines
+// and sends each line to the driver as a message for stream
+func NewWriter(d Driver, stream string) io.WriteCloser {
+	return &writer{
+		d:      d,
+		stream: stream,
+	}
+}
+
+// maxLineSize is the size at which a line is sent to the driver before its
+// newline is written so that the buffer of a process that never writes a
+// newline does not grow without bound
+const maxLineSize = 16 * 1024
+
+type writer struct {
+	d      Driver
+	stream string
+	buf    []byte
+	// failed is the number of messages the driver failed to log
+	failed int
+}
+
+// Write never returns the errors of the driver so that a driver that cannot
+// log does not stop the copy of the output to the other writers of an
+// io.MultiWriter, the messages that failed are counted and reported on Close
+func (w *writer) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 || i > maxLineSize {
+			if len(w.buf) < maxLineSize {
+				break
+			}
+			w.log(w.buf[:maxLineSize])
+			w.buf = w.buf[maxLineSize:]
+			continue
+		}
+		w.log(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close flushes any partial line that is buffered to the driver
+func (w *writer) Close() error {
+	var err error
+	if len(w.buf) > 0 {
+		err = w.log(w.buf)
+		w.buf = nil
+	}
+	if w.failed > 0 {
+		log.WithFields(logrus.Fields{
+			"stream": w.stream,
+			"failed": w.failed,
+		}).Warn("containerd: log driver failed to log messages")
+	}
+	return err
+}
+
+func (w *writer) log(line []byte) error {
+	// drivers may hold on to the message so the line is copied out of the buffer
+	l := make([]byte, len(line))
+	copy(l, line)
+	err := w.d.Log(&Message{
+		Stream:    w.stream,
+		Line:      l,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		// only the first failure is logged as a driver that fails usually
+		// fails for every message
+		if w.failed == 0 {
+			log.WithFields(logrus.Fields{
+				"stream": w.stream,
+				"error":  err,
+			}).Warn("containerd: log message")
+		}
+		w.failed++
+	}
+	return err
+}
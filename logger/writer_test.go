@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type recorder struct {
+	messages []*Message
+}
+
+func (r *recorder) Log(m *Message) error {
+	r.messages = append(r.messages, m)
+	return nil
+}
+
+func (r *recorder) Close() error {
+	return nil
+}
+
+func TestWriterSplitsLines(t *testing.T) {
+	r := &recorder{}
+	w := NewWriter(r, "stdout")
+	for _, s := range []string{"hello\nwor", "ld\n", "partial"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"hello", "world", "partial"}
+	if len(r.messages) != len(expected) {
+		t.Fatalf("expected %d messages but received %d", len(expected), len(r.messages))
+	}
+	for i, m := range r.messages {
+		if string(m.Line) != expected[i] || m.Stream != "stdout" {
+			t.Fatalf("expected %q on stdout but received %q on %s", expected[i], m.Line, m.Stream)
+		}
+	}
+}
+
+type failingDriver struct {
+	recorder
+}
+
+func (d *failingDriver) Log(m *Message) error {
+	d.recorder.Log(m)
+	return errors.New("driver failed")
+}
+
+func TestWriterIgnoresDriverErrors(t *testing.T) {
+	d := &failingDriver{}
+	var out bytes.Buffer
+	w := NewWriter(d, "stdout")
+	if _, err := io.Copy(io.MultiWriter(w, &out), strings.NewReader("one\ntwo\n")); err != nil {
+		t.Fatalf("expected the copy to continue after the driver failed: %v", err)
+	}
+	if out.String() != "one\ntwo\n" || len(d.messages) != 2 {
+		t.Fatalf("expected the output to be copied and both lines to be logged but received %q and %d messages", out.String(), len(d.messages))
+	}
+}
+
+func TestWriterSplitsLongLines(t *testing.T) {
+	r := &recorder{}
+	w := NewWriter(r, "stdout")
+	long := strings.Repeat("a", 2*maxLineSize+1)
+	if _, err := w.Write([]byte(long)); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.messages) != 2 {
+		t.Fatalf("expected 2 messages before the newline but received %d", len(r.messages))
+	}
+	if _, err := w.Write([]byte("b\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{maxLineSize, maxLineSize, 2}
+	if len(r.messages) != len(expected) {
+		t.Fatalf("expected %d messages but received %d", len(expected), len(r.messages))
+	}
+	for i, m := range r.messages {
+		if len(m.Line) != expected[i] {
+			t.Fatalf("expected message %d to be %d bytes but it is %d", i, expected[i], len(m.Line))
+		}
+	}
+}
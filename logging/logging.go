@@ -0,0 +1,98 @@
+// Package logging provides leveled, structured loggers for the subsystems of
+// the daemon.  Every subsystem logs at the global level and format unless it
+// has its own level override.
+package logging
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+)
+
+var (
+	mu        sync.Mutex
+	loggers   = make(map[string]*logrus.Logger)
+	overrides = make(map[string]logrus.Level)
+)
+
+// Logger returns the logger of a subsystem, its entries carry the name of the
+// subsystem in the module field
+func Logger(subsystem string) *logrus.Entry {
+	mu.Lock()
+	defer mu.Unlock()
+	l, ok := loggers[subsystem]
+	if !ok {
+		l = logrus.New()
+		loggers[subsystem] = l
+		apply(subsystem, l)
+	}
+	return logrus.NewEntry(l).WithField("module", subsystem)
+}
+
+// Writer returns a writer that logs each line written to it at the info level
+// of the subsystem's logger, for libraries that log with the log package
+func Writer(subsystem string) io.Writer {
+	l := Logger(subsystem)
+	r, w := io.Pipe()
+	go func() {
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			l.Info(s.Text())
+		}
+	}()
+	return w
+}
+
+// Configure sets the global level and format of the logs and the level
+// overrides of subsystems in the subsystem=level format
+func Configure(level, format string, subsystemLevels []string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	var formatter logrus.Formatter
+	switch format {
+	case "", "text":
+		formatter = &logrus.TextFormatter{}
+	case "json":
+		formatter = &logrus.JSONFormatter{}
+	default:
+		return fmt.Errorf("unknown log format %q", format)
+	}
+	o := make(map[string]logrus.Level)
+	for _, s := range subsystemLevels {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("invalid subsystem log level %q, expected subsystem=level", s)
+		}
+		l, err := logrus.ParseLevel(parts[1])
+		if err != nil {
+			return err
+		}
+		o[parts[0]] = l
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	logrus.SetLevel(lvl)
+	logrus.SetFormatter(formatter)
+	overrides = o
+	for name, l := range loggers {
+		apply(name, l)
+	}
+	return nil
+}
+
+// apply copies the settings of the standard logger to the subsystem's logger
+func apply(subsystem string, l *logrus.Logger) {
+	std := logrus.StandardLogger()
+	l.Out = std.Out
+	l.Formatter = std.Formatter
+	l.Level = std.Level
+	if lvl, ok := overrides[subsystem]; ok {
+		l.Level = lvl
+	}
+}
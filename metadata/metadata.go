@@ -0,0 +1,512 @@
+// Package metadata is an embedded database of the daemon's records kept in a
+// single file.
+//
+// The records are values stored by key in named buckets.  A transaction that
+// changes the database is appended to the file as a single checksummed record
+// and the file is synced before the changes are visible, so that a crash
+// leaves every transaction either committed or absent.  A record at the end of
+// the file that was only partly written when the host crashed is dropped when
+// the database is opened.  A corrupt record followed by other records is not
+// the result of a crash, the database is not opened so that the records after
+// it are not lost.
+// The file is rewritten with only the current buckets and keys once it mostly
+// holds changes that were overwritten.
+package metadata
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logging"
+)
+
+var log = logging.Logger("metadata")
+
+const (
+	// compactMinOps is the number of operations the file holds before it is
+	// compacted
+	compactMinOps = 1024
+	// headerSize is the size of the length and the checksum of a record
+	headerSize = 8
+	// tornSuffix is the suffix of the copy of a file whose tail was dropped
+	tornSuffix = ".torn"
+)
+
+var (
+	ErrDatabaseClosed     = errors.New("metadata: database is closed")
+	ErrTxNotWritable      = errors.New("metadata: transaction is not writable")
+	ErrBucketNotFound     = errors.New("metadata: bucket not found")
+	ErrBucketNameRequired = errors.New("metadata: bucket name required")
+	ErrCorrupt            = errors.New("metadata: corrupt record before the end of the database")
+	errCorruptRecord      = errors.New("metadata: corrupt record")
+)
+
+const (
+	opCreateBucket = "create-bucket"
+	opDeleteBucket = "delete-bucket"
+	opPut          = "put"
+	opDelete       = "delete"
+)
+
+// op is a change of a transaction as it is written to the file
+type op struct {
+	Op     string `json:"op"`
+	Bucket string `json:"bucket"`
+	Key    string `json:"key,omitempty"`
+	Value  []byte `json:"value,omitempty"`
+}
+
+// DB is a database opened from a file, it is safe for concurrent use.  Read
+// transactions run concurrently and write transactions run one at a time.
+type DB struct {
+	mu      sync.RWMutex
+	path    string
+	f       *os.File
+	buckets map[string]map[string][]byte
+	// size is the size of the file's committed records
+	size int64
+	// ops is the number of operations in the file
+	ops int
+}
+
+// Open opens the database in the file at path, the file is created if it does
+// not exist
+func Open(path string) (*DB, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	db := &DB{
+		path:    path,
+		buckets: make(map[string]map[string][]byte),
+	}
+	var off int
+	for off < len(data) {
+		ops, n, err := decodeRecord(data[off:])
+		if err != nil {
+			if !tornTail(data[off:]) {
+				log.WithFields(logrus.Fields{
+					"path":   path,
+					"offset": off,
+				}).Error("metadata: corrupt record is followed by other records")
+				return nil, ErrCorrupt
+			}
+			// the record was not completely written before a crash
+			log.WithFields(logrus.Fields{
+				"path":    path,
+				"offset":  off,
+				"dropped": len(data) - off,
+				"copy":    path + tornSuffix,
+			}).Warn("metadata: drop partially written record at the end of the database")
+			break
+		}
+		for _, o := range ops {
+			apply(db.buckets, o)
+		}
+		db.ops += len(ops)
+		off += n
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if off < len(data) {
+		// a copy of the file is kept in case the length of a record in the
+		// middle of the file was corrupted and the records after it are
+		// dropped with the tail
+		if err := ioutil.WriteFile(path+tornSuffix, data, 0600); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Truncate(int64(off)); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	db.f, db.size = f, int64(off)
+	return db, nil
+}
+
+// Path returns the path of the database's file
+func (db *DB) Path() string {
+	return db.path
+}
+
+// Close closes the file of the database, the transactions that are run after
+// it was closed fail with ErrDatabaseClosed
+func (db *DB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.f == nil {
+		return nil
+	}
+	err := db.f.Close()
+	db.f = nil
+	return err
+}
+
+// View runs fn in a read-only transaction
+func (db *DB) View(fn func(*Tx) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	if db.f == nil {
+		return ErrDatabaseClosed
+	}
+	return fn(&Tx{
+		buckets: db.buckets,
+	})
+}
+
+// Update runs fn in a write transaction.  The changes of fn are committed when
+// it returns nil and they are discarded when it returns an error.
+func (db *DB) Update(fn func(*Tx) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.f == nil {
+		return ErrDatabaseClosed
+	}
+	tx := &Tx{
+		writable: true,
+		buckets:  make(map[string]map[string][]byte, len(db.buckets)),
+		copied:   make(map[string]bool),
+	}
+	for name, b := range db.buckets {
+		tx.buckets[name] = b
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.ops) == 0 {
+		return nil
+	}
+	record, err := encodeRecord(tx.ops)
+	if err != nil {
+		return err
+	}
+	if err := db.append(record); err != nil {
+		return err
+	}
+	db.buckets = tx.buckets
+	db.ops += len(tx.ops)
+	if db.ops > compactMinOps && db.ops > 2*db.live() {
+		// the transaction is committed, a failed compaction leaves the
+		// larger file in place
+		db.compact()
+	}
+	return nil
+}
+
+// append writes the record to the file and syncs it, a record that failed to
+// be written is truncated so that the next records are not appended after it
+func (db *DB) append(record []byte) error {
+	_, err := db.f.Write(record)
+	if err == nil {
+		err = db.f.Sync()
+	}
+	if err != nil {
+		db.f.Truncate(db.size)
+		return err
+	}
+	db.size += int64(len(record))
+	return nil
+}
+
+// live returns the number of buckets and keys of the database
+func (db *DB) live() int {
+	n := len(db.buckets)
+	for _, b := range db.buckets {
+		n += len(b)
+	}
+	return n
+}
+
+// compact replaces the file with a file holding a single record of the
+// buckets and keys, the new file is synced before it is renamed over the old
+// one so that a crash leaves either of them
+func (db *DB) compact() error {
+	var ops []op
+	for _, name := range bucketNames(db.buckets) {
+		ops = append(ops, op{Op: opCreateBucket, Bucket: name})
+		b := db.buckets[name]
+		for _, key := range sortedKeys(b) {
+			ops = append(ops, op{Op: opPut, Bucket: name, Key: key, Value: b[key]})
+		}
+	}
+	record, err := encodeRecord(ops)
+	if err != nil {
+		return err
+	}
+	tmp := db.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(record); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, db.path); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if d, err := os.Open(filepath.Dir(db.path)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	db.f.Close()
+	db.f, db.size, db.ops = f, int64(len(record)), len(ops)
+	return nil
+}
+
+// Tx is a transaction of the database, it is only valid while the function it
+// was passed to runs
+type Tx struct {
+	writable bool
+	buckets  map[string]map[string][]byte
+	// copied are the buckets that were copied for the changes of the
+	// transaction, the other buckets are shared with the database
+	copied map[string]bool
+	ops    []op
+}
+
+// Writable returns true if the transaction can change the database
+func (tx *Tx) Writable() bool {
+	return tx.writable
+}
+
+// Bucket returns the bucket with the name or nil if it does not exist
+func (tx *Tx) Bucket(name string) *Bucket {
+	if _, ok := tx.buckets[name]; !ok {
+		return nil
+	}
+	return &Bucket{tx: tx, name: name}
+}
+
+// ForEach calls fn with the buckets of the database sorted by name, it stops
+// at the first error returned by fn
+func (tx *Tx) ForEach(fn func(name string, b *Bucket) error) error {
+	for _, name := range bucketNames(tx.buckets) {
+		if err := fn(name, &Bucket{tx: tx, name: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateBucketIfNotExists returns the bucket with the name, the bucket is
+// created if it does not exist
+func (tx *Tx) CreateBucketIfNotExists(name string) (*Bucket, error) {
+	if !tx.writable {
+		return nil, ErrTxNotWritable
+	}
+	if name == "" {
+		return nil, ErrBucketNameRequired
+	}
+	if _, ok := tx.buckets[name]; !ok {
+		tx.add(op{Op: opCreateBucket, Bucket: name})
+	}
+	return &Bucket{tx: tx, name: name}, nil
+}
+
+// DeleteBucket removes the bucket with the name and all of its keys
+func (tx *Tx) DeleteBucket(name string) error {
+	if !tx.writable {
+		return ErrTxNotWritable
+	}
+	if _, ok := tx.buckets[name]; !ok {
+		return ErrBucketNotFound
+	}
+	tx.add(op{Op: opDeleteBucket, Bucket: name})
+	return nil
+}
+
+// add applies the change to the buckets of the transaction and records it
+// to be written when the transaction is committed
+func (tx *Tx) add(o op) {
+	switch o.Op {
+	case opPut, opDelete:
+		if !tx.copied[o.Bucket] {
+			b := tx.buckets[o.Bucket]
+			c := make(map[string][]byte, len(b)+1)
+			for k, v := range b {
+				c[k] = v
+			}
+			tx.buckets[o.Bucket] = c
+			tx.copied[o.Bucket] = true
+		}
+	case opCreateBucket:
+		tx.copied[o.Bucket] = true
+	case opDeleteBucket:
+		delete(tx.copied, o.Bucket)
+	}
+	apply(tx.buckets, o)
+	tx.ops = append(tx.ops, o)
+}
+
+// Bucket is a named collection of keys and values
+type Bucket struct {
+	tx   *Tx
+	name string
+}
+
+// Get returns the value of the key or nil if it does not exist, the value
+// must not be modified
+func (b *Bucket) Get(key string) []byte {
+	return b.tx.buckets[b.name][key]
+}
+
+// Put sets the value of the key
+func (b *Bucket) Put(key string, value []byte) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+	if _, ok := b.tx.buckets[b.name]; !ok {
+		return ErrBucketNotFound
+	}
+	b.tx.add(op{Op: opPut, Bucket: b.name, Key: key, Value: append([]byte{}, value...)})
+	return nil
+}
+
+// Delete removes the key, removing a key that does not exist is not an error
+func (b *Bucket) Delete(key string) error {
+	if !b.tx.writable {
+		return ErrTxNotWritable
+	}
+	bucket, ok := b.tx.buckets[b.name]
+	if !ok {
+		return ErrBucketNotFound
+	}
+	if _, ok := bucket[key]; ok {
+		b.tx.add(op{Op: opDelete, Bucket: b.name, Key: key})
+	}
+	return nil
+}
+
+// Len returns the number of keys of the bucket
+func (b *Bucket) Len() int {
+	return len(b.tx.buckets[b.name])
+}
+
+// ForEach calls fn with the keys of the bucket and their values sorted by key,
+// it stops at the first error returned by fn.  The bucket must not be changed
+// by fn.
+func (b *Bucket) ForEach(fn func(key string, value []byte) error) error {
+	bucket := b.tx.buckets[b.name]
+	for _, key := range sortedKeys(bucket) {
+		if err := fn(key, bucket[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply changes the buckets with the operation
+func apply(buckets map[string]map[string][]byte, o op) {
+	switch o.Op {
+	case opCreateBucket:
+		if _, ok := buckets[o.Bucket]; !ok {
+			buckets[o.Bucket] = make(map[string][]byte)
+		}
+	case opDeleteBucket:
+		delete(buckets, o.Bucket)
+	case opPut:
+		if b, ok := buckets[o.Bucket]; ok {
+			b[o.Key] = o.Value
+		}
+	case opDelete:
+		if b, ok := buckets[o.Bucket]; ok {
+			delete(b, o.Key)
+		}
+	}
+}
+
+// encodeRecord returns the record of the operations of a transaction: the
+// length and the crc32 checksum of the json encoded operations followed by
+// them
+func encodeRecord(ops []op) ([]byte, error) {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	record := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(data))
+	copy(record[headerSize:], data)
+	return record, nil
+}
+
+// decodeRecord returns the operations of the record at the start of data and
+// the size of the record
+func decodeRecord(data []byte) ([]op, int, error) {
+	if len(data) < headerSize {
+		return nil, 0, errCorruptRecord
+	}
+	n := int(binary.BigEndian.Uint32(data[0:4]))
+	if len(data)-headerSize < n {
+		return nil, 0, errCorruptRecord
+	}
+	payload := data[headerSize : headerSize+n]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(data[4:8]) {
+		return nil, 0, errCorruptRecord
+	}
+	var ops []op
+	if err := json.Unmarshal(payload, &ops); err != nil {
+		return nil, 0, errCorruptRecord
+	}
+	return ops, headerSize + n, nil
+}
+
+// tornTail returns true if the corrupt record at the start of data is the last
+// one, a record that was being appended when the host crashed is cut short or
+// followed by the zeros of blocks that were allocated but not written
+func tornTail(data []byte) bool {
+	if len(data) < headerSize {
+		return true
+	}
+	if n := int(binary.BigEndian.Uint32(data[0:4])); len(data)-headerSize <= n {
+		return true
+	}
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func bucketNames(buckets map[string]map[string][]byte) []string {
+	names := make([]string, 0, len(buckets))
+	for name := range buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(b map[string][]byte) []string {
+	keys := make([]string, 0, len(b))
+	for key := range b {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,235 @@
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func openTestDB(t *testing.T) (*DB, string) {
+	dir, err := ioutil.TempDir("", "containerd-metadata-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := Open(filepath.Join(dir, "metadata.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return db, dir
+}
+
+func put(db *DB, bucket, key, value string) error {
+	return db.Update(func(tx *Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, []byte(value))
+	})
+}
+
+func get(t *testing.T, db *DB, bucket, key string) string {
+	var value []byte
+	if err := db.View(func(tx *Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			value = b.Get(key)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return string(value)
+}
+
+func TestUpdateIsKeptAfterReopen(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	if err := put(db, "containers", "redis", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := put(db, "containers", "nginx", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Update(func(tx *Tx) error {
+		return tx.Bucket("containers").Delete("nginx")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	if db, err := Open(db.Path()); err != nil {
+		t.Fatal(err)
+	} else {
+		defer db.Close()
+		if v := get(t, db, "containers", "redis"); v != "a" {
+			t.Fatalf("expected a but received %q", v)
+		}
+		if v := get(t, db, "containers", "nginx"); v != "" {
+			t.Fatalf("expected the deleted key to be removed but received %q", v)
+		}
+	}
+}
+
+func TestFailedUpdateIsDiscarded(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	defer db.Close()
+	if err := put(db, "containers", "redis", "a"); err != nil {
+		t.Fatal(err)
+	}
+	errFailed := errors.New("failed")
+	if err := db.Update(func(tx *Tx) error {
+		b := tx.Bucket("containers")
+		b.Put("redis", []byte("b"))
+		b.Put("nginx", []byte("c"))
+		if v := string(b.Get("redis")); v != "b" {
+			t.Fatalf("expected the transaction to read its own change but received %q", v)
+		}
+		return errFailed
+	}); err != errFailed {
+		t.Fatalf("expected the error of the transaction but received %v", err)
+	}
+	if v := get(t, db, "containers", "redis"); v != "a" {
+		t.Fatalf("expected a but received %q", v)
+	}
+	if v := get(t, db, "containers", "nginx"); v != "" {
+		t.Fatalf("expected the key of the failed transaction to not exist but received %q", v)
+	}
+	if err := db.View(func(tx *Tx) error {
+		return tx.Bucket("containers").Put("redis", nil)
+	}); err != ErrTxNotWritable {
+		t.Fatalf("expected ErrTxNotWritable but received %v", err)
+	}
+}
+
+func TestPartialRecordIsDropped(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	if err := put(db, "containers", "redis", "a"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	record, err := encodeRecord([]op{{Op: opPut, Bucket: "containers", Key: "nginx", Value: []byte("b")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(db.Path(), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a crash while the record was written
+	f.Write(record[:len(record)-1])
+	f.Close()
+
+	db, err = Open(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := get(t, db, "containers", "nginx"); v != "" {
+		t.Fatalf("expected the partial record to be dropped but received %q", v)
+	}
+	if err := put(db, "containers", "mysql", "c"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	db, err = Open(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if v := get(t, db, "containers", "mysql"); v != "c" {
+		t.Fatalf("expected the record written after the partial one to be kept but received %q", v)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	for i := 0; i < 2*compactMinOps; i++ {
+		if err := put(db, "containers", fmt.Sprintf("c%d", i%10), fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if db.ops > compactMinOps {
+		t.Fatalf("expected the database to be compacted but it holds %d operations", db.ops)
+	}
+	db.Close()
+	db, err := Open(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var keys []string
+	db.View(func(tx *Tx) error {
+		return tx.Bucket("containers").ForEach(func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		})
+	})
+	if len(keys) != 10 || keys[0] != "c0" || keys[9] != "c9" {
+		t.Fatalf("expected the 10 keys sorted but received %v", keys)
+	}
+	if v := get(t, db, "containers", "c7"); v != fmt.Sprint(2*compactMinOps-1) {
+		t.Fatalf("expected the last value of the key but received %q", v)
+	}
+}
+
+func TestCorruptRecordBeforeTheEnd(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	for _, key := range []string{"redis", "nginx"} {
+		if err := put(db, "containers", key, "a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+	data, err := ioutil.ReadFile(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// flip a byte of the first record's payload
+	data[headerSize] ^= 0xff
+	if err := ioutil.WriteFile(db.Path(), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(db.Path()); err != ErrCorrupt {
+		t.Fatalf("expected ErrCorrupt but received %v", err)
+	}
+	after, err := ioutil.ReadFile(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(data) {
+		t.Fatalf("expected the file to be left as it is but its size changed from %d to %d", len(data), len(after))
+	}
+}
+
+func TestZeroedTailIsDropped(t *testing.T) {
+	db, dir := openTestDB(t)
+	defer os.RemoveAll(dir)
+	if err := put(db, "containers", "redis", "a"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	f, err := os.OpenFile(db.Path(), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// blocks allocated for a record that was never written
+	f.Write(make([]byte, 64))
+	f.Close()
+	db, err = Open(db.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if v := get(t, db, "containers", "redis"); v != "a" {
+		t.Fatalf("expected the committed record to be kept but received %q", v)
+	}
+	if _, err := os.Stat(db.Path() + tornSuffix); err != nil {
+		t.Fatalf("expected a copy of the file with the dropped tail: %v", err)
+	}
+}
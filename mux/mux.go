@@ -0,0 +1,139 @@
+// Package mux implements the framing used to carry a process' stdio streams
+// over a single connection.  Each frame starts with an 8 byte header where the
+// first byte is the stream id and the last 4 bytes are the big endian length
+// of the payload that follows.
+package mux
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Stream identifies the stdio stream that a frame belongs to
+type Stream byte
+
+const (
+	Stdin Stream = iota
+	Stdout
+	Stderr
+)
+
+const (
+	// HeaderSize is the size of a frame's header
+	HeaderSize = 8
+	// MaxFrameSize is the largest frame written including its header.  It is
+	// kept at or below PIPE_BUF so that a frame written to a fifo is atomic.
+	MaxFrameSize = 4096
+)
+
+var ErrInvalidStream = errors.New("containerd: invalid stream in frame header")
+
+func (s Stream) String() string {
+	switch s {
+	case Stdin:
+		return "stdin"
+	case Stdout:
+		return "stdout"
+	case Stderr:
+		return "stderr"
+	}
+	return "unknown"
+}
+
+// Muxer writes frames for multiple streams to a single writer so that the
+// order of writes across the streams is preserved
+type Muxer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// New returns a Muxer that writes frames to w
+func New(w io.Writer) *Muxer {
+	return &Muxer{
+		w: w,
+	}
+}
+
+// Stream returns a writer that frames everything written to it as s
+func (m *Muxer) Stream(s Stream) io.Writer {
+	return &streamWriter{
+		m: m,
+		s: s,
+	}
+}
+
+type streamWriter struct {
+	m *Muxer
+	s Stream
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.m.mu.Lock()
+	defer w.m.mu.Unlock()
+	var (
+		written int
+		frame   = make([]byte, MaxFrameSize)
+	)
+	for len(p) > 0 {
+		n := copy(frame[HeaderSize:], p)
+		putHeader(frame, w.s, n)
+		if _, err := w.m.w.Write(frame[:HeaderSize+n]); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func putHeader(frame []byte, s Stream, n int) {
+	frame[0] = byte(s)
+	frame[1], frame[2], frame[3] = 0, 0, 0
+	binary.BigEndian.PutUint32(frame[4:HeaderSize], uint32(n))
+}
+
+// ReadFrame reads the next frame from r returning its stream and payload
+func ReadFrame(r io.Reader) (Stream, []byte, error) {
+	var header [HeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	s := Stream(header[0])
+	if s > Stderr {
+		return 0, nil, ErrInvalidStream
+	}
+	data := make([]byte, binary.BigEndian.Uint32(header[4:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return s, data, nil
+}
+
+// Demux reads frames from r and copies their payloads to stdout or stderr
+// until r returns io.EOF
+func Demux(r io.Reader, stdout, stderr io.Writer) error {
+	for {
+		s, data, err := ReadFrame(r)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		w := stdout
+		if s == Stderr {
+			w = stderr
+		}
+		if w == nil {
+			continue
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package mux
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestMuxDemux(t *testing.T) {
+	var (
+		b   = &bytes.Buffer{}
+		m   = New(b)
+		big = strings.Repeat("x", MaxFrameSize*2)
+	)
+	m.Stream(Stdout).Write([]byte("out1 "))
+	m.Stream(Stderr).Write([]byte("err1 "))
+	m.Stream(Stdout).Write([]byte(big))
+	var order []Stream
+	for r := bytes.NewReader(b.Bytes()); r.Len() > 0; {
+		s, data, err := ReadFrame(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data)+HeaderSize > MaxFrameSize {
+			t.Fatalf("frame of %d bytes exceeds the max frame size", len(data)+HeaderSize)
+		}
+		order = append(order, s)
+	}
+	if order[0] != Stdout || order[1] != Stderr {
+		t.Fatalf("expected the order of writes to be preserved but received %v", order)
+	}
+	var stdout, stderr bytes.Buffer
+	if err := Demux(bytes.NewReader(b.Bytes()), &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "out1 "+big {
+		t.Fatal("unexpected stdout after demux")
+	}
+	if stderr.String() != "err1 " {
+		t.Fatalf("expected stderr %q but received %q", "err1 ", stderr.String())
+	}
+}
@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// AddAddress adds the labelled address to the interface of the running
+// container, the recorded addresses of the container are updated
+func (c *container) AddAddress(a Address) error {
+	ipnet, err := parseLabelledAddress(a)
+	if err != nil {
+		return err
+	}
+	pid, err := c.networkPid()
+	if err != nil {
+		return err
+	}
+	if err := inNetworkNamespace(pid, func() error {
+		link, err := netlink.LinkByName(a.Interface)
+		if err != nil {
+			return err
+		}
+		return addrAdd(link, ipnet, a.Label)
+	}); err != nil {
+		return fmt.Errorf("containerd: add address %s to %s: %v", a.Address, a.Label, err)
+	}
+	c.refreshAddresses(pid)
+	return nil
+}
+
+// DeleteAddresses removes the addresses with the label from the interface the
+// label belongs to.  The secondary addresses are removed before the primary
+// ones as removing a primary address removes the secondary addresses of its
+// subnet unless the interface promotes them.
+func (c *container) DeleteAddresses(label string) ([]Address, error) {
+	name, err := labelInterface(label)
+	if err != nil {
+		return nil, err
+	}
+	pid, err := c.networkPid()
+	if err != nil {
+		return nil, err
+	}
+	var deleted []Address
+	if err := inNetworkNamespace(pid, func() error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+		addrs, err := addrList(link, netlink.FAMILY_V4)
+		if err != nil {
+			return err
+		}
+		var secondary, primary []netlink.Addr
+		for _, a := range addrs {
+			switch {
+			case a.Label != label:
+			case a.Flags&syscall.IFA_F_SECONDARY != 0:
+				secondary = append(secondary, a)
+			default:
+				primary = append(primary, a)
+			}
+		}
+		for _, a := range append(secondary, primary...) {
+			if err := addrDel(link, a.IPNet); err != nil && !isErrno(err, syscall.EADDRNOTAVAIL) {
+				return fmt.Errorf("delete address %s: %v", a.IPNet, err)
+			}
+			deleted = append(deleted, Address{Interface: name, Label: label, Address: a.IPNet.String()})
+		}
+		return nil
+	}); err != nil {
+		return deleted, fmt.Errorf("containerd: delete addresses of %s: %v", label, err)
+	}
+	if len(deleted) == 0 {
+		return nil, ErrAddressLabelNotFound
+	}
+	c.refreshAddresses(pid)
+	return deleted, nil
+}
+
+// ListAddresses returns the addresses of the interfaces of the running
+// container with the label, or all of them when the label is empty.  The
+// primary IPv4 addresses are labelled with the name of their interface.
+func (c *container) ListAddresses(label string) ([]Address, error) {
+	pid, err := c.networkPid()
+	if err != nil {
+		return nil, err
+	}
+	var list []Address
+	if err := inNetworkNamespace(pid, func() error {
+		links, err := netlink.LinkList()
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			addrs, err := addrList(link, netlink.FAMILY_ALL)
+			if err != nil {
+				return err
+			}
+			for _, a := range addrs {
+				if label != "" && a.Label != label {
+					continue
+				}
+				list = append(list, Address{
+					Interface: link.Attrs().Name,
+					Label:     a.Label,
+					Address:   a.IPNet.String(),
+				})
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("containerd: list addresses: %v", err)
+	}
+	return list, nil
+}
+
+// networkPid returns the pid of the running container's init process whose
+// network namespace is changed, the host's network is never changed
+func (c *container) networkPid() (int, error) {
+	init, ok := c.processes[InitProcessID]
+	if !ok {
+		return 0, ErrContainerNotStarted
+	}
+	spec, err := c.Spec()
+	if err != nil {
+		return 0, err
+	}
+	if !hasNetworkNamespace(spec) {
+		return 0, &InterfaceError{Reason: "the container shares the host's network namespace"}
+	}
+	return init.SystemPid(), nil
+}
+
+// refreshAddresses records the addresses of the container after they changed
+func (c *container) refreshAddresses(pid int) {
+	addrs, err := networkAddresses(pid)
+	if err == nil {
+		err = c.setAddresses(addrs)
+	}
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Warn("containerd: record container addresses")
+	}
+}
+
+// parseLabelledAddress checks the address and returns it, the label must
+// belong to the interface and the address must be an IPv4 address as the
+// kernel drops the labels of the other families
+func parseLabelledAddress(a Address) (*net.IPNet, error) {
+	if !validInterfaceName(a.Interface) {
+		return nil, &InterfaceError{Name: a.Interface, Reason: "invalid name"}
+	}
+	name, err := labelInterface(a.Label)
+	if err != nil {
+		return nil, err
+	}
+	if name != a.Interface {
+		return nil, &InterfaceError{Name: a.Interface, Reason: fmt.Sprintf("label %q does not begin with the interface's name", a.Label)}
+	}
+	ip, ipnet, err := net.ParseCIDR(a.Address)
+	if err != nil {
+		return nil, &InterfaceError{Name: a.Interface, Reason: fmt.Sprintf("invalid address %q", a.Address)}
+	}
+	if ip.To4() == nil {
+		return nil, &InterfaceError{Name: a.Interface, Reason: fmt.Sprintf("%s is not an IPv4 address, only IPv4 addresses have labels", a.Address)}
+	}
+	ipnet.IP = ip
+	return ipnet, nil
+}
+
+// labelInterface returns the name of the interface of a secondary address
+// label, the label is the name followed by a colon and a suffix that fits in
+// the kernel's limit on interface names
+func labelInterface(label string) (string, error) {
+	i := strings.Index(label, ":")
+	if i < 1 || i == len(label)-1 || len(label) >= syscall.IFNAMSIZ {
+		return "", &InterfaceError{Reason: fmt.Sprintf("label %q is not an interface name followed by a colon and a suffix, in at most %d characters", label, syscall.IFNAMSIZ-1)}
+	}
+	name := label[:i]
+	if !validInterfaceName(name) {
+		return "", &InterfaceError{Name: name, Reason: "invalid name"}
+	}
+	return name, nil
+}
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// AdoptedFile is created by a shim once it has adopted a process
+const AdoptedFile = "adopted"
+
+var (
+	adoptedMu sync.Mutex
+	// adopted are the roots of adopted processes by system pid
+	adopted = make(map[int]string)
+)
+
+// Reaped records the exit status of a process reaped by the daemon.  A process
+// whose shim died while the daemon ran is reparented to the daemon so only the
+// daemon's reaper learns the exit status of an adopted process.  Processes
+// that were not reparented to the daemon are never recorded, the shim reports
+// their exit status as unknown.
+func Reaped(pid, status int) {
+	adoptedMu.Lock()
+	root, ok := adopted[pid]
+	delete(adopted, pid)
+	adoptedMu.Unlock()
+	if !ok {
+		return
+	}
+	// the shim polls for the status file so it must never see a partial write
+	tmp := filepath.Join(root, ExitStatusFile+".tmp")
+	if err := ioutil.WriteFile(tmp, []byte(fmt.Sprint(status)), 0644); err != nil {
+		log.WithField("error", err).Error("containerd: write exit status of adopted process")
+		return
+	}
+	if err := os.Rename(tmp, filepath.Join(root, ExitStatusFile)); err != nil {
+		log.WithField("error", err).Error("containerd: write exit status of adopted process")
+	}
+}
+
+// unadopt forgets the adopted process when its shim failed to adopt it
+func unadopt(pid int) {
+	adoptedMu.Lock()
+	delete(adopted, pid)
+	adoptedMu.Unlock()
+}
@@ -0,0 +1,43 @@
+package runtime
+
+// Capabilities are the features of the host that containers can use
+type Capabilities struct {
+	KernelVersion string
+	// CRIU is true if criu is found to checkpoint and restore containers
+	CRIU     bool
+	Seccomp  bool
+	AppArmor bool
+	SELinux  bool
+	// CgroupVersion is 1 or 2, 0 if there are no cgroups
+	CgroupVersion int
+	// CgroupControllers are the enabled cgroup controllers
+	CgroupControllers []string
+	CgroupNamespace   bool
+	UserNamespaces    bool
+	Overlayfs         bool
+	// Rootless is true if the daemon runs unprivileged
+	Rootless bool
+	// CgroupsDelegated is true if the daemon can create the cgroups of
+	// containers
+	CgroupsDelegated bool
+	// Slirp4netns is true if unprivileged containers with their own network
+	// namespace get usermode networking
+	Slirp4netns bool
+	// Realtime is true if containers can be given realtime cpu time
+	Realtime bool
+	// SwapAccounting is true if the memory and swap of containers can be
+	// limited together
+	SwapAccounting bool
+	// GroupNamespaces are the namespace types that groups can share
+	GroupNamespaces []string
+}
+
+// HasCgroupController returns true if the cgroup controller is enabled
+func (c Capabilities) HasCgroupController(name string) bool {
+	for _, n := range c.CgroupControllers {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package runtime
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// HostCapabilities probes the features of the host
+func HostCapabilities() Capabilities {
+	c := Capabilities{
+		KernelVersion:   kernelVersion(),
+		Seccomp:         seccompSupported(),
+		CgroupNamespace: CgroupNamespaceSupported(),
+		CgroupVersion:   cgroupVersion(),
+		UserNamespaces:  userNamespacesSupported(),
+		Overlayfs:       filesystemSupported("overlay"),
+		Rootless:        Rootless(),
+	}
+	c.CgroupsDelegated = CgroupsDelegated()
+	if _, err := exec.LookPath(Slirp4netnsBinary); err == nil {
+		c.Slirp4netns = true
+	}
+	c.CgroupControllers = cgroupControllers(c.CgroupVersion)
+	if _, err := exec.LookPath("criu"); err == nil {
+		c.CRIU = true
+	}
+	if data, err := ioutil.ReadFile("/sys/module/apparmor/parameters/enabled"); err == nil {
+		c.AppArmor = strings.HasPrefix(string(data), "Y")
+	}
+	if _, err := os.Stat("/sys/fs/selinux/enforce"); err == nil {
+		c.SELinux = true
+	}
+	if root, err := cgroups.FindCgroupMountpoint("cpu"); err == nil {
+		if runtime, _, err := realtimeBudget(root); err == nil && runtime != 0 {
+			c.Realtime = true
+		}
+	}
+	if root, err := cgroups.FindCgroupMountpoint("memory"); err == nil {
+		if _, err := os.Stat(filepath.Join(root, "memory.memsw.limit_in_bytes")); err == nil {
+			c.SwapAccounting = true
+		}
+	}
+	for ns := range sandboxNamespaceFiles {
+		c.GroupNamespaces = append(c.GroupNamespaces, ns)
+	}
+	sort.Strings(c.GroupNamespaces)
+	return c
+}
+
+// seccompSupported returns true if the kernel reports the seccomp mode of
+// processes
+func seccompSupported() bool {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if strings.HasPrefix(s.Text(), "Seccomp:") {
+			return true
+		}
+	}
+	return false
+}
+
+func kernelVersion() string {
+	var u syscall.Utsname
+	if err := syscall.Uname(&u); err != nil {
+		return ""
+	}
+	var b []byte
+	for _, c := range u.Release {
+		if c == 0 {
+			break
+		}
+		b = append(b, byte(c))
+	}
+	return string(b)
+}
+
+// userNamespacesSupported returns true if the kernel has user namespaces and
+// allows creating them
+func userNamespacesSupported() bool {
+	if _, err := os.Stat("/proc/self/ns/user"); err != nil {
+		return false
+	}
+	if data, err := ioutil.ReadFile("/proc/sys/user/max_user_namespaces"); err == nil {
+		return strings.TrimSpace(string(data)) != "0"
+	}
+	return true
+}
+
+// filesystemSupported returns true if the kernel knows the filesystem or has
+// its module loaded
+func filesystemSupported(name string) bool {
+	if _, err := os.Stat(filepath.Join("/sys/module", name)); err == nil {
+		return true
+	}
+	data, err := ioutil.ReadFile("/proc/filesystems")
+	if err != nil {
+		return false
+	}
+	for _, l := range strings.Split(string(data), "\n") {
+		if f := strings.Fields(l); len(f) > 0 && f[len(f)-1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// cgroupControllers returns the enabled controllers of the cgroup version
+func cgroupControllers(version int) []string {
+	switch version {
+	case 2:
+		data, err := ioutil.ReadFile("/sys/fs/cgroup/cgroup.controllers")
+		if err != nil {
+			return nil
+		}
+		controllers := strings.Fields(string(data))
+		sort.Strings(controllers)
+		return controllers
+	case 1:
+		f, err := os.Open("/proc/cgroups")
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+		var controllers []string
+		s := bufio.NewScanner(f)
+		for s.Scan() {
+			// #subsys_name hierarchy num_cgroups enabled
+			f := strings.Fields(s.Text())
+			if len(f) == 4 && !strings.HasPrefix(f[0], "#") && f[3] == "1" {
+				controllers = append(controllers, f[0])
+			}
+		}
+		sort.Strings(controllers)
+		return controllers
+	}
+	return nil
+}
+
+func cgroupVersion() int {
+	if _, err := os.Stat("/sys/fs/cgroup/cgroup.controllers"); err == nil {
+		return 2
+	}
+	if _, err := os.Stat("/proc/self/cgroup"); err == nil {
+		return 1
+	}
+	return 0
+}
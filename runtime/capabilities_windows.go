@@ -0,0 +1,7 @@
+package runtime
+
+// HostCapabilities returns no capabilities as none of the probed features
+// exist on Windows
+func HostCapabilities() Capabilities {
+	return Capabilities{}
+}
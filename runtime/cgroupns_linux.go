@@ -0,0 +1,34 @@
+package runtime
+
+import "os"
+
+// cgroupNamespace is the namespace type of cgroup namespaces in the spec
+const cgroupNamespace = "cgroup"
+
+// CgroupNamespaceSupported returns true if the kernel supports cgroup
+// namespaces
+func CgroupNamespaceSupported() bool {
+	_, err := os.Stat("/proc/self/ns/cgroup")
+	return err == nil
+}
+
+// InjectCgroupNamespace adds a new cgroup namespace to the spec of the bundle
+// so that the container only sees its own cgroup subtree.  Fields of the spec
+// that are unknown to containerd are preserved.
+func InjectCgroupNamespace(bundle string) error {
+	if !CgroupNamespaceSupported() {
+		return ErrCgroupNSNotSupported
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, namespaces := specNamespaces(spec)
+		for _, n := range namespaces {
+			if ns, ok := n.(map[string]interface{}); ok && ns["type"] == cgroupNamespace {
+				return false, nil
+			}
+		}
+		linux["namespaces"] = append(namespaces, map[string]interface{}{
+			"type": cgroupNamespace,
+		})
+		return true, nil
+	})
+}
@@ -0,0 +1,10 @@
+package runtime
+
+// CgroupNamespaceSupported returns false as there are no cgroups on Windows
+func CgroupNamespaceSupported() bool {
+	return false
+}
+
+func InjectCgroupNamespace(bundle string) error {
+	return ErrCgroupNSNotSupported
+}
@@ -0,0 +1,168 @@
+package runtime
+
+import (
+	"net"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// The ctnetlink messages and attributes of the netfilter netlink interface,
+// they are missing from the syscall package and the vendored netlink
+const (
+	nfnlSubsysCTNetlink = 1
+	ipctnlMsgCTGet      = 1
+	ipctnlMsgCTDelete   = 2
+
+	ctaTupleOrig  = 1
+	ctaTupleReply = 2
+	ctaZone       = 18
+
+	ctaTupleIP = 1
+
+	ctaIPv4Src = 1
+	ctaIPv4Dst = 2
+	ctaIPv6Src = 3
+	ctaIPv6Dst = 4
+
+	nlaFNested   = 0x8000
+	nlaTypeMask  = 0x3fff
+	nfnetlinkV0  = 0
+	nfgenmsgSize = 4
+)
+
+// nfgenmsg is the header of the netfilter netlink messages
+type nfgenmsg struct {
+	family uint8
+}
+
+func (m *nfgenmsg) Len() int {
+	return nfgenmsgSize
+}
+
+func (m *nfgenmsg) Serialize() []byte {
+	// the resource id is zero in network byte order
+	return []byte{m.family, nfnetlinkV0, 0, 0}
+}
+
+// conntrackEntry is the original tuple of a connection tracking entry, it is
+// sent back to the kernel as is to delete the entry
+type conntrackEntry struct {
+	family uint8
+	orig   []byte
+	zone   []byte
+}
+
+// flushConntrack deletes the connection tracking entries of the daemon's
+// network namespace that have one of the container's recorded addresses as
+// their source or destination, in either direction.  The entries of the
+// ports published to the container with DNAT outlive the container and
+// keep sending the traffic of a new container with the address or the port
+// to the stopped one until they time out.
+func (c *container) flushConntrack() {
+	ips := make(map[string]bool)
+	for _, a := range c.Addresses() {
+		if ip, _, err := net.ParseCIDR(a); err == nil {
+			ips[ip.String()] = true
+		}
+	}
+	if len(ips) == 0 {
+		return
+	}
+	entries, err := conntrackEntries(ips)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Warn("containerd: list connection tracking entries")
+		return
+	}
+	for _, e := range entries {
+		if err := conntrackDelete(e); err != nil && !isErrno(err, syscall.ENOENT) {
+			log.WithFields(logrus.Fields{
+				"error": err,
+				"id":    c.id,
+			}).Warn("containerd: delete connection tracking entry")
+		}
+	}
+}
+
+// conntrackEntries dumps the connection tracking table and returns the
+// entries of the addresses, a host without connection tracking has none
+func conntrackEntries(ips map[string]bool) ([]conntrackEntry, error) {
+	req := nl.NewNetlinkRequest(nfnlSubsysCTNetlink<<8|ipctnlMsgCTGet, syscall.NLM_F_DUMP)
+	req.AddData(&nfgenmsg{family: syscall.AF_UNSPEC})
+	msgs, err := req.Execute(syscall.NETLINK_NETFILTER, 0)
+	if err != nil {
+		if err == syscall.ENOENT || err == syscall.EOPNOTSUPP {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entries []conntrackEntry
+	for _, m := range msgs {
+		if len(m) < nfgenmsgSize {
+			continue
+		}
+		attrs, err := nl.ParseRouteAttr(m[nfgenmsgSize:])
+		if err != nil {
+			return nil, err
+		}
+		e := conntrackEntry{family: m[0]}
+		var match bool
+		for _, a := range attrs {
+			switch a.Attr.Type & nlaTypeMask {
+			case ctaTupleOrig:
+				e.orig = a.Value
+				match = match || tupleHasAddress(a.Value, ips)
+			case ctaTupleReply:
+				match = match || tupleHasAddress(a.Value, ips)
+			case ctaZone:
+				e.zone = a.Value
+			}
+		}
+		if match && e.orig != nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// tupleHasAddress returns true when the source or the destination of the
+// tuple is one of the addresses
+func tupleHasAddress(tuple []byte, ips map[string]bool) bool {
+	attrs, err := nl.ParseRouteAttr(tuple)
+	if err != nil {
+		return false
+	}
+	for _, a := range attrs {
+		if a.Attr.Type&nlaTypeMask != ctaTupleIP {
+			continue
+		}
+		addrs, err := nl.ParseRouteAttr(a.Value)
+		if err != nil {
+			return false
+		}
+		for _, addr := range addrs {
+			switch addr.Attr.Type & nlaTypeMask {
+			case ctaIPv4Src, ctaIPv4Dst, ctaIPv6Src, ctaIPv6Dst:
+				if ips[net.IP(addr.Value).String()] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// conntrackDelete deletes the entry with its original tuple
+func conntrackDelete(e conntrackEntry) error {
+	req := nl.NewNetlinkRequest(nfnlSubsysCTNetlink<<8|ipctnlMsgCTDelete, 0)
+	req.AddData(&nfgenmsg{family: e.family})
+	req.AddData(nl.NewRtAttr(ctaTupleOrig|nlaFNested, e.orig))
+	if e.zone != nil {
+		req.AddData(nl.NewRtAttr(ctaZone, e.zone))
+	}
+	return netlinkExecuteProto(syscall.NETLINK_NETFILTER, req)
+}
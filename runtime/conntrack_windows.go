@@ -0,0 +1,5 @@
+package runtime
+
+// flushConntrack does nothing as there is no connection tracking on Windows
+func (c *container) flushConntrack() {
+}
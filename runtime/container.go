@@ -7,9 +7,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/metadata"
 	"github.com/docker/containerd/specs"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	netcontext "golang.org/x/net/context"
 )
 
 type Container interface {
@@ -17,12 +21,19 @@ type Container interface {
 	ID() string
 	// Path returns the path to the bundle
 	Path() string
-	// Start starts the init process of the container
-	Start(checkpoint string, s Stdio) (Process, error)
-	// Exec starts another process in an existing container
-	Exec(string, specs.ProcessSpec, Stdio) (Process, error)
+	// Start starts the init process of the container, the shim and the
+	// runtime are killed when ctx is done before the container started
+	Start(ctx netcontext.Context, checkpoint string, s Stdio) (Process, error)
+	// Exec starts another process in an existing container, ctx is only
+	// checked before the shim is started since a process could be left
+	// running without its shim
+	Exec(netcontext.Context, string, specs.ProcessSpec, Stdio) (Process, error)
 	// Delete removes the container's state and any resources
 	Delete() error
+	// Release removes the runtime's container and the init process of a
+	// stopped container that is kept, the container's state is kept so that
+	// it is restored as a stopped container
+	Release() error
 	// Processes returns all the containers processes that have been added
 	Processes() ([]Process, error)
 	// State returns the containers runtime state
@@ -31,6 +42,8 @@ type Container interface {
 	Resume() error
 	// Pause pauses a running container
 	Pause() error
+	// Kill sends SIGKILL to every process of the container's cgroup
+	Kill() error
 	// RemoveProcess removes the specified process from the container
 	RemoveProcess(string) error
 	// Checkpoints returns all the checkpoints for a container
@@ -43,14 +56,62 @@ type Container interface {
 	Labels() []string
 	// Pids returns all pids inside the container
 	Pids() ([]int, error)
+	// CgroupPaths returns the cgroup of each controller of the running
+	// container
+	CgroupPaths() (map[string]string, error)
 	// Stats returns realtime container stats and resource information
 	Stats() (*Stat, error)
 	// Name or path of the OCI compliant runtime used to execute the container
 	Runtime() string
+	// LogConfig returns the configuration used to capture the output of the
+	// container's processes
+	LogConfig() LogConfig
+	// StdinOnce returns true if the init process' stdin is closed after the
+	// first attached client detaches
+	StdinOnce() bool
+	// NUMA returns the NUMA nodes that the container's memory is bound to
+	NUMA() NUMAConfig
+	// Network returns the configuration applied to the container's network
+	// namespace when it starts
+	Network() NetworkConfig
+	// Keep returns true if the container is kept after its init process
+	// exits until it is deleted
+	Keep() bool
+	// AutoRemove returns true if the container's bundle is removed with
+	// the container when its init process exits
+	AutoRemove() bool
+	// Addresses returns the addresses of the container's network namespace
+	// in CIDR notation, read when the container was last started
+	Addresses() []string
 	// OOM signals the channel if the container received an OOM notification
 	OOM() (OOM, error)
+	// MemoryPressure returns a notifier for each of the MemoryPressureLevels
+	// of the container's memory cgroup
+	MemoryPressure() ([]MemoryPressure, error)
 	// UpdateResource updates the containers resources to new values
 	UpdateResources(*Resource) error
+	// RootFS returns the host path to the container's root filesystem
+	RootFS() (string, error)
+	// AddDevice grants the running container access to a device
+	AddDevice(Device) error
+	// RemoveDevice revokes the running container's access to a device
+	RemoveDevice(Device) error
+	// AddAddress adds a labelled secondary address to an interface of the
+	// running container
+	AddAddress(Address) error
+	// DeleteAddresses removes the addresses of the running container with
+	// the label and returns them
+	DeleteAddresses(label string) ([]Address, error)
+	// ListAddresses returns the addresses of the running container's
+	// interfaces, only the addresses with the label when it is not empty
+	ListAddresses(label string) ([]Address, error)
+	// Adopt starts a new shim for a running process whose shim died.  The
+	// process' stdio was owned by the dead shim and is not recovered.
+	Adopt(pid string) (Process, error)
+	// Spec returns the parsed and validated spec of the bundle
+	Spec() (*specs.Spec, error)
+	// InvalidateSpec drops the cached spec after the bundle's spec changed
+	InvalidateSpec()
 }
 
 type OOM interface {
@@ -61,10 +122,44 @@ type OOM interface {
 	Removed() bool
 }
 
+// Device is a host device node that is added to or removed from a running
+// container
+type Device struct {
+	// Path is the path to the device node on the host
+	Path string
+	// ContainerPath is the path of the node inside the container, it defaults
+	// to Path
+	ContainerPath string
+	// Permissions are the cgroup permissions in the rwm format, they default
+	// to rwm
+	Permissions string
+	// Mknod creates the node inside the container on add and removes it on
+	// remove
+	Mknod bool
+}
+
+// MemoryPressureLevels are the memory cgroup pressure levels that containers
+// are notified of
+var MemoryPressureLevels = []string{"low", "medium", "critical"}
+
+type MemoryPressure interface {
+	io.Closer
+	FD() int
+	ContainerID() string
+	// Level is the pressure level that the notifier is registered for
+	Level() string
+	Flush()
+	Removed() bool
+}
+
 type Stdio struct {
 	Stdin  string
 	Stdout string
 	Stderr string
+	// Socket passes a socket to the shim for the process' stdio instead of
+	// using the fifo paths.  The process' stdio is then only available
+	// through Attach.
+	Socket bool
 }
 
 func NewStdio(stdin, stdout, stderr string) Stdio {
@@ -82,53 +177,128 @@ func NewStdio(stdin, stdout, stderr string) Stdio {
 	}
 }
 
-// New returns a new container
-func New(root, id, bundle, runtimeName string, runtimeArgs, labels []string) (Container, error) {
+// ContainerOpts are the settings of a new container
+type ContainerOpts struct {
+	// Root is the directory that the state of the container is kept in
+	Root        string
+	ID          string
+	Bundle      string
+	Runtime     string
+	RuntimeArgs []string
+	Labels      []string
+	// LogConfig selects the logging driver of the container's processes,
+	// its path defaults to the container's state directory
+	LogConfig LogConfig
+	// StdinOnce closes the init process' stdin when the first attached
+	// client detaches
+	StdinOnce bool
+	NUMA      NUMAConfig
+	Network   NetworkConfig
+	// Keep keeps the container after its init process exits
+	Keep bool
+	// AutoRemove removes the bundle with the container
+	AutoRemove bool
+}
+
+// New returns a new container, its record is added to the containers bucket of
+// the database
+func New(db *metadata.DB, opts ContainerOpts) (Container, error) {
+	if opts.LogConfig.Driver != "" && opts.LogConfig.Path == "" {
+		opts.LogConfig.Path = filepath.Join(opts.Root, opts.ID)
+	}
+	// parse the spec at create so that an invalid bundle fails early
+	spec, err := ReadSpec(opts.Bundle)
+	if err != nil {
+		return nil, err
+	}
 	c := &container{
-		root:        root,
-		id:          id,
-		bundle:      bundle,
-		labels:      labels,
+		db:          db,
+		root:        opts.Root,
+		id:          opts.ID,
+		bundle:      opts.Bundle,
+		labels:      opts.Labels,
 		processes:   make(map[string]*process),
-		runtime:     runtimeName,
-		runtimeArgs: runtimeArgs,
+		runtime:     opts.Runtime,
+		runtimeArgs: opts.RuntimeArgs,
+		logConfig:   opts.LogConfig,
+		stdinOnce:   opts.StdinOnce,
+		numa:        opts.NUMA,
+		network:     opts.Network,
+		keep:        opts.Keep,
+		autoRemove:  opts.AutoRemove,
+		spec:        spec,
 	}
-	if err := os.Mkdir(filepath.Join(root, id), 0755); err != nil {
+	if err := os.Mkdir(filepath.Join(opts.Root, opts.ID), 0755); err != nil {
 		return nil, err
 	}
-	f, err := os.Create(filepath.Join(root, id, StateFile))
+	data, err := json.Marshal(state{
+		Bundle:      opts.Bundle,
+		Labels:      opts.Labels,
+		Runtime:     opts.Runtime,
+		RuntimeArgs: opts.RuntimeArgs,
+		LogConfig:   opts.LogConfig,
+		StdinOnce:   opts.StdinOnce,
+		NUMA:        opts.NUMA,
+		Network:     opts.Network,
+		Keep:        opts.Keep,
+		AutoRemove:  opts.AutoRemove,
+	})
+	if err == nil {
+		err = db.Update(func(tx *metadata.Tx) error {
+			b, err := tx.CreateBucketIfNotExists(ContainersBucket)
+			if err != nil {
+				return err
+			}
+			return b.Put(opts.ID, data)
+		})
+	}
 	if err != nil {
+		os.RemoveAll(filepath.Join(opts.Root, opts.ID))
 		return nil, err
 	}
-	defer f.Close()
-	if err := json.NewEncoder(f).Encode(state{
-		Bundle:      bundle,
-		Labels:      labels,
-		Runtime:     runtimeName,
-		RuntimeArgs: runtimeArgs,
+	return c, nil
+}
+
+// ContainerIDs returns the ids of the containers recorded in the database
+func ContainerIDs(db *metadata.DB) ([]string, error) {
+	var ids []string
+	if err := db.View(func(tx *metadata.Tx) error {
+		b := tx.Bucket(ContainersBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(id string, _ []byte) error {
+			ids = append(ids, id)
+			return nil
+		})
 	}); err != nil {
 		return nil, err
 	}
-	return c, nil
+	return ids, nil
 }
 
-func Load(root, id string) (Container, error) {
-	var s state
-	f, err := os.Open(filepath.Join(root, id, StateFile))
+// Load returns the container with the id from its record in the database
+func Load(db *metadata.DB, root, id string) (Container, error) {
+	s, err := readState(db, id)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
-	if err := json.NewDecoder(f).Decode(&s); err != nil {
-		return nil, err
-	}
 	c := &container{
+		db:          db,
 		root:        root,
 		id:          id,
 		bundle:      s.Bundle,
 		labels:      s.Labels,
 		runtime:     s.Runtime,
 		runtimeArgs: s.RuntimeArgs,
+		logConfig:   s.LogConfig,
+		stdinOnce:   s.StdinOnce,
+		numa:        s.NUMA,
+		network:     s.Network,
+		keep:        s.Keep,
+		autoRemove:  s.AutoRemove,
+		addresses:   s.Addresses,
+		routed:      s.Routed,
 		processes:   make(map[string]*process),
 	}
 	dirs, err := ioutil.ReadDir(filepath.Join(root, id))
@@ -146,7 +316,11 @@ func Load(root, id string) (Container, error) {
 		}
 		p, err := loadProcess(filepath.Join(root, id, pid), pid, c, s)
 		if err != nil {
-			logrus.WithField("id", id).WithField("pid", pid).Debug("containerd: error loading process %s", err)
+			log.WithFields(logrus.Fields{
+				"id":    id,
+				"pid":   pid,
+				"error": err,
+			}).Debug("containerd: error loading process")
 			continue
 		}
 		c.processes[pid] = p
@@ -154,6 +328,27 @@ func Load(root, id string) (Container, error) {
 	return c, nil
 }
 
+// readState returns the record of the container
+func readState(db *metadata.DB, id string) (*state, error) {
+	var data []byte
+	if err := db.View(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(ContainersBucket); b != nil {
+			data = b.Get(id)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errNoRecord
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
 func readProcessState(dir string) (*ProcessState, error) {
 	f, err := os.Open(filepath.Join(dir, "process.json"))
 	if err != nil {
@@ -168,15 +363,33 @@ func readProcessState(dir string) (*ProcessState, error) {
 }
 
 type container struct {
+	// db holds the record of the container
+	db *metadata.DB
 	// path to store runtime state information
 	root        string
 	id          string
 	bundle      string
 	runtime     string
 	runtimeArgs []string
+	logConfig   LogConfig
+	stdinOnce   bool
+	numa        NUMAConfig
+	network     NetworkConfig
+	keep        bool
+	autoRemove  bool
 	processes   map[string]*process
 	labels      []string
 	oomFds      []int
+	// spec is parsed from the bundle once, the lock guards it as the
+	// container is used by both the event loop and the start workers
+	specLock sync.Mutex
+	spec     *specs.Spec
+	// addresses are set by the start workers and read by the event loop
+	addrLock  sync.Mutex
+	addresses []string
+	// routed are the addresses the host routes to the container and
+	// answers ARP and NDP for on the routed uplink
+	routed []string
 }
 
 func (c *container) ID() string {
@@ -191,21 +404,86 @@ func (c *container) Labels() []string {
 	return c.labels
 }
 
-func (c *container) readSpec() (*specs.Spec, error) {
-	var spec specs.Spec
-	f, err := os.Open(filepath.Join(c.bundle, "config.json"))
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
-	if err := json.NewDecoder(f).Decode(&spec); err != nil {
-		return nil, err
-	}
-	return &spec, nil
+func (c *container) LogConfig() LogConfig {
+	return c.logConfig
+}
+
+func (c *container) StdinOnce() bool {
+	return c.stdinOnce
+}
+
+func (c *container) NUMA() NUMAConfig {
+	return c.numa
+}
+
+func (c *container) Network() NetworkConfig {
+	return c.network
+}
+
+func (c *container) Keep() bool {
+	return c.keep
+}
+
+func (c *container) AutoRemove() bool {
+	return c.autoRemove
+}
+
+func (c *container) Addresses() []string {
+	c.addrLock.Lock()
+	defer c.addrLock.Unlock()
+	return c.addresses
+}
+
+// setAddresses saves the addresses of the container in its record
+func (c *container) setAddresses(addrs []string) error {
+	c.addrLock.Lock()
+	c.addresses = addrs
+	c.addrLock.Unlock()
+	return c.updateState(func(s *state) {
+		s.Addresses = addrs
+	})
+}
+
+// updateState changes the record of the container with fn
+func (c *container) updateState(fn func(*state)) error {
+	return c.db.Update(func(tx *metadata.Tx) error {
+		b := tx.Bucket(ContainersBucket)
+		if b == nil {
+			return errNoRecord
+		}
+		data := b.Get(c.id)
+		if data == nil {
+			return errNoRecord
+		}
+		var s state
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		fn(&s)
+		data, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		return b.Put(c.id, data)
+	})
 }
 
 func (c *container) Delete() error {
-	err := os.RemoveAll(filepath.Join(c.root, c.id))
+	c.stopUsernet()
+	c.unrouteAddresses()
+	c.flushConntrack()
+	c.releaseVF()
+	// the record is removed first so that a crash does not leave a record
+	// without the container's state directory
+	err := c.db.Update(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(ContainersBucket); b != nil {
+			return b.Delete(c.id)
+		}
+		return nil
+	})
+	if rerr := os.RemoveAll(filepath.Join(c.root, c.id)); err == nil {
+		err = rerr
+	}
 
 	args := c.runtimeArgs
 	args = append(args, "delete", c.id)
@@ -214,6 +492,16 @@ func (c *container) Delete() error {
 	return err
 }
 
+func (c *container) Release() error {
+	c.stopUsernet()
+	c.unrouteAddresses()
+	c.flushConntrack()
+	args := c.runtimeArgs
+	args = append(args, "delete", c.id)
+	exec.Command(c.runtime, args...).Run()
+	return c.RemoveProcess(InitProcessID)
+}
+
 func (c *container) Processes() ([]Process, error) {
 	out := []Process{}
 	for _, p := range c.processes {
@@ -228,6 +516,21 @@ func (c *container) RemoveProcess(pid string) error {
 }
 
 func (c *container) UpdateResources(r *Resource) error {
+	if err := c.validateRealtime(r.CPURealtimeRuntime, r.CPURealtimePeriod); err != nil {
+		return err
+	}
+	if r.MemorySwappiness != nil && (*r.MemorySwappiness < 0 || *r.MemorySwappiness > 100) {
+		return ErrInvalidSwappiness
+	}
+	if r.MemorySwap != 0 || r.MemorySwappiness != nil {
+		ok, err := c.swapAccounting()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrSwapNotSupported
+		}
+	}
 	container, err := c.getLibctContainer()
 	if err != nil {
 		return err
@@ -243,5 +546,39 @@ func (c *container) UpdateResources(r *Resource) error {
 	config.Cgroups.Resources.Memory = r.Memory
 	config.Cgroups.Resources.MemoryReservation = r.MemoryReservation
 	config.Cgroups.Resources.MemorySwap = r.MemorySwap
+	config.Cgroups.Resources.CpuRtRuntime = r.CPURealtimeRuntime
+	config.Cgroups.Resources.CpuRtPeriod = r.CPURealtimePeriod
+	config.Cgroups.Resources.PidsLimit = r.PidsLimit
+	if r.MemorySwappiness != nil {
+		config.Cgroups.Resources.MemorySwappiness = r.MemorySwappiness
+	}
+	// only the devices being updated are written so that the devices set at
+	// create time keep their values
+	config.Cgroups.Resources.BlkioWeightDevice = nil
+	for _, d := range r.BlkioWeightDevice {
+		major, minor, err := blkioDevice(d.Path)
+		if err != nil {
+			return err
+		}
+		config.Cgroups.Resources.BlkioWeightDevice = append(config.Cgroups.Resources.BlkioWeightDevice, configs.NewWeightDevice(major, minor, d.Weight, 0))
+	}
+	for _, t := range []struct {
+		devices []ThrottleDevice
+		config  *[]*configs.ThrottleDevice
+	}{
+		{r.BlkioThrottleReadBpsDevice, &config.Cgroups.Resources.BlkioThrottleReadBpsDevice},
+		{r.BlkioThrottleWriteBpsDevice, &config.Cgroups.Resources.BlkioThrottleWriteBpsDevice},
+		{r.BlkioThrottleReadIOPSDevice, &config.Cgroups.Resources.BlkioThrottleReadIOPSDevice},
+		{r.BlkioThrottleWriteIOPSDevice, &config.Cgroups.Resources.BlkioThrottleWriteIOPSDevice},
+	} {
+		*t.config = nil
+		for _, d := range t.devices {
+			major, minor, err := blkioDevice(d.Path)
+			if err != nil {
+				return err
+			}
+			*t.config = append(*t.config, configs.NewThrottleDevice(major, minor, d.Rate))
+		}
+	}
 	return container.Set(config)
 }
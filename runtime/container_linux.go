@@ -8,13 +8,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/containerd/specs"
 	"github.com/opencontainers/runc/libcontainer"
 	ocs "github.com/opencontainers/specs/specs-go"
+	netcontext "golang.org/x/net/context"
 )
 
 var shimBinary = os.Args[0] + "-shim"
@@ -50,6 +53,17 @@ func (c *container) Runtime() string {
 	return c.runtime
 }
 
+func (c *container) RootFS() (string, error) {
+	spec, err := c.Spec()
+	if err != nil {
+		return "", err
+	}
+	if filepath.IsAbs(spec.Root.Path) {
+		return spec.Root.Path, nil
+	}
+	return filepath.Join(c.bundle, spec.Root.Path), nil
+}
+
 func (c *container) Pause() error {
 	args := c.runtimeArgs
 	args = append(args, "pause", c.id)
@@ -62,6 +76,26 @@ func (c *container) Resume() error {
 	return exec.Command(c.runtime, args...).Run()
 }
 
+// Kill freezes the container, sends SIGKILL to every process of its cgroup
+// and thaws it so that no process can fork while the processes are killed
+func (c *container) Kill() error {
+	if err := c.Pause(); err != nil {
+		return err
+	}
+	pids, err := c.Pids()
+	if err == nil {
+		for _, pid := range pids {
+			if kerr := syscall.Kill(pid, syscall.SIGKILL); kerr != nil && kerr != syscall.ESRCH {
+				err = kerr
+			}
+		}
+	}
+	if rerr := c.Resume(); err == nil {
+		err = rerr
+	}
+	return err
+}
+
 func (c *container) Checkpoints() ([]Checkpoint, error) {
 	dirs, err := ioutil.ReadDir(filepath.Join(c.bundle, "checkpoints"))
 	if err != nil {
@@ -132,7 +166,10 @@ func (c *container) DeleteCheckpoint(name string) error {
 	return os.RemoveAll(filepath.Join(c.bundle, "checkpoints", name))
 }
 
-func (c *container) Start(checkpoint string, s Stdio) (Process, error) {
+func (c *container) Start(ctx netcontext.Context, checkpoint string, s Stdio) (Process, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	processRoot := filepath.Join(c.root, c.id, InitProcessID)
 	if err := os.Mkdir(processRoot, 0755); err != nil {
 		return nil, err
@@ -144,30 +181,57 @@ func (c *container) Start(checkpoint string, s Stdio) (Process, error) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
 	}
-	spec, err := c.readSpec()
+	runtimeBundle, err := c.runtimeBundle()
+	if err != nil {
+		return nil, err
+	}
+	spec, err := c.Spec()
 	if err != nil {
 		return nil, err
 	}
 	config := &processConfig{
-		checkpoint:  checkpoint,
-		root:        processRoot,
-		id:          InitProcessID,
-		c:           c,
-		stdio:       s,
-		spec:        spec,
-		processSpec: specs.ProcessSpec(spec.Process),
+		runtimeBundle: runtimeBundle,
+		checkpoint:    checkpoint,
+		root:          processRoot,
+		id:            InitProcessID,
+		c:             c,
+		stdio:         s,
+		spec:          spec,
+		processSpec:   specs.ProcessSpec(spec.Process),
 	}
 	p, err := newProcess(config)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.startCmd(InitProcessID, cmd, p); err != nil {
+	if err := c.startCmd(ctx, InitProcessID, cmd, p); err != nil {
+		if err == ctx.Err() {
+			c.abortStart(cmd)
+		}
+		return nil, err
+	}
+	// the client gave up while the runtime was starting the container
+	if err := ctx.Err(); err != nil {
+		c.abortStart(cmd)
 		return nil, err
 	}
+	c.startUsernet(spec, p.SystemPid())
+	if err := c.configureNetwork(spec, p.SystemPid()); err != nil {
+		c.abortStart(cmd)
+		return nil, err
+	}
+	c.recordAddresses(spec, p.SystemPid())
+	if c.numa.Nodes != "" {
+		if err := c.UpdateResources(&Resource{CpusetMems: c.numa.Nodes}); err != nil {
+			return nil, err
+		}
+	}
 	return p, nil
 }
 
-func (c *container) Exec(pid string, pspec specs.ProcessSpec, s Stdio) (pp Process, err error) {
+func (c *container) Exec(ctx netcontext.Context, pid string, pspec specs.ProcessSpec, s Stdio) (pp Process, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	processRoot := filepath.Join(c.root, c.id, pid)
 	if err := os.Mkdir(processRoot, 0755); err != nil {
 		return nil, err
@@ -184,7 +248,7 @@ func (c *container) Exec(pid string, pspec specs.ProcessSpec, s Stdio) (pp Proce
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
 	}
-	spec, err := c.readSpec()
+	spec, err := c.Spec()
 	if err != nil {
 		return nil, err
 	}
@@ -201,13 +265,47 @@ func (c *container) Exec(pid string, pspec specs.ProcessSpec, s Stdio) (pp Proce
 	if err != nil {
 		return nil, err
 	}
-	if err := c.startCmd(pid, cmd, p); err != nil {
+	if err := c.startCmd(netcontext.Background(), pid, cmd, p); err != nil {
 		return nil, err
 	}
 	return p, nil
 }
 
-func (c *container) startCmd(pid string, cmd *exec.Cmd, p *process) error {
+func (c *container) startCmd(ctx netcontext.Context, pid string, cmd *exec.Cmd, p *process) (err error) {
+	if p.stdio.Socket {
+		defer func() {
+			if err != nil && p.stdioSocket != nil {
+				p.stdioSocket.Close()
+			}
+		}()
+	}
+	// an idle shim of the pool is used in place of a new shim when the
+	// process' stdio does not need to be passed to the shim
+	if p.stdio.Socket || !pooledShim(cmd) {
+		if err := startShim(cmd, p); err != nil {
+			return err
+		}
+	}
+	if err := waitForStart(ctx, p, cmd); err != nil {
+		return err
+	}
+	if err := p.readOutput(); err != nil {
+		return err
+	}
+	c.processes[pid] = p
+	return nil
+}
+
+func startShim(cmd *exec.Cmd, p *process) error {
+	if p.stdio.Socket {
+		child, err := p.openStdioSocket()
+		if err != nil {
+			return err
+		}
+		// the shim receives its side of the socket as fd 3
+		cmd.ExtraFiles = []*os.File{child}
+		defer child.Close()
+	}
 	if err := cmd.Start(); err != nil {
 		if exErr, ok := err.(*exec.Error); ok {
 			if exErr.Err == exec.ErrNotFound || exErr.Err == os.ErrNotExist {
@@ -216,11 +314,121 @@ func (c *container) startCmd(pid string, cmd *exec.Cmd, p *process) error {
 		}
 		return err
 	}
-	if err := waitForStart(p, cmd); err != nil {
-		return err
+	return nil
+}
+
+func (c *container) Adopt(pid string) (Process, error) {
+	old, ok := c.processes[pid]
+	if !ok {
+		return nil, ErrProcessNotFound
+	}
+	processRoot := filepath.Join(c.root, c.id, pid)
+	os.Remove(filepath.Join(processRoot, AdoptedFile))
+	p := &process{
+		root:      processRoot,
+		id:        pid,
+		pid:       old.pid,
+		container: c,
+		spec:      old.spec,
+		stdio:     old.stdio,
+		output:    newOutput(),
+	}
+	// the output was written by the dead shim
+	p.output.done = true
+	exit, err := getExitPipe(filepath.Join(processRoot, ExitFile))
+	if err != nil {
+		return nil, err
+	}
+	p.exitPipe = exit
+	control, err := getControlPipe(filepath.Join(processRoot, ControlFile))
+	if err != nil {
+		exit.Close()
+		return nil, err
+	}
+	p.controlPipe = control
+	logEvents, err := getLogEventsPipe(filepath.Join(processRoot, LogEventsFile))
+	if err != nil {
+		p.Close()
+		control.Close()
+		return nil, err
+	}
+	p.logEventsPipe = logEvents
+	cmd := exec.Command(shimBinary,
+		"-adopt", c.id, c.bundle, c.runtime,
+	)
+	cmd.Dir = processRoot
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	// only a process that was reparented to the daemon is reaped by it.
+	// The shims of a previous daemon were reparented to init, or to an
+	// ancestor subreaper, when it died and so are the processes of those
+	// shims that died since, their exit status cannot be learned.
+	reaped := parentPid(p.pid) == os.Getpid()
+	if reaped {
+		adoptedMu.Lock()
+		adopted[p.pid] = processRoot
+		adoptedMu.Unlock()
+	} else {
+		log.WithFields(logrus.Fields{
+			"id":  c.id,
+			"pid": pid,
+		}).Warn("containerd: adopted process is not a child of the daemon, its exit status will be unknown")
+	}
+	if err := cmd.Start(); err != nil {
+		unadopt(p.pid)
+		p.Close()
+		control.Close()
+		return nil, err
+	}
+	if err := waitForAdopt(p, cmd); err != nil {
+		unadopt(p.pid)
+		cmd.Process.Kill()
+		p.Close()
+		control.Close()
+		return nil, err
+	}
+	if old.controlPipe != nil {
+		old.controlPipe.Close()
 	}
 	c.processes[pid] = p
-	return nil
+	return p, nil
+}
+
+// parentPid returns the parent of the process, or 0 if it cannot be read
+func parentPid(pid int) int {
+	data, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0
+	}
+	// the fields after the command, which may contain spaces and
+	// parentheses, are the state and the parent pid
+	stat := string(data)
+	fields := strings.Fields(stat[strings.LastIndex(stat, ")")+1:])
+	if len(fields) < 2 {
+		return 0
+	}
+	ppid, _ := strconv.Atoi(fields[1])
+	return ppid
+}
+
+// waitForAdopt waits for the shim to create the adopted file after it opened
+// the process' exit fifo
+func waitForAdopt(p *process, cmd *exec.Cmd) error {
+	for i := 0; i < 300; i++ {
+		if _, err := os.Stat(filepath.Join(p.root, AdoptedFile)); err == nil {
+			return nil
+		}
+		alive, err := isAlive(cmd)
+		if err != nil {
+			return err
+		}
+		if !alive {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return ErrShimNotAdopted
 }
 
 func (c *container) getLibctContainer() (libcontainer.Container, error) {
@@ -250,6 +458,18 @@ func hostIDFromMap(id uint32, mp []ocs.IDMapping) int {
 	return 0
 }
 
+func (c *container) CgroupPaths() (map[string]string, error) {
+	container, err := c.getLibctContainer()
+	if err != nil {
+		return nil, err
+	}
+	state, err := container.State()
+	if err != nil {
+		return nil, err
+	}
+	return state.CgroupPaths, nil
+}
+
 func (c *container) Pids() ([]int, error) {
 	container, err := c.getLibctContainer()
 	if err != nil {
@@ -268,12 +488,61 @@ func (c *container) Stats() (*Stat, error) {
 	if err != nil {
 		return nil, err
 	}
+	state, err := container.State()
+	if err != nil {
+		return nil, err
+	}
+	numa, err := numaStats(state.CgroupPaths["memory"])
+	if err != nil {
+		return nil, err
+	}
+	swappiness, err := readCgroupUint(state.CgroupPaths["memory"], "memory.swappiness")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// libcontainer only reports links that it created, the links of
+	// containers created by the runtime are read from their namespace
+	if len(stats.Interfaces) == 0 && state.InitProcessPid > 0 {
+		if stats.Interfaces, err = networkStats(state.InitProcessPid); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
 	return &Stat{
-		Timestamp: now,
-		Data:      stats,
+		Timestamp:        now,
+		Data:             stats,
+		NUMA:             numa,
+		MemorySwappiness: swappiness,
 	}, nil
 }
 
+// swapAccounting returns true if the container's memory cgroup accounts for
+// swap usage so that swap limits can be applied
+func (c *container) swapAccounting() (bool, error) {
+	container, err := c.getLibctContainer()
+	if err != nil {
+		return false, err
+	}
+	state, err := container.State()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(filepath.Join(state.CgroupPaths["memory"], "memory.memsw.limit_in_bytes")); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func readCgroupUint(root, name string) (uint64, error) {
+	data, err := ioutil.ReadFile(filepath.Join(root, name))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
 func (c *container) OOM() (OOM, error) {
 	container, err := c.getLibctContainer()
 	if err != nil {
@@ -294,6 +563,61 @@ func (c *container) OOM() (OOM, error) {
 	return c.getMemeoryEventFD(memoryPath)
 }
 
+func (c *container) MemoryPressure() ([]MemoryPressure, error) {
+	container, err := c.getLibctContainer()
+	if err != nil {
+		if lerr, ok := err.(libcontainer.Error); ok {
+			if lerr.Code() == libcontainer.ContainerNotExists {
+				return nil, ErrContainerExited
+			}
+		}
+		return nil, err
+	}
+	state, err := container.State()
+	if err != nil {
+		return nil, err
+	}
+	memoryPath := state.CgroupPaths["memory"]
+	var out []MemoryPressure
+	for _, level := range MemoryPressureLevels {
+		p, err := c.getMemoryPressureFD(memoryPath, level)
+		if err != nil {
+			for _, p := range out {
+				p.Close()
+			}
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (c *container) getMemoryPressureFD(root, level string) (*memoryPressure, error) {
+	f, err := os.Open(filepath.Join(root, "memory.pressure_level"))
+	if err != nil {
+		return nil, err
+	}
+	fd, _, serr := syscall.RawSyscall(syscall.SYS_EVENTFD2, 0, syscall.FD_CLOEXEC, 0)
+	if serr != 0 {
+		f.Close()
+		return nil, serr
+	}
+	if err := c.writeEventFD(root, int(f.Fd()), int(fd), level); err != nil {
+		syscall.Close(int(fd))
+		f.Close()
+		return nil, err
+	}
+	return &memoryPressure{
+		oom: oom{
+			root:    root,
+			id:      c.id,
+			eventfd: int(fd),
+			control: f,
+		},
+		level: level,
+	}, nil
+}
+
 func (c *container) getMemeoryEventFD(root string) (*oom, error) {
 	f, err := os.Open(filepath.Join(root, "memory.oom_control"))
 	if err != nil {
@@ -304,7 +628,7 @@ func (c *container) getMemeoryEventFD(root string) (*oom, error) {
 		f.Close()
 		return nil, serr
 	}
-	if err := c.writeEventFD(root, int(f.Fd()), int(fd)); err != nil {
+	if err := c.writeEventFD(root, int(f.Fd()), int(fd), ""); err != nil {
 		syscall.Close(int(fd))
 		f.Close()
 		return nil, err
@@ -317,17 +641,25 @@ func (c *container) getMemeoryEventFD(root string) (*oom, error) {
 	}, nil
 }
 
-func (c *container) writeEventFD(root string, cfd, efd int) error {
+// writeEventFD registers the eventfd to be notified on events of the control
+// file, args are passed to the control file's registration when not empty
+func (c *container) writeEventFD(root string, cfd, efd int, args string) error {
 	f, err := os.OpenFile(filepath.Join(root, "cgroup.event_control"), os.O_WRONLY, 0)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
-	_, err = f.WriteString(fmt.Sprintf("%d %d", efd, cfd))
+	registration := fmt.Sprintf("%d %d", efd, cfd)
+	if args != "" {
+		registration += " " + args
+	}
+	_, err = f.WriteString(registration)
 	return err
 }
 
-func waitForStart(p *process, cmd *exec.Cmd) error {
+// waitForStart waits for the shim to write the pid of the process, it
+// returns the context's error once ctx is done
+func waitForStart(ctx netcontext.Context, p *process, cmd *exec.Cmd) error {
 	for i := 0; i < 300; i++ {
 		if _, err := p.getPidFromFile(); err != nil {
 			if os.IsNotExist(err) || err == errInvalidPidInt {
@@ -362,7 +694,11 @@ func waitForStart(p *process, cmd *exec.Cmd) error {
 					}
 					return ErrContainerNotStarted
 				}
-				time.Sleep(50 * time.Millisecond)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(50 * time.Millisecond):
+				}
 				continue
 			}
 			return err
@@ -372,6 +708,18 @@ func waitForStart(p *process, cmd *exec.Cmd) error {
 	return errNoPidFile
 }
 
+// abortStart kills the shim and the runtime starting the container, and the
+// container's init if it was already started, so that a canceled start does
+// not leave the container running.  The shims run in their own process group.
+func (c *container) abortStart(cmd *exec.Cmd) {
+	if cmd.Process != nil {
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	// copy the args so that appending never writes to c.runtimeArgs
+	args := append(append([]string{}, c.runtimeArgs...), "kill", c.id, "KILL")
+	exec.Command(c.runtime, args...).Run()
+}
+
 // isAlive checks if the shim that launched the container is still alive
 func isAlive(cmd *exec.Cmd) (bool, error) {
 	if err := syscall.Kill(cmd.Process.Pid, 0); err != nil {
@@ -416,6 +764,16 @@ func (o *oom) Close() error {
 	return err
 }
 
+// memoryPressure is notified when the memory cgroup reaches its pressure level
+type memoryPressure struct {
+	oom
+	level string
+}
+
+func (m *memoryPressure) Level() string {
+	return m.level
+}
+
 type message struct {
 	Level string `json:"level"`
 	Msg   string `json:"msg"`
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/docker/containerd/specs"
+	netcontext "golang.org/x/net/context"
 )
 
 func getRootIDs(s *specs.PlatformSpec) (int, int, error) {
@@ -19,6 +20,10 @@ func (c *container) Runtime() string {
 	return "windows"
 }
 
+func (c *container) RootFS() (string, error) {
+	return "", errors.New("RootFS not supported on Windows")
+}
+
 func (c *container) Pause() error {
 	return errors.New("Pause not supported on Windows")
 }
@@ -41,13 +46,13 @@ func (c *container) DeleteCheckpoint(name string) error {
 
 // TODO Windows: Implement me.
 // This will have a very different implementation on Windows.
-func (c *container) Start(checkpoint string, s Stdio) (Process, error) {
+func (c *container) Start(ctx netcontext.Context, checkpoint string, s Stdio) (Process, error) {
 	return nil, errors.New("Start not yet implemented on Windows")
 }
 
 // TODO Windows: Implement me.
 // This will have a very different implementation on Windows.
-func (c *container) Exec(pid string, spec specs.ProcessSpec, s Stdio) (Process, error) {
+func (c *container) Exec(ctx netcontext.Context, pid string, spec specs.ProcessSpec, s Stdio) (Process, error) {
 	return nil, errors.New("Exec not yet implemented on Windows")
 }
 
@@ -57,6 +62,10 @@ func (c *container) Pids() ([]int, error) {
 }
 
 // TODO Windows: Implement me. (Not yet supported by docker on Windows either...)
+func (c *container) CgroupPaths() (map[string]string, error) {
+	return nil, errors.New("CgroupPaths not supported on Windows")
+}
+
 func (c *container) Stats() (*Stat, error) {
 	return nil, errors.New("Stats not yet implemented on Windows")
 }
@@ -64,3 +73,43 @@ func (c *container) Stats() (*Stat, error) {
 func (c *container) OOM() (OOM, error) {
 	return nil, errors.New("OOM not yet implemented on Windows")
 }
+
+func (c *container) Kill() error {
+	return errors.New("Kill not yet implemented on Windows")
+}
+
+func (c *container) AddDevice(d Device) error {
+	return errors.New("AddDevice not supported on Windows")
+}
+
+func (c *container) RemoveDevice(d Device) error {
+	return errors.New("RemoveDevice not supported on Windows")
+}
+
+func (c *container) AddAddress(a Address) error {
+	return ErrNetworkNotSupported
+}
+
+func (c *container) DeleteAddresses(label string) ([]Address, error) {
+	return nil, ErrNetworkNotSupported
+}
+
+func (c *container) ListAddresses(label string) ([]Address, error) {
+	return nil, ErrNetworkNotSupported
+}
+
+func (c *container) Adopt(pid string) (Process, error) {
+	return nil, errors.New("Adopt not yet implemented on Windows")
+}
+
+func (c *container) MemoryPressure() ([]MemoryPressure, error) {
+	return nil, errors.New("MemoryPressure not yet implemented on Windows")
+}
+
+func (c *container) swapAccounting() (bool, error) {
+	return false, nil
+}
+
+func blkioDevice(path string) (int64, int64, error) {
+	return 0, 0, errors.New("blkio devices not supported on Windows")
+}
@@ -0,0 +1,89 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// AllowedCPUs returns the cpus that the daemon is allowed to run on.  They are
+// read from the daemon's cpuset cgroup and from the online cpus of the host
+// when the cgroup cannot be read.
+func AllowedCPUs() ([]int, error) {
+	if list, err := daemonCPUSet(); err == nil && list != "" {
+		return parseCPUList(list)
+	}
+	data, err := ioutil.ReadFile("/sys/devices/system/cpu/online")
+	if err != nil {
+		return nil, err
+	}
+	return parseCPUList(strings.TrimSpace(string(data)))
+}
+
+// daemonCPUSet returns the effective cpus of the daemon's cpuset cgroup in the
+// cpuset list format
+func daemonCPUSet() (string, error) {
+	var path string
+	if cgroupVersion() == 2 {
+		data, err := ioutil.ReadFile("/proc/self/cgroup")
+		if err != nil {
+			return "", err
+		}
+		for _, l := range strings.Split(string(data), "\n") {
+			if strings.HasPrefix(l, "0::") {
+				path = filepath.Join("/sys/fs/cgroup", strings.TrimPrefix(l, "0::"), "cpuset.cpus.effective")
+			}
+		}
+		if path == "" {
+			return "", cgroups.NewNotFoundError("cpuset")
+		}
+	} else {
+		mnt, root, err := cgroups.FindCgroupMountpointAndRoot("cpuset")
+		if err != nil {
+			return "", err
+		}
+		dir, err := cgroups.GetThisCgroupDir("cpuset")
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(mnt, rel, "cpuset.cpus")
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// parseCPUList returns the ids of a list in the cpuset list format
+func parseCPUList(list string) ([]int, error) {
+	var ids []int
+	for _, r := range strings.Split(list, ",") {
+		parts := strings.SplitN(r, "-", 2)
+		start, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpuset list %q", list)
+		}
+		end := start
+		if len(parts) == 2 {
+			if end, err = strconv.Atoi(parts[1]); err != nil {
+				return nil, fmt.Errorf("invalid cpuset list %q", list)
+			}
+		}
+		if start < 0 || end < start {
+			return nil, fmt.Errorf("invalid cpuset list %q", list)
+		}
+		for n := start; n <= end; n++ {
+			ids = append(ids, n)
+		}
+	}
+	return ids, nil
+}
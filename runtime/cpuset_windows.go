@@ -0,0 +1,6 @@
+package runtime
+
+// AllowedCPUs returns no cpus as cpusets are not supported on Windows
+func AllowedCPUs() ([]int, error) {
+	return nil, nil
+}
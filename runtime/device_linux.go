@@ -0,0 +1,160 @@
+package runtime
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// AddDevice grants the container access to the device in the devices cgroup
+// and creates the device node inside the container when requested
+func (c *container) AddDevice(d Device) error {
+	dev, err := c.cgroupDevice(d, true)
+	if err != nil {
+		return err
+	}
+	if err := c.setDevice(dev); err != nil {
+		return err
+	}
+	if !d.Mknod {
+		return nil
+	}
+	path, err := c.devicePath(d)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// the node is created without following a link at the path and Lchown
+	// leaves the target of a link that was planted there alone
+	mode := uint32(dev.FileMode.Perm())
+	if dev.Type == 'b' {
+		mode |= syscall.S_IFBLK
+	} else {
+		mode |= syscall.S_IFCHR
+	}
+	if err := syscall.Mknod(path, mode, dev.Mkdev()); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return os.Lchown(path, int(dev.Uid), int(dev.Gid))
+}
+
+// RemoveDevice revokes the container's access to the device in the devices
+// cgroup and removes the device node inside the container when requested
+func (c *container) RemoveDevice(d Device) error {
+	dev, err := c.cgroupDevice(d, false)
+	if err != nil {
+		return err
+	}
+	if err := c.setDevice(dev); err != nil {
+		return err
+	}
+	if !d.Mknod {
+		return nil
+	}
+	path, err := c.devicePath(d)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// cgroupDevice returns the cgroup rule for the host device node
+func (c *container) cgroupDevice(d Device, allow bool) (*configs.Device, error) {
+	return hostDevice(d.Path, d.Permissions, allow)
+}
+
+// hostDevice returns the cgroup rule for the host device node at path
+func hostDevice(path, permissions string, allow bool) (*configs.Device, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return nil, err
+	}
+	var t rune
+	switch st.Mode & syscall.S_IFMT {
+	case syscall.S_IFBLK:
+		t = 'b'
+	case syscall.S_IFCHR:
+		t = 'c'
+	default:
+		return nil, ErrNotDevice
+	}
+	if permissions == "" {
+		permissions = "rwm"
+	}
+	return &configs.Device{
+		Type:        t,
+		Path:        path,
+		Major:       major(st.Rdev),
+		Minor:       minor(st.Rdev),
+		Permissions: permissions,
+		FileMode:    os.FileMode(st.Mode),
+		Uid:         st.Uid,
+		Gid:         st.Gid,
+		Allow:       allow,
+	}, nil
+}
+
+// setDevice writes a single rule to the container's devices cgroup
+func (c *container) setDevice(dev *configs.Device) error {
+	container, err := c.getLibctContainer()
+	if err != nil {
+		return err
+	}
+	config := container.Config()
+	config.Cgroups.Resources.Devices = []*configs.Device{dev}
+	return container.Set(config)
+}
+
+// devicePath returns the host path to the device node inside the container's
+// mount namespace.  The symlinks of the node's parent directories are
+// evaluated with the container's root as the filesystem root, so that a link
+// planted by the container cannot point the daemon at a host path.
+func (c *container) devicePath(d Device) (string, error) {
+	init, ok := c.processes[InitProcessID]
+	if !ok {
+		return "", ErrContainerNotStarted
+	}
+	path := d.ContainerPath
+	if path == "" {
+		path = d.Path
+	}
+	if !filepath.IsAbs(path) {
+		return "", ErrDevicePathNotAbs
+	}
+	root := filepath.Join("/proc", strconv.Itoa(init.SystemPid()), "root")
+	path = filepath.Clean(path)
+	dir, err := symlink.FollowSymlinkInScope(filepath.Join(root, filepath.Dir(path)), root)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, filepath.Base(path)), nil
+}
+
+// blkioDevice returns the major and minor numbers of the host block device
+func blkioDevice(path string) (int64, int64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return 0, 0, err
+	}
+	if st.Mode&syscall.S_IFMT != syscall.S_IFBLK {
+		return 0, 0, ErrNotBlockDevice
+	}
+	return major(st.Rdev), minor(st.Rdev), nil
+}
+
+func major(dev uint64) int64 {
+	return int64(((dev >> 8) & 0xfff) | ((dev >> 32) &^ 0xfff))
+}
+
+func minor(dev uint64) int64 {
+	return int64((dev & 0xff) | ((dev >> 12) & 0xffffff00))
+}
@@ -0,0 +1,39 @@
+package runtime
+
+const (
+	GPUVendorNVIDIA = "nvidia"
+	GPUVendorAMD    = "amd"
+	// AllGPUs selects all gpus of the host
+	AllGPUs = "all"
+)
+
+// GPU is a gpu of the host
+type GPU struct {
+	// ID is the vendor and the index of the gpu, e.g. nvidia0 or amd1
+	ID     string
+	Vendor string
+	// Devices are the device nodes of the gpu
+	Devices []string
+}
+
+// selectGPUs returns the gpus with the ids, AllGPUs selects all of them
+func selectGPUs(gpus []GPU, ids []string) ([]GPU, error) {
+	var selected []GPU
+	for _, id := range ids {
+		if id == AllGPUs {
+			return gpus, nil
+		}
+		found := false
+		for _, g := range gpus {
+			if g.ID == id {
+				selected = append(selected, g)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, ErrGPUNotFound
+		}
+	}
+	return selected, nil
+}
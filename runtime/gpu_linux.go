@@ -0,0 +1,249 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// amdVendorID is the pci vendor id of AMD
+const amdVendorID = "0x1002"
+
+var (
+	// gpuControlDevices are the device nodes shared by all gpus of a vendor
+	gpuControlDevices = map[string][]string{
+		GPUVendorNVIDIA: {"/dev/nvidiactl", "/dev/nvidia-uvm", "/dev/nvidia-uvm-tools", "/dev/nvidia-modeset"},
+		GPUVendorAMD:    {"/dev/kfd"},
+	}
+	// nvidiaLibraries are the prefixes of the driver's user space libraries,
+	// they must match the host's kernel module so they are mounted from the
+	// host
+	nvidiaLibraries = []string{
+		"libcuda.so",
+		"libnvidia-ml.so",
+		"libnvidia-ptxjitcompiler.so",
+		"libnvidia-fatbinaryloader.so",
+		"libnvidia-opencl.so",
+		"libnvidia-compiler.so",
+		"libnvcuvid.so",
+		"libnvidia-encode.so",
+	}
+	nvidiaBinaries = []string{
+		"/usr/bin/nvidia-smi",
+		"/usr/bin/nvidia-debugdump",
+		"/usr/bin/nvidia-persistenced",
+	}
+	libraryDirs = []string{
+		"/usr/lib64",
+		"/usr/lib/x86_64-linux-gnu",
+		"/usr/lib/aarch64-linux-gnu",
+		"/usr/lib",
+	}
+)
+
+// DetectGPUs returns the NVIDIA and AMD gpus of the host
+func DetectGPUs() ([]GPU, error) {
+	var gpus []GPU
+	nodes, err := indexedPaths("/dev/nvidia[0-9]*", "nvidia")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		gpus = append(gpus, GPU{
+			ID:      filepath.Base(n),
+			Vendor:  GPUVendorNVIDIA,
+			Devices: []string{n},
+		})
+	}
+	cards, err := indexedPaths("/sys/class/drm/card[0-9]*", "card")
+	if err != nil {
+		return nil, err
+	}
+	amd := 0
+	for _, c := range cards {
+		vendor, err := ioutil.ReadFile(filepath.Join(c, "device", "vendor"))
+		if err != nil || strings.TrimSpace(string(vendor)) != amdVendorID {
+			continue
+		}
+		devices := []string{filepath.Join("/dev/dri", filepath.Base(c))}
+		renders, err := filepath.Glob(filepath.Join(c, "device", "drm", "renderD*"))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range renders {
+			devices = append(devices, filepath.Join("/dev/dri", filepath.Base(r)))
+		}
+		gpus = append(gpus, GPU{
+			ID:      fmt.Sprintf("%s%d", GPUVendorAMD, amd),
+			Vendor:  GPUVendorAMD,
+			Devices: devices,
+		})
+		amd++
+	}
+	return gpus, nil
+}
+
+// indexedPaths returns the paths matching the pattern whose names are the
+// prefix followed by an index, sorted by the index
+func indexedPaths(pattern, prefix string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make(map[string]int)
+	var paths []string
+	for _, m := range matches {
+		i, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(m), prefix))
+		if err != nil {
+			continue
+		}
+		indexes[m] = i
+		paths = append(paths, m)
+	}
+	sort.Sort(byIndex{paths, indexes})
+	return paths, nil
+}
+
+type byIndex struct {
+	paths   []string
+	indexes map[string]int
+}
+
+func (b byIndex) Len() int           { return len(b.paths) }
+func (b byIndex) Less(i, j int) bool { return b.indexes[b.paths[i]] < b.indexes[b.paths[j]] }
+func (b byIndex) Swap(i, j int)      { b.paths[i], b.paths[j] = b.paths[j], b.paths[i] }
+
+// InjectGPUs adds the device nodes and the cgroup rules of the gpus with the
+// ids to the spec of the bundle, the NVIDIA driver's libraries and binaries
+// are bind mounted read only.  Fields of the spec that are unknown to
+// containerd are preserved.
+func InjectGPUs(bundle string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	gpus, err := DetectGPUs()
+	if err != nil {
+		return err
+	}
+	selected, err := selectGPUs(gpus, ids)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return ErrGPUNotFound
+	}
+	var (
+		paths   []string
+		vendors = make(map[string]bool)
+	)
+	for _, g := range selected {
+		paths = append(paths, g.Devices...)
+		vendors[g.Vendor] = true
+	}
+	var mounts []BindMount
+	for v := range vendors {
+		for _, p := range gpuControlDevices[v] {
+			// the control devices that are created on demand are skipped
+			if _, err := os.Stat(p); err == nil {
+				paths = append(paths, p)
+			}
+		}
+	}
+	if vendors[GPUVendorNVIDIA] {
+		if mounts, err = nvidiaMounts(); err != nil {
+			return err
+		}
+	}
+	var devices []map[string]interface{}
+	for _, p := range paths {
+		d, err := hostDevice(p, "rwm", true)
+		if err != nil {
+			return err
+		}
+		devices = append(devices, map[string]interface{}{
+			"path":     d.Path,
+			"type":     string(d.Type),
+			"major":    d.Major,
+			"minor":    d.Minor,
+			"fileMode": uint32(d.FileMode.Perm()),
+			"uid":      d.Uid,
+			"gid":      d.Gid,
+		})
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, _ := spec["linux"].(map[string]interface{})
+		if linux == nil {
+			linux = make(map[string]interface{})
+			spec["linux"] = linux
+		}
+		resources, _ := linux["resources"].(map[string]interface{})
+		if resources == nil {
+			resources = make(map[string]interface{})
+			linux["resources"] = resources
+		}
+		nodes, _ := linux["devices"].([]interface{})
+		rules, _ := resources["devices"].([]interface{})
+		for _, d := range devices {
+			if !containsDevice(nodes, d["path"]) {
+				nodes = append(nodes, d)
+			}
+			rules = append(rules, map[string]interface{}{
+				"allow":  true,
+				"type":   d["type"],
+				"major":  d["major"],
+				"minor":  d["minor"],
+				"access": "rwm",
+			})
+		}
+		linux["devices"] = nodes
+		resources["devices"] = rules
+		for _, m := range mounts {
+			addBindMount(spec, m)
+		}
+		return true, nil
+	})
+}
+
+func containsDevice(nodes []interface{}, path interface{}) bool {
+	for _, n := range nodes {
+		if d, ok := n.(map[string]interface{}); ok && d["path"] == path {
+			return true
+		}
+	}
+	return false
+}
+
+// nvidiaMounts returns the read only mounts of the NVIDIA driver's libraries
+// and binaries found on the host
+func nvidiaMounts() ([]BindMount, error) {
+	var mounts []BindMount
+	for _, dir := range libraryDirs {
+		for _, l := range nvidiaLibraries {
+			matches, err := filepath.Glob(filepath.Join(dir, l+"*"))
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range matches {
+				mounts = append(mounts, BindMount{
+					Source:      m,
+					Destination: m,
+					ReadOnly:    true,
+				})
+			}
+		}
+	}
+	for _, b := range nvidiaBinaries {
+		if _, err := os.Stat(b); err == nil {
+			mounts = append(mounts, BindMount{
+				Source:      b,
+				Destination: b,
+				ReadOnly:    true,
+			})
+		}
+	}
+	return mounts, nil
+}
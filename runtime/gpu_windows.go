@@ -0,0 +1,10 @@
+package runtime
+
+// DetectGPUs returns no gpus as gpu injection is not supported on Windows
+func DetectGPUs() ([]GPU, error) {
+	return nil, nil
+}
+
+func InjectGPUs(bundle string, ids []string) error {
+	return ErrGPUsNotSupported
+}
@@ -0,0 +1,48 @@
+package runtime
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+var initBinary = os.Args[0] + "-init"
+
+// initPath is where the init is bind mounted in the container, /dev is a
+// tmpfs so the mount point is created without writing to the rootfs
+const initPath = "/dev/init"
+
+// InjectInit bind mounts containerd-init in the container and makes it the
+// process of the bundle's spec with the spec's args as its command, so that it
+// reaps the zombies of the container and forwards signals to the command.
+// Fields of the spec that are unknown to containerd are preserved.
+func InjectInit(bundle string) error {
+	path, err := exec.LookPath(initBinary)
+	if err != nil {
+		return err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return err
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		process, _ := spec["process"].(map[string]interface{})
+		if process == nil {
+			return false, ErrNoProcessArgs
+		}
+		args, _ := process["args"].([]interface{})
+		if len(args) == 0 {
+			return false, ErrNoProcessArgs
+		}
+		// the bundle is created more than once
+		if args[0] == initPath {
+			return false, nil
+		}
+		addBindMount(spec, BindMount{
+			Source:      path,
+			Destination: initPath,
+			ReadOnly:    true,
+		})
+		process["args"] = append([]interface{}{initPath, "--"}, args...)
+		return true, nil
+	})
+}
@@ -0,0 +1,5 @@
+package runtime
+
+func InjectInit(bundle string) error {
+	return ErrInitNotSupported
+}
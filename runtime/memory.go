@@ -0,0 +1,33 @@
+package runtime
+
+// RaiseMemoryLimit adds increment to the memory limit of the bundle's spec,
+// the limit is not raised above limitCap unless limitCap is 0.  The memory and
+// swap limit is raised by as much so that it stays above the memory limit.  It
+// returns the new limit, 0 when the spec has no memory limit and is unchanged.
+// Fields of the spec that are unknown to containerd are preserved.
+func RaiseMemoryLimit(bundle string, increment, limitCap int64) (int64, error) {
+	var limit int64
+	err := rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, _ := spec["linux"].(map[string]interface{})
+		resources, _ := linux["resources"].(map[string]interface{})
+		memory, _ := resources["memory"].(map[string]interface{})
+		current, _ := memory["limit"].(float64)
+		if current <= 0 {
+			return false, nil
+		}
+		limit = int64(current) + increment
+		if limitCap > 0 && limit > limitCap {
+			limit = limitCap
+		}
+		if limit <= int64(current) {
+			limit = int64(current)
+			return false, nil
+		}
+		memory["limit"] = limit
+		if swap, ok := memory["swap"].(float64); ok && swap > 0 {
+			memory["swap"] = int64(swap) + limit - int64(current)
+		}
+		return true, nil
+	})
+	return limit, err
+}
@@ -0,0 +1,158 @@
+package runtime
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// BindMount is a host path bind mounted into a container
+type BindMount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// InjectBindMounts adds the mounts to the spec of the bundle, a mount of the
+// spec at the same destination is replaced.  Fields of the spec that are
+// unknown to containerd are preserved.
+func InjectBindMounts(bundle string, mounts []BindMount) error {
+	if len(mounts) == 0 {
+		return nil
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		for _, m := range mounts {
+			addBindMount(spec, m)
+		}
+		return true, nil
+	})
+}
+
+// addBindMount adds the mount to the decoded spec, replacing a mount at the
+// same destination
+func addBindMount(spec map[string]interface{}, m BindMount) {
+	options := []interface{}{"rbind", "rw"}
+	if m.ReadOnly {
+		options[1] = "ro"
+	}
+	mount := map[string]interface{}{
+		"destination": m.Destination,
+		"type":        "bind",
+		"source":      m.Source,
+		"options":     options,
+	}
+	existing, _ := spec["mounts"].([]interface{})
+	for i, e := range existing {
+		if em, ok := e.(map[string]interface{}); ok && em["destination"] == m.Destination {
+			existing[i] = mount
+			return
+		}
+	}
+	spec["mounts"] = append(existing, mount)
+}
+
+// SpecEdit changes the mounts and the environment of a bundle's spec
+type SpecEdit struct {
+	// AddMounts are bind mounted, a mount at the same destination is replaced
+	AddMounts []BindMount
+	// RemoveMounts are the destinations of the mounts that are removed
+	RemoveMounts []string
+	// SetEnv are KEY=VALUE variables of the init process, a variable with the
+	// same key is replaced
+	SetEnv []string
+	// UnsetEnv are the keys of the variables that are removed
+	UnsetEnv []string
+}
+
+// Validate returns an error if a path of the edit is not absolute or a
+// variable is not KEY=VALUE
+func (e SpecEdit) Validate() error {
+	for _, m := range e.AddMounts {
+		if !filepath.IsAbs(m.Source) || !filepath.IsAbs(m.Destination) {
+			return ErrMountPathNotAbs
+		}
+	}
+	for _, d := range e.RemoveMounts {
+		if !filepath.IsAbs(d) {
+			return ErrMountPathNotAbs
+		}
+	}
+	for _, v := range e.SetEnv {
+		if strings.Index(v, "=") <= 0 {
+			return ErrInvalidEnv
+		}
+	}
+	for _, k := range e.UnsetEnv {
+		if k == "" || strings.Contains(k, "=") {
+			return ErrInvalidEnv
+		}
+	}
+	return nil
+}
+
+// EditSpec applies the edit to the spec of the bundle, mounts are removed
+// before the new mounts are added and variables are unset before the new
+// ones are set.  Fields of the spec that are unknown to containerd are
+// preserved.
+func EditSpec(bundle string, e SpecEdit) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		if len(e.RemoveMounts) > 0 {
+			existing, _ := spec["mounts"].([]interface{})
+			var mounts []interface{}
+			for _, m := range existing {
+				if em, ok := m.(map[string]interface{}); ok && containsString(e.RemoveMounts, em["destination"]) {
+					continue
+				}
+				mounts = append(mounts, m)
+			}
+			spec["mounts"] = mounts
+		}
+		for _, m := range e.AddMounts {
+			addBindMount(spec, m)
+		}
+		if len(e.SetEnv) > 0 || len(e.UnsetEnv) > 0 {
+			process, _ := spec["process"].(map[string]interface{})
+			if process == nil {
+				process = make(map[string]interface{})
+				spec["process"] = process
+			}
+			existing, _ := process["env"].([]interface{})
+			var env []interface{}
+			for _, v := range existing {
+				if s, ok := v.(string); ok && (containsString(e.UnsetEnv, envKey(s)) || containsEnvKey(e.SetEnv, envKey(s))) {
+					continue
+				}
+				env = append(env, v)
+			}
+			for _, v := range e.SetEnv {
+				env = append(env, v)
+			}
+			process["env"] = env
+		}
+		return true, nil
+	})
+}
+
+func envKey(v string) string {
+	return strings.SplitN(v, "=", 2)[0]
+}
+
+func containsEnvKey(env []string, key string) bool {
+	for _, v := range env {
+		if envKey(v) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, v interface{}) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
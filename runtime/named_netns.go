@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// networkNamespaceFile is the file in the directory of a named network
+// namespace that the namespace is bind mounted on
+const networkNamespaceFile = "net"
+
+// NetworkNamespace is a network namespace kept by a bind mount under a name,
+// it outlives the processes in it and is joined by containers through the
+// path of the bind mount in their spec
+type NetworkNamespace struct {
+	Name string `json:"name"`
+	// Path is the file the namespace is bind mounted on
+	Path string `json:"path"`
+	// Source is the container whose network namespace was bind mounted, it
+	// is empty for a namespace created for the name
+	Source string `json:"source,omitempty"`
+	// Containers are the IDs of the containers that joined the namespace
+	Containers []string `json:"containers"`
+
+	root string
+}
+
+// LoadNetworkNamespaces returns the named network namespaces in the root
+// directory
+func LoadNetworkNamespaces(root string) ([]*NetworkNamespace, error) {
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*NetworkNamespace
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(root, d.Name(), StateFile))
+		if err != nil {
+			return nil, err
+		}
+		n := &NetworkNamespace{
+			root: root,
+		}
+		if err := json.Unmarshal(data, n); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// Add records the container as joined to the namespace
+func (n *NetworkNamespace) Add(id string) error {
+	n.Containers = append(n.Containers, id)
+	return n.save()
+}
+
+// Remove removes the container from the containers of the namespace
+func (n *NetworkNamespace) Remove(id string) error {
+	for i, c := range n.Containers {
+		if c == id {
+			n.Containers = append(n.Containers[:i], n.Containers[i+1:]...)
+			return n.save()
+		}
+	}
+	return nil
+}
+
+// Contains returns true if the container joined the namespace
+func (n *NetworkNamespace) Contains(id string) bool {
+	for _, c := range n.Containers {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *NetworkNamespace) save() error {
+	data, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(n.root, n.Name, StateFile), data, 0644)
+}
@@ -0,0 +1,187 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/vishvananda/netlink"
+)
+
+// The magic numbers of the filesystems a network namespace file is on, proc
+// on kernels before 3.19
+const (
+	nsfsMagic = 0x6e736673
+	procMagic = 0x9fa0
+)
+
+// NewNetworkNamespace bind mounts the network namespace of the running
+// source container under the name, or a new network namespace whose loopback
+// interface is up when source is nil.  The namespace's state is kept in
+// root/name.
+func NewNetworkNamespace(root, name string, source Container) (*NetworkNamespace, error) {
+	var target string
+	if source != nil {
+		pid, err := initPid(source)
+		if err != nil {
+			return nil, err
+		}
+		target = fmt.Sprintf("/proc/%d/ns/net", pid)
+	}
+	if err := os.MkdirAll(root, 0711); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(root, name)
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, networkNamespaceFile)
+	f, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE|os.O_EXCL, 0444)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	f.Close()
+	if target != "" {
+		err = syscall.Mount(target, path, "none", syscall.MS_BIND, "")
+	} else {
+		err = mountNewNetworkNamespace(path)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	n := &NetworkNamespace{
+		Name: name,
+		Path: path,
+		root: root,
+	}
+	if source != nil {
+		n.Source = source.ID()
+	}
+	if err := n.save(); err != nil {
+		n.Delete()
+		return nil, err
+	}
+	return n, nil
+}
+
+// initPid returns the host pid of the container's init process, the
+// container must have a network namespace of its own
+func initPid(c Container) (int, error) {
+	spec, err := c.Spec()
+	if err != nil {
+		return 0, err
+	}
+	if !hasNetworkNamespace(spec) {
+		return 0, &InterfaceError{Reason: "the container shares the host's network namespace"}
+	}
+	processes, err := c.Processes()
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range processes {
+		if p.ID() == InitProcessID {
+			return p.SystemPid(), nil
+		}
+	}
+	return 0, ErrContainerNotStarted
+}
+
+// mountNewNetworkNamespace creates a network namespace on a thread that left
+// the daemon's namespace and bind mounts it on the path.  The thread is given
+// back to the scheduler only when it could return to the daemon's namespace,
+// otherwise it exits with its goroutine.
+func mountNewNetworkNamespace(path string) error {
+	errCh := make(chan error, 1)
+	go func() {
+		goruntime.LockOSThread()
+		tid := syscall.Gettid()
+		self, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", tid))
+		if err != nil {
+			goruntime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer self.Close()
+		if err := syscall.Unshare(syscall.CLONE_NEWNET); err != nil {
+			goruntime.UnlockOSThread()
+			errCh <- fmt.Errorf("create network namespace: %v", err)
+			return
+		}
+		err = syscall.Mount(fmt.Sprintf("/proc/self/task/%d/ns/net", tid), path, "none", syscall.MS_BIND, "")
+		if err == nil {
+			err = setLoopbackUp()
+		}
+		if serr := system.Setns(self.Fd(), syscall.CLONE_NEWNET); serr != nil {
+			log.WithField("error", serr).Error("containerd: return to the daemon's network namespace")
+			errCh <- err
+			return
+		}
+		goruntime.UnlockOSThread()
+		errCh <- err
+	}()
+	return <-errCh
+}
+
+// setLoopbackUp brings up the loopback interface of the current network
+// namespace, the runtime only does so for the namespaces it creates
+func setLoopbackUp() error {
+	lo, err := netlink.LinkByName("lo")
+	if err != nil {
+		return err
+	}
+	if err := linkSetUp(lo); err != nil {
+		return fmt.Errorf("set lo up: %v", err)
+	}
+	return nil
+}
+
+// JoinNetworkNamespace sets the path of the named network namespace in the
+// spec of the bundle so that the container joins it instead of creating its
+// own
+func JoinNetworkNamespace(bundle string, n *NetworkNamespace) error {
+	if !n.Mounted() {
+		return ErrNetworkNamespaceGone
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, namespaces := specNamespaces(spec)
+		var out []interface{}
+		for _, ns := range namespaces {
+			if m, ok := ns.(map[string]interface{}); ok {
+				if t, _ := m["type"].(string); t == "network" {
+					continue
+				}
+			}
+			out = append(out, ns)
+		}
+		out = append(out, map[string]interface{}{
+			"type": "network",
+			"path": n.Path,
+		})
+		linux["namespaces"] = out
+		return true, nil
+	})
+}
+
+// Mounted returns true if a namespace is still bind mounted on the path, the
+// mount is lost when the host reboots
+func (n *NetworkNamespace) Mounted() bool {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(n.Path, &st); err != nil {
+		return false
+	}
+	return st.Type == nsfsMagic || st.Type == procMagic
+}
+
+// Delete unmounts the namespace and removes its state, the namespace is
+// destroyed once no process is left in it
+func (n *NetworkNamespace) Delete() error {
+	if err := syscall.Unmount(n.Path, syscall.MNT_DETACH); err != nil && err != syscall.EINVAL && err != syscall.ENOENT {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(n.root, n.Name))
+}
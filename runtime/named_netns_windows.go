@@ -0,0 +1,21 @@
+package runtime
+
+// NewNetworkNamespace is not implemented on Windows
+func NewNetworkNamespace(root, name string, source Container) (*NetworkNamespace, error) {
+	return nil, ErrNetworkNotSupported
+}
+
+// JoinNetworkNamespace is not implemented on Windows
+func JoinNetworkNamespace(bundle string, n *NetworkNamespace) error {
+	return ErrNetworkNotSupported
+}
+
+// Mounted returns false as there are no named network namespaces on Windows
+func (n *NetworkNamespace) Mounted() bool {
+	return false
+}
+
+// Delete is not implemented on Windows
+func (n *NetworkNamespace) Delete() error {
+	return ErrNetworkNotSupported
+}
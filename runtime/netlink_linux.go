@@ -0,0 +1,257 @@
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// The netlink socket options and flags of extended acks, they are missing
+// from the syscall package
+const (
+	solNetlink          = 270
+	netlinkCapAck       = 10
+	netlinkExtAck       = 11
+	netlinkGetStrictChk = 12
+
+	nlmFCapped  = 0x100
+	nlmFAckTLVs = 0x200
+
+	nlmsgerrAttrMsg = 1
+)
+
+// NetlinkError is a request of the daemon rejected by the kernel, Message is
+// the kernel's explanation sent in the extended ack
+type NetlinkError struct {
+	Errno   syscall.Errno
+	Message string
+}
+
+func (e *NetlinkError) Error() string {
+	if e.Message == "" {
+		return e.Errno.Error()
+	}
+	return fmt.Sprintf("%s (%v)", e.Message, e.Errno)
+}
+
+// isErrno returns true when err is the errno, returned by the kernel to the
+// daemon's or the vendored netlink's request
+func isErrno(err error, errno syscall.Errno) bool {
+	if e, ok := err.(*NetlinkError); ok {
+		return e.Errno == errno
+	}
+	return err == errno
+}
+
+// netlinkExecute sends the request on a route socket of the current network
+// namespace and waits for its ack.  Extended acks and strict checking are
+// enabled on the socket so that the kernel explains why it rejected the
+// request, the vendored netlink only returns the bare errno.  Kernels older
+// than 4.12 ignore the options and their errors have no message.
+func netlinkExecute(req *nl.NetlinkRequest) error {
+	return netlinkExecuteProto(syscall.NETLINK_ROUTE, req)
+}
+
+// netlinkExecuteProto is netlinkExecute on a socket of the netlink protocol
+func netlinkExecuteProto(proto int, req *nl.NetlinkRequest) error {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, proto)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+	for _, opt := range []int{netlinkCapAck, netlinkExtAck, netlinkGetStrictChk} {
+		syscall.SetsockoptInt(fd, solNetlink, opt, 1)
+	}
+	sa := &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}
+	if err := syscall.Bind(fd, sa); err != nil {
+		return err
+	}
+	req.Flags |= syscall.NLM_F_ACK
+	if err := syscall.Sendto(fd, req.Serialize(), 0, sa); err != nil {
+		return err
+	}
+	buf := make([]byte, syscall.Getpagesize())
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if m.Header.Seq != req.Seq {
+				continue
+			}
+			switch m.Header.Type {
+			case syscall.NLMSG_ERROR:
+				return parseNetlinkError(m)
+			case syscall.NLMSG_DONE:
+				return nil
+			}
+		}
+	}
+}
+
+// parseNetlinkError returns the error of the ack, nil when the request
+// succeeded
+func parseNetlinkError(m syscall.NetlinkMessage) error {
+	native := nl.NativeEndian()
+	if len(m.Data) < 4 {
+		return syscall.EINVAL
+	}
+	errno := -int32(native.Uint32(m.Data[0:4]))
+	if errno == 0 {
+		return nil
+	}
+	e := &NetlinkError{Errno: syscall.Errno(errno)}
+	if m.Header.Flags&nlmFAckTLVs == 0 || len(m.Data) < 4+syscall.NLMSG_HDRLEN {
+		return e
+	}
+	// the request is echoed after the errno, only its header when capped
+	offset := 4 + syscall.NLMSG_HDRLEN
+	if m.Header.Flags&nlmFCapped == 0 {
+		offset = 4 + int(native.Uint32(m.Data[4:8]))
+	}
+	offset = (offset + syscall.NLMSG_ALIGNTO - 1) &^ (syscall.NLMSG_ALIGNTO - 1)
+	if offset > len(m.Data) {
+		return e
+	}
+	attrs, err := nl.ParseRouteAttr(m.Data[offset:])
+	if err != nil {
+		return e
+	}
+	for _, a := range attrs {
+		if a.Attr.Type == nlmsgerrAttrMsg {
+			e.Message = nl.BytesToString(a.Value)
+		}
+	}
+	return e
+}
+
+// linkSet changes the attributes and the flags in change of the link
+func linkSet(link netlink.Link, flags, change uint32, attrs ...*nl.RtAttr) error {
+	req := nl.NewNetlinkRequest(syscall.RTM_SETLINK, syscall.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(syscall.AF_UNSPEC)
+	msg.Index = int32(link.Attrs().Index)
+	msg.Flags = flags
+	msg.Change = change
+	req.AddData(msg)
+	for _, a := range attrs {
+		req.AddData(a)
+	}
+	return netlinkExecute(req)
+}
+
+func linkSetMTU(link netlink.Link, mtu int) error {
+	return linkSet(link, 0, 0, nl.NewRtAttr(syscall.IFLA_MTU, nl.Uint32Attr(uint32(mtu))))
+}
+
+func linkSetTxQueueLen(link netlink.Link, qlen int) error {
+	return linkSet(link, 0, 0, nl.NewRtAttr(syscall.IFLA_TXQLEN, nl.Uint32Attr(uint32(qlen))))
+}
+
+func linkSetHardwareAddr(link netlink.Link, mac net.HardwareAddr) error {
+	return linkSet(link, 0, 0, nl.NewRtAttr(syscall.IFLA_ADDRESS, []byte(mac)))
+}
+
+func linkSetName(link netlink.Link, name string) error {
+	return linkSet(link, 0, 0, nl.NewRtAttr(syscall.IFLA_IFNAME, nl.ZeroTerminated(name)))
+}
+
+func linkSetUp(link netlink.Link) error {
+	return linkSet(link, syscall.IFF_UP, syscall.IFF_UP)
+}
+
+// ipBytes returns the address in the length of its family
+func ipBytes(ip net.IP) []byte {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip.To16()
+}
+
+// routeRequest returns the request adding or deleting the route to its
+// destination in the main table, the gateway, the interface and the priority
+// are set when they are not zero
+func routeRequest(proto int, flags int, route *netlink.Route) *nl.NetlinkRequest {
+	req := nl.NewNetlinkRequest(proto, flags)
+	msg := nl.NewRtMsg()
+	if proto == syscall.RTM_DELROUTE {
+		msg = nl.NewRtDelMsg()
+	}
+	ones, _ := route.Dst.Mask.Size()
+	msg.Family = uint8(nl.GetIPFamily(route.Dst.IP))
+	msg.Dst_len = uint8(ones)
+	msg.Scope = uint8(route.Scope)
+	req.AddData(msg)
+	req.AddData(nl.NewRtAttr(syscall.RTA_DST, ipBytes(route.Dst.IP)))
+	if route.Gw != nil {
+		req.AddData(nl.NewRtAttr(syscall.RTA_GATEWAY, ipBytes(route.Gw)))
+	}
+	if route.LinkIndex != 0 {
+		req.AddData(nl.NewRtAttr(syscall.RTA_OIF, nl.Uint32Attr(uint32(route.LinkIndex))))
+	}
+	if route.Priority > 0 {
+		req.AddData(nl.NewRtAttr(syscall.RTA_PRIORITY, nl.Uint32Attr(uint32(route.Priority))))
+	}
+	return req
+}
+
+// routeReplace adds the route, it replaces a route to the same destination
+// with the same priority
+func routeReplace(route *netlink.Route) error {
+	return netlinkExecute(routeRequest(syscall.RTM_NEWROUTE, syscall.NLM_F_CREATE|syscall.NLM_F_REPLACE, route))
+}
+
+// proxyRequest returns the request adding or deleting the proxy neighbor
+// entry of the address on the link
+func proxyRequest(proto, flags int, ip net.IP, link netlink.Link) *nl.NetlinkRequest {
+	req := nl.NewNetlinkRequest(proto, flags)
+	req.AddData(&netlink.Ndmsg{
+		Family: uint8(nl.GetIPFamily(ip)),
+		Index:  uint32(link.Attrs().Index),
+		Flags:  netlink.NTF_PROXY,
+	})
+	req.AddData(nl.NewRtAttr(netlink.NDA_DST, ipBytes(ip)))
+	return req
+}
+
+func proxyAdd(ip net.IP, link netlink.Link) error {
+	return netlinkExecute(proxyRequest(syscall.RTM_NEWNEIGH, syscall.NLM_F_CREATE|syscall.NLM_F_REPLACE, ip, link))
+}
+
+func proxyDel(ip net.IP, link netlink.Link) error {
+	return netlinkExecute(proxyRequest(syscall.RTM_DELNEIGH, 0, ip, link))
+}
+
+// addrRequest returns the request adding or deleting the address of the link,
+// the label is only sent when it is not empty
+func addrRequest(proto, flags int, link netlink.Link, ipnet *net.IPNet, label string) *nl.NetlinkRequest {
+	req := nl.NewNetlinkRequest(proto, flags)
+	msg := nl.NewIfAddrmsg(nl.GetIPFamily(ipnet.IP))
+	msg.Index = uint32(link.Attrs().Index)
+	ones, _ := ipnet.Mask.Size()
+	msg.Prefixlen = uint8(ones)
+	req.AddData(msg)
+	req.AddData(nl.NewRtAttr(syscall.IFA_LOCAL, ipBytes(ipnet.IP)))
+	req.AddData(nl.NewRtAttr(syscall.IFA_ADDRESS, ipBytes(ipnet.IP)))
+	if label != "" {
+		req.AddData(nl.NewRtAttr(syscall.IFA_LABEL, nl.ZeroTerminated(label)))
+	}
+	return req
+}
+
+// addrAdd adds the address to the link, it fails when the link has the
+// address already
+func addrAdd(link netlink.Link, ipnet *net.IPNet, label string) error {
+	return netlinkExecute(addrRequest(syscall.RTM_NEWADDR, syscall.NLM_F_CREATE|syscall.NLM_F_EXCL, link, ipnet, label))
+}
+
+func addrDel(link netlink.Link, ipnet *net.IPNet) error {
+	return netlinkExecute(addrRequest(syscall.RTM_DELADDR, 0, link, ipnet, ""))
+}
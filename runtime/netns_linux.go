@@ -0,0 +1,104 @@
+package runtime
+
+import (
+	"fmt"
+	"os"
+	goruntime "runtime"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/specs"
+	"github.com/opencontainers/runc/libcontainer/system"
+	"github.com/vishvananda/netlink"
+)
+
+// inNetworkNamespace calls fn on a thread that joined the network namespace
+// of the pid, the netlink sockets opened by fn are in that namespace.  The
+// thread is given back to the scheduler only when it could return to the
+// daemon's namespace, otherwise it exits with its goroutine.
+func inNetworkNamespace(pid int, fn func() error) error {
+	target, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	errCh := make(chan error, 1)
+	go func() {
+		goruntime.LockOSThread()
+		self, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", syscall.Gettid()))
+		if err != nil {
+			goruntime.UnlockOSThread()
+			errCh <- err
+			return
+		}
+		defer self.Close()
+		if err := system.Setns(target.Fd(), syscall.CLONE_NEWNET); err != nil {
+			goruntime.UnlockOSThread()
+			errCh <- fmt.Errorf("join network namespace of %d: %v", pid, err)
+			return
+		}
+		err = fn()
+		if serr := system.Setns(self.Fd(), syscall.CLONE_NEWNET); serr != nil {
+			log.WithField("error", serr).Error("containerd: return to the daemon's network namespace")
+			errCh <- err
+			return
+		}
+		goruntime.UnlockOSThread()
+		errCh <- err
+	}()
+	return <-errCh
+}
+
+// hasNetworkNamespace returns true when the spec gives the container a
+// network namespace of its own or joins another one, containers without one
+// share the host's network namespace
+func hasNetworkNamespace(spec *specs.Spec) bool {
+	for _, ns := range spec.Linux.Namespaces {
+		if ns.Type == "network" {
+			return true
+		}
+	}
+	return false
+}
+
+// networkAddresses returns the addresses of the network namespace of the pid
+// in CIDR notation, the loopback and the link-local addresses are left out
+func networkAddresses(pid int) ([]string, error) {
+	var addrs []string
+	err := inNetworkNamespace(pid, func() error {
+		list, err := addrList(nil, netlink.FAMILY_ALL)
+		if err != nil {
+			return err
+		}
+		for _, a := range list {
+			if a.IP.IsLoopback() || a.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			addrs = append(addrs, a.IPNet.String())
+		}
+		return nil
+	})
+	return addrs, err
+}
+
+// recordAddresses saves the addresses of the container's network namespace
+// in its record, nothing is recorded for a container on the host's network
+func (c *container) recordAddresses(spec *specs.Spec, pid int) {
+	if !hasNetworkNamespace(spec) {
+		return
+	}
+	addrs, err := networkAddresses(pid)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Warn("containerd: read container addresses")
+		return
+	}
+	if err := c.setAddresses(addrs); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Error("containerd: save container addresses")
+	}
+}
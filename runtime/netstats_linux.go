@@ -0,0 +1,119 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/runc/libcontainer"
+)
+
+// netStatsTTL is how long the link stats of a network namespace are reused,
+// containers that share a namespace are collected in the same stats interval
+// so they read the namespace's links once
+const netStatsTTL = 500 * time.Millisecond
+
+var netStats = &netStatsCache{
+	namespaces: make(map[uint64]*namespaceStats),
+}
+
+// netStatsCache holds the stats of all links of each network namespace
+type netStatsCache struct {
+	mu         sync.Mutex
+	namespaces map[uint64]*namespaceStats
+}
+
+type namespaceStats struct {
+	read       time.Time
+	interfaces []*libcontainer.NetworkInterface
+}
+
+// networkStats returns the stats of the links in the network namespace of the
+// pid, reading all of the namespace's links at once
+func networkStats(pid int) ([]*libcontainer.NetworkInterface, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(fmt.Sprintf("/proc/%d/ns/net", pid), &st); err != nil {
+		return nil, err
+	}
+	return netStats.get(st.Ino, pid)
+}
+
+func (c *netStatsCache) get(ns uint64, pid int) ([]*libcontainer.NetworkInterface, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if s, ok := c.namespaces[ns]; ok && now.Sub(s.read) < netStatsTTL {
+		return s.interfaces, nil
+	}
+	interfaces, err := readNetDev(fmt.Sprintf("/proc/%d/net/dev", pid))
+	if err != nil {
+		return nil, err
+	}
+	// drop the namespaces that were not collected recently so that the
+	// cache does not grow with containers that were deleted
+	for id, s := range c.namespaces {
+		if now.Sub(s.read) > 10*netStatsTTL {
+			delete(c.namespaces, id)
+		}
+	}
+	c.namespaces[ns] = &namespaceStats{
+		read:       now,
+		interfaces: interfaces,
+	}
+	return interfaces, nil
+}
+
+// readNetDev parses the link stats of a /proc/<pid>/net/dev file, the loopback
+// link is skipped
+func readNetDev(path string) ([]*libcontainer.NetworkInterface, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var (
+		out []*libcontainer.NetworkInterface
+		s   = bufio.NewScanner(f)
+	)
+	for n := 0; s.Scan(); n++ {
+		// the first two lines are headers
+		if n < 2 {
+			continue
+		}
+		parts := strings.SplitN(s.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		if name == "lo" {
+			continue
+		}
+		fields := strings.Fields(parts[1])
+		if len(fields) < 12 {
+			return nil, fmt.Errorf("invalid net/dev line for %s", name)
+		}
+		var v [12]uint64
+		for i := range v {
+			if v[i], err = strconv.ParseUint(fields[i], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, &libcontainer.NetworkInterface{
+			Name:      name,
+			RxBytes:   v[0],
+			RxPackets: v[1],
+			RxErrors:  v[2],
+			RxDropped: v[3],
+			TxBytes:   v[8],
+			TxPackets: v[9],
+			TxErrors:  v[10],
+			TxDropped: v[11],
+		})
+	}
+	return out, s.Err()
+}
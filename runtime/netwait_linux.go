@@ -0,0 +1,28 @@
+package runtime
+
+import (
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// InjectNetworkWait adds a prestart hook to the spec of the bundle that delays
+// the start of the user process until the container's network namespace has
+// an address, for at most timeout.  The hook is the shim and it runs after the
+// bundle's and the daemon's prestart hooks so that hooks configuring the
+// network run first.
+func InjectNetworkWait(bundle string, timeout time.Duration) error {
+	path, err := exec.LookPath(shimBinary)
+	if err != nil {
+		return err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return err
+	}
+	return InjectOCIHooks(bundle, &OCIHooks{
+		Prestart: []OCIHook{{
+			Path: path,
+			Args: []string{filepath.Base(path), "-wait-network", timeout.String()},
+		}},
+	})
+}
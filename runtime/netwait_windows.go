@@ -0,0 +1,7 @@
+package runtime
+
+import "time"
+
+func InjectNetworkWait(bundle string, timeout time.Duration) error {
+	return ErrNetworkWaitNotSupported
+}
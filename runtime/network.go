@@ -0,0 +1,113 @@
+package runtime
+
+import "fmt"
+
+// NetworkConfig is the configuration the daemon applies to the container's
+// network namespace once the container started
+type NetworkConfig struct {
+	// Interfaces are the properties of the container's interfaces, they are
+	// set on interfaces created by the prestart hooks or the network agent
+	Interfaces []InterfaceConfig `json:"interfaces,omitempty"`
+	// VF is an SR-IOV virtual function moved into the container's network
+	// namespace
+	VF *VFConfig `json:"vf,omitempty"`
+	// Addresses are added to the interfaces once they are configured, before
+	// the routes
+	Addresses []AddressConfig `json:"addresses,omitempty"`
+	// Routes are added in order once the interfaces are configured
+	Routes []RouteConfig `json:"routes,omitempty"`
+	// RoutedInterface is the container's side of a veth pair whose addresses
+	// are routed by the host through the peer of the interface, the host
+	// answers ARP and NDP for them on the routed uplink
+	RoutedInterface string `json:"routedInterface,omitempty"`
+}
+
+// InterfaceConfig sets the link properties of an interface in the container's
+// network namespace, the zero values leave a property unchanged
+type InterfaceConfig struct {
+	// Name is the name of the interface in the container, e.g. eth0
+	Name string `json:"name"`
+	// MTU must not exceed the MTU of the interface's parent device
+	MTU int `json:"mtu,omitempty"`
+	// TxQueueLen is the length of the interface's transmit queue in packets
+	TxQueueLen int `json:"txQueueLen,omitempty"`
+	// MAC is the unicast hardware address of the interface
+	MAC string `json:"mac,omitempty"`
+}
+
+// VFConfig is an SR-IOV virtual function of a physical function of the host
+type VFConfig struct {
+	// PF is the name of the physical function's interface on the host
+	PF string `json:"pf"`
+	// Index is the number of the virtual function on the physical function,
+	// it is allocated by the daemon
+	Index int `json:"index"`
+	// Name is the name of the virtual function's interface in the container,
+	// the interface keeps its name when empty
+	Name string `json:"name,omitempty"`
+	// MAC and VLAN are set on the virtual function by the physical function
+	// so that the container cannot change them
+	MAC  string `json:"mac,omitempty"`
+	VLAN int    `json:"vlan,omitempty"`
+}
+
+// AddressConfig is an address added to an interface in the container's
+// network namespace, the interface is brought up
+type AddressConfig struct {
+	// Interface is the name of the interface in the container
+	Interface string `json:"interface"`
+	// Network is the network of the daemon's IPAM the address is allocated
+	// from, the address is given by the client when it is empty
+	Network string `json:"network,omitempty"`
+	// Address is in CIDR notation, it is set by the daemon when the
+	// address is allocated from a network
+	Address string `json:"address,omitempty"`
+	// Gateway is the router of the network the address was allocated from
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// RouteConfig is a route added to the main table of the container's network
+// namespace, it replaces a route to the same destination
+type RouteConfig struct {
+	// Destination is a network in CIDR notation or default for the default
+	// route of the gateway's family
+	Destination string `json:"destination"`
+	// Gateway is the address of the next hop, a route without a gateway is
+	// a route to the interface's link
+	Gateway string `json:"gateway,omitempty"`
+	// Interface is the name of the interface the route goes through, it is
+	// found from the gateway when empty
+	Interface string `json:"interface,omitempty"`
+	Metric    int    `json:"metric,omitempty"`
+}
+
+// Address is an address of an interface in the container's network namespace
+type Address struct {
+	// Interface is the name of the interface in the container, e.g. eth0
+	Interface string
+	// Label tells the secondary addresses of the interface apart, it is the
+	// interface's name followed by a colon and a suffix, e.g. eth0:meta.  The
+	// kernel keeps labels on IPv4 addresses only.
+	Label string
+	// Address is in CIDR notation
+	Address string
+}
+
+// Empty returns true when the configuration changes nothing
+func (n NetworkConfig) Empty() bool {
+	return len(n.Interfaces) == 0 && n.VF == nil && len(n.Addresses) == 0 && len(n.Routes) == 0 && n.RoutedInterface == ""
+}
+
+// InterfaceError is returned when the configuration of an interface is invalid
+// or does not fit the interface's parent device
+type InterfaceError struct {
+	Name   string
+	Reason string
+}
+
+func (e *InterfaceError) Error() string {
+	if e.Name == "" {
+		return "containerd: invalid network configuration: " + e.Reason
+	}
+	return fmt.Sprintf("containerd: invalid configuration of interface %q: %s", e.Name, e.Reason)
+}
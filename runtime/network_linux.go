@@ -0,0 +1,303 @@
+package runtime
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/docker/containerd/specs"
+	"github.com/vishvananda/netlink"
+)
+
+// minMTU is the smallest MTU of an IPv4 link
+const minMTU = 68
+
+// ValidateNetwork checks the configuration before the container is created,
+// the interfaces are checked against their parent device once they exist
+func ValidateNetwork(n NetworkConfig) error {
+	if vf := n.VF; vf != nil {
+		if vf.Name != "" && !validInterfaceName(vf.Name) {
+			return &InterfaceError{Name: vf.Name, Reason: "invalid name"}
+		}
+		if vf.VLAN < 0 || vf.VLAN > 4094 {
+			return &InterfaceError{Name: vf.Name, Reason: fmt.Sprintf("vlan %d is not between 0 and 4094", vf.VLAN)}
+		}
+		if vf.MAC != "" {
+			if _, err := parseUnicastMAC(vf.MAC); err != nil {
+				return &InterfaceError{Name: vf.Name, Reason: err.Error()}
+			}
+		}
+	}
+	names := make(map[string]bool)
+	for _, i := range n.Interfaces {
+		if !validInterfaceName(i.Name) {
+			return &InterfaceError{Name: i.Name, Reason: "invalid name"}
+		}
+		if names[i.Name] {
+			return &InterfaceError{Name: i.Name, Reason: "configured twice"}
+		}
+		names[i.Name] = true
+		if i.MTU != 0 && i.MTU < minMTU {
+			return &InterfaceError{Name: i.Name, Reason: fmt.Sprintf("mtu %d is below %d", i.MTU, minMTU)}
+		}
+		if i.TxQueueLen < 0 {
+			return &InterfaceError{Name: i.Name, Reason: fmt.Sprintf("negative txqueuelen %d", i.TxQueueLen)}
+		}
+		if i.MAC != "" {
+			if _, err := parseUnicastMAC(i.MAC); err != nil {
+				return &InterfaceError{Name: i.Name, Reason: err.Error()}
+			}
+		}
+	}
+	if n.RoutedInterface != "" {
+		if routedUplink == "" {
+			return ErrRoutingNotEnabled
+		}
+		if !validInterfaceName(n.RoutedInterface) {
+			return &InterfaceError{Name: n.RoutedInterface, Reason: "invalid name"}
+		}
+	}
+	for _, a := range n.Addresses {
+		if !validInterfaceName(a.Interface) {
+			return &InterfaceError{Name: a.Interface, Reason: "invalid name"}
+		}
+		// the address of a network is allocated once the configuration
+		// is valid
+		if a.Network != "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(a.Address); err != nil {
+			return &InterfaceError{Name: a.Interface, Reason: fmt.Sprintf("invalid address %q, an address needs a network or to be in CIDR notation", a.Address)}
+		}
+	}
+	for _, r := range n.Routes {
+		if _, err := netlinkRoute(r); err != nil {
+			return &InterfaceError{Name: r.Interface, Reason: fmt.Sprintf("route to %s: %v", r.Destination, err)}
+		}
+	}
+	return nil
+}
+
+// netlinkRoute returns the netlink route of r, the index of its interface is
+// not set
+func netlinkRoute(r RouteConfig) (*netlink.Route, error) {
+	route := &netlink.Route{Priority: r.Metric}
+	if r.Gateway != "" {
+		if route.Gw = net.ParseIP(r.Gateway); route.Gw == nil {
+			return nil, fmt.Errorf("invalid gateway %q", r.Gateway)
+		}
+	} else {
+		if r.Interface == "" {
+			return nil, fmt.Errorf("a route needs a gateway or an interface")
+		}
+		route.Scope = netlink.SCOPE_LINK
+	}
+	if r.Interface != "" && !validInterfaceName(r.Interface) {
+		return nil, fmt.Errorf("invalid interface name %q", r.Interface)
+	}
+	if r.Metric < 0 {
+		return nil, fmt.Errorf("negative metric %d", r.Metric)
+	}
+	dst := r.Destination
+	if dst == "default" {
+		dst = "0.0.0.0/0"
+		if route.Gw != nil && route.Gw.To4() == nil {
+			dst = "::/0"
+		}
+	}
+	_, ipnet, err := net.ParseCIDR(dst)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination %q", r.Destination)
+	}
+	if route.Gw != nil && (ipnet.IP.To4() == nil) != (route.Gw.To4() == nil) {
+		return nil, fmt.Errorf("gateway %s is not of the destination's family", r.Gateway)
+	}
+	route.Dst = ipnet
+	return route, nil
+}
+
+// validInterfaceName returns true when the kernel accepts the name of an
+// interface
+func validInterfaceName(name string) bool {
+	return name != "" && name != "." && name != ".." && len(name) < syscall.IFNAMSIZ && !strings.ContainsAny(name, "/: \t\n")
+}
+
+// parseUnicastMAC returns the ethernet address, multicast and zero addresses
+// cannot be set on an interface
+func parseUnicastMAC(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("%s is not an ethernet address", s)
+	}
+	if mac[0]&1 == 1 {
+		return nil, fmt.Errorf("%s is a multicast address", s)
+	}
+	if mac.String() == "00:00:00:00:00:00" {
+		return nil, fmt.Errorf("%s is the zero address", s)
+	}
+	return mac, nil
+}
+
+// configureNetwork applies the network configuration of the container to the
+// network namespace of the pid: the virtual function is attached, then the
+// interfaces are configured, the addresses and the routes are added and the
+// addresses of the routed interface are routed by the host
+func (c *container) configureNetwork(spec *specs.Spec, pid int) error {
+	if c.network.Empty() {
+		return nil
+	}
+	if !hasNetworkNamespace(spec) {
+		return &InterfaceError{Reason: "the container shares the host's network namespace"}
+	}
+	if c.network.VF != nil {
+		if err := attachVF(pid, *c.network.VF); err != nil {
+			return fmt.Errorf("containerd: attach virtual function %d of %s: %v", c.network.VF.Index, c.network.VF.PF, err)
+		}
+	}
+	for _, i := range c.network.Interfaces {
+		if err := configureInterface(pid, i); err != nil {
+			if _, ok := err.(*InterfaceError); ok {
+				return err
+			}
+			return fmt.Errorf("containerd: configure interface %s: %v", i.Name, err)
+		}
+	}
+	if len(c.network.Addresses) > 0 {
+		if err := inNetworkNamespace(pid, func() error {
+			return addAddresses(c.network.Addresses)
+		}); err != nil {
+			return fmt.Errorf("containerd: add addresses: %v", err)
+		}
+	}
+	if len(c.network.Routes) > 0 {
+		if err := inNetworkNamespace(pid, func() error {
+			return addRoutes(c.network.Routes)
+		}); err != nil {
+			return fmt.Errorf("containerd: add routes: %v", err)
+		}
+	}
+	if c.network.RoutedInterface != "" {
+		if err := c.routeAddresses(pid); err != nil {
+			if _, ok := err.(*InterfaceError); ok {
+				return err
+			}
+			return fmt.Errorf("containerd: route interface %s: %v", c.network.RoutedInterface, err)
+		}
+	}
+	return nil
+}
+
+// addAddresses adds the addresses to the interfaces of the current network
+// namespace and brings them up, an address the interface has already is kept
+// so that a restarted container gets its address again
+func addAddresses(addresses []AddressConfig) error {
+	for _, a := range addresses {
+		ip, ipnet, err := net.ParseCIDR(a.Address)
+		if err != nil {
+			return fmt.Errorf("address of %s: invalid address %q", a.Interface, a.Address)
+		}
+		ipnet.IP = ip
+		link, err := netlink.LinkByName(a.Interface)
+		if err != nil {
+			return fmt.Errorf("address %s: %v", a.Address, err)
+		}
+		if err := addrAdd(link, ipnet, ""); err != nil && !isErrno(err, syscall.EEXIST) {
+			return fmt.Errorf("address %s of %s: %v", a.Address, a.Interface, err)
+		}
+		if err := linkSetUp(link); err != nil {
+			return fmt.Errorf("set %s up: %v", a.Interface, err)
+		}
+	}
+	return nil
+}
+
+// addRoutes adds the routes to the current network namespace, a route to the
+// same destination is replaced
+func addRoutes(routes []RouteConfig) error {
+	for _, r := range routes {
+		route, err := netlinkRoute(r)
+		if err != nil {
+			return err
+		}
+		if r.Interface != "" {
+			link, err := netlink.LinkByName(r.Interface)
+			if err != nil {
+				return fmt.Errorf("route to %s: %v", r.Destination, err)
+			}
+			route.LinkIndex = link.Attrs().Index
+		}
+		if err := routeReplace(route); err != nil {
+			return fmt.Errorf("route to %s: %v", r.Destination, err)
+		}
+	}
+	return nil
+}
+
+// configureInterface sets the link properties of the interface, the MTU and
+// the MAC are checked against the parent of a macvlan, ipvlan or vlan
+// interface first
+func configureInterface(pid int, i InterfaceConfig) error {
+	var link netlink.Link
+	if err := inNetworkNamespace(pid, func() (err error) {
+		link, err = netlink.LinkByName(i.Name)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := validateParent(link, i); err != nil {
+		return err
+	}
+	return inNetworkNamespace(pid, func() error {
+		if i.MTU != 0 {
+			if err := linkSetMTU(link, i.MTU); err != nil {
+				return fmt.Errorf("set mtu: %v", err)
+			}
+		}
+		if i.TxQueueLen != 0 {
+			if err := linkSetTxQueueLen(link, i.TxQueueLen); err != nil {
+				return fmt.Errorf("set txqueuelen: %v", err)
+			}
+		}
+		if i.MAC != "" {
+			mac, err := parseUnicastMAC(i.MAC)
+			if err != nil {
+				return err
+			}
+			if err := linkSetHardwareAddr(link, mac); err != nil {
+				return fmt.Errorf("set mac: %v", err)
+			}
+		}
+		return nil
+	})
+}
+
+// validateParent checks the configuration against the parent device of the
+// link in the daemon's network namespace, veth pairs have a peer and no parent
+func validateParent(link netlink.Link, i InterfaceConfig) error {
+	switch link.(type) {
+	case *netlink.Macvlan, *netlink.Macvtap, *netlink.IPVlan, *netlink.Vlan:
+	default:
+		return nil
+	}
+	parent, err := netlink.LinkByIndex(link.Attrs().ParentIndex)
+	if err != nil {
+		return fmt.Errorf("parent device: %v", err)
+	}
+	pa := parent.Attrs()
+	if i.MTU > pa.MTU {
+		return &InterfaceError{Name: i.Name, Reason: fmt.Sprintf("mtu %d exceeds the mtu %d of the parent device %s", i.MTU, pa.MTU, pa.Name)}
+	}
+	if i.MAC != "" {
+		if _, ok := link.(*netlink.IPVlan); ok {
+			return &InterfaceError{Name: i.Name, Reason: "an ipvlan interface has the mac of its parent device " + pa.Name}
+		}
+		if mac, err := parseUnicastMAC(i.MAC); err == nil && mac.String() == pa.HardwareAddr.String() {
+			return &InterfaceError{Name: i.Name, Reason: fmt.Sprintf("mac %s is the mac of the parent device %s", i.MAC, pa.Name)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package runtime
+
+// ValidateNetwork returns ErrNetworkNotSupported as the daemon does not
+// configure the network of containers on Windows
+func ValidateNetwork(n NetworkConfig) error {
+	if n.Empty() {
+		return nil
+	}
+	return ErrNetworkNotSupported
+}
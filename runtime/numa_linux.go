@@ -0,0 +1,128 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseNUMANodes returns the nodes of a list in the cpuset list format
+func ParseNUMANodes(list string) ([]int, error) {
+	nodes, err := parseCPUList(list)
+	if err != nil {
+		return nil, ErrInvalidNUMANodes
+	}
+	return nodes, nil
+}
+
+// ValidateNUMA checks that the nodes exist on the host and that the memory
+// policy can be applied to them
+func ValidateNUMA(n NUMAConfig) error {
+	if n.Nodes == "" {
+		if n.MemoryPolicy != "" {
+			return ErrInvalidMemoryPolicy
+		}
+		return nil
+	}
+	nodes, err := ParseNUMANodes(n.Nodes)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if _, err := os.Stat(fmt.Sprintf("/sys/devices/system/node/node%d", node)); err != nil {
+			return ErrInvalidNUMANodes
+		}
+	}
+	switch n.MemoryPolicy {
+	case "", MemoryPolicyBind, MemoryPolicyInterleave:
+	case MemoryPolicyPreferred:
+		// the kernel only prefers a single node
+		if len(nodes) != 1 {
+			return ErrInvalidMemoryPolicy
+		}
+	default:
+		return ErrInvalidMemoryPolicy
+	}
+	return nil
+}
+
+// numaStats returns the memory usage per NUMA node from the memory cgroup's
+// memory.numa_stat
+func numaStats(root string) ([]NUMAStat, error) {
+	f, err := os.Open(filepath.Join(root, "memory.numa_stat"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var (
+		pageSize = uint64(os.Getpagesize())
+		nodes    = make(map[int]*NUMAStat)
+		s        = bufio.NewScanner(f)
+	)
+	for s.Scan() {
+		// each line is in the format "total=<pages> N0=<pages> N1=<pages>"
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 || !strings.HasPrefix(kv[0], "N") {
+				continue
+			}
+			node, err := strconv.Atoi(kv[0][1:])
+			if err != nil {
+				continue
+			}
+			pages, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			st, ok := nodes[node]
+			if !ok {
+				st = &NUMAStat{Node: node}
+				nodes[node] = st
+			}
+			switch strings.SplitN(fields[0], "=", 2)[0] {
+			case "total":
+				st.Total = pages * pageSize
+			case "file":
+				st.File = pages * pageSize
+			case "anon":
+				st.Anon = pages * pageSize
+			case "unevictable":
+				st.Unevictable = pages * pageSize
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	var out []NUMAStat
+	for _, st := range nodes {
+		out = append(out, *st)
+	}
+	sort.Sort(byNode(out))
+	return out, nil
+}
+
+type byNode []NUMAStat
+
+func (s byNode) Len() int {
+	return len(s)
+}
+
+func (s byNode) Less(i, j int) bool {
+	return s[i].Node < s[j].Node
+}
+
+func (s byNode) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
@@ -0,0 +1,10 @@
+package runtime
+
+// ValidateNUMA returns ErrInvalidNUMANodes as NUMA binding is not supported on
+// Windows
+func ValidateNUMA(n NUMAConfig) error {
+	if n.Nodes == "" && n.MemoryPolicy == "" {
+		return nil
+	}
+	return ErrInvalidNUMANodes
+}
@@ -0,0 +1,118 @@
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+)
+
+// OCIHook is a hook of the OCI spec
+type OCIHook struct {
+	Path string   `json:"path"`
+	Args []string `json:"args,omitempty"`
+	Env  []string `json:"env,omitempty"`
+}
+
+// OCIHooks are the hooks the daemon adds to the spec of every container.
+// Prestart and poststart hooks run after the bundle's own hooks and poststop
+// hooks run before them, so the daemon's hooks always see the container set up
+// by the bundle's hooks.  Within a stage hooks run in the order they are given.
+type OCIHooks struct {
+	Prestart  []OCIHook `json:"prestart,omitempty"`
+	Poststart []OCIHook `json:"poststart,omitempty"`
+	Poststop  []OCIHook `json:"poststop,omitempty"`
+}
+
+// LoadOCIHooks reads the hooks from the json file at path
+func LoadOCIHooks(path string) (*OCIHooks, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var h OCIHooks
+	if err := json.NewDecoder(f).Decode(&h); err != nil {
+		return nil, err
+	}
+	for _, hooks := range [][]OCIHook{h.Prestart, h.Poststart, h.Poststop} {
+		for _, hook := range hooks {
+			if !filepath.IsAbs(hook.Path) {
+				return nil, ErrOCIHookPathNotAbs
+			}
+		}
+	}
+	return &h, nil
+}
+
+// InjectOCIHooks adds the hooks to the spec of the bundle.  Hooks that are
+// already in the spec are not added again so a bundle can be created more than
+// once.  Fields of the spec that are unknown to containerd are preserved.
+func InjectOCIHooks(bundle string, h *OCIHooks) error {
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		existing, _ := spec["hooks"].(map[string]interface{})
+		if existing == nil {
+			existing = make(map[string]interface{})
+		}
+		changed := false
+		for _, stage := range []struct {
+			name  string
+			hooks []OCIHook
+			// first places the hooks before the bundle's hooks
+			first bool
+		}{
+			{"prestart", h.Prestart, false},
+			{"poststart", h.Poststart, false},
+			{"poststop", h.Poststop, true},
+		} {
+			current, _ := existing[stage.name].([]interface{})
+			var add []interface{}
+			for _, hook := range stage.hooks {
+				d, err := decodedHook(hook)
+				if err != nil {
+					return false, err
+				}
+				if !containsHook(current, d) {
+					add = append(add, d)
+				}
+			}
+			if len(add) == 0 {
+				continue
+			}
+			if stage.first {
+				current = append(add, current...)
+			} else {
+				current = append(current, add...)
+			}
+			existing[stage.name] = current
+			changed = true
+		}
+		if changed {
+			spec["hooks"] = existing
+		}
+		return changed, nil
+	})
+}
+
+// decodedHook returns the hook as it is decoded from a spec so that it can be
+// compared with the spec's hooks
+func decodedHook(hook OCIHook) (interface{}, error) {
+	data, err := json.Marshal(hook)
+	if err != nil {
+		return nil, err
+	}
+	var d interface{}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func containsHook(hooks []interface{}, hook interface{}) bool {
+	for _, h := range hooks {
+		if reflect.DeepEqual(h, hook) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/docker/containerd/mux"
+)
+
+const (
+	// MaxReplaySize is the amount of recent output kept for each process so
+	// that it can be replayed to clients when they attach
+	MaxReplaySize = 64 * 1024
+	// frames buffered for each attached client before it is disconnected
+	subscriberBuffer = 128
+)
+
+// Frame is a chunk of a process' stdout or stderr
+type Frame struct {
+	Stream mux.Stream
+	Data   []byte
+}
+
+// output reads the framed stdout and stderr of a process written by the shim
+// and broadcasts it to attached clients
+type output struct {
+	mu          sync.Mutex
+	history     []Frame
+	size        int
+	subscribers map[chan Frame]struct{}
+	// dropped are the subscribers that were disconnected because they did
+	// not keep up with the output until they detach
+	dropped map[chan Frame]struct{}
+	done    bool
+	// pending is the start of a frame that was not fully read yet
+	pending []byte
+}
+
+func newOutput() *output {
+	return &output{
+		subscribers: make(map[chan Frame]struct{}),
+		dropped:     make(map[chan Frame]struct{}),
+	}
+}
+
+// feed parses the frames in data, which continues the data of the previous
+// call, and broadcasts them.  It returns false if the data is not framed.
+func (o *output) feed(data []byte) bool {
+	buf := append(o.pending, data...)
+	for len(buf) >= mux.HeaderSize {
+		s := mux.Stream(buf[0])
+		if s > mux.Stderr {
+			o.pending = nil
+			return false
+		}
+		n := mux.HeaderSize + int(binary.BigEndian.Uint32(buf[4:mux.HeaderSize]))
+		if len(buf) < n {
+			break
+		}
+		o.broadcast(Frame{
+			Stream: s,
+			Data:   append([]byte(nil), buf[mux.HeaderSize:n]...),
+		})
+		buf = buf[n:]
+	}
+	o.pending = append([]byte(nil), buf...)
+	return true
+}
+
+// finish closes the channels of the attached clients once the shim has exited
+func (o *output) finish() {
+	o.mu.Lock()
+	o.done = true
+	for ch := range o.subscribers {
+		delete(o.subscribers, ch)
+		close(ch)
+	}
+	o.mu.Unlock()
+}
+
+func (o *output) broadcast(f Frame) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.history = append(o.history, f)
+	o.size += len(f.Data)
+	for o.size > MaxReplaySize {
+		o.size -= len(o.history[0].Data)
+		o.history = o.history[1:]
+	}
+	for ch := range o.subscribers {
+		select {
+		case ch <- f:
+		default:
+			// the client is not keeping up with the output so it is
+			// disconnected instead of stalling every other client
+			delete(o.subscribers, ch)
+			o.dropped[ch] = struct{}{}
+			close(ch)
+		}
+	}
+}
+
+// subscribe returns a channel that first receives up to replay bytes of the
+// most recent output followed by all new output.  The channel is closed when
+// the process' output ends.
+func (o *output) subscribe(replay int) (chan Frame, func() error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var (
+		start = len(o.history)
+		size  int
+	)
+	for start > 0 && size+len(o.history[start-1].Data) <= replay {
+		start--
+		size += len(o.history[start].Data)
+	}
+	ch := make(chan Frame, len(o.history)-start+subscriberBuffer)
+	for _, f := range o.history[start:] {
+		ch <- f
+	}
+	if o.done {
+		close(ch)
+		return ch, func() error { return nil }
+	}
+	o.subscribers[ch] = struct{}{}
+	return ch, func() error {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if _, ok := o.dropped[ch]; ok {
+			delete(o.dropped, ch)
+			return ErrAttachTooSlow
+		}
+		if _, ok := o.subscribers[ch]; ok {
+			delete(o.subscribers, ch)
+			close(ch)
+		}
+		return nil
+	}
+}
@@ -9,8 +9,12 @@ import (
 	"path/filepath"
 	"strconv"
 	"syscall"
+	"time"
 
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/mux"
 	"github.com/docker/containerd/specs"
+	"github.com/docker/containerd/tracing"
 )
 
 type Process interface {
@@ -22,8 +26,14 @@ type Process interface {
 	ID() string
 	CloseStdin() error
 	Resize(int, int) error
+	// Trace sends the span context of the process' start to the shim which
+	// reports the timings of starting the runtime as its children
+	Trace(tracing.SpanContext) error
 	// ExitFD returns the fd the provides an event when the process exits
 	ExitFD() int
+	// LogFD returns the fd that provides an event each time the process'
+	// log file is rotated
+	LogFD() int
 	// ExitStatus returns the exit status of the process or an error if it
 	// has not exited
 	ExitStatus() (int, error)
@@ -35,10 +45,32 @@ type Process interface {
 	Container() Container
 	// Stdio of the container
 	Stdio() Stdio
+	// OutputFD returns the fd of the framed output written by the shim or -1
+	// if the process' output is not read
+	OutputFD() int
+	// ReadOutput reads the available output from OutputFD to the attached
+	// clients and returns false once the output ended
+	ReadOutput() bool
+	// StdioMode returns how the shim forwards the process' stdio, it is empty
+	// when the stdio is a terminal or the stdio socket
+	StdioMode() string
 	// SystemPid is the pid on the system
 	SystemPid() int
+	// StartedAt returns when the process was started or the zero time if
+	// it was not started
+	StartedAt() time.Time
 	// State returns if the process is running or not
 	State() State
+	// ShimAlive returns false when the shim of a process loaded from the
+	// state directory has exited
+	ShimAlive() bool
+	// Attach returns a channel of the process' stdout and stderr starting
+	// with up to replay bytes of recent output and a func to detach.  The
+	// func returns ErrAttachTooSlow if the channel was closed because the
+	// client did not keep up with the output.
+	Attach(replay int) (<-chan Frame, func() error)
+	// OpenStdin returns a writer to the process' stdin
+	OpenStdin() (io.WriteCloser, error)
 }
 
 type processConfig struct {
@@ -50,6 +82,9 @@ type processConfig struct {
 	stdio       Stdio
 	exec        bool
 	checkpoint  string
+	// runtimeBundle is the bundle the runtime is started with when it is
+	// not the container's bundle
+	runtimeBundle string
 }
 
 func newProcess(config *processConfig) (*process, error) {
@@ -59,6 +94,7 @@ func newProcess(config *processConfig) (*process, error) {
 		container: config.c,
 		spec:      config.processSpec,
 		stdio:     config.stdio,
+		output:    newOutput(),
 	}
 	uid, gid, err := getRootIDs(config.spec)
 	if err != nil {
@@ -82,8 +118,16 @@ func newProcess(config *processConfig) (*process, error) {
 	if err != nil {
 		return nil, err
 	}
+	logEvents, err := getLogEventsPipe(filepath.Join(config.root, LogEventsFile))
+	if err != nil {
+		return nil, err
+	}
+	if err := createOutputPipe(filepath.Join(config.root, OutputFile)); err != nil {
+		return nil, err
+	}
 	p.exitPipe = exit
 	p.controlPipe = control
+	p.logEventsPipe = logEvents
 	return p, nil
 }
 
@@ -97,7 +141,9 @@ func loadProcess(root, id string, c *container, s *ProcessState) (*process, erro
 			Stdin:  s.Stdin,
 			Stdout: s.Stdout,
 			Stderr: s.Stderr,
+			Socket: s.StdioSocket,
 		},
+		output: newOutput(),
 	}
 	if _, err := p.getPidFromFile(); err != nil {
 		return nil, err
@@ -109,22 +155,42 @@ func loadProcess(root, id string, c *container, s *ProcessState) (*process, erro
 				return nil, err
 			}
 			p.exitPipe = exit
+			logEvents, err := getLogEventsPipe(filepath.Join(root, LogEventsFile))
+			if err != nil {
+				return nil, err
+			}
+			p.logEventsPipe = logEvents
+			if err := p.readOutput(); err != nil {
+				return nil, err
+			}
 			return p, nil
 		}
 		return nil, err
 	}
+	// the process has already exited so there is no output to read
+	p.output.done = true
 	return p, nil
 }
 
 type process struct {
-	root        string
-	id          string
-	pid         int
-	exitPipe    *os.File
-	controlPipe *os.File
-	container   *container
-	spec        specs.ProcessSpec
-	stdio       Stdio
+	root          string
+	id            string
+	pid           int
+	exitPipe      *os.File
+	controlPipe   *os.File
+	logEventsPipe *os.File
+	container     *container
+	spec          specs.ProcessSpec
+	stdio         Stdio
+	output        *output
+	// outputPipe is the fifo of the framed output, the output is read from
+	// outputFd which is the fifo or the stdio socket
+	outputPipe *os.File
+	outputFd   int
+	// stdioSocket is containerd's side of the socket passed to the shim
+	// when the process uses a stdio socket
+	stdioSocket *os.File
+	stdin       *mux.Muxer
 }
 
 func (p *process) ID() string {
@@ -140,10 +206,126 @@ func (p *process) SystemPid() int {
 }
 
 // ExitFD returns the fd of the exit pipe
+// StartedAt returns the time the shim wrote the pid of the started process
+func (p *process) StartedAt() time.Time {
+	fi, err := os.Stat(filepath.Join(p.root, "pid"))
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
 func (p *process) ExitFD() int {
 	return int(p.exitPipe.Fd())
 }
 
+func (p *process) Attach(replay int) (<-chan Frame, func() error) {
+	return p.output.subscribe(replay)
+}
+
+// readOutput starts reading the framed output written by the shim.  It must
+// be called after the shim has opened its side of the fifo.
+func (p *process) readOutput() error {
+	if p.stdio.Socket {
+		// after a restart the shim hands its side of the stdio over on the
+		// socket in the process' directory
+		if p.stdioSocket == nil {
+			if err := p.connectStdioSocket(filepath.Join(p.root, StdioSocketFile)); err != nil {
+				log.WithFields(logrus.Fields{
+					"error": err,
+					"id":    p.container.id,
+					"pid":   p.id,
+				}).Warn("containerd: take over the stdio socket of the process")
+				p.output.done = true
+				return nil
+			}
+		}
+		p.outputFd = int(p.stdioSocket.Fd())
+		return nil
+	}
+	r, err := openOutputPipe(filepath.Join(p.root, OutputFile))
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		p.output.done = true
+		return nil
+	}
+	p.outputPipe = r
+	// Fd puts the fifo in blocking mode
+	p.outputFd = int(r.Fd())
+	return setNonblock(p.outputFd)
+}
+
+// OutputFD returns the fd that the shim writes the framed output to or -1 if
+// the output is not read
+func (p *process) OutputFD() int {
+	if p.outputPipe == nil && p.stdioSocket == nil {
+		return -1
+	}
+	return p.outputFd
+}
+
+// ReadOutput reads the output that is available on the output fd, it returns
+// false once the output ended
+func (p *process) ReadOutput() bool {
+	var buf [64 * 1024]byte
+	n, err := p.readOutputFd(buf[:])
+	if err == syscall.EAGAIN || err == syscall.EINTR {
+		return true
+	}
+	if err != nil || n == 0 || !p.output.feed(buf[:n]) {
+		p.closeOutput()
+		return false
+	}
+	return true
+}
+
+// closeOutput reads what is left of the output and ends it
+func (p *process) closeOutput() {
+	if p.outputPipe == nil && p.stdioSocket == nil {
+		return
+	}
+	// reads do not block so this stops once the output is drained
+	var buf [64 * 1024]byte
+	for {
+		n, err := p.readOutputFd(buf[:])
+		if err != nil || n <= 0 || !p.output.feed(buf[:n]) {
+			break
+		}
+	}
+	if p.outputPipe != nil {
+		p.outputPipe.Close()
+		p.outputPipe = nil
+	} else {
+		p.stdioSocket.Close()
+		p.stdioSocket = nil
+	}
+	p.output.finish()
+}
+
+func (p *process) OpenStdin() (io.WriteCloser, error) {
+	if !p.stdio.Socket {
+		return os.OpenFile(p.stdio.Stdin, os.O_WRONLY, 0)
+	}
+	if p.stdioSocket == nil {
+		return nil, ErrStdioSocketClosed
+	}
+	return nopCloser{p.stdin.Stream(mux.Stdin)}, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+func (p *process) LogFD() int {
+	return int(p.logEventsPipe.Fd())
+}
+
 func (p *process) CloseStdin() error {
 	_, err := fmt.Fprintf(p.controlPipe, "%d %d %d\n", 0, 0, 0)
 	return err
@@ -154,6 +336,11 @@ func (p *process) Resize(w, h int) error {
 	return err
 }
 
+func (p *process) Trace(sc tracing.SpanContext) error {
+	_, err := fmt.Fprintf(p.controlPipe, "%d %d %d\n", 2, sc.TraceID, sc.SpanID)
+	return err
+}
+
 func (p *process) ExitStatus() (int, error) {
 	data, err := ioutil.ReadFile(filepath.Join(p.root, ExitStatusFile))
 	if err != nil {
@@ -176,9 +363,24 @@ func (p *process) Stdio() Stdio {
 	return p.stdio
 }
 
+func (p *process) StdioMode() string {
+	data, err := ioutil.ReadFile(filepath.Join(p.root, StdioModeFile))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
 // Close closes any open files and/or resouces on the process
 func (p *process) Close() error {
-	return p.exitPipe.Close()
+	p.closeOutput()
+	err := p.exitPipe.Close()
+	if p.logEventsPipe != nil {
+		if lerr := p.logEventsPipe.Close(); err == nil {
+			err = lerr
+		}
+	}
+	return err
 }
 
 func (p *process) State() State {
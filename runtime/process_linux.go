@@ -2,7 +2,10 @@ package runtime
 
 import (
 	"os"
+	"path/filepath"
 	"syscall"
+
+	"github.com/docker/containerd/mux"
 )
 
 func getExitPipe(path string) (*os.File, error) {
@@ -21,6 +24,97 @@ func getControlPipe(path string) (*os.File, error) {
 	return os.OpenFile(path, syscall.O_RDWR|syscall.O_NONBLOCK, 0)
 }
 
+// ShimAlive opens the control fifo for writing, which fails when no shim
+// holds it open for reading.  containerd holds the control fifo of the
+// processes it started itself, so only a shim that died while containerd was
+// not running is detected.
+func (p *process) ShimAlive() bool {
+	f, err := os.OpenFile(filepath.Join(p.root, ControlFile), syscall.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		if pe, ok := err.(*os.PathError); ok && pe.Err == syscall.ENXIO {
+			return false
+		}
+		return true
+	}
+	f.Close()
+	return true
+}
+
+// getLogEventsPipe opens the fifo that the shim writes to after rotating the
+// process' log file.  It is opened RDWR so that it never reports a hangup.
+func getLogEventsPipe(path string) (*os.File, error) {
+	if err := syscall.Mkfifo(path, 0755); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+	return os.OpenFile(path, syscall.O_RDWR|syscall.O_NONBLOCK, 0)
+}
+
+// createOutputPipe creates the fifo that the shim writes the process' framed
+// stdout and stderr to
+func createOutputPipe(path string) error {
+	if err := syscall.Mkfifo(path, 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return nil
+}
+
+// openOutputPipe opens the read side of the fifo created by createOutputPipe.
+// The shim holds the fifo open for writing until it exits so the reader
+// receives EOF once all of the process' output has been read.
+func openOutputPipe(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		// processes created by an older containerd do not have the fifo
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func setNonblock(fd int) error {
+	return syscall.SetNonblock(fd, true)
+}
+
+// readOutputFd reads from the output fd without blocking
+func (p *process) readOutputFd(buf []byte) (int, error) {
+	if p.outputPipe == nil {
+		n, _, err := syscall.Recvfrom(p.outputFd, buf, syscall.MSG_DONTWAIT)
+		return n, err
+	}
+	// the fifo is opened non-blocking
+	return syscall.Read(p.outputFd, buf)
+}
+
+// connectStdioSocket connects to the socket the shim serves the process'
+// stdio on
+func (p *process) connectStdioSocket(path string) error {
+	fd, err := syscall.Socket(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Connect(fd, &syscall.SockaddrUnix{Name: path}); err != nil {
+		syscall.Close(fd)
+		return err
+	}
+	p.stdioSocket = os.NewFile(uintptr(fd), "stdio")
+	p.stdin = mux.New(p.stdioSocket)
+	return nil
+}
+
+// openStdioSocket creates the socket pair used for the process' stdio and
+// returns the side to pass to the shim
+func (p *process) openStdioSocket() (*os.File, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	p.stdioSocket = os.NewFile(uintptr(fds[0]), "stdio")
+	p.stdin = mux.New(p.stdioSocket)
+	return os.NewFile(uintptr(fds[1]), "stdio"), nil
+}
+
 // Signal sends the provided signal to the process
 func (p *process) Signal(s os.Signal) error {
 	return syscall.Kill(p.pid, s.(syscall.Signal))
@@ -31,13 +125,17 @@ func populateProcessStateForEncoding(config *processConfig, uid int, gid int) Pr
 		ProcessSpec: config.processSpec,
 		Exec:        config.exec,
 		PlatformProcessState: PlatformProcessState{
-			Checkpoint: config.checkpoint,
-			RootUID:    uid,
-			RootGID:    gid,
+			Checkpoint:    config.checkpoint,
+			RootUID:       uid,
+			RootGID:       gid,
+			RuntimeBundle: config.runtimeBundle,
 		},
 		Stdin:       config.stdio.Stdin,
 		Stdout:      config.stdio.Stdout,
 		Stderr:      config.stdio.Stderr,
 		RuntimeArgs: config.c.runtimeArgs,
+		LogConfig:   config.c.logConfig,
+		StdioSocket: config.stdio.Socket,
+		NUMA:        config.c.numa,
 	}
 }
@@ -7,11 +7,39 @@ func getExitPipe(path string) (*os.File, error) {
 	return nil, nil
 }
 
+// TODO Windows: Linux uses syscalls which don't map to Windows. Needs alternate mechanism
+func (p *process) ShimAlive() bool {
+	return true
+}
+
 // TODO Windows: Linux uses syscalls which don't map to Windows. Needs alternate mechanism
 func getControlPipe(path string) (*os.File, error) {
 	return nil, nil
 }
 
+// TODO Windows: Linux uses syscalls which don't map to Windows. Needs alternate mechanism
+func getLogEventsPipe(path string) (*os.File, error) {
+	return nil, nil
+}
+
+// TODO Windows: Linux uses syscalls which don't map to Windows. Needs alternate mechanism
+func createOutputPipe(path string) error {
+	return nil
+}
+
+func setNonblock(fd int) error {
+	return nil
+}
+
+func (p *process) readOutputFd(buf []byte) (int, error) {
+	return 0, nil
+}
+
+// TODO Windows: Linux uses syscalls which don't map to Windows. Needs alternate mechanism
+func openOutputPipe(path string) (*os.File, error) {
+	return nil, nil
+}
+
 // TODO Windows. Windows does not support signals. Need alternate mechanism
 // Signal sends the provided signal to the process
 func (p *process) Signal(s os.Signal) error {
@@ -27,3 +55,7 @@ func populateProcessStateForEncoding(config *processConfig, uid int, gid int) Pr
 		Stderr:      config.stdio.Stderr,
 	}
 }
+
+func (p *process) connectStdioSocket(path string) error {
+	return errNotImplemented
+}
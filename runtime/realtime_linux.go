@@ -0,0 +1,142 @@
+package runtime
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/docker/containerd/specs"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// realtimeBudget returns the realtime runtime and period of the cpu cgroup at
+// dir, a runtime of -1 means that realtime tasks are not limited
+func realtimeBudget(dir string) (int64, int64, error) {
+	read := func(name string) (int64, error) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return 0, ErrRealtimeNotSupported
+			}
+			return 0, err
+		}
+		return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+	runtime, err := read("cpu.rt_runtime_us")
+	if err != nil {
+		return 0, 0, err
+	}
+	period, err := read("cpu.rt_period_us")
+	if err != nil {
+		return 0, 0, err
+	}
+	return runtime, period, nil
+}
+
+// grantedRealtime returns the share of the realtime budget of the cpu cgroup
+// at dir that is granted to its children other than skip
+func grantedRealtime(dir, skip string) (float64, error) {
+	children, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	var granted float64
+	for _, fi := range children {
+		child := filepath.Join(dir, fi.Name())
+		if !fi.IsDir() || child == filepath.Clean(skip) {
+			continue
+		}
+		runtime, period, err := realtimeBudget(child)
+		if err != nil {
+			return 0, err
+		}
+		if runtime > 0 && period > 0 {
+			granted += float64(runtime) / float64(period)
+		}
+	}
+	return granted, nil
+}
+
+// ValidateRealtime checks that the realtime runtime and period in usecs can be
+// granted to a new container in the root cpu cgroup.  A zero period is
+// validated against the root cgroup's period.
+func ValidateRealtime(runtime, period int64) error {
+	if runtime == 0 && period == 0 {
+		return nil
+	}
+	root, err := cgroups.FindCgroupMountpoint("cpu")
+	if err != nil {
+		return ErrRealtimeNotSupported
+	}
+	return validateRealtime(runtime, period, root, "")
+}
+
+// validateRealtime checks that the realtime runtime and period can be granted
+// to the cgroup self in the cpu cgroup parent.  Like the kernel it sums the
+// shares of the runtime granted to the other children of parent, together with
+// the requested share they must fit into the budget of parent.
+func validateRealtime(runtime, period int64, parent, self string) error {
+	if runtime < 0 || period < 0 {
+		return ErrInvalidRealtime
+	}
+	parentRuntime, parentPeriod, err := realtimeBudget(parent)
+	if err != nil {
+		return err
+	}
+	if period == 0 {
+		period = parentPeriod
+	}
+	if runtime > period {
+		return ErrInvalidRealtime
+	}
+	if parentRuntime < 0 {
+		return nil
+	}
+	granted, err := grantedRealtime(parent, self)
+	if err != nil {
+		return err
+	}
+	if granted+float64(runtime)/float64(period) > float64(parentRuntime)/float64(parentPeriod) {
+		return ErrRealtimeBudgetExceeded
+	}
+	return nil
+}
+
+// validateRealtime checks that the realtime runtime and period can be granted
+// to the container's cpu cgroup in addition to the runtime granted to the
+// other cgroups of its parent
+func (c *container) validateRealtime(runtime, period int64) error {
+	if runtime == 0 && period == 0 {
+		return nil
+	}
+	paths, err := c.CgroupPaths()
+	if err != nil {
+		return err
+	}
+	dir, ok := paths["cpu"]
+	if !ok {
+		return ErrRealtimeNotSupported
+	}
+	return validateRealtime(runtime, period, filepath.Dir(dir), dir)
+}
+
+// validateSpecRealtime validates the realtime resources in the bundle's spec
+func validateSpecRealtime(spec *specs.Spec) error {
+	if spec.Linux.Resources == nil || spec.Linux.Resources.CPU == nil {
+		return nil
+	}
+	var (
+		cpu     = spec.Linux.Resources.CPU
+		runtime int64
+		period  int64
+	)
+	if cpu.RealtimeRuntime != nil {
+		runtime = int64(*cpu.RealtimeRuntime)
+	}
+	if cpu.RealtimePeriod != nil {
+		period = int64(*cpu.RealtimePeriod)
+	}
+	return ValidateRealtime(runtime, period)
+}
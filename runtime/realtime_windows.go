@@ -0,0 +1,20 @@
+package runtime
+
+import "github.com/docker/containerd/specs"
+
+// ValidateRealtime returns ErrRealtimeNotSupported as there are no realtime
+// cgroup parameters on Windows
+func ValidateRealtime(runtime, period int64) error {
+	if runtime == 0 && period == 0 {
+		return nil
+	}
+	return ErrRealtimeNotSupported
+}
+
+func (c *container) validateRealtime(runtime, period int64) error {
+	return ValidateRealtime(runtime, period)
+}
+
+func validateSpecRealtime(spec *specs.Spec) error {
+	return nil
+}
@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/docker/containerd/metadata"
+)
+
+// Repair fixes the state directory of a container that could not be loaded
+// after a crash.  A missing state directory is recreated, so the container is
+// loaded without processes, and the directories of processes that were not
+// fully written and are not running are removed.  It returns a description of
+// each repair and fails with ErrProcessStateCorrupt when a process whose state
+// cannot be read is still running.  A record that cannot be read is not
+// repaired, its error is returned.
+func Repair(db *metadata.DB, root, id string) ([]string, error) {
+	if _, err := readState(db, id); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(root, id)
+	dirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		return []string{"recreated the missing state directory"}, nil
+	}
+	var repairs []string
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, d.Name())
+		if _, err := readProcessState(path); err == nil {
+			continue
+		}
+		if data, err := ioutil.ReadFile(filepath.Join(path, "pid")); err == nil {
+			if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && pid > 0 && syscall.Kill(pid, 0) == nil {
+				return repairs, ErrProcessStateCorrupt
+			}
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return repairs, err
+		}
+		repairs = append(repairs, fmt.Sprintf("removed the state of process %s that was not fully written", d.Name()))
+	}
+	return repairs, nil
+}
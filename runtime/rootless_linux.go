@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// accessWrite is W_OK of access(2)
+const accessWrite = 2
+
+// Rootless returns true if the daemon runs unprivileged
+func Rootless() bool {
+	return os.Geteuid() != 0
+}
+
+// CgroupsDelegated returns true if the daemon can create the cgroups of
+// containers.  An unprivileged daemon needs the cgroup v2 subtree it runs in
+// to be delegated to its user.
+func CgroupsDelegated() bool {
+	if !Rootless() {
+		return true
+	}
+	if cgroupVersion() != 2 {
+		return false
+	}
+	data, err := ioutil.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return false
+	}
+	for _, l := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(l, "0::") {
+			dir := filepath.Join("/sys/fs/cgroup", strings.TrimPrefix(l, "0::"))
+			return syscall.Access(dir, accessWrite) == nil &&
+				syscall.Access(filepath.Join(dir, "cgroup.subtree_control"), accessWrite) == nil
+		}
+	}
+	return false
+}
+
+// runtimeBundle returns the bundle the runtime is started with, it is empty
+// when the runtime runs the container's bundle.  A rootless daemon hands the
+// runtime a copy of the bundle's spec that it can run so that the user's
+// config.json is not changed.  The copy is written on each start so that the
+// changes made to the bundle's spec since the last start are picked up.
+func (c *container) runtimeBundle() (string, error) {
+	if !Rootless() {
+		return "", nil
+	}
+	data, err := ExportSpec(c.bundle)
+	if err != nil {
+		return "", err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return "", err
+	}
+	if dropped := rootlessSpec(spec, CgroupsDelegated()); dropped {
+		log.WithField("id", c.id).Warn("containerd: cgroups are not delegated to the daemon's user, the resource limits of the container are dropped")
+	}
+	if data, err = json.MarshalIndent(spec, "", "\t"); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(c.root, c.id, runtimeBundleDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	if err := writeSpec(filepath.Join(dir, "config.json"), data, 0600); err != nil {
+		return "", err
+	}
+	c.InvalidateSpec()
+	return dir, nil
+}
+
+// rootlessSpec changes the spec so that an unprivileged daemon can run it and
+// returns true if resource limits were dropped.  The container gets a user
+// namespace mapping root to the daemon's user unless the spec already has one,
+// mount options setting the group of a mount are removed and without
+// delegated cgroups the resources are left to the host.  Fields of the spec
+// that are unknown to containerd are preserved.
+func rootlessSpec(spec map[string]interface{}, delegated bool) bool {
+	linux, namespaces := specNamespaces(spec)
+	hasUserNS := false
+	for _, n := range namespaces {
+		if ns, ok := n.(map[string]interface{}); ok && ns["type"] == "user" {
+			hasUserNS = true
+		}
+	}
+	if !hasUserNS {
+		linux["namespaces"] = append(namespaces, map[string]interface{}{
+			"type": "user",
+		})
+		linux["uidMappings"] = []interface{}{idMapping(os.Geteuid())}
+		linux["gidMappings"] = []interface{}{idMapping(os.Getegid())}
+	}
+	// the daemon's user cannot chown mounts to other groups, such as the
+	// tty group of devpts
+	mounts, _ := spec["mounts"].([]interface{})
+	for _, m := range mounts {
+		mount, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		options, _ := mount["options"].([]interface{})
+		var kept []interface{}
+		for _, o := range options {
+			if s, ok := o.(string); ok && strings.HasPrefix(s, "gid=") {
+				continue
+			}
+			kept = append(kept, o)
+		}
+		if len(kept) != len(options) {
+			mount["options"] = kept
+		}
+	}
+	if delegated {
+		return false
+	}
+	_, dropped := linux["resources"]
+	delete(linux, "resources")
+	delete(linux, "cgroupsPath")
+	return dropped
+}
+
+func idMapping(id int) map[string]interface{} {
+	return map[string]interface{}{
+		"hostID":      id,
+		"containerID": 0,
+		"size":        1,
+	}
+}
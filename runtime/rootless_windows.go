@@ -0,0 +1,14 @@
+package runtime
+
+// Rootless returns false as the daemon's privileges are not probed on Windows
+func Rootless() bool {
+	return false
+}
+
+func CgroupsDelegated() bool {
+	return false
+}
+
+func (c *container) runtimeBundle() (string, error) {
+	return "", nil
+}
@@ -0,0 +1,161 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// routedUplink is the host's interface that answers ARP and NDP for the
+// addresses of routed interfaces, containers cannot be routed when it is
+// empty
+var routedUplink string
+
+// EnableRouting routes the addresses of the containers' routed interfaces
+// through the host, the uplink answers ARP and NDP for them.  Forwarding is
+// enabled on the host and NDP proxying on the uplink.
+func EnableRouting(uplink string) error {
+	if _, err := netlink.LinkByName(uplink); err != nil {
+		return fmt.Errorf("containerd: routed uplink %s: %v", uplink, err)
+	}
+	if err := writeSysctl("net/ipv4/ip_forward", "1"); err != nil {
+		return err
+	}
+	// the host may have ipv6 disabled
+	if _, err := os.Stat("/proc/sys/net/ipv6"); err == nil {
+		if err := writeSysctl("net/ipv6/conf/all/forwarding", "1"); err != nil {
+			return err
+		}
+		if err := writeSysctl(filepath.Join("net/ipv6/conf", uplink, "proxy_ndp"), "1"); err != nil {
+			return err
+		}
+	}
+	routedUplink = uplink
+	return nil
+}
+
+func writeSysctl(key, value string) error {
+	return ioutil.WriteFile(filepath.Join("/proc/sys", key), []byte(value), 0644)
+}
+
+// hostRoute returns the route of the host to the address through the peer
+func hostRoute(ip net.IP, peer netlink.Link) *netlink.Route {
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	return &netlink.Route{
+		Dst:       &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+		LinkIndex: peer.Attrs().Index,
+		Scope:     netlink.SCOPE_LINK,
+	}
+}
+
+// routeAddresses routes the addresses of the container's routed interface
+// through the peer of the interface on the host and adds proxy entries for
+// them on the uplink.  The peer answers ARP for any address so that the
+// container can use any gateway on the interface.
+func (c *container) routeAddresses(pid int) error {
+	name := c.network.RoutedInterface
+	var (
+		peerIndex int
+		ips       []net.IP
+	)
+	if err := inNetworkNamespace(pid, func() error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+		if _, ok := link.(*netlink.Veth); !ok {
+			return &InterfaceError{Name: name, Reason: "a routed interface must be a veth interface"}
+		}
+		peerIndex = link.Attrs().ParentIndex
+		addrs, err := addrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return err
+		}
+		for _, a := range addrs {
+			if !a.IP.IsLinkLocalUnicast() {
+				ips = append(ips, a.IP)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	peer, err := netlink.LinkByIndex(peerIndex)
+	if err != nil || peer.Type() != "veth" {
+		return &InterfaceError{Name: name, Reason: "the peer of the interface is not in the daemon's network namespace"}
+	}
+	uplink, err := netlink.LinkByName(routedUplink)
+	if err != nil {
+		return err
+	}
+	if err := writeSysctl(filepath.Join("net/ipv4/conf", peer.Attrs().Name, "proxy_arp"), "1"); err != nil {
+		return err
+	}
+	var routed []string
+	for _, ip := range ips {
+		// a stale route of a container that had the address is replaced
+		if err := routeReplace(hostRoute(ip, peer)); err != nil {
+			return fmt.Errorf("route %s: %v", ip, err)
+		}
+		if err := proxyAdd(ip, uplink); err != nil {
+			return fmt.Errorf("proxy %s on %s: %v", ip, routedUplink, err)
+		}
+		routed = append(routed, ip.String())
+	}
+	c.addrLock.Lock()
+	c.routed = routed
+	c.addrLock.Unlock()
+	return c.updateState(func(s *state) {
+		s.Routed = routed
+	})
+}
+
+// unrouteAddresses removes the proxy entries of the container's routed
+// addresses, the host routes are removed by the kernel with the veth pair
+func (c *container) unrouteAddresses() {
+	c.addrLock.Lock()
+	routed := c.routed
+	c.routed = nil
+	c.addrLock.Unlock()
+	if len(routed) == 0 {
+		return
+	}
+	uplink, err := netlink.LinkByName(routedUplink)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Warn("containerd: remove proxy entries")
+		routed = nil
+	}
+	for _, a := range routed {
+		ip := net.ParseIP(a)
+		if ip == nil {
+			continue
+		}
+		if err := proxyDel(ip, uplink); err != nil && !isErrno(err, syscall.ENOENT) {
+			log.WithFields(logrus.Fields{
+				"error":   err,
+				"id":      c.id,
+				"address": a,
+			}).Warn("containerd: remove proxy entry")
+		}
+	}
+	if err := c.updateState(func(s *state) {
+		s.Routed = nil
+	}); err != nil && err != errNoRecord {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Warn("containerd: save routed addresses")
+	}
+}
@@ -0,0 +1,11 @@
+package runtime
+
+// EnableRouting returns ErrNetworkNotSupported as containers cannot be routed
+// on Windows
+func EnableRouting(uplink string) error {
+	return ErrNetworkNotSupported
+}
+
+// unrouteAddresses does nothing as containers are not routed on Windows
+func (c *container) unrouteAddresses() {
+}
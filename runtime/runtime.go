@@ -4,23 +4,58 @@ import (
 	"errors"
 	"time"
 
+	"github.com/docker/containerd/logging"
 	"github.com/docker/containerd/specs"
 )
 
+var log = logging.Logger("runtime")
+
 var (
-	ErrNotChildProcess       = errors.New("containerd: not a child process for container")
-	ErrInvalidContainerType  = errors.New("containerd: invalid container type for runtime")
-	ErrCheckpointNotExists   = errors.New("containerd: checkpoint does not exist for container")
-	ErrCheckpointExists      = errors.New("containerd: checkpoint already exists")
-	ErrContainerExited       = errors.New("containerd: container has exited")
-	ErrTerminalsNotSupported = errors.New("containerd: terminals are not supported for runtime")
-	ErrProcessNotExited      = errors.New("containerd: process has not exited")
-	ErrProcessExited         = errors.New("containerd: process has exited")
-	ErrContainerNotStarted   = errors.New("containerd: container not started")
+	ErrNotChildProcess         = errors.New("containerd: not a child process for container")
+	ErrInvalidContainerType    = errors.New("containerd: invalid container type for runtime")
+	ErrCheckpointNotExists     = errors.New("containerd: checkpoint does not exist for container")
+	ErrCheckpointExists        = errors.New("containerd: checkpoint already exists")
+	ErrContainerExited         = errors.New("containerd: container has exited")
+	ErrTerminalsNotSupported   = errors.New("containerd: terminals are not supported for runtime")
+	ErrStdioSocketClosed       = errors.New("containerd: stdio socket of the process is not available")
+	ErrAttachTooSlow           = errors.New("containerd: attached client was detached as it did not keep up with the output")
+	ErrProcessNotExited        = errors.New("containerd: process has not exited")
+	ErrProcessExited           = errors.New("containerd: process has exited")
+	ErrContainerNotStarted     = errors.New("containerd: container not started")
+	ErrRealtimeNotSupported    = errors.New("containerd: realtime cgroup scheduling is not supported by the host")
+	ErrInvalidRealtime         = errors.New("containerd: realtime runtime must be between 0 and the realtime period")
+	ErrRealtimeBudgetExceeded  = errors.New("containerd: realtime runtime exceeds the budget left in the parent cgroup")
+	ErrNotDevice               = errors.New("containerd: path is not a block or character device")
+	ErrDevicePathNotAbs        = errors.New("containerd: device path is not an absolute path")
+	ErrInvalidNUMANodes        = errors.New("containerd: invalid or unknown NUMA nodes")
+	ErrInvalidMemoryPolicy     = errors.New("containerd: invalid memory policy for the NUMA nodes")
+	ErrSwapNotSupported        = errors.New("containerd: swap accounting is not enabled on the host")
+	ErrInvalidSwappiness       = errors.New("containerd: memory swappiness must be between 0 and 100")
+	ErrNotBlockDevice          = errors.New("containerd: path is not a block device")
+	ErrCgroupNSNotSupported    = errors.New("containerd: cgroup namespaces are not supported by the kernel")
+	ErrProcessNotFound         = errors.New("containerd: process not found for container")
+	ErrShimNotAdopted          = errors.New("containerd: shim did not adopt the process")
+	ErrNoProcessArgs           = errors.New("containerd: spec has no process args")
+	ErrNamespaceNotShareable   = errors.New("containerd: namespace cannot be shared by a group")
+	ErrSandboxNotRunning       = errors.New("containerd: sandbox holder of the group is not running")
+	ErrOCIHookPathNotAbs       = errors.New("containerd: oci hook path is not an absolute path")
+	ErrGPUNotFound             = errors.New("containerd: gpu not found on the host")
+	ErrGPUsNotSupported        = errors.New("containerd: gpus are not supported on this platform")
+	ErrMountPathNotAbs         = errors.New("containerd: mount source and destination must be absolute paths")
+	ErrInvalidEnv              = errors.New("containerd: environment variables must be KEY=VALUE with a non empty key")
+	ErrNetworkWaitNotSupported = errors.New("containerd: waiting for the network is not supported on this platform")
+	ErrInitNotSupported        = errors.New("containerd: injecting an init is not supported on this platform")
+	ErrProcessStateCorrupt     = errors.New("containerd: state of a running process cannot be read")
+	ErrNetworkNotSupported     = errors.New("containerd: configuring the network is not supported on this platform")
+	ErrNoVirtualFunctions      = errors.New("containerd: interface is not an SR-IOV physical function with virtual functions")
+	ErrRoutingNotEnabled       = errors.New("containerd: routed interfaces require the daemon's --routed-uplink")
+	ErrAddressLabelNotFound    = errors.New("containerd: no address of the container has the label")
+	ErrNetworkNamespaceGone    = errors.New("containerd: named network namespace is no longer mounted")
 
 	errNoPidFile      = errors.New("containerd: no process pid file found")
 	errInvalidPidInt  = errors.New("containerd: process pid is invalid")
 	errNotImplemented = errors.New("containerd: not implemented")
+	errNoRecord       = errors.New("containerd: container has no record in the database")
 )
 
 const (
@@ -28,9 +63,27 @@ const (
 	ExitStatusFile = "exitStatus"
 	StateFile      = "state.json"
 	ControlFile    = "control"
+	LogEventsFile  = "log-events"
+	OutputFile     = "output"
+	StdioModeFile  = "stdio-mode"
 	InitProcessID  = "init"
 )
 
+// StdioSocketFile is the socket the shim serves the stdio of a process that
+// uses a stdio socket on so that a restarted containerd takes it over
+const StdioSocketFile = "stdio.sock"
+
+// ContainersBucket is the bucket of the metadata database holding the records
+// of the containers by id
+const ContainersBucket = "containers"
+
+// StdioModeFile holds one of the stdio modes, the way the shim forwards the
+// process' stdio
+const (
+	StdioModeSplice = "splice"
+	StdioModeCopy   = "copy"
+)
+
 type State string
 
 type Resource struct {
@@ -44,6 +97,33 @@ type Resource struct {
 	Memory            int64
 	MemoryReservation int64
 	MemorySwap        int64
+	// CPURealtimeRuntime and CPURealtimePeriod are in usecs
+	CPURealtimeRuntime int64
+	CPURealtimePeriod  int64
+	// PidsLimit is the maximum number of pids, -1 removes the limit
+	PidsLimit int64
+	// MemorySwappiness is between 0 and 100, nil leaves it unchanged
+	MemorySwappiness *int64
+	// BlkioWeightDevice overrides BlkioWeight for devices
+	BlkioWeightDevice []WeightDevice
+	// Throttles are rates per second for devices, a rate of 0 removes the
+	// device's throttle
+	BlkioThrottleReadBpsDevice   []ThrottleDevice
+	BlkioThrottleWriteBpsDevice  []ThrottleDevice
+	BlkioThrottleReadIOPSDevice  []ThrottleDevice
+	BlkioThrottleWriteIOPSDevice []ThrottleDevice
+}
+
+// WeightDevice is the blkio weight of a host block device
+type WeightDevice struct {
+	Path   string
+	Weight uint16
+}
+
+// ThrottleDevice is the blkio throttle of a host block device
+type ThrottleDevice struct {
+	Path string
+	Rate uint64
 }
 
 const (
@@ -53,26 +133,98 @@ const (
 )
 
 type state struct {
-	Bundle      string   `json:"bundle"`
-	Labels      []string `json:"labels"`
-	Stdin       string   `json:"stdin"`
-	Stdout      string   `json:"stdout"`
-	Stderr      string   `json:"stderr"`
-	Runtime     string   `json:"runtime"`
-	RuntimeArgs []string `json:"runtimeArgs"`
+	Bundle      string     `json:"bundle"`
+	Labels      []string   `json:"labels"`
+	Stdin       string     `json:"stdin"`
+	Stdout      string     `json:"stdout"`
+	Stderr      string     `json:"stderr"`
+	Runtime     string     `json:"runtime"`
+	RuntimeArgs []string   `json:"runtimeArgs"`
+	LogConfig   LogConfig  `json:"logConfig"`
+	StdinOnce   bool       `json:"stdinOnce,omitempty"`
+	NUMA        NUMAConfig `json:"numa,omitempty"`
+	Keep        bool       `json:"keep,omitempty"`
+	AutoRemove  bool       `json:"autoRemove,omitempty"`
+	// Network is applied to the container's network namespace at start
+	Network NetworkConfig `json:"network,omitempty"`
+	// Addresses are the addresses of the container's network namespace
+	// when it was last started
+	Addresses []string `json:"addresses,omitempty"`
+	// Routed are the addresses routed to the container by the host
+	Routed []string `json:"routed,omitempty"`
+}
+
+// LogConfig is the configuration used by the shim to capture the output of
+// a container's processes
+type LogConfig struct {
+	// Driver is the name of the log driver, an empty driver disables capture
+	Driver string `json:"driver,omitempty"`
+	// Options are passed to the driver, e.g. max-size, max-file and max-age
+	// for json-file rotation
+	Options map[string]string `json:"options,omitempty"`
+	// Path is the directory that file based drivers write to, it is set to
+	// the container's state directory when not provided
+	Path string `json:"path,omitempty"`
+	// Mode is either LogModeBlocking or LogModeNonBlocking
+	Mode string `json:"mode,omitempty"`
+	// MaxBufferSize is the size of the buffer in bytes used for each of the
+	// process' output streams in non-blocking mode
+	MaxBufferSize int `json:"maxBufferSize,omitempty"`
 }
 
+const (
+	// LogModeBlocking stalls the process when its output is not consumed
+	LogModeBlocking = "blocking"
+	// LogModeNonBlocking buffers the process' output and drops it when the
+	// buffer is full so that the process is never stalled
+	LogModeNonBlocking = "non-blocking"
+	// DefaultMaxBufferSize is used in non-blocking mode when no size is set
+	DefaultMaxBufferSize = 1024 * 1024
+)
+
 type ProcessState struct {
 	specs.ProcessSpec
-	Exec        bool     `json:"exec"`
-	Stdin       string   `json:"containerdStdin"`
-	Stdout      string   `json:"containerdStdout"`
-	Stderr      string   `json:"containerdStderr"`
-	RuntimeArgs []string `json:"runtimeArgs"`
+	Exec        bool       `json:"exec"`
+	Stdin       string     `json:"containerdStdin"`
+	Stdout      string     `json:"containerdStdout"`
+	Stderr      string     `json:"containerdStderr"`
+	RuntimeArgs []string   `json:"runtimeArgs"`
+	LogConfig   LogConfig  `json:"logConfig"`
+	StdioSocket bool       `json:"stdioSocket,omitempty"`
+	NUMA        NUMAConfig `json:"numa,omitempty"`
 
 	PlatformProcessState
 }
 
+const (
+	// MemoryPolicyBind only allocates memory from the NUMA nodes
+	MemoryPolicyBind = "bind"
+	// MemoryPolicyPreferred allocates memory from the NUMA node when possible
+	// and falls back to other nodes
+	MemoryPolicyPreferred = "preferred"
+	// MemoryPolicyInterleave interleaves allocations across the NUMA nodes
+	MemoryPolicyInterleave = "interleave"
+)
+
+// NUMAConfig binds the memory of a container's processes to NUMA nodes
+type NUMAConfig struct {
+	// Nodes are the NUMA nodes in the cpuset list format, e.g. 0-1,3, they
+	// are written to the container's cpuset.mems
+	Nodes string `json:"nodes,omitempty"`
+	// MemoryPolicy is the memory policy of the container's processes for the
+	// nodes, an empty policy leaves the default policy in place
+	MemoryPolicy string `json:"memoryPolicy,omitempty"`
+}
+
+// NUMAStat is the memory of a container allocated on a NUMA node in bytes
+type NUMAStat struct {
+	Node        int
+	Total       uint64
+	File        uint64
+	Anon        uint64
+	Unevictable uint64
+}
+
 type Stat struct {
 	// Timestamp is the time that the statistics where collected
 	Timestamp time.Time
@@ -81,4 +233,8 @@ type Stat struct {
 	// we will have or what the structure should look like at the moment os the containers
 	// can return what they want and we could marshal to json or whatever.
 	Data interface{}
+	// NUMA is the container's memory usage per NUMA node
+	NUMA []NUMAStat
+	// MemorySwappiness is the swappiness of the container's memory cgroup
+	MemorySwappiness uint64
 }
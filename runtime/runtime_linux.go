@@ -22,4 +22,7 @@ type PlatformProcessState struct {
 	Checkpoint string `json:"checkpoint"`
 	RootUID    int    `json:"rootUID"`
 	RootGID    int    `json:"rootGID"`
+	// RuntimeBundle is the bundle the runtime is started with when it is
+	// not the container's bundle
+	RuntimeBundle string `json:"runtimeBundle,omitempty"`
 }
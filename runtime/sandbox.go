@@ -0,0 +1,127 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// DefaultSandboxNamespaces are the namespaces shared by a group when none are
+// requested
+var DefaultSandboxNamespaces = []string{"network", "ipc"}
+
+// sandboxNamespaceFiles are the namespace types of the spec that a group can
+// share and their files in /proc/<pid>/ns
+var sandboxNamespaceFiles = map[string]string{
+	"network": "net",
+	"ipc":     "ipc",
+	"uts":     "uts",
+	"pid":     "pid",
+}
+
+// Sandbox is a holder process owning the namespaces that the containers of a
+// group share.  Containers join the namespaces through the paths in their
+// spec and keep running when the holder exits, except when the group shares
+// the pid namespace whose processes are killed with the holder.
+type Sandbox struct {
+	ID string `json:"id"`
+	// Namespaces are the namespace types of the spec that are shared
+	Namespaces []string `json:"namespaces"`
+	// Pid is the host pid of the holder
+	Pid int `json:"pid"`
+	// Containers are the IDs of the containers in the group
+	Containers []string `json:"containers"`
+
+	root string
+	// cmd is set for holders started by this daemon, holders loaded after a
+	// restart were reparented
+	cmd *exec.Cmd
+}
+
+// validSandboxNamespaces returns the namespaces without duplicates or the
+// defaults if there are none
+func validSandboxNamespaces(namespaces []string) ([]string, error) {
+	if len(namespaces) == 0 {
+		return DefaultSandboxNamespaces, nil
+	}
+	var (
+		out  []string
+		seen = make(map[string]bool)
+	)
+	for _, n := range namespaces {
+		if _, ok := sandboxNamespaceFiles[n]; !ok {
+			return nil, ErrNamespaceNotShareable
+		}
+		if !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// LoadSandboxes returns the sandboxes of the groups in the root directory
+func LoadSandboxes(root string) ([]*Sandbox, error) {
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*Sandbox
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(root, d.Name(), StateFile))
+		if err != nil {
+			return nil, err
+		}
+		s := &Sandbox{
+			root: root,
+		}
+		if err := json.Unmarshal(data, s); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// Add records the container as a member of the group
+func (s *Sandbox) Add(id string) error {
+	s.Containers = append(s.Containers, id)
+	return s.save()
+}
+
+// Remove removes the container from the members of the group
+func (s *Sandbox) Remove(id string) error {
+	for i, c := range s.Containers {
+		if c == id {
+			s.Containers = append(s.Containers[:i], s.Containers[i+1:]...)
+			return s.save()
+		}
+	}
+	return nil
+}
+
+// Contains returns true if the container is a member of the group
+func (s *Sandbox) Contains(id string) bool {
+	for _, c := range s.Containers {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sandbox) save() error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(s.root, s.ID, StateFile), data, 0644)
+}
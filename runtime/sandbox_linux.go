@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+)
+
+// sandboxCloneFlags are the clone flags creating the namespaces of the spec
+var sandboxCloneFlags = map[string]uintptr{
+	"network": syscall.CLONE_NEWNET,
+	"ipc":     syscall.CLONE_NEWIPC,
+	"uts":     syscall.CLONE_NEWUTS,
+	"pid":     syscall.CLONE_NEWPID,
+}
+
+// NewSandbox starts a holder process in new namespaces of the types for the
+// group with the id, the sandbox's state is kept in root/id
+func NewSandbox(root, id string, namespaces []string) (*Sandbox, error) {
+	namespaces, err := validSandboxNamespaces(namespaces)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(root, 0711); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(root, id)
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return nil, err
+	}
+	var flags uintptr
+	for _, n := range namespaces {
+		flags |= sandboxCloneFlags[n]
+	}
+	cmd := exec.Command(shimBinary, "-sandbox")
+	cmd.Dir = dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:    true,
+		Cloneflags: flags,
+	}
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	s := &Sandbox{
+		ID:         id,
+		Namespaces: namespaces,
+		Pid:        cmd.Process.Pid,
+		root:       root,
+		cmd:        cmd,
+	}
+	if err := s.save(); err != nil {
+		s.Delete()
+		return nil, err
+	}
+	return s, nil
+}
+
+// JoinSandbox sets the paths of the sandbox's namespaces in the spec of the
+// bundle so that the container joins them instead of creating its own
+func JoinSandbox(bundle string, s *Sandbox) error {
+	if !s.Running() {
+		return ErrSandboxNotRunning
+	}
+	paths := make(map[string]string)
+	for _, n := range s.Namespaces {
+		paths[n] = fmt.Sprintf("/proc/%d/ns/%s", s.Pid, sandboxNamespaceFiles[n])
+	}
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, namespaces := specNamespaces(spec)
+		var out []interface{}
+		for _, n := range namespaces {
+			if ns, ok := n.(map[string]interface{}); ok {
+				if t, _ := ns["type"].(string); paths[t] != "" {
+					continue
+				}
+			}
+			out = append(out, n)
+		}
+		for _, n := range s.Namespaces {
+			out = append(out, map[string]interface{}{
+				"type": n,
+				"path": paths[n],
+			})
+		}
+		linux["namespaces"] = out
+		return true, nil
+	})
+}
+
+// Running returns true if the sandbox's holder is alive.  The command line is
+// checked so that a reused pid is not mistaken for the holder after a restart.
+func (s *Sandbox) Running() bool {
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", s.Pid))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(cmdline, []byte("\x00-sandbox\x00"))
+}
+
+// Delete kills the holder and removes the sandbox's state
+func (s *Sandbox) Delete() error {
+	if s.Running() {
+		if err := syscall.Kill(s.Pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return err
+		}
+	}
+	if s.cmd != nil {
+		s.cmd.Wait()
+	}
+	return os.RemoveAll(filepath.Join(s.root, s.ID))
+}
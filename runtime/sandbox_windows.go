@@ -0,0 +1,21 @@
+package runtime
+
+// NewSandbox is not implemented on Windows
+func NewSandbox(root, id string, namespaces []string) (*Sandbox, error) {
+	return nil, errNotImplemented
+}
+
+// JoinSandbox is not implemented on Windows
+func JoinSandbox(bundle string, s *Sandbox) error {
+	return errNotImplemented
+}
+
+// Running returns false as there are no sandboxes on Windows
+func (s *Sandbox) Running() bool {
+	return false
+}
+
+// Delete is not implemented on Windows
+func (s *Sandbox) Delete() error {
+	return errNotImplemented
+}
@@ -0,0 +1,76 @@
+package runtime
+
+import goruntime "runtime"
+
+// seccompNativeArchitectures are the seccomp architectures of the host's
+// GOARCH
+var seccompNativeArchitectures = map[string]string{
+	"amd64":    "SCMP_ARCH_X86_64",
+	"386":      "SCMP_ARCH_X86",
+	"arm64":    "SCMP_ARCH_AARCH64",
+	"arm":      "SCMP_ARCH_ARM",
+	"s390x":    "SCMP_ARCH_S390X",
+	"ppc64le":  "SCMP_ARCH_PPC64LE",
+	"mips64":   "SCMP_ARCH_MIPS64",
+	"mips64le": "SCMP_ARCH_MIPSEL64",
+}
+
+// seccompSubArchitectures are the architectures whose binaries run on each
+// architecture.  Syscalls of an architecture missing from a filter are killed
+// so 32-bit binaries break on 64-bit hosts without them.
+var seccompSubArchitectures = map[string][]string{
+	"SCMP_ARCH_X86_64":      {"SCMP_ARCH_X86", "SCMP_ARCH_X32"},
+	"SCMP_ARCH_AARCH64":     {"SCMP_ARCH_ARM"},
+	"SCMP_ARCH_S390X":       {"SCMP_ARCH_S390"},
+	"SCMP_ARCH_MIPS64":      {"SCMP_ARCH_MIPS", "SCMP_ARCH_MIPS64N32"},
+	"SCMP_ARCH_MIPS64N32":   {"SCMP_ARCH_MIPS", "SCMP_ARCH_MIPS64"},
+	"SCMP_ARCH_MIPSEL64":    {"SCMP_ARCH_MIPSEL", "SCMP_ARCH_MIPSEL64N32"},
+	"SCMP_ARCH_MIPSEL64N32": {"SCMP_ARCH_MIPSEL", "SCMP_ARCH_MIPSEL64"},
+}
+
+// InjectSeccompArchitectures adds the architectures that run on the
+// architectures of the seccomp filter in the spec of the bundle.  A filter
+// without architectures gets the host's architecture and the ones running on
+// it, specs without a filter are left unchanged.  Fields of the spec that are
+// unknown to containerd are preserved.
+func InjectSeccompArchitectures(bundle string) error {
+	return rewriteSpec(bundle, func(spec map[string]interface{}) (bool, error) {
+		linux, _ := spec["linux"].(map[string]interface{})
+		if linux == nil {
+			return false, nil
+		}
+		seccomp, _ := linux["seccomp"].(map[string]interface{})
+		if seccomp == nil {
+			return false, nil
+		}
+		architectures, _ := seccomp["architectures"].([]interface{})
+		if len(architectures) == 0 {
+			native, ok := seccompNativeArchitectures[goruntime.GOARCH]
+			if !ok {
+				return false, nil
+			}
+			architectures = []interface{}{native}
+		}
+		seen := make(map[string]bool)
+		for _, a := range architectures {
+			if s, ok := a.(string); ok {
+				seen[s] = true
+			}
+		}
+		out := architectures
+		for _, a := range architectures {
+			s, _ := a.(string)
+			for _, sub := range seccompSubArchitectures[s] {
+				if !seen[sub] {
+					seen[sub] = true
+					out = append(out, sub)
+				}
+			}
+		}
+		if old, _ := seccomp["architectures"].([]interface{}); len(old) == len(out) {
+			return false, nil
+		}
+		seccomp["architectures"] = out
+		return true, nil
+	})
+}
@@ -0,0 +1,6 @@
+package runtime
+
+// InjectSeccompArchitectures does nothing as there is no seccomp on Windows
+func InjectSeccompArchitectures(bundle string) error {
+	return nil
+}
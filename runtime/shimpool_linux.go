@@ -0,0 +1,99 @@
+package runtime
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// ShimHandoff is the work sent to an idle shim of the pool, the shim changes
+// to Dir and then runs as if it was started with Args
+type ShimHandoff struct {
+	Dir  string
+	Args []string
+}
+
+// idleShim is a shim started with -idle that waits on its stdin for a handoff
+type idleShim struct {
+	cmd *exec.Cmd
+	w   *os.File
+}
+
+// shimPool holds the idle shims, it is nil when the pool is disabled
+var shimPool chan *idleShim
+
+// EnableShimPool keeps size shims started and idle so that containers and
+// execs are handed to a running shim instead of waiting for a fork and exec
+// of the shim binary.  Processes with a stdio socket always start a new shim.
+func EnableShimPool(size int) {
+	shimPool = make(chan *idleShim, size)
+	go fillShimPool(shimPool)
+}
+
+func fillShimPool(pool chan *idleShim) {
+	for {
+		s, err := startIdleShim()
+		if err != nil {
+			log.WithField("error", err).Error("containerd: start idle shim")
+			time.Sleep(time.Second)
+			continue
+		}
+		pool <- s
+	}
+}
+
+func startIdleShim() (*idleShim, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	cmd := exec.Command(shimBinary, "-idle")
+	cmd.Dir = "/"
+	cmd.Stdin = r
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	if err := cmd.Start(); err != nil {
+		w.Close()
+		return nil, err
+	}
+	return &idleShim{
+		cmd: cmd,
+		w:   w,
+	}, nil
+}
+
+// pooledShim hands the shim command to an idle shim of the pool and returns
+// false when the pool is disabled or has no idle shims
+func pooledShim(cmd *exec.Cmd) bool {
+	if shimPool == nil {
+		return false
+	}
+	var s *idleShim
+	select {
+	case s = <-shimPool:
+	default:
+		log.Debug("containerd: no idle shim in the pool")
+		return false
+	}
+	defer s.w.Close()
+	if err := json.NewEncoder(s.w).Encode(ShimHandoff{
+		Dir:  cmd.Dir,
+		Args: cmd.Args[1:],
+	}); err != nil {
+		// the idle shim exits once its stdin is closed
+		log.WithFields(logrus.Fields{
+			"pid":   s.cmd.Process.Pid,
+			"error": err,
+		}).Warn("containerd: hand off to idle shim")
+		return false
+	}
+	// the pooled shim runs in place of the command
+	cmd.Process = s.cmd.Process
+	return true
+}
@@ -0,0 +1,169 @@
+package runtime
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/containerd/specs"
+)
+
+// ReadSpec reads and validates the spec of the bundle
+func ReadSpec(bundle string) (*specs.Spec, error) {
+	f, err := os.Open(filepath.Join(bundle, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var spec specs.Spec
+	if err := json.NewDecoder(f).Decode(&spec); err != nil {
+		return nil, err
+	}
+	if err := validateSpec(&spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func validateSpec(spec *specs.Spec) error {
+	if len(spec.Process.Args) == 0 {
+		return ErrNoProcessArgs
+	}
+	return validateSpecRealtime(spec)
+}
+
+// runtimeBundleDir is the directory in the container's state that the bundle
+// handed to the runtime is written to when it differs from the container's
+const runtimeBundleDir = "bundle"
+
+// Spec returns the spec the runtime runs the container with.  The spec is
+// parsed once and cached until InvalidateSpec is called.
+func (c *container) Spec() (*specs.Spec, error) {
+	c.specLock.Lock()
+	defer c.specLock.Unlock()
+	if c.spec == nil {
+		spec, err := ReadSpec(c.specBundle())
+		if err != nil {
+			return nil, err
+		}
+		c.spec = spec
+	}
+	return c.spec, nil
+}
+
+// InvalidateSpec drops the cached spec so that the next call to Spec reads
+// the bundle's config.json again
+func (c *container) InvalidateSpec() {
+	c.specLock.Lock()
+	c.spec = nil
+	c.specLock.Unlock()
+}
+
+// specBundle returns the bundle handed to the runtime if it was written,
+// otherwise the container's bundle
+func (c *container) specBundle() string {
+	dir := filepath.Join(c.root, c.id, runtimeBundleDir)
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err == nil {
+		return dir
+	}
+	return c.bundle
+}
+
+// rewriteSpec passes the bundle's config.json decoded into a map to fn and
+// writes it back if fn changed it.  Fields of the spec that are unknown to
+// containerd are preserved.
+func rewriteSpec(bundle string, fn func(spec map[string]interface{}) (bool, error)) error {
+	path := filepath.Join(bundle, "config.json")
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	changed, err := fn(spec)
+	if err != nil || !changed {
+		return err
+	}
+	if data, err = json.MarshalIndent(spec, "", "\t"); err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return writeSpec(path, data, fi.Mode())
+}
+
+// writeSpec replaces the spec at path with data.  It is written to a file in
+// the same directory that is synced before it is renamed over the spec so
+// that a crash leaves either the old or the new spec and never a torn one.
+func writeSpec(path string, data []byte, mode os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".config.json")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if d, err := os.Open(filepath.Dir(path)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
+
+// specNamespaces returns the linux section of the decoded spec and its
+// namespaces, the linux section is added if it is missing
+func specNamespaces(spec map[string]interface{}) (map[string]interface{}, []interface{}) {
+	linux, _ := spec["linux"].(map[string]interface{})
+	if linux == nil {
+		linux = make(map[string]interface{})
+		spec["linux"] = linux
+	}
+	namespaces, _ := linux["namespaces"].([]interface{})
+	return linux, namespaces
+}
+
+// ExportSpec returns the bundle's config.json with an absolute root path so
+// that the spec can be written to a bundle in another directory.  Fields of
+// the spec that are unknown to containerd are preserved.
+func ExportSpec(bundle string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(bundle, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+	if root, ok := spec["root"].(map[string]interface{}); ok {
+		if path, ok := root["path"].(string); ok && !filepath.IsAbs(path) {
+			root["path"] = filepath.Join(bundle, path)
+		}
+	}
+	return json.MarshalIndent(spec, "", "\t")
+}
@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// sysClassNet is where the kernel lists the network interfaces of the host
+const sysClassNet = "/sys/class/net"
+
+// VirtualFunctions returns the number of virtual functions enabled on the
+// SR-IOV physical function pf
+func VirtualFunctions(pf string) (int, error) {
+	data, err := ioutil.ReadFile(filepath.Join(sysClassNet, pf, "device", "sriov_numvfs"))
+	if err != nil {
+		return 0, fmt.Errorf("%v: %s", ErrNoVirtualFunctions, pf)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("%v: %s", ErrNoVirtualFunctions, pf)
+	}
+	return n, nil
+}
+
+// vfInterface returns the name of the interface of the virtual function in
+// the daemon's network namespace, a virtual function bound to a driver other
+// than a network driver has none
+func vfInterface(vf VFConfig) (string, error) {
+	dirs, err := ioutil.ReadDir(filepath.Join(sysClassNet, vf.PF, "device", fmt.Sprintf("virtfn%d", vf.Index), "net"))
+	if err != nil || len(dirs) == 0 {
+		return "", fmt.Errorf("virtual function has no interface on the host")
+	}
+	return dirs[0].Name(), nil
+}
+
+// attachVF sets the MAC and the VLAN of the virtual function and moves its
+// interface into the network namespace of the pid
+func attachVF(pid int, vf VFConfig) error {
+	pf, err := netlink.LinkByName(vf.PF)
+	if err != nil {
+		return err
+	}
+	if vf.MAC != "" {
+		mac, err := net.ParseMAC(vf.MAC)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetVfHardwareAddr(pf, vf.Index, mac); err != nil {
+			return fmt.Errorf("set mac: %v", err)
+		}
+	}
+	if vf.VLAN != 0 {
+		if err := netlink.LinkSetVfVlan(pf, vf.Index, vf.VLAN); err != nil {
+			return fmt.Errorf("set vlan: %v", err)
+		}
+	}
+	name, err := vfInterface(vf)
+	if err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return err
+	}
+	if err := netlink.LinkSetNsPid(link, pid); err != nil {
+		return fmt.Errorf("move interface %s: %v", name, err)
+	}
+	return inNetworkNamespace(pid, func() error {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return err
+		}
+		if vf.Name != "" && vf.Name != name {
+			if err := linkSetName(link, vf.Name); err != nil {
+				return fmt.Errorf("rename interface %s: %v", name, err)
+			}
+		}
+		return linkSetUp(link)
+	})
+}
+
+// releaseVF clears the MAC and the VLAN set on the container's virtual
+// function so that the next container does not inherit them.  The interface
+// of the virtual function returns to the host's network namespace with the
+// container's network namespace.
+func (c *container) releaseVF() {
+	vf := c.network.VF
+	if vf == nil || (vf.MAC == "" && vf.VLAN == 0) {
+		return
+	}
+	pf, err := netlink.LinkByName(vf.PF)
+	if err == nil && vf.MAC != "" {
+		err = netlink.LinkSetVfHardwareAddr(pf, vf.Index, make(net.HardwareAddr, 6))
+	}
+	if err == nil && vf.VLAN != 0 {
+		err = netlink.LinkSetVfVlan(pf, vf.Index, 0)
+	}
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+			"pf":    vf.PF,
+			"vf":    vf.Index,
+		}).Warn("containerd: reset virtual function")
+	}
+}
@@ -0,0 +1,11 @@
+package runtime
+
+// VirtualFunctions returns ErrNetworkNotSupported as SR-IOV is not supported
+// on Windows
+func VirtualFunctions(pf string) (int, error) {
+	return 0, ErrNetworkNotSupported
+}
+
+// releaseVF does nothing as containers have no virtual functions on Windows
+func (c *container) releaseVF() {
+}
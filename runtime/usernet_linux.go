@@ -0,0 +1,84 @@
+package runtime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/specs"
+)
+
+const (
+	// Slirp4netnsBinary provides usermode networking to the containers of an
+	// unprivileged daemon
+	Slirp4netnsBinary = "slirp4netns"
+	// UsernetPidFile holds the pid of the container's slirp4netns
+	UsernetPidFile = "slirp4netns.pid"
+)
+
+// startUsernet connects the network namespace of the container to the host
+// through slirp4netns when the daemon is unprivileged and cannot create veth
+// pairs.  Containers sharing another network namespace are left alone.
+func (c *container) startUsernet(spec *specs.Spec, pid int) {
+	if !Rootless() || !ownNetworkNamespace(spec) {
+		return
+	}
+	path, err := exec.LookPath(Slirp4netnsBinary)
+	if err != nil {
+		log.WithField("id", c.id).Warn("containerd: slirp4netns not found, container has no network")
+		return
+	}
+	cmd := exec.Command(path, "--configure", "--mtu=65520", "--disable-host-loopback", strconv.Itoa(pid), "tap0")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+	if err := cmd.Start(); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Error("containerd: start slirp4netns")
+		return
+	}
+	// the daemon's reaper waits on the process
+	if err := ioutil.WriteFile(filepath.Join(c.root, c.id, UsernetPidFile), []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+		cmd.Process.Kill()
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    c.id,
+		}).Error("containerd: save slirp4netns pid")
+	}
+}
+
+// stopUsernet kills the container's slirp4netns, the pid is only signaled
+// while it still is a slirp4netns process
+func (c *container) stopUsernet() {
+	data, err := ioutil.ReadFile(filepath.Join(c.root, c.id, UsernetPidFile))
+	if err != nil {
+		return
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return
+	}
+	cmdline, err := ioutil.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "cmdline"))
+	if err != nil || !bytes.Contains(cmdline, []byte(Slirp4netnsBinary)) {
+		return
+	}
+	if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		log.WithField("error", err).Warn("containerd: kill slirp4netns")
+	}
+}
+
+func ownNetworkNamespace(spec *specs.Spec) bool {
+	for _, ns := range spec.Linux.Namespaces {
+		if ns.Type == "network" {
+			return ns.Path == ""
+		}
+	}
+	return false
+}
@@ -0,0 +1,5 @@
+package runtime
+
+// stopUsernet does nothing as there is no usermode networking on Windows
+func (c *container) stopUsernet() {
+}
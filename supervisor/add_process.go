@@ -14,6 +14,7 @@ type AddProcessTask struct {
 	Stdout        string
 	Stderr        string
 	Stdin         string
+	StdioSocket   bool
 	ProcessSpec   *specs.ProcessSpec
 	StartResponse chan StartResponse
 }
@@ -24,7 +25,9 @@ func (s *Supervisor) addProcess(t *AddProcessTask) error {
 	if !ok {
 		return ErrContainerNotFound
 	}
-	process, err := ci.container.Exec(t.PID, *t.ProcessSpec, runtime.NewStdio(t.Stdin, t.Stdout, t.Stderr))
+	stdio := runtime.NewStdio(t.Stdin, t.Stdout, t.Stderr)
+	stdio.Socket = t.StdioSocket
+	process, err := ci.container.Exec(t.Context(), t.PID, *t.ProcessSpec, stdio)
 	if err != nil {
 		return err
 	}
@@ -32,6 +35,7 @@ func (s *Supervisor) addProcess(t *AddProcessTask) error {
 		return err
 	}
 	ExecProcessTimer.UpdateSince(start)
+	countStdioMode(process)
 	t.StartResponse <- StartResponse{}
 	s.notifySubscribers(Event{
 		Timestamp: time.Now(),
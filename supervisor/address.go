@@ -0,0 +1,68 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// AddressTask adds a labelled secondary address to an interface of a running
+// container or removes the addresses with a label
+type AddressTask struct {
+	baseTask
+	ID      string
+	Address runtime.Address
+	// Remove removes the addresses with the label of Address instead
+	Remove bool
+	// Removed are the addresses that were removed
+	Removed []runtime.Address
+}
+
+func (s *Supervisor) updateAddress(t *AddressTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	typ := "address-add"
+	if t.Remove {
+		typ = "address-remove"
+		removed, err := i.container.DeleteAddresses(t.Address.Label)
+		if err != nil {
+			return err
+		}
+		t.Removed = removed
+	} else if err := i.container.AddAddress(t.Address); err != nil {
+		return err
+	}
+	s.notifySubscribers(Event{
+		ID:        t.ID,
+		Type:      typ,
+		Timestamp: time.Now(),
+		Addresses: i.container.Addresses(),
+	})
+	return nil
+}
+
+// ListAddressesTask returns the addresses of the interfaces of a running
+// container
+type ListAddressesTask struct {
+	baseTask
+	ID string
+	// Label selects the addresses with the label, all of the addresses are
+	// returned when it is empty
+	Label     string
+	Addresses []runtime.Address
+}
+
+func (s *Supervisor) listAddresses(t *ListAddressesTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	addrs, err := i.container.ListAddresses(t.Label)
+	if err != nil {
+		return err
+	}
+	t.Addresses = addrs
+	return nil
+}
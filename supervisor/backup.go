@@ -0,0 +1,349 @@
+package supervisor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+// backupVersion is the version of the backup archive written by this daemon
+const backupVersion = 1
+
+// Backup is the state of the daemon exported to restore it on another host or
+// after the state directory was lost
+type Backup struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	// Records are the records of the metadata database by bucket and
+	// container id
+	Records map[string]map[string]json.RawMessage `json:"-"`
+	// Checkpoints are the checkpoints of the containers' bundles by container
+	// id
+	Checkpoints map[string][]runtime.Checkpoint `json:"-"`
+	Templates   []*Template                     `json:"-"`
+	// Groups are the groups whose containers share a network namespace
+	Groups []BackupGroup `json:"-"`
+}
+
+// BackupGroup is a group of a backup, its sandbox holder is started again
+// when the backup is restored
+type BackupGroup struct {
+	ID         string   `json:"id"`
+	Namespaces []string `json:"namespaces"`
+}
+
+// BackupTask exports the records of the containers, the index of their
+// checkpoints, the templates and the groups
+type BackupTask struct {
+	baseTask
+	Backup *Backup
+}
+
+func (s *Supervisor) backup(t *BackupTask) error {
+	b := &Backup{
+		Version:     backupVersion,
+		Created:     time.Now(),
+		Records:     make(map[string]map[string]json.RawMessage),
+		Checkpoints: make(map[string][]runtime.Checkpoint),
+	}
+	if err := s.db.View(func(tx *metadata.Tx) error {
+		return tx.ForEach(func(name string, bucket *metadata.Bucket) error {
+			// the host-local allocations are exported by network so that
+			// the addresses of the restored containers are allocated again
+			if daemonBuckets[name] && name != ipam.Bucket {
+				return nil
+			}
+			records := make(map[string]json.RawMessage)
+			if err := bucket.ForEach(func(key string, value []byte) error {
+				records[key] = json.RawMessage(append([]byte(nil), value...))
+				return nil
+			}); err != nil {
+				return err
+			}
+			b.Records[name] = records
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	for id, i := range s.containers {
+		// containers without checkpoints have no checkpoints directory
+		if checkpoints, err := i.container.Checkpoints(); err == nil && len(checkpoints) > 0 {
+			b.Checkpoints[id] = checkpoints
+		}
+	}
+	templates, err := s.Templates()
+	if err != nil {
+		return err
+	}
+	b.Templates = templates
+	for _, g := range s.groups {
+		b.Groups = append(b.Groups, BackupGroup{
+			ID:         g.sandbox.ID,
+			Namespaces: g.sandbox.Namespaces,
+		})
+	}
+	sort.Sort(backupGroups(b.Groups))
+	t.Backup = b
+	return nil
+}
+
+// RestoreBackupTask adds the containers, the templates and the groups of a
+// backup that do not exist.  The containers are restored as stopped
+// containers.
+type RestoreBackupTask struct {
+	baseTask
+	Backup *Backup
+	// Restored are the ids of the containers that were restored
+	Restored []string
+	// Skipped are the ids of the containers that existed already or could
+	// not be restored
+	Skipped []string
+}
+
+func (s *Supervisor) restoreBackup(t *RestoreBackupTask) error {
+	b := t.Backup
+	if b.Version < 1 || b.Version > backupVersion {
+		return ErrBackupVersion
+	}
+	for _, g := range b.Groups {
+		if _, ok := s.groups[g.ID]; ok {
+			continue
+		}
+		sb, err := runtime.NewSandbox(filepath.Join(s.stateDir, groupsDir), g.ID, g.Namespaces)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err,
+				"id":    g.ID,
+			}).Error("containerd: restore group from backup")
+			continue
+		}
+		s.addGroup(&group{
+			sandbox: sb,
+		})
+	}
+	for _, tmpl := range b.Templates {
+		if !validFileName(tmpl.Name) {
+			continue
+		}
+		if err := s.writeTemplate(tmpl); err != nil && err != ErrTemplateExists {
+			return err
+		}
+	}
+	var ids []string
+	for id := range b.Records[runtime.ContainersBucket] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := s.containers[id]; ok || !validFileName(id) {
+			t.Skipped = append(t.Skipped, id)
+			continue
+		}
+		if err := s.restoreBackupContainer(b, id); err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err,
+				"id":    id,
+			}).Error("containerd: restore container from backup")
+			t.Skipped = append(t.Skipped, id)
+			continue
+		}
+		t.Restored = append(t.Restored, id)
+	}
+	return nil
+}
+
+// restoreBackupContainer writes the records of the container and loads it as
+// a stopped container
+func (s *Supervisor) restoreBackupContainer(b *Backup, id string) error {
+	dir := filepath.Join(s.containerRoot(id), id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := s.restoreAddresses(b, id); err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		for name, records := range b.Records {
+			data, ok := records[id]
+			if !ok || daemonBuckets[name] {
+				continue
+			}
+			bucket, err := tx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(id, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		s.releaseBackupAddresses(b, id)
+		os.RemoveAll(dir)
+		return err
+	}
+	container, err := runtime.Load(s.db, s.containerRoot(id), id)
+	if err != nil {
+		s.db.Update(func(tx *metadata.Tx) error {
+			return tx.ForEach(func(name string, bucket *metadata.Bucket) error {
+				if daemonBuckets[name] {
+					return nil
+				}
+				return bucket.Delete(id)
+			})
+		})
+		s.releaseBackupAddresses(b, id)
+		os.RemoveAll(dir)
+		return err
+	}
+	for _, c := range b.Checkpoints[id] {
+		if _, err := os.Stat(filepath.Join(container.Path(), "checkpoints", c.Name)); err != nil {
+			log.WithFields(logrus.Fields{
+				"id":         id,
+				"checkpoint": c.Name,
+			}).Warn("containerd: checkpoint of restored container is missing from its bundle")
+		}
+	}
+	i := &containerInfo{
+		container: container,
+		lifecycle: newLifecycle(Stopped),
+	}
+	s.restoreMaxRuntime(i)
+	s.restoreOOMRestart(i)
+	s.restorePinnedCPUSet(i)
+	s.containers[id] = i
+	ContainersCounter.Inc(1)
+	return nil
+}
+
+type backupGroups []BackupGroup
+
+func (g backupGroups) Len() int           { return len(g) }
+func (g backupGroups) Less(i, j int) bool { return g[i].ID < g[j].ID }
+func (g backupGroups) Swap(i, j int)      { g[i], g[j] = g[j], g[i] }
+
+const (
+	backupFile      = "backup.json"
+	recordsDir      = "records"
+	checkpointsFile = "checkpoints.json"
+	groupsFile      = "groups.json"
+)
+
+// WriteBackup writes the backup as a gzip compressed tar archive
+func WriteBackup(w io.Writer, b *Backup) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	add := func(name string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(data)),
+			ModTime:  b.Created,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+	if err := add(backupFile, b); err != nil {
+		return err
+	}
+	var buckets []string
+	for name := range b.Records {
+		buckets = append(buckets, name)
+	}
+	sort.Strings(buckets)
+	for _, name := range buckets {
+		if err := add(path.Join(recordsDir, name+".json"), b.Records[name]); err != nil {
+			return err
+		}
+	}
+	if err := add(checkpointsFile, b.Checkpoints); err != nil {
+		return err
+	}
+	for _, t := range b.Templates {
+		if err := add(path.Join(templatesDir, t.Name+".json"), t); err != nil {
+			return err
+		}
+	}
+	if err := add(groupsFile, b.Groups); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ReadBackup reads a backup archive written by WriteBackup
+func ReadBackup(r io.Reader) (*Backup, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrBackupVersion
+	}
+	defer gr.Close()
+	b := &Backup{
+		Records:     make(map[string]map[string]json.RawMessage),
+		Checkpoints: make(map[string][]runtime.Checkpoint),
+	}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		dir, file := path.Split(hdr.Name)
+		switch {
+		case hdr.Name == backupFile:
+			err = json.Unmarshal(data, b)
+		case hdr.Name == checkpointsFile:
+			err = json.Unmarshal(data, &b.Checkpoints)
+		case hdr.Name == groupsFile:
+			err = json.Unmarshal(data, &b.Groups)
+		case dir == recordsDir+"/" && strings.HasSuffix(file, ".json"):
+			var records map[string]json.RawMessage
+			if err = json.Unmarshal(data, &records); err == nil {
+				b.Records[strings.TrimSuffix(file, ".json")] = records
+			}
+		case dir == templatesDir+"/" && strings.HasSuffix(file, ".json"):
+			var t Template
+			if err = json.Unmarshal(data, &t); err == nil {
+				b.Templates = append(b.Templates, &t)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if b.Version < 1 || b.Version > backupVersion {
+		return nil, ErrBackupVersion
+	}
+	return b, nil
+}
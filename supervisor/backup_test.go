@@ -0,0 +1,112 @@
+package supervisor
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/runtime"
+)
+
+func TestBackupRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-backup-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := newTestSupervisor()
+	src.stateDir = filepath.Join(dir, "src")
+	if err := os.MkdirAll(src.stateDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	src.db = openTestDB(t, src.stateDir)
+	defer src.db.Close()
+	src.containers = make(map[string]*containerInfo)
+	for _, id := range []string{"redis", "nginx"} {
+		bundle := filepath.Join(dir, id)
+		if err := os.Mkdir(bundle, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(bundle, "config.json"), []byte(`{"process": {"args": ["sh"]}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		c, err := runtime.New(src.db, runtime.ContainerOpts{
+			Root:    src.stateDir,
+			ID:      id,
+			Bundle:  bundle,
+			Runtime: "runc",
+			Labels:  []string{"app=" + id},
+			Keep:    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		src.containers[id] = &containerInfo{container: c, lifecycle: newLifecycle(Stopped)}
+	}
+	if err := src.saveContainerRecord(oomRestartsBucket, "redis", &OOMRestartPolicy{MaxRestarts: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.writeTemplate(&Template{Name: "cache", Container: "redis"}); err != nil {
+		t.Fatal(err)
+	}
+	network := ipam.Network{Subnet: "10.0.0.0/24", Gateway: "10.0.0.1"}
+	addr, err := ipam.NewHostLocal(src.db).Allocate("bridge", network, "redis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt := &BackupTask{}
+	if err := src.backup(bt); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteBackup(&buf, bt.Backup); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ReadBackup(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := newTestSupervisor()
+	dst.stateDir = filepath.Join(dir, "dst")
+	if err := os.MkdirAll(dst.stateDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	dst.db = openTestDB(t, dst.stateDir)
+	defer dst.db.Close()
+	dst.containers = map[string]*containerInfo{"nginx": src.containers["nginx"]}
+	rt := &RestoreBackupTask{Backup: b}
+	if err := dst.restoreBackup(rt); err != nil {
+		t.Fatal(err)
+	}
+	if len(rt.Restored) != 1 || rt.Restored[0] != "redis" {
+		t.Fatalf("expected redis to be restored but received %v", rt.Restored)
+	}
+	if len(rt.Skipped) != 1 || rt.Skipped[0] != "nginx" {
+		t.Fatalf("expected the existing container to be skipped but received %v", rt.Skipped)
+	}
+	i := dst.containers["redis"]
+	if i.lifecycle.snapshot().State() != Stopped {
+		t.Fatalf("expected the restored container to be stopped but it is %s", i.lifecycle.snapshot().State())
+	}
+	if labels := i.container.Labels(); len(labels) != 1 || labels[0] != "app=redis" {
+		t.Fatalf("expected the labels of the container but received %v", labels)
+	}
+	if i.oomRestart == nil || i.oomRestart.MaxRestarts != 3 {
+		t.Fatalf("expected the OOM restart policy to be restored but received %v", i.oomRestart)
+	}
+	if _, err := dst.readTemplate("cache"); err != nil {
+		t.Fatalf("expected the template to be restored but received %v", err)
+	}
+	if web, err := ipam.NewHostLocal(dst.db).Allocate("bridge", network, "web"); err != nil || web.Address == addr.Address {
+		t.Fatalf("expected the address of the restored container to be allocated but received %+v %v", web, err)
+	}
+
+	b.Version = backupVersion + 1
+	if err := dst.restoreBackup(&RestoreBackupTask{Backup: b}); err != ErrBackupVersion {
+		t.Fatalf("expected ErrBackupVersion but received %v", err)
+	}
+}
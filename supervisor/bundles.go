@@ -0,0 +1,159 @@
+package supervisor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/containerd/archive"
+	"github.com/docker/containerd/metadata"
+)
+
+// SetBundleRoot sets the directory bundles uploaded over the api are unpacked
+// in, it must be called before the supervisor is started
+func (s *Supervisor) SetBundleRoot(root string) {
+	s.bundleRoot = root
+}
+
+// UploadBundle unpacks the tar stream r of a bundle in the bundle root and
+// returns the id and the path of the bundle.  The config.json and rootfs of
+// the bundle are either at the top level of the stream or inside of its only
+// top level directory.  The size of the bundle counts against the bundle disk
+// quota of the namespace and the bundle is added to the lease unless it is
+// empty.
+func (s *Supervisor) UploadBundle(r io.Reader, namespace, lease string) (string, string, error) {
+	if s.bundleRoot == "" {
+		return "", "", ErrBundleUploadDisabled
+	}
+	if lease != "" {
+		// fail before the bundle is unpacked
+		if err := s.updateLease(lease, func(*Lease) {}); err != nil {
+			return "", "", err
+		}
+	}
+	if err := os.MkdirAll(s.bundleRoot, 0700); err != nil {
+		return "", "", err
+	}
+	tmp, err := ioutil.TempDir(s.bundleRoot, ".upload-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmp)
+	if err := archive.Untar(r, tmp, "/"); err != nil {
+		return "", "", err
+	}
+	src, err := bundleDir(tmp)
+	if err != nil {
+		return "", "", err
+	}
+	size, err := dirSize(src)
+	if err != nil {
+		return "", "", err
+	}
+	id, err := newBundleID()
+	if err != nil {
+		return "", "", err
+	}
+	path := filepath.Join(s.bundleRoot, id)
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(uploadsBucket)
+		if err != nil {
+			return err
+		}
+		if quota := s.bundleDiskQuota(namespace); quota > 0 {
+			used, err := uploadedSize(b, namespace)
+			if err != nil {
+				return err
+			}
+			if used+size > quota {
+				return &QuotaError{Namespace: namespace, Resource: QuotaBundleDisk, Limit: float64(quota), Used: float64(used), Requested: float64(size)}
+			}
+		}
+		if err := recordUpload(b, id, &upload{
+			Uploaded:  time.Now(),
+			Namespace: namespace,
+			Size:      size,
+		}); err != nil {
+			return err
+		}
+		// the bundle is moved last so that the record is rolled back when
+		// it cannot be moved
+		return os.Rename(src, path)
+	}); err != nil {
+		os.RemoveAll(path)
+		return "", "", err
+	}
+	if lease != "" {
+		if err := s.updateLease(lease, func(l *Lease) {
+			l.Bundles = append(l.Bundles, id)
+		}); err != nil {
+			os.RemoveAll(path)
+			return "", "", err
+		}
+	}
+	return id, path, nil
+}
+
+// BundlePath returns the path of the uploaded bundle id
+func (s *Supervisor) BundlePath(id string) (string, error) {
+	if s.bundleRoot == "" {
+		return "", ErrBundleUploadDisabled
+	}
+	if _, err := hex.DecodeString(id); err != nil || id == "" {
+		return "", ErrBundleNotFound
+	}
+	path := filepath.Join(s.bundleRoot, id)
+	if _, err := os.Stat(filepath.Join(path, "config.json")); err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrBundleNotFound
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// bundleDir returns the directory holding the config.json of an unpacked
+// bundle
+func bundleDir(dir string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, "config.json")); err == nil {
+		return dir, nil
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		sub := filepath.Join(dir, entries[0].Name())
+		if _, err := os.Stat(filepath.Join(sub, "config.json")); err == nil {
+			return sub, nil
+		}
+	}
+	return "", ErrBundleConfigNotFound
+}
+
+func newBundleID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// dirSize returns the size of the regular files of dir
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.Mode().IsRegular() {
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
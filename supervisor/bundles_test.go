@@ -0,0 +1,72 @@
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// bundleTar returns a tar stream of the files, directories end with /
+func bundleTar(t *testing.T, files ...string) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Typeflag: tar.TypeReg}
+		if name[len(name)-1] == '/' {
+			hdr.Mode, hdr.Typeflag = 0755, tar.TypeDir
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestUploadBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-bundles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := &Supervisor{}
+	s.SetBundleRoot(filepath.Join(dir, "bundles"))
+	s.db = openTestDB(t, dir)
+	defer s.db.Close()
+	for _, files := range [][]string{
+		{"config.json", "rootfs/"},
+		{"bundle/", "bundle/config.json", "bundle/rootfs/"},
+	} {
+		id, path, err := s.UploadBundle(bundleTar(t, files...), DefaultNamespace, "")
+		if err != nil {
+			t.Fatalf("%v: %v", files, err)
+		}
+		if _, err := os.Stat(filepath.Join(path, "rootfs")); err != nil {
+			t.Fatalf("%v: expected the rootfs to be unpacked: %v", files, err)
+		}
+		if p, err := s.BundlePath(id); err != nil || p != path {
+			t.Fatalf("%v: expected the path %s of the bundle but received %s %v", files, path, p, err)
+		}
+	}
+	if _, _, err := s.UploadBundle(bundleTar(t, "rootfs/"), DefaultNamespace, ""); err != ErrBundleConfigNotFound {
+		t.Fatalf("expected ErrBundleConfigNotFound but received %v", err)
+	}
+	for _, id := range []string{"", "00", "../bundles"} {
+		if _, err := s.BundlePath(id); err != ErrBundleNotFound {
+			t.Fatalf("expected ErrBundleNotFound for %q but received %v", id, err)
+		}
+	}
+	// the unpacked uploads are removed
+	entries, err := ioutil.ReadDir(s.bundleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the two bundles in the bundle root but received %d entries", len(entries))
+	}
+}
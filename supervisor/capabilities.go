@@ -0,0 +1,59 @@
+package supervisor
+
+import (
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logger"
+	"github.com/docker/containerd/runtime"
+)
+
+// Capabilities are the features of the daemon and the host, integrators check
+// them instead of probing with calls that fail
+type Capabilities struct {
+	Host runtime.Capabilities
+	// Runtime is the binary that runs containers
+	Runtime       string
+	LogDrivers    []string
+	VolumeDrivers []string
+	// HookPlugins is true if hook plugins are called for the containers
+	HookPlugins bool
+	// OCIHooks is true if the daemon adds OCI hooks to the containers' specs
+	OCIHooks bool
+	GPUs     []string
+}
+
+// Capabilities returns the features of the daemon and of the host as they
+// were probed at startup
+func (s *Supervisor) Capabilities() Capabilities {
+	return Capabilities{
+		Host:          s.capabilities,
+		Runtime:       s.runtime,
+		LogDrivers:    logger.Drivers(),
+		VolumeDrivers: s.volumes.Names(),
+		HookPlugins:   s.hooks.Enabled(),
+		OCIHooks:      s.ociHooks != nil,
+		GPUs:          s.machine.GPUs,
+	}
+}
+
+// logCapabilities logs the report of the host's features
+func logCapabilities(c runtime.Capabilities) {
+	log.WithFields(logrus.Fields{
+		"kernel":            c.KernelVersion,
+		"cgroupVersion":     c.CgroupVersion,
+		"cgroupControllers": strings.Join(c.CgroupControllers, ","),
+		"cgroupNamespace":   c.CgroupNamespace,
+		"userNamespaces":    c.UserNamespaces,
+		"seccomp":           c.Seccomp,
+		"apparmor":          c.AppArmor,
+		"selinux":           c.SELinux,
+		"overlayfs":         c.Overlayfs,
+		"criu":              c.CRIU,
+		"realtime":          c.Realtime,
+		"swapAccounting":    c.SwapAccounting,
+		"rootless":          c.Rootless,
+		"cgroupsDelegated":  c.CgroupsDelegated,
+		"slirp4netns":       c.Slirp4netns,
+	}).Info("containerd: host capabilities")
+}
@@ -0,0 +1,289 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+// FindingKind is the kind of a discrepancy between the containers of the
+// supervisor and the host
+type FindingKind string
+
+const (
+	// UnloadedRecord is a record of the metadata database without a
+	// container, it is cleaned by deleting the container's records and state
+	// directory
+	UnloadedRecord FindingKind = "unloaded-record"
+	// OrphanStateDir is a container state directory with processes but
+	// without a container or a record, it is cleaned by removing it
+	OrphanStateDir FindingKind = "orphan-state-dir"
+	// MissingCgroup is a cgroup of a running container that does not exist
+	MissingCgroup FindingKind = "missing-cgroup"
+	// MissingShim is a running process whose shim exited, it is cleaned by
+	// adopting the process with a new shim
+	MissingShim FindingKind = "missing-shim"
+	// MissingNetworkNamespace is the network namespace path of a running
+	// container's spec that does not exist
+	MissingNetworkNamespace FindingKind = "missing-netns"
+	// DeadSandbox is the sandbox holder of a group with containers that is
+	// not running, the group's namespaces are gone
+	DeadSandbox FindingKind = "dead-sandbox"
+	// StaleGroupMember is a member of a group that is not a container, it is
+	// cleaned by removing it from the group
+	StaleGroupMember FindingKind = "stale-group-member"
+)
+
+// Finding is a discrepancy found by a check
+type Finding struct {
+	Kind FindingKind
+	// ID is the id of the container or of the group
+	ID string
+	// PID is the id of the process of missing-shim findings
+	PID string
+	// Detail is the path or the error of the discrepancy
+	Detail string
+	// Cleaned is true when the discrepancy was cleaned
+	Cleaned bool
+}
+
+// CheckReport is the result of a check
+type CheckReport struct {
+	Started  time.Time
+	Duration time.Duration
+	Findings []Finding
+}
+
+// CheckTask cross checks the containers and the records of the supervisor
+// against the state directories, cgroups, shims and namespaces of the host
+type CheckTask struct {
+	baseTask
+	// Clean cleans the discrepancies that can be cleaned
+	Clean  bool
+	Report *CheckReport
+}
+
+// StartChecks checks the consistency of the supervisor's state every
+// interval, logs the findings and keeps the report of the last check
+func (s *Supervisor) StartChecks(interval time.Duration, clean bool) {
+	go func() {
+		defer s.HandlePanic()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			t := &CheckTask{Clean: clean}
+			s.SendTask(t)
+			if err := <-t.ErrorCh(); err != nil {
+				log.WithField("error", err).Error("containerd: check state consistency")
+				continue
+			}
+			for _, f := range t.Report.Findings {
+				log.WithFields(logrus.Fields{
+					"kind":    f.Kind,
+					"id":      f.ID,
+					"pid":     f.PID,
+					"detail":  f.Detail,
+					"cleaned": f.Cleaned,
+				}).Warn("containerd: state is inconsistent")
+			}
+			s.checkLock.Lock()
+			s.lastCheck = t.Report
+			s.checkLock.Unlock()
+		}
+	}()
+}
+
+// LastCheck returns the report of the last periodic check or nil when no
+// check ran
+func (s *Supervisor) LastCheck() *CheckReport {
+	s.checkLock.Lock()
+	defer s.checkLock.Unlock()
+	return s.lastCheck
+}
+
+func (s *Supervisor) check(t *CheckTask) error {
+	r := &CheckReport{Started: time.Now()}
+	records, err := s.checkRecords(t.Clean)
+	if err != nil {
+		return err
+	}
+	r.Findings = append(r.Findings, records...)
+	r.Findings = append(r.Findings, s.checkStateDirs(t.Clean)...)
+	for id, i := range s.containers {
+		r.Findings = append(r.Findings, s.checkContainer(id, i, t.Clean)...)
+	}
+	r.Findings = append(r.Findings, s.checkGroups(t.Clean)...)
+	r.Duration = time.Since(r.Started)
+	t.Report = r
+	return nil
+}
+
+// checkRecords finds the records of containers that are not known to the
+// supervisor, such as the containers that could not be restored
+func (s *Supervisor) checkRecords(clean bool) ([]Finding, error) {
+	ids, err := runtime.ContainerIDs(s.db)
+	if err != nil {
+		return nil, err
+	}
+	var findings []Finding
+	for _, id := range ids {
+		if _, ok := s.containers[id]; ok {
+			continue
+		}
+		f := Finding{Kind: UnloadedRecord, ID: id}
+		if clean {
+			if err := s.deleteRecords(id); err != nil {
+				f.Detail = err.Error()
+			} else {
+				f.Cleaned = true
+			}
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// deleteRecords deletes the records of the container from every bucket and
+// its state directory
+func (s *Supervisor) deleteRecords(id string) error {
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		return tx.ForEach(func(name string, b *metadata.Bucket) error {
+			if daemonBuckets[name] {
+				return nil
+			}
+			return b.Delete(id)
+		})
+	}); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.containerRoot(id), id))
+}
+
+// checkStateDirs finds the state directories of containers that have neither
+// a container nor a record.  Only directories with a process are considered
+// so that the other directories of the state dir are never reported.
+func (s *Supervisor) checkStateDirs(clean bool) []Finding {
+	roots := map[string]string{s.stateDir: DefaultNamespace}
+	if dirs, err := ioutil.ReadDir(filepath.Join(s.stateDir, namespacesDir)); err == nil {
+		for _, d := range dirs {
+			if d.IsDir() {
+				roots[filepath.Join(s.stateDir, namespacesDir, d.Name())] = d.Name()
+			}
+		}
+	}
+	var findings []Finding
+	for root, namespace := range roots {
+		dirs, err := ioutil.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, d := range dirs {
+			id := QualifiedID(namespace, d.Name())
+			if !d.IsDir() || s.containers[id] != nil || !hasProcessState(filepath.Join(root, d.Name())) {
+				continue
+			}
+			if s.hasRecord(id) {
+				continue
+			}
+			f := Finding{Kind: OrphanStateDir, ID: id, Detail: filepath.Join(root, d.Name())}
+			if clean {
+				if err := os.RemoveAll(f.Detail); err == nil {
+					f.Cleaned = true
+				}
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// hasProcessState returns true when a directory of dir holds the state of a
+// process
+func hasProcessState(dir string) bool {
+	dirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, d := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, d.Name(), "process.json")); d.IsDir() && err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Supervisor) hasRecord(id string) bool {
+	var ok bool
+	s.db.View(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(runtime.ContainersBucket); b != nil {
+			ok = b.Get(id) != nil
+		}
+		return nil
+	})
+	return ok
+}
+
+// checkContainer checks the cgroups, shims and network namespace of a running
+// container
+func (s *Supervisor) checkContainer(id string, i *containerInfo, clean bool) []Finding {
+	if i.container.State() == runtime.Stopped {
+		return nil
+	}
+	var findings []Finding
+	if paths, err := i.container.CgroupPaths(); err == nil {
+		for _, p := range paths {
+			if _, err := os.Stat(p); os.IsNotExist(err) {
+				findings = append(findings, Finding{Kind: MissingCgroup, ID: id, Detail: p})
+			}
+		}
+	}
+	if path := s.missingNetworkNamespace(i.container); path != "" {
+		findings = append(findings, Finding{Kind: MissingNetworkNamespace, ID: id, Detail: path})
+	}
+	processes, err := i.container.Processes()
+	if err != nil {
+		return findings
+	}
+	for _, p := range processes {
+		if p.State() != runtime.Running || p.ShimAlive() {
+			continue
+		}
+		f := Finding{Kind: MissingShim, ID: id, PID: p.ID()}
+		if clean {
+			if err := s.recoverShim(p); err == nil {
+				f.Cleaned = true
+			}
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// checkGroups checks that the sandbox holders of the groups with containers
+// are running and that their members are containers
+func (s *Supervisor) checkGroups(clean bool) []Finding {
+	var findings []Finding
+	for id, g := range s.groups {
+		for _, member := range append([]string(nil), g.sandbox.Containers...) {
+			if _, ok := s.containers[member]; ok {
+				continue
+			}
+			f := Finding{Kind: StaleGroupMember, ID: id, Detail: member}
+			if clean {
+				if err := g.sandbox.Remove(member); err == nil {
+					f.Cleaned = true
+				}
+			}
+			findings = append(findings, f)
+		}
+		if len(g.sandbox.Containers) > 0 && !g.sandbox.Running() {
+			findings = append(findings, Finding{Kind: DeadSandbox, ID: id})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,25 @@
+package supervisor
+
+import (
+	"os"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// missingNetworkNamespace returns the network namespace path of the
+// container's spec when it does not exist
+func (s *Supervisor) missingNetworkNamespace(c runtime.Container) string {
+	spec, err := c.Spec()
+	if err != nil {
+		return ""
+	}
+	for _, ns := range spec.Linux.Namespaces {
+		if ns.Type != "network" || ns.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(ns.Path); os.IsNotExist(err) {
+			return ns.Path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+func TestCheckStateDirsAndRecords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-check-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.db = openTestDB(t, dir)
+	defer s.db.Close()
+	s.containers = make(map[string]*containerInfo)
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(runtime.ContainersBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put("unloaded", []byte("{"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.saveContainerRecord(oomRestartsBucket, "unloaded", &OOMRestartPolicy{MaxRestarts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	orphan := filepath.Join(dir, namespacesDir, "team", "orphan", runtime.InitProcessID)
+	if err := os.MkdirAll(orphan, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(orphan, "process.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// directories without processes are never reported
+	if err := os.MkdirAll(filepath.Join(dir, groupsDir, "web"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	ct := &CheckTask{}
+	if err := s.check(ct); err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[FindingKind]Finding)
+	for _, f := range ct.Report.Findings {
+		if f.Cleaned {
+			t.Fatalf("expected no finding to be cleaned but %s was", f.Kind)
+		}
+		kinds[f.Kind] = f
+	}
+	if len(kinds) != 2 || kinds[UnloadedRecord].ID != "unloaded" || kinds[OrphanStateDir].ID != "team+orphan" {
+		t.Fatalf("expected an unloaded record and an orphan state dir but received %+v", ct.Report.Findings)
+	}
+
+	ct = &CheckTask{Clean: true}
+	if err := s.check(ct); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range ct.Report.Findings {
+		if !f.Cleaned {
+			t.Fatalf("expected %s to be cleaned", f.Kind)
+		}
+	}
+	if s.hasRecord("unloaded") || len(s.containerRecords(oomRestartsBucket)) != 0 {
+		t.Fatal("expected the records of the unloaded container to be deleted")
+	}
+	if _, err := os.Stat(filepath.Dir(orphan)); !os.IsNotExist(err) {
+		t.Fatalf("expected the orphan state dir to be removed but received %v", err)
+	}
+	ct = &CheckTask{}
+	if err := s.check(ct); err != nil {
+		t.Fatal(err)
+	}
+	if len(ct.Report.Findings) != 0 {
+		t.Fatalf("expected no findings after cleaning but received %+v", ct.Report.Findings)
+	}
+}
@@ -0,0 +1,7 @@
+package supervisor
+
+import "github.com/docker/containerd/runtime"
+
+func (s *Supervisor) missingNetworkNamespace(c runtime.Container) string {
+	return ""
+}
@@ -11,10 +11,16 @@ type CreateCheckpointTask struct {
 }
 
 func (s *Supervisor) createCheckpoint(t *CreateCheckpointTask) error {
+	if !s.capabilities.CRIU {
+		return ErrCRIUNotFound
+	}
 	i, ok := s.containers[t.ID]
 	if !ok {
 		return ErrContainerNotFound
 	}
+	if err := s.checkCheckpointQuota(t.ID); err != nil {
+		return err
+	}
 	return i.container.Checkpoint(*t.Checkpoint)
 }
 
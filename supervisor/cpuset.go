@@ -0,0 +1,246 @@
+package supervisor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+const (
+	// CPUSetPolicyNone leaves the cpusets of containers untouched
+	CPUSetPolicyNone = "none"
+	// CPUSetPolicySpread gives every container an equal, disjoint share of the
+	// host's cpus
+	CPUSetPolicySpread = "spread"
+	// CPUSetPolicyPack places all containers on the fewest cpus that provide
+	// the cpu time they are allowed to use
+	CPUSetPolicyPack = "pack"
+	// CPUSetPolicyExclusive pins every container to dedicated cpus sized by
+	// its cpu quota
+	CPUSetPolicyExclusive = "exclusive"
+
+	// CPUSetOptOutLabel excludes a container from cpuset rebalancing
+	CPUSetOptOutLabel = "containerd.cpuset-policy=none"
+)
+
+// pinnedCPUSetsBucket is the bucket of the metadata database that the cpusets
+// set by UpdateContainer are kept in
+const pinnedCPUSetsBucket = "pinned-cpusets"
+
+// cpusetBalancer re-pins the cpusets of running containers according to its
+// policy as containers come and go
+type cpusetBalancer struct {
+	policy string
+	// cpus are the ids of the cpus the daemon is allowed to run on
+	cpus []int
+	// assigned is the last cpuset written for each container
+	assigned map[string]string
+}
+
+func newCPUSetBalancer(policy string, cpus []int) (*cpusetBalancer, error) {
+	switch policy {
+	case "", CPUSetPolicyNone:
+		return nil, nil
+	case CPUSetPolicySpread, CPUSetPolicyPack, CPUSetPolicyExclusive:
+	default:
+		return nil, ErrInvalidCPUSetPolicy
+	}
+	return &cpusetBalancer{
+		policy:   policy,
+		cpus:     cpus,
+		assigned: make(map[string]string),
+	}, nil
+}
+
+// RebalanceCPUSetsTask re-pins the cpusets of the running containers after a
+// container started
+type RebalanceCPUSetsTask struct {
+	baseTask
+}
+
+func (s *Supervisor) rebalanceCPUSets(t *RebalanceCPUSetsTask) error {
+	if s.cpusets != nil {
+		s.cpusets.rebalance(s.containers)
+	}
+	return nil
+}
+
+func (b *cpusetBalancer) rebalance(containers map[string]*containerInfo) {
+	var (
+		ids   []string
+		needs []float64
+	)
+	for id, i := range containers {
+		need, ok := cpusetNeed(i.container)
+		// stopped containers that are kept have no cgroup and the cpuset
+		// of a container set by UpdateContainer is kept
+		if !ok || i.lifecycle.snapshot().State() == Stopped || i.pinnedCPUSet != "" {
+			delete(b.assigned, id)
+			continue
+		}
+		ids = append(ids, id)
+		needs = append(needs, need)
+	}
+	for id := range b.assigned {
+		if _, ok := containers[id]; !ok {
+			delete(b.assigned, id)
+		}
+	}
+	// keep the assignment stable for the same set of containers
+	sort.Sort(byID{ids, needs})
+	sets := assignCPUSets(b.policy, b.cpus, needs)
+	for i, id := range ids {
+		if b.assigned[id] == sets[i] {
+			continue
+		}
+		if err := containers[id].container.UpdateResources(&runtime.Resource{
+			CpusetCpus: sets[i],
+		}); err != nil {
+			log.WithFields(logrus.Fields{
+				"error":  err,
+				"id":     id,
+				"cpuset": sets[i],
+			}).Warn("containerd: rebalance container cpuset")
+			continue
+		}
+		b.assigned[id] = sets[i]
+	}
+}
+
+// assignCPUSets returns the cpuset for each container on the allowed cpus
+// where needs are the cpus worth of time each container may use
+func assignCPUSets(policy string, allowed []int, needs []float64) []string {
+	sets := make([]string, len(needs))
+	cpus := len(allowed)
+	if len(needs) == 0 || cpus == 0 {
+		return sets
+	}
+	// cpuRange formats n of the allowed cpus starting at the index start
+	cpuRange := func(start, n int) string {
+		return cpuList(allowed[start : start+n])
+	}
+	switch policy {
+	case CPUSetPolicySpread:
+		if len(needs) >= cpus {
+			for i := range needs {
+				sets[i] = cpuRange(i%cpus, 1)
+			}
+			return sets
+		}
+		share, extra := cpus/len(needs), cpus%len(needs)
+		start := 0
+		for i := range needs {
+			n := share
+			if i < extra {
+				n++
+			}
+			sets[i] = cpuRange(start, n)
+			start += n
+		}
+	case CPUSetPolicyPack:
+		var total float64
+		for _, n := range needs {
+			total += n
+		}
+		n := int(math.Ceil(total))
+		if n > cpus {
+			n = cpus
+		}
+		for i := range needs {
+			sets[i] = cpuRange(0, n)
+		}
+	case CPUSetPolicyExclusive:
+		start := 0
+		var overflow []int
+		for i, need := range needs {
+			n := int(math.Ceil(need))
+			if start+n > cpus {
+				overflow = append(overflow, i)
+				continue
+			}
+			sets[i] = cpuRange(start, n)
+			start += n
+		}
+		// containers that do not fit share the cpus that are left, or all
+		// cpus when every cpu is dedicated
+		shared := cpuRange(start, cpus-start)
+		if start == cpus {
+			shared = cpuRange(0, cpus)
+		}
+		for _, i := range overflow {
+			sets[i] = shared
+		}
+	}
+	return sets
+}
+
+// cpuList formats the sorted ids of cpus in the cpuset list format
+func cpuList(ids []int) string {
+	var ranges []string
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, fmt.Sprint(ids[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", ids[i], ids[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+// pinCPUSet keeps the cpuset set by UpdateContainer for the container so that
+// it is not rebalanced
+func (s *Supervisor) pinCPUSet(i *containerInfo, cpuset string) {
+	i.pinnedCPUSet = cpuset
+	if s.cpusets != nil {
+		delete(s.cpusets.assigned, i.container.ID())
+	}
+	if err := s.saveContainerRecord(pinnedCPUSetsBucket, i.container.ID(), cpuset); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    i.container.ID(),
+		}).Error("containerd: save pinned cpuset of container")
+	}
+}
+
+// restorePinnedCPUSet reads the cpuset set by UpdateContainer for a restored
+// container
+func (s *Supervisor) restorePinnedCPUSet(i *containerInfo) {
+	var cpuset string
+	if s.readContainerRecord(pinnedCPUSetsBucket, i.container.ID(), &cpuset) {
+		i.pinnedCPUSet = cpuset
+	}
+}
+
+// deletePinnedCPUSet removes the saved cpuset of a deleted container
+func (s *Supervisor) deletePinnedCPUSet(i *containerInfo) {
+	if i.pinnedCPUSet != "" {
+		s.deleteContainerRecord(pinnedCPUSetsBucket, i.container.ID())
+	}
+}
+
+type byID struct {
+	ids   []string
+	needs []float64
+}
+
+func (s byID) Len() int {
+	return len(s.ids)
+}
+
+func (s byID) Less(i, j int) bool {
+	return s.ids[i] < s.ids[j]
+}
+
+func (s byID) Swap(i, j int) {
+	s.ids[i], s.ids[j] = s.ids[j], s.ids[i]
+	s.needs[i], s.needs[j] = s.needs[j], s.needs[i]
+}
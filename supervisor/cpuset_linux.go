@@ -0,0 +1,32 @@
+package supervisor
+
+import "github.com/docker/containerd/runtime"
+
+// cpusetNeed returns the number of cpus worth of time the container may use
+// and false if the container does not take part in rebalancing
+func cpusetNeed(c runtime.Container) (float64, bool) {
+	if s := c.State(); s != runtime.Running && s != runtime.Paused {
+		return 0, false
+	}
+	for _, l := range c.Labels() {
+		if l == CPUSetOptOutLabel {
+			return 0, false
+		}
+	}
+	spec, err := c.Spec()
+	if err != nil {
+		return 0, false
+	}
+	if spec.Linux.Resources == nil || spec.Linux.Resources.CPU == nil {
+		return 1, true
+	}
+	cpu := spec.Linux.Resources.CPU
+	// containers pinned in their spec keep their cpus
+	if cpu.Cpus != nil && *cpu.Cpus != "" {
+		return 0, false
+	}
+	if cpu.Quota != nil && cpu.Period != nil && *cpu.Quota > 0 && *cpu.Period > 0 {
+		return float64(*cpu.Quota) / float64(*cpu.Period), true
+	}
+	return 1, true
+}
@@ -0,0 +1,39 @@
+package supervisor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignCPUSets(t *testing.T) {
+	for _, tc := range []struct {
+		policy string
+		cpus   []int
+		needs  []float64
+		sets   []string
+	}{
+		{CPUSetPolicySpread, cpuIDs(0, 8), []float64{1, 1, 1}, []string{"0-2", "3-5", "6-7"}},
+		{CPUSetPolicySpread, cpuIDs(0, 2), []float64{1, 1, 1}, []string{"0", "1", "0"}},
+		{CPUSetPolicyPack, cpuIDs(0, 8), []float64{0.5, 1, 0.25}, []string{"0-1", "0-1", "0-1"}},
+		{CPUSetPolicyPack, cpuIDs(0, 2), []float64{2, 2}, []string{"0-1", "0-1"}},
+		{CPUSetPolicyExclusive, cpuIDs(0, 4), []float64{2, 1.5, 1}, []string{"0-1", "2-3", "0-3"}},
+		{CPUSetPolicyExclusive, cpuIDs(0, 4), []float64{1, 4, 1}, []string{"0", "2-3", "1"}},
+		// the daemon's cpuset cgroup only allows some of the host's cpus
+		{CPUSetPolicySpread, []int{2, 3, 6, 7}, []float64{1, 1}, []string{"2-3", "6-7"}},
+		{CPUSetPolicyPack, []int{2, 3, 6, 7}, []float64{2.5}, []string{"2-3,6"}},
+		{CPUSetPolicyExclusive, []int{4, 5, 6}, []float64{1, 2}, []string{"4", "5-6"}},
+	} {
+		if sets := assignCPUSets(tc.policy, tc.cpus, tc.needs); !reflect.DeepEqual(sets, tc.sets) {
+			t.Errorf("%s on cpus %v for %v: expected %v but received %v", tc.policy, tc.cpus, tc.needs, tc.sets, sets)
+		}
+	}
+}
+
+// cpuIDs returns the ids of n cpus starting at start
+func cpuIDs(start, n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = start + i
+	}
+	return ids
+}
@@ -0,0 +1,8 @@
+package supervisor
+
+import "github.com/docker/containerd/runtime"
+
+// cpusetNeed returns false as cpusets are not supported on Windows
+func cpusetNeed(c runtime.Container) (float64, bool) {
+	return 0, false
+}
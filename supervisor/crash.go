@@ -0,0 +1,92 @@
+package supervisor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"time"
+)
+
+const (
+	// crashEvents is the number of recent events included in a crash report
+	crashEvents = 100
+	// crashDumpTimeout is the time a crash report waits for the event loop to
+	// report the containers
+	crashDumpTimeout = time.Second
+)
+
+// HandlePanic writes a crash report when it is deferred in a goroutine that
+// panics and then panics again with the same value so that the daemon exits
+func (s *Supervisor) HandlePanic() {
+	if r := recover(); r != nil {
+		s.writeCrashReport(r, s.Dump(crashDumpTimeout))
+		panic(r)
+	}
+}
+
+// handleLoopPanic is HandlePanic for the event loop, the loop cannot answer a
+// dump once it panicked so the containers are read directly
+func (s *Supervisor) handleLoopPanic() {
+	if r := recover(); r != nil {
+		d := s.dumpQueues()
+		t := &DumpTask{}
+		s.dump(t)
+		d.Containers = t.Containers
+		s.writeCrashReport(r, d)
+		panic(r)
+	}
+}
+
+// writeCrashReport writes the stacks of all goroutines, the supervisor's state
+// and the last events to a new file in the crash directory
+func (s *Supervisor) writeCrashReport(r interface{}, d *Dump) {
+	if s.crashDir == "" {
+		return
+	}
+	now := time.Now()
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "panic: %v\ntime: %s\n\n", r, now.Format(time.RFC3339Nano))
+	b.WriteString("goroutines:\n")
+	b.Write(stacks())
+	b.WriteString("\nstate:\n")
+	if data, err := json.MarshalIndent(d, "", "  "); err == nil {
+		b.Write(data)
+	} else {
+		fmt.Fprintf(b, "marshal state: %v", err)
+	}
+	b.WriteString("\n\nevents:\n")
+	events := s.eventLog
+	if len(events) > crashEvents {
+		events = events[len(events)-crashEvents:]
+	}
+	enc := json.NewEncoder(b)
+	for _, e := range events {
+		enc.Encode(e)
+	}
+	path := filepath.Join(s.crashDir, fmt.Sprintf("crash-%d.log", now.UnixNano()))
+	if err := os.MkdirAll(s.crashDir, 0700); err != nil {
+		log.WithField("error", err).Error("containerd: create crash directory")
+		return
+	}
+	if err := ioutil.WriteFile(path, b.Bytes(), 0600); err != nil {
+		log.WithField("error", err).Error("containerd: write crash report")
+		return
+	}
+	log.WithField("path", path).Error("containerd: wrote crash report")
+}
+
+// stacks returns the stacks of all goroutines
+func stacks() []byte {
+	buf := make([]byte, 1<<20)
+	for {
+		n := goruntime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -1,11 +1,21 @@
 package supervisor
 
 import (
+	"os"
+	"path/filepath"
+	"syscall"
 	"time"
 
+	"github.com/docker/containerd/hooks"
+	"github.com/docker/containerd/logger"
 	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/volumes"
 )
 
+// SeccompArchitecturesOptOutLabel keeps the architectures of a container's
+// seccomp filter as they are in its spec
+const SeccompArchitecturesOptOutLabel = "containerd.seccomp-architectures=none"
+
 type StartTask struct {
 	baseTask
 	platformStartTask
@@ -16,18 +26,221 @@ type StartTask struct {
 	Stdin         string
 	StartResponse chan StartResponse
 	Labels        []string
+	LogConfig     runtime.LogConfig
+	StdinOnce     bool
+	StdioSocket   bool
+	NUMA          runtime.NUMAConfig
+	Network       runtime.NetworkConfig
+	// CgroupNamespace adds a new cgroup namespace to the bundle's spec
+	CgroupNamespace bool
+	// Group is the group whose namespaces the container joins, the bundle's
+	// spec is updated with the paths of the group's namespaces
+	Group string
+	// NetworkNamespace is the named network namespace the container joins,
+	// the bundle's spec is updated with its path
+	NetworkNamespace string
+	// Volumes are mounted by their drivers before the task is sent and bind
+	// mounted in the bundle's spec
+	Volumes []volumes.Volume
+	// GPUs are the ids of the host's gpus or runtime.AllGPUs, their devices
+	// are added to the bundle's spec
+	GPUs []string
+	// Peer is the client creating the container, passed to the pre-create
+	// plugins
+	Peer *hooks.Peer
+	// Keep keeps the container after its init process exits until it is
+	// deleted with a RemoveTask
+	Keep bool
+	// AutoRemove removes the bundle with the container when its init
+	// process exits
+	AutoRemove bool
+	// NetworkWait delays the start of the user process until the
+	// container's network namespace has an address, for at most this long
+	NetworkWait time.Duration
+	// Init runs the bundle's process under containerd-init which reaps
+	// zombies and forwards signals to it
+	Init bool
+	// Clone is set when the bundle was created from a template by
+	// CloneTemplate, it is removed if the container is not created
+	Clone bool
+	// MaxRuntime stops the container with MaxRuntimeSignal once it ran for
+	// this long, it is killed if it did not exit within DefaultStopTimeout
+	MaxRuntime       time.Duration
+	MaxRuntimeSignal syscall.Signal
+	// OOMRestart restarts the container when its init process was killed
+	// after it ran out of memory
+	OOMRestart *OOMRestartPolicy
+	// rollback undoes the completed steps of creating the container when a
+	// later step fails
+	rollback *rollback
 }
 
-func (s *Supervisor) start(t *StartTask) error {
+func (s *Supervisor) start(t *StartTask) (err error) {
 	start := time.Now()
-	container, err := runtime.New(s.stateDir, t.ID, t.BundlePath, s.runtime, s.runtimeArgs, t.Labels)
-	if err != nil {
+	defer func() {
+		// the steps of a container that was created are undone by the
+		// worker if the container fails to start
+		if err != nil && err != errDeferedResponse {
+			t.rollback.run()
+		}
+	}()
+	if t.LogConfig.Driver != "" {
+		if err := logger.Validate(t.LogConfig.Driver, t.LogConfig.Options); err != nil {
+			return err
+		}
+	}
+	if t.LogConfig.Path != "" && !filepath.IsAbs(t.LogConfig.Path) {
+		return ErrLogPathNotAbs
+	}
+	switch t.LogConfig.Mode {
+	case "", runtime.LogModeBlocking, runtime.LogModeNonBlocking:
+	default:
+		return ErrInvalidLogMode
+	}
+	if err := runtime.ValidateNUMA(t.NUMA); err != nil {
+		return err
+	}
+	if err := runtime.ValidateNetwork(t.Network); err != nil {
+		return err
+	}
+	if t.Keep && t.AutoRemove {
+		return ErrAutoRemoveKept
+	}
+	if err := s.validateCheckpoint(t); err != nil {
+		return err
+	}
+	if err := s.checkStartQuota(t); err != nil {
+		return err
+	}
+	if err := s.allocateVF(t); err != nil {
 		return err
 	}
-	s.containers[t.ID] = &containerInfo{
+	if err := s.allocateAddresses(t); err != nil {
+		return err
+	}
+	// the changes to the bundle's spec are undone by the bundle step of
+	// PreCreate
+	if t.CgroupNamespace {
+		if err := runtime.InjectCgroupNamespace(t.BundlePath); err != nil {
+			return stepError("cgroup-namespace", err)
+		}
+	}
+	if !containsLabel(t.Labels, SeccompArchitecturesOptOutLabel) {
+		if err := runtime.InjectSeccompArchitectures(t.BundlePath); err != nil {
+			return stepError("seccomp", err)
+		}
+	}
+	if err := runtime.InjectGPUs(t.BundlePath, t.GPUs); err != nil {
+		return stepError("gpus", err)
+	}
+	if err := s.injectOCIHooks(t); err != nil {
+		return stepError("oci-hooks", err)
+	}
+	if t.Init {
+		if err := runtime.InjectInit(t.BundlePath); err != nil {
+			return stepError("init", err)
+		}
+	}
+	if t.NetworkWait > 0 {
+		if err := runtime.InjectNetworkWait(t.BundlePath, t.NetworkWait); err != nil {
+			return stepError("network-wait", err)
+		}
+	}
+	var g *group
+	if t.Group != "" {
+		if g, err = s.joinGroup(t.Group, t.ID, t.BundlePath); err != nil {
+			return stepError("group", err)
+		}
+	}
+	var netns *runtime.NetworkNamespace
+	if t.NetworkNamespace != "" {
+		if netns, err = s.joinNetworkNamespace(t.NetworkNamespace, t.BundlePath, g); err != nil {
+			return stepError("netns", err)
+		}
+	}
+	root := s.containerRoot(t.ID)
+	if err := os.MkdirAll(root, 0711); err != nil {
+		return stepError("container", err)
+	}
+	container, err := runtime.New(s.db, runtime.ContainerOpts{
+		Root:        root,
+		ID:          t.ID,
+		Bundle:      t.BundlePath,
+		Runtime:     s.runtime,
+		RuntimeArgs: s.runtimeArgs,
+		Labels:      t.Labels,
+		LogConfig:   t.LogConfig,
+		StdinOnce:   t.StdinOnce,
+		NUMA:        t.NUMA,
+		Network:     t.Network,
+		Keep:        t.Keep,
+		AutoRemove:  t.AutoRemove,
+	})
+	if err != nil {
+		return stepError("container", err)
+	}
+	t.undo("container", container.Delete)
+	if err := s.saveVolumes(t.ID, t.Volumes); err != nil {
+		return stepError("volumes-record", err)
+	}
+	t.undo("volumes-record", func() error {
+		s.deleteContainerRecord(volumesBucket, t.ID)
+		return nil
+	})
+	if g != nil {
+		if err := g.sandbox.Add(t.ID); err != nil {
+			return stepError("group-member", err)
+		}
+		t.undo("group-member", func() error {
+			s.leaveGroup(t.ID)
+			return nil
+		})
+	}
+	if netns != nil {
+		if err := netns.Add(t.ID); err != nil {
+			return stepError("netns-member", err)
+		}
+		t.undo("netns-member", func() error {
+			s.leaveNetworkNamespace(t.ID)
+			return nil
+		})
+	}
+	i := &containerInfo{
 		container: container,
+		lifecycle: newLifecycle(Created),
+		stdio: runtime.Stdio{
+			Stdin:  t.Stdin,
+			Stdout: t.Stdout,
+			Stderr: t.Stderr,
+			Socket: t.StdioSocket,
+		},
+	}
+	if t.MaxRuntime > 0 {
+		i.maxRuntime = &maxRuntime{
+			Limit:  t.MaxRuntime,
+			Signal: t.MaxRuntimeSignal,
+		}
 	}
+	if t.OOMRestart != nil {
+		i.oomRestart = t.OOMRestart
+		if err := s.saveContainerRecord(oomRestartsBucket, t.ID, t.OOMRestart); err != nil {
+			return stepError("oom-restart", err)
+		}
+		t.undo("oom-restart", func() error {
+			s.deleteContainerRecord(oomRestartsBucket, t.ID)
+			return nil
+		})
+	}
+	s.containers[t.ID] = i
 	ContainersCounter.Inc(1)
+	i.lifecycle.transition(Starting)
+	s.startMaxRuntime(i)
+	t.undo("register", func() error {
+		delete(s.containers, t.ID)
+		ContainersCounter.Dec(1)
+		s.deleteMaxRuntime(i)
+		return nil
+	})
 	task := &startTask{
 		Err:           t.ErrorCh(),
 		Container:     container,
@@ -35,6 +248,10 @@ func (s *Supervisor) start(t *StartTask) error {
 		Stdin:         t.Stdin,
 		Stdout:        t.Stdout,
 		Stderr:        t.Stderr,
+		StdioSocket:   t.StdioSocket,
+		Ctx:           t.Context(),
+		Lifecycle:     i.lifecycle,
+		Rollback:      t.rollback,
 	}
 	task.setTaskCheckpoint(t)
 
@@ -42,3 +259,12 @@ func (s *Supervisor) start(t *StartTask) error {
 	ContainerCreateTimer.UpdateSince(start)
 	return errDeferedResponse
 }
+
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
@@ -11,3 +11,12 @@ func (task *startTask) setTaskCheckpoint(t *StartTask) {
 		task.Checkpoint = t.Checkpoint.Name
 	}
 }
+
+// validateCheckpoint returns an error if the task restores a checkpoint and
+// criu was not found
+func (s *Supervisor) validateCheckpoint(t *StartTask) error {
+	if t.Checkpoint != nil && !s.capabilities.CRIU {
+		return ErrCRIUNotFound
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package supervisor
 
 import (
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/hooks"
 	"github.com/docker/containerd/runtime"
 )
 
@@ -13,14 +16,43 @@ type DeleteTask struct {
 	Status  int
 	PID     string
 	NoEvent bool
+	// Keep releases the runtime's container of a container that is kept
+	// after its init process exited instead of deleting it
+	Keep bool
+	// Rollback undoes the steps of creating a container that failed to
+	// start instead of deleting it
+	Rollback *rollback
 }
 
 func (s *Supervisor) delete(t *DeleteTask) error {
 	if i, ok := s.containers[t.ID]; ok {
 		start := time.Now()
-		if err := s.deleteContainer(i.container); err != nil {
-			logrus.WithField("error", err).Error("containerd: deleting container")
+		stopMaxRuntime(i)
+		if t.Rollback != nil {
+			t.Rollback.run()
+			s.stopped(i, t.Status)
+			return nil
 		}
+		s.restartAfterOOM(i, t.Status)
+		if i.restart != nil {
+			s.restarted(i)
+			return nil
+		}
+		if t.Keep {
+			if err := i.container.Release(); err != nil {
+				log.WithFields(logrus.Fields{
+					"error": err,
+					"id":    t.ID,
+				}).Error("containerd: releasing stopped container")
+			}
+			i.exitStatus = t.Status
+		} else {
+			s.removeContainer(i, t.Status)
+			if i.container.AutoRemove() {
+				s.removeBundle(i.container)
+			}
+		}
+		s.stopped(i, t.Status)
 		if !t.NoEvent {
 			s.notifySubscribers(Event{
 				Type:      "exit",
@@ -30,13 +62,92 @@ func (s *Supervisor) delete(t *DeleteTask) error {
 				PID:       t.PID,
 			})
 		}
-		ContainersCounter.Dec(1)
 		ContainerDeleteTimer.UpdateSince(start)
 	}
 	return nil
 }
 
+// RemoveTask deletes a stopped container that was kept after its init
+// process exited
+type RemoveTask struct {
+	baseTask
+	ID string
+}
+
+func (s *Supervisor) remove(t *RemoveTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if i.lifecycle.snapshot().State() != Stopped {
+		return ErrContainerNotStopped
+	}
+	s.removeContainer(i, i.exitStatus)
+	s.notifySubscribers(Event{
+		Type:      "delete",
+		Timestamp: time.Now(),
+		ID:        t.ID,
+		Status:    i.exitStatus,
+	})
+	return nil
+}
+
+// removeContainer deletes the container and calls the post-delete plugins
+// with the exit status of its init process
+func (s *Supervisor) removeContainer(i *containerInfo, status int) {
+	if err := s.deleteContainer(i.container); err != nil {
+		log.WithField("error", err).Error("containerd: deleting container")
+	}
+	if s.hooks.Enabled() {
+		// the plugins are called outside of the event loop
+		r := hookRequest(i.container)
+		r.Status = status
+		go s.hooks.Notify(hooks.PostDelete, r)
+	}
+	ContainersCounter.Dec(1)
+	if s.cpusets != nil {
+		s.cpusets.rebalance(s.containers)
+	}
+}
+
 func (s *Supervisor) deleteContainer(container runtime.Container) error {
+	if i, ok := s.containers[container.ID()]; ok {
+		s.deleteMaxRuntime(i)
+		s.deleteOOMRestart(i)
+		s.deletePinnedCPUSet(i)
+	}
 	delete(s.containers, container.ID())
-	return container.Delete()
+	s.leaveGroup(container.ID())
+	s.leaveNetworkNamespace(container.ID())
+	err := container.Delete()
+	s.deleteVolumes(container.ID())
+	s.releaseAddresses(container)
+	s.removeClone(container)
+	return err
+}
+
+// removeBundle removes the bundle of a container removed on exit outside of
+// the event loop.  The bundle is leased to every container created from it,
+// it is kept while another container uses it.
+func (s *Supervisor) removeBundle(c runtime.Container) {
+	bundle := filepath.Clean(c.Path())
+	for _, i := range s.containers {
+		if filepath.Clean(i.container.Path()) == bundle {
+			log.WithFields(logrus.Fields{
+				"id":     c.ID(),
+				"bundle": bundle,
+				"user":   i.container.ID(),
+			}).Debug("containerd: bundle is used by another container")
+			return
+		}
+	}
+	go func() {
+		if err := os.RemoveAll(bundle); err != nil {
+			log.WithFields(logrus.Fields{
+				"error":  err,
+				"id":     c.ID(),
+				"bundle": bundle,
+			}).Error("containerd: remove bundle")
+		}
+	}()
 }
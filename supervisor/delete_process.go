@@ -0,0 +1,68 @@
+package supervisor
+
+import (
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// DeleteProcessTask kills an exec process and removes it from its container
+// without waiting for its shim to report the exit, so that a session that
+// hung can be cleaned up
+type DeleteProcessTask struct {
+	baseTask
+	ID  string
+	PID string
+	// Status is the exit status of the process, 137 when it did not exit
+	Status int
+}
+
+func (s *Supervisor) deleteProcess(t *DeleteProcessTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if t.PID == runtime.InitProcessID {
+		return ErrInitProcess
+	}
+	processes, err := i.container.Processes()
+	if err != nil {
+		return err
+	}
+	var proc runtime.Process
+	for _, p := range processes {
+		if p.ID() == t.PID {
+			proc = p
+		}
+	}
+	if proc == nil {
+		return ErrProcessNotFound
+	}
+	if err := proc.Signal(syscall.SIGKILL); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    t.ID,
+			"pid":   t.PID,
+		}).Warn("containerd: kill deleted process")
+	}
+	status, err := proc.ExitStatus()
+	if err != nil {
+		status = 128 + int(syscall.SIGKILL)
+	}
+	// the exit of the process is ignored by execExit once it is removed
+	s.monitor.Remove(proc)
+	if err := i.container.RemoveProcess(t.PID); err != nil {
+		return err
+	}
+	t.Status = status
+	s.notifySubscribers(Event{
+		Timestamp: time.Now(),
+		ID:        t.ID,
+		Type:      "exit",
+		PID:       t.PID,
+		Status:    status,
+	})
+	return nil
+}
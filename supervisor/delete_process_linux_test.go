@@ -0,0 +1,113 @@
+package supervisor
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// hungProcess is an exec process that does not exit when it is killed
+type hungProcess struct {
+	pipeProcess
+	container runtime.Container
+	signals   []os.Signal
+}
+
+func (p *hungProcess) Signal(s os.Signal) error {
+	p.signals = append(p.signals, s)
+	return nil
+}
+
+func (p *hungProcess) ExitStatus() (int, error) {
+	return -1, runtime.ErrProcessNotExited
+}
+
+func (p *hungProcess) Container() runtime.Container {
+	return p.container
+}
+
+// processContainer is a container with a set of processes, the methods it
+// does not implement panic
+type processContainer struct {
+	runtime.Container
+	processes map[string]runtime.Process
+}
+
+func (c *processContainer) ID() string {
+	return "c"
+}
+
+func (c *processContainer) Processes() ([]runtime.Process, error) {
+	var out []runtime.Process
+	for _, p := range c.processes {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (c *processContainer) RemoveProcess(pid string) error {
+	delete(c.processes, pid)
+	return nil
+}
+
+func TestDeleteProcess(t *testing.T) {
+	// the monitor is not closed as closing its epoll fd while it waits on
+	// it makes it exit the tests that follow
+	m, err := NewMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	c := &processContainer{processes: make(map[string]runtime.Process)}
+	p := &hungProcess{pipeProcess: pipeProcess{testProcess: testProcess{"exec"}, r: r, w: w}, container: c}
+	c.processes["exec"] = p
+	if err := m.Monitor(p); err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSupervisor()
+	s.monitor = m
+	s.containers = map[string]*containerInfo{
+		"c": {container: c, lifecycle: newLifecycle(Running)},
+	}
+	events := s.Events(time.Time{})
+	defer s.Unsubscribe(events)
+
+	if err := s.deleteProcess(&DeleteProcessTask{ID: "c", PID: runtime.InitProcessID}); err != ErrInitProcess {
+		t.Fatalf("expected %v when deleting the init process but received %v", ErrInitProcess, err)
+	}
+	task := &DeleteProcessTask{ID: "c", PID: "exec"}
+	if err := s.deleteProcess(task); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.signals) != 1 || p.signals[0] != syscall.SIGKILL {
+		t.Fatalf("expected SIGKILL to be sent to the process but received %v", p.signals)
+	}
+	if task.Status != 137 {
+		t.Fatalf("expected the status 137 but received %d", task.Status)
+	}
+	if _, ok := c.processes["exec"]; ok {
+		t.Fatal("expected the process to be removed from the container")
+	}
+	if m.Remove(p) {
+		t.Fatal("expected the process to not be monitored after it was deleted")
+	}
+	if e := <-events; e.Type != "exit" || e.PID != "exec" || e.Status != 137 {
+		t.Fatalf("expected the exit event of the process but received %+v", e)
+	}
+	// the exit reported by the shim afterwards is ignored
+	if err := s.execExit(&ExecExitTask{ID: "c", PID: "exec", Status: 0, Process: p}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("expected no event for the deleted process but received %+v", e)
+	default:
+	}
+}
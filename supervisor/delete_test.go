@@ -0,0 +1,73 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// bundleContainer is a container removed on exit, the methods it does not
+// implement panic
+type bundleContainer struct {
+	runtime.Container
+	id     string
+	bundle string
+}
+
+func (c *bundleContainer) ID() string {
+	return c.id
+}
+
+func (c *bundleContainer) Path() string {
+	return c.bundle
+}
+
+func (c *bundleContainer) AutoRemove() bool {
+	return true
+}
+
+func (c *bundleContainer) Delete() error {
+	return nil
+}
+
+func TestAutoRemoveBundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-delete-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bundle, err := ioutil.TempDir(dir, "bundle-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.db = openTestDB(t, dir)
+	s.containers = map[string]*containerInfo{
+		"a": {container: &bundleContainer{id: "a", bundle: bundle}, lifecycle: newLifecycle(Running)},
+		"b": {container: &bundleContainer{id: "b", bundle: bundle}, lifecycle: newLifecycle(Running)},
+	}
+	if err := s.delete(&DeleteTask{ID: "a", PID: runtime.InitProcessID}); err != nil {
+		t.Fatal(err)
+	}
+	// give a removal of the bundle time to happen
+	time.Sleep(50 * time.Millisecond)
+	if _, err := os.Stat(bundle); err != nil {
+		t.Fatalf("expected the bundle used by b to be kept but received %v", err)
+	}
+	if err := s.delete(&DeleteTask{ID: "b", PID: runtime.InitProcessID}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		if _, err := os.Stat(bundle); os.IsNotExist(err) {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected the bundle to be removed with the last container using it")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,145 @@
+package supervisor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// Dump is a snapshot of the supervisor's internal state used to debug a daemon
+// that appears wedged
+type Dump struct {
+	Tasks      Queue
+	StartTasks Queue
+	Exits      Queue
+	OOMs       Queue
+	// Subscribers are the event queues of the subscribers
+	Subscribers []Queue
+	// CurrentTask is the task being handled by the event loop and empty when
+	// the event loop is idle
+	CurrentTask        string
+	CurrentTaskStarted time.Time
+	// Blocked is true when the event loop did not handle the dump before the
+	// timeout, Containers is empty in that case
+	Blocked    bool
+	Containers []ContainerDump
+}
+
+// Queue is the number of items waiting in a queue and its size
+type Queue struct {
+	Length   int
+	Capacity int
+}
+
+// ContainerDump is the state of a container known to the supervisor
+type ContainerDump struct {
+	ID        string
+	Bundle    string
+	Status    runtime.State
+	Labels    []string
+	CPUSet    string
+	Processes []ProcessDump
+}
+
+// ProcessDump is the state of a process known to the supervisor
+type ProcessDump struct {
+	ID        string
+	SystemPid int
+	Status    runtime.State
+}
+
+// currentTask records the task being handled by the event loop
+type currentTask struct {
+	mu      sync.Mutex
+	name    string
+	started time.Time
+}
+
+func (c *currentTask) set(t Task) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t == nil {
+		c.name, c.started = "", time.Time{}
+		return
+	}
+	c.name, c.started = taskName(t), time.Now()
+}
+
+func (c *currentTask) get() (string, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.name, c.started
+}
+
+type DumpTask struct {
+	baseTask
+	Containers []ContainerDump
+}
+
+// Dump returns a snapshot of the supervisor's state.  The queues are read
+// directly so that they are reported even when the event loop is stuck, the
+// containers are collected by the event loop if it answers within the timeout.
+func (s *Supervisor) Dump(timeout time.Duration) *Dump {
+	d := s.dumpQueues()
+	t := &DumpTask{}
+	t.enqueued(time.Now())
+	deadline := time.After(timeout)
+	select {
+	case s.tasks <- t:
+		TasksCounter.Inc(1)
+	case <-deadline:
+		d.Blocked = true
+		return d
+	}
+	select {
+	case <-t.ErrorCh():
+		d.Containers = t.Containers
+	case <-deadline:
+		d.Blocked = true
+	}
+	return d
+}
+
+// dumpQueues returns the state of the supervisor that is safe to read outside
+// of the event loop
+func (s *Supervisor) dumpQueues() *Dump {
+	d := &Dump{
+		Tasks:      Queue{len(s.tasks), cap(s.tasks)},
+		StartTasks: Queue{len(s.startTasks), cap(s.startTasks)},
+		Exits:      Queue{len(s.monitor.Exits()), cap(s.monitor.Exits())},
+		OOMs:       Queue{len(s.monitor.OOMs()), cap(s.monitor.OOMs())},
+	}
+	s.subscriberLock.RLock()
+	for c := range s.subscribers {
+		d.Subscribers = append(d.Subscribers, Queue{len(c), cap(c)})
+	}
+	s.subscriberLock.RUnlock()
+	d.CurrentTask, d.CurrentTaskStarted = s.current.get()
+	return d
+}
+
+func (s *Supervisor) dump(t *DumpTask) error {
+	for id, i := range s.containers {
+		c := ContainerDump{
+			ID:     id,
+			Bundle: i.container.Path(),
+			Status: i.container.State(),
+			Labels: i.container.Labels(),
+		}
+		if s.cpusets != nil {
+			c.CPUSet = s.cpusets.assigned[id]
+		}
+		// a container that cannot list its processes is still reported
+		processes, _ := i.container.Processes()
+		for _, p := range processes {
+			c.Processes = append(c.Processes, ProcessDump{
+				ID:        p.ID(),
+				SystemPid: p.SystemPid(),
+				Status:    p.State(),
+			})
+		}
+		t.Containers = append(t.Containers, c)
+	}
+	return nil
+}
@@ -4,13 +4,50 @@ import "errors"
 
 var (
 	// External errors
-	ErrTaskChanNil            = errors.New("containerd: task channel is nil")
-	ErrBundleNotFound         = errors.New("containerd: bundle not found")
-	ErrContainerNotFound      = errors.New("containerd: container not found")
-	ErrContainerExists        = errors.New("containerd: container already exists")
-	ErrProcessNotFound        = errors.New("containerd: processs not found for container")
-	ErrUnknownContainerStatus = errors.New("containerd: unknown container status ")
-	ErrUnknownTask            = errors.New("containerd: unknown task type")
+	ErrTaskChanNil              = errors.New("containerd: task channel is nil")
+	ErrBundleNotFound           = errors.New("containerd: bundle not found")
+	ErrContainerNotFound        = errors.New("containerd: container not found")
+	ErrContainerExists          = errors.New("containerd: container already exists")
+	ErrProcessNotFound          = errors.New("containerd: processs not found for container")
+	ErrUnknownContainerStatus   = errors.New("containerd: unknown container status ")
+	ErrUnknownTask              = errors.New("containerd: unknown task type")
+	ErrInvalidLogMode           = errors.New("containerd: invalid log mode")
+	ErrLogPathNotAbs            = errors.New("containerd: log path is not an absolute path")
+	ErrInvalidCPUSetPolicy      = errors.New("containerd: invalid cpuset policy")
+	ErrShimDiedAgain            = errors.New("containerd: shim of an adopted process died")
+	ErrInvalidOverflowPolicy    = errors.New("containerd: invalid event overflow policy")
+	ErrGroupNotFound            = errors.New("containerd: group not found")
+	ErrGroupExists              = errors.New("containerd: group already exists")
+	ErrGroupDeleting            = errors.New("containerd: group is being deleted")
+	ErrGroupStarting            = errors.New("containerd: group has containers that are starting")
+	ErrGroupShared              = errors.New("containerd: group has containers of another namespace")
+	ErrCPUSetNotSupported       = errors.New("containerd: cpuset policy requires the cpuset cgroup controller to be writable")
+	ErrCRIUNotFound             = errors.New("containerd: checkpoints require criu which was not found at startup")
+	ErrBundleConfigNotFound     = errors.New("containerd: bundle has no config.json")
+	ErrBundleUploadDisabled     = errors.New("containerd: no bundle root is set for uploaded bundles")
+	ErrContainerNotStopped      = errors.New("containerd: container is not stopped")
+	ErrInitProcess              = errors.New("containerd: the init process cannot be deleted")
+	ErrAutoRemoveKept           = errors.New("containerd: a kept container cannot be removed on exit")
+	ErrContainerRestarting      = errors.New("containerd: container is restarting")
+	ErrTemplateNotFound         = errors.New("containerd: template not found")
+	ErrTemplateExists           = errors.New("containerd: template already exists")
+	ErrInvalidTemplateName      = errors.New("containerd: template names cannot be empty, start with a dot or contain a slash")
+	ErrInvalidContainerID       = errors.New("containerd: ids of containers created from templates cannot start with a dot or contain a slash")
+	ErrBackupVersion            = errors.New("containerd: not a backup archive or a backup of a newer version")
+	ErrFormatTooNew             = errors.New("containerd: state directory was written by a newer version of containerd")
+	ErrBundleMissing            = errors.New("containerd: bundle of the container is missing or has no config.json")
+	ErrLeaseNotFound            = errors.New("containerd: lease not found or expired")
+	ErrLeaseExists              = errors.New("containerd: lease already exists")
+	ErrInvalidLeaseID           = errors.New("containerd: lease id cannot be empty")
+	ErrInvalidLeaseTTL          = errors.New("containerd: lease ttl must be positive")
+	ErrQuotaLimitRequired       = errors.New("containerd: containers of a namespace with a memory or cpu quota must set a memory limit or a cpu quota")
+	ErrPhysicalFunctionNotFound = errors.New("containerd: SR-IOV physical function is not configured with --sriov-pf")
+	ErrNoFreeVirtualFunction    = errors.New("containerd: all the virtual functions of the SR-IOV physical functions are allocated")
+	ErrNetworkNamespaceNotFound = errors.New("containerd: named network namespace not found")
+	ErrNetworkNamespaceExists   = errors.New("containerd: named network namespace already exists")
+	ErrNetworkNamespaceInUse    = errors.New("containerd: named network namespace has containers")
+	ErrInvalidNetnsName         = errors.New("containerd: network namespace names are letters, digits, dots, dashes and underscores starting with a letter or a digit")
+	ErrGroupSharesNetwork       = errors.New("containerd: the group of the container shares a network namespace already")
 
 	// Internal errors
 	errShutdown          = errors.New("containerd: supervisor is shutdown")
@@ -16,15 +16,18 @@ func (s *Supervisor) exit(t *ExitTask) error {
 	start := time.Now()
 	proc := t.Process
 	status, err := proc.ExitStatus()
+	if shimDied(proc, err) {
+		return s.recoverShim(proc)
+	}
 	if err != nil {
-		logrus.WithFields(logrus.Fields{
+		log.WithFields(logrus.Fields{
 			"error":     err,
 			"pid":       proc.ID(),
 			"id":        proc.Container().ID(),
 			"systemPid": proc.SystemPid(),
 		}).Error("containerd: get exit status")
 	}
-	logrus.WithFields(logrus.Fields{
+	log.WithFields(logrus.Fields{
 		"pid":       proc.ID(),
 		"status":    status,
 		"id":        proc.Container().ID(),
@@ -44,10 +47,14 @@ func (s *Supervisor) exit(t *ExitTask) error {
 		return nil
 	}
 	container := proc.Container()
+	if i, ok := s.containers[container.ID()]; ok {
+		i.lifecycle.transition(Stopped)
+	}
 	ne := &DeleteTask{
 		ID:     container.ID(),
 		Status: status,
 		PID:    proc.ID(),
+		Keep:   container.Keep(),
 	}
 	s.SendTask(ne)
 
@@ -66,9 +73,13 @@ type ExecExitTask struct {
 
 func (s *Supervisor) execExit(t *ExecExitTask) error {
 	container := t.Process.Container()
+	if !hasProcess(container, t.Process) {
+		// the process was deleted and its exit event sent already
+		return nil
+	}
 	// exec process: we remove this process without notifying the main event loop
 	if err := container.RemoveProcess(t.PID); err != nil {
-		logrus.WithField("error", err).Error("containerd: find container for pid")
+		log.WithField("error", err).Error("containerd: find container for pid")
 	}
 	s.notifySubscribers(Event{
 		Timestamp: time.Now(),
@@ -79,3 +90,17 @@ func (s *Supervisor) execExit(t *ExecExitTask) error {
 	})
 	return nil
 }
+
+// hasProcess returns true if the process was not removed from the container
+func hasProcess(c runtime.Container, proc runtime.Process) bool {
+	processes, err := c.Processes()
+	if err != nil {
+		return false
+	}
+	for _, p := range processes {
+		if p == proc {
+			return true
+		}
+	}
+	return false
+}
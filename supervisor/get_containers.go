@@ -4,21 +4,32 @@ import "github.com/docker/containerd/runtime"
 
 type GetContainersTask struct {
 	baseTask
-	ID         string
+	ID string
+	// Namespace restricts the containers returned without an ID to the
+	// containers of the namespace
+	Namespace  string
 	Containers []runtime.Container
+	// Lifecycles are the lifecycles of the containers by ID
+	Lifecycles map[string]Lifecycle
 }
 
 func (s *Supervisor) getContainers(t *GetContainersTask) error {
+	t.Lifecycles = make(map[string]Lifecycle)
 	if t.ID != "" {
 		ci := s.containers[t.ID]
 		if ci == nil {
 			return ErrContainerNotFound
 		}
 		t.Containers = append(t.Containers, ci.container)
+		t.Lifecycles[t.ID] = ci.lifecycle.snapshot()
 		return nil
 	}
-	for _, i := range s.containers {
+	for id, i := range s.containers {
+		if !inNamespace(id, t.Namespace) {
+			continue
+		}
 		t.Containers = append(t.Containers, i.container)
+		t.Lifecycles[id] = i.lifecycle.snapshot()
 	}
 	return nil
 }
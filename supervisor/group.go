@@ -0,0 +1,229 @@
+package supervisor
+
+import (
+	"path/filepath"
+	"syscall"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// groupsDir is the directory in the state directory that holds the sandboxes
+// of groups
+const groupsDir = "groups"
+
+// group is a set of containers sharing the namespaces of a sandbox holder
+type group struct {
+	sandbox *runtime.Sandbox
+	// deleting groups accept no new containers, the sandbox is removed with
+	// the group's last container
+	deleting bool
+}
+
+// GroupInfo is the state of a group
+type GroupInfo struct {
+	ID         string
+	Namespaces []string
+	Pid        int
+	Containers []string
+	Running    bool
+	Deleting   bool
+}
+
+func (g *group) info() GroupInfo {
+	return GroupInfo{
+		ID:         g.sandbox.ID,
+		Namespaces: g.sandbox.Namespaces,
+		Pid:        g.sandbox.Pid,
+		Containers: append([]string(nil), g.sandbox.Containers...),
+		Running:    g.sandbox.Running(),
+		Deleting:   g.deleting,
+	}
+}
+
+// CreateGroupTask starts a sandbox holder for a new group whose containers
+// share the holder's namespaces
+type CreateGroupTask struct {
+	baseTask
+	ID         string
+	Namespaces []string
+	Group      GroupInfo
+}
+
+func (s *Supervisor) createGroup(t *CreateGroupTask) error {
+	if _, ok := s.groups[t.ID]; ok {
+		return ErrGroupExists
+	}
+	sb, err := runtime.NewSandbox(filepath.Join(s.stateDir, groupsDir), t.ID, t.Namespaces)
+	if err != nil {
+		return err
+	}
+	g := &group{
+		sandbox: sb,
+	}
+	s.addGroup(g)
+	t.Group = g.info()
+	return nil
+}
+
+func (s *Supervisor) addGroup(g *group) {
+	if s.groups == nil {
+		s.groups = make(map[string]*group)
+	}
+	s.groups[g.sandbox.ID] = g
+}
+
+// DeleteGroupTask kills the containers of a group, the group's sandbox is
+// removed once all of its containers are deleted
+type DeleteGroupTask struct {
+	baseTask
+	ID string
+	// Killed are the IDs of the containers that were killed
+	Killed []string
+}
+
+func (s *Supervisor) deleteGroup(t *DeleteGroupTask) error {
+	g, ok := s.groups[t.ID]
+	if !ok {
+		return ErrGroupNotFound
+	}
+	namespace, _ := SplitID(t.ID)
+	for _, id := range g.sandbox.Containers {
+		// a group created before groups were namespaced can have the
+		// containers of other namespaces, they are not killed for a caller
+		// of the group's namespace
+		if !inNamespace(id, namespace) {
+			return ErrGroupShared
+		}
+		// containers that are still starting would be started after they
+		// were killed
+		if i, ok := s.containers[id]; ok && i.lifecycle.snapshot().State() == Starting {
+			return ErrGroupStarting
+		}
+	}
+	g.deleting = true
+	for _, id := range g.sandbox.Containers {
+		i, ok := s.containers[id]
+		if !ok {
+			continue
+		}
+		processes, err := i.container.Processes()
+		if err != nil {
+			return err
+		}
+		for _, p := range processes {
+			if p.ID() != runtime.InitProcessID {
+				continue
+			}
+			if err := p.Signal(syscall.SIGKILL); err != nil {
+				return err
+			}
+			i.lifecycle.transition(Stopping)
+			t.Killed = append(t.Killed, id)
+		}
+	}
+	if len(g.sandbox.Containers) == 0 {
+		return s.removeGroup(g)
+	}
+	return nil
+}
+
+func (s *Supervisor) removeGroup(g *group) error {
+	delete(s.groups, g.sandbox.ID)
+	return g.sandbox.Delete()
+}
+
+// GetGroupsTask returns the groups or only the group with the ID
+type GetGroupsTask struct {
+	baseTask
+	ID     string
+	Groups []GroupInfo
+}
+
+func (s *Supervisor) getGroups(t *GetGroupsTask) error {
+	if t.ID != "" {
+		g, ok := s.groups[t.ID]
+		if !ok {
+			return ErrGroupNotFound
+		}
+		t.Groups = append(t.Groups, g.info())
+		return nil
+	}
+	for _, g := range s.groups {
+		t.Groups = append(t.Groups, g.info())
+	}
+	return nil
+}
+
+// joinGroup sets the namespaces of the group's sandbox in the bundle's spec
+// of the container, only the groups of the container's namespace are joined
+func (s *Supervisor) joinGroup(id, container, bundle string) (*group, error) {
+	g, ok := s.groups[id]
+	if namespace, _ := SplitID(id); !ok || !inNamespace(container, namespace) {
+		return nil, ErrGroupNotFound
+	}
+	if g.deleting {
+		return nil, ErrGroupDeleting
+	}
+	if err := runtime.JoinSandbox(bundle, g.sandbox); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// leaveGroup removes the container from its group and removes the group's
+// sandbox when it was the last container of a deleted group
+func (s *Supervisor) leaveGroup(id string) {
+	for _, g := range s.groups {
+		if !g.sandbox.Contains(id) {
+			continue
+		}
+		if err := g.sandbox.Remove(id); err != nil {
+			log.WithField("error", err).Error("containerd: remove container from group")
+		}
+		if g.deleting && len(g.sandbox.Containers) == 0 {
+			if err := s.removeGroup(g); err != nil {
+				log.WithField("error", err).Error("containerd: remove group sandbox")
+			}
+		}
+		return
+	}
+}
+
+// containersOfGroup returns the IDs of the group's containers
+func (s *Supervisor) containersOfGroup(id string) ([]string, error) {
+	g, ok := s.groups[id]
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	return g.sandbox.Containers, nil
+}
+
+// restoreGroups loads the sandboxes of the groups, it is called before the
+// containers are restored
+func (s *Supervisor) restoreGroups() error {
+	sandboxes, err := runtime.LoadSandboxes(filepath.Join(s.stateDir, groupsDir))
+	if err != nil {
+		return err
+	}
+	for _, sb := range sandboxes {
+		if !sb.Running() {
+			log.WithField("id", sb.ID).Warn("containerd: sandbox holder of group is not running")
+		}
+		s.addGroup(&group{
+			sandbox: sb,
+		})
+	}
+	return nil
+}
+
+// pruneGroups removes the containers that were not restored from their
+// groups
+func (s *Supervisor) pruneGroups() {
+	for _, g := range s.groups {
+		for _, id := range append([]string(nil), g.sandbox.Containers...) {
+			if _, ok := s.containers[id]; !ok {
+				s.leaveGroup(id)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeGroupState(t *testing.T, dir, id, state string) {
+	path := filepath.Join(dir, groupsDir, id)
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "state.json"), []byte(state), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGroupRemovedWithLastContainer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeGroupState(t, dir, "g1", `{"id":"g1","namespaces":["network"],"containers":["a","b","gone"]}`)
+	s := &Supervisor{
+		stateDir:   dir,
+		containers: map[string]*containerInfo{"a": {}, "b": {}},
+	}
+	if err := s.restoreGroups(); err != nil {
+		t.Fatal(err)
+	}
+	s.pruneGroups()
+	ids, err := s.containersOfGroup("g1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+		t.Fatalf("expected the containers a and b but received %v", ids)
+	}
+	if _, err := s.joinGroup("missing", "a", dir); err != ErrGroupNotFound {
+		t.Fatalf("expected %v but received %v", ErrGroupNotFound, err)
+	}
+	s.groups["g1"].deleting = true
+	if _, err := s.joinGroup("g1", "a", dir); err != ErrGroupDeleting {
+		t.Fatalf("expected %v but received %v", ErrGroupDeleting, err)
+	}
+	s.leaveGroup("a")
+	if _, ok := s.groups["g1"]; !ok {
+		t.Fatal("group removed before its last container")
+	}
+	s.leaveGroup("b")
+	if _, ok := s.groups["g1"]; ok {
+		t.Fatal("group not removed with its last container")
+	}
+	if _, err := os.Stat(filepath.Join(dir, groupsDir, "g1")); !os.IsNotExist(err) {
+		t.Fatalf("expected the group's state to be removed but received %v", err)
+	}
+}
+
+func TestGroupOfAnotherNamespace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// a group created before groups were namespaced has the containers of
+	// several namespaces
+	writeGroupState(t, dir, "shared", `{"id":"shared","namespaces":["network"],"containers":["a","ci+b"]}`)
+	writeGroupState(t, dir, "ci+g", `{"id":"ci+g","namespaces":["network"],"containers":["ci+c"]}`)
+	s := &Supervisor{
+		stateDir: dir,
+		containers: map[string]*containerInfo{
+			"a":    {lifecycle: newLifecycle(Running)},
+			"ci+b": {lifecycle: newLifecycle(Running)},
+			"ci+c": {lifecycle: newLifecycle(Running)},
+		},
+	}
+	if err := s.restoreGroups(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.deleteGroup(&DeleteGroupTask{ID: "shared"}); err != ErrGroupShared {
+		t.Fatalf("expected %v but received %v", ErrGroupShared, err)
+	}
+	if s.groups["shared"].deleting {
+		t.Fatal("expected the shared group to not be deleted")
+	}
+	if _, err := s.joinGroup("ci+g", "paas+d", dir); err != ErrGroupNotFound {
+		t.Fatalf("expected the group of another namespace to not be found but received %v", err)
+	}
+	if _, err := s.joinGroup("ci+g", "d", dir); err != ErrGroupNotFound {
+		t.Fatalf("expected the group of another namespace to not be found but received %v", err)
+	}
+}
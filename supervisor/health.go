@@ -0,0 +1,115 @@
+package supervisor
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// HealthCheck is the result of checking a part of the daemon
+type HealthCheck struct {
+	Name     string
+	Healthy  bool
+	Error    string
+	Duration time.Duration
+}
+
+type HealthTask struct {
+	baseTask
+}
+
+// Health checks that the event loop handles tasks, that a start worker picks
+// up work and that the runtime binary executes, each within the timeout
+func (s *Supervisor) Health(timeout time.Duration) []HealthCheck {
+	return []HealthCheck{
+		healthCheck("event-loop", func() error {
+			return s.checkEventLoop(timeout)
+		}),
+		healthCheck("workers", func() error {
+			return s.checkWorkers(timeout)
+		}),
+		healthCheck("runtime", func() error {
+			return s.checkRuntime(timeout)
+		}),
+	}
+}
+
+func healthCheck(name string, check func() error) HealthCheck {
+	start := time.Now()
+	err := check()
+	h := HealthCheck{
+		Name:     name,
+		Healthy:  err == nil,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		h.Error = err.Error()
+	}
+	return h
+}
+
+// Ping returns an error if the event loop does not handle a task within the
+// timeout
+func (s *Supervisor) Ping(timeout time.Duration) error {
+	return s.checkEventLoop(timeout)
+}
+
+func (s *Supervisor) checkEventLoop(timeout time.Duration) error {
+	t := &HealthTask{}
+	t.enqueued(time.Now())
+	deadline := time.After(timeout)
+	select {
+	case s.tasks <- t:
+	case <-deadline:
+		return fmt.Errorf("task queue is full with %d tasks", len(s.tasks))
+	}
+	select {
+	case err := <-t.ErrorCh():
+		return err
+	case <-deadline:
+		if name, started := s.current.get(); name != "" {
+			return fmt.Errorf("event loop is blocked handling %s for %s", name, time.Since(started))
+		}
+		return fmt.Errorf("event loop did not answer within %s", timeout)
+	}
+}
+
+func (s *Supervisor) checkWorkers(timeout time.Duration) error {
+	probe := &startTask{
+		Probe: make(chan struct{}),
+	}
+	deadline := time.After(timeout)
+	select {
+	case s.startTasks <- probe:
+	case <-deadline:
+		return fmt.Errorf("all workers are busy with %d containers waiting to start", len(s.startTasks))
+	}
+	select {
+	case <-probe.Probe:
+		return nil
+	case <-deadline:
+		return fmt.Errorf("no worker picked up work within %s", timeout)
+	}
+}
+
+// checkRuntime runs the runtime's version command
+func (s *Supervisor) checkRuntime(timeout time.Duration) error {
+	cmd := exec.Command(s.runtime, "--version")
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("%s --version: %v", s.runtime, err)
+		}
+		return nil
+	case <-time.After(timeout):
+		cmd.Process.Kill()
+		return fmt.Errorf("%s --version did not exit within %s", s.runtime, timeout)
+	}
+}
@@ -0,0 +1,87 @@
+package supervisor
+
+import (
+	"os"
+
+	"github.com/docker/containerd/hooks"
+	"github.com/docker/containerd/runtime"
+)
+
+// SetHooks sets the plugins called at points of the containers' lifecycle,
+// it must be called before the supervisor is started
+func (s *Supervisor) SetHooks(h *hooks.Hooks) {
+	s.hooks = h
+}
+
+// PreCreate keeps the bundle's spec, mounts the volumes of the task and calls
+// the pre-create plugins for its container.  It is called by the api before
+// the task is sent so that the event loop does not wait on the drivers and
+// plugins.  The completed steps are undone when a step fails.
+func (s *Supervisor) PreCreate(t *StartTask) (err error) {
+	defer func() {
+		if err != nil {
+			t.rollback.run()
+		}
+	}()
+	if !t.Clone {
+		// a bundle created from a template is removed instead
+		if err := saveBundleSpec(t); err != nil {
+			return stepError("bundle", err)
+		}
+	}
+	if err := s.mountVolumes(t); err != nil {
+		return stepError("volumes", err)
+	}
+	if err := s.hooks.PreCreate(&hooks.Request{
+		ID:     t.ID,
+		Bundle: t.BundlePath,
+		Labels: t.Labels,
+		Peer:   t.Peer,
+	}); err != nil {
+		return stepError("pre-create", err)
+	}
+	if s.hooks.Enabled() {
+		t.undo("pre-create", func() error {
+			// the plugins release what they set up for the container
+			go s.hooks.Notify(hooks.PostDelete, &hooks.Request{
+				ID:     t.ID,
+				Bundle: t.BundlePath,
+				Labels: t.Labels,
+			})
+			return nil
+		})
+	}
+	return nil
+}
+
+// PreStop calls the pre-stop plugins if the signal stops the container, it is
+// called by the api before the signal is sent by the peer
+func (s *Supervisor) PreStop(id, pid string, sig os.Signal, peer *hooks.Peer) {
+	if !s.hooks.Enabled() || pid != runtime.InitProcessID || !isStopSignal(sig) {
+		return
+	}
+	t := &GetContainersTask{}
+	t.ID = id
+	s.SendTask(t)
+	if err := <-t.ErrorCh(); err != nil {
+		return
+	}
+	r := hookRequest(t.Containers[0])
+	r.Peer = peer
+	if processes, err := t.Containers[0].Processes(); err == nil {
+		for _, p := range processes {
+			if p.ID() == runtime.InitProcessID {
+				r.Pid = p.SystemPid()
+			}
+		}
+	}
+	s.hooks.Notify(hooks.PreStop, r)
+}
+
+func hookRequest(c runtime.Container) *hooks.Request {
+	return &hooks.Request{
+		ID:     c.ID(),
+		Bundle: c.Path(),
+		Labels: c.Labels(),
+	}
+}
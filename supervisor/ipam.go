@@ -0,0 +1,101 @@
+package supervisor
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/runtime"
+)
+
+// SetIPAMNetworks sets the networks the addresses of the containers are
+// allocated from, the host-local allocations are kept in the supervisor's
+// metadata database.  It must be called before the supervisor is started.
+func (s *Supervisor) SetIPAMNetworks(networks map[string]ipam.Network) error {
+	m, err := ipam.New(networks, s.db, ipam.DefaultTimeout)
+	if err != nil {
+		return err
+	}
+	s.ipam = m
+	return nil
+}
+
+// allocateAddresses sets the addresses of the task that are allocated from a
+// network, the addresses are released again when the start fails
+func (s *Supervisor) allocateAddresses(t *StartTask) error {
+	for i := range t.Network.Addresses {
+		a := &t.Network.Addresses[i]
+		if a.Network == "" {
+			continue
+		}
+		allocation, err := s.ipam.Allocate(a.Network, t.ID)
+		if err != nil {
+			return err
+		}
+		a.Address, a.Gateway = allocation.Address, allocation.Gateway
+		network := a.Network
+		t.undo("ipam", func() error {
+			return s.ipam.Release(network, t.ID)
+		})
+	}
+	return nil
+}
+
+// releaseAddresses returns the addresses of the container to their networks
+func (s *Supervisor) releaseAddresses(container runtime.Container) {
+	if s.ipam == nil {
+		return
+	}
+	for _, a := range container.Network().Addresses {
+		if a.Network == "" {
+			continue
+		}
+		if err := s.ipam.Release(a.Network, container.ID()); err != nil {
+			log.WithFields(logrus.Fields{
+				"error":   err,
+				"id":      container.ID(),
+				"network": a.Network,
+			}).Warn("containerd: release address")
+		}
+	}
+}
+
+// pruneAddresses releases the host-local addresses of containers that were
+// not restored
+func (s *Supervisor) pruneAddresses() {
+	if err := s.ipam.Prune(func(id string) bool {
+		_, ok := s.containers[id]
+		return ok
+	}); err != nil {
+		log.WithField("error", err).Warn("containerd: prune addresses")
+	}
+}
+
+// restoreAddresses allocates the host-local addresses the container has in
+// the backup again, the container is not restored when one of them is
+// allocated to another container
+func (s *Supervisor) restoreAddresses(b *Backup, id string) error {
+	local := ipam.NewHostLocal(s.db)
+	for network, record := range b.Records[ipam.Bucket] {
+		if err := local.Restore(network, record, id); err != nil {
+			s.releaseBackupAddresses(b, id)
+			return fmt.Errorf("containerd: restore address of network %s: %v", network, err)
+		}
+	}
+	return nil
+}
+
+// releaseBackupAddresses releases the host-local addresses of the container in
+// the networks of the backup
+func (s *Supervisor) releaseBackupAddresses(b *Backup, id string) {
+	local := ipam.NewHostLocal(s.db)
+	for network := range b.Records[ipam.Bucket] {
+		if err := local.Release(network, ipam.Network{}, id); err != nil {
+			log.WithFields(logrus.Fields{
+				"error":   err,
+				"id":      id,
+				"network": network,
+			}).Warn("containerd: release address")
+		}
+	}
+}
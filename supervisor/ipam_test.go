@@ -0,0 +1,61 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/runtime"
+)
+
+func TestAllocateAddresses(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-ipam-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := newTestSupervisor()
+	s.db = openTestDB(t, dir)
+	defer s.db.Close()
+	if err := s.SetIPAMNetworks(map[string]ipam.Network{
+		"bridge": {Subnet: "10.1.0.0/24", Gateway: "10.1.0.1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	task := &StartTask{ID: "c", Network: runtime.NetworkConfig{
+		Addresses: []runtime.AddressConfig{
+			{Interface: "eth0", Network: "bridge"},
+			{Interface: "eth1", Address: "192.168.0.2/24"},
+		},
+	}}
+	if err := s.allocateAddresses(task); err != nil {
+		t.Fatal(err)
+	}
+	a := task.Network.Addresses
+	if a[0].Address != "10.1.0.2/24" || a[0].Gateway != "10.1.0.1" {
+		t.Fatalf("expected the first address of the network but received %+v", a[0])
+	}
+	if a[1].Address != "192.168.0.2/24" || a[1].Gateway != "" {
+		t.Fatalf("expected the static address to be kept but received %+v", a[1])
+	}
+
+	// the address is released when the start is rolled back, the container
+	// gets the next address of the network when it is created again
+	task.rollback.run()
+	task.Network.Addresses[0].Address = ""
+	if err := s.allocateAddresses(task); err != nil {
+		t.Fatal(err)
+	}
+	if a := task.Network.Addresses[0].Address; a != "10.1.0.3/24" {
+		t.Fatalf("expected the next address of the network but received %s", a)
+	}
+
+	task = &StartTask{ID: "d", Network: runtime.NetworkConfig{
+		Addresses: []runtime.AddressConfig{{Interface: "eth0", Network: "missing"}},
+	}}
+	if err := s.allocateAddresses(task); err != ipam.ErrUnknownNetwork {
+		t.Fatalf("expected ErrUnknownNetwork but received %v", err)
+	}
+}
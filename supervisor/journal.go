@@ -0,0 +1,91 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// eventLogFile is the append-only journal of events in the state directory
+	eventLogFile = "events.log"
+	// maxEventLog is the number of recent events kept by the journal, the
+	// journal is compacted to them when it holds twice as many
+	maxEventLog = 10000
+)
+
+// eventJournal appends the events to the journal file.  It only records the
+// events sent to subscribers, the state of the containers is kept in the
+// metadata database which appends each change as a record as well.
+type eventJournal struct {
+	path string
+	f    *os.File
+	enc  *json.Encoder
+	// entries is the number of events in the file
+	entries int
+}
+
+func openEventJournal(path string, entries int) (*eventJournal, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &eventJournal{
+		path:    path,
+		f:       f,
+		enc:     json.NewEncoder(f),
+		entries: entries,
+	}, nil
+}
+
+func (j *eventJournal) append(e Event) error {
+	if err := j.enc.Encode(e); err != nil {
+		return err
+	}
+	j.entries++
+	return nil
+}
+
+// compact replaces the journal with a file holding only the events, the new
+// file is synced before it is renamed over the journal so that a crash leaves
+// either the old or the new journal
+func (j *eventJournal) compact(events []Event) error {
+	tmp := j.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if d, err := os.Open(filepath.Dir(j.path)); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	j.f.Close()
+	j.f, j.enc, j.entries = f, enc, len(events)
+	return nil
+}
+
+// trimEventLog drops the oldest events so that at most maxEventLog are kept
+// in memory, it must be called with the subscriber lock held for writing
+func (s *Supervisor) trimEventLog() {
+	if n := len(s.eventLog); n > maxEventLog {
+		s.eventLog = append([]Event(nil), s.eventLog[n-maxEventLog:]...)
+	}
+}
@@ -0,0 +1,41 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEventJournalCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-journal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	j, err := openEventJournal(filepath.Join(dir, eventLogFile), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := j.append(Event{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := j.compact([]Event{{ID: "c"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.append(Event{ID: "d"}); err != nil {
+		t.Fatal(err)
+	}
+	if j.entries != 2 {
+		t.Fatalf("expected 2 entries but received %d", j.entries)
+	}
+	s := &Supervisor{stateDir: dir}
+	if err := readEventLog(s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.eventLog) != 2 || s.eventLog[0].ID != "c" || s.eventLog[1].ID != "d" {
+		t.Fatalf("expected the events c and d but received %v", s.eventLog)
+	}
+}
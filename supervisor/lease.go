@@ -0,0 +1,366 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/metadata"
+)
+
+const (
+	// leasesBucket is the bucket of the metadata database holding the leases
+	// by id
+	leasesBucket = "leases"
+	// uploadsBucket is the bucket of the metadata database holding the
+	// uploads of the bundles by id
+	uploadsBucket = "uploads"
+	// gcInterval is the interval of the collections of the uploaded bundles
+	// that are no longer referenced
+	gcInterval = time.Minute
+	// bundleGracePeriod is how long an uploaded bundle is kept before it is
+	// collected when neither a lease nor a container references it
+	bundleGracePeriod = 10 * time.Minute
+)
+
+// Lease holds references to resources so that they are not collected while a
+// client builds a container from them in several steps.  A lease that is not
+// renewed before it expires is deleted with its references.
+type Lease struct {
+	ID      string    `json:"id"`
+	Expires time.Time `json:"expires"`
+	// Bundles are the ids of the uploaded bundles referenced by the lease
+	Bundles []string `json:"bundles,omitempty"`
+}
+
+func (l *Lease) expired(now time.Time) bool {
+	return !now.Before(l.Expires)
+}
+
+// NewLeaseID returns a random id for a lease
+func NewLeaseID() (string, error) {
+	return newBundleID()
+}
+
+// CreateLease creates the lease id that expires after ttl
+func (s *Supervisor) CreateLease(id string, ttl time.Duration) (*Lease, error) {
+	if id == "" {
+		return nil, ErrInvalidLeaseID
+	}
+	if ttl <= 0 {
+		return nil, ErrInvalidLeaseTTL
+	}
+	l := &Lease{
+		ID:      id,
+		Expires: time.Now().Add(ttl),
+	}
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(leasesBucket)
+		if err != nil {
+			return err
+		}
+		if old, err := readLease(b, id); err == nil && !old.expired(time.Now()) {
+			return ErrLeaseExists
+		}
+		return writeLease(b, l)
+	}); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// RenewLease extends the lease to expire after ttl, a lease that expired
+// cannot be renewed
+func (s *Supervisor) RenewLease(id string, ttl time.Duration) (*Lease, error) {
+	if ttl <= 0 {
+		return nil, ErrInvalidLeaseTTL
+	}
+	var l *Lease
+	err := s.updateLease(id, func(lease *Lease) {
+		lease.Expires = time.Now().Add(ttl)
+		l = lease
+	})
+	return l, err
+}
+
+// DeleteLease deletes the lease, the resources it referenced are collected
+// once nothing else references them
+func (s *Supervisor) DeleteLease(id string) error {
+	return s.db.Update(func(tx *metadata.Tx) error {
+		b := tx.Bucket(leasesBucket)
+		if b == nil {
+			return ErrLeaseNotFound
+		}
+		l, err := readLease(b, id)
+		if err != nil {
+			return err
+		}
+		if l.expired(time.Now()) {
+			return ErrLeaseNotFound
+		}
+		return b.Delete(id)
+	})
+}
+
+// Leases returns the leases that did not expire
+func (s *Supervisor) Leases() ([]*Lease, error) {
+	var (
+		out []*Lease
+		now = time.Now()
+	)
+	err := s.db.View(func(tx *metadata.Tx) error {
+		b := tx.Bucket(leasesBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(id string, data []byte) error {
+			var l Lease
+			if err := json.Unmarshal(data, &l); err != nil {
+				return err
+			}
+			if !l.expired(now) {
+				out = append(out, &l)
+			}
+			return nil
+		})
+	})
+	return out, err
+}
+
+// updateLease calls fn with the lease id and saves it, it fails with
+// ErrLeaseNotFound when the lease expired
+func (s *Supervisor) updateLease(id string, fn func(*Lease)) error {
+	return s.db.Update(func(tx *metadata.Tx) error {
+		b := tx.Bucket(leasesBucket)
+		if b == nil {
+			return ErrLeaseNotFound
+		}
+		l, err := readLease(b, id)
+		if err != nil {
+			return err
+		}
+		if l.expired(time.Now()) {
+			return ErrLeaseNotFound
+		}
+		fn(l)
+		return writeLease(b, l)
+	})
+}
+
+func readLease(b *metadata.Bucket, id string) (*Lease, error) {
+	data := b.Get(id)
+	if data == nil {
+		return nil, ErrLeaseNotFound
+	}
+	var l Lease
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func writeLease(b *metadata.Bucket, l *Lease) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return b.Put(l.ID, data)
+}
+
+// GCTask deletes the expired leases and removes the uploaded bundles that
+// are not referenced by a lease, a container or a template once their grace
+// period is over
+type GCTask struct {
+	baseTask
+	// Removed are the ids of the removed bundles
+	Removed []string
+}
+
+// collectGarbage sends a GCTask every gcInterval, it is only started when
+// bundles can be uploaded
+func (s *Supervisor) collectGarbage() {
+	defer s.HandlePanic()
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		t := &GCTask{}
+		s.SendTask(t)
+		if err := <-t.ErrorCh(); err != nil {
+			log.WithField("error", err).Error("containerd: collect uploaded bundles")
+		}
+	}
+}
+
+func (s *Supervisor) gc(t *GCTask) error {
+	if s.bundleRoot == "" {
+		return nil
+	}
+	now := time.Now()
+	referenced := make(map[string]bool)
+	for _, i := range s.containers {
+		if id, ok := s.uploadedBundle(i.container.Path()); ok {
+			referenced[id] = true
+		}
+	}
+	templates, err := s.Templates()
+	if err != nil {
+		return err
+	}
+	for _, tmpl := range templates {
+		var spec struct {
+			Root struct {
+				Path string `json:"path"`
+			} `json:"root"`
+		}
+		if err := json.Unmarshal(tmpl.Spec, &spec); err == nil {
+			if id, ok := s.uploadedBundle(spec.Root.Path); ok {
+				referenced[id] = true
+			}
+		}
+	}
+	return s.db.Update(func(tx *metadata.Tx) error {
+		// the bundle root is read in the transaction as uploads move their
+		// bundle in their own transaction
+		entries, err := ioutil.ReadDir(s.bundleRoot)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		leases, err := tx.CreateBucketIfNotExists(leasesBucket)
+		if err != nil {
+			return err
+		}
+		var expired []string
+		if err := leases.ForEach(func(id string, data []byte) error {
+			var l Lease
+			if err := json.Unmarshal(data, &l); err != nil {
+				return err
+			}
+			if l.expired(now) {
+				expired = append(expired, id)
+				return nil
+			}
+			for _, b := range l.Bundles {
+				referenced[b] = true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, id := range expired {
+			log.WithField("lease", id).Debug("containerd: lease expired")
+			if err := leases.Delete(id); err != nil {
+				return err
+			}
+		}
+		uploads, err := tx.CreateBucketIfNotExists(uploadsBucket)
+		if err != nil {
+			return err
+		}
+		present := make(map[string]bool)
+		for _, e := range entries {
+			id := e.Name()
+			if !e.IsDir() || strings.HasPrefix(id, ".") {
+				continue
+			}
+			present[id] = true
+			if referenced[id] {
+				continue
+			}
+			u, err := readUpload(uploads, id)
+			if err != nil {
+				// bundles uploaded before they were recorded get their
+				// grace period from now
+				if err := recordUpload(uploads, id, &upload{Uploaded: now}); err != nil {
+					return err
+				}
+				continue
+			}
+			if now.Sub(u.Uploaded) < bundleGracePeriod {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(s.bundleRoot, id)); err != nil {
+				log.WithFields(logrus.Fields{
+					"bundle": id,
+					"error":  err,
+				}).Error("containerd: remove uploaded bundle")
+				continue
+			}
+			log.WithField("bundle", id).Info("containerd: removed unreferenced uploaded bundle")
+			t.Removed = append(t.Removed, id)
+			delete(present, id)
+		}
+		// forget the bundles that were removed
+		var gone []string
+		uploads.ForEach(func(id string, _ []byte) error {
+			if !present[id] {
+				gone = append(gone, id)
+			}
+			return nil
+		})
+		for _, id := range gone {
+			if err := uploads.Delete(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// uploadedBundle returns the id of the uploaded bundle holding path
+func (s *Supervisor) uploadedBundle(path string) (string, bool) {
+	rel, err := filepath.Rel(s.bundleRoot, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return strings.SplitN(filepath.ToSlash(rel), "/", 2)[0], true
+}
+
+// upload is the record of an uploaded bundle
+type upload struct {
+	Uploaded time.Time `json:"uploaded"`
+	// Namespace is the namespace of the request that uploaded the bundle,
+	// its size counts against the bundle disk quota of the namespace
+	Namespace string `json:"namespace,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+}
+
+func readUpload(b *metadata.Bucket, id string) (*upload, error) {
+	data := b.Get(id)
+	if data == nil {
+		return nil, ErrBundleNotFound
+	}
+	var u upload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func recordUpload(b *metadata.Bucket, id string, u *upload) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return b.Put(id, data)
+}
+
+// uploadedSize returns the size of the bundles uploaded in the namespace
+func uploadedSize(b *metadata.Bucket, namespace string) (int64, error) {
+	var size int64
+	err := b.ForEach(func(id string, data []byte) error {
+		var u upload
+		if err := json.Unmarshal(data, &u); err != nil {
+			// records that cannot be read are replaced by the next gc
+			return nil
+		}
+		if u.Namespace == namespace {
+			size += u.Size
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,109 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/metadata"
+)
+
+func TestLeaseGC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-lease-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := newTestSupervisor()
+	s.SetBundleRoot(filepath.Join(dir, "bundles"))
+	s.db = openTestDB(t, dir)
+	defer s.db.Close()
+	s.containers = make(map[string]*containerInfo)
+
+	if _, err := s.CreateLease("build", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateLease("build", time.Hour); err != ErrLeaseExists {
+		t.Fatalf("expected ErrLeaseExists but received %v", err)
+	}
+	if _, err := s.CreateLease("gone", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.UploadBundle(bundleTar(t, "config.json", "rootfs/"), DefaultNamespace, "missing"); err != ErrLeaseNotFound {
+		t.Fatalf("expected ErrLeaseNotFound but received %v", err)
+	}
+	leased, _, err := s.UploadBundle(bundleTar(t, "config.json", "rootfs/"), DefaultNamespace, "build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale, _, err := s.UploadBundle(bundleTar(t, "config.json", "rootfs/"), DefaultNamespace, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent, _, err := s.UploadBundle(bundleTar(t, "config.json", "rootfs/"), DefaultNamespace, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the leased and the stale bundles were uploaded before the grace period
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		b := tx.Bucket(uploadsBucket)
+		for _, id := range []string{leased, stale} {
+			u, err := readUpload(b, id)
+			if err != nil {
+				return err
+			}
+			u.Uploaded = time.Now().Add(-2 * bundleGracePeriod)
+			if err := recordUpload(b, id, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteLease("gone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteLease("gone"); err != ErrLeaseNotFound {
+		t.Fatalf("expected ErrLeaseNotFound but received %v", err)
+	}
+
+	gt := &GCTask{}
+	if err := s.gc(gt); err != nil {
+		t.Fatal(err)
+	}
+	if len(gt.Removed) != 1 || gt.Removed[0] != stale {
+		t.Fatalf("expected only the stale bundle to be removed but received %v", gt.Removed)
+	}
+	for _, id := range []string{leased, recent} {
+		if _, err := s.BundlePath(id); err != nil {
+			t.Fatalf("expected bundle %s to be kept but received %v", id, err)
+		}
+	}
+
+	// the bundles of an expired lease are collected with it
+	if err := s.updateLease("build", func(l *Lease) {
+		l.Expires = time.Now().Add(-time.Second)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RenewLease("build", time.Hour); err != ErrLeaseNotFound {
+		t.Fatalf("expected an expired lease not to be renewed but received %v", err)
+	}
+	gt = &GCTask{}
+	if err := s.gc(gt); err != nil {
+		t.Fatal(err)
+	}
+	if len(gt.Removed) != 1 || gt.Removed[0] != leased {
+		t.Fatalf("expected the bundle of the expired lease to be removed but received %v", gt.Removed)
+	}
+	leases, err := s.Leases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leases) != 0 {
+		t.Fatalf("expected no leases but received %d", len(leases))
+	}
+}
@@ -0,0 +1,105 @@
+package supervisor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// LifecycleState is a state of the lifecycle of a container
+type LifecycleState string
+
+const (
+	Created  LifecycleState = "created"
+	Starting LifecycleState = "starting"
+	Running  LifecycleState = "running"
+	Pausing  LifecycleState = "pausing"
+	Paused   LifecycleState = "paused"
+	Stopping LifecycleState = "stopping"
+	Stopped  LifecycleState = "stopped"
+	// Degraded containers have a running process that lost its shim or
+	// lost their bundle while the daemon was not running
+	Degraded LifecycleState = "degraded"
+)
+
+// maxTransitions is the number of transitions kept for each container
+const maxTransitions = 64
+
+// Transition is a change of a container's lifecycle state
+type Transition struct {
+	State     LifecycleState
+	Timestamp time.Time
+}
+
+// Lifecycle is the history of a container's lifecycle states
+type Lifecycle struct {
+	// Transitions are the most recent transitions, the last is the current state
+	Transitions []Transition
+	// LastError is the error of the last transition that failed
+	LastError          string
+	LastErrorTimestamp time.Time
+}
+
+// State returns the current state of the lifecycle
+func (l Lifecycle) State() LifecycleState {
+	if len(l.Transitions) == 0 {
+		return ""
+	}
+	return l.Transitions[len(l.Transitions)-1].State
+}
+
+// lifecycle records the transitions of a container, the start workers
+// transition containers outside of the event loop so it has its own lock
+type lifecycle struct {
+	mu sync.Mutex
+	l  Lifecycle
+}
+
+func newLifecycle(s LifecycleState) *lifecycle {
+	l := &lifecycle{}
+	l.transition(s)
+	return l
+}
+
+// restoredLifecycle returns the lifecycle of a container that was running
+// before the daemon was restarted
+func restoredLifecycle(c runtime.Container) *lifecycle {
+	switch c.State() {
+	case runtime.Paused:
+		return newLifecycle(Paused)
+	case runtime.Stopped:
+		return newLifecycle(Stopped)
+	}
+	return newLifecycle(Running)
+}
+
+func (l *lifecycle) transition(s LifecycleState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Transitions = append(l.l.Transitions, Transition{
+		State:     s,
+		Timestamp: time.Now(),
+	})
+	if n := len(l.l.Transitions); n > maxTransitions {
+		l.l.Transitions = append([]Transition(nil), l.l.Transitions[n-maxTransitions:]...)
+	}
+}
+
+// fail records the error of a failed transition and moves the container to
+// the state it is left in
+func (l *lifecycle) fail(s LifecycleState, err error) {
+	l.mu.Lock()
+	l.l.LastError = err.Error()
+	l.l.LastErrorTimestamp = time.Now()
+	l.mu.Unlock()
+	l.transition(s)
+}
+
+func (l *lifecycle) snapshot() Lifecycle {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := l.l
+	s.Transitions = append([]Transition(nil), l.l.Transitions...)
+	return s
+}
@@ -0,0 +1,37 @@
+package supervisor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLifecycleFail(t *testing.T) {
+	l := newLifecycle(Running)
+	l.transition(Pausing)
+	l.fail(Running, errors.New("freezer busy"))
+	s := l.snapshot()
+	if s.State() != Running {
+		t.Fatalf("expected state %s but received %s", Running, s.State())
+	}
+	if len(s.Transitions) != 3 {
+		t.Fatalf("expected 3 transitions but received %d", len(s.Transitions))
+	}
+	if s.LastError != "freezer busy" || s.LastErrorTimestamp.IsZero() {
+		t.Fatalf("expected the last error to be recorded but received %q", s.LastError)
+	}
+}
+
+func TestLifecycleKeepsRecentTransitions(t *testing.T) {
+	l := newLifecycle(Created)
+	for i := 0; i < maxTransitions; i++ {
+		l.transition(Paused)
+	}
+	l.transition(Stopped)
+	s := l.snapshot()
+	if len(s.Transitions) != maxTransitions {
+		t.Fatalf("expected %d transitions but received %d", maxTransitions, len(s.Transitions))
+	}
+	if s.Transitions[0].State != Paused || s.State() != Stopped {
+		t.Fatalf("expected the oldest transitions to be dropped but received %v", s.Transitions)
+	}
+}
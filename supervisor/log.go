@@ -0,0 +1,24 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+type LogRotateTask struct {
+	baseTask
+	ID  string
+	PID string
+}
+
+func (s *Supervisor) logRotate(t *LogRotateTask) error {
+	log.WithFields(logrus.Fields{"id": t.ID, "pid": t.PID}).Debug("containerd: log rotated")
+	s.notifySubscribers(Event{
+		Timestamp: time.Now(),
+		ID:        t.ID,
+		PID:       t.PID,
+		Type:      "log-rotate",
+	})
+	return nil
+}
@@ -3,4 +3,6 @@ package supervisor
 type Machine struct {
 	Cpus   int
 	Memory int64
+	// GPUs are the ids of the gpus
+	GPUs []string
 }
@@ -1,6 +1,9 @@
 package supervisor
 
-import "github.com/cloudfoundry/gosigar"
+import (
+	"github.com/cloudfoundry/gosigar"
+	"github.com/docker/containerd/runtime"
+)
 
 func CollectMachineInformation() (Machine, error) {
 	m := Machine{}
@@ -14,5 +17,12 @@ func CollectMachineInformation() (Machine, error) {
 		return m, err
 	}
 	m.Memory = int64(mem.Total / 1024 / 1024)
+	gpus, err := runtime.DetectGPUs()
+	if err != nil {
+		return m, err
+	}
+	for _, g := range gpus {
+		m.GPUs = append(m.GPUs, g.ID)
+	}
 	return m, nil
 }
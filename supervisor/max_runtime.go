@@ -0,0 +1,121 @@
+package supervisor
+
+import (
+	"syscall"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// maxRuntimesBucket is the bucket of the metadata database that the maximum
+// runtimes of the containers are kept in
+const maxRuntimesBucket = "max-runtimes"
+
+// maxRuntime is the time a container may run before it is stopped
+type maxRuntime struct {
+	Limit time.Duration `json:"limit"`
+	// Signal is sent when the limit passed, the container is killed if it
+	// did not exit within DefaultStopTimeout
+	Signal syscall.Signal `json:"signal"`
+	// Deadline is when the running container is stopped, it is set each
+	// time the container is started
+	Deadline time.Time `json:"deadline"`
+	timer    *time.Timer
+}
+
+// startMaxRuntime sets the deadline of a container that was started and saves
+// it so that the deadline is kept when the daemon restarts
+func (s *Supervisor) startMaxRuntime(i *containerInfo) {
+	m := i.maxRuntime
+	if m == nil {
+		return
+	}
+	m.Deadline = time.Now().Add(m.Limit)
+	if err := s.saveContainerRecord(maxRuntimesBucket, i.container.ID(), m); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    i.container.ID(),
+		}).Error("containerd: save maximum runtime of container")
+	}
+	s.armMaxRuntime(i.container.ID(), m)
+}
+
+// armMaxRuntime stops the container when the deadline passed
+func (s *Supervisor) armMaxRuntime(id string, m *maxRuntime) {
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	deadline := m.Deadline
+	m.timer = time.AfterFunc(deadline.Sub(time.Now()), func() {
+		s.SendTask(&maxRuntimeTask{ID: id, maxRuntime: m, deadline: deadline})
+	})
+}
+
+// stopMaxRuntime stops the timer of a container that exited
+func stopMaxRuntime(i *containerInfo) {
+	if m := i.maxRuntime; m != nil && m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+// restoreMaxRuntime reads the maximum runtime of a restored container and
+// arms it if the container is running, a deadline that passed while the
+// daemon was down stops the container right away
+func (s *Supervisor) restoreMaxRuntime(i *containerInfo) {
+	id := i.container.ID()
+	var m maxRuntime
+	if !s.readContainerRecord(maxRuntimesBucket, id, &m) {
+		return
+	}
+	i.maxRuntime = &m
+	if i.lifecycle.snapshot().State() != Stopped {
+		s.armMaxRuntime(id, &m)
+	}
+}
+
+// deleteMaxRuntime removes the saved maximum runtime of a deleted container
+func (s *Supervisor) deleteMaxRuntime(i *containerInfo) {
+	if i.maxRuntime == nil {
+		return
+	}
+	stopMaxRuntime(i)
+	s.deleteContainerRecord(maxRuntimesBucket, i.container.ID())
+}
+
+// maxRuntimeTask stops a container whose maximum runtime passed
+type maxRuntimeTask struct {
+	baseTask
+	ID         string
+	maxRuntime *maxRuntime
+	deadline   time.Time
+}
+
+func (s *Supervisor) maxRuntimeExceeded(t *maxRuntimeTask) error {
+	i, ok := s.containers[t.ID]
+	// the container exited or was started again
+	if !ok || i.maxRuntime != t.maxRuntime || t.maxRuntime.timer == nil || !t.deadline.Equal(t.maxRuntime.Deadline) {
+		return nil
+	}
+	t.maxRuntime.timer = nil
+	if i.stop != nil || i.lifecycle.snapshot().State() == Stopped {
+		// the container is stopping already
+		return nil
+	}
+	s.notifySubscribers(Event{
+		ID:        t.ID,
+		Type:      "timeout",
+		Timestamp: time.Now(),
+	})
+	if err := s.stopContainer(&StopTask{
+		ID:     t.ID,
+		Signal: t.maxRuntime.Signal,
+	}); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    t.ID,
+		}).Error("containerd: stop container after its maximum runtime")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestMaxRuntime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-max-runtime-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	c := &stopContainer{init: &stopProcess{}}
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.db = openTestDB(t, dir)
+	s.tasks = make(chan Task, 1)
+	i := &containerInfo{
+		container:  c,
+		lifecycle:  newLifecycle(Running),
+		maxRuntime: &maxRuntime{Limit: time.Millisecond, Signal: syscall.SIGINT},
+	}
+	s.containers = map[string]*containerInfo{"c": i}
+	events := s.Events(time.Time{})
+	defer s.Unsubscribe(events)
+
+	s.startMaxRuntime(i)
+	task := (<-s.tasks).(*maxRuntimeTask)
+	// a deadline of a previous start is ignored
+	stale := &maxRuntimeTask{ID: "c", maxRuntime: task.maxRuntime, deadline: task.deadline.Add(-time.Second)}
+	if err := s.maxRuntimeExceeded(stale); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.init.signals) != 0 {
+		t.Fatalf("expected no signal for a stale deadline but received %v", c.init.signals)
+	}
+	if err := s.maxRuntimeExceeded(task); err != nil {
+		t.Fatal(err)
+	}
+	if e := <-events; e.Type != "timeout" || e.ID != "c" {
+		t.Fatalf("expected a timeout event but received %+v", e)
+	}
+	if len(c.init.signals) != 1 || c.init.signals[0] != syscall.SIGINT {
+		t.Fatalf("expected SIGINT to be sent to the init process but received %v", c.init.signals)
+	}
+	if i.stop == nil {
+		t.Fatal("expected the container to be stopping")
+	}
+	i.stop.timer.Stop()
+
+	// the saved deadline is restored with the container
+	restored := &containerInfo{container: c, lifecycle: newLifecycle(Stopped)}
+	s.restoreMaxRuntime(restored)
+	if m := restored.maxRuntime; m == nil || m.Limit != time.Millisecond || m.Signal != syscall.SIGINT || !m.Deadline.Equal(i.maxRuntime.Deadline) {
+		t.Fatalf("expected the maximum runtime to be restored but received %+v", m)
+	}
+	if restored.maxRuntime.timer != nil {
+		t.Fatal("expected the maximum runtime of a stopped container to not be armed")
+	}
+	s.deleteMaxRuntime(i)
+	if ids := s.containerRecords(maxRuntimesBucket); len(ids) != 0 {
+		t.Fatalf("expected the maximum runtime to be removed with the container but received %v", ids)
+	}
+}
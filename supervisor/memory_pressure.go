@@ -0,0 +1,24 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+)
+
+type MemoryPressureTask struct {
+	baseTask
+	ID    string
+	Level string
+}
+
+func (s *Supervisor) memoryPressure(t *MemoryPressureTask) error {
+	log.WithFields(logrus.Fields{"id": t.ID, "level": t.Level}).Debug("containerd: container memory pressure")
+	s.notifySubscribers(Event{
+		Timestamp: time.Now(),
+		ID:        t.ID,
+		Type:      "memory-pressure",
+		Level:     t.Level,
+	})
+	return nil
+}
@@ -1,14 +1,23 @@
 package supervisor
 
-import "github.com/rcrowley/go-metrics"
+import (
+	"github.com/docker/containerd/runtime"
+	"github.com/rcrowley/go-metrics"
+)
 
 var (
 	ContainerCreateTimer   = metrics.NewTimer()
 	ContainerDeleteTimer   = metrics.NewTimer()
 	ContainerStartTimer    = metrics.NewTimer()
 	ContainerStatsTimer    = metrics.NewTimer()
+	ContainerRestoreTimer  = metrics.NewTimer()
+	ContainersRestoreTimer = metrics.NewTimer()
 	ContainersCounter      = metrics.NewCounter()
 	EventSubscriberCounter = metrics.NewCounter()
+	EventsDroppedCounter   = metrics.NewCounter()
+	StatsCollectorsCounter = metrics.NewCounter()
+	StdioSpliceCounter     = metrics.NewCounter()
+	StdioCopyCounter       = metrics.NewCounter()
 	TasksCounter           = metrics.NewCounter()
 	ExecProcessTimer       = metrics.NewTimer()
 	ExitProcessTimer       = metrics.NewTimer()
@@ -21,11 +30,28 @@ func Metrics() map[string]interface{} {
 		"container-delete-time": ContainerDeleteTimer,
 		"container-start-time":  ContainerStartTimer,
 		"container-stats-time":  ContainerStatsTimer,
+		"restore-time":          ContainerRestoreTimer,
+		"restore-all-time":      ContainersRestoreTimer,
 		"containers":            ContainersCounter,
 		"event-subscribers":     EventSubscriberCounter,
+		"events-dropped":        EventsDroppedCounter,
+		"stats-collectors":      StatsCollectorsCounter,
+		"stdio-splice":          StdioSpliceCounter,
+		"stdio-copy":            StdioCopyCounter,
 		"tasks":                 TasksCounter,
 		"exec-process-time":     ExecProcessTimer,
 		"exit-process-time":     ExitProcessTimer,
 		"epoll-fds":             EpollFdCounter,
 	}
 }
+
+// countStdioMode counts the started process by the way its shim forwards its
+// stdio
+func countStdioMode(p runtime.Process) {
+	switch p.StdioMode() {
+	case runtime.StdioModeSplice:
+		StdioSpliceCounter.Inc(1)
+	case runtime.StdioModeCopy:
+		StdioCopyCounter.Inc(1)
+	}
+}
@@ -0,0 +1,290 @@
+package supervisor
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+const (
+	// FormatVersion is the version of the layout of the state directory
+	// written by this daemon
+	FormatVersion = 2
+	// versionFile is the file of the state dir holding its format version,
+	// state directories without it have the version 0
+	versionFile = "version"
+	// MigrationBackupsDir is the directory of the state dir that the state
+	// is archived in before it is migrated
+	MigrationBackupsDir = "migration-backups"
+)
+
+// Migration upgrades the state directory from the previous version to Version
+type Migration struct {
+	Version     int
+	Description string
+	// migrate must be safe to run again when it failed part way
+	migrate func(stateDir string, db *metadata.DB) error
+}
+
+// migrations are the migrations of the state directory ordered by version
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "move the volumes, maximum runtimes and OOM restart policies of the containers to the metadata database",
+		migrate:     migrateRecordDirs,
+	},
+	{
+		Version:     2,
+		Description: "move the state.json files of the containers to the metadata database",
+		migrate:     migrateStateFiles,
+	},
+}
+
+// MigrateOptions are the options of Migrate
+type MigrateOptions struct {
+	// DryRun returns the pending migrations without changing the state
+	// directory
+	DryRun bool
+	// BackupDir is the directory the metadata of the state directory is
+	// archived in before it is migrated, no archive is written when it is
+	// empty
+	BackupDir string
+}
+
+// Migrate upgrades the state directory to FormatVersion and returns the
+// migrations that were applied, or that are pending with DryRun.  It fails
+// with ErrFormatTooNew when the state directory was written by a newer
+// daemon.
+func Migrate(stateDir string, opts MigrateOptions) ([]Migration, error) {
+	version, err := readFormatVersion(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	if version > FormatVersion {
+		return nil, ErrFormatTooNew
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > version {
+			pending = append(pending, m)
+		}
+	}
+	if opts.DryRun || version == FormatVersion {
+		return pending, nil
+	}
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return nil, err
+	}
+	if opts.BackupDir != "" {
+		entries, err := ioutil.ReadDir(stateDir)
+		if err != nil {
+			return nil, err
+		}
+		// a new state directory has nothing to archive
+		if len(entries) > 0 {
+			path, err := backupStateDir(stateDir, opts.BackupDir, version)
+			if err != nil {
+				return nil, err
+			}
+			log.WithField("path", path).Info("containerd: archived state directory before migrating it")
+		}
+	}
+	db, err := metadata.Open(filepath.Join(stateDir, metadataFile))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	for _, m := range pending {
+		if err := m.migrate(stateDir, db); err != nil {
+			return nil, fmt.Errorf("containerd: migrate state directory to version %d: %v", m.Version, err)
+		}
+		// the version is written after each migration so that a failed
+		// migration is the first one run again
+		if err := writeFormatVersion(stateDir, m.Version); err != nil {
+			return nil, err
+		}
+		log.WithFields(logrus.Fields{
+			"version":     m.Version,
+			"description": m.Description,
+		}).Info("containerd: migrated state directory")
+	}
+	return pending, nil
+}
+
+func readFormatVersion(stateDir string) (int, error) {
+	data, err := ioutil.ReadFile(filepath.Join(stateDir, versionFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+func writeFormatVersion(stateDir string, version int) error {
+	path := filepath.Join(stateDir, versionFile)
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, []byte(strconv.Itoa(version)+"\n"), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// backupStateDir writes the metadata database and the json files of the state
+// directory to a gzip compressed tar archive in dir and returns its path.  The
+// logs, fifos and sockets of the containers are not archived.
+func backupStateDir(stateDir, dir string, version int) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("state-v%d-%s.tar.gz", version, time.Now().UTC().Format("20060102T150405Z")))
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	err = filepath.Walk(stateDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() && p == dir {
+			return filepath.SkipDir
+		}
+		name := fi.Name()
+		if !fi.Mode().IsRegular() || (name != metadataFile && name != versionFile && !strings.HasSuffix(name, ".json")) {
+			return nil
+		}
+		rel, err := filepath.Rel(stateDir, p)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, src)
+		src.Close()
+		return err
+	})
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gw.Close()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// migrateRecordDirs moves the json files that version 0 kept in a directory of
+// the state dir for each bucket to the metadata database
+func migrateRecordDirs(stateDir string, db *metadata.DB) error {
+	for _, bucket := range []string{volumesBucket, maxRuntimesBucket, oomRestartsBucket} {
+		if err := migrateRecordDir(stateDir, db, bucket); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateRecordDir moves the json files of the containers in dir of the state
+// dir to the bucket of the same name, the directory is removed once all of its
+// files were moved
+func migrateRecordDir(stateDir string, db *metadata.DB, dir string) error {
+	path := filepath.Join(stateDir, dir)
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	records := make(map[string][]byte)
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(path, f.Name()))
+		if err != nil {
+			return err
+		}
+		records[strings.TrimSuffix(f.Name(), ".json")] = data
+	}
+	if err := db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(dir)
+		if err != nil {
+			return err
+		}
+		for id, data := range records {
+			if err := b.Put(id, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+// migrateStateFiles moves the state file that version 1 kept in the state
+// directory of each container to the metadata database
+func migrateStateFiles(stateDir string, db *metadata.DB) error {
+	dirs, err := ioutil.ReadDir(stateDir)
+	if err != nil {
+		return err
+	}
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		path := filepath.Join(stateDir, d.Name(), runtime.StateFile)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if err := db.Update(func(tx *metadata.Tx) error {
+			b, err := tx.CreateBucketIfNotExists(runtime.ContainersBucket)
+			if err != nil {
+				return err
+			}
+			return b.Put(d.Name(), data)
+		}); err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+func TestMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-migrate-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	stateDir := filepath.Join(dir, "state")
+	for _, d := range []string{filepath.Join(stateDir, "redis"), filepath.Join(stateDir, volumesBucket)} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state := filepath.Join(stateDir, "redis", runtime.StateFile)
+	if err := ioutil.WriteFile(state, []byte(`{"bundle": "/bundles/redis"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(stateDir, volumesBucket, "redis.json"), []byte(`[]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := Migrate(stateDir, MigrateOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != len(migrations) {
+		t.Fatalf("expected %d pending migrations but received %d", len(migrations), len(pending))
+	}
+	if _, err := os.Stat(state); err != nil {
+		t.Fatalf("expected a dry run to leave the state file but received %v", err)
+	}
+
+	backups := filepath.Join(dir, "backups")
+	if _, err := Migrate(stateDir, MigrateOptions{BackupDir: backups}); err != nil {
+		t.Fatal(err)
+	}
+	if version, err := readFormatVersion(stateDir); err != nil || version != FormatVersion {
+		t.Fatalf("expected the state directory to be at version %d but received %d %v", FormatVersion, version, err)
+	}
+	if _, err := os.Stat(state); !os.IsNotExist(err) {
+		t.Fatalf("expected the state file to be removed but received %v", err)
+	}
+	if archives, err := ioutil.ReadDir(backups); err != nil || len(archives) != 1 {
+		t.Fatalf("expected one backup archive but received %v %v", archives, err)
+	}
+	db := openTestDB(t, stateDir)
+	if err := db.View(func(tx *metadata.Tx) error {
+		for _, bucket := range []string{runtime.ContainersBucket, volumesBucket} {
+			b := tx.Bucket(bucket)
+			if b == nil || b.Get("redis") == nil {
+				t.Fatalf("expected the record of redis in %s", bucket)
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if pending, err := Migrate(stateDir, MigrateOptions{DryRun: true}); err != nil || len(pending) != 0 {
+		t.Fatalf("expected no pending migrations but received %v %v", pending, err)
+	}
+	if err := writeFormatVersion(stateDir, FormatVersion+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(stateDir, MigrateOptions{}); err != ErrFormatTooNew {
+		t.Fatalf("expected ErrFormatTooNew but received %v", err)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"sync"
 	"syscall"
 
-	"github.com/Sirupsen/logrus"
 	"github.com/docker/containerd/runtime"
 )
 
@@ -13,6 +12,8 @@ func NewMonitor() (*Monitor, error) {
 		receivers: make(map[int]interface{}),
 		exits:     make(chan runtime.Process, 1024),
 		ooms:      make(chan string, 1024),
+		logs:      make(chan runtime.Process, 1024),
+		pressures: make(chan runtime.MemoryPressure, 1024),
 	}
 	fd, err := syscall.EpollCreate1(0)
 	if err != nil {
@@ -28,9 +29,22 @@ type Monitor struct {
 	receivers map[int]interface{}
 	exits     chan runtime.Process
 	ooms      chan string
+	logs      chan runtime.Process
+	pressures chan runtime.MemoryPressure
 	epollFd   int
 }
 
+// logEvents is registered with the monitor for a process' log events fd
+type logEvents struct {
+	p runtime.Process
+}
+
+// outputEvents is registered with the monitor for a process' output fd so
+// that the output of all processes is read without a goroutine for each
+type outputEvents struct {
+	p runtime.Process
+}
+
 func (m *Monitor) Exits() chan runtime.Process {
 	return m.exits
 }
@@ -39,6 +53,18 @@ func (m *Monitor) OOMs() chan string {
 	return m.ooms
 }
 
+// LogRotations returns a channel that receives a process each time its log
+// file is rotated
+func (m *Monitor) LogRotations() chan runtime.Process {
+	return m.logs
+}
+
+// MemoryPressures returns a channel that receives a container's notifier each
+// time the container's memory cgroup reaches its pressure level
+func (m *Monitor) MemoryPressures() chan runtime.MemoryPressure {
+	return m.pressures
+}
+
 func (m *Monitor) Monitor(p runtime.Process) error {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -52,9 +78,76 @@ func (m *Monitor) Monitor(p runtime.Process) error {
 	}
 	EpollFdCounter.Inc(1)
 	m.receivers[fd] = p
+	if lfd := p.LogFD(); lfd > 0 {
+		if err := syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_ADD, lfd, &syscall.EpollEvent{
+			Fd:     int32(lfd),
+			Events: syscall.EPOLLIN,
+		}); err != nil {
+			// stop watching the exit fd so that a retry can add it again
+			delete(m.receivers, fd)
+			syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_DEL, fd, &event)
+			EpollFdCounter.Dec(1)
+			return err
+		}
+		EpollFdCounter.Inc(1)
+		m.receivers[lfd] = logEvents{p}
+	}
+	if ofd := p.OutputFD(); ofd >= 0 {
+		if err := syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_ADD, ofd, &syscall.EpollEvent{
+			Fd:     int32(ofd),
+			Events: syscall.EPOLLIN,
+		}); err != nil {
+			// the output is still drained when the process exits
+			log.WithField("error", err).Error("containerd: monitor process output")
+			return nil
+		}
+		EpollFdCounter.Inc(1)
+		m.receivers[ofd] = outputEvents{p}
+	}
 	return nil
 }
 
+// Remove stops monitoring the process and closes it without delivering its
+// exit.  It returns false when the exit of the process was handled already.
+func (m *Monitor) Remove(p runtime.Process) bool {
+	m.m.Lock()
+	defer m.m.Unlock()
+	fd := p.ExitFD()
+	if m.receivers[fd] != p {
+		return false
+	}
+	m.remove(fd, p)
+	return true
+}
+
+// remove removes the exit fd of the process and its log events and output
+// fds from the epoll set and closes the process, it must be called with the
+// lock held
+func (m *Monitor) remove(fd int, p runtime.Process) {
+	delete(m.receivers, fd)
+	if err := syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_DEL, fd, &syscall.EpollEvent{
+		Events: syscall.EPOLLHUP,
+		Fd:     int32(fd),
+	}); err != nil {
+		log.WithField("error", err).Error("containerd: epoll remove fd")
+	}
+	// closing the process also closes its log events fd which
+	// removes it from the epoll set
+	if _, ok := m.receivers[p.LogFD()]; ok {
+		delete(m.receivers, p.LogFD())
+		EpollFdCounter.Dec(1)
+	}
+	// closing the process also drains and closes its output
+	if _, ok := m.receivers[p.OutputFD()]; ok {
+		delete(m.receivers, p.OutputFD())
+		EpollFdCounter.Dec(1)
+	}
+	if err := p.Close(); err != nil {
+		log.WithField("error", err).Error("containerd: close process IO")
+	}
+	EpollFdCounter.Dec(1)
+}
+
 func (m *Monitor) MonitorOOM(c runtime.Container) error {
 	m.m.Lock()
 	defer m.m.Unlock()
@@ -75,10 +168,35 @@ func (m *Monitor) MonitorOOM(c runtime.Container) error {
 	return nil
 }
 
+func (m *Monitor) MonitorMemoryPressure(c runtime.Container) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	pressures, err := c.MemoryPressure()
+	if err != nil {
+		return err
+	}
+	for _, p := range pressures {
+		fd := p.FD()
+		event := syscall.EpollEvent{
+			Fd:     int32(fd),
+			Events: syscall.EPOLLHUP | syscall.EPOLLIN,
+		}
+		if err := syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_ADD, fd, &event); err != nil {
+			return err
+		}
+		EpollFdCounter.Inc(1)
+		m.receivers[fd] = p
+	}
+	return nil
+}
+
 func (m *Monitor) Close() error {
 	return syscall.Close(m.epollFd)
 }
 
+// start is the only goroutine monitoring the processes and containers, it
+// waits on the fds of all of them in a single epoll set so the number of
+// goroutines stays flat with the number of containers
 func (m *Monitor) start() {
 	var events [128]syscall.EpollEvent
 	for {
@@ -87,42 +205,105 @@ func (m *Monitor) start() {
 			if err == syscall.EINTR {
 				continue
 			}
-			logrus.WithField("error", err).Fatal("containerd: epoll wait")
+			log.WithField("error", err).Fatal("containerd: epoll wait")
 		}
-		// process events
+		// the events are handled under the lock and delivered after it is
+		// released so that a full channel never blocks Monitor callers
+		var d deliveries
+		m.m.Lock()
 		for i := 0; i < n; i++ {
-			fd := int(events[i].Fd)
-			m.m.Lock()
-			r := m.receivers[fd]
-			switch t := r.(type) {
-			case runtime.Process:
-				if events[i].Events == syscall.EPOLLHUP {
-					delete(m.receivers, fd)
-					if err = syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_DEL, fd, &syscall.EpollEvent{
-						Events: syscall.EPOLLHUP,
-						Fd:     int32(fd),
-					}); err != nil {
-						logrus.WithField("error", err).Error("containerd: epoll remove fd")
-					}
-					if err := t.Close(); err != nil {
-						logrus.WithField("error", err).Error("containerd: close process IO")
-					}
-					EpollFdCounter.Dec(1)
-					m.exits <- t
-				}
-			case runtime.OOM:
-				// always flush the event fd
-				t.Flush()
-				if t.Removed() {
-					delete(m.receivers, fd)
-					// epoll will remove the fd from its set after it has been closed
-					t.Close()
-					EpollFdCounter.Dec(1)
-				} else {
-					m.ooms <- t.ContainerID()
-				}
+			m.handle(int(events[i].Fd), events[i].Events, &d)
+		}
+		m.m.Unlock()
+		d.send(m)
+	}
+}
+
+// deliveries are the results of a batch of epoll events
+type deliveries struct {
+	exits     []runtime.Process
+	ooms      []string
+	logs      []runtime.Process
+	pressures []runtime.MemoryPressure
+}
+
+// send delivers the OOM notifications before the exits so that the OOM that
+// killed a process is known when its exit is handled
+func (d *deliveries) send(m *Monitor) {
+	for _, id := range d.ooms {
+		m.ooms <- id
+	}
+	for _, p := range d.exits {
+		m.exits <- p
+	}
+	for _, p := range d.logs {
+		m.logs <- p
+	}
+	for _, p := range d.pressures {
+		m.pressures <- p
+	}
+}
+
+// handle handles an epoll event for the fd, it must be called with the lock held
+func (m *Monitor) handle(fd int, events uint32, d *deliveries) {
+	switch t := m.receivers[fd].(type) {
+	case runtime.Process:
+		if events&syscall.EPOLLHUP == 0 {
+			return
+		}
+		m.remove(fd, t)
+		d.exits = append(d.exits, t)
+	// memory pressure notifiers also implement runtime.OOM so they
+	// must be matched first
+	case runtime.MemoryPressure:
+		t.Flush()
+		if t.Removed() {
+			delete(m.receivers, fd)
+			t.Close()
+			EpollFdCounter.Dec(1)
+		} else {
+			d.pressures = append(d.pressures, t)
+		}
+	case runtime.OOM:
+		// always flush the event fd
+		t.Flush()
+		if t.Removed() {
+			delete(m.receivers, fd)
+			// epoll will remove the fd from its set after it has been closed
+			t.Close()
+			EpollFdCounter.Dec(1)
+		} else {
+			d.ooms = append(d.ooms, t.ContainerID())
+		}
+	case outputEvents:
+		if !t.p.ReadOutput() {
+			// the output ended and its fd was closed which removed it
+			// from the epoll set
+			delete(m.receivers, fd)
+			EpollFdCounter.Dec(1)
+		}
+	case logEvents:
+		// the shim writes a line for each rotation
+		var buf [4096]byte
+		n, err := syscall.Read(fd, buf[:])
+		if err != nil && err != syscall.EAGAIN {
+			log.WithField("error", err).Error("containerd: read log events")
+		}
+		if n == 0 && err == nil {
+			// the shim closed its end, stop watching the fd until the
+			// process exits and closes it
+			delete(m.receivers, fd)
+			syscall.EpollCtl(m.epollFd, syscall.EPOLL_CTL_DEL, fd, &syscall.EpollEvent{Fd: int32(fd)})
+			EpollFdCounter.Dec(1)
+			return
+		}
+		if n < 0 {
+			n = 0
+		}
+		for _, b := range buf[:n] {
+			if b == '\n' {
+				d.logs = append(d.logs, t.p)
 			}
-			m.m.Unlock()
 		}
 	}
 }
@@ -0,0 +1,70 @@
+package supervisor
+
+import (
+	"os"
+	goruntime "runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// pipeProcess exits when the write side of its exit pipe is closed
+type pipeProcess struct {
+	testProcess
+	r, w *os.File
+}
+
+func (p *pipeProcess) ExitFD() int {
+	return int(p.r.Fd())
+}
+
+func (p *pipeProcess) Close() error {
+	return p.r.Close()
+}
+
+// TestMonitorScales monitors 5000 processes and checks that the monitor
+// needs no goroutine for each of them and delivers every exit
+func TestMonitorScales(t *testing.T) {
+	const processes = 5000
+	var l syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &l); err != nil {
+		t.Fatal(err)
+	}
+	if l.Cur < 2*processes+100 {
+		t.Skipf("RLIMIT_NOFILE of %d is too low to monitor %d processes", l.Cur, processes)
+	}
+	m, err := NewMonitor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	before := goruntime.NumGoroutine()
+	var procs []*pipeProcess
+	for i := 0; i < processes; i++ {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := &pipeProcess{r: r, w: w}
+		if err := m.Monitor(p); err != nil {
+			t.Fatal(err)
+		}
+		procs = append(procs, p)
+	}
+	if after := goruntime.NumGoroutine(); after > before {
+		t.Fatalf("expected no goroutines for the monitored processes but %d were started", after-before)
+	}
+	go func() {
+		for _, p := range procs {
+			p.w.Close()
+		}
+	}()
+	timeout := time.After(30 * time.Second)
+	for i := 0; i < processes; i++ {
+		select {
+		case <-m.Exits():
+		case <-timeout:
+			t.Fatalf("received %d of %d exits", i, processes)
+		}
+	}
+}
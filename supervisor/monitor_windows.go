@@ -22,10 +22,30 @@ func (m *Monitor) Exits() chan runtime.Process {
 	return nil
 }
 
+func (m *Monitor) OOMs() chan string {
+	return nil
+}
+
+func (m *Monitor) LogRotations() chan runtime.Process {
+	return nil
+}
+
+func (m *Monitor) MemoryPressures() chan runtime.MemoryPressure {
+	return nil
+}
+
+func (m *Monitor) MonitorMemoryPressure(c runtime.Container) error {
+	return errors.New("MonitorMemoryPressure not implemented on Windows")
+}
+
 func (m *Monitor) Monitor(p runtime.Process) error {
 	return errors.New("Monitor not implemented on Windows")
 }
 
+func (m *Monitor) Remove(p runtime.Process) bool {
+	return false
+}
+
 func (m *Monitor) Close() error {
 	return errors.New("Monitor Close() not implemented on Windows")
 }
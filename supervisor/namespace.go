@@ -0,0 +1,73 @@
+package supervisor
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultNamespace is the namespace of the containers of requests that
+	// set no namespace, its containers keep their ids in the supervisor
+	DefaultNamespace = "default"
+	// namespacesDir is the directory of the state dir that holds the state
+	// directories of the namespaces other than the default one
+	namespacesDir = "namespaces"
+	// namespaceSeparator separates the namespace from the container's id in
+	// the supervisor's ids, runc allows it in container ids
+	namespaceSeparator = "+"
+)
+
+var namespaceRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9_.-]*[a-z0-9])?$`)
+
+// ValidNamespace returns true when the namespace can be used by requests,
+// namespaces are lowercase letters, digits, dots, dashes and underscores that
+// start and end with a letter or a digit
+func ValidNamespace(namespace string) bool {
+	return len(namespace) <= 64 && namespaceRe.MatchString(namespace)
+}
+
+// QualifiedID returns the supervisor's id of the container id of the
+// namespace.  The containers of the default namespace keep their id unless it
+// contains the separator of namespaces.
+func QualifiedID(namespace, id string) string {
+	if id == "" {
+		return ""
+	}
+	if (namespace == "" || namespace == DefaultNamespace) && !strings.Contains(id, namespaceSeparator) {
+		return id
+	}
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return namespace + namespaceSeparator + id
+}
+
+// SplitID returns the namespace of the supervisor's id of a container and the
+// container's id in its namespace
+func SplitID(id string) (namespace, nsID string) {
+	if i := strings.Index(id, namespaceSeparator); i > 0 {
+		return id[:i], id[i+1:]
+	}
+	return DefaultNamespace, id
+}
+
+// containerRoot returns the state directory of the namespace of the container,
+// the state directory of the supervisor for the default namespace
+func (s *Supervisor) containerRoot(id string) string {
+	namespace, _ := SplitID(id)
+	if namespace == DefaultNamespace {
+		return s.stateDir
+	}
+	return filepath.Join(s.stateDir, namespacesDir, namespace)
+}
+
+// inNamespace returns true when the container with the supervisor's id
+// belongs to the namespace, an empty namespace matches every container
+func inNamespace(id, namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+	ns, _ := SplitID(id)
+	return ns == namespace
+}
@@ -0,0 +1,181 @@
+package supervisor
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// netnsDir is the directory in the state directory that holds the named
+// network namespaces
+const netnsDir = "netns"
+
+// networkNamespaceRe matches the names of network namespaces
+var networkNamespaceRe = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// NetworkNamespaceInfo is the state of a named network namespace
+type NetworkNamespaceInfo struct {
+	Name       string
+	Path       string
+	Source     string
+	Containers []string
+	Mounted    bool
+}
+
+func networkNamespaceInfo(n *runtime.NetworkNamespace) NetworkNamespaceInfo {
+	return NetworkNamespaceInfo{
+		Name:       n.Name,
+		Path:       n.Path,
+		Source:     n.Source,
+		Containers: append([]string(nil), n.Containers...),
+		Mounted:    n.Mounted(),
+	}
+}
+
+// CreateNetworkNamespaceTask bind mounts a new network namespace, or the
+// network namespace of a running container, under a name
+type CreateNetworkNamespaceTask struct {
+	baseTask
+	Name string
+	// Source is the container whose network namespace is kept under the
+	// name (optional)
+	Source           string
+	NetworkNamespace NetworkNamespaceInfo
+}
+
+func (s *Supervisor) createNetworkNamespace(t *CreateNetworkNamespaceTask) error {
+	if len(t.Name) > 64 || !networkNamespaceRe.MatchString(t.Name) {
+		return ErrInvalidNetnsName
+	}
+	if _, ok := s.networkNamespaces[t.Name]; ok {
+		return ErrNetworkNamespaceExists
+	}
+	var source runtime.Container
+	if t.Source != "" {
+		i, ok := s.containers[t.Source]
+		if !ok {
+			return ErrContainerNotFound
+		}
+		source = i.container
+	}
+	n, err := runtime.NewNetworkNamespace(filepath.Join(s.stateDir, netnsDir), t.Name, source)
+	if err != nil {
+		return err
+	}
+	s.addNetworkNamespace(n)
+	t.NetworkNamespace = networkNamespaceInfo(n)
+	return nil
+}
+
+func (s *Supervisor) addNetworkNamespace(n *runtime.NetworkNamespace) {
+	if s.networkNamespaces == nil {
+		s.networkNamespaces = make(map[string]*runtime.NetworkNamespace)
+	}
+	s.networkNamespaces[n.Name] = n
+}
+
+// DeleteNetworkNamespaceTask unmounts a named network namespace that no
+// container joined
+type DeleteNetworkNamespaceTask struct {
+	baseTask
+	Name string
+}
+
+func (s *Supervisor) deleteNetworkNamespace(t *DeleteNetworkNamespaceTask) error {
+	n, ok := s.networkNamespaces[t.Name]
+	if !ok {
+		return ErrNetworkNamespaceNotFound
+	}
+	if len(n.Containers) > 0 {
+		return ErrNetworkNamespaceInUse
+	}
+	delete(s.networkNamespaces, t.Name)
+	return n.Delete()
+}
+
+// GetNetworkNamespacesTask returns the named network namespaces or only the
+// one with the name
+type GetNetworkNamespacesTask struct {
+	baseTask
+	Name              string
+	NetworkNamespaces []NetworkNamespaceInfo
+}
+
+func (s *Supervisor) getNetworkNamespaces(t *GetNetworkNamespacesTask) error {
+	if t.Name != "" {
+		n, ok := s.networkNamespaces[t.Name]
+		if !ok {
+			return ErrNetworkNamespaceNotFound
+		}
+		t.NetworkNamespaces = append(t.NetworkNamespaces, networkNamespaceInfo(n))
+		return nil
+	}
+	for _, n := range s.networkNamespaces {
+		t.NetworkNamespaces = append(t.NetworkNamespaces, networkNamespaceInfo(n))
+	}
+	return nil
+}
+
+// joinNetworkNamespace sets the path of the named network namespace in the
+// bundle's spec, a container cannot join it when its group shares a network
+// namespace already
+func (s *Supervisor) joinNetworkNamespace(name, bundle string, g *group) (*runtime.NetworkNamespace, error) {
+	n, ok := s.networkNamespaces[name]
+	if !ok {
+		return nil, ErrNetworkNamespaceNotFound
+	}
+	if g != nil {
+		for _, ns := range g.sandbox.Namespaces {
+			if ns == "network" {
+				return nil, ErrGroupSharesNetwork
+			}
+		}
+	}
+	if err := runtime.JoinNetworkNamespace(bundle, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// leaveNetworkNamespace removes the container from the named network
+// namespace it joined, the namespace is kept until it is deleted
+func (s *Supervisor) leaveNetworkNamespace(id string) {
+	for _, n := range s.networkNamespaces {
+		if !n.Contains(id) {
+			continue
+		}
+		if err := n.Remove(id); err != nil {
+			log.WithField("error", err).Error("containerd: remove container from network namespace")
+		}
+		return
+	}
+}
+
+// restoreNetworkNamespaces loads the named network namespaces, it is called
+// before the containers are restored
+func (s *Supervisor) restoreNetworkNamespaces() error {
+	namespaces, err := runtime.LoadNetworkNamespaces(filepath.Join(s.stateDir, netnsDir))
+	if err != nil {
+		return err
+	}
+	for _, n := range namespaces {
+		if !n.Mounted() {
+			log.WithField("name", n.Name).Warn("containerd: named network namespace is no longer mounted")
+		}
+		s.addNetworkNamespace(n)
+	}
+	return nil
+}
+
+// pruneNetworkNamespaces removes the containers that were not restored from
+// the named network namespaces
+func (s *Supervisor) pruneNetworkNamespaces() {
+	for _, n := range s.networkNamespaces {
+		for _, id := range append([]string(nil), n.Containers...) {
+			if _, ok := s.containers[id]; !ok {
+				s.leaveNetworkNamespace(id)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+)
+
+func TestNetworkNamespaceMembers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-netns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, netnsDir, "pod")
+	if err := os.MkdirAll(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	state := `{"name":"pod","path":"` + filepath.Join(path, "net") + `","containers":["a","gone"]}`
+	if err := ioutil.WriteFile(filepath.Join(path, "state.json"), []byte(state), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := &Supervisor{
+		stateDir:   dir,
+		containers: map[string]*containerInfo{"a": {}},
+	}
+	if err := s.restoreNetworkNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+	s.pruneNetworkNamespaces()
+	task := &GetNetworkNamespacesTask{Name: "pod"}
+	if err := s.getNetworkNamespaces(task); err != nil {
+		t.Fatal(err)
+	}
+	n := task.NetworkNamespaces[0]
+	if len(n.Containers) != 1 || n.Containers[0] != "a" || n.Mounted {
+		t.Fatalf("expected the unmounted namespace with the container a but received %+v", n)
+	}
+
+	if err := s.createNetworkNamespace(&CreateNetworkNamespaceTask{Name: "../pod"}); err != ErrInvalidNetnsName {
+		t.Fatalf("expected %v but received %v", ErrInvalidNetnsName, err)
+	}
+	if err := s.createNetworkNamespace(&CreateNetworkNamespaceTask{Name: "pod"}); err != ErrNetworkNamespaceExists {
+		t.Fatalf("expected %v but received %v", ErrNetworkNamespaceExists, err)
+	}
+	if _, err := s.joinNetworkNamespace("missing", dir, nil); err != ErrNetworkNamespaceNotFound {
+		t.Fatalf("expected %v but received %v", ErrNetworkNamespaceNotFound, err)
+	}
+	g := &group{sandbox: &runtime.Sandbox{Namespaces: []string{"ipc", "network"}}}
+	if _, err := s.joinNetworkNamespace("pod", dir, g); err != ErrGroupSharesNetwork {
+		t.Fatalf("expected %v but received %v", ErrGroupSharesNetwork, err)
+	}
+	if _, err := s.joinNetworkNamespace("pod", dir, nil); err != runtime.ErrNetworkNamespaceGone {
+		t.Fatalf("expected %v but received %v", runtime.ErrNetworkNamespaceGone, err)
+	}
+
+	if err := s.deleteNetworkNamespace(&DeleteNetworkNamespaceTask{Name: "pod"}); err != ErrNetworkNamespaceInUse {
+		t.Fatalf("expected %v but received %v", ErrNetworkNamespaceInUse, err)
+	}
+	s.leaveNetworkNamespace("a")
+	if err := s.deleteNetworkNamespace(&DeleteNetworkNamespaceTask{Name: "pod"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the namespace's state to be removed but received %v", err)
+	}
+}
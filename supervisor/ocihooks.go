@@ -0,0 +1,21 @@
+package supervisor
+
+import "github.com/docker/containerd/runtime"
+
+// OCIHooksOptOutLabel excludes a container from the daemon's OCI hooks
+const OCIHooksOptOutLabel = "containerd.oci-hooks=none"
+
+// SetOCIHooks sets the hooks added to the spec of every container, it must be
+// called before the supervisor is started
+func (s *Supervisor) SetOCIHooks(h *runtime.OCIHooks) {
+	s.ociHooks = h
+}
+
+// injectOCIHooks adds the daemon's hooks to the bundle's spec unless the
+// container opted out
+func (s *Supervisor) injectOCIHooks(t *StartTask) error {
+	if s.ociHooks == nil || containsLabel(t.Labels, OCIHooksOptOutLabel) {
+		return nil
+	}
+	return runtime.InjectOCIHooks(t.BundlePath, s.ociHooks)
+}
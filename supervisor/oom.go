@@ -4,15 +4,26 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
 )
 
+// oomRestartsBucket is the bucket of the metadata database that the OOM
+// restart policies of the containers are kept in
+const oomRestartsBucket = "oom-restarts"
+
+// OOMKilledStatus is the exit status of a process killed by the OOM killer
+const OOMKilledStatus = 137
+
 type OOMTask struct {
 	baseTask
 	ID string
 }
 
 func (s *Supervisor) oom(t *OOMTask) error {
-	logrus.WithField("id", t.ID).Debug("containerd: container oom")
+	log.WithField("id", t.ID).Debug("containerd: container oom")
+	if i, ok := s.containers[t.ID]; ok {
+		i.oomKilled = true
+	}
 	s.notifySubscribers(Event{
 		Timestamp: time.Now(),
 		ID:        t.ID,
@@ -20,3 +31,107 @@ func (s *Supervisor) oom(t *OOMTask) error {
 	})
 	return nil
 }
+
+// OOMRestartPolicy restarts a container whose init process was killed after
+// the container ran out of memory
+type OOMRestartPolicy struct {
+	// MaxRestarts is the number of times the container is restarted, 0
+	// restarts it every time
+	MaxRestarts int `json:"maxRestarts,omitempty"`
+	// MemoryIncrement is added to the memory limit of the bundle's spec
+	// before each restart
+	MemoryIncrement int64 `json:"memoryIncrement,omitempty"`
+	// MemoryLimitCap is the limit the memory limit is not raised above, 0
+	// for no cap
+	MemoryLimitCap int64 `json:"memoryLimitCap,omitempty"`
+	// Restarts is the number of times the container was restarted
+	Restarts int `json:"restarts"`
+}
+
+// restartAfterOOM sets a pending restart for a container whose init process
+// exited with status after an OOM notification if its policy allows it.  The
+// notification is cleared as it only applies to the run that ended.
+func (s *Supervisor) restartAfterOOM(i *containerInfo, status int) {
+	p, killed := i.oomRestart, i.oomKilled
+	i.oomKilled = false
+	if p == nil || !killed || status != OOMKilledStatus || i.stop != nil || i.restart != nil {
+		return
+	}
+	id := i.container.ID()
+	if p.MaxRestarts > 0 && p.Restarts >= p.MaxRestarts {
+		log.WithField("id", id).Warn("containerd: container ran out of memory after its last restart")
+		return
+	}
+	if p.MemoryIncrement > 0 {
+		s.raiseMemoryLimit(i, p)
+	}
+	p.Restarts++
+	if err := s.saveContainerRecord(oomRestartsBucket, id, p); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("containerd: save OOM restart policy of container")
+	}
+	i.restart = &RestartTask{
+		ID:            id,
+		StartResponse: make(chan StartResponse, 1),
+	}
+}
+
+// raiseMemoryLimit raises the memory limit of the container's spec by the
+// increment of the policy, the limit is left unchanged when the raised limit
+// does not fit in the quota of the container's namespace
+func (s *Supervisor) raiseMemoryLimit(i *containerInfo, p *OOMRestartPolicy) {
+	id := i.container.ID()
+	spec, err := runtime.ReadSpec(i.container.Path())
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("containerd: read spec of container")
+		return
+	}
+	r := specReservation(spec)
+	r.Memory += p.MemoryIncrement
+	if p.MemoryLimitCap > 0 && r.Memory > p.MemoryLimitCap {
+		r.Memory = p.MemoryLimitCap
+	}
+	if err := s.checkContainerQuota(id, r); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Warn("containerd: memory limit not raised after OOM")
+		return
+	}
+	limit, err := runtime.RaiseMemoryLimit(i.container.Path(), p.MemoryIncrement, p.MemoryLimitCap)
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    id,
+		}).Error("containerd: raise memory limit of container")
+	}
+	if limit > 0 {
+		i.container.InvalidateSpec()
+		// the reservation is read from the raised spec again
+		i.reservation = nil
+		log.WithFields(logrus.Fields{
+			"id":    id,
+			"limit": limit,
+		}).Info("containerd: memory limit raised after OOM")
+	}
+}
+
+// restoreOOMRestart reads the OOM restart policy of a restored container
+func (s *Supervisor) restoreOOMRestart(i *containerInfo) {
+	var p OOMRestartPolicy
+	if s.readContainerRecord(oomRestartsBucket, i.container.ID(), &p) {
+		i.oomRestart = &p
+	}
+}
+
+// deleteOOMRestart removes the saved OOM restart policy of a deleted container
+func (s *Supervisor) deleteOOMRestart(i *containerInfo) {
+	if i.oomRestart != nil {
+		s.deleteContainerRecord(oomRestartsBucket, i.container.ID())
+	}
+}
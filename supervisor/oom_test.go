@@ -0,0 +1,166 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/specs"
+)
+
+// oomContainer is a kept container with a bundle, the methods it does not
+// implement panic
+type oomContainer struct {
+	restartContainer
+	bundle string
+}
+
+func (c *oomContainer) Path() string {
+	return c.bundle
+}
+
+func (c *oomContainer) InvalidateSpec() {
+}
+
+func (c *oomContainer) Spec() (*specs.Spec, error) {
+	return runtime.ReadSpec(c.bundle)
+}
+
+func memoryLimits(t *testing.T, bundle string) (int64, int64) {
+	data, err := ioutil.ReadFile(filepath.Join(bundle, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		Linux struct {
+			Resources struct {
+				Memory struct {
+					Limit int64 `json:"limit"`
+					Swap  int64 `json:"swap"`
+				} `json:"memory"`
+			} `json:"resources"`
+		} `json:"linux"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+	return spec.Linux.Resources.Memory.Limit, spec.Linux.Resources.Memory.Swap
+}
+
+func TestRestartAfterOOM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-oom-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := `{"process": {"args": ["sh"]}, "linux": {"resources": {"memory": {"limit": 100, "swap": 150}}}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &oomContainer{bundle: dir}
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.db = openTestDB(t, dir)
+	s.startTasks = make(chan *startTask, 1)
+	i := &containerInfo{
+		container:  c,
+		lifecycle:  newLifecycle(Stopped),
+		oomRestart: &OOMRestartPolicy{MaxRestarts: 2, MemoryIncrement: 60, MemoryLimitCap: 200},
+	}
+	s.containers = map[string]*containerInfo{"r": i}
+
+	// an exit without an OOM notification is not restarted
+	s.restartAfterOOM(i, OOMKilledStatus)
+	if i.restart != nil {
+		t.Fatal("expected a container that did not run out of memory to not be restarted")
+	}
+	for n, expected := range []int64{160, 200} {
+		if err := s.oom(&OOMTask{ID: "r"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.delete(&DeleteTask{ID: "r", PID: runtime.InitProcessID, Status: OOMKilledStatus, Keep: true}); err != nil {
+			t.Fatal(err)
+		}
+		task := <-s.startTasks
+		if !task.Restart {
+			t.Fatalf("expected restart %d of the container", n+1)
+		}
+		limit, swap := memoryLimits(t, dir)
+		if limit != expected || swap != expected+50 {
+			t.Fatalf("expected the memory limit %d and swap %d but received %d and %d", expected, expected+50, limit, swap)
+		}
+		if i.oomKilled {
+			t.Fatal("expected the OOM notification to be cleared when the container started again")
+		}
+	}
+	if i.oomRestart.Restarts != 2 {
+		t.Fatalf("expected 2 restarts but received %d", i.oomRestart.Restarts)
+	}
+	var saved OOMRestartPolicy
+	if !s.readContainerRecord(oomRestartsBucket, "r", &saved) || saved.Restarts != 2 {
+		t.Fatalf("expected the restarts to be saved but received %+v", saved)
+	}
+	// the container is not restarted after MaxRestarts
+	i.lifecycle = newLifecycle(Running)
+	if err := s.oom(&OOMTask{ID: "r"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.delete(&DeleteTask{ID: "r", PID: runtime.InitProcessID, Status: OOMKilledStatus, Keep: true}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case task := <-s.startTasks:
+		t.Fatalf("expected the container to not be restarted again but received %+v", task)
+	default:
+	}
+	if i.oomKilled {
+		t.Fatal("expected the OOM notification to be cleared when the container exited")
+	}
+}
+
+func TestRestartAfterOOMQuota(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-oom-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := `{"process": {"args": ["sh"]}, "linux": {"resources": {"memory": {"limit": 100}}}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.db = openTestDB(t, dir)
+	s.startTasks = make(chan *startTask, 1)
+	s.SetQuotas(map[string]Quota{DefaultNamespace: {Memory: 180}})
+	i := &containerInfo{
+		container:   &oomContainer{bundle: dir},
+		lifecycle:   newLifecycle(Stopped),
+		oomRestart:  &OOMRestartPolicy{MemoryIncrement: 60},
+		reservation: &reservation{Memory: 100},
+	}
+	s.containers = map[string]*containerInfo{"r": i}
+
+	// the second raise would take the namespace over its quota, the
+	// container is restarted with the limit it has
+	for n, expected := range []int64{160, 160} {
+		if err := s.oom(&OOMTask{ID: "r"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.delete(&DeleteTask{ID: "r", PID: runtime.InitProcessID, Status: OOMKilledStatus, Keep: true}); err != nil {
+			t.Fatal(err)
+		}
+		if task := <-s.startTasks; !task.Restart {
+			t.Fatalf("expected restart %d of the container", n+1)
+		}
+		if limit, _ := memoryLimits(t, dir); limit != expected {
+			t.Fatalf("expected the memory limit %d but received %d", expected, limit)
+		}
+		if r := i.reserved(); r.Memory != expected {
+			t.Fatalf("expected the reservation of the raised limit %d but received %d", expected, r.Memory)
+		}
+	}
+}
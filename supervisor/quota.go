@@ -0,0 +1,233 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// Quota limits the resources of the containers of a namespace, a zero limit
+// is unlimited
+type Quota struct {
+	// Containers is the number of containers
+	Containers int `json:"containers,omitempty"`
+	// Memory is the sum of the memory limits of the containers in bytes
+	Memory int64 `json:"memory,omitempty"`
+	// CPUs is the sum of the cpus worth of time the containers may use
+	CPUs float64 `json:"cpus,omitempty"`
+	// Checkpoints is the number of checkpoints of the containers
+	Checkpoints int `json:"checkpoints,omitempty"`
+	// BundleDisk is the size of the bundles uploaded in the namespace in
+	// bytes
+	BundleDisk int64 `json:"bundleDisk,omitempty"`
+}
+
+// The resources of the quotas
+const (
+	QuotaContainers  = "containers"
+	QuotaMemory      = "memory"
+	QuotaCPUs        = "cpus"
+	QuotaCheckpoints = "checkpoints"
+	QuotaBundleDisk  = "bundle-disk"
+)
+
+// QuotaError is returned when a request would take a namespace over its quota
+type QuotaError struct {
+	Namespace string
+	// Resource is the resource of the quota that would be exceeded
+	Resource string
+	Limit    float64
+	// Used is the amount of the resource used by the namespace
+	Used float64
+	// Requested is the amount of the resource the request needs
+	Requested float64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("containerd: namespace %s exceeds its %s quota of %g, %g used and %g requested", e.Namespace, e.Resource, e.Limit, e.Used, e.Requested)
+}
+
+// LoadQuotas reads the quotas of the namespaces from a json file that maps the
+// namespaces to their quota
+func LoadQuotas(path string) (map[string]Quota, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var quotas map[string]Quota
+	if err := json.Unmarshal(data, &quotas); err != nil {
+		return nil, fmt.Errorf("containerd: parse quotas %s: %v", path, err)
+	}
+	for namespace := range quotas {
+		if !ValidNamespace(namespace) {
+			return nil, fmt.Errorf("containerd: quotas %s: invalid namespace %q", path, namespace)
+		}
+	}
+	return quotas, nil
+}
+
+// SetQuotas sets the quotas of the namespaces, the namespaces without a quota
+// are unlimited
+func (s *Supervisor) SetQuotas(quotas map[string]Quota) {
+	s.quotas = quotas
+}
+
+// defaultCPUPeriod is the cfs period of the containers whose spec has none
+const defaultCPUPeriod = 100000
+
+// reservation is the memory and the cpus that a container may use
+type reservation struct {
+	Memory int64
+	CPUs   float64
+	// period is the cfs period of the container in usecs
+	period int64
+}
+
+// reserved returns the reservation of the container, it is read from the
+// bundle's spec until the resources of the container are updated
+func (i *containerInfo) reserved() reservation {
+	if i.reservation == nil {
+		r := reservation{}
+		if spec, err := i.container.Spec(); err == nil {
+			r = specReservation(spec)
+		}
+		i.reservation = &r
+	}
+	return *i.reservation
+}
+
+// updated returns the reservation after the container's resources were
+// updated with res, the zero values of res leave the reservation unchanged
+func (r reservation) updated(res *runtime.Resource) reservation {
+	switch {
+	case res.Memory > 0:
+		r.Memory = res.Memory
+	case res.Memory < 0:
+		r.Memory = 0
+	}
+	if r.period == 0 {
+		r.period = defaultCPUPeriod
+	}
+	if res.CPUPeriod > 0 {
+		if r.CPUs > 0 && res.CPUQuota == 0 {
+			// the quota is kept in usecs so the cpus follow the period
+			r.CPUs = r.CPUs * float64(r.period) / float64(res.CPUPeriod)
+		}
+		r.period = res.CPUPeriod
+	}
+	switch {
+	case res.CPUQuota > 0:
+		r.CPUs = float64(res.CPUQuota) / float64(r.period)
+	case res.CPUQuota < 0:
+		r.CPUs = 0
+	}
+	return r
+}
+
+// checkContainerQuota returns a QuotaError when the container id with the
+// reservation r does not fit in the quota of its namespace.  A container that
+// exists already is counted with r instead of its current reservation.
+func (s *Supervisor) checkContainerQuota(id string, r reservation) error {
+	namespace, _ := SplitID(id)
+	q, ok := s.quotas[namespace]
+	if !ok {
+		return nil
+	}
+	var (
+		count int
+		used  reservation
+	)
+	for cid, i := range s.containers {
+		if cid == id || !inNamespace(cid, namespace) {
+			continue
+		}
+		count++
+		ir := i.reserved()
+		used.Memory += ir.Memory
+		used.CPUs += ir.CPUs
+	}
+	if _, exists := s.containers[id]; !exists && q.Containers > 0 && count+1 > q.Containers {
+		return &QuotaError{Namespace: namespace, Resource: QuotaContainers, Limit: float64(q.Containers), Used: float64(count), Requested: 1}
+	}
+	if q.Memory > 0 {
+		if r.Memory == 0 {
+			return ErrQuotaLimitRequired
+		}
+		if used.Memory+r.Memory > q.Memory {
+			return &QuotaError{Namespace: namespace, Resource: QuotaMemory, Limit: float64(q.Memory), Used: float64(used.Memory), Requested: float64(r.Memory)}
+		}
+	}
+	if q.CPUs > 0 {
+		if r.CPUs == 0 {
+			return ErrQuotaLimitRequired
+		}
+		if used.CPUs+r.CPUs > q.CPUs {
+			return &QuotaError{Namespace: namespace, Resource: QuotaCPUs, Limit: q.CPUs, Used: used.CPUs, Requested: r.CPUs}
+		}
+	}
+	return nil
+}
+
+// checkStartQuota returns a QuotaError when the container of the task does
+// not fit in the quota of its namespace
+func (s *Supervisor) checkStartQuota(t *StartTask) error {
+	namespace, _ := SplitID(t.ID)
+	if _, ok := s.quotas[namespace]; !ok {
+		return nil
+	}
+	spec, err := runtime.ReadSpec(t.BundlePath)
+	if err != nil {
+		return err
+	}
+	return s.checkContainerQuota(t.ID, specReservation(spec))
+}
+
+// checkUpdateQuota returns the reservation of the container after its
+// resources are updated with res, or a QuotaError when it does not fit in the
+// quota of its namespace.  The reservation is nil when the namespace has no
+// quota.
+func (s *Supervisor) checkUpdateQuota(id string, i *containerInfo, res *runtime.Resource) (*reservation, error) {
+	namespace, _ := SplitID(id)
+	if _, ok := s.quotas[namespace]; !ok {
+		return nil, nil
+	}
+	r := i.reserved().updated(res)
+	if err := s.checkContainerQuota(id, r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// checkCheckpointQuota returns a QuotaError when the namespace of the
+// container id has no checkpoint left
+func (s *Supervisor) checkCheckpointQuota(id string) error {
+	namespace, _ := SplitID(id)
+	q, ok := s.quotas[namespace]
+	if !ok || q.Checkpoints == 0 {
+		return nil
+	}
+	var count int
+	for cid, i := range s.containers {
+		if !inNamespace(cid, namespace) {
+			continue
+		}
+		checkpoints, err := i.container.Checkpoints()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		count += len(checkpoints)
+	}
+	if count+1 > q.Checkpoints {
+		return &QuotaError{Namespace: namespace, Resource: QuotaCheckpoints, Limit: float64(q.Checkpoints), Used: float64(count), Requested: 1}
+	}
+	return nil
+}
+
+// bundleDiskQuota returns the bundle disk quota of the namespace, 0 when it
+// is unlimited
+func (s *Supervisor) bundleDiskQuota(namespace string) int64 {
+	return s.quotas[namespace].BundleDisk
+}
@@ -0,0 +1,30 @@
+package supervisor
+
+import "github.com/docker/containerd/specs"
+
+// specReservation returns the memory limit and the cpus worth of time of the
+// spec, the memory reservation is used when the spec has no limit
+func specReservation(spec *specs.Spec) reservation {
+	r := reservation{period: defaultCPUPeriod}
+	res := spec.Linux.Resources
+	if res == nil {
+		return r
+	}
+	if m := res.Memory; m != nil {
+		switch {
+		case m.Limit != nil && *m.Limit > 0:
+			r.Memory = int64(*m.Limit)
+		case m.Reservation != nil && *m.Reservation > 0:
+			r.Memory = int64(*m.Reservation)
+		}
+	}
+	if cpu := res.CPU; cpu != nil {
+		if cpu.Period != nil && *cpu.Period > 0 {
+			r.period = int64(*cpu.Period)
+		}
+		if cpu.Quota != nil && *cpu.Quota > 0 {
+			r.CPUs = float64(*cpu.Quota) / float64(r.period)
+		}
+	}
+	return r
+}
@@ -0,0 +1,101 @@
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/specs"
+	ocs "github.com/opencontainers/specs/specs-go"
+)
+
+// quotaContainer is a container with a memory limit and checkpoints, the
+// methods it does not implement panic
+type quotaContainer struct {
+	runtime.Container
+	memory      uint64
+	checkpoints int
+}
+
+func (c *quotaContainer) Spec() (*specs.Spec, error) {
+	spec := &specs.Spec{}
+	spec.Linux.Resources = &ocs.Resources{
+		Memory: &ocs.Memory{Limit: &c.memory},
+	}
+	return spec, nil
+}
+
+func (c *quotaContainer) Checkpoints() ([]runtime.Checkpoint, error) {
+	return make([]runtime.Checkpoint, c.checkpoints), nil
+}
+
+func (c *quotaContainer) UpdateResources(*runtime.Resource) error {
+	return nil
+}
+
+func TestQuotas(t *testing.T) {
+	s := newTestSupervisor()
+	s.SetQuotas(map[string]Quota{
+		"team": {Containers: 2, Memory: 3 << 20, Checkpoints: 2},
+	})
+	s.containers = map[string]*containerInfo{
+		"team+a":  {container: &quotaContainer{memory: 1 << 20, checkpoints: 1}},
+		"other+b": {container: &quotaContainer{memory: 8 << 20}},
+		"c":       {container: &quotaContainer{memory: 8 << 20}},
+	}
+
+	if err := s.checkContainerQuota("team+new", reservation{Memory: 1 << 20}); err != nil {
+		t.Fatalf("expected the container to fit in the quota but received %v", err)
+	}
+	err := s.checkContainerQuota("team+new", reservation{Memory: 4 << 20})
+	if qe, ok := err.(*QuotaError); !ok || qe.Resource != QuotaMemory || qe.Used != 1<<20 {
+		t.Fatalf("expected a memory QuotaError but received %v", err)
+	}
+	if err := s.checkContainerQuota("team+new", reservation{}); err != ErrQuotaLimitRequired {
+		t.Fatalf("expected ErrQuotaLimitRequired but received %v", err)
+	}
+	if err := s.checkContainerQuota("new", reservation{}); err != nil {
+		t.Fatalf("expected the default namespace to be unlimited but received %v", err)
+	}
+
+	s.containers["team+b"] = &containerInfo{container: &quotaContainer{memory: 1 << 20}}
+	err = s.checkContainerQuota("team+new", reservation{Memory: 1 << 20})
+	if qe, ok := err.(*QuotaError); !ok || qe.Resource != QuotaContainers {
+		t.Fatalf("expected a containers QuotaError but received %v", err)
+	}
+
+	// the containers are counted with their updated resources
+	if err := s.updateContainer(&UpdateTask{ID: "team+b", Resources: &runtime.Resource{Memory: 3 << 20}}); err == nil {
+		t.Fatal("expected the update over the memory quota to fail")
+	}
+	if err := s.updateContainer(&UpdateTask{ID: "team+b", Resources: &runtime.Resource{Memory: 2 << 20}}); err != nil {
+		t.Fatal(err)
+	}
+	if r := s.containers["team+b"].reserved(); r.Memory != 2<<20 {
+		t.Fatalf("expected the reservation to be updated but it is %d", r.Memory)
+	}
+
+	if err := s.checkCheckpointQuota("team+a"); err != nil {
+		t.Fatalf("expected a checkpoint to fit in the quota but received %v", err)
+	}
+	s.containers["team+b"].container.(*quotaContainer).checkpoints = 1
+	err = s.checkCheckpointQuota("team+a")
+	if qe, ok := err.(*QuotaError); !ok || qe.Resource != QuotaCheckpoints {
+		t.Fatalf("expected a checkpoints QuotaError but received %v", err)
+	}
+}
+
+func TestReservationUpdated(t *testing.T) {
+	r := reservation{Memory: 1 << 20, CPUs: 1, period: 100000}
+	r = r.updated(&runtime.Resource{CPUQuota: 50000})
+	if r.CPUs != 0.5 || r.Memory != 1<<20 {
+		t.Fatalf("expected half a cpu and the memory to be kept but received %+v", r)
+	}
+	r = r.updated(&runtime.Resource{CPUPeriod: 200000})
+	if r.CPUs != 0.25 {
+		t.Fatalf("expected the cpus to follow the period but received %v", r.CPUs)
+	}
+	r = r.updated(&runtime.Resource{Memory: -1, CPUQuota: -1})
+	if r.CPUs != 0 || r.Memory != 0 {
+		t.Fatalf("expected the limits to be removed but received %+v", r)
+	}
+}
@@ -0,0 +1,8 @@
+package supervisor
+
+import "github.com/docker/containerd/specs"
+
+// specReservation returns no reservation as the Windows spec has no resources
+func specReservation(spec *specs.Spec) reservation {
+	return reservation{period: defaultCPUPeriod}
+}
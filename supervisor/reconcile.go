@@ -0,0 +1,80 @@
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// reconcileClass is what the state directory says about a container when the
+// daemon starts
+type reconcileClass string
+
+const (
+	// reconcileRunning containers have a running init process
+	reconcileRunning reconcileClass = "running"
+	// reconcileExited containers have no running init process, they are
+	// deleted or kept as stopped containers by their exit
+	reconcileExited reconcileClass = "exited"
+	// reconcileCorrupt containers cannot be restored, their record and state
+	// directory are kept for inspection
+	reconcileCorrupt reconcileClass = "corrupt"
+)
+
+// reconcileContainer restores the container with the id from a state
+// directory that may have been left dirty by a crash.  A container that cannot
+// be loaded is repaired and loaded again, a container that still cannot be
+// restored is not added to the supervisor and a corrupt event with the reason
+// is sent.  It is safe to call concurrently for different containers.
+func (s *Supervisor) reconcileContainer(id string) (*containerInfo, reconcileClass) {
+	i, err := s.restoreContainer(id)
+	if err != nil {
+		repairs, rerr := runtime.Repair(s.db, s.containerRoot(id), id)
+		for _, r := range repairs {
+			log.WithFields(logrus.Fields{
+				"id":     id,
+				"repair": r,
+			}).Warn("containerd: repaired container state")
+		}
+		switch {
+		case rerr != nil:
+			err = rerr
+		case len(repairs) > 0:
+			i, err = s.restoreContainer(id)
+		}
+	}
+	if err != nil {
+		log.WithFields(logrus.Fields{
+			"id":    id,
+			"error": err,
+		}).Error("containerd: container cannot be restored")
+		s.notifySubscribers(Event{
+			ID:        id,
+			Type:      "corrupt",
+			Reason:    err.Error(),
+			Timestamp: time.Now(),
+		})
+		return nil, reconcileCorrupt
+	}
+	if _, err := os.Stat(filepath.Join(i.container.Path(), "config.json")); err != nil {
+		// a running container keeps running but it cannot be started again
+		log.WithFields(logrus.Fields{
+			"id":     id,
+			"bundle": i.container.Path(),
+		}).Error("containerd: bundle of restored container is missing")
+		i.lifecycle.fail(Degraded, ErrBundleMissing)
+		s.notifySubscribers(Event{
+			ID:        id,
+			Type:      "degraded",
+			Reason:    ErrBundleMissing.Error(),
+			Timestamp: time.Now(),
+		})
+	}
+	if i.container.State() == runtime.Stopped {
+		return i, reconcileExited
+	}
+	return i, reconcileRunning
+}
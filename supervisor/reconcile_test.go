@@ -0,0 +1,85 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/metadata"
+	"github.com/docker/containerd/runtime"
+)
+
+func TestReconcileContainer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-reconcile-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := newTestSupervisor()
+	s.stateDir = filepath.Join(dir, "state")
+	if err := os.MkdirAll(s.stateDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	s.db = openTestDB(t, s.stateDir)
+	defer s.db.Close()
+	for _, id := range []string{"half-written", "no-state-dir", "no-bundle"} {
+		bundle := filepath.Join(dir, id)
+		if err := os.Mkdir(bundle, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(bundle, "config.json"), []byte(`{"process": {"args": ["sh"]}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := runtime.New(s.db, runtime.ContainerOpts{
+			Root:    s.stateDir,
+			ID:      id,
+			Bundle:  bundle,
+			Runtime: "runc",
+			Keep:    true,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a crash while the init process was created left no process.json
+	if err := os.Mkdir(filepath.Join(s.stateDir, "half-written", runtime.InitProcessID), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(s.stateDir, "no-state-dir")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "no-bundle", "config.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		return tx.Bucket(runtime.ContainersBucket).Put("corrupt", []byte("{"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+	events := s.Events(time.Time{})
+	defer s.Unsubscribe(events)
+
+	for _, id := range []string{"half-written", "no-state-dir"} {
+		i, class := s.reconcileContainer(id)
+		if class != reconcileExited {
+			t.Fatalf("expected %s to be repaired and exited but it is %s", id, class)
+		}
+		if processes, err := i.container.Processes(); err != nil || len(processes) != 0 {
+			t.Fatalf("expected %s to have no processes but received %v %v", id, processes, err)
+		}
+	}
+	i, class := s.reconcileContainer("no-bundle")
+	if class != reconcileExited || i.lifecycle.snapshot().State() != Degraded {
+		t.Fatalf("expected the container without a bundle to be degraded but it is %s", class)
+	}
+	if e := <-events; e.Type != "degraded" || e.ID != "no-bundle" || e.Reason != ErrBundleMissing.Error() {
+		t.Fatalf("expected a degraded event but received %+v", e)
+	}
+	if i, class := s.reconcileContainer("corrupt"); i != nil || class != reconcileCorrupt {
+		t.Fatalf("expected the container with a corrupt record to be corrupt but it is %s", class)
+	}
+	if e := <-events; e.Type != "corrupt" || e.ID != "corrupt" || e.Reason == "" {
+		t.Fatalf("expected a corrupt event with its reason but received %+v", e)
+	}
+}
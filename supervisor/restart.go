@@ -0,0 +1,92 @@
+package supervisor
+
+import (
+	"os"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+	netcontext "golang.org/x/net/context"
+)
+
+// RestartTask stops a container and starts it again with the same id,
+// bundle, labels, checkpoints and log file.  A stopped container that is kept
+// is started right away.  The response is sent once the container started
+// again.
+type RestartTask struct {
+	baseTask
+	ID string
+	// Signal and Timeout stop the container as a StopTask does
+	Signal        os.Signal
+	Timeout       time.Duration
+	StartResponse chan StartResponse
+}
+
+func (s *Supervisor) restart(t *RestartTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if i.restart != nil {
+		return ErrContainerRestarting
+	}
+	if i.lifecycle.snapshot().State() == Stopped {
+		s.startAgain(i, t)
+		return errDeferedResponse
+	}
+	if err := s.stopContainer(&StopTask{
+		ID:      t.ID,
+		Signal:  t.Signal,
+		Timeout: t.Timeout,
+	}); err != nil {
+		return err
+	}
+	// the container is started again when the exit of its init process
+	// is handled by delete
+	i.restart = t
+	return errDeferedResponse
+}
+
+// restarted releases the container of a pending restart whose init process
+// exited and starts it again, no exit or stop event is sent
+func (s *Supervisor) restarted(i *containerInfo) {
+	if err := i.container.Release(); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    i.container.ID(),
+		}).Error("containerd: releasing restarted container")
+	}
+	if i.stop != nil {
+		i.stop.timer.Stop()
+		i.stop = nil
+	}
+	s.startAgain(i, i.restart)
+}
+
+// startAgain starts the stopped container with the stdio of its previous init
+// process
+func (s *Supervisor) startAgain(i *containerInfo, t *RestartTask) {
+	i.restart = nil
+	i.oomKilled = false
+	i.lifecycle.transition(Starting)
+	s.startMaxRuntime(i)
+	stdio := i.stdio
+	if stdio == (runtime.Stdio{}) {
+		// the stdio of a container restored as stopped is not known
+		stdio.Socket = true
+	}
+	s.startTasks <- &startTask{
+		Err:           t.ErrorCh(),
+		Container:     i.container,
+		StartResponse: t.StartResponse,
+		Stdin:         stdio.Stdin,
+		Stdout:        stdio.Stdout,
+		Stderr:        stdio.Stderr,
+		StdioSocket:   stdio.Socket,
+		// the client giving up on the restart does not delete the
+		// container
+		Ctx:       netcontext.Background(),
+		Lifecycle: i.lifecycle,
+		Restart:   true,
+	}
+}
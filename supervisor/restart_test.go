@@ -0,0 +1,94 @@
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// restartContainer is a kept container that counts its releases, the methods
+// it does not implement panic
+type restartContainer struct {
+	runtime.Container
+	released int
+}
+
+func (c *restartContainer) ID() string {
+	return "r"
+}
+
+func (c *restartContainer) Keep() bool {
+	return true
+}
+
+func (c *restartContainer) Release() error {
+	c.released++
+	return nil
+}
+
+func TestRestartStoppedContainer(t *testing.T) {
+	c := &restartContainer{}
+	s := newTestSupervisor()
+	s.startTasks = make(chan *startTask, 1)
+	s.containers = map[string]*containerInfo{
+		"r": {container: c, lifecycle: newLifecycle(Stopped)},
+	}
+	if err := s.restart(&RestartTask{ID: "missing"}); err != ErrContainerNotFound {
+		t.Fatalf("expected %v but received %v", ErrContainerNotFound, err)
+	}
+	if err := s.restart(&RestartTask{ID: "r"}); err != errDeferedResponse {
+		t.Fatalf("expected the response to be deferred but received %v", err)
+	}
+	task := <-s.startTasks
+	if !task.Restart || task.Container != c {
+		t.Fatalf("expected the container to be started again but received %+v", task)
+	}
+	if !task.StdioSocket {
+		t.Fatal("expected a restored container without stdio to use a stdio socket")
+	}
+	if state := s.containers["r"].lifecycle.snapshot().State(); state != Starting {
+		t.Fatalf("expected the container to be starting but it is %s", state)
+	}
+}
+
+func TestRestartAfterExit(t *testing.T) {
+	c := &restartContainer{}
+	s := newTestSupervisor()
+	s.startTasks = make(chan *startTask, 1)
+	stdio := runtime.Stdio{Stdin: "in", Stdout: "out", Stderr: "err"}
+	s.containers = map[string]*containerInfo{
+		"r": {
+			container: c,
+			lifecycle: newLifecycle(Stopped),
+			stdio:     stdio,
+			restart:   &RestartTask{ID: "r"},
+			stop:      &pendingStop{timer: time.NewTimer(time.Hour)},
+		},
+	}
+	events := s.Events(time.Time{})
+	defer s.Unsubscribe(events)
+
+	if err := s.restart(&RestartTask{ID: "r"}); err != ErrContainerRestarting {
+		t.Fatalf("expected %v but received %v", ErrContainerRestarting, err)
+	}
+	if err := s.delete(&DeleteTask{ID: "r", PID: runtime.InitProcessID, Keep: true}); err != nil {
+		t.Fatal(err)
+	}
+	if c.released != 1 {
+		t.Fatalf("expected the container to be released once but it was released %d times", c.released)
+	}
+	task := <-s.startTasks
+	if !task.Restart || task.Stdin != stdio.Stdin || task.Stdout != stdio.Stdout || task.Stderr != stdio.Stderr {
+		t.Fatalf("expected the container to be started again with its stdio but received %+v", task)
+	}
+	i := s.containers["r"]
+	if i.restart != nil || i.stop != nil {
+		t.Fatal("expected the pending restart and stop to be cleared")
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("expected no event before the container started again but received %+v", e)
+	default:
+	}
+}
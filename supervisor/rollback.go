@@ -0,0 +1,85 @@
+package supervisor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+)
+
+// CreateError is the error of the step of creating a container that failed,
+// the steps that completed before it were undone
+type CreateError struct {
+	// Step is the name of the step that failed
+	Step string
+	Err  error
+}
+
+func (e *CreateError) Error() string {
+	return fmt.Sprintf("containerd: create container failed at %s: %v", e.Step, e.Err)
+}
+
+// stepError returns the error of the step that failed
+func stepError(step string, err error) error {
+	return &CreateError{Step: step, Err: err}
+}
+
+// rollback undoes the completed steps of creating a container when a later
+// step fails
+type rollback struct {
+	id    string
+	steps []undoStep
+}
+
+type undoStep struct {
+	name string
+	undo func() error
+}
+
+// undo adds a completed step of creating the task's container that is undone
+// if a later step fails
+func (t *StartTask) undo(name string, fn func() error) {
+	if t.rollback == nil {
+		t.rollback = &rollback{id: t.ID}
+	}
+	t.rollback.steps = append(t.rollback.steps, undoStep{name: name, undo: fn})
+}
+
+// saveBundleSpec keeps the bundle's config.json so that the changes made to
+// the spec while the container is created are undone if it fails
+func saveBundleSpec(t *StartTask) error {
+	path := filepath.Join(t.BundlePath, "config.json")
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	t.undo("bundle", func() error {
+		return ioutil.WriteFile(path, data, fi.Mode())
+	})
+	return nil
+}
+
+// run undoes the steps in the reverse order they completed in, a step that
+// fails to be undone is logged and the steps before it are still undone
+func (r *rollback) run() {
+	if r == nil {
+		return
+	}
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		step := r.steps[i]
+		if err := step.undo(); err != nil {
+			log.WithFields(logrus.Fields{
+				"error": err,
+				"id":    r.id,
+				"step":  step.name,
+			}).Error("containerd: undo step of creating container")
+		}
+	}
+	r.steps = nil
+}
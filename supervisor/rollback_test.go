@@ -0,0 +1,58 @@
+package supervisor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+)
+
+func TestCreateRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-rollback-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := `{"process": {"args": ["sh"]}}`
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSupervisor()
+	s.stateDir = dir
+	s.ociHooks = &runtime.OCIHooks{Prestart: []runtime.OCIHook{{Path: "/usr/bin/hook"}}}
+	task := &StartTask{ID: "c", BundlePath: dir, Group: "missing"}
+	if err := saveBundleSpec(task); err != nil {
+		t.Fatal(err)
+	}
+	var undone []string
+	task.undo("volumes", func() error {
+		undone = append(undone, "volumes")
+		return nil
+	})
+	task.undo("pre-create", func() error {
+		undone = append(undone, "pre-create")
+		return nil
+	})
+
+	err = s.start(task)
+	e, ok := err.(*CreateError)
+	if !ok || e.Step != "group" || e.Err != ErrGroupNotFound {
+		t.Fatalf("expected the group step to fail with %v but received %v", ErrGroupNotFound, err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != config {
+		t.Fatalf("expected the spec with the oci hooks to be restored but received %s", data)
+	}
+	if len(undone) != 2 || undone[0] != "pre-create" || undone[1] != "volumes" {
+		t.Fatalf("expected the steps to be undone in reverse order but received %v", undone)
+	}
+	if len(s.containers) != 0 {
+		t.Fatal("expected the container to not be registered")
+	}
+}
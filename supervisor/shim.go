@@ -0,0 +1,75 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// shimDied returns true when the shim of a process exited without recording
+// the exit status of a process that is still running
+func shimDied(p runtime.Process, err error) bool {
+	return err == runtime.ErrProcessNotExited && p.State() == runtime.Running
+}
+
+// recoverShim starts a new shim for a process whose shim died.  The container
+// is marked degraded when the process cannot be adopted or when the shim of
+// an adopted process dies again.
+func (s *Supervisor) recoverShim(proc runtime.Process) error {
+	id := proc.Container().ID()
+	log.WithFields(logrus.Fields{
+		"id":        id,
+		"pid":       proc.ID(),
+		"systemPid": proc.SystemPid(),
+	}).Warn("containerd: shim died while its process is running")
+	s.notifySubscribers(Event{
+		ID:        id,
+		PID:       proc.ID(),
+		Type:      "shim-died",
+		Timestamp: time.Now(),
+	})
+	i, ok := s.containers[id]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if i.adopted[proc.ID()] {
+		s.degrade(i, proc, ErrShimDiedAgain)
+		return nil
+	}
+	p, err := i.container.Adopt(proc.ID())
+	if err != nil {
+		s.degrade(i, proc, err)
+		return nil
+	}
+	if err := s.monitorProcess(p); err != nil {
+		s.degrade(i, proc, err)
+		return nil
+	}
+	if i.adopted == nil {
+		i.adopted = make(map[string]bool)
+	}
+	i.adopted[proc.ID()] = true
+	s.notifySubscribers(Event{
+		ID:        id,
+		PID:       proc.ID(),
+		Type:      "shim-adopted",
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+func (s *Supervisor) degrade(i *containerInfo, proc runtime.Process, err error) {
+	log.WithFields(logrus.Fields{
+		"error": err,
+		"id":    i.container.ID(),
+		"pid":   proc.ID(),
+	}).Error("containerd: adopt process of dead shim")
+	i.lifecycle.fail(Degraded, err)
+	s.notifySubscribers(Event{
+		ID:        i.container.ID(),
+		PID:       proc.ID(),
+		Type:      "degraded",
+		Timestamp: time.Now(),
+	})
+}
@@ -1,6 +1,11 @@
 package supervisor
 
-import "os"
+import (
+	"os"
+	"syscall"
+
+	"github.com/docker/containerd/runtime"
+)
 
 type SignalTask struct {
 	baseTask
@@ -20,8 +25,19 @@ func (s *Supervisor) signal(t *SignalTask) error {
 	}
 	for _, p := range processes {
 		if p.ID() == t.PID {
-			return p.Signal(t.Signal)
+			if err := p.Signal(t.Signal); err != nil {
+				return err
+			}
+			if p.ID() == runtime.InitProcessID && isStopSignal(t.Signal) {
+				i.lifecycle.transition(Stopping)
+			}
+			return nil
 		}
 	}
 	return ErrProcessNotFound
 }
+
+// isStopSignal returns true for the signals that stop a container by default
+func isStopSignal(s os.Signal) bool {
+	return s == syscall.SIGTERM || s == syscall.SIGKILL || s == syscall.SIGINT
+}
@@ -1,12 +1,15 @@
 package supervisor
 
 import (
+	"io"
 	"os"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/docker/containerd/runtime"
 	"github.com/docker/containerd/specs"
+	"github.com/docker/containerd/tracing"
 )
 
 type testProcess struct {
@@ -29,10 +32,46 @@ func (p *testProcess) Stdio() runtime.Stdio {
 	return runtime.Stdio{}
 }
 
+func (p *testProcess) OutputFD() int {
+	return -1
+}
+
+func (p *testProcess) ReadOutput() bool {
+	return false
+}
+
+func (p *testProcess) StdioMode() string {
+	return ""
+}
+
+func (p *testProcess) ShimAlive() bool {
+	return true
+}
+
 func (p *testProcess) SystemPid() int {
 	return -1
 }
 
+func (p *testProcess) StartedAt() time.Time {
+	return time.Time{}
+}
+
+func (p *testProcess) Attach(replay int) (<-chan runtime.Frame, func() error) {
+	return nil, func() error { return nil }
+}
+
+func (p *testProcess) OpenStdin() (io.WriteCloser, error) {
+	return nil, nil
+}
+
+func (p *testProcess) Trace(tracing.SpanContext) error {
+	return nil
+}
+
+func (p *testProcess) LogFD() int {
+	return -1
+}
+
 func (p *testProcess) ExitFD() int {
 	return -1
 }
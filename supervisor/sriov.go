@@ -0,0 +1,55 @@
+package supervisor
+
+import "github.com/docker/containerd/runtime"
+
+// SetPhysicalFunctions sets the SR-IOV physical functions whose virtual
+// functions are allocated to the containers, each of them must have virtual
+// functions enabled
+func (s *Supervisor) SetPhysicalFunctions(pfs []string) error {
+	for _, pf := range pfs {
+		if _, err := runtime.VirtualFunctions(pf); err != nil {
+			return err
+		}
+	}
+	s.physicalFunctions = pfs
+	return nil
+}
+
+// allocateVF sets the physical function and the index of the virtual
+// function requested by the task to a virtual function that no container
+// has.  The virtual functions of the containers are in their records so the
+// pool is rebuilt from the containers when the daemon restarts, a virtual
+// function returns to the pool when its container is deleted.
+func (s *Supervisor) allocateVF(t *StartTask) error {
+	vf := t.Network.VF
+	if vf == nil {
+		return nil
+	}
+	used := make(map[runtime.VFConfig]bool)
+	for _, i := range s.containers {
+		if v := i.container.Network().VF; v != nil {
+			used[runtime.VFConfig{PF: v.PF, Index: v.Index}] = true
+		}
+	}
+	var configured bool
+	for _, pf := range s.physicalFunctions {
+		if vf.PF != "" && vf.PF != pf {
+			continue
+		}
+		configured = true
+		n, err := runtime.VirtualFunctions(pf)
+		if err != nil {
+			return err
+		}
+		for index := 0; index < n; index++ {
+			if !used[runtime.VFConfig{PF: pf, Index: index}] {
+				vf.PF, vf.Index = pf, index
+				return nil
+			}
+		}
+	}
+	if !configured {
+		return ErrPhysicalFunctionNotFound
+	}
+	return ErrNoFreeVirtualFunction
+}
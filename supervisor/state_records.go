@@ -0,0 +1,97 @@
+package supervisor
+
+import (
+	"encoding/json"
+
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/metadata"
+)
+
+// metadataFile is the database of the state dir holding the records of the
+// containers
+const metadataFile = "metadata.db"
+
+// daemonBuckets are the buckets of the metadata database whose keys are not
+// container ids, they are not part of the records of containers
+var daemonBuckets = map[string]bool{
+	leasesBucket:  true,
+	uploadsBucket: true,
+	ipam.Bucket:   true,
+}
+
+// saveContainerRecord writes v as the json record of the container in the
+// bucket of the metadata database
+func (s *Supervisor) saveContainerRecord(bucket, id string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *metadata.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucket)
+		if err != nil {
+			return err
+		}
+		return b.Put(id, data)
+	})
+}
+
+// readContainerRecord decodes the record of the container in the bucket into
+// v, it returns false when there is no record or it cannot be decoded
+func (s *Supervisor) readContainerRecord(bucket, id string, v interface{}) bool {
+	var data []byte
+	if err := s.db.View(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			data = b.Get(id)
+		}
+		return nil
+	}); err != nil {
+		log.WithField("error", err).Errorf("containerd: read %s of container", bucket)
+		return false
+	}
+	if data == nil {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		log.WithField("error", err).Errorf("containerd: decode %s of container", bucket)
+		return false
+	}
+	return true
+}
+
+// deleteContainerRecord removes the record of the container in the bucket
+func (s *Supervisor) deleteContainerRecord(bucket, id string) {
+	if err := s.db.Update(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			return b.Delete(id)
+		}
+		return nil
+	}); err != nil {
+		log.WithField("error", err).Errorf("containerd: delete %s of container", bucket)
+	}
+}
+
+// containerRecords returns the ids of the containers with a record in the
+// bucket
+func (s *Supervisor) containerRecords(bucket string) []string {
+	var ids []string
+	s.db.View(func(tx *metadata.Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			b.ForEach(func(id string, _ []byte) error {
+				ids = append(ids, id)
+				return nil
+			})
+		}
+		return nil
+	})
+	return ids
+}
+
+// pruneContainerRecords removes the records in the bucket of containers that
+// were not restored
+func (s *Supervisor) pruneContainerRecords(bucket string) {
+	for _, id := range s.containerRecords(bucket) {
+		if _, ok := s.containers[id]; !ok {
+			s.deleteContainerRecord(bucket, id)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package supervisor
+
+import (
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// statsInterval is the time between the stats collected for subscribers
+const statsInterval = time.Second
+
+// statsCollector holds the subscribers to the stats of a container
+type statsCollector struct {
+	id          string
+	subscribers map[chan *runtime.Stat]struct{}
+}
+
+// SubscribeStats returns a channel that receives the stats of the container
+// every statsInterval.  Stats are collected only while a container has
+// subscribers, the first subscriber starts the collection and the last one
+// to unsubscribe stops it.  The channel is closed when the container is gone.
+func (s *Supervisor) SubscribeStats(id string) chan *runtime.Stat {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	if s.statsCollectors == nil {
+		s.statsCollectors = make(map[string]*statsCollector)
+	}
+	c := make(chan *runtime.Stat, 1)
+	sc, ok := s.statsCollectors[id]
+	if !ok {
+		sc = &statsCollector{
+			id:          id,
+			subscribers: make(map[chan *runtime.Stat]struct{}),
+		}
+		s.statsCollectors[id] = sc
+		StatsCollectorsCounter.Inc(1)
+	}
+	sc.subscribers[c] = struct{}{}
+	// a single goroutine collects the stats of all containers
+	if !s.statsRunning {
+		s.statsRunning = true
+		go s.collectStats()
+	}
+	return c
+}
+
+// UnsubscribeStats removes the channel from the subscribers of the container
+func (s *Supervisor) UnsubscribeStats(id string, c chan *runtime.Stat) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	sc, ok := s.statsCollectors[id]
+	if !ok {
+		return
+	}
+	if _, ok := sc.subscribers[c]; !ok {
+		return
+	}
+	delete(sc.subscribers, c)
+	close(c)
+	if len(sc.subscribers) == 0 {
+		s.stopStats(sc)
+	}
+}
+
+// stopStats stops collecting the container's stats, it must be called with
+// the stats lock held
+func (s *Supervisor) stopStats(sc *statsCollector) {
+	delete(s.statsCollectors, sc.id)
+	StatsCollectorsCounter.Dec(1)
+}
+
+// collectStats collects the stats of the containers with subscribers every
+// statsInterval and returns once no container has subscribers
+func (s *Supervisor) collectStats() {
+	defer s.HandlePanic()
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		s.statsLock.Lock()
+		if len(s.statsCollectors) == 0 {
+			s.statsRunning = false
+			s.statsLock.Unlock()
+			return
+		}
+		tasks := make(map[*statsCollector]*StatsTask, len(s.statsCollectors))
+		for _, sc := range s.statsCollectors {
+			tasks[sc] = &StatsTask{
+				ID:   sc.id,
+				Stat: make(chan *runtime.Stat, 1),
+			}
+		}
+		s.statsLock.Unlock()
+		// the tasks are all sent before waiting so the containers' stats are
+		// collected concurrently
+		for _, t := range tasks {
+			s.SendTask(t)
+		}
+		for sc, t := range tasks {
+			switch err := <-t.ErrorCh(); err {
+			case nil:
+				s.publishStats(sc, <-t.Stat)
+			case ErrContainerNotFound:
+				s.closeStats(sc)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// closeStats closes the subscribers of a container that is gone
+func (s *Supervisor) closeStats(sc *statsCollector) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	if s.statsCollectors[sc.id] != sc {
+		return
+	}
+	for c := range sc.subscribers {
+		close(c)
+	}
+	s.stopStats(sc)
+}
+
+// publishStats sends the stats to the subscribers, subscribers that did not
+// receive the previous stats yet get the latest ones instead
+func (s *Supervisor) publishStats(sc *statsCollector, stat *runtime.Stat) {
+	s.statsLock.Lock()
+	defer s.statsLock.Unlock()
+	if s.statsCollectors[sc.id] != sc {
+		return
+	}
+	for c := range sc.subscribers {
+		select {
+		case <-c:
+		default:
+		}
+		c <- stat
+	}
+}
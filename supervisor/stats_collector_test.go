@@ -0,0 +1,65 @@
+package supervisor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// statsLoop answers the stats tasks of the supervisor like the event loop
+func statsLoop(s *Supervisor, stat *runtime.Stat) {
+	for t := range s.tasks {
+		st := t.(*StatsTask)
+		if stat == nil {
+			st.ErrorCh() <- ErrContainerNotFound
+			continue
+		}
+		st.ErrorCh() <- nil
+		st.Stat <- stat
+	}
+}
+
+func TestStatsCollectedWhileSubscribed(t *testing.T) {
+	s := &Supervisor{
+		tasks: make(chan Task, 1),
+	}
+	stat := &runtime.Stat{Timestamp: time.Now()}
+	go statsLoop(s, stat)
+	a := s.SubscribeStats("test")
+	b := s.SubscribeStats("test")
+	if n := len(s.statsCollectors); n != 1 {
+		t.Fatalf("expected a single collector for the container but received %d", n)
+	}
+	for _, c := range []chan *runtime.Stat{a, b} {
+		if st := <-c; st != stat {
+			t.Fatalf("expected %v but received %v", stat, st)
+		}
+	}
+	s.UnsubscribeStats("test", a)
+	if n := len(s.statsCollectors); n != 1 {
+		t.Fatalf("expected the collector to run while subscribed but received %d collectors", n)
+	}
+	s.UnsubscribeStats("test", b)
+	if n := len(s.statsCollectors); n != 0 {
+		t.Fatalf("expected the collector to stop after the last subscriber but received %d collectors", n)
+	}
+}
+
+func TestStatsClosedForMissingContainer(t *testing.T) {
+	s := &Supervisor{
+		tasks: make(chan Task, 1),
+	}
+	go statsLoop(s, nil)
+	c := s.SubscribeStats("missing")
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Fatal("expected the stats channel to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stats channel was not closed")
+	}
+	// unsubscribing after the collector stopped is a no-op
+	s.UnsubscribeStats("missing", c)
+}
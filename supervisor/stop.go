@@ -0,0 +1,123 @@
+package supervisor
+
+import (
+	"os"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/runtime"
+)
+
+// DefaultStopTimeout is the time a container has to exit after the stop
+// signal before it is killed
+const DefaultStopTimeout = 10 * time.Second
+
+// StopTask sends the stop signal to the init process of a container and
+// kills every process of the container's cgroup if the container did not
+// exit within the timeout.  A "stop" event is sent when the container exited
+// after the signal and a "stop-forced" event when it was killed, both before
+// its exit event.
+type StopTask struct {
+	baseTask
+	ID     string
+	Signal os.Signal
+	// Timeout is DefaultStopTimeout when it is 0, the container is killed
+	// without waiting when it is negative
+	Timeout time.Duration
+}
+
+// pendingStop is the stop of a container that did not exit yet
+type pendingStop struct {
+	timer  *time.Timer
+	forced bool
+}
+
+func (s *Supervisor) stopContainer(t *StopTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if i.lifecycle.snapshot().State() == Stopped {
+		return runtime.ErrContainerExited
+	}
+	processes, err := i.container.Processes()
+	if err != nil {
+		return err
+	}
+	var init runtime.Process
+	for _, p := range processes {
+		if p.ID() == runtime.InitProcessID {
+			init = p
+		}
+	}
+	if init == nil {
+		return ErrProcessNotFound
+	}
+	timeout := t.Timeout
+	if timeout == 0 {
+		timeout = DefaultStopTimeout
+	}
+	if timeout > 0 {
+		if err := init.Signal(t.Signal); err != nil {
+			return err
+		}
+	}
+	i.lifecycle.transition(Stopping)
+	if i.stop != nil {
+		i.stop.timer.Stop()
+	}
+	stop := &pendingStop{}
+	if timeout < 0 {
+		timeout = 0
+	}
+	stop.timer = time.AfterFunc(timeout, func() {
+		s.SendTask(&killTask{ID: t.ID, stop: stop})
+	})
+	i.stop = stop
+	return nil
+}
+
+// killTask kills a container that did not exit within the timeout of its
+// stop
+type killTask struct {
+	baseTask
+	ID   string
+	stop *pendingStop
+}
+
+func (s *Supervisor) killContainer(t *killTask) error {
+	i, ok := s.containers[t.ID]
+	// the container exited or was stopped again
+	if !ok || i.stop != t.stop {
+		return nil
+	}
+	t.stop.forced = true
+	if err := i.container.Kill(); err != nil {
+		log.WithFields(logrus.Fields{
+			"error": err,
+			"id":    t.ID,
+		}).Error("containerd: kill container after the stop timeout")
+		return err
+	}
+	return nil
+}
+
+// stopped sends the event of the pending stop of the container that exited
+// with the status
+func (s *Supervisor) stopped(i *containerInfo, status int) {
+	if i.stop == nil {
+		return
+	}
+	i.stop.timer.Stop()
+	typ := "stop"
+	if i.stop.forced {
+		typ = "stop-forced"
+	}
+	i.stop = nil
+	s.notifySubscribers(Event{
+		Type:      typ,
+		Timestamp: time.Now(),
+		ID:        i.container.ID(),
+		Status:    status,
+	})
+}
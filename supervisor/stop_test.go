@@ -0,0 +1,87 @@
+package supervisor
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// stopProcess is an init process recording the signals it received, the
+// methods it does not implement panic
+type stopProcess struct {
+	runtime.Process
+	signals []os.Signal
+}
+
+func (p *stopProcess) ID() string {
+	return runtime.InitProcessID
+}
+
+func (p *stopProcess) Signal(s os.Signal) error {
+	p.signals = append(p.signals, s)
+	return nil
+}
+
+// stopContainer is a container recording whether it was killed
+type stopContainer struct {
+	runtime.Container
+	init   *stopProcess
+	killed bool
+}
+
+func (c *stopContainer) ID() string {
+	return "c"
+}
+
+func (c *stopContainer) Processes() ([]runtime.Process, error) {
+	return []runtime.Process{c.init}, nil
+}
+
+func (c *stopContainer) Kill() error {
+	c.killed = true
+	return nil
+}
+
+func TestStop(t *testing.T) {
+	for _, forced := range []bool{false, true} {
+		c := &stopContainer{init: &stopProcess{}}
+		s := newTestSupervisor()
+		s.tasks = make(chan Task, 1)
+		s.containers = map[string]*containerInfo{
+			"c": {container: c, lifecycle: newLifecycle(Running)},
+		}
+		events := s.Events(time.Time{})
+		if err := s.stopContainer(&StopTask{ID: "c", Signal: syscall.SIGTERM, Timeout: time.Millisecond}); err != nil {
+			t.Fatal(err)
+		}
+		if len(c.init.signals) != 1 || c.init.signals[0] != syscall.SIGTERM {
+			t.Fatalf("expected SIGTERM to be sent to the init process but received %v", c.init.signals)
+		}
+		kill := (<-s.tasks).(*killTask)
+		if !forced {
+			// the container exited before the kill was handled
+			s.stopped(s.containers["c"], 0)
+		}
+		if err := s.killContainer(kill); err != nil {
+			t.Fatal(err)
+		}
+		if c.killed != forced {
+			t.Fatalf("expected the container to be killed %v but it was %v", forced, c.killed)
+		}
+		if forced {
+			s.stopped(s.containers["c"], 137)
+		}
+		e := <-events
+		expected := "stop"
+		if forced {
+			expected = "stop-forced"
+		}
+		if e.Type != expected {
+			t.Fatalf("expected the event %s but received %s", expected, e.Type)
+		}
+		s.Unsubscribe(events)
+	}
+}
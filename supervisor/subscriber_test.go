@@ -0,0 +1,98 @@
+package supervisor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/containerd/metadata"
+)
+
+func newTestSupervisor() *Supervisor {
+	return &Supervisor{
+		subscribers: make(map[chan Event]*subscriber),
+	}
+}
+
+// openTestDB opens a metadata database in dir
+func openTestDB(t *testing.T, dir string) *metadata.DB {
+	db, err := metadata.Open(filepath.Join(dir, metadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestSlowSubscriberDropsEvents(t *testing.T) {
+	s := newTestSupervisor()
+	c := s.Events(time.Time{})
+	for i := 0; i < defaultBufferSize+10; i++ {
+		s.notifySubscribers(Event{Type: "exit"})
+	}
+	if s.Disconnected(c) {
+		t.Fatal("expected a subscriber with the drop policy to stay connected")
+	}
+	if dropped := s.subscribers[c].dropped; dropped != 10 {
+		t.Fatalf("expected 10 dropped events but received %d", dropped)
+	}
+	s.Unsubscribe(c)
+}
+
+func TestSlowSubscriberDisconnects(t *testing.T) {
+	s := newTestSupervisor()
+	c, err := s.Subscribe(time.Time{}, Disconnect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < defaultBufferSize+10; i++ {
+		s.notifySubscribers(Event{Type: "exit"})
+	}
+	n := 0
+	for range c {
+		n++
+	}
+	if n != defaultBufferSize {
+		t.Fatalf("expected %d events before the disconnect but received %d", defaultBufferSize, n)
+	}
+	if !s.Disconnected(c) {
+		t.Fatal("expected the subscriber to be disconnected")
+	}
+	// unsubscribing a disconnected subscriber must not close the channel again
+	s.Unsubscribe(c)
+	if len(s.subscribers) != 0 {
+		t.Fatalf("expected no subscribers but received %d", len(s.subscribers))
+	}
+}
+
+func TestSubscribeInvalidPolicy(t *testing.T) {
+	s := newTestSupervisor()
+	if _, err := s.Subscribe(time.Time{}, "block"); err != ErrInvalidOverflowPolicy {
+		t.Fatalf("expected %v but received %v", ErrInvalidOverflowPolicy, err)
+	}
+}
+
+func TestSubscribeAfter(t *testing.T) {
+	s := newTestSupervisor()
+	for i := 0; i < 3; i++ {
+		s.notifySubscribers(Event{Type: "exit"})
+		// the journal appends the events to the log
+		s.eventLog = append(s.eventLog, Event{Type: "exit", Seq: s.seq})
+	}
+	c, err := s.SubscribeAfter(1, DropEvents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Unsubscribe(c)
+	for _, seq := range []uint64{2, 3} {
+		if e := <-c; e.Seq != seq {
+			t.Fatalf("expected event %d but received %d", seq, e.Seq)
+		}
+	}
+	if e := <-c; e.Type != "live" {
+		t.Fatalf("expected the live event but received %s", e.Type)
+	}
+	s.notifySubscribers(Event{Type: "exit"})
+	if e := <-c; e.Seq != 4 {
+		t.Fatalf("expected event 4 but received %d", e.Seq)
+	}
+}
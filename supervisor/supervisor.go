@@ -3,22 +3,30 @@ package supervisor
 import (
 	"encoding/json"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/hooks"
+	"github.com/docker/containerd/ipam"
+	"github.com/docker/containerd/logging"
+	"github.com/docker/containerd/metadata"
 	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/volumes"
 )
 
 const (
 	defaultBufferSize = 2048 // size of queue in eventloop
+	restoreWorkers    = 16   // containers restored concurrently on boot
+	restoreProgress   = 100  // containers restored between progress logs
 )
 
+var log = logging.Logger("supervisor")
+
 // New returns an initialized Process supervisor.
-func New(stateDir string, runtimeName string, runtimeArgs []string) (*Supervisor, error) {
+func New(stateDir string, runtimeName string, runtimeArgs []string, cpusetPolicy string, crashDir string) (*Supervisor, error) {
 	startTasks := make(chan *startTask, 10)
 	if err := os.MkdirAll(stateDir, 0755); err != nil {
 		return nil, err
@@ -27,52 +35,127 @@ func New(stateDir string, runtimeName string, runtimeArgs []string) (*Supervisor
 	if err != nil {
 		return nil, err
 	}
+	host := runtime.HostCapabilities()
+	logCapabilities(host)
+	var cpus []int
+	if cpusetPolicy != "" && cpusetPolicy != CPUSetPolicyNone {
+		if cpus, err = runtime.AllowedCPUs(); err != nil {
+			return nil, err
+		}
+	}
+	cpusets, err := newCPUSetBalancer(cpusetPolicy, cpus)
+	if err != nil {
+		return nil, err
+	}
+	if cpusets != nil && (!host.HasCgroupController("cpuset") || !host.CgroupsDelegated) {
+		return nil, ErrCPUSetNotSupported
+	}
+	if _, err := Migrate(stateDir, MigrateOptions{
+		BackupDir: filepath.Join(stateDir, MigrationBackupsDir),
+	}); err != nil {
+		return nil, err
+	}
+	db, err := metadata.Open(filepath.Join(stateDir, metadataFile))
+	if err != nil {
+		return nil, err
+	}
 	monitor, err := NewMonitor()
 	if err != nil {
 		return nil, err
 	}
 	s := &Supervisor{
 		stateDir:    stateDir,
+		db:          db,
 		containers:  make(map[string]*containerInfo),
 		startTasks:  startTasks,
 		machine:     machine,
-		subscribers: make(map[chan Event]struct{}),
+		subscribers: make(map[chan Event]*subscriber),
 		tasks:       make(chan Task, defaultBufferSize),
 		monitor:     monitor,
 		runtime:     runtimeName,
 		runtimeArgs: runtimeArgs,
+		cpusets:     cpusets,
+		crashDir:    crashDir,
 	}
+	s.capabilities = host
 	if err := setupEventLog(s); err != nil {
 		return nil, err
 	}
 	go s.exitHandler()
-	go s.oomHandler()
+	go s.memoryPressureHandler()
+	go s.logRotationHandler()
 	if err := s.restore(); err != nil {
 		return nil, err
 	}
+	if s.cpusets != nil {
+		s.cpusets.rebalance(s.containers)
+	}
 	return s, nil
 }
 
 type containerInfo struct {
 	container runtime.Container
+	lifecycle *lifecycle
+	// adopted are the processes that were adopted by a new shim
+	adopted map[string]bool
+	// exitStatus is the exit status of the init process of a stopped
+	// container that is kept
+	exitStatus int
+	// stop is the stop of the container that is waiting for it to exit
+	stop *pendingStop
+	// restart is the restart waiting for the container to exit
+	restart *RestartTask
+	// stdio is the stdio of the init process, used when the container is
+	// restarted
+	stdio runtime.Stdio
+	// maxRuntime stops the container when it ran for too long
+	maxRuntime *maxRuntime
+	// oomRestart restarts the container when it was killed after it ran
+	// out of memory
+	oomRestart *OOMRestartPolicy
+	// oomKilled is set when the container ran out of memory since it was
+	// started
+	oomKilled bool
+	// pinnedCPUSet is the cpuset set by UpdateContainer, the container is
+	// left out of cpuset rebalancing when it is set
+	pinnedCPUSet string
+	// reservation is the memory and the cpus of the container counted
+	// against the quota of its namespace, see reserved
+	reservation *reservation
 }
 
 func setupEventLog(s *Supervisor) error {
 	if err := readEventLog(s); err != nil {
 		return err
 	}
-	logrus.WithField("count", len(s.eventLog)).Debug("containerd: read past events")
-	events := s.Events(time.Time{})
-	f, err := os.OpenFile(filepath.Join(s.stateDir, "events.log"), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0755)
+	log.WithField("count", len(s.eventLog)).Debug("containerd: read past events")
+	j, err := openEventJournal(filepath.Join(s.stateDir, eventLogFile), len(s.eventLog))
 	if err != nil {
 		return err
 	}
-	enc := json.NewEncoder(f)
+	s.trimEventLog()
+	if j.entries > len(s.eventLog) {
+		if err := j.compact(s.eventLog); err != nil {
+			log.WithField("error", err).Error("containerd: compact event journal")
+		}
+	}
+	events := s.Events(time.Time{})
 	go func() {
 		for e := range events {
+			s.subscriberLock.Lock()
 			s.eventLog = append(s.eventLog, e)
-			if err := enc.Encode(e); err != nil {
-				logrus.WithField("error", err).Error("containerd: write event to journal")
+			s.subscriberLock.Unlock()
+			if err := j.append(e); err != nil {
+				log.WithField("error", err).Error("containerd: write event to journal")
+			}
+			if j.entries >= 2*maxEventLog {
+				s.subscriberLock.Lock()
+				s.trimEventLog()
+				recent := s.eventLog
+				s.subscriberLock.Unlock()
+				if err := j.compact(recent); err != nil {
+					log.WithField("error", err).Error("containerd: compact event journal")
+				}
 			}
 		}
 	}()
@@ -80,7 +163,7 @@ func setupEventLog(s *Supervisor) error {
 }
 
 func readEventLog(s *Supervisor) error {
-	f, err := os.Open(filepath.Join(s.stateDir, "events.log"))
+	f, err := os.Open(filepath.Join(s.stateDir, eventLogFile))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil
@@ -98,6 +181,9 @@ func readEventLog(s *Supervisor) error {
 			return err
 		}
 		s.eventLog = append(s.eventLog, e)
+		if e.Seq > s.seq {
+			s.seq = e.Seq
+		}
 	}
 	return nil
 }
@@ -105,6 +191,8 @@ func readEventLog(s *Supervisor) error {
 type Supervisor struct {
 	// stateDir is the directory on the system to store container runtime state information.
 	stateDir string
+	// db holds the records of the containers
+	db *metadata.DB
 	// name of the OCI compatible runtime used to execute containers
 	runtime     string
 	runtimeArgs []string
@@ -113,11 +201,47 @@ type Supervisor struct {
 	// we need a lock around the subscribers map only because additions and deletions from
 	// the map are via the API so we cannot really control the concurrency
 	subscriberLock sync.RWMutex
-	subscribers    map[chan Event]struct{}
-	machine        Machine
-	tasks          chan Task
-	monitor        *Monitor
-	eventLog       []Event
+	subscribers    map[chan Event]*subscriber
+	// seq is the sequence number of the last event
+	seq      uint64
+	machine  Machine
+	tasks    chan Task
+	monitor  *Monitor
+	eventLog []Event
+	// statsCollectors are the containers whose stats have subscribers and
+	// statsRunning is true while their stats are collected
+	statsLock       sync.Mutex
+	statsCollectors map[string]*statsCollector
+	statsRunning    bool
+	// cpusets rebalances the cpusets of containers, it is nil when disabled
+	cpusets *cpusetBalancer
+	current currentTask
+	// crashDir is the directory crash reports are written to
+	crashDir string
+	// groups are the groups of containers sharing a sandbox's namespaces
+	groups map[string]*group
+	// networkNamespaces are the named network namespaces containers join
+	networkNamespaces map[string]*runtime.NetworkNamespace
+	// hooks are the plugins called at points of the containers' lifecycle
+	hooks *hooks.Hooks
+	// ociHooks are added to the spec of every container
+	ociHooks *runtime.OCIHooks
+	// volumes are the drivers provisioning the containers' volumes
+	volumes *volumes.Drivers
+	// capabilities are the features of the host probed at startup
+	capabilities runtime.Capabilities
+	// bundleRoot is the directory bundles uploaded over the api are unpacked in
+	bundleRoot string
+	// lastCheck is the report of the last periodic consistency check
+	checkLock sync.Mutex
+	lastCheck *CheckReport
+	// quotas are the quotas of the namespaces
+	quotas map[string]Quota
+	// physicalFunctions are the SR-IOV physical functions whose virtual
+	// functions are allocated to the containers
+	physicalFunctions []string
+	// ipam allocates the addresses of the containers from the networks
+	ipam *ipam.IPAM
 }
 
 // Stop closes all startTasks and sends a SIGTERM to each container's pid1 then waits for they to
@@ -140,52 +264,172 @@ type Event struct {
 	Timestamp time.Time `json:"timestamp"`
 	PID       string    `json:"pid,omitempty"`
 	Status    int       `json:"status,omitempty"`
+	// Level is the memory pressure level of memory-pressure events
+	Level string `json:"level,omitempty"`
+	// Reason is why the container of a corrupt event could not be restored
+	Reason string `json:"reason,omitempty"`
+	// Addresses are the addresses of the container's network namespace in
+	// start-container and restart events
+	Addresses []string `json:"addresses,omitempty"`
+	// Seq is the sequence number of the event, it keeps increasing across
+	// restarts of the daemon
+	Seq uint64 `json:"seq,omitempty"`
+}
+
+// OverflowPolicy is what happens to a subscriber that does not keep up with
+// the events
+type OverflowPolicy string
+
+const (
+	// DropEvents drops the events that do not fit in the subscriber's queue
+	DropEvents OverflowPolicy = "drop"
+	// Disconnect closes the subscriber's queue when an event does not fit
+	Disconnect OverflowPolicy = "disconnect"
+)
+
+// subscriber is the state of an events subscriber. Events are sent to its
+// bounded queue without blocking so that a slow subscriber never stalls the
+// supervisor
+type subscriber struct {
+	policy OverflowPolicy
+	// dropped is the number of events that did not fit in the queue
+	dropped int
+	// disconnected is set when the queue was closed because the subscriber
+	// fell behind
+	disconnected bool
 }
 
 // Events returns an event channel that external consumers can use to receive updates
-// on container events
+// on container events, events that do not fit in the channel are dropped
 func (s *Supervisor) Events(from time.Time) chan Event {
+	c, _ := s.Subscribe(from, DropEvents)
+	return c
+}
+
+// Subscribe returns an event channel that receives the events after from
+// followed by live events, the policy decides what happens when the channel
+// is full
+func (s *Supervisor) Subscribe(from time.Time, policy OverflowPolicy) (chan Event, error) {
+	if from.IsZero() {
+		return s.subscribe(nil, policy)
+	}
+	return s.subscribe(func(e Event) bool {
+		return e.Timestamp.After(from)
+	}, policy)
+}
+
+// SubscribeAfter returns an event channel that receives the events with a
+// sequence number greater than seq followed by live events so that a client
+// can resume from the last event it received
+func (s *Supervisor) SubscribeAfter(seq uint64, policy OverflowPolicy) (chan Event, error) {
+	return s.subscribe(func(e Event) bool {
+		return e.Seq > seq
+	}, policy)
+}
+
+// subscribe replays the past events for which replay returns true, no
+// events are replayed when it is nil
+func (s *Supervisor) subscribe(replay func(Event) bool, policy OverflowPolicy) (chan Event, error) {
+	switch policy {
+	case "":
+		policy = DropEvents
+	case DropEvents, Disconnect:
+	default:
+		return nil, ErrInvalidOverflowPolicy
+	}
 	s.subscriberLock.Lock()
 	defer s.subscriberLock.Unlock()
 	c := make(chan Event, defaultBufferSize)
+	sub := &subscriber{
+		policy: policy,
+	}
 	EventSubscriberCounter.Inc(1)
-	s.subscribers[c] = struct{}{}
-	if !from.IsZero() {
+	s.subscribers[c] = sub
+	if replay != nil {
 		// replay old event
 		for _, e := range s.eventLog {
-			if e.Timestamp.After(from) {
-				c <- e
+			if replay(e) && !sub.send(c, e) {
+				s.disconnect(c, sub)
+				return c, nil
 			}
 		}
 		// Notify the client that from now on it's live events
-		c <- Event{
+		if !sub.send(c, Event{
 			Type:      "live",
 			Timestamp: time.Now(),
+		}) {
+			s.disconnect(c, sub)
 		}
 	}
-	return c
+	return c, nil
+}
+
+// send does a non-blocking send of the event to the subscriber's queue and
+// returns false if the subscriber has to be disconnected
+func (sub *subscriber) send(c chan Event, e Event) bool {
+	select {
+	case c <- e:
+		return true
+	default:
+	}
+	if sub.policy == Disconnect {
+		return false
+	}
+	sub.dropped++
+	EventsDroppedCounter.Inc(1)
+	log.WithFields(logrus.Fields{
+		"event":   e.Type,
+		"dropped": sub.dropped,
+	}).Warn("containerd: event not sent to subscriber")
+	return true
+}
+
+// disconnect closes the subscriber's queue, it must be called with the
+// subscriber lock held for writing
+func (s *Supervisor) disconnect(c chan Event, sub *subscriber) {
+	if sub.disconnected {
+		return
+	}
+	log.WithField("events", len(c)).Warn("containerd: disconnect subscriber that fell behind")
+	sub.disconnected = true
+	close(c)
+}
+
+// Disconnected returns true if the event channel was closed because the
+// subscriber did not keep up with the events
+func (s *Supervisor) Disconnected(c chan Event) bool {
+	s.subscriberLock.RLock()
+	defer s.subscriberLock.RUnlock()
+	sub, ok := s.subscribers[c]
+	return ok && sub.disconnected
 }
 
 // Unsubscribe removes the provided channel from receiving any more events
-func (s *Supervisor) Unsubscribe(sub chan Event) {
+func (s *Supervisor) Unsubscribe(c chan Event) {
 	s.subscriberLock.Lock()
 	defer s.subscriberLock.Unlock()
-	delete(s.subscribers, sub)
-	close(sub)
+	sub, ok := s.subscribers[c]
+	if !ok {
+		return
+	}
+	delete(s.subscribers, c)
+	if !sub.disconnected {
+		close(c)
+	}
 	EventSubscriberCounter.Dec(1)
 }
 
 // notifySubscribers will send the provided event to the external subscribers
 // of the events channel
 func (s *Supervisor) notifySubscribers(e Event) {
-	s.subscriberLock.RLock()
-	defer s.subscriberLock.RUnlock()
-	for sub := range s.subscribers {
-		// do a non-blocking send for the channel
-		select {
-		case sub <- e:
-		default:
-			logrus.WithField("event", e.Type).Warn("containerd: event not sent to subscriber")
+	// the sends never block so the write lock is only held briefly
+	s.subscriberLock.Lock()
+	defer s.subscriberLock.Unlock()
+	s.seq++
+	e.Seq = s.seq
+	for c, sub := range s.subscribers {
+		if !sub.disconnected && !sub.send(c, e) {
+			s.disconnect(c, sub)
 		}
 	}
 }
@@ -197,15 +441,27 @@ func (s *Supervisor) notifySubscribers(e Event) {
 // therefore it is save to do operations in the handlers that modify state of the system or
 // state of the Supervisor
 func (s *Supervisor) Start() error {
-	logrus.WithFields(logrus.Fields{
+	log.WithFields(logrus.Fields{
 		"stateDir":    s.stateDir,
 		"runtime":     s.runtime,
 		"runtimeArgs": s.runtimeArgs,
 		"memory":      s.machine.Memory,
 		"cpus":        s.machine.Cpus,
 	}).Debug("containerd: supervisor running")
+	s.pruneVolumes()
+	s.pruneClones()
+	s.pruneAddresses()
+	s.pruneContainerRecords(maxRuntimesBucket)
+	s.pruneContainerRecords(oomRestartsBucket)
+	if s.bundleRoot != "" {
+		go s.collectGarbage()
+	}
 	go func() {
+		defer s.handleLoopPanic()
 		for i := range s.tasks {
+			if canceled(i) {
+				continue
+			}
 			s.handleTask(i)
 		}
 	}()
@@ -221,22 +477,62 @@ func (s *Supervisor) Machine() Machine {
 // SendTask sends the provided event the the supervisors main event loop
 func (s *Supervisor) SendTask(evt Task) {
 	TasksCounter.Inc(1)
+	evt.enqueued(time.Now())
 	s.tasks <- evt
 }
 
+// exitHandler sends the tasks for the exits and the OOM notifications of the
+// monitor.  The monitor delivers the OOMs of a batch before its exits, the
+// pending OOMs are sent before each exit so that they are handled first.
 func (s *Supervisor) exitHandler() {
-	for p := range s.monitor.Exits() {
-		e := &ExitTask{
-			Process: p,
+	defer s.HandlePanic()
+	exits, ooms := s.monitor.Exits(), s.monitor.OOMs()
+	for {
+		select {
+		case id := <-ooms:
+			s.SendTask(&OOMTask{
+				ID: id,
+			})
+		case p := <-exits:
+			s.sendOOMs(ooms)
+			s.SendTask(&ExitTask{
+				Process: p,
+			})
+		}
+	}
+}
+
+// sendOOMs sends the tasks for the OOM notifications that are pending
+func (s *Supervisor) sendOOMs(ooms chan string) {
+	for {
+		select {
+		case id := <-ooms:
+			s.SendTask(&OOMTask{
+				ID: id,
+			})
+		default:
+			return
+		}
+	}
+}
+
+func (s *Supervisor) memoryPressureHandler() {
+	defer s.HandlePanic()
+	for p := range s.monitor.MemoryPressures() {
+		e := &MemoryPressureTask{
+			ID:    p.ContainerID(),
+			Level: p.Level(),
 		}
 		s.SendTask(e)
 	}
 }
 
-func (s *Supervisor) oomHandler() {
-	for id := range s.monitor.OOMs() {
-		e := &OOMTask{
-			ID: id,
+func (s *Supervisor) logRotationHandler() {
+	defer s.HandlePanic()
+	for p := range s.monitor.LogRotations() {
+		e := &LogRotateTask{
+			ID:  p.Container().ID(),
+			PID: p.ID(),
 		}
 		s.SendTask(e)
 	}
@@ -247,53 +543,121 @@ func (s *Supervisor) monitorProcess(p runtime.Process) error {
 }
 
 func (s *Supervisor) restore() error {
-	dirs, err := ioutil.ReadDir(s.stateDir)
+	if err := s.restoreGroups(); err != nil {
+		return err
+	}
+	if err := s.restoreNetworkNamespaces(); err != nil {
+		return err
+	}
+	ids, err := runtime.ContainerIDs(s.db)
 	if err != nil {
 		return err
 	}
-	for _, d := range dirs {
-		if !d.IsDir() {
-			continue
-		}
-		id := d.Name()
-		container, err := runtime.Load(s.stateDir, id)
-		if err != nil {
-			return err
-		}
-		processes, err := container.Processes()
-		if err != nil {
-			return err
-		}
+	start := time.Now()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		restored int
+		classes  = make(map[reconcileClass]int)
+		work     = make(chan string)
+	)
+	for i := 0; i < restoreWorkers && i < len(ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range work {
+				info, class := s.reconcileContainer(id)
+				mu.Lock()
+				classes[class]++
+				if info != nil {
+					s.containers[id] = info
+					restored++
+					if restored%restoreProgress == 0 {
+						log.WithFields(logrus.Fields{
+							"restored": restored,
+							"total":    len(ids),
+						}).Info("containerd: restoring containers")
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, id := range ids {
+		work <- id
+	}
+	close(work)
+	wg.Wait()
+	ContainersRestoreTimer.UpdateSince(start)
+	s.pruneGroups()
+	s.pruneNetworkNamespaces()
+	log.WithFields(logrus.Fields{
+		"count":    restored,
+		"running":  classes[reconcileRunning],
+		"exited":   classes[reconcileExited],
+		"corrupt":  classes[reconcileCorrupt],
+		"duration": time.Since(start).String(),
+	}).Info("containerd: restored containers")
+	return nil
+}
 
-		ContainersCounter.Inc(1)
-		s.containers[id] = &containerInfo{
-			container: container,
-		}
+// restoreContainer loads the container with the id and monitors its running
+// processes, it is safe to call concurrently for different containers
+func (s *Supervisor) restoreContainer(id string) (*containerInfo, error) {
+	start := time.Now()
+	container, err := runtime.Load(s.db, s.containerRoot(id), id)
+	if err != nil {
+		return nil, err
+	}
+	processes, err := container.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	ContainersCounter.Inc(1)
+	i := &containerInfo{
+		container: container,
+		lifecycle: restoredLifecycle(container),
+	}
+	// stopped containers that are kept have no cgroup to monitor
+	if container.State() != runtime.Stopped {
 		if err := s.monitor.MonitorOOM(container); err != nil && err != runtime.ErrContainerExited {
-			logrus.WithField("error", err).Error("containerd: notify OOM events")
+			log.WithField("error", err).Error("containerd: notify OOM events")
 		}
-		logrus.WithField("id", id).Debug("containerd: container restored")
-		var exitedProcesses []runtime.Process
-		for _, p := range processes {
-			if p.State() == runtime.Running {
-				if err := s.monitorProcess(p); err != nil {
-					return err
-				}
-			} else {
-				exitedProcesses = append(exitedProcesses, p)
+		if err := s.monitor.MonitorMemoryPressure(container); err != nil && err != runtime.ErrContainerExited {
+			log.WithField("error", err).Error("containerd: notify memory pressure events")
+		}
+	}
+	log.WithField("id", id).Debug("containerd: container restored")
+	var exitedProcesses []runtime.Process
+	for _, p := range processes {
+		if p.ID() == runtime.InitProcessID {
+			i.stdio = p.Stdio()
+		}
+		// a running process whose shim died is sent to exit, which finds
+		// the process running and adopts it with a new shim
+		if p.State() == runtime.Running && p.ShimAlive() {
+			if err := s.monitorProcess(p); err != nil {
+				return nil, err
 			}
+		} else {
+			exitedProcesses = append(exitedProcesses, p)
 		}
-		if len(exitedProcesses) > 0 {
-			// sort processes so that init is fired last because that is how the kernel sends the
-			// exit events
-			sortProcesses(exitedProcesses)
-			for _, p := range exitedProcesses {
-				e := &ExitTask{
-					Process: p,
-				}
-				s.SendTask(e)
+	}
+	if len(exitedProcesses) > 0 {
+		// sort processes so that init is fired last because that is how the kernel sends the
+		// exit events
+		sortProcesses(exitedProcesses)
+		for _, p := range exitedProcesses {
+			e := &ExitTask{
+				Process: p,
 			}
+			s.SendTask(e)
 		}
 	}
-	return nil
+	s.restoreMaxRuntime(i)
+	s.restoreOOMRestart(i)
+	s.restorePinnedCPUSet(i)
+	ContainerRestoreTimer.UpdateSince(start)
+	return i, nil
 }
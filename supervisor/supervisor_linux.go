@@ -2,6 +2,10 @@ package supervisor
 
 func (s *Supervisor) handleTask(i Task) {
 	var err error
+	span := startTaskSpan(i)
+	defer span.Finish()
+	// the current task is kept when the task panics for the crash report
+	s.current.set(i)
 	switch t := i.(type) {
 	case *AddProcessTask:
 		err = s.addProcess(t)
@@ -13,6 +17,8 @@ func (s *Supervisor) handleTask(i Task) {
 		err = s.start(t)
 	case *DeleteTask:
 		err = s.delete(t)
+	case *RemoveTask:
+		err = s.remove(t)
 	case *ExitTask:
 		err = s.exit(t)
 	case *ExecExitTask:
@@ -21,19 +27,76 @@ func (s *Supervisor) handleTask(i Task) {
 		err = s.getContainers(t)
 	case *SignalTask:
 		err = s.signal(t)
+	case *StopTask:
+		err = s.stopContainer(t)
+	case *killTask:
+		err = s.killContainer(t)
+	case *SaveTemplateTask:
+		err = s.saveTemplate(t)
+	case *maxRuntimeTask:
+		err = s.maxRuntimeExceeded(t)
+	case *RestartTask:
+		err = s.restart(t)
+	case *DeleteProcessTask:
+		err = s.deleteProcess(t)
 	case *StatsTask:
 		err = s.stats(t)
 	case *UpdateTask:
 		err = s.updateContainer(t)
 	case *UpdateProcessTask:
 		err = s.updateProcess(t)
+	case *UpdateDeviceTask:
+		err = s.updateDevice(t)
+	case *AddressTask:
+		err = s.updateAddress(t)
+	case *ListAddressesTask:
+		err = s.listAddresses(t)
+	case *UpdateSpecTask:
+		err = s.updateSpec(t)
+	case *MemoryPressureTask:
+		err = s.memoryPressure(t)
+	case *RebalanceCPUSetsTask:
+		err = s.rebalanceCPUSets(t)
+	case *FreezeTask:
+		err = s.freeze(t)
 	case *OOMTask:
 		err = s.oom(t)
+	case *LogRotateTask:
+		err = s.logRotate(t)
+	case *CreateGroupTask:
+		err = s.createGroup(t)
+	case *DeleteGroupTask:
+		err = s.deleteGroup(t)
+	case *GetGroupsTask:
+		err = s.getGroups(t)
+	case *CreateNetworkNamespaceTask:
+		err = s.createNetworkNamespace(t)
+	case *DeleteNetworkNamespaceTask:
+		err = s.deleteNetworkNamespace(t)
+	case *GetNetworkNamespacesTask:
+		err = s.getNetworkNamespaces(t)
+	case *DumpTask:
+		err = s.dump(t)
+	case *CheckTask:
+		err = s.check(t)
+	case *GCTask:
+		err = s.gc(t)
+	case *BackupTask:
+		err = s.backup(t)
+	case *RestoreBackupTask:
+		err = s.restoreBackup(t)
+	case *HealthTask:
+		// the event loop is healthy when it answers
 	default:
 		err = ErrUnknownTask
 	}
+	span.Phase("handle")
+	if err != nil && err != errDeferedResponse {
+		span.SetTag("error", err.Error())
+	}
 	if err != errDeferedResponse {
 		i.ErrorCh() <- err
 		close(i.ErrorCh())
 	}
+	s.current.set(nil)
 }
@@ -1,9 +1,16 @@
 package supervisor
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/tracing"
+	netcontext "golang.org/x/net/context"
 )
 
 // StartResponse is the response containing a started container
@@ -16,11 +23,47 @@ type StartResponse struct {
 type Task interface {
 	// ErrorCh returns a channel used to report and error from an async task
 	ErrorCh() chan error
+	// Context returns the context of the request that sent the task
+	Context() netcontext.Context
+	// WithContext sets the context of the request that sent the task
+	WithContext(netcontext.Context)
+	// enqueued records the time the task was sent to the event loop
+	enqueued(time.Time)
+	queuedAt() time.Time
 }
 
 type baseTask struct {
-	errCh chan error
-	mu    sync.Mutex
+	errCh  chan error
+	mu     sync.Mutex
+	ctx    netcontext.Context
+	queued time.Time
+}
+
+func (t *baseTask) enqueued(at time.Time) {
+	t.mu.Lock()
+	t.queued = at
+	t.mu.Unlock()
+}
+
+func (t *baseTask) queuedAt() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queued
+}
+
+func (t *baseTask) Context() netcontext.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ctx == nil {
+		return netcontext.Background()
+	}
+	return t.ctx
+}
+
+func (t *baseTask) WithContext(ctx netcontext.Context) {
+	t.mu.Lock()
+	t.ctx = ctx
+	t.mu.Unlock()
 }
 
 func (t *baseTask) ErrorCh() chan error {
@@ -31,3 +74,51 @@ func (t *baseTask) ErrorCh() chan error {
 	}
 	return t.errCh
 }
+
+// canceled answers a task whose request was canceled or timed out while the
+// task was queued with the context's error, such a task is not handled since
+// nobody waits for its result
+func canceled(t Task) bool {
+	err := t.Context().Err()
+	if err == nil {
+		return false
+	}
+	log.WithFields(logrus.Fields{
+		"task":  taskName(t),
+		"error": err,
+	}).Debug("containerd: drop task of a canceled request")
+	t.ErrorCh() <- err
+	close(t.ErrorCh())
+	return true
+}
+
+// taskName returns the name of the task's type without the package
+func taskName(t Task) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", t), "*supervisor.")
+}
+
+// startTaskSpan starts the span of a task handled by the event loop.  The span
+// starts when the task was queued and is tagged with the task's arguments so
+// that a slow task can be identified.
+func startTaskSpan(t Task) *tracing.Span {
+	span, ctx := tracing.StartSpan(t.Context(), "supervisor."+taskName(t))
+	t.WithContext(ctx)
+	if q := t.queuedAt(); !q.IsZero() {
+		span.Start = q
+	}
+	span.Phase("queued")
+	v := reflect.Indirect(reflect.ValueOf(t))
+	for i := 0; i < v.NumField(); i++ {
+		f, field := v.Field(i), v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		switch f.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Uint32:
+			if f.Interface() != reflect.Zero(f.Type()).Interface() {
+				span.SetTag(field.Name, f.Interface())
+			}
+		}
+	}
+	return span
+}
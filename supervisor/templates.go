@@ -0,0 +1,269 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/containerd/runtime"
+)
+
+const (
+	// templatesDir is the directory of the state dir that the templates are
+	// saved in
+	templatesDir = "templates"
+	// clonesDir is the directory of the state dir that the bundles of the
+	// containers created from templates are kept in
+	clonesDir = "clones"
+)
+
+// Template is the configuration of a container saved to create new containers
+// from
+type Template struct {
+	Name string `json:"name"`
+	// Container is the ID of the container the template was saved from
+	Container  string             `json:"container"`
+	Labels     []string           `json:"labels,omitempty"`
+	LogConfig  runtime.LogConfig  `json:"logConfig"`
+	StdinOnce  bool               `json:"stdinOnce,omitempty"`
+	NUMA       runtime.NUMAConfig `json:"numa"`
+	Keep       bool               `json:"keep,omitempty"`
+	AutoRemove bool               `json:"autoRemove,omitempty"`
+	Created    time.Time          `json:"created"`
+	// Spec is the config.json of the container's bundle with an absolute
+	// root path
+	Spec json.RawMessage `json:"spec"`
+}
+
+// SaveTemplateTask saves the configuration and the spec of a container as a
+// template
+type SaveTemplateTask struct {
+	baseTask
+	ID       string
+	Name     string
+	Template *Template
+}
+
+func (s *Supervisor) saveTemplate(t *SaveTemplateTask) error {
+	if !validFileName(t.Name) {
+		return ErrInvalidTemplateName
+	}
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	c := i.container
+	spec, err := runtime.ExportSpec(c.Path())
+	if err != nil {
+		return err
+	}
+	logConfig := c.LogConfig()
+	// the path defaults to the state directory of the container
+	logConfig.Path = ""
+	tmpl := &Template{
+		Name:       t.Name,
+		Container:  t.ID,
+		Labels:     c.Labels(),
+		LogConfig:  logConfig,
+		StdinOnce:  c.StdinOnce(),
+		NUMA:       c.NUMA(),
+		Keep:       c.Keep(),
+		AutoRemove: c.AutoRemove(),
+		Created:    time.Now(),
+		Spec:       spec,
+	}
+	if err := s.writeTemplate(tmpl); err != nil {
+		return err
+	}
+	t.Template = tmpl
+	return nil
+}
+
+func (s *Supervisor) templatePath(name string) string {
+	return filepath.Join(s.stateDir, templatesDir, name+".json")
+}
+
+// writeTemplate saves a new template, it fails with ErrTemplateExists if a
+// template of the same name was saved
+func (s *Supervisor) writeTemplate(t *Template) error {
+	dir := filepath.Join(s.stateDir, templatesDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	// the link fails instead of replacing an existing template
+	if err := os.Link(f.Name(), s.templatePath(t.Name)); err != nil {
+		if os.IsExist(err) {
+			return ErrTemplateExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Supervisor) readTemplate(name string) (*Template, error) {
+	if !validFileName(name) {
+		return nil, ErrInvalidTemplateName
+	}
+	data, err := ioutil.ReadFile(s.templatePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	var t Template
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Templates returns the saved templates sorted by name
+func (s *Supervisor) Templates() ([]*Template, error) {
+	files, err := ioutil.ReadDir(filepath.Join(s.stateDir, templatesDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []*Template
+	for _, f := range files {
+		name := f.Name()
+		if strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		t, err := s.readTemplate(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			if err == ErrTemplateNotFound {
+				// deleted while the templates were listed
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// DeleteTemplate removes a template, the containers created from it are not
+// affected
+func (s *Supervisor) DeleteTemplate(name string) error {
+	if !validFileName(name) {
+		return ErrInvalidTemplateName
+	}
+	if err := os.Remove(s.templatePath(name)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrTemplateNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneTemplate creates the bundle of the task from the template's spec edited
+// with edit and adds the template's configuration to the task.  The labels of
+// the template are added before the task's labels, the template's log config
+// and NUMA config are used when the task has none and keep, autoRemove and
+// stdinOnce are set when either sets them.  The bundle is removed with the
+// container.
+func (s *Supervisor) CloneTemplate(t *StartTask, name string, edit runtime.SpecEdit) error {
+	if !validFileName(t.ID) {
+		return ErrInvalidContainerID
+	}
+	if err := edit.Validate(); err != nil {
+		return err
+	}
+	tmpl, err := s.readTemplate(name)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(s.stateDir, clonesDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	bundle := filepath.Join(dir, t.ID)
+	if err := os.Mkdir(bundle, 0700); err != nil {
+		if os.IsExist(err) {
+			return ErrContainerExists
+		}
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(bundle, "config.json"), tmpl.Spec, 0644); err != nil {
+		os.RemoveAll(bundle)
+		return err
+	}
+	if err := runtime.EditSpec(bundle, edit); err != nil {
+		os.RemoveAll(bundle)
+		return err
+	}
+	t.BundlePath = bundle
+	t.Clone = true
+	t.undo("clone", func() error {
+		return os.RemoveAll(bundle)
+	})
+	t.Labels = append(append([]string(nil), tmpl.Labels...), t.Labels...)
+	if t.LogConfig.Driver == "" && t.LogConfig.Mode == "" {
+		t.LogConfig = tmpl.LogConfig
+	}
+	if t.NUMA.Nodes == "" {
+		t.NUMA = tmpl.NUMA
+	}
+	t.Keep = t.Keep || tmpl.Keep
+	t.AutoRemove = t.AutoRemove || tmpl.AutoRemove
+	t.StdinOnce = t.StdinOnce || tmpl.StdinOnce
+	return nil
+}
+
+// removeClone removes the bundle of a deleted container if it was created
+// from a template
+func (s *Supervisor) removeClone(c runtime.Container) {
+	bundle := c.Path()
+	if filepath.Dir(bundle) != filepath.Join(s.stateDir, clonesDir) {
+		return
+	}
+	if err := os.RemoveAll(bundle); err != nil {
+		log.WithField("error", err).Error("containerd: remove bundle of cloned container")
+	}
+}
+
+// pruneClones removes the bundles created from templates for containers that
+// were not restored
+func (s *Supervisor) pruneClones() {
+	dir := filepath.Join(s.stateDir, clonesDir)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if i, ok := s.containers[f.Name()]; ok && i.container.Path() == filepath.Join(dir, f.Name()) {
+			continue
+		}
+		os.RemoveAll(filepath.Join(dir, f.Name()))
+	}
+}
+
+// validFileName returns true if name can be used as the name of a file in a
+// directory of the state dir
+func validFileName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, "/"+string(filepath.Separator))
+}
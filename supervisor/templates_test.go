@@ -0,0 +1,151 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// templateContainer is a container saved as a template, the methods it does
+// not implement panic
+type templateContainer struct {
+	runtime.Container
+	bundle string
+}
+
+func (c *templateContainer) ID() string {
+	return "source"
+}
+
+func (c *templateContainer) Path() string {
+	return c.bundle
+}
+
+func (c *templateContainer) Labels() []string {
+	return []string{"app=web"}
+}
+
+func (c *templateContainer) LogConfig() runtime.LogConfig {
+	return runtime.LogConfig{Driver: "json-file", Path: "/run/containerd/source"}
+}
+
+func (c *templateContainer) StdinOnce() bool {
+	return false
+}
+
+func (c *templateContainer) NUMA() runtime.NUMAConfig {
+	return runtime.NUMAConfig{}
+}
+
+func (c *templateContainer) Keep() bool {
+	return true
+}
+
+func (c *templateContainer) AutoRemove() bool {
+	return false
+}
+
+func TestCloneTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-template-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	bundle := filepath.Join(dir, "bundle")
+	if err := os.Mkdir(bundle, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{"root": {"path": "rootfs"}, "process": {"args": ["sh"], "env": ["A=1", "B=2"]}, "mounts": []}`
+	if err := ioutil.WriteFile(filepath.Join(bundle, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := newTestSupervisor()
+	s.stateDir = filepath.Join(dir, "state")
+	s.containers = map[string]*containerInfo{
+		"source": {container: &templateContainer{bundle: bundle}, lifecycle: newLifecycle(Running)},
+	}
+
+	if err := s.saveTemplate(&SaveTemplateTask{ID: "source", Name: "../web"}); err != ErrInvalidTemplateName {
+		t.Fatalf("expected %v but received %v", ErrInvalidTemplateName, err)
+	}
+	task := &SaveTemplateTask{ID: "source", Name: "web"}
+	if err := s.saveTemplate(task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Template.LogConfig.Path != "" {
+		t.Fatalf("expected the log path of the source to not be saved but received %q", task.Template.LogConfig.Path)
+	}
+	if err := s.saveTemplate(&SaveTemplateTask{ID: "source", Name: "web"}); err != ErrTemplateExists {
+		t.Fatalf("expected %v but received %v", ErrTemplateExists, err)
+	}
+
+	st := &StartTask{ID: "clone", Labels: []string{"tier=1"}}
+	edit := runtime.SpecEdit{
+		SetEnv:    []string{"B=3"},
+		AddMounts: []runtime.BindMount{{Source: "/srv", Destination: "/data", ReadOnly: true}},
+	}
+	if err := s.CloneTemplate(st, "web", edit); err != nil {
+		t.Fatal(err)
+	}
+	if st.BundlePath != filepath.Join(s.stateDir, clonesDir, "clone") || !st.Clone {
+		t.Fatalf("expected the bundle to be created in the state dir but received %q", st.BundlePath)
+	}
+	if len(st.Labels) != 2 || st.Labels[0] != "app=web" || st.Labels[1] != "tier=1" {
+		t.Fatalf("expected the labels of the template and the task but received %v", st.Labels)
+	}
+	if !st.Keep || st.LogConfig.Driver != "json-file" {
+		t.Fatalf("expected the configuration of the template but received %+v", st)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(st.BundlePath, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		Root struct {
+			Path string `json:"path"`
+		} `json:"root"`
+		Process struct {
+			Env []string `json:"env"`
+		} `json:"process"`
+		Mounts []struct {
+			Destination string `json:"destination"`
+		} `json:"mounts"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Root.Path != filepath.Join(bundle, "rootfs") {
+		t.Fatalf("expected the root of the source bundle but received %q", spec.Root.Path)
+	}
+	if env := spec.Process.Env; len(env) != 2 || env[0] != "A=1" || env[1] != "B=3" {
+		t.Fatalf("expected the environment to be overridden but received %v", env)
+	}
+	if len(spec.Mounts) != 1 || spec.Mounts[0].Destination != "/data" {
+		t.Fatalf("expected the mount to be added but received %+v", spec.Mounts)
+	}
+	if err := s.CloneTemplate(&StartTask{ID: "clone"}, "web", runtime.SpecEdit{}); err != ErrContainerExists {
+		t.Fatalf("expected %v but received %v", ErrContainerExists, err)
+	}
+
+	templates, err := s.Templates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(templates) != 1 || templates[0].Name != "web" || templates[0].Container != "source" {
+		t.Fatalf("expected the saved template but received %+v", templates)
+	}
+	if err := s.DeleteTemplate("web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteTemplate("web"); err != ErrTemplateNotFound {
+		t.Fatalf("expected %v but received %v", ErrTemplateNotFound, err)
+	}
+	s.removeClone(&templateContainer{bundle: st.BundlePath})
+	if _, err := os.Stat(st.BundlePath); !os.IsNotExist(err) {
+		t.Fatalf("expected the bundle of the clone to be removed but received %v", err)
+	}
+}
@@ -1,8 +1,10 @@
 package supervisor
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/containerd/runtime"
 )
 
@@ -23,17 +25,22 @@ func (s *Supervisor) updateContainer(t *UpdateTask) error {
 		switch t.State {
 		case runtime.Running:
 			if err := container.Resume(); err != nil {
+				i.lifecycle.fail(Paused, err)
 				return ErrUnknownContainerStatus
 			}
+			i.lifecycle.transition(Running)
 			s.notifySubscribers(Event{
 				ID:        t.ID,
 				Type:      "resume",
 				Timestamp: time.Now(),
 			})
 		case runtime.Paused:
+			i.lifecycle.transition(Pausing)
 			if err := container.Pause(); err != nil {
+				i.lifecycle.fail(Running, err)
 				return ErrUnknownContainerStatus
 			}
+			i.lifecycle.transition(Paused)
 			s.notifySubscribers(Event{
 				ID:        t.ID,
 				Type:      "pause",
@@ -45,7 +52,19 @@ func (s *Supervisor) updateContainer(t *UpdateTask) error {
 		return nil
 	}
 	if t.Resources != nil {
-		return container.UpdateResources(t.Resources)
+		r, err := s.checkUpdateQuota(t.ID, i, t.Resources)
+		if err != nil {
+			return err
+		}
+		if err := container.UpdateResources(t.Resources); err != nil {
+			return err
+		}
+		if r != nil {
+			i.reservation = r
+		}
+		if t.Resources.CpusetCpus != "" {
+			s.pinCPUSet(i, t.Resources.CpusetCpus)
+		}
 	}
 	return nil
 }
@@ -90,3 +109,179 @@ func (s *Supervisor) updateProcess(t *UpdateProcessTask) error {
 	}
 	return nil
 }
+
+type UpdateDeviceTask struct {
+	baseTask
+	ID     string
+	Device runtime.Device
+	Remove bool
+}
+
+func (s *Supervisor) updateDevice(t *UpdateDeviceTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	typ, update := "device-add", i.container.AddDevice
+	if t.Remove {
+		typ, update = "device-remove", i.container.RemoveDevice
+	}
+	if err := update(t.Device); err != nil {
+		return err
+	}
+	s.notifySubscribers(Event{
+		ID:        t.ID,
+		Type:      typ,
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+// UpdateSpecTask changes the mounts and the environment of the bundle's spec
+// of a stopped container that is kept, they are used when the container is
+// started again
+type UpdateSpecTask struct {
+	baseTask
+	ID   string
+	Edit runtime.SpecEdit
+}
+
+func (s *Supervisor) updateSpec(t *UpdateSpecTask) error {
+	i, ok := s.containers[t.ID]
+	if !ok {
+		return ErrContainerNotFound
+	}
+	if i.lifecycle.snapshot().State() != Stopped {
+		return ErrContainerNotStopped
+	}
+	if err := runtime.EditSpec(i.container.Path(), t.Edit); err != nil {
+		return err
+	}
+	i.container.InvalidateSpec()
+	s.notifySubscribers(Event{
+		ID:        t.ID,
+		Type:      "spec-update",
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
+// FreezeTask pauses or resumes a group of containers selected by ID or by
+// labels.  If any of the containers fails to change its state the containers
+// that were already changed are reverted.
+type FreezeTask struct {
+	baseTask
+	IDs []string
+	// Labels select the containers that have all of the labels
+	Labels []string
+	// Group selects the containers of the group
+	Group string
+	// Namespace restricts the containers selected by Labels and Group to
+	// the containers of the namespace
+	Namespace string
+	// Thaw resumes the containers instead of pausing them
+	Thaw bool
+	// Updated are the IDs of the containers that changed state
+	Updated []string
+}
+
+func (s *Supervisor) freeze(t *FreezeTask) error {
+	ids := t.IDs
+	if t.Group != "" {
+		members, err := s.containersOfGroup(t.Group)
+		if err != nil {
+			return err
+		}
+		ids = append([]string(nil), ids...)
+		for _, id := range members {
+			if inNamespace(id, t.Namespace) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	containers, err := s.selectContainers(ids, t.Labels, t.Namespace)
+	if err != nil {
+		return err
+	}
+	change, revert := (runtime.Container).Pause, (runtime.Container).Resume
+	typ, from := "pause", runtime.Running
+	state, failed := Paused, Running
+	if t.Thaw {
+		change, revert = revert, change
+		typ, from = "resume", runtime.Paused
+		state, failed = Running, Paused
+	}
+	var changed []runtime.Container
+	for _, c := range containers {
+		// containers already in the requested state are left as they are
+		if c.State() != from {
+			continue
+		}
+		if err := change(c); err != nil {
+			s.containers[c.ID()].lifecycle.fail(failed, err)
+			for _, r := range changed {
+				if rerr := revert(r); rerr != nil {
+					log.WithFields(logrus.Fields{
+						"error": rerr,
+						"id":    r.ID(),
+					}).Error("containerd: revert container state")
+				}
+			}
+			_, id := SplitID(c.ID())
+			return fmt.Errorf("containerd: %s container %s: %v", typ, id, err)
+		}
+		changed = append(changed, c)
+	}
+	for _, c := range changed {
+		t.Updated = append(t.Updated, c.ID())
+		s.containers[c.ID()].lifecycle.transition(state)
+		s.notifySubscribers(Event{
+			ID:        c.ID(),
+			Type:      typ,
+			Timestamp: time.Now(),
+		})
+	}
+	return nil
+}
+
+// selectContainers returns the containers with the IDs and the containers
+// of the namespace that have all of the labels
+func (s *Supervisor) selectContainers(ids, labels []string, namespace string) ([]runtime.Container, error) {
+	var (
+		out  []runtime.Container
+		seen = make(map[string]bool)
+	)
+	for _, id := range ids {
+		i, ok := s.containers[id]
+		if !ok {
+			return nil, ErrContainerNotFound
+		}
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, i.container)
+		}
+	}
+	if len(labels) == 0 {
+		return out, nil
+	}
+	for id, i := range s.containers {
+		if !seen[id] && inNamespace(id, namespace) && hasLabels(i.container, labels) {
+			seen[id] = true
+			out = append(out, i.container)
+		}
+	}
+	return out, nil
+}
+
+func hasLabels(c runtime.Container, labels []string) bool {
+	has := make(map[string]bool)
+	for _, l := range c.Labels() {
+		has[l] = true
+	}
+	for _, l := range labels {
+		if !has[l] {
+			return false
+		}
+	}
+	return true
+}
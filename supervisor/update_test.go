@@ -0,0 +1,150 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/docker/containerd/runtime"
+)
+
+// specContainer is a container whose bundle is dir, the methods it does not
+// implement panic
+type specContainer struct {
+	runtime.Container
+	dir         string
+	invalidated bool
+}
+
+func (c *specContainer) Path() string {
+	return c.dir
+}
+
+func (c *specContainer) InvalidateSpec() {
+	c.invalidated = true
+}
+
+// pauseContainer is a running container whose pause fails with err, the
+// methods it does not implement panic
+type pauseContainer struct {
+	runtime.Container
+	id    string
+	state runtime.State
+	err   error
+}
+
+func (c *pauseContainer) ID() string {
+	return c.id
+}
+
+func (c *pauseContainer) State() runtime.State {
+	return c.state
+}
+
+func (c *pauseContainer) Pause() error {
+	if c.err != nil {
+		return c.err
+	}
+	c.state = runtime.Paused
+	return nil
+}
+
+func (c *pauseContainer) Resume() error {
+	c.state = runtime.Running
+	return nil
+}
+
+func TestFreezeFailure(t *testing.T) {
+	a := &pauseContainer{id: "team+a", state: runtime.Running}
+	b := &pauseContainer{id: "team+b", state: runtime.Running, err: errors.New("cgroup is gone")}
+	s := newTestSupervisor()
+	s.containers = map[string]*containerInfo{
+		a.id: {container: a, lifecycle: newLifecycle(Running)},
+		b.id: {container: b, lifecycle: newLifecycle(Running)},
+	}
+	err := s.freeze(&FreezeTask{IDs: []string{a.id, b.id}, Namespace: "team"})
+	if err == nil || err.Error() != "containerd: pause container b: cgroup is gone" {
+		t.Fatalf("expected the error of the container that failed but received %v", err)
+	}
+	if a.state != runtime.Running {
+		t.Fatalf("expected the paused container to be resumed but it is %s", a.state)
+	}
+}
+
+func TestUpdateSpec(t *testing.T) {
+	dir, err := ioutil.TempDir("", "containerd-spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := `{
+		"process": {"args": ["sh"], "env": ["PATH=/bin", "DEBUG=1"]},
+		"mounts": [
+			{"destination": "/proc", "type": "proc", "source": "proc"},
+			{"destination": "/cache", "type": "bind", "source": "/var/cache"}
+		],
+		"annotations": {"keep": "me"}
+	}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &specContainer{dir: dir}
+	s := newTestSupervisor()
+	s.containers = map[string]*containerInfo{
+		"stopped": {container: c, lifecycle: newLifecycle(Stopped)},
+		"running": {container: c, lifecycle: newLifecycle(Running)},
+	}
+	edit := runtime.SpecEdit{
+		AddMounts:    []runtime.BindMount{{Source: "/srv/data", Destination: "/data", ReadOnly: true}},
+		RemoveMounts: []string{"/cache"},
+		SetEnv:       []string{"PATH=/usr/bin", "LEVEL=debug"},
+		UnsetEnv:     []string{"DEBUG"},
+	}
+	if err := s.updateSpec(&UpdateSpecTask{ID: "running", Edit: edit}); err != ErrContainerNotStopped {
+		t.Fatalf("expected ErrContainerNotStopped for a running container but received %v", err)
+	}
+	invalid := runtime.SpecEdit{SetEnv: []string{"=value"}}
+	if err := s.updateSpec(&UpdateSpecTask{ID: "stopped", Edit: invalid}); err != runtime.ErrInvalidEnv {
+		t.Fatalf("expected ErrInvalidEnv but received %v", err)
+	}
+	if err := s.updateSpec(&UpdateSpecTask{ID: "stopped", Edit: edit}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.invalidated {
+		t.Error("expected the cached spec to be invalidated")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		Process struct {
+			Env []string `json:"env"`
+		} `json:"process"`
+		Mounts []struct {
+			Destination string   `json:"destination"`
+			Options     []string `json:"options"`
+		} `json:"mounts"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"PATH=/usr/bin", "LEVEL=debug"}; !reflect.DeepEqual(spec.Process.Env, expected) {
+		t.Errorf("expected the env %v but received %v", expected, spec.Process.Env)
+	}
+	var destinations []string
+	for _, m := range spec.Mounts {
+		destinations = append(destinations, m.Destination)
+	}
+	if expected := []string{"/proc", "/data"}; !reflect.DeepEqual(destinations, expected) {
+		t.Errorf("expected the mounts %v but received %v", expected, destinations)
+	}
+	if spec.Annotations["keep"] != "me" {
+		t.Error("expected the unknown fields of the spec to be preserved")
+	}
+}
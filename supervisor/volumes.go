@@ -0,0 +1,86 @@
+package supervisor
+
+import (
+	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/volumes"
+)
+
+// volumesBucket is the bucket of the metadata database that the volumes
+// mounted for each container are kept in
+const volumesBucket = "volumes"
+
+// SetVolumeDrivers sets the drivers that provision the containers' volumes,
+// it must be called before the supervisor is started
+func (s *Supervisor) SetVolumeDrivers(d *volumes.Drivers) {
+	s.volumes = d
+}
+
+// DeleteVolume removes a volume of a driver
+func (s *Supervisor) DeleteVolume(driver, name string) error {
+	return s.volumes.Delete(driver, name)
+}
+
+// mountVolumes mounts the volumes of the task and bind mounts them in the
+// bundle's spec, the task's volumes are replaced with the mounted ones
+func (s *Supervisor) mountVolumes(t *StartTask) error {
+	if len(t.Volumes) == 0 {
+		return nil
+	}
+	vs, err := s.volumes.Mount(t.ID, t.Volumes)
+	if err != nil {
+		return err
+	}
+	var mounts []runtime.BindMount
+	for _, v := range vs {
+		mounts = append(mounts, runtime.BindMount{
+			Source:      v.Mountpoint,
+			Destination: v.Destination,
+			ReadOnly:    v.ReadOnly,
+		})
+	}
+	if err := runtime.InjectBindMounts(t.BundlePath, mounts); err != nil {
+		s.volumes.Unmount(t.ID, vs)
+		return err
+	}
+	t.Volumes = vs
+	t.undo("volumes", func() error {
+		s.releaseVolumes(t.ID, vs)
+		return nil
+	})
+	return nil
+}
+
+// releaseVolumes unmounts the volumes outside of the event loop
+func (s *Supervisor) releaseVolumes(id string, vs []volumes.Volume) {
+	if len(vs) > 0 {
+		go s.volumes.Unmount(id, vs)
+	}
+}
+
+// saveVolumes keeps the container's volumes so that they are unmounted when
+// the container is deleted after the daemon was restarted
+func (s *Supervisor) saveVolumes(id string, vs []volumes.Volume) error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return s.saveContainerRecord(volumesBucket, id, vs)
+}
+
+// deleteVolumes releases the volumes that were saved for the container
+func (s *Supervisor) deleteVolumes(id string) {
+	var vs []volumes.Volume
+	if !s.readContainerRecord(volumesBucket, id, &vs) {
+		return
+	}
+	s.deleteContainerRecord(volumesBucket, id)
+	s.releaseVolumes(id, vs)
+}
+
+// pruneVolumes releases the volumes of containers that were not restored
+func (s *Supervisor) pruneVolumes() {
+	for _, id := range s.containerRecords(volumesBucket) {
+		if _, ok := s.containers[id]; !ok {
+			s.deleteVolumes(id)
+		}
+	}
+}
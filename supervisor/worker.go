@@ -5,7 +5,10 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/hooks"
 	"github.com/docker/containerd/runtime"
+	"github.com/docker/containerd/tracing"
+	netcontext "golang.org/x/net/context"
 )
 
 type Worker interface {
@@ -18,8 +21,21 @@ type startTask struct {
 	Stdin         string
 	Stdout        string
 	Stderr        string
+	StdioSocket   bool
 	Err           chan error
 	StartResponse chan StartResponse
+	// Ctx is the context of the request that started the container
+	Ctx       netcontext.Context
+	Lifecycle *lifecycle
+	// Probe is closed by the worker instead of starting a container to show
+	// that the workers pick up work
+	Probe chan struct{}
+	// Restart starts a stopped container again, a restart event is sent
+	// in place of the start-container event
+	Restart bool
+	// Rollback undoes the steps of creating the container if it fails to
+	// start
+	Rollback *rollback
 }
 
 func NewWorker(s *Supervisor, wg *sync.WaitGroup) Worker {
@@ -36,11 +52,27 @@ type worker struct {
 
 func (w *worker) Start() {
 	defer w.wg.Done()
+	defer w.s.HandlePanic()
 	for t := range w.s.startTasks {
+		if t.Probe != nil {
+			close(t.Probe)
+			continue
+		}
 		started := time.Now()
-		process, err := t.Container.Start(t.Checkpoint, runtime.NewStdio(t.Stdin, t.Stdout, t.Stderr))
+		span, _ := tracing.StartSpan(t.Ctx, "runtime.Start")
+		span.SetTag("id", t.Container.ID())
+		stdio := runtime.NewStdio(t.Stdin, t.Stdout, t.Stderr)
+		stdio.Socket = t.StdioSocket
+		process, err := t.Container.Start(t.Ctx, t.Checkpoint, stdio)
+		span.Phase("runtime")
 		if err != nil {
-			logrus.WithFields(logrus.Fields{
+			span.SetTag("error", err.Error())
+			span.Finish()
+			if !t.Restart {
+				err = stepError("start", err)
+			}
+			t.Lifecycle.fail(Stopped, err)
+			log.WithFields(logrus.Fields{
 				"error": err,
 				"id":    t.Container.ID(),
 			}).Error("containerd: start container")
@@ -48,25 +80,55 @@ func (w *worker) Start() {
 			evt := &DeleteTask{
 				ID:      t.Container.ID(),
 				NoEvent: true,
+				// a kept container that failed to restart stays stopped
+				Keep:     t.Restart && t.Container.Keep(),
+				Rollback: t.Rollback,
 			}
 			w.s.SendTask(evt)
 			continue
 		}
 		if err := w.s.monitor.MonitorOOM(t.Container); err != nil && err != runtime.ErrContainerExited {
-			logrus.WithField("error", err).Error("containerd: notify OOM events")
+			log.WithField("error", err).Error("containerd: notify OOM events")
+		}
+		if err := w.s.monitor.MonitorMemoryPressure(t.Container); err != nil && err != runtime.ErrContainerExited {
+			log.WithField("error", err).Error("containerd: notify memory pressure events")
 		}
 		if err := w.s.monitorProcess(process); err != nil {
-			logrus.WithField("error", err).Error("containerd: add process to monitor")
+			log.WithField("error", err).Error("containerd: add process to monitor")
+		}
+		countStdioMode(process)
+		span.Phase("monitor")
+		span.Finish()
+		if tracing.Enabled() {
+			// the shim reports the timings of starting the runtime as children
+			// of the start span
+			if err := process.Trace(span.Context); err != nil {
+				log.WithField("error", err).Warn("containerd: send trace context to shim")
+			}
 		}
 		ContainerStartTimer.UpdateSince(started)
+		t.Lifecycle.transition(Running)
 		t.Err <- nil
 		t.StartResponse <- StartResponse{
 			Container: t.Container,
 		}
+		typ := "start-container"
+		if t.Restart {
+			typ = "restart"
+		}
 		w.s.notifySubscribers(Event{
 			Timestamp: time.Now(),
 			ID:        t.Container.ID(),
-			Type:      "start-container",
+			Type:      typ,
+			Addresses: t.Container.Addresses(),
 		})
+		if w.s.hooks.Enabled() {
+			r := hookRequest(t.Container)
+			r.Pid = process.SystemPid()
+			w.s.hooks.Notify(hooks.PostStart, r)
+		}
+		if w.s.cpusets != nil {
+			w.s.SendTask(&RebalanceCPUSetsTask{})
+		}
 	}
 }
@@ -0,0 +1,242 @@
+// Package tracing records spans for the operations of the daemon and the shim
+// so that a slow operation can be broken down end to end.  Span contexts are
+// propagated using the text map keys of the OpenTracing basic tracer so that
+// OpenTracing clients can continue a trace started by containerd and the
+// reverse.
+package tracing
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	netcontext "golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// TraceIDKey is the text map key of the trace id
+	TraceIDKey = "ot-tracer-traceid"
+	// SpanIDKey is the text map key of the parent span id
+	SpanIDKey = "ot-tracer-spanid"
+)
+
+var (
+	mu      sync.Mutex
+	random  = rand.New(rand.NewSource(time.Now().UnixNano()))
+	enabled bool
+	// slow is the duration above which spans are logged as slow operations
+	slow time.Duration
+)
+
+// Enable starts recording spans, spans are discarded while disabled
+func Enable() {
+	mu.Lock()
+	enabled = true
+	mu.Unlock()
+}
+
+// Enabled returns true if spans are recorded
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return enabled
+}
+
+// SetSlowThreshold logs a warning with the tags and phases of every span that
+// takes longer than d even when tracing is disabled, zero disables it
+func SetSlowThreshold(d time.Duration) {
+	mu.Lock()
+	slow = d
+	mu.Unlock()
+}
+
+func slowThreshold() time.Duration {
+	mu.Lock()
+	defer mu.Unlock()
+	return slow
+}
+
+// newID returns a random id that fits in an int64 so it can be passed over
+// the shim's control channel
+func newID() uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return uint64(random.Int63())
+}
+
+// SpanContext identifies a span within a trace
+type SpanContext struct {
+	TraceID uint64
+	SpanID  uint64
+}
+
+// Span is a timed operation within a trace
+type Span struct {
+	Context   SpanContext
+	ParentID  uint64
+	Operation string
+	Start     time.Time
+	mu        sync.Mutex
+	tags      map[string]interface{}
+	phases    []phase
+	finished  bool
+}
+
+// phase is a named part of a span's operation that ended at end
+type phase struct {
+	name string
+	end  time.Time
+}
+
+type spanKey struct{}
+
+// StartSpan starts a span that is a child of the span in ctx or of the span
+// context propagated in the ctx's grpc metadata.  A new trace is started if
+// there is neither.
+func StartSpan(ctx netcontext.Context, operation string) (*Span, netcontext.Context) {
+	s := &Span{
+		Operation: operation,
+		Start:     time.Now(),
+		Context: SpanContext{
+			SpanID: newID(),
+		},
+	}
+	if parent, ok := FromContext(ctx); ok {
+		s.Context.TraceID = parent.TraceID
+		s.ParentID = parent.SpanID
+	} else {
+		s.Context.TraceID = newID()
+	}
+	return s, netcontext.WithValue(ctx, spanKey{}, s.Context)
+}
+
+// StartSpanFrom starts a span that is a child of the parent span context
+func StartSpanFrom(parent SpanContext, operation string) *Span {
+	s, _ := StartSpan(netcontext.WithValue(netcontext.Background(), spanKey{}, parent), operation)
+	return s
+}
+
+// FromContext returns the span context of the current span in ctx or the span
+// context propagated in the ctx's grpc metadata
+func FromContext(ctx netcontext.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	if sc, ok := ctx.Value(spanKey{}).(SpanContext); ok {
+		return sc, true
+	}
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return SpanContext{}, false
+	}
+	return Extract(map[string]string{
+		TraceIDKey: first(md[TraceIDKey]),
+		SpanIDKey:  first(md[SpanIDKey]),
+	})
+}
+
+func first(v []string) string {
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+// Inject returns the span context as a text map
+func Inject(sc SpanContext) map[string]string {
+	return map[string]string{
+		TraceIDKey: strconv.FormatUint(sc.TraceID, 16),
+		SpanIDKey:  strconv.FormatUint(sc.SpanID, 16),
+	}
+}
+
+// Extract returns the span context from a text map
+func Extract(m map[string]string) (SpanContext, bool) {
+	traceID, err := strconv.ParseUint(m[TraceIDKey], 16, 64)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	spanID, err := strconv.ParseUint(m[SpanIDKey], 16, 64)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	return SpanContext{TraceID: traceID, SpanID: spanID}, true
+}
+
+// SetTag sets a tag on the span
+func (s *Span) SetTag(key string, value interface{}) *Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tags == nil {
+		s.tags = make(map[string]interface{})
+	}
+	s.tags[key] = value
+	return s
+}
+
+// Phase marks the end of a phase of the span's operation which started at the
+// end of the previous phase or at the start of the span
+func (s *Span) Phase(name string) *Span {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases = append(s.phases, phase{name: name, end: time.Now()})
+	return s
+}
+
+// Finish records the span with its duration, further calls are ignored
+func (s *Span) Finish() {
+	s.FinishAt(time.Now())
+}
+
+// FinishAt records the span as finished at the provided time
+func (s *Span) FinishAt(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return
+	}
+	s.finished = true
+	if d := slowThreshold(); d > 0 && t.Sub(s.Start) > d {
+		s.logSlow(t)
+	}
+	if !Enabled() {
+		return
+	}
+	fields := logrus.Fields{
+		"trace":     fmt.Sprintf("%x", s.Context.TraceID),
+		"span":      fmt.Sprintf("%x", s.Context.SpanID),
+		"operation": s.Operation,
+		"start":     s.Start,
+		"duration":  t.Sub(s.Start).String(),
+	}
+	if s.ParentID != 0 {
+		fields["parent"] = fmt.Sprintf("%x", s.ParentID)
+	}
+	for k, v := range s.tags {
+		fields["tag."+k] = v
+	}
+	logrus.WithFields(fields).Info("span")
+}
+
+func (s *Span) logSlow(t time.Time) {
+	fields := logrus.Fields{
+		"operation": s.Operation,
+		"duration":  t.Sub(s.Start).String(),
+	}
+	if Enabled() {
+		fields["trace"] = fmt.Sprintf("%x", s.Context.TraceID)
+	}
+	last := s.Start
+	for _, p := range s.phases {
+		fields["phase."+p.name] = p.end.Sub(last).String()
+		last = p.end
+	}
+	for k, v := range s.tags {
+		fields["tag."+k] = v
+	}
+	logrus.WithFields(fields).Warn("containerd: slow operation")
+}
@@ -0,0 +1,250 @@
+// Package volumes provisions the volumes of containers through driver plugins.
+//
+// A driver is a socket that is sent a request as json, the write side of the
+// connection is then closed and the driver answers the response before closing
+// the connection.  Volumes are created and mounted before a container is
+// created and unmounted after it was deleted.
+package volumes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/containerd/logging"
+)
+
+var log = logging.Logger("volumes")
+
+// Method is the operation a driver is asked to do
+type Method string
+
+const (
+	// Create creates the volume if it does not exist
+	Create Method = "create"
+	// Mount mounts the volume for a container and answers the mountpoint
+	Mount Method = "mount"
+	// Unmount releases the mount of the volume for a container
+	Unmount Method = "unmount"
+	// Delete removes the volume
+	Delete Method = "delete"
+)
+
+// DefaultTimeout is the time a driver has to answer
+const DefaultTimeout = 30 * time.Second
+
+// socketPrefix is the prefix of the drivers' addresses
+const socketPrefix = "unix://"
+
+// Request is sent to the driver
+type Request struct {
+	Method  Method            `json:"method"`
+	Name    string            `json:"name"`
+	Options map[string]string `json:"options,omitempty"`
+	// ID is the container the volume is mounted for
+	ID string `json:"id,omitempty"`
+}
+
+// Response is answered by the driver
+type Response struct {
+	// Mountpoint is the host path of the volume for mount
+	Mountpoint string `json:"mountpoint,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Volume is a volume of a container
+type Volume struct {
+	Driver      string            `json:"driver"`
+	Name        string            `json:"name"`
+	Destination string            `json:"destination"`
+	Options     map[string]string `json:"options,omitempty"`
+	ReadOnly    bool              `json:"readOnly,omitempty"`
+	// Ephemeral volumes are deleted after they were unmounted
+	Ephemeral bool `json:"ephemeral,omitempty"`
+	// Mountpoint is the host path answered by the driver
+	Mountpoint string `json:"mountpoint,omitempty"`
+}
+
+// Drivers calls the volume drivers by their names
+type Drivers struct {
+	sockets map[string]string
+	timeout time.Duration
+}
+
+// New returns the drivers given as name=unix:///path, each of them has the
+// timeout to answer
+func New(drivers []string, timeout time.Duration) (*Drivers, error) {
+	d := &Drivers{
+		sockets: make(map[string]string),
+		timeout: timeout,
+	}
+	if d.timeout <= 0 {
+		d.timeout = DefaultTimeout
+	}
+	for _, s := range drivers {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || !strings.HasPrefix(parts[1], socketPrefix) {
+			return nil, fmt.Errorf("containerd: volume driver %s is not in the format name=%s/path", s, socketPrefix)
+		}
+		path := strings.TrimPrefix(parts[1], socketPrefix)
+		if !filepath.IsAbs(path) {
+			return nil, fmt.Errorf("containerd: socket of volume driver %s is not an absolute path", parts[0])
+		}
+		if _, ok := d.sockets[parts[0]]; ok {
+			return nil, fmt.Errorf("containerd: volume driver %s is given more than once", parts[0])
+		}
+		d.sockets[parts[0]] = path
+	}
+	return d, nil
+}
+
+// Names returns the sorted names of the drivers
+func (d *Drivers) Names() []string {
+	if d == nil {
+		return nil
+	}
+	var names []string
+	for n := range d.sockets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate returns an error if a volume has no driver, name or destination or
+// if its driver is unknown
+func (d *Drivers) Validate(vs []Volume) error {
+	for _, v := range vs {
+		if v.Name == "" || !filepath.IsAbs(v.Destination) {
+			return fmt.Errorf("containerd: volume %q needs a name and an absolute destination", v.Name)
+		}
+		if d == nil || d.sockets[v.Driver] == "" {
+			return fmt.Errorf("containerd: unknown volume driver %q", v.Driver)
+		}
+	}
+	return nil
+}
+
+// Mount creates and mounts the volumes for the container and returns them
+// with their mountpoints.  If a volume fails the volumes mounted before it
+// are released.
+func (d *Drivers) Mount(id string, vs []Volume) ([]Volume, error) {
+	if err := d.Validate(vs); err != nil {
+		return nil, err
+	}
+	var mounted []Volume
+	for _, v := range vs {
+		r := &Request{
+			Name:    v.Name,
+			Options: v.Options,
+			ID:      id,
+		}
+		r.Method = Create
+		if _, err := d.call(v.Driver, r); err != nil {
+			d.Unmount(id, mounted)
+			return nil, err
+		}
+		r.Method = Mount
+		resp, err := d.call(v.Driver, r)
+		if err == nil && !filepath.IsAbs(resp.Mountpoint) {
+			err = fmt.Errorf("containerd: volume driver %s: mountpoint of %s is not an absolute path", v.Driver, v.Name)
+		}
+		if err != nil {
+			// the volume was not mounted but an ephemeral volume was
+			// created and is deleted with the others
+			v.Mountpoint = ""
+			d.Unmount(id, append(mounted, v))
+			return nil, err
+		}
+		v.Mountpoint = resp.Mountpoint
+		mounted = append(mounted, v)
+	}
+	return mounted, nil
+}
+
+// Unmount releases the volumes of the container and deletes the ephemeral
+// ones, errors of the drivers are logged
+func (d *Drivers) Unmount(id string, vs []Volume) {
+	for _, v := range vs {
+		if v.Mountpoint != "" {
+			if _, err := d.call(v.Driver, &Request{Method: Unmount, Name: v.Name, ID: id}); err != nil {
+				logError(err, v, Unmount)
+				continue
+			}
+		}
+		if v.Ephemeral {
+			if err := d.Delete(v.Driver, v.Name); err != nil {
+				logError(err, v, Delete)
+			}
+		}
+	}
+}
+
+// Delete removes the volume of the driver
+func (d *Drivers) Delete(driver, name string) error {
+	if d == nil || d.sockets[driver] == "" {
+		return fmt.Errorf("containerd: unknown volume driver %q", driver)
+	}
+	_, err := d.call(driver, &Request{Method: Delete, Name: name})
+	return err
+}
+
+func logError(err error, v Volume, m Method) {
+	log.WithFields(logrus.Fields{
+		"error":  err,
+		"driver": v.Driver,
+		"volume": v.Name,
+		"method": m,
+	}).Error("containerd: call volume driver")
+}
+
+func (d *Drivers) call(driver string, r *Request) (*Response, error) {
+	path := d.sockets[driver]
+	if path == "" {
+		return nil, fmt.Errorf("containerd: unknown volume driver %q", driver)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	out, err := d.callSocket(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("containerd: volume driver %s: %v", driver, err)
+	}
+	var resp Response
+	if len(bytes.TrimSpace(out)) > 0 {
+		if err := json.Unmarshal(out, &resp); err != nil {
+			return nil, fmt.Errorf("containerd: volume driver %s: invalid response: %v", driver, err)
+		}
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("containerd: volume driver %s: %s %s: %s", driver, r.Method, r.Name, resp.Error)
+	}
+	return &resp, nil
+}
+
+func (d *Drivers) callSocket(path string, data []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("unix", path, d.timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(d.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return nil, err
+	}
+	// the driver reads the request until the write side is closed
+	if err := conn.(*net.UnixConn).CloseWrite(); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(conn)
+}
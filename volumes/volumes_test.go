@@ -0,0 +1,126 @@
+package volumes
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeDriver answers mountpoints below /mnt and fails to mount the volume
+// named fail, it sends every request it received on the channel
+func fakeDriver(t *testing.T) (*Drivers, chan Request, func()) {
+	dir, err := ioutil.TempDir("", "containerd-volumes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "driver.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(chan Request, 32)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			var r Request
+			data, _ := ioutil.ReadAll(conn)
+			json.Unmarshal(data, &r)
+			requests <- r
+			var resp Response
+			if r.Method == Mount {
+				resp.Mountpoint = "/mnt/" + r.Name
+				if r.Name == "fail" {
+					resp.Error = "no space left"
+				}
+			}
+			json.NewEncoder(conn).Encode(resp)
+			conn.Close()
+		}
+	}()
+	d, err := New([]string{"fake=unix://" + path}, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d, requests, func() {
+		l.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func received(requests chan Request) []Request {
+	var rs []Request
+	for {
+		select {
+		case r := <-requests:
+			rs = append(rs, r)
+		default:
+			return rs
+		}
+	}
+}
+
+func TestMount(t *testing.T) {
+	d, requests, cleanup := fakeDriver(t)
+	defer cleanup()
+	vs, err := d.Mount("test", []Volume{{Driver: "fake", Name: "data", Destination: "/data"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs) != 1 || vs[0].Mountpoint != "/mnt/data" {
+		t.Fatalf("expected the mountpoint /mnt/data but received %v", vs)
+	}
+	expected := []Request{
+		{Method: Create, Name: "data", ID: "test"},
+		{Method: Mount, Name: "data", ID: "test"},
+	}
+	if rs := received(requests); !reflect.DeepEqual(rs, expected) {
+		t.Fatalf("expected the requests %v but received %v", expected, rs)
+	}
+}
+
+func TestMountFailureReleasesVolumes(t *testing.T) {
+	d, requests, cleanup := fakeDriver(t)
+	defer cleanup()
+	_, err := d.Mount("test", []Volume{
+		{Driver: "fake", Name: "data", Destination: "/data"},
+		{Driver: "fake", Name: "fail", Destination: "/fail", Ephemeral: true},
+	})
+	if err == nil {
+		t.Fatal("expected the mount to fail")
+	}
+	// the mounted volume is unmounted and the ephemeral volume that failed to
+	// mount is deleted
+	expected := []Request{
+		{Method: Create, Name: "data", ID: "test"},
+		{Method: Mount, Name: "data", ID: "test"},
+		{Method: Create, Name: "fail", ID: "test"},
+		{Method: Mount, Name: "fail", ID: "test"},
+		{Method: Unmount, Name: "data", ID: "test"},
+		{Method: Delete, Name: "fail"},
+	}
+	if rs := received(requests); !reflect.DeepEqual(rs, expected) {
+		t.Fatalf("expected the requests %v but received %v", expected, rs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	d, _, cleanup := fakeDriver(t)
+	defer cleanup()
+	for _, v := range []Volume{
+		{Driver: "fake", Destination: "/data"},
+		{Driver: "fake", Name: "data", Destination: "data"},
+		{Driver: "unknown", Name: "data", Destination: "/data"},
+	} {
+		if err := d.Validate([]Volume{v}); err == nil {
+			t.Fatalf("expected volume %v to be invalid", v)
+		}
+	}
+}